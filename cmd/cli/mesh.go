@@ -23,6 +23,8 @@ func newMeshCmd(config *action.Configuration, in io.Reader, out io.Writer) *cobr
 	}
 	cmd.AddCommand(newMeshList(out))
 	cmd.AddCommand(newMeshUpgradeCmd(config, out))
+	cmd.AddCommand(newMeshGraphCmd(out))
+	cmd.AddCommand(newMeshCheckCmd(out))
 
 	return cmd
 }
@@ -0,0 +1,749 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	osmConfigClient "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+)
+
+const meshCheckDescription = `
+This command checks the health of the mesh installed in the namespace given by
+the global --osm-namespace flag. It verifies that:
+
+  - the osm-controller and osm-injector deployments are ready
+  - the CA bundle of the MutatingWebhookConfiguration of the osm-injector
+    matches the certificate the osm-injector serves the webhook with
+  - the CRDs installed in the cluster serve the versions of the OSM CRDs
+  - the MeshConfig is valid
+  - the root certificate of the mesh is not expired or about to expire, read
+    from the CA bundle secret of the osm-controller or from Vault when Vault
+    is the certificate provider
+
+With --pre, the command instead checks the prerequisites of an installation:
+
+  - the Kubernetes version satisfies the requirement of the OSM chart
+  - the Kubernetes APIs used by the OSM chart are served
+  - no other MutatingWebhookConfiguration mutates the pods created, which
+    could conflict with the sidecar injection
+  - the nodes run Linux, which the iptables redirection set up by the init
+    container of the sidecar requires. The iptables support of the node
+    kernels is not checked.
+
+A report of the checks is printed. The command exits with a non-zero code when
+a check fails, or when a check warns and --fail-on-warning is set, so it can
+be used in CI pipelines.
+`
+
+const meshCheckExample = `
+# Check the prerequisites of an installation
+osm mesh check --pre
+
+# Check the health of the mesh installed in the 'osm-system' namespace
+osm mesh check --osm-namespace osm-system
+
+# Check the health of the mesh and print the report in JSON
+osm mesh check -o json
+`
+
+const (
+	meshCheckPass = "PASS"
+	meshCheckWarn = "WARN"
+	meshCheckFail = "FAIL"
+
+	meshCheckOutputTable = "table"
+	meshCheckOutputJSON  = "json"
+
+	// defaultRootCertExpiryThreshold is the default time before the expiration of the root certificate from which it is reported
+	defaultRootCertExpiryThreshold = 30 * 24 * time.Hour
+
+	// The flags below are the flags of the control plane whose values are read from the deployments
+	webhookConfigNameFlag  = "--webhook-config-name"
+	caBundleSecretNameFlag = "--ca-bundle-secret-name"
+	certManagerFlag        = "--certificate-manager"
+	vaultProtocolFlag      = "--vault-protocol"
+	vaultHostFlag          = "--vault-host"
+	vaultPortFlag          = "--vault-port"
+
+	// The values below are the defaults of the Vault flags of the osm-controller
+	defaultVaultProtocol = "http"
+	defaultVaultHost     = "vault.default.svc.cluster.local"
+	defaultVaultPort     = "8200"
+
+	// vaultCertManager is the certificate provider issuing the certificates from Vault
+	vaultCertManager = "vault"
+
+	// vaultCAPath is the unauthenticated path of the Vault PKI secrets engine returning its CA certificate in PEM
+	vaultCAPath = "v1/pki/ca/pem"
+
+	// vaultRequestTimeout is the timeout of the request for the CA certificate of Vault
+	vaultRequestTimeout = 10 * time.Second
+
+	osmInjectorName = "osm-injector"
+)
+
+// requiredAPIGroupVersions are the API group versions of the resources of the OSM chart
+var requiredAPIGroupVersions = []string{
+	"v1",
+	"apps/v1",
+	"batch/v1",
+	"rbac.authorization.k8s.io/v1",
+	"admissionregistration.k8s.io/v1",
+	"apiextensions.k8s.io/v1",
+	"policy/v1beta1",
+	"autoscaling/v2beta2",
+}
+
+// envoyLogLevels are the log levels accepted by Envoy
+var envoyLogLevels = map[string]bool{
+	"trace":    true,
+	"debug":    true,
+	"info":     true,
+	"warning":  true,
+	"warn":     true,
+	"error":    true,
+	"critical": true,
+	"off":      true,
+}
+
+type meshCheckCmd struct {
+	out                 io.Writer
+	pre                 bool
+	output              string
+	failOnWarning       bool
+	rootCertExpiry      time.Duration
+	osmNamespace        string
+	chartRequested      *chart.Chart
+	clientSet           kubernetes.Interface
+	extensionsClientSet apiextensionsclientset.Interface
+	meshConfigClient    osmConfigClient.Interface
+}
+
+// meshCheck is the outcome of a check of the mesh
+type meshCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// meshCheckReport is the report of the checks of the mesh
+type meshCheckReport struct {
+	Checks   []meshCheck `json:"checks"`
+	Passed   int         `json:"passed"`
+	Warnings int         `json:"warnings"`
+	Failed   int         `json:"failed"`
+}
+
+func newMeshCheckCmd(out io.Writer) *cobra.Command {
+	checkCmd := &meshCheckCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "check the health or the installation prerequisites of the mesh",
+		Long:  meshCheckDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+			checkCmd.clientSet = clientset
+
+			extensionsClientSet, err := apiextensionsclientset.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not initialize the apiextensions client: %s", err)
+			}
+			checkCmd.extensionsClientSet = extensionsClientSet
+
+			configClient, err := osmConfigClient.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not initialize OSM Config client: %s", err)
+			}
+			checkCmd.meshConfigClient = configClient
+
+			checkCmd.chartRequested, err = loader.LoadArchive(bytes.NewReader(chartTGZSource))
+			if err != nil {
+				return errors.Errorf("Error loading the OSM chart: %s", err)
+			}
+			checkCmd.osmNamespace = settings.Namespace()
+
+			return checkCmd.run()
+		},
+		Example: meshCheckExample,
+	}
+
+	f := cmd.Flags()
+	f.BoolVar(&checkCmd.pre, "pre", false, "Check the prerequisites of an installation instead of the health of the mesh")
+	f.StringVarP(&checkCmd.output, "output", "o", meshCheckOutputTable, "Output format, one of: table|json")
+	f.BoolVar(&checkCmd.failOnWarning, "fail-on-warning", false, "Exit with a non-zero code when a check warns")
+	f.DurationVar(&checkCmd.rootCertExpiry, "root-cert-expiry-threshold", defaultRootCertExpiryThreshold, "Warn when the root certificate expires within this duration")
+
+	return cmd
+}
+
+func (cmd *meshCheckCmd) run() error {
+	if cmd.output != meshCheckOutputTable && cmd.output != meshCheckOutputJSON {
+		return errors.Errorf("Invalid output format %q, expected %s or %s", cmd.output, meshCheckOutputTable, meshCheckOutputJSON)
+	}
+
+	var checks []meshCheck
+	if cmd.pre {
+		checks = cmd.checkPrerequisites()
+	} else {
+		checks = cmd.checkMesh()
+	}
+
+	report := meshCheckReport{Checks: checks}
+	for _, check := range checks {
+		switch check.Status {
+		case meshCheckPass:
+			report.Passed++
+		case meshCheckWarn:
+			report.Warnings++
+		case meshCheckFail:
+			report.Failed++
+		}
+	}
+
+	if err := cmd.printReport(report); err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return errors.Errorf("%d of %d checks failed", report.Failed, len(report.Checks))
+	}
+	if cmd.failOnWarning && report.Warnings > 0 {
+		return errors.Errorf("%d of %d checks warned", report.Warnings, len(report.Checks))
+	}
+	return nil
+}
+
+func (cmd *meshCheckCmd) printReport(report meshCheckReport) error {
+	if cmd.output == meshCheckOutputJSON {
+		jsonReport, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Errorf("Error marshalling the report: %s", err)
+		}
+		fmt.Fprintln(cmd.out, string(jsonReport))
+		return nil
+	}
+
+	w := newTabWriter(cmd.out)
+	fmt.Fprintln(w, "STATUS\tCHECK\tDETAIL")
+	for _, check := range report.Checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.Status, check.Name, check.Detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.out, "\n%d passed, %d warnings, %d failed\n", report.Passed, report.Warnings, report.Failed)
+	return nil
+}
+
+func (cmd *meshCheckCmd) checkPrerequisites() []meshCheck {
+	return []meshCheck{
+		cmd.checkKubernetesVersion(),
+		cmd.checkKubernetesAPIs(),
+		cmd.checkMutatingWebhooks(),
+		cmd.checkNodes(),
+	}
+}
+
+func (cmd *meshCheckCmd) checkMesh() []meshCheck {
+	controller, controllerCheck := cmd.checkDeploymentReady(constants.OSMControllerName)
+	injector, injectorCheck := cmd.checkDeploymentReady(osmInjectorName)
+
+	return []meshCheck{
+		controllerCheck,
+		injectorCheck,
+		cmd.checkWebhookCABundle(injector),
+		cmd.checkCRDVersions(),
+		cmd.checkMeshConfig(),
+		cmd.checkRootCertificate(controller),
+	}
+}
+
+func (cmd *meshCheckCmd) checkKubernetesVersion() meshCheck {
+	check := meshCheck{Name: "Kubernetes version"}
+
+	serverVersion, err := cmd.clientSet.Discovery().ServerVersion()
+	if err != nil {
+		return check.fail("Error fetching the Kubernetes version: %s", err)
+	}
+
+	constraint := cmd.chartRequested.Metadata.KubeVersion
+	if constraint == "" {
+		return check.pass("Kubernetes %s, the OSM chart does not require a version", serverVersion.GitVersion)
+	}
+
+	// Drop the pre-release and build suffixes of vendor versions, e.g. v1.20.7-gke.1 or v1.20.7+k3s1
+	kubeVersion := strings.SplitN(strings.SplitN(serverVersion.GitVersion, "-", 2)[0], "+", 2)[0]
+	if !chartutil.IsCompatibleRange(constraint, kubeVersion) {
+		return check.fail("Kubernetes %s does not satisfy the requirement %q of the OSM chart", serverVersion.GitVersion, constraint)
+	}
+	return check.pass("Kubernetes %s satisfies the requirement %q of the OSM chart", serverVersion.GitVersion, constraint)
+}
+
+func (cmd *meshCheckCmd) checkKubernetesAPIs() meshCheck {
+	check := meshCheck{Name: "Kubernetes APIs"}
+
+	groups, err := cmd.clientSet.Discovery().ServerGroups()
+	if err != nil {
+		return check.fail("Error fetching the Kubernetes APIs: %s", err)
+	}
+
+	served := make(map[string]bool)
+	for _, group := range groups.Groups {
+		for _, version := range group.Versions {
+			served[version.GroupVersion] = true
+		}
+	}
+
+	var missing []string
+	for _, groupVersion := range requiredAPIGroupVersions {
+		if !served[groupVersion] {
+			missing = append(missing, groupVersion)
+		}
+	}
+	if len(missing) > 0 {
+		return check.fail("The cluster does not serve the APIs %s", strings.Join(missing, ", "))
+	}
+	return check.pass("The cluster serves the %d APIs used by the OSM chart", len(requiredAPIGroupVersions))
+}
+
+func (cmd *meshCheckCmd) checkMutatingWebhooks() meshCheck {
+	check := meshCheck{Name: "Mutating webhooks"}
+
+	webhookConfigs, err := cmd.clientSet.AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return check.fail("Error listing MutatingWebhookConfigurations: %s", err)
+	}
+
+	var conflicting []string
+	for _, webhookConfig := range webhookConfigs.Items {
+		// The webhooks of other meshes only mutate the pods of the namespaces they monitor
+		if webhookConfig.Labels["app"] == osmInjectorName {
+			continue
+		}
+		for _, webhook := range webhookConfig.Webhooks {
+			if mutatesPodCreation(webhook) {
+				conflicting = append(conflicting, fmt.Sprintf("%s/%s", webhookConfig.Name, webhook.Name))
+			}
+		}
+	}
+	if len(conflicting) > 0 {
+		return check.warn("Webhooks %s also mutate the pods created and may conflict with the sidecar injection", strings.Join(conflicting, ", "))
+	}
+	return check.pass("No other webhook mutates the pods created")
+}
+
+// mutatesPodCreation returns whether the rules of the webhook match the creation of pods
+func mutatesPodCreation(webhook admissionregv1.MutatingWebhook) bool {
+	for _, rule := range webhook.Rules {
+		if !containsAny(rule.APIGroups, "", "*") || !containsAny(rule.Resources, "pods", "*", "*/*") {
+			continue
+		}
+		for _, operation := range rule.Operations {
+			if operation == admissionregv1.Create || operation == admissionregv1.OperationAll {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAny(values []string, candidates ...string) bool {
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if value == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (cmd *meshCheckCmd) checkNodes() meshCheck {
+	check := meshCheck{Name: "Linux nodes"}
+
+	nodes, err := cmd.clientSet.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return check.fail("Error listing nodes: %s", err)
+	}
+
+	var linuxNodes int
+	var otherNodes []string
+	for _, node := range nodes.Items {
+		os := node.Status.NodeInfo.OperatingSystem
+		if os == "" {
+			os = node.Labels[corev1.LabelOSStable]
+		}
+		if os == constants.OSLinux {
+			linuxNodes++
+		} else {
+			otherNodes = append(otherNodes, fmt.Sprintf("%s (%s)", node.Name, orDash(os)))
+		}
+	}
+
+	if linuxNodes == 0 {
+		return check.fail("No node runs Linux, which the iptables redirection set up by the sidecar init container requires")
+	}
+	if len(otherNodes) > 0 {
+		return check.warn("Nodes %s do not run Linux, the iptables redirection set up by the sidecar init container is only supported on Linux nodes", strings.Join(otherNodes, ", "))
+	}
+	return check.pass("%d nodes run Linux, the iptables support of their kernel is not checked", linuxNodes)
+}
+
+// checkDeploymentReady checks the control plane deployment of the given app is ready, and returns it when it exists
+func (cmd *meshCheckCmd) checkDeploymentReady(app string) (*appsv1.Deployment, meshCheck) {
+	check := meshCheck{Name: fmt.Sprintf("%s readiness", app)}
+
+	selector := labels.Set{"app": app}.String()
+	deployments, err := cmd.clientSet.AppsV1().Deployments(cmd.osmNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, check.fail("Error listing deployments: %s", err)
+	}
+	if len(deployments.Items) == 0 {
+		return nil, check.fail("No %s deployment found in namespace [%s]", app, cmd.osmNamespace)
+	}
+
+	deployment := &deployments.Items[0]
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.ReadyReplicas < replicas {
+		return deployment, check.fail("%d/%d replicas of deployment %s/%s are ready", deployment.Status.ReadyReplicas, replicas, deployment.Namespace, deployment.Name)
+	}
+	return deployment, check.pass("%d/%d replicas of deployment %s/%s are ready", deployment.Status.ReadyReplicas, replicas, deployment.Namespace, deployment.Name)
+}
+
+// checkWebhookCABundle checks the CA bundle of the MutatingWebhookConfiguration is the certificate of the webhook server,
+// as set by the osm-injector when it starts
+func (cmd *meshCheckCmd) checkWebhookCABundle(injector *appsv1.Deployment) meshCheck {
+	check := meshCheck{Name: "Webhook CA bundle"}
+
+	if injector == nil {
+		return check.fail("No %s deployment found in namespace [%s]", osmInjectorName, cmd.osmNamespace)
+	}
+	webhookConfigName := getDeploymentArg(injector, webhookConfigNameFlag)
+	if webhookConfigName == "" {
+		return check.fail("Deployment %s/%s has no %s argument", injector.Namespace, injector.Name, webhookConfigNameFlag)
+	}
+
+	webhookConfig, err := cmd.clientSet.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), webhookConfigName, metav1.GetOptions{})
+	if err != nil {
+		return check.fail("Error fetching MutatingWebhookConfiguration %s: %s", webhookConfigName, err)
+	}
+
+	secret, err := cmd.clientSet.CoreV1().Secrets(cmd.osmNamespace).Get(context.TODO(), constants.WebhookCertificateSecretName, metav1.GetOptions{})
+	if err != nil {
+		return check.fail("Error fetching secret %s/%s: %s", cmd.osmNamespace, constants.WebhookCertificateSecretName, err)
+	}
+	cert := secret.Data[constants.KubernetesOpaqueSecretCAKey]
+
+	for _, webhook := range webhookConfig.Webhooks {
+		if !bytes.Equal(webhook.ClientConfig.CABundle, cert) {
+			return check.fail("CA bundle of webhook %s of MutatingWebhookConfiguration %s does not match the certificate in secret %s/%s",
+				webhook.Name, webhookConfigName, cmd.osmNamespace, constants.WebhookCertificateSecretName)
+		}
+	}
+	return check.pass("CA bundle of MutatingWebhookConfiguration %s matches the certificate in secret %s/%s",
+		webhookConfigName, cmd.osmNamespace, constants.WebhookCertificateSecretName)
+}
+
+// checkCRDVersions checks the CRDs installed in the cluster serve the versions of the CRDs of the OSM chart
+func (cmd *meshCheckCmd) checkCRDVersions() meshCheck {
+	check := meshCheck{Name: "CRD versions"}
+
+	var problems []string
+	crds := cmd.chartRequested.CRDObjects()
+	for _, chartCRD := range crds {
+		var expected apiextensionsv1.CustomResourceDefinition
+		if err := yaml.Unmarshal(chartCRD.File.Data, &expected); err != nil {
+			return check.fail("Error parsing CRD %s of the OSM chart: %s", chartCRD.Filename, err)
+		}
+
+		installed, err := cmd.extensionsClientSet.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), expected.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			problems = append(problems, fmt.Sprintf("CRD %s is not installed", expected.Name))
+			continue
+		}
+		if err != nil {
+			return check.fail("Error fetching CRD %s: %s", expected.Name, err)
+		}
+
+		served := make(map[string]bool)
+		for _, version := range installed.Spec.Versions {
+			served[version.Name] = version.Served
+		}
+		for _, version := range expected.Spec.Versions {
+			if version.Served && !served[version.Name] {
+				gv := schema.GroupVersion{Group: expected.Spec.Group, Version: version.Name}
+				problems = append(problems, fmt.Sprintf("CRD %s does not serve %s", expected.Name, gv))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return check.fail("%s", strings.Join(problems, "; "))
+	}
+	return check.pass("The %d CRDs of the OSM chart are installed with the expected versions", len(crds))
+}
+
+func (cmd *meshCheckCmd) checkMeshConfig() meshCheck {
+	check := meshCheck{Name: "MeshConfig"}
+
+	meshConfig, err := cmd.meshConfigClient.ConfigV1alpha1().MeshConfigs(cmd.osmNamespace).Get(context.TODO(), defaultOsmMeshConfigName, metav1.GetOptions{})
+	if err != nil {
+		return check.fail("Error fetching MeshConfig %s/%s: %s", cmd.osmNamespace, defaultOsmMeshConfigName, err)
+	}
+
+	if problems := validateMeshConfig(meshConfig.Spec); len(problems) > 0 {
+		return check.fail("MeshConfig %s/%s is invalid: %s", cmd.osmNamespace, defaultOsmMeshConfigName, strings.Join(problems, "; "))
+	}
+	return check.pass("MeshConfig %s/%s is valid", cmd.osmNamespace, defaultOsmMeshConfigName)
+}
+
+// validateMeshConfig returns the problems of the MeshConfig the control plane would fail to apply
+func validateMeshConfig(spec configv1alpha1.MeshConfigSpec) []string {
+	var problems []string
+
+	durations := map[string]string{
+		"spec.sidecar.configResyncInterval":                 spec.Sidecar.ConfigResyncInterval,
+		"spec.sidecar.staleSidecarRestartInterval":          spec.Sidecar.StaleSidecarRestartInterval,
+		"spec.sidecar.configUpdateDebounceWindow":           spec.Sidecar.ConfigUpdateDebounceWindow,
+		"spec.sidecar.configUpdateMaxDelay":                 spec.Sidecar.ConfigUpdateMaxDelay,
+		"spec.certificate.serviceCertValidityDuration":      spec.Certificate.ServiceCertValidityDuration,
+		"spec.traffic.inboundExternalAuthorization.timeout": spec.Traffic.InboundExternalAuthorization.Timeout,
+	}
+	for field, duration := range durations {
+		if duration == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(duration); err != nil {
+			problems = append(problems, fmt.Sprintf("%s %q is not a duration", field, duration))
+		}
+	}
+
+	if level := spec.Sidecar.LogLevel; level != "" && !envoyLogLevels[strings.ToLower(level)] {
+		problems = append(problems, fmt.Sprintf("spec.sidecar.logLevel %q is not an Envoy log level", level))
+	}
+	if level := spec.Observability.OSMLogLevel; level != "" {
+		if _, err := zerolog.ParseLevel(strings.ToLower(level)); err != nil {
+			problems = append(problems, fmt.Sprintf("spec.observability.osmLogLevel %q is not a log level", level))
+		}
+	}
+
+	for _, ipRange := range spec.Traffic.OutboundIPRangeExclusionList {
+		if _, _, err := net.ParseCIDR(ipRange); err != nil {
+			problems = append(problems, fmt.Sprintf("spec.traffic.outboundIPRangeExclusionList %q is not a CIDR", ipRange))
+		}
+	}
+	for field, ports := range map[string][]int{
+		"spec.traffic.outboundPortExclusionList": spec.Traffic.OutboundPortExclusionList,
+		"spec.traffic.inboundPortExclusionList":  spec.Traffic.InboundPortExclusionList,
+	} {
+		for _, port := range ports {
+			if port <= 0 || port > 65535 {
+				problems = append(problems, fmt.Sprintf("%s %d is not a port", field, port))
+			}
+		}
+	}
+
+	if tracing := spec.Observability.Tracing; tracing.Enable && (tracing.Address == "" || tracing.Port <= 0) {
+		problems = append(problems, "spec.observability.tracing is enabled without an address and a port")
+	}
+	if authz := spec.Traffic.InboundExternalAuthorization; authz.Enable && (authz.Address == "" || authz.Port == 0) {
+		problems = append(problems, "spec.traffic.inboundExternalAuthorization is enabled without an address and a port")
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+// checkRootCertificate checks the root certificate of the mesh does not expire soon. The root certificate is stored in
+// the CA bundle secret of the osm-controller, except with Vault which does not write it to the secret.
+func (cmd *meshCheckCmd) checkRootCertificate(controller *appsv1.Deployment) meshCheck {
+	check := meshCheck{Name: "Root certificate"}
+
+	if controller == nil {
+		return check.fail("No %s deployment found in namespace [%s]", constants.OSMControllerName, cmd.osmNamespace)
+	}
+	if getDeploymentArg(controller, certManagerFlag) == vaultCertManager {
+		return cmd.checkVaultRootCertificate(controller)
+	}
+
+	secretName := getDeploymentArg(controller, caBundleSecretNameFlag)
+	if secretName == "" {
+		return check.fail("Deployment %s/%s has no %s argument", controller.Namespace, controller.Name, caBundleSecretNameFlag)
+	}
+
+	secret, err := cmd.clientSet.CoreV1().Secrets(cmd.osmNamespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil {
+		return check.fail("Error fetching secret %s/%s: %s", cmd.osmNamespace, secretName, err)
+	}
+	return cmd.checkRootCertificateExpiry(check, secret.Data[constants.KubernetesOpaqueSecretCAKey], fmt.Sprintf("in secret %s/%s", cmd.osmNamespace, secretName))
+}
+
+// checkVaultRootCertificate checks the CA certificate of the Vault the osm-controller issues certificates from does not
+// expire soon. Vault is queried through the API server when its host is the DNS name of a Service, and directly
+// otherwise. The check warns when Vault can not be reached, e.g. from outside the cluster.
+func (cmd *meshCheckCmd) checkVaultRootCertificate(controller *appsv1.Deployment) meshCheck {
+	check := meshCheck{Name: "Root certificate"}
+
+	protocol := getDeploymentArgOrDefault(controller, vaultProtocolFlag, defaultVaultProtocol)
+	host := getDeploymentArgOrDefault(controller, vaultHostFlag, defaultVaultHost)
+	port := getDeploymentArgOrDefault(controller, vaultPortFlag, defaultVaultPort)
+	vaultAddr := fmt.Sprintf("%s://%s", protocol, net.JoinHostPort(host, port))
+
+	var pemCert []byte
+	var err error
+	if svcName, svcNamespace, ok := getServiceFromHost(host, cmd.osmNamespace); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), vaultRequestTimeout)
+		defer cancel()
+		pemCert, err = cmd.clientSet.CoreV1().Services(svcNamespace).ProxyGet(protocol, svcName, port, vaultCAPath, nil).DoRaw(ctx)
+	} else {
+		pemCert, err = getURL(fmt.Sprintf("%s/%s", vaultAddr, vaultCAPath))
+	}
+	if err != nil {
+		return check.warn("Root certificate is managed by Vault at %s, error fetching it: %s", vaultAddr, err)
+	}
+	return cmd.checkRootCertificateExpiry(check, pemCert, fmt.Sprintf("of Vault at %s", vaultAddr))
+}
+
+// checkRootCertificateExpiry checks the given PEM encoded root certificate, described by the given location, does not
+// expire soon
+func (cmd *meshCheckCmd) checkRootCertificateExpiry(check meshCheck, pemCert []byte, location string) meshCheck {
+	cert, err := certificate.DecodePEMCertificate(pemCert)
+	if err != nil {
+		return check.fail("Error decoding the root certificate %s: %s", location, err)
+	}
+
+	expiration := cert.NotAfter.UTC().Format(time.RFC3339)
+	remaining := time.Until(cert.NotAfter)
+	if remaining <= 0 {
+		return check.fail("Root certificate %s expired on %s", location, expiration)
+	}
+	if remaining < cmd.rootCertExpiry {
+		return check.warn("Root certificate %s expires on %s, in less than %s", location, expiration, cmd.rootCertExpiry)
+	}
+	return check.pass("Root certificate %s expires on %s", location, expiration)
+}
+
+// getServiceFromHost returns the name and namespace of the Service whose DNS name is the given host, of the form
+// <service>, <service>.<namespace>.svc or <service>.<namespace>.svc.<cluster domain>. A Service name alone resolves
+// to a Service in the given namespace, the namespace of the osm-controller.
+func getServiceFromHost(host, namespace string) (string, string, bool) {
+	if net.ParseIP(host) != nil {
+		return "", "", false
+	}
+	domains := strings.Split(host, ".")
+	switch {
+	case len(domains) == 1:
+		return domains[0], namespace, true
+	case len(domains) >= 3 && domains[2] == "svc":
+		return domains[0], domains[1], true
+	default:
+		return "", "", false
+	}
+}
+
+// getURL returns the body of the response to a GET request of the given URL
+func getURL(url string) ([]byte, error) {
+	client := &http.Client{Timeout: vaultRequestTimeout}
+
+	// #nosec G107: Potential HTTP request made with variable url
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint: errcheck,gosec
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Unexpected status %s from %s", resp.Status, url)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// getDeploymentArgOrDefault returns the value of the given flag in the command line of the containers of the deployment,
+// or the given default value when the flag is not set
+func getDeploymentArgOrDefault(deployment *appsv1.Deployment, flag, defaultValue string) string {
+	if value := getDeploymentArg(deployment, flag); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getDeploymentArg returns the value of the given flag in the command line of the containers of the deployment
+func getDeploymentArg(deployment *appsv1.Deployment, flag string) string {
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		args := append(append([]string{}, container.Command...), container.Args...)
+		for i, arg := range args {
+			if arg == flag && i+1 < len(args) {
+				return args[i+1]
+			}
+			if strings.HasPrefix(arg, flag+"=") {
+				return strings.TrimPrefix(arg, flag+"=")
+			}
+		}
+	}
+	return ""
+}
+
+func (c meshCheck) pass(format string, a ...interface{}) meshCheck {
+	return c.withStatus(meshCheckPass, format, a...)
+}
+
+func (c meshCheck) warn(format string, a ...interface{}) meshCheck {
+	return c.withStatus(meshCheckWarn, format, a...)
+}
+
+func (c meshCheck) fail(format string, a ...interface{}) meshCheck {
+	return c.withStatus(meshCheckFail, format, a...)
+}
+
+func (c meshCheck) withStatus(status string, format string, a ...interface{}) meshCheck {
+	c.Status = status
+	c.Detail = fmt.Sprintf(format, a...)
+	return c
+}
@@ -0,0 +1,567 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	fakeExtensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/constants"
+	fakeConfig "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+)
+
+const testMeshCheckCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: meshconfigs.config.openservicemesh.io
+spec:
+  group: config.openservicemesh.io
+  scope: Namespaced
+  names:
+    kind: MeshConfig
+    plural: meshconfigs
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+`
+
+var testMeshCheckChart = &chart.Chart{
+	Metadata: &chart.Metadata{
+		Name:        "osm",
+		KubeVersion: ">= 1.19.0",
+	},
+	Files: []*chart.File{
+		{Name: "crds/config_meshconfig.yaml", Data: []byte(testMeshCheckCRD)},
+	},
+}
+
+func TestMeshCheckPrerequisites(t *testing.T) {
+	podCreationWebhook := &admissionregv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-mesh-injector"},
+		Webhooks: []admissionregv1.MutatingWebhook{{
+			Name: "inject.other-mesh.io",
+			Rules: []admissionregv1.RuleWithOperations{{
+				Operations: []admissionregv1.OperationType{admissionregv1.Create},
+				Rule: admissionregv1.Rule{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+				},
+			}},
+		}},
+	}
+	osmWebhook := podCreationWebhook.DeepCopy()
+	osmWebhook.Name = "osm-webhook-osm"
+	osmWebhook.Labels = map[string]string{"app": osmInjectorName}
+
+	testCases := []struct {
+		name           string
+		kubeVersion    string
+		groupVersions  []string
+		objects        []runtime.Object
+		expectedStatus map[string]string
+		expectError    bool
+	}{
+		{
+			name:          "prerequisites met",
+			kubeVersion:   "v1.20.7-gke.1",
+			groupVersions: requiredAPIGroupVersions,
+			objects:       []runtime.Object{osmWebhook, newMeshCheckTestNode("node-1", constants.OSLinux)},
+			expectedStatus: map[string]string{
+				"Kubernetes version": meshCheckPass,
+				"Kubernetes APIs":    meshCheckPass,
+				"Mutating webhooks":  meshCheckPass,
+				"Linux nodes":        meshCheckPass,
+			},
+		},
+		{
+			name:          "conflicting webhook and windows node",
+			kubeVersion:   "v1.21.1",
+			groupVersions: requiredAPIGroupVersions,
+			objects: []runtime.Object{
+				podCreationWebhook,
+				newMeshCheckTestNode("node-1", constants.OSLinux),
+				newMeshCheckTestNode("node-2", "windows"),
+			},
+			expectedStatus: map[string]string{
+				"Kubernetes version": meshCheckPass,
+				"Kubernetes APIs":    meshCheckPass,
+				"Mutating webhooks":  meshCheckWarn,
+				"Linux nodes":        meshCheckWarn,
+			},
+		},
+		{
+			name:          "old Kubernetes without required APIs nor linux nodes",
+			kubeVersion:   "v1.18.3",
+			groupVersions: []string{"v1", "apps/v1"},
+			objects:       []runtime.Object{newMeshCheckTestNode("node-1", "windows")},
+			expectedStatus: map[string]string{
+				"Kubernetes version": meshCheckFail,
+				"Kubernetes APIs":    meshCheckFail,
+				"Mutating webhooks":  meshCheckPass,
+				"Linux nodes":        meshCheckFail,
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			fakeClient := fake.NewSimpleClientset(tc.objects...)
+			fakeDiscovery := fakeClient.Discovery().(*fakediscovery.FakeDiscovery)
+			fakeDiscovery.FakedServerVersion = &version.Info{GitVersion: tc.kubeVersion}
+			for _, groupVersion := range tc.groupVersions {
+				fakeDiscovery.Resources = append(fakeDiscovery.Resources, &metav1.APIResourceList{GroupVersion: groupVersion})
+			}
+
+			out := new(bytes.Buffer)
+			cmd := &meshCheckCmd{
+				out:            out,
+				pre:            true,
+				output:         meshCheckOutputJSON,
+				chartRequested: testMeshCheckChart,
+				clientSet:      fakeClient,
+			}
+
+			err := cmd.run()
+			assert.Equal(tc.expectError, err != nil, "unexpected error: %v", err)
+			assert.Equal(tc.expectedStatus, getMeshCheckStatuses(t, out.Bytes()))
+		})
+	}
+}
+
+func TestMeshCheckMesh(t *testing.T) {
+	testCases := []struct {
+		name           string
+		unready        bool
+		caBundle       []byte
+		rootCertExpiry time.Duration
+		crdVersion     string
+		meshConfigSpec configv1alpha1.MeshConfigSpec
+		failOnWarning  bool
+		expectedStatus map[string]string
+		expectError    bool
+	}{
+		{
+			name:       "healthy mesh",
+			crdVersion: "v1alpha1",
+			expectedStatus: map[string]string{
+				"osm-controller readiness": meshCheckPass,
+				"osm-injector readiness":   meshCheckPass,
+				"Webhook CA bundle":        meshCheckPass,
+				"CRD versions":             meshCheckPass,
+				"MeshConfig":               meshCheckPass,
+				"Root certificate":         meshCheckPass,
+			},
+		},
+		{
+			name:           "root certificate about to expire",
+			crdVersion:     "v1alpha1",
+			rootCertExpiry: 2 * 365 * 24 * time.Hour,
+			expectedStatus: map[string]string{
+				"osm-controller readiness": meshCheckPass,
+				"osm-injector readiness":   meshCheckPass,
+				"Webhook CA bundle":        meshCheckPass,
+				"CRD versions":             meshCheckPass,
+				"MeshConfig":               meshCheckPass,
+				"Root certificate":         meshCheckWarn,
+			},
+		},
+		{
+			name:           "root certificate about to expire with --fail-on-warning",
+			crdVersion:     "v1alpha1",
+			rootCertExpiry: 2 * 365 * 24 * time.Hour,
+			failOnWarning:  true,
+			expectedStatus: map[string]string{
+				"osm-controller readiness": meshCheckPass,
+				"osm-injector readiness":   meshCheckPass,
+				"Webhook CA bundle":        meshCheckPass,
+				"CRD versions":             meshCheckPass,
+				"MeshConfig":               meshCheckPass,
+				"Root certificate":         meshCheckWarn,
+			},
+			expectError: true,
+		},
+		{
+			name:       "unhealthy mesh",
+			unready:    true,
+			caBundle:   []byte("stale"),
+			crdVersion: "v1alpha0",
+			meshConfigSpec: configv1alpha1.MeshConfigSpec{
+				Sidecar: configv1alpha1.SidecarSpec{ConfigResyncInterval: "often"},
+			},
+			expectedStatus: map[string]string{
+				"osm-controller readiness": meshCheckFail,
+				"osm-injector readiness":   meshCheckFail,
+				"Webhook CA bundle":        meshCheckFail,
+				"CRD versions":             meshCheckFail,
+				"MeshConfig":               meshCheckFail,
+				"Root certificate":         meshCheckPass,
+			},
+			expectError: true,
+		},
+	}
+
+	rootCert, err := tresor.NewCA("osm-ca.openservicemesh.io", 365*24*time.Hour, "US", "CA", "Open Service Mesh")
+	trequire.NoError(t, err)
+	webhookCert := []byte("webhook-cert")
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			osmNamespace := "osm-system"
+			readyReplicas := int32(1)
+			if tc.unready {
+				readyReplicas = 0
+			}
+			caBundle := webhookCert
+			if tc.caBundle != nil {
+				caBundle = tc.caBundle
+			}
+
+			fakeClient := fake.NewSimpleClientset(
+				newMeshCheckTestDeployment(osmNamespace, constants.OSMControllerName, readyReplicas, caBundleSecretNameFlag, "osm-ca-bundle"),
+				newMeshCheckTestDeployment(osmNamespace, osmInjectorName, readyReplicas, webhookConfigNameFlag, "osm-webhook-osm"),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "osm-ca-bundle", Namespace: osmNamespace},
+					Data:       map[string][]byte{constants.KubernetesOpaqueSecretCAKey: rootCert.GetCertificateChain()},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: constants.WebhookCertificateSecretName, Namespace: osmNamespace},
+					Data:       map[string][]byte{constants.KubernetesOpaqueSecretCAKey: webhookCert},
+				},
+				&admissionregv1.MutatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{Name: "osm-webhook-osm"},
+					Webhooks: []admissionregv1.MutatingWebhook{{
+						Name:         "osm-inject.k8s.io",
+						ClientConfig: admissionregv1.WebhookClientConfig{CABundle: caBundle},
+					}},
+				},
+			)
+			fakeExtensionsClient := fakeExtensions.NewSimpleClientset(&apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "meshconfigs.config.openservicemesh.io"},
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Group:    "config.openservicemesh.io",
+					Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: tc.crdVersion, Served: true, Storage: true}},
+				},
+			})
+			fakeConfigClient := fakeConfig.NewSimpleClientset(&configv1alpha1.MeshConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultOsmMeshConfigName, Namespace: osmNamespace},
+				Spec:       tc.meshConfigSpec,
+			})
+
+			rootCertExpiry := defaultRootCertExpiryThreshold
+			if tc.rootCertExpiry != 0 {
+				rootCertExpiry = tc.rootCertExpiry
+			}
+
+			out := new(bytes.Buffer)
+			cmd := &meshCheckCmd{
+				out:                 out,
+				output:              meshCheckOutputJSON,
+				failOnWarning:       tc.failOnWarning,
+				rootCertExpiry:      rootCertExpiry,
+				osmNamespace:        osmNamespace,
+				chartRequested:      testMeshCheckChart,
+				clientSet:           fakeClient,
+				extensionsClientSet: fakeExtensionsClient,
+				meshConfigClient:    fakeConfigClient,
+			}
+
+			err := cmd.run()
+			assert.Equal(tc.expectError, err != nil, "unexpected error: %v", err)
+			assert.Equal(tc.expectedStatus, getMeshCheckStatuses(t, out.Bytes()))
+		})
+	}
+}
+
+func TestMeshCheckVaultRootCertificate(t *testing.T) {
+	rootCert, err := tresor.NewCA("osm-ca.openservicemesh.io", 365*24*time.Hour, "US", "CA", "Open Service Mesh")
+	trequire.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+vaultCAPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(rootCert.GetCertificateChain())
+	}))
+	defer server.Close()
+	serverHost, serverPort, err := net.SplitHostPort(server.Listener.Addr().String())
+	trequire.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		args            []string
+		proxyResponse   []byte
+		proxyErr        error
+		expectedService string
+		expectedStatus  string
+		expectedDetail  string
+	}{
+		{
+			name:            "Vault Service queried through the API server",
+			args:            []string{certManagerFlag, vaultCertManager, vaultHostFlag, "vault.vault.svc.cluster.local"},
+			proxyResponse:   rootCert.GetCertificateChain(),
+			expectedService: "vault/vault",
+			expectedStatus:  meshCheckPass,
+			expectedDetail:  "Root certificate of Vault at http://vault.vault.svc.cluster.local:8200 expires on",
+		},
+		{
+			name:            "Vault Service in the namespace of the osm-controller",
+			args:            []string{certManagerFlag, vaultCertManager, vaultHostFlag, "vault", vaultProtocolFlag, "https", vaultPortFlag, "8443"},
+			proxyResponse:   rootCert.GetCertificateChain(),
+			expectedService: "osm-system/vault",
+			expectedStatus:  meshCheckPass,
+			expectedDetail:  "Root certificate of Vault at https://vault:8443 expires on",
+		},
+		{
+			name:            "Vault Service unreachable",
+			args:            []string{certManagerFlag, vaultCertManager},
+			proxyErr:        errors.New("service unavailable"),
+			expectedService: "default/vault",
+			expectedStatus:  meshCheckWarn,
+			expectedDetail:  "Root certificate is managed by Vault at http://vault.default.svc.cluster.local:8200, error fetching it: service unavailable",
+		},
+		{
+			name:            "Vault Service returning an invalid certificate",
+			args:            []string{certManagerFlag, vaultCertManager},
+			proxyResponse:   []byte("not a certificate"),
+			expectedService: "default/vault",
+			expectedStatus:  meshCheckFail,
+			expectedDetail:  "Error decoding the root certificate of Vault at http://vault.default.svc.cluster.local:8200",
+		},
+		{
+			name:           "Vault outside of the cluster queried directly",
+			args:           []string{certManagerFlag, vaultCertManager, vaultHostFlag, serverHost, vaultPortFlag, serverPort},
+			expectedStatus: meshCheckPass,
+			expectedDetail: fmt.Sprintf("Root certificate of Vault at %s expires on", server.URL),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			fakeClient := fake.NewSimpleClientset()
+			var proxiedService string
+			fakeClient.AddProxyReactor("services", func(action k8stesting.Action) (bool, rest.ResponseWrapper, error) {
+				proxyGet := action.(k8stesting.ProxyGetAction)
+				proxiedService = fmt.Sprintf("%s/%s", proxyGet.GetNamespace(), proxyGet.GetName())
+				assert.Equal(vaultCAPath, proxyGet.GetPath())
+				return true, testResponseWrapper{body: tc.proxyResponse, err: tc.proxyErr}, nil
+			})
+
+			cmd := &meshCheckCmd{
+				rootCertExpiry: defaultRootCertExpiryThreshold,
+				osmNamespace:   "osm-system",
+				clientSet:      fakeClient,
+			}
+			controller := newMeshCheckTestDeployment("osm-system", constants.OSMControllerName, 1, tc.args...)
+
+			check := cmd.checkRootCertificate(controller)
+			assert.Equal(tc.expectedStatus, check.Status, check.Detail)
+			assert.Contains(check.Detail, tc.expectedDetail)
+			assert.Equal(tc.expectedService, proxiedService)
+		})
+	}
+}
+
+func TestGetServiceFromHost(t *testing.T) {
+	testCases := []struct {
+		host              string
+		expectedName      string
+		expectedNamespace string
+		expectedOk        bool
+	}{
+		{host: "vault", expectedName: "vault", expectedNamespace: "osm-system", expectedOk: true},
+		{host: "vault.vault.svc", expectedName: "vault", expectedNamespace: "vault", expectedOk: true},
+		{host: "vault.vault.svc.cluster.local", expectedName: "vault", expectedNamespace: "vault", expectedOk: true},
+		{host: "vault.example.com"},
+		{host: "example.com"},
+		{host: "10.0.0.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.host, func(t *testing.T) {
+			assert := tassert.New(t)
+			name, namespace, ok := getServiceFromHost(tc.host, "osm-system")
+			assert.Equal(tc.expectedOk, ok)
+			assert.Equal(tc.expectedName, name)
+			assert.Equal(tc.expectedNamespace, namespace)
+		})
+	}
+}
+
+// testResponseWrapper is a response of the fake API server to a proxied request
+type testResponseWrapper struct {
+	body []byte
+	err  error
+}
+
+func (r testResponseWrapper) DoRaw(context.Context) ([]byte, error) {
+	return r.body, r.err
+}
+
+func (r testResponseWrapper) Stream(context.Context) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(r.body)), r.err
+}
+
+func TestMeshCheckTableOutput(t *testing.T) {
+	assert := tassert.New(t)
+
+	out := new(bytes.Buffer)
+	cmd := &meshCheckCmd{
+		out:          out,
+		output:       meshCheckOutputTable,
+		osmNamespace: "osm-system",
+		clientSet:    fake.NewSimpleClientset(),
+	}
+	report := meshCheckReport{
+		Checks: []meshCheck{
+			meshCheck{Name: "osm-controller readiness"}.fail("No osm-controller deployment found in namespace [osm-system]"),
+		},
+		Failed: 1,
+	}
+
+	assert.NoError(cmd.printReport(report))
+	assert.Contains(out.String(), "STATUS")
+	assert.Contains(out.String(), "No osm-controller deployment found in namespace [osm-system]")
+	assert.Contains(out.String(), "0 passed, 0 warnings, 1 failed")
+
+	cmd.output = "yaml"
+	assert.Error(cmd.run())
+}
+
+func TestValidateMeshConfig(t *testing.T) {
+	assert := tassert.New(t)
+
+	valid := configv1alpha1.MeshConfigSpec{
+		Sidecar: configv1alpha1.SidecarSpec{
+			LogLevel:             "error",
+			ConfigResyncInterval: "90s",
+		},
+		Traffic: configv1alpha1.TrafficSpec{
+			OutboundIPRangeExclusionList: []string{"10.0.0.0/8"},
+			OutboundPortExclusionList:    []int{6379},
+		},
+		Observability: configv1alpha1.ObservabilitySpec{
+			OSMLogLevel: "info",
+		},
+		Certificate: configv1alpha1.CertificateSpec{
+			ServiceCertValidityDuration: "24h",
+		},
+	}
+	assert.Empty(validateMeshConfig(valid))
+
+	invalid := valid
+	invalid.Sidecar.LogLevel = "verbose"
+	invalid.Traffic.OutboundIPRangeExclusionList = []string{"10.0.0.1"}
+	invalid.Traffic.InboundPortExclusionList = []int{70000}
+	invalid.Observability.Tracing = configv1alpha1.TracingSpec{Enable: true}
+	invalid.Certificate.ServiceCertValidityDuration = "1 day"
+	assert.Equal([]string{
+		`spec.certificate.serviceCertValidityDuration "1 day" is not a duration`,
+		`spec.observability.tracing is enabled without an address and a port`,
+		`spec.sidecar.logLevel "verbose" is not an Envoy log level`,
+		`spec.traffic.inboundPortExclusionList 70000 is not a port`,
+		`spec.traffic.outboundIPRangeExclusionList "10.0.0.1" is not a CIDR`,
+	}, validateMeshConfig(invalid))
+}
+
+func TestGetDeploymentArg(t *testing.T) {
+	assert := tassert.New(t)
+
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Command: []string{"/osm-injector"},
+						Args:    []string{"--verbosity", "info", "--webhook-config-name", "osm-webhook-osm", "--ca-bundle-secret-name=osm-ca-bundle"},
+					}},
+				},
+			},
+		},
+	}
+
+	assert.Equal("osm-webhook-osm", getDeploymentArg(deployment, webhookConfigNameFlag))
+	assert.Equal("osm-ca-bundle", getDeploymentArg(deployment, caBundleSecretNameFlag))
+	assert.Empty(getDeploymentArg(deployment, "--mesh-name"))
+}
+
+// getMeshCheckStatuses returns the status of each check of a JSON report
+func getMeshCheckStatuses(t *testing.T, jsonReport []byte) map[string]string {
+	var report meshCheckReport
+	trequire.NoError(t, json.Unmarshal(jsonReport, &report))
+
+	statuses := make(map[string]string)
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func newMeshCheckTestNode(name, os string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{OperatingSystem: os},
+		},
+	}
+}
+
+func newMeshCheckTestDeployment(namespace, app string, readyReplicas int32, args ...string) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app,
+			Namespace: namespace,
+			Labels:    map[string]string{"app": app},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: app,
+						Args: args,
+					}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: readyReplicas,
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const meshGraphDescription = `
+This command prints the graph of the traffic allowed between the service
+accounts of the mesh by the SMI TrafficTarget policies. The edges of the graph
+are annotated with the protocols of the destination services, the HTTP routes
+and TCP ports allowed, and the TrafficSplit weights of the destination services.
+
+The graph is printed in JSON or in the Graphviz DOT language, which can be
+rendered with the 'dot' tool. It is queried from the debug server of the
+osm-controller, which requires the debug server to be enabled in the MeshConfig.
+`
+
+const meshGraphExample = `
+# Print the graph of the allowed traffic in JSON
+osm mesh graph
+
+# Render the graph of the allowed traffic as an SVG image
+osm mesh graph --format dot | dot -Tsvg > mesh.svg
+`
+
+const (
+	// meshGraphDebugPath is the osm-controller debug server path returning the graph of the allowed traffic
+	meshGraphDebugPath = "/debug/graph"
+
+	meshGraphFormatJSON = "json"
+	meshGraphFormatDOT  = "dot"
+)
+
+type meshGraphCmd struct {
+	out       io.Writer
+	format    string
+	localPort uint16
+
+	// getControllerDebugInfo returns the responses of the osm-controller replicas' debug servers for the given path
+	getControllerDebugInfo func(path string) (map[string][]byte, error)
+}
+
+func newMeshGraphCmd(out io.Writer) *cobra.Command {
+	graphCmd := &meshGraphCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "print the graph of the traffic allowed in the mesh",
+		Long:  meshGraphDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+
+			graphCmd.getControllerDebugInfo = func(path string) (map[string][]byte, error) {
+				return cli.GetControllerDebugInfo(clientset, config, settings.Namespace(), graphCmd.localPort, path)
+			}
+
+			return graphCmd.run()
+		},
+		Example: meshGraphExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&graphCmd.format, "format", meshGraphFormatJSON, "Output format, one of: json|dot")
+	f.Uint16VarP(&graphCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (cmd *meshGraphCmd) run() error {
+	if cmd.format != meshGraphFormatJSON && cmd.format != meshGraphFormatDOT {
+		return errors.Errorf("Invalid format %q, expected %s or %s", cmd.format, meshGraphFormatJSON, meshGraphFormatDOT)
+	}
+
+	responses, err := cmd.getControllerDebugInfo(fmt.Sprintf("%s?format=%s", meshGraphDebugPath, cmd.format))
+	if err != nil {
+		return err
+	}
+
+	// Every osm-controller replica builds the same graph from the policies in the cluster
+	var controllers []string
+	for controller := range responses {
+		controllers = append(controllers, controller)
+	}
+	sort.Strings(controllers)
+	graph := responses[controllers[0]]
+
+	if cmd.format == meshGraphFormatJSON {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, graph, "", "  "); err != nil {
+			return errors.Errorf("Error parsing the graph reported by %s: %s", controllers[0], err)
+		}
+		fmt.Fprintln(cmd.out, indented.String())
+		return nil
+	}
+
+	_, err = cmd.out.Write(graph)
+	return err
+}
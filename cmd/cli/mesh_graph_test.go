@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestMeshGraphRun(t *testing.T) {
+	testCases := []struct {
+		name         string
+		format       string
+		responses    map[string][]byte
+		err          error
+		expectedPath string
+		expected     string
+		expectedErr  bool
+	}{
+		{
+			name:   "JSON graph",
+			format: "json",
+			responses: map[string][]byte{
+				"osm-controller-2": []byte(`{"nodes":[],"edges":[]}`),
+				"osm-controller-1": []byte(`{"nodes":[{"identity":"bookstore/bookstore"}],"edges":[]}`),
+			},
+			expectedPath: "/debug/graph?format=json",
+			expected:     "{\n  \"nodes\": [\n    {\n      \"identity\": \"bookstore/bookstore\"\n    }\n  ],\n  \"edges\": []\n}\n",
+		},
+		{
+			name:   "DOT graph",
+			format: "dot",
+			responses: map[string][]byte{
+				"osm-controller-1": []byte("digraph mesh {\n}\n"),
+			},
+			expectedPath: "/debug/graph?format=dot",
+			expected:     "digraph mesh {\n}\n",
+		},
+		{
+			name:        "invalid format",
+			format:      "svg",
+			expectedErr: true,
+		},
+		{
+			name:         "debug server unreachable",
+			format:       "json",
+			err:          errors.New("debug server disabled"),
+			expectedPath: "/debug/graph?format=json",
+			expectedErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			out := new(bytes.Buffer)
+
+			cmd := &meshGraphCmd{
+				out:    out,
+				format: tc.format,
+				getControllerDebugInfo: func(path string) (map[string][]byte, error) {
+					assert.Equal(tc.expectedPath, path)
+					return tc.responses, tc.err
+				},
+			}
+
+			err := cmd.run()
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Equal(tc.expected, out.String())
+		})
+	}
+}
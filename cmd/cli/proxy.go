@@ -20,6 +20,8 @@ func newProxyCmd(config *action.Configuration, out io.Writer) *cobra.Command {
 		Args:  cobra.NoArgs,
 	}
 	cmd.AddCommand(newProxyGetCmd(config, out))
+	cmd.AddCommand(newProxyOutdatedCmd(out))
+	cmd.AddCommand(newProxyNACKsCmd(out))
 
 	return cmd
 }
@@ -0,0 +1,623 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The types below are the subset of Envoy's config dump, as returned by its /config_dump admin endpoint,
+// used to render the proxy configuration. They are decoded with encoding/json instead of the xDS protos
+// so that the typed configs of filters and extensions unknown to the CLI do not fail the decoding.
+const (
+	listenersConfigDumpType = "type.googleapis.com/envoy.admin.v3.ListenersConfigDump"
+	clustersConfigDumpType  = "type.googleapis.com/envoy.admin.v3.ClustersConfigDump"
+	routesConfigDumpType    = "type.googleapis.com/envoy.admin.v3.RoutesConfigDump"
+	endpointsConfigDumpType = "type.googleapis.com/envoy.admin.v3.EndpointsConfigDump"
+	secretsConfigDumpType   = "type.googleapis.com/envoy.admin.v3.SecretsConfigDump"
+)
+
+type envoyConfigDump struct {
+	Configs []json.RawMessage `json:"configs"`
+}
+
+type envoyAddress struct {
+	SocketAddress struct {
+		Address   string `json:"address"`
+		PortValue uint32 `json:"port_value"`
+	} `json:"socket_address"`
+}
+
+type envoyListener struct {
+	Name               string             `json:"name"`
+	Address            envoyAddress       `json:"address"`
+	FilterChains       []envoyFilterChain `json:"filter_chains"`
+	DefaultFilterChain *envoyFilterChain  `json:"default_filter_chain"`
+}
+
+type envoyFilterChain struct {
+	Name             string `json:"name"`
+	FilterChainMatch struct {
+		DestinationPort uint32 `json:"destination_port"`
+		PrefixRanges    []struct {
+			AddressPrefix string `json:"address_prefix"`
+		} `json:"prefix_ranges"`
+		ServerNames          []string `json:"server_names"`
+		TransportProtocol    string   `json:"transport_protocol"`
+		ApplicationProtocols []string `json:"application_protocols"`
+	} `json:"filter_chain_match"`
+	Filters []struct {
+		Name        string          `json:"name"`
+		TypedConfig json.RawMessage `json:"typed_config"`
+	} `json:"filters"`
+}
+
+type envoyListenersConfigDump struct {
+	StaticListeners []struct {
+		Listener envoyListener `json:"listener"`
+	} `json:"static_listeners"`
+	DynamicListeners []struct {
+		ActiveState *struct {
+			Listener envoyListener `json:"listener"`
+		} `json:"active_state"`
+	} `json:"dynamic_listeners"`
+}
+
+type envoyCluster struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	ClusterType *struct {
+		Name string `json:"name"`
+	} `json:"cluster_type"`
+	LBPolicy        string `json:"lb_policy"`
+	TransportSocket *struct {
+		TypedConfig struct {
+			SNI string `json:"sni"`
+		} `json:"typed_config"`
+	} `json:"transport_socket"`
+}
+
+type envoyClustersConfigDump struct {
+	StaticClusters []struct {
+		Cluster envoyCluster `json:"cluster"`
+	} `json:"static_clusters"`
+	DynamicActiveClusters []struct {
+		Cluster envoyCluster `json:"cluster"`
+	} `json:"dynamic_active_clusters"`
+}
+
+type envoyRegex struct {
+	Regex string `json:"regex"`
+}
+
+type envoyRoute struct {
+	Match struct {
+		Prefix    *string     `json:"prefix"`
+		Path      *string     `json:"path"`
+		SafeRegex *envoyRegex `json:"safe_regex"`
+		Headers   []struct {
+			Name           string      `json:"name"`
+			ExactMatch     string      `json:"exact_match"`
+			SafeRegexMatch *envoyRegex `json:"safe_regex_match"`
+		} `json:"headers"`
+	} `json:"match"`
+	Route *struct {
+		Cluster          string `json:"cluster"`
+		WeightedClusters *struct {
+			Clusters []struct {
+				Name   string `json:"name"`
+				Weight uint32 `json:"weight"`
+			} `json:"clusters"`
+		} `json:"weighted_clusters"`
+	} `json:"route"`
+	TypedPerFilterConfig map[string]json.RawMessage `json:"typed_per_filter_config"`
+}
+
+type envoyVirtualHost struct {
+	Name    string       `json:"name"`
+	Domains []string     `json:"domains"`
+	Routes  []envoyRoute `json:"routes"`
+}
+
+type envoyRouteConfig struct {
+	Name         string             `json:"name"`
+	VirtualHosts []envoyVirtualHost `json:"virtual_hosts"`
+}
+
+type envoyRoutesConfigDump struct {
+	StaticRouteConfigs []struct {
+		RouteConfig envoyRouteConfig `json:"route_config"`
+	} `json:"static_route_configs"`
+	DynamicRouteConfigs []struct {
+		RouteConfig envoyRouteConfig `json:"route_config"`
+	} `json:"dynamic_route_configs"`
+}
+
+type envoyClusterLoadAssignment struct {
+	ClusterName string `json:"cluster_name"`
+	Endpoints   []struct {
+		LBEndpoints []struct {
+			Endpoint struct {
+				Address envoyAddress `json:"address"`
+			} `json:"endpoint"`
+			HealthStatus string `json:"health_status"`
+		} `json:"lb_endpoints"`
+	} `json:"endpoints"`
+}
+
+type envoyEndpointsConfigDump struct {
+	StaticEndpointConfigs []struct {
+		EndpointConfig envoyClusterLoadAssignment `json:"endpoint_config"`
+	} `json:"static_endpoint_configs"`
+	DynamicEndpointConfigs []struct {
+		EndpointConfig envoyClusterLoadAssignment `json:"endpoint_config"`
+	} `json:"dynamic_endpoint_configs"`
+}
+
+type envoySecret struct {
+	Name        string    `json:"name"`
+	VersionInfo string    `json:"version_info"`
+	LastUpdated time.Time `json:"last_updated"`
+	Secret      struct {
+		TLSCertificate *struct {
+			CertificateChain struct {
+				InlineBytes []byte `json:"inline_bytes"`
+			} `json:"certificate_chain"`
+		} `json:"tls_certificate"`
+		ValidationContext *struct {
+			TrustedCA struct {
+				InlineBytes []byte `json:"inline_bytes"`
+			} `json:"trusted_ca"`
+			MatchSubjectAltNames []struct {
+				Exact string `json:"exact"`
+			} `json:"match_subject_alt_names"`
+		} `json:"validation_context"`
+	} `json:"secret"`
+}
+
+type envoySecretsConfigDump struct {
+	StaticSecrets        []envoySecret `json:"static_secrets"`
+	DynamicActiveSecrets []envoySecret `json:"dynamic_active_secrets"`
+}
+
+// proxyConfigFilter is the filter applied to the proxy configuration rendered, the zero values match any resource
+type proxyConfigFilter struct {
+	port    uint32
+	cluster string
+}
+
+// proxyConfigRow is a resource of the proxy configuration rendered as a table row
+type proxyConfigRow interface {
+	columns() []string
+}
+
+type listenerRow struct {
+	Name        string   `json:"name"`
+	Address     string   `json:"address"`
+	Port        uint32   `json:"port"`
+	FilterChain string   `json:"filterChain"`
+	Match       string   `json:"match,omitempty"`
+	Filters     []string `json:"filters"`
+}
+
+func (r listenerRow) columns() []string {
+	return []string{r.Name, r.Address, fmt.Sprint(r.Port), r.FilterChain, orDash(r.Match), strings.Join(r.Filters, ",")}
+}
+
+type clusterRow struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	LBPolicy string `json:"lbPolicy"`
+	SNI      string `json:"sni,omitempty"`
+}
+
+func (r clusterRow) columns() []string {
+	return []string{r.Name, r.Type, r.LBPolicy, orDash(r.SNI)}
+}
+
+type routeRow struct {
+	RouteConfig  string   `json:"routeConfig"`
+	VirtualHost  string   `json:"virtualHost"`
+	Domains      []string `json:"domains"`
+	Match        string   `json:"match"`
+	Destinations []string `json:"destinations,omitempty"`
+}
+
+func (r routeRow) columns() []string {
+	return []string{r.RouteConfig, r.VirtualHost, strings.Join(r.Domains, ","), r.Match, orDash(strings.Join(r.Destinations, ","))}
+}
+
+type endpointRow struct {
+	Cluster string `json:"cluster"`
+	Address string `json:"address"`
+	Port    uint32 `json:"port"`
+	Health  string `json:"health"`
+}
+
+func (r endpointRow) columns() []string {
+	return []string{r.Cluster, r.Address, fmt.Sprint(r.Port), r.Health}
+}
+
+type secretRow struct {
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	Version     string     `json:"version,omitempty"`
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+	NotAfter    *time.Time `json:"notAfter,omitempty"`
+}
+
+func (r secretRow) columns() []string {
+	columns := []string{r.Name, r.Type, orDash(r.Version), "-", "-"}
+	if r.LastUpdated != nil {
+		columns[3] = r.LastUpdated.Format(time.RFC3339)
+	}
+	if r.NotAfter != nil {
+		columns[4] = r.NotAfter.Format(time.RFC3339)
+	}
+	return columns
+}
+
+// decodeConfigDump decodes the config of the given type from the given config dump into out
+func decodeConfigDump(configDump []byte, configType string, out interface{}) error {
+	var dump envoyConfigDump
+	if err := json.Unmarshal(configDump, &dump); err != nil {
+		return errors.Errorf("Error parsing the proxy config dump: %s", err)
+	}
+
+	for _, config := range dump.Configs {
+		var typed struct {
+			Type string `json:"@type"`
+		}
+		if err := json.Unmarshal(config, &typed); err != nil {
+			return errors.Errorf("Error parsing the proxy config dump: %s", err)
+		}
+		if typed.Type != configType {
+			continue
+		}
+		if err := json.Unmarshal(config, out); err != nil {
+			return errors.Errorf("Error parsing %s from the proxy config dump: %s", configType, err)
+		}
+		return nil
+	}
+
+	// The proxy has no config of the given type
+	return nil
+}
+
+// getListeners returns the static and active dynamic listeners of the given config dump
+func getListeners(configDump []byte) ([]envoyListener, error) {
+	var dump envoyListenersConfigDump
+	if err := decodeConfigDump(configDump, listenersConfigDumpType, &dump); err != nil {
+		return nil, err
+	}
+
+	var listeners []envoyListener
+	for _, l := range dump.StaticListeners {
+		listeners = append(listeners, l.Listener)
+	}
+	for _, l := range dump.DynamicListeners {
+		if l.ActiveState != nil {
+			listeners = append(listeners, l.ActiveState.Listener)
+		}
+	}
+	return listeners, nil
+}
+
+func getListenerRows(configDump []byte, filter proxyConfigFilter) ([]proxyConfigRow, error) {
+	listeners, err := getListeners(configDump)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []proxyConfigRow
+	for _, l := range listeners {
+		filterChains := l.FilterChains
+		if l.DefaultFilterChain != nil {
+			filterChains = append(filterChains, *l.DefaultFilterChain)
+		}
+		for _, fc := range filterChains {
+			port := l.Address.SocketAddress.PortValue
+			match := fc.FilterChainMatch
+			if filter.port != 0 && filter.port != port && filter.port != match.DestinationPort {
+				continue
+			}
+
+			var matches []string
+			if match.DestinationPort != 0 {
+				matches = append(matches, fmt.Sprintf("port=%d", match.DestinationPort))
+			}
+			if match.TransportProtocol != "" {
+				matches = append(matches, "transport="+match.TransportProtocol)
+			}
+			if len(match.ApplicationProtocols) > 0 {
+				matches = append(matches, "alpn="+strings.Join(match.ApplicationProtocols, "|"))
+			}
+			if len(match.ServerNames) > 0 {
+				matches = append(matches, "sni="+strings.Join(match.ServerNames, "|"))
+			}
+
+			row := listenerRow{
+				Name:        l.Name,
+				Address:     l.Address.SocketAddress.Address,
+				Port:        port,
+				FilterChain: fc.Name,
+				Match:       strings.Join(matches, " "),
+			}
+			for _, f := range fc.Filters {
+				row.Filters = append(row.Filters, f.Name)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// getClusters returns the static and active dynamic clusters of the given config dump
+func getClusters(configDump []byte) ([]envoyCluster, error) {
+	var dump envoyClustersConfigDump
+	if err := decodeConfigDump(configDump, clustersConfigDumpType, &dump); err != nil {
+		return nil, err
+	}
+
+	var clusters []envoyCluster
+	for _, c := range dump.StaticClusters {
+		clusters = append(clusters, c.Cluster)
+	}
+	for _, c := range dump.DynamicActiveClusters {
+		clusters = append(clusters, c.Cluster)
+	}
+	return clusters, nil
+}
+
+func getClusterRows(configDump []byte, filter proxyConfigFilter) ([]proxyConfigRow, error) {
+	clusters, err := getClusters(configDump)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []proxyConfigRow
+	for _, c := range clusters {
+		if filter.cluster != "" && filter.cluster != c.Name {
+			continue
+		}
+
+		// Default values are omitted from the config dump
+		row := clusterRow{Name: c.Name, Type: c.Type, LBPolicy: c.LBPolicy}
+		if c.ClusterType != nil {
+			row.Type = c.ClusterType.Name
+		}
+		if row.Type == "" {
+			row.Type = "STATIC"
+		}
+		if row.LBPolicy == "" {
+			row.LBPolicy = "ROUND_ROBIN"
+		}
+		if c.TransportSocket != nil {
+			row.SNI = c.TransportSocket.TypedConfig.SNI
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].(clusterRow).Name < rows[j].(clusterRow).Name
+	})
+	return rows, nil
+}
+
+// getRouteConfigs returns the static and dynamic route configurations of the given config dump
+func getRouteConfigs(configDump []byte) ([]envoyRouteConfig, error) {
+	var dump envoyRoutesConfigDump
+	if err := decodeConfigDump(configDump, routesConfigDumpType, &dump); err != nil {
+		return nil, err
+	}
+
+	var routeConfigs []envoyRouteConfig
+	for _, rc := range dump.StaticRouteConfigs {
+		routeConfigs = append(routeConfigs, rc.RouteConfig)
+	}
+	for _, rc := range dump.DynamicRouteConfigs {
+		routeConfigs = append(routeConfigs, rc.RouteConfig)
+	}
+	return routeConfigs, nil
+}
+
+func getRouteRows(configDump []byte, filter proxyConfigFilter) ([]proxyConfigRow, error) {
+	routeConfigs, err := getRouteConfigs(configDump)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []proxyConfigRow
+	for _, rc := range routeConfigs {
+		for _, vh := range rc.VirtualHosts {
+			for _, route := range vh.Routes {
+				if filter.cluster != "" && !routesToCluster(route, filter.cluster) {
+					continue
+				}
+				rows = append(rows, routeRow{
+					RouteConfig:  rc.Name,
+					VirtualHost:  vh.Name,
+					Domains:      vh.Domains,
+					Match:        getRouteMatch(route),
+					Destinations: getRouteDestinations(route),
+				})
+			}
+		}
+	}
+	return rows, nil
+}
+
+// getRouteMatch returns the path and header matches of the given route
+func getRouteMatch(route envoyRoute) string {
+	var matches []string
+	switch {
+	case route.Match.Prefix != nil:
+		matches = append(matches, "prefix="+*route.Match.Prefix)
+	case route.Match.Path != nil:
+		matches = append(matches, "path="+*route.Match.Path)
+	case route.Match.SafeRegex != nil:
+		matches = append(matches, "regex="+route.Match.SafeRegex.Regex)
+	}
+	for _, header := range route.Match.Headers {
+		if header.SafeRegexMatch != nil {
+			matches = append(matches, fmt.Sprintf("%s~%s", header.Name, header.SafeRegexMatch.Regex))
+		} else {
+			matches = append(matches, fmt.Sprintf("%s=%s", header.Name, header.ExactMatch))
+		}
+	}
+	return strings.Join(matches, " ")
+}
+
+// getRouteDestinations returns the clusters the given route forwards requests to, with their weight if weighted
+func getRouteDestinations(route envoyRoute) []string {
+	if route.Route == nil {
+		return nil
+	}
+	if route.Route.WeightedClusters == nil {
+		return []string{route.Route.Cluster}
+	}
+	var destinations []string
+	for _, c := range route.Route.WeightedClusters.Clusters {
+		destinations = append(destinations, fmt.Sprintf("%s:%d", c.Name, c.Weight))
+	}
+	return destinations
+}
+
+// routesToCluster returns whether the given route forwards requests to the given cluster
+func routesToCluster(route envoyRoute, cluster string) bool {
+	if route.Route == nil {
+		return false
+	}
+	if route.Route.Cluster == cluster {
+		return true
+	}
+	if route.Route.WeightedClusters != nil {
+		for _, c := range route.Route.WeightedClusters.Clusters {
+			if c.Name == cluster {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getClusterLoadAssignments returns the static and dynamic endpoint configs of the given config dump, which only
+// has endpoints when queried with include_eds
+func getClusterLoadAssignments(configDump []byte) ([]envoyClusterLoadAssignment, error) {
+	var dump envoyEndpointsConfigDump
+	if err := decodeConfigDump(configDump, endpointsConfigDumpType, &dump); err != nil {
+		return nil, err
+	}
+
+	var assignments []envoyClusterLoadAssignment
+	for _, ec := range dump.StaticEndpointConfigs {
+		assignments = append(assignments, ec.EndpointConfig)
+	}
+	for _, ec := range dump.DynamicEndpointConfigs {
+		assignments = append(assignments, ec.EndpointConfig)
+	}
+	return assignments, nil
+}
+
+func getEndpointRows(configDump []byte, filter proxyConfigFilter) ([]proxyConfigRow, error) {
+	assignments, err := getClusterLoadAssignments(configDump)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []proxyConfigRow
+	for _, assignment := range assignments {
+		if filter.cluster != "" && filter.cluster != assignment.ClusterName {
+			continue
+		}
+		for _, locality := range assignment.Endpoints {
+			for _, lbEndpoint := range locality.LBEndpoints {
+				address := lbEndpoint.Endpoint.Address.SocketAddress
+				if filter.port != 0 && filter.port != address.PortValue {
+					continue
+				}
+				health := lbEndpoint.HealthStatus
+				if health == "" {
+					health = "UNKNOWN"
+				}
+				rows = append(rows, endpointRow{
+					Cluster: assignment.ClusterName,
+					Address: address.Address,
+					Port:    address.PortValue,
+					Health:  health,
+				})
+			}
+		}
+	}
+	return rows, nil
+}
+
+// getSecrets returns the static and active dynamic secrets of the given config dump
+func getSecrets(configDump []byte) ([]envoySecret, error) {
+	var dump envoySecretsConfigDump
+	if err := decodeConfigDump(configDump, secretsConfigDumpType, &dump); err != nil {
+		return nil, err
+	}
+	return append(dump.StaticSecrets, dump.DynamicActiveSecrets...), nil
+}
+
+func getSecretRows(configDump []byte, _ proxyConfigFilter) ([]proxyConfigRow, error) {
+	secrets, err := getSecrets(configDump)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []proxyConfigRow
+	for _, secret := range secrets {
+		row := secretRow{
+			Name:    secret.Name,
+			Version: secret.VersionInfo,
+		}
+		if !secret.LastUpdated.IsZero() {
+			lastUpdated := secret.LastUpdated
+			row.LastUpdated = &lastUpdated
+		}
+
+		var certs []byte
+		switch {
+		case secret.Secret.TLSCertificate != nil:
+			row.Type = "tls_certificate"
+			certs = secret.Secret.TLSCertificate.CertificateChain.InlineBytes
+		case secret.Secret.ValidationContext != nil:
+			row.Type = "validation_context"
+			certs = secret.Secret.ValidationContext.TrustedCA.InlineBytes
+		default:
+			row.Type = "unknown"
+		}
+		row.NotAfter = getFirstCertNotAfter(certs)
+
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// getFirstCertNotAfter returns the expiration of the first certificate of the given PEM encoded certificates,
+// or nil if it can not be parsed
+func getFirstCertNotAfter(pemCerts []byte) *time.Time {
+	block, _ := pem.Decode(pemCerts)
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return &cert.NotAfter
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -22,6 +22,10 @@ This command will get the Envoy proxy configuration for the given query and pod.
 The query is forwarded as is to the Envoy proxy sidecar.
 Refer to https://www.envoyproxy.io/docs/envoy/latest/operations/admin for the
 list of supported GET queries.
+
+The listener-table, cluster-table, route-table, endpoint-table and
+secret-table subcommands parse the proxy's config dump and render it as a
+table, JSON or YAML.
 `
 
 const getCmdExample = `
@@ -30,6 +34,9 @@ osm proxy get config_dump bookbuyer-5ccf77f46d-rc5mg -n bookbuyer
 
 # Get the cluster config for the given pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace and output to file 'clusters.txt'
 osm proxy get clusters bookbuyer-5ccf77f46d-rc5mg -n bookbuyer -f clusters.txt
+
+# List the clusters configured on the given pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace
+osm proxy get cluster-table bookbuyer-5ccf77f46d-rc5mg -n bookbuyer
 `
 
 type proxyGetCmd struct {
@@ -74,11 +81,17 @@ func newProxyGetCmd(config *action.Configuration, out io.Writer) *cobra.Command
 		Example: getCmdExample,
 	}
 
-	//add mesh name flag
+	// The namespace and local port are shared with the subcommands
+	pf := cmd.PersistentFlags()
+	pf.StringVarP(&getCmd.namespace, "namespace", "n", metav1.NamespaceDefault, "Namespace of pod")
+	pf.Uint16VarP(&getCmd.localPort, "local-port", "p", constants.EnvoyAdminPort, "Local port to use for port forwarding")
+
 	f := cmd.Flags()
-	f.StringVarP(&getCmd.namespace, "namespace", "n", metav1.NamespaceDefault, "Namespace of pod")
 	f.StringVarP(&getCmd.outFile, "file", "f", "", "File to write output to")
-	f.Uint16VarP(&getCmd.localPort, "local-port", "p", constants.EnvoyAdminPort, "Local port to use for port forwarding")
+
+	for _, resource := range proxyConfigResources {
+		cmd.AddCommand(newProxyGetConfigCmd(config, out, getCmd, resource))
+	}
 
 	return cmd
 }
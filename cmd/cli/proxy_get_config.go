@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/mesh"
+)
+
+const (
+	proxyConfigOutputTable = "table"
+	proxyConfigOutputJSON  = "json"
+	proxyConfigOutputYAML  = "yaml"
+)
+
+// proxyConfigResource is a type of resource of the proxy configuration rendered by a subcommand of 'osm proxy get'
+type proxyConfigResource struct {
+	name  string
+	short string
+
+	// command is the name of the subcommand, which differs from the Envoy admin queries such as clusters and
+	// listeners so that these queries are still forwarded as is by 'osm proxy get'
+	command string
+
+	// query is the Envoy admin query returning the config dump the resources are parsed from
+	query   string
+	headers []string
+
+	// portFilter and clusterFilter are whether the resources can be filtered by port and cluster
+	portFilter    bool
+	clusterFilter bool
+
+	getRows func(configDump []byte, filter proxyConfigFilter) ([]proxyConfigRow, error)
+}
+
+var proxyConfigResources = []proxyConfigResource{
+	{
+		name:       "listeners",
+		command:    "listener-table",
+		short:      "list the listeners and filter chains of the proxy",
+		query:      "config_dump",
+		headers:    []string{"NAME", "ADDRESS", "PORT", "FILTER CHAIN", "MATCH", "FILTERS"},
+		portFilter: true,
+		getRows:    getListenerRows,
+	},
+	{
+		name:          "clusters",
+		command:       "cluster-table",
+		short:         "list the clusters of the proxy",
+		query:         "config_dump",
+		headers:       []string{"NAME", "TYPE", "LB POLICY", "SNI"},
+		clusterFilter: true,
+		getRows:       getClusterRows,
+	},
+	{
+		name:          "routes",
+		command:       "route-table",
+		short:         "list the HTTP routes of the proxy",
+		query:         "config_dump",
+		headers:       []string{"ROUTE CONFIG", "VIRTUAL HOST", "DOMAINS", "MATCH", "DESTINATIONS"},
+		clusterFilter: true,
+		getRows:       getRouteRows,
+	},
+	{
+		name:          "endpoints",
+		command:       "endpoint-table",
+		short:         "list the endpoints of the clusters of the proxy",
+		query:         "config_dump?include_eds",
+		headers:       []string{"CLUSTER", "ADDRESS", "PORT", "HEALTH"},
+		portFilter:    true,
+		clusterFilter: true,
+		getRows:       getEndpointRows,
+	},
+	{
+		name:    "secrets",
+		command: "secret-table",
+		short:   "list the certificates and validation contexts of the proxy",
+		query:   "config_dump",
+		headers: []string{"NAME", "TYPE", "VERSION", "LAST UPDATED", "NOT AFTER"},
+		getRows: getSecretRows,
+	},
+}
+
+type proxyGetConfigCmd struct {
+	out      io.Writer
+	getCmd   *proxyGetCmd
+	resource proxyConfigResource
+	pod      string
+	output   string
+	allPods  bool
+	selector string
+	filter   proxyConfigFilter
+
+	// listPods returns the names of the running meshed pods matching the given label selector in the given namespace
+	listPods func(namespace, selector string) ([]string, error)
+
+	// getConfig returns the response of the proxy of the given pod to the given Envoy admin query
+	getConfig func(namespace, pod, query string) ([]byte, error)
+}
+
+func newProxyGetConfigCmd(config *action.Configuration, out io.Writer, getCmd *proxyGetCmd, resource proxyConfigResource) *cobra.Command {
+	configCmd := &proxyGetConfigCmd{
+		out:      out,
+		getCmd:   getCmd,
+		resource: resource,
+	}
+
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [POD]", resource.command),
+		Short: resource.short,
+		Long: fmt.Sprintf(`
+This command parses the config dump of the Envoy proxy sidecar of the given pod
+and lists its %s. With --all-pods, the %s of the proxies of all the pods
+matching the label selector in the namespace are listed.
+`, resource.name, resource.name),
+		Args: func(c *cobra.Command, args []string) error {
+			if configCmd.allPods {
+				return cobra.NoArgs(c, args)
+			}
+			return cobra.ExactArgs(1)(c, args)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				configCmd.pod = args[0]
+			}
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+
+			clientset, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+
+			configCmd.listPods = func(namespace, selector string) ([]string, error) {
+				return listMeshedPods(clientset, namespace, selector)
+			}
+			configCmd.getConfig = func(namespace, pod, query string) ([]byte, error) {
+				return cli.GetEnvoyProxyConfig(clientset, conf, namespace, pod, getCmd.localPort, query)
+			}
+			return configCmd.run()
+		},
+		Example: fmt.Sprintf(`
+# List the %[1]s of the proxy of the pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace
+osm proxy get %[2]s bookbuyer-5ccf77f46d-rc5mg -n bookbuyer
+
+# List the %[1]s of the proxies of the pods labelled 'app=bookbuyer' in the 'bookbuyer' namespace in YAML
+osm proxy get %[2]s --all-pods -l app=bookbuyer -n bookbuyer -o yaml
+`, resource.name, resource.command),
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&configCmd.output, "output", "o", proxyConfigOutputTable, "Output format, one of: table|json|yaml")
+	f.BoolVar(&configCmd.allPods, "all-pods", false, "List the resources of the proxies of all the pods matching the label selector")
+	f.StringVarP(&configCmd.selector, "selector", "l", "", "Label selector of the pods, used with --all-pods")
+	if resource.portFilter {
+		f.Uint32Var(&configCmd.filter.port, "port", 0, "Only list the resources matching the given port")
+	}
+	if resource.clusterFilter {
+		f.StringVar(&configCmd.filter.cluster, "cluster", "", "Only list the resources of the given cluster")
+	}
+
+	return cmd
+}
+
+func (cmd *proxyGetConfigCmd) run() error {
+	switch cmd.output {
+	case proxyConfigOutputTable, proxyConfigOutputJSON, proxyConfigOutputYAML:
+	default:
+		return errors.Errorf("Invalid output format %q, expected one of: %s|%s|%s", cmd.output, proxyConfigOutputTable, proxyConfigOutputJSON, proxyConfigOutputYAML)
+	}
+
+	pods := []string{cmd.pod}
+	if cmd.allPods {
+		var err error
+		if pods, err = cmd.listPods(cmd.getCmd.namespace, cmd.selector); err != nil {
+			return err
+		}
+		if len(pods) == 0 {
+			return errors.Errorf("No running meshed pods found in namespace %s matching selector %q", cmd.getCmd.namespace, cmd.selector)
+		}
+	}
+
+	rowsByPod := make(map[string][]proxyConfigRow)
+	for _, pod := range pods {
+		configDump, err := cmd.getConfig(cmd.getCmd.namespace, pod, cmd.resource.query)
+		if err != nil {
+			return err
+		}
+		rows, err := cmd.resource.getRows(configDump, cmd.filter)
+		if err != nil {
+			return errors.Errorf("Error getting the %s of pod %s in namespace %s: %s", cmd.resource.name, pod, cmd.getCmd.namespace, err)
+		}
+		rowsByPod[pod] = rows
+	}
+
+	if cmd.output != proxyConfigOutputTable {
+		// The resources of all the pods are keyed by pod name
+		var resources interface{} = rowsByPod
+		if !cmd.allPods {
+			resources = rowsByPod[cmd.pod]
+		}
+		out, err := json.MarshalIndent(resources, "", "  ")
+		if err == nil && cmd.output == proxyConfigOutputYAML {
+			out, err = yaml.JSONToYAML(out)
+		}
+		if err != nil {
+			return errors.Errorf("Error rendering the %s: %s", cmd.resource.name, err)
+		}
+		fmt.Fprintln(cmd.out, string(out))
+		return nil
+	}
+
+	w := newTabWriter(cmd.out)
+	headers := cmd.resource.headers
+	if cmd.allPods {
+		headers = append([]string{"POD"}, headers...)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, pod := range pods {
+		for _, row := range rowsByPod[pod] {
+			columns := row.columns()
+			if cmd.allPods {
+				columns = append([]string{pod}, columns...)
+			}
+			fmt.Fprintln(w, strings.Join(columns, "\t"))
+		}
+	}
+	return w.Flush()
+}
+
+// listMeshedPods returns the names of the running meshed pods matching the given label selector in the given namespace
+func listMeshedPods(clientSet kubernetes.Interface, namespace, selector string) ([]string, error) {
+	podList, err := clientSet.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Errorf("Error listing pods in namespace %s: %s", namespace, err)
+	}
+
+	var pods []string
+	for _, pod := range podList.Items {
+		if mesh.ProxyLabelExists(pod) && pod.Status.Phase == corev1.PodRunning {
+			pods = append(pods, pod.Name)
+		}
+	}
+	sort.Strings(pods)
+	return pods, nil
+}
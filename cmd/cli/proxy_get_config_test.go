@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/action"
+)
+
+const testConfigDump = `{"configs": [
+	{"@type": "type.googleapis.com/envoy.admin.v3.BootstrapConfigDump", "bootstrap": {"node": {"id": "bookbuyer"}}},
+	{"@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+	 "static_clusters": [{"cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "envoy-admin-cluster"}}],
+	 "dynamic_active_clusters": [
+		{"version_info": "1", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "bookstore/bookstore-v1", "type": "EDS",
+		 "transport_socket": {"name": "envoy.transport_sockets.tls", "typed_config": {"@type": "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext", "sni": "bookstore-v1.bookstore.svc.cluster.local"}}}},
+		{"version_info": "1", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "passthrough-outbound", "type": "ORIGINAL_DST", "lb_policy": "CLUSTER_PROVIDED"}}
+	 ]},
+	{"@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump",
+	 "dynamic_listeners": [
+		{"name": "outbound-listener", "active_state": {"version_info": "1", "listener": {"@type": "type.googleapis.com/envoy.config.listener.v3.Listener", "name": "outbound-listener",
+		 "address": {"socket_address": {"address": "0.0.0.0", "port_value": 15001}},
+		 "filter_chains": [
+			{"name": "outbound_bookstore/bookstore-v1_14001_http", "filter_chain_match": {"destination_port": 14001, "prefix_ranges": [{"address_prefix": "10.0.0.1", "prefix_len": 32}]},
+			 "filters": [{"name": "envoy.filters.network.http_connection_manager", "typed_config": {"@type": "type.googleapis.com/some.unknown.Type"}}]}
+		 ],
+		 "default_filter_chain": {"name": "outbound-egress-filter-chain", "filters": [{"name": "envoy.filters.network.tcp_proxy"}]}}}},
+		{"name": "warming-listener", "warming_state": {"listener": {"name": "warming-listener"}}}
+	 ]},
+	{"@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump",
+	 "dynamic_route_configs": [
+		{"route_config": {"@type": "type.googleapis.com/envoy.config.route.v3.RouteConfiguration", "name": "rds-outbound.14001",
+		 "virtual_hosts": [{"name": "outbound_virtual-host|bookstore", "domains": ["bookstore", "bookstore:14001"],
+		  "routes": [
+			{"match": {"safe_regex": {"regex": ".*"}, "headers": [{"name": ":method", "safe_regex_match": {"regex": ".*"}}]},
+			 "route": {"weighted_clusters": {"clusters": [{"name": "bookstore/bookstore-v1", "weight": 90}, {"name": "bookstore/bookstore-v2", "weight": 10}]}}},
+			{"match": {"prefix": "/healthz"}, "direct_response": {"status": 200}}
+		  ]}]}}
+	 ]},
+	{"@type": "type.googleapis.com/envoy.admin.v3.SecretsConfigDump",
+	 "dynamic_active_secrets": [
+		{"name": "service-cert:bookbuyer/bookbuyer", "version_info": "2", "last_updated": "2021-06-01T10:00:00Z",
+		 "secret": {"@type": "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret", "name": "service-cert:bookbuyer/bookbuyer",
+		  "tls_certificate": {"certificate_chain": {"inline_bytes": "%s"}, "private_key": {"inline_bytes": "W3JlZGFjdGVkXQ=="}}}}
+	 ]}
+]}`
+
+const testEndpointsConfigDump = `{"configs": [
+	{"@type": "type.googleapis.com/envoy.admin.v3.EndpointsConfigDump",
+	 "dynamic_endpoint_configs": [
+		{"endpoint_config": {"@type": "type.googleapis.com/envoy.config.endpoint.v3.ClusterLoadAssignment", "cluster_name": "bookstore/bookstore-v1",
+		 "endpoints": [{"lb_endpoints": [
+			{"endpoint": {"address": {"socket_address": {"address": "10.0.0.10", "port_value": 14001}}}, "health_status": "HEALTHY"},
+			{"endpoint": {"address": {"socket_address": {"address": "10.0.0.11", "port_value": 14001}}}}
+		 ]}]}},
+		{"endpoint_config": {"@type": "type.googleapis.com/envoy.config.endpoint.v3.ClusterLoadAssignment", "cluster_name": "bookstore/bookstore-v2",
+		 "endpoints": [{"lb_endpoints": [{"endpoint": {"address": {"socket_address": {"address": "10.0.0.12", "port_value": 14001}}}}]}]}}
+	 ]}
+]}`
+
+func TestProxyGetConfigRun(t *testing.T) {
+	notAfter := time.Date(2031, 6, 1, 10, 0, 0, 0, time.UTC)
+	configDump := fmt.Sprintf(testConfigDump, base64.StdEncoding.EncodeToString(newTestCertPEM(t, notAfter)))
+
+	resources := make(map[string]proxyConfigResource)
+	for _, resource := range proxyConfigResources {
+		resources[resource.name] = resource
+	}
+
+	testCases := []struct {
+		name          string
+		resource      string
+		output        string
+		allPods       bool
+		filter        proxyConfigFilter
+		pods          []string
+		expectedQuery string
+		expected      string
+		expectedErr   bool
+	}{
+		{
+			name:          "listeners",
+			resource:      "listeners",
+			output:        "table",
+			expectedQuery: "config_dump",
+			expected: "NAME                ADDRESS   PORT    FILTER CHAIN                                 MATCH        FILTERS\n" +
+				"outbound-listener   0.0.0.0   15001   outbound_bookstore/bookstore-v1_14001_http   port=14001   envoy.filters.network.http_connection_manager\n" +
+				"outbound-listener   0.0.0.0   15001   outbound-egress-filter-chain                 -            envoy.filters.network.tcp_proxy\n",
+		},
+		{
+			name:          "listeners filtered by port",
+			resource:      "listeners",
+			output:        "table",
+			filter:        proxyConfigFilter{port: 14001},
+			expectedQuery: "config_dump",
+			expected: "NAME                ADDRESS   PORT    FILTER CHAIN                                 MATCH        FILTERS\n" +
+				"outbound-listener   0.0.0.0   15001   outbound_bookstore/bookstore-v1_14001_http   port=14001   envoy.filters.network.http_connection_manager\n",
+		},
+		{
+			name:          "clusters",
+			resource:      "clusters",
+			output:        "table",
+			expectedQuery: "config_dump",
+			expected: "NAME                     TYPE           LB POLICY          SNI\n" +
+				"bookstore/bookstore-v1   EDS            ROUND_ROBIN        bookstore-v1.bookstore.svc.cluster.local\n" +
+				"envoy-admin-cluster      STATIC         ROUND_ROBIN        -\n" +
+				"passthrough-outbound     ORIGINAL_DST   CLUSTER_PROVIDED   -\n",
+		},
+		{
+			name:          "clusters filtered by cluster in JSON",
+			resource:      "clusters",
+			output:        "json",
+			filter:        proxyConfigFilter{cluster: "passthrough-outbound"},
+			expectedQuery: "config_dump",
+			expected:      "[\n  {\n    \"name\": \"passthrough-outbound\",\n    \"type\": \"ORIGINAL_DST\",\n    \"lbPolicy\": \"CLUSTER_PROVIDED\"\n  }\n]\n",
+		},
+		{
+			name:          "routes",
+			resource:      "routes",
+			output:        "table",
+			expectedQuery: "config_dump",
+			expected: "ROUTE CONFIG         VIRTUAL HOST                      DOMAINS                     MATCH                 DESTINATIONS\n" +
+				"rds-outbound.14001   outbound_virtual-host|bookstore   bookstore,bookstore:14001   regex=.* :method~.*   bookstore/bookstore-v1:90,bookstore/bookstore-v2:10\n" +
+				"rds-outbound.14001   outbound_virtual-host|bookstore   bookstore,bookstore:14001   prefix=/healthz       -\n",
+		},
+		{
+			name:          "routes filtered by cluster",
+			resource:      "routes",
+			output:        "table",
+			filter:        proxyConfigFilter{cluster: "bookstore/bookstore-v3"},
+			expectedQuery: "config_dump",
+			expected:      "ROUTE CONFIG   VIRTUAL HOST   DOMAINS   MATCH   DESTINATIONS\n",
+		},
+		{
+			name:          "secrets",
+			resource:      "secrets",
+			output:        "yaml",
+			expectedQuery: "config_dump",
+			expected: "- lastUpdated: \"2021-06-01T10:00:00Z\"\n  name: service-cert:bookbuyer/bookbuyer\n  notAfter: \"2031-06-01T10:00:00Z\"\n" +
+				"  type: tls_certificate\n  version: \"2\"\n\n",
+		},
+		{
+			name:          "endpoints of all pods filtered by cluster",
+			resource:      "endpoints",
+			output:        "table",
+			allPods:       true,
+			pods:          []string{"bookbuyer-1", "bookbuyer-2"},
+			filter:        proxyConfigFilter{cluster: "bookstore/bookstore-v1"},
+			expectedQuery: "config_dump?include_eds",
+			expected: "POD           CLUSTER                  ADDRESS     PORT    HEALTH\n" +
+				"bookbuyer-1   bookstore/bookstore-v1   10.0.0.10   14001   HEALTHY\n" +
+				"bookbuyer-1   bookstore/bookstore-v1   10.0.0.11   14001   UNKNOWN\n" +
+				"bookbuyer-2   bookstore/bookstore-v1   10.0.0.10   14001   HEALTHY\n" +
+				"bookbuyer-2   bookstore/bookstore-v1   10.0.0.11   14001   UNKNOWN\n",
+		},
+		{
+			name:        "all pods without matching pods",
+			resource:    "endpoints",
+			output:      "table",
+			allPods:     true,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid output",
+			resource:    "clusters",
+			output:      "xml",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			out := new(bytes.Buffer)
+
+			cmd := &proxyGetConfigCmd{
+				out:      out,
+				getCmd:   &proxyGetCmd{namespace: "bookbuyer"},
+				resource: resources[tc.resource],
+				pod:      "bookbuyer-1",
+				output:   tc.output,
+				allPods:  tc.allPods,
+				selector: "app=bookbuyer",
+				filter:   tc.filter,
+				listPods: func(namespace, selector string) ([]string, error) {
+					assert.Equal("bookbuyer", namespace)
+					assert.Equal("app=bookbuyer", selector)
+					return tc.pods, nil
+				},
+				getConfig: func(namespace, pod, query string) ([]byte, error) {
+					assert.Equal("bookbuyer", namespace)
+					assert.Equal(tc.expectedQuery, query)
+					if query == "config_dump?include_eds" {
+						return []byte(testEndpointsConfigDump), nil
+					}
+					return []byte(configDump), nil
+				},
+			}
+
+			err := cmd.run()
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Equal(tc.expected, out.String())
+		})
+	}
+}
+
+func TestProxyGetConfigRunProxyError(t *testing.T) {
+	assert := tassert.New(t)
+
+	cmd := &proxyGetConfigCmd{
+		out:      new(bytes.Buffer),
+		getCmd:   &proxyGetCmd{namespace: "bookbuyer"},
+		resource: proxyConfigResources[0],
+		pod:      "bookbuyer-1",
+		output:   proxyConfigOutputTable,
+		getConfig: func(_, _, _ string) ([]byte, error) {
+			return nil, errors.New("pod not running")
+		},
+	}
+	assert.NotNil(cmd.run())
+
+	cmd.getConfig = func(_, _, _ string) ([]byte, error) {
+		return []byte("not a config dump"), nil
+	}
+	assert.NotNil(cmd.run())
+}
+
+// newTestCertPEM returns a PEM encoded self-signed certificate expiring at the given time
+func newTestCertPEM(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tassert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	tassert.Nil(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestProxyGetRawQueries(t *testing.T) {
+	getCmd := newProxyGetCmd(&action.Configuration{}, new(bytes.Buffer))
+
+	// The Envoy admin queries are forwarded as is rather than handled by a subcommand
+	for _, query := range []string{"clusters", "listeners", "config_dump", "certs"} {
+		t.Run(query, func(t *testing.T) {
+			assert := tassert.New(t)
+			cmd, args, err := getCmd.Find([]string{query, "bookbuyer-5ccf77f46d-rc5mg"})
+			assert.Nil(err)
+			assert.Equal(getCmd, cmd)
+			assert.Equal([]string{query, "bookbuyer-5ccf77f46d-rc5mg"}, args)
+		})
+	}
+
+	for _, resource := range proxyConfigResources {
+		t.Run(resource.command, func(t *testing.T) {
+			assert := tassert.New(t)
+			cmd, _, err := getCmd.Find([]string{resource.command, "bookbuyer-5ccf77f46d-rc5mg"})
+			assert.Nil(err)
+			assert.Equal(resource.command, cmd.Name())
+		})
+	}
+}
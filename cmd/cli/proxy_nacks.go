@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+const proxyNACKsDescription = `
+This command lists the sidecar proxies whose last configuration pushed by the
+osm-controller was rejected (NACKed), along with the error reported by the proxy.
+A proxy is no longer listed once it accepts (ACKs) a configuration of the same type.
+
+The information is queried from the debug server of every osm-controller replica,
+which requires the debug server to be enabled in the MeshConfig.
+`
+
+const proxyNACKsExample = `
+# List the proxies with a rejected configuration in all namespaces
+osm proxy nacks
+
+# List the proxies with a rejected configuration in the 'bookstore' namespace
+osm proxy nacks -n bookstore
+`
+
+// proxyNACKsDebugPath is the osm-controller debug server path listing the last configuration rejected by each proxy
+const proxyNACKsDebugPath = "/debug/proxy?nacks"
+
+// proxyNACK is the last configuration rejected by a proxy as reported by the osm-controller debug server
+type proxyNACK struct {
+	CommonName string      `json:"commonName"`
+	Namespace  string      `json:"namespace"`
+	Pod        string      `json:"pod"`
+	NACK       *envoy.NACK `json:"nack"`
+}
+
+type proxyNACKsCmd struct {
+	out       io.Writer
+	namespace string
+	localPort uint16
+
+	// getControllerDebugInfo returns the responses of the osm-controller replicas' debug servers for the given path
+	getControllerDebugInfo func(path string) (map[string][]byte, error)
+}
+
+func newProxyNACKsCmd(out io.Writer) *cobra.Command {
+	nacksCmd := &proxyNACKsCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "nacks",
+		Short: "list proxies with a rejected configuration",
+		Long:  proxyNACKsDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+
+			nacksCmd.getControllerDebugInfo = func(path string) (map[string][]byte, error) {
+				return cli.GetControllerDebugInfo(clientset, config, settings.Namespace(), nacksCmd.localPort, path)
+			}
+
+			return nacksCmd.run()
+		},
+		Example: proxyNACKsExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&nacksCmd.namespace, "namespace", "n", "", "Namespace of pods, all namespaces if unspecified")
+	f.Uint16VarP(&nacksCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (cmd *proxyNACKsCmd) run() error {
+	responses, err := cmd.getControllerDebugInfo(proxyNACKsDebugPath)
+	if err != nil {
+		return err
+	}
+
+	var nacks []proxyNACK
+	for controller, response := range responses {
+		var controllerNACKs []proxyNACK
+		if err := json.Unmarshal(response, &controllerNACKs); err != nil {
+			return errors.Errorf("Error parsing the proxy NACKs reported by %s: %s", controller, err)
+		}
+		for _, nack := range controllerNACKs {
+			if nack.NACK == nil || (cmd.namespace != "" && nack.Namespace != cmd.namespace) {
+				continue
+			}
+			nacks = append(nacks, nack)
+		}
+	}
+
+	if len(nacks) == 0 {
+		fmt.Fprintln(cmd.out, "No proxies with a rejected configuration found")
+		return nil
+	}
+
+	sort.Slice(nacks, func(i, j int) bool {
+		if nacks[i].Namespace != nacks[j].Namespace {
+			return nacks[i].Namespace < nacks[j].Namespace
+		}
+		if nacks[i].Pod != nacks[j].Pod {
+			return nacks[i].Pod < nacks[j].Pod
+		}
+		return nacks[i].CommonName < nacks[j].CommonName
+	})
+
+	w := newTabWriter(cmd.out)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tTYPE\tRECEIVED\tERROR")
+	for _, nack := range nacks {
+		pod := nack.Pod
+		if pod == "" {
+			pod = nack.CommonName
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", nack.Namespace, pod, nack.NACK.TypeURI.Short(),
+			nack.NACK.Timestamp.Format(time.RFC3339), nack.NACK.Error)
+	}
+	return w.Flush()
+}
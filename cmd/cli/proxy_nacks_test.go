@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestProxyNACKsRun(t *testing.T) {
+	testCases := []struct {
+		name        string
+		namespace   string
+		responses   map[string][]byte
+		err         error
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name: "no NACKs",
+			responses: map[string][]byte{
+				"osm-controller-1": []byte(`[]`),
+			},
+			expected: "No proxies with a rejected configuration found\n",
+		},
+		{
+			name: "NACKs across controller replicas",
+			responses: map[string][]byte{
+				"osm-controller-1": []byte(`[{"commonName":"a.sidecar.bookstore.bookstore","namespace":"bookstore","pod":"bookstore",` +
+					`"nack":{"typeURI":"type.googleapis.com/envoy.config.cluster.v3.Cluster","error":"invalid cluster","timestamp":"2021-06-01T10:00:00Z"}}]`),
+				"osm-controller-2": []byte(`[{"commonName":"b.sidecar.bookbuyer.bookbuyer","namespace":"bookbuyer","pod":"bookbuyer",` +
+					`"nack":{"typeURI":"type.googleapis.com/envoy.config.route.v3.RouteConfiguration","error":"invalid route","timestamp":"2021-06-01T11:00:00Z"}}]`),
+			},
+			expected: "NAMESPACE   POD         TYPE   RECEIVED               ERROR\n" +
+				"bookbuyer   bookbuyer   RDS    2021-06-01T11:00:00Z   invalid route\n" +
+				"bookstore   bookstore   CDS    2021-06-01T10:00:00Z   invalid cluster\n",
+		},
+		{
+			name:      "NACKs filtered by namespace",
+			namespace: "bookstore",
+			responses: map[string][]byte{
+				"osm-controller-1": []byte(`[{"commonName":"b.sidecar.bookbuyer.bookbuyer","namespace":"bookbuyer","pod":"bookbuyer",` +
+					`"nack":{"typeURI":"type.googleapis.com/envoy.config.route.v3.RouteConfiguration","error":"invalid route","timestamp":"2021-06-01T11:00:00Z"}}]`),
+			},
+			expected: "No proxies with a rejected configuration found\n",
+		},
+		{
+			name:        "debug server unreachable",
+			err:         errors.New("debug server disabled"),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			out := new(bytes.Buffer)
+
+			cmd := &proxyNACKsCmd{
+				out:       out,
+				namespace: tc.namespace,
+				getControllerDebugInfo: func(path string) (map[string][]byte, error) {
+					assert.Equal(proxyNACKsDebugPath, path)
+					return tc.responses, tc.err
+				},
+			}
+
+			err := cmd.run()
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Equal(tc.expected, out.String())
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	osmConfigClient "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/mesh"
+)
+
+const proxyOutdatedDescription = `
+This command lists the meshed pods running a sidecar that is out of date with
+the sidecar images configured in the MeshConfig. Such pods need to be restarted
+for the configured sidecar images to take effect.
+`
+
+const proxyOutdatedExample = `
+# List the pods with an outdated sidecar in all namespaces
+osm proxy outdated
+
+# List the pods with an outdated sidecar in the 'bookstore' namespace
+osm proxy outdated -n bookstore
+`
+
+type proxyOutdatedCmd struct {
+	out              io.Writer
+	namespace        string
+	clientSet        kubernetes.Interface
+	meshConfigClient osmConfigClient.Interface
+}
+
+func newProxyOutdatedCmd(out io.Writer) *cobra.Command {
+	outdatedCmd := &proxyOutdatedCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "list pods with an outdated sidecar",
+		Long:  proxyOutdatedDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+			outdatedCmd.clientSet = clientset
+
+			configClient, err := osmConfigClient.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not initialize OSM Config client: %s", err)
+			}
+			outdatedCmd.meshConfigClient = configClient
+
+			return outdatedCmd.run()
+		},
+		Example: proxyOutdatedExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&outdatedCmd.namespace, "namespace", "n", "", "Namespace of pods, all namespaces if unspecified")
+
+	return cmd
+}
+
+func (cmd *proxyOutdatedCmd) run() error {
+	osmNamespace := settings.Namespace()
+	meshConfig, err := cmd.meshConfigClient.ConfigV1alpha1().MeshConfigs(osmNamespace).Get(context.TODO(), defaultOsmMeshConfigName, metav1.GetOptions{})
+	if err != nil {
+		return annotateErrorMessageWithOsmNamespace("Error fetching MeshConfig %s: %s", defaultOsmMeshConfigName, err)
+	}
+	envoyImage, envoyWindowsImage, initContainerImage := getSidecarImages(meshConfig.Spec.Sidecar)
+
+	pods, err := cmd.clientSet.CoreV1().Pods(cmd.namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: constants.EnvoyUniqueIDLabelName,
+	})
+	if err != nil {
+		return errors.Errorf("Error listing meshed pods: %s", err)
+	}
+
+	w := newTabWriter(cmd.out)
+	var found bool
+	for _, pod := range pods.Items {
+		expectedVersion := mesh.ExpectedSidecarVersion(pod, envoyImage, envoyWindowsImage, initContainerImage)
+		if !mesh.IsSidecarStale(pod, expectedVersion) {
+			continue
+		}
+		if !found {
+			fmt.Fprintln(w, "NAMESPACE\tPOD\tINJECTED\tEXPECTED")
+			found = true
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", pod.Namespace, pod.Name, mesh.InjectedSidecarVersion(pod), expectedVersion)
+	}
+	_ = w.Flush()
+
+	if !found {
+		fmt.Fprintln(cmd.out, "No pods with an outdated sidecar found")
+	}
+	return nil
+}
+
+// getSidecarImages returns the Envoy, Envoy Windows and init container images configured in the given
+// sidecar spec, falling back to the defaults used by the control plane when unset
+func getSidecarImages(spec configv1alpha1.SidecarSpec) (envoyImage, envoyWindowsImage, initContainerImage string) {
+	envoyImage, envoyWindowsImage, initContainerImage = spec.EnvoyImage, spec.EnvoyWindowsImage, spec.InitContainerImage
+	if envoyImage == "" {
+		envoyImage = constants.DefaultEnvoyImage
+	}
+	if envoyWindowsImage == "" {
+		envoyWindowsImage = constants.DefaultEnvoyWindowsImage
+	}
+	if initContainerImage == "" {
+		initContainerImage = constants.DefaultInitContainerImage
+	}
+	return
+}
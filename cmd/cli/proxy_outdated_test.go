@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fakeConfig "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestProxyOutdatedRun(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pods     []*corev1.Pod
+		expected string
+	}{
+		{
+			name: "no outdated sidecars",
+			pods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "bookstore",
+						Namespace:   "bookstore",
+						Labels:      map[string]string{constants.EnvoyUniqueIDLabelName: uuid.New().String()},
+						Annotations: map[string]string{constants.SidecarVersionAnnotation: "envoy:v2,init:v2"},
+					},
+				},
+			},
+			expected: "No pods with an outdated sidecar found\n",
+		},
+		{
+			name: "outdated sidecar",
+			pods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "bookstore",
+						Namespace:   "bookstore",
+						Labels:      map[string]string{constants.EnvoyUniqueIDLabelName: uuid.New().String()},
+						Annotations: map[string]string{constants.SidecarVersionAnnotation: "envoy:v1,init:v2"},
+					},
+				},
+			},
+			expected: "NAMESPACE   POD         INJECTED           EXPECTED\n" +
+				"bookstore   bookstore   envoy:v1,init:v2   envoy:v2,init:v2\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			fakeK8sClient := fake.NewSimpleClientset()
+			fakeConfigClient := fakeConfig.NewSimpleClientset()
+			out := new(bytes.Buffer)
+
+			_, err := fakeConfigClient.ConfigV1alpha1().MeshConfigs(settings.Namespace()).Create(context.TODO(), &v1alpha1.MeshConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: defaultOsmMeshConfigName,
+				},
+				Spec: v1alpha1.MeshConfigSpec{
+					Sidecar: v1alpha1.SidecarSpec{
+						EnvoyImage:         "envoy:v2",
+						InitContainerImage: "init:v2",
+					},
+				},
+			}, metav1.CreateOptions{})
+			assert.Nil(err)
+
+			for _, pod := range tc.pods {
+				_, err := fakeK8sClient.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+				assert.Nil(err)
+			}
+
+			cmd := proxyOutdatedCmd{
+				out:              out,
+				clientSet:        fakeK8sClient,
+				meshConfigClient: fakeConfigClient,
+			}
+
+			assert.Nil(cmd.run())
+			assert.Equal(tc.expected, out.String())
+		})
+	}
+}
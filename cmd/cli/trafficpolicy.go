@@ -19,6 +19,7 @@ func newTrafficPolicyCmd(out io.Writer) *cobra.Command {
 		Args:  cobra.NoArgs,
 	}
 	cmd.AddCommand(newTrafficPolicyCheck(out))
+	cmd.AddCommand(newTrafficPolicySimulate(out))
 
 	return cmd
 }
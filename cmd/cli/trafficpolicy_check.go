@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -11,9 +15,14 @@ import (
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
 	osmConfigClient "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 )
 
@@ -21,6 +30,16 @@ const trafficPolicyCheckDescription = `
 This command will check whether a given source pod is allowed to communicate
 (send traffic) to a given destination pod by an SMI TrafficTarget policy or
 in lieu of the mesh operating in permissive traffic policy mode.
+
+With any of the --port, --path, --method or --header flags, the request is
+evaluated against the traffic policies the same way the osm-controller
+programs the Envoy proxies: for each Service selecting the destination pod,
+the outbound routes and TrafficSplit backends of the source, and the
+TrafficTargets, HTTP routes and TCP routes of the destination are checked, and
+the rule allowing or denying the request is reported for the backends the
+destination pod receives it on. This evaluation is queried from the debug
+server of an osm-controller replica, which requires the debug server to be
+enabled in the MeshConfig.
 `
 
 const trafficPolicyCheckExample = `
@@ -30,12 +49,18 @@ osm policy check-pods bookbuyer/bookbuyer-client bookstore/bookstore-server
 # If the pod belongs to the default namespace, the namespace can be omitted with the flags
 # To check if pod 'bookbuyer-client' in the 'default' namespace can send traffic to pod 'bookstore-server' in the 'default' namespace
 osm policy check-pods bookbuyer-client bookstore-server
+
+# To check if pod 'bookbuyer-client' in the 'bookbuyer' namespace can send GET /books requests with the 'user-agent: curl' header to pod 'bookstore-server' in the 'bookstore' namespace on port 14001
+osm policy check-pods bookbuyer/bookbuyer-client bookstore/bookstore-server --port 14001 --method GET --path /books --header user-agent=curl
 `
 
 const (
 	namespaceSeparator       = "/"
 	defaultOsmMeshConfigName = "osm-mesh-config"
 	serviceAccountKind       = "ServiceAccount"
+
+	// trafficCheckDebugPath is the osm-controller debug server path evaluating a request against the traffic policies
+	trafficCheckDebugPath = "/debug/traffic-check"
 )
 
 type trafficPolicyCheckCmd struct {
@@ -46,6 +71,17 @@ type trafficPolicyCheckCmd struct {
 	smiAccessClient  smiAccessClient.Interface
 	meshConfigClient osmConfigClient.Interface
 	restConfig       *rest.Config
+
+	// checkRoutes is true when the request is evaluated against the routes of the traffic policies
+	checkRoutes bool
+	port        uint16
+	method      string
+	path        string
+	headers     []string
+	localPort   uint16
+
+	// getControllerDebugInfo returns the response of an osm-controller replica's debug server for the given path
+	getControllerDebugInfo func(path string) ([]byte, error)
 }
 
 func newTrafficPolicyCheck(out io.Writer) *cobra.Command {
@@ -58,9 +94,12 @@ func newTrafficPolicyCheck(out io.Writer) *cobra.Command {
 		Short: "check-pods traffic policy",
 		Long:  trafficPolicyCheckDescription,
 		Args:  cobra.ExactArgs(2),
-		RunE: func(_ *cobra.Command, args []string) error {
+		RunE: func(c *cobra.Command, args []string) error {
 			trafficPolicyCheckCmd.sourcePod = args[0]
 			trafficPolicyCheckCmd.destinationPod = args[1]
+			for _, flag := range []string{"port", "method", "path", "header"} {
+				trafficPolicyCheckCmd.checkRoutes = trafficPolicyCheckCmd.checkRoutes || c.Flags().Changed(flag)
+			}
 
 			config, err := settings.RESTClientGetter().ToRESTConfig()
 			if err != nil {
@@ -87,11 +126,23 @@ func newTrafficPolicyCheck(out io.Writer) *cobra.Command {
 			}
 			trafficPolicyCheckCmd.meshConfigClient = configClient
 
+			trafficPolicyCheckCmd.getControllerDebugInfo = func(path string) ([]byte, error) {
+				// Every osm-controller replica evaluates the request against the same policies in the cluster
+				return cli.GetControllerReplicaDebugInfo(clientset, config, settings.Namespace(), trafficPolicyCheckCmd.localPort, path)
+			}
+
 			return trafficPolicyCheckCmd.run()
 		},
 		Example: trafficPolicyCheckExample,
 	}
 
+	f := cmd.Flags()
+	f.Uint16Var(&trafficPolicyCheckCmd.port, "port", 0, "Port of the destination service the request is sent to, required when the service has several ports")
+	f.StringVar(&trafficPolicyCheckCmd.method, "method", "GET", "HTTP method of the request")
+	f.StringVar(&trafficPolicyCheckCmd.path, "path", "/", "HTTP path of the request")
+	f.StringArrayVar(&trafficPolicyCheckCmd.headers, "header", nil, "HTTP header of the request, of the form <name=value>, can be repeated")
+	f.Uint16VarP(&trafficPolicyCheckCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
 	return cmd
 }
 
@@ -116,6 +167,9 @@ func (cmd *trafficPolicyCheckCmd) run() error {
 		return err
 	}
 
+	if cmd.checkRoutes {
+		return cmd.checkTrafficRoutes(srcPod, dstPod)
+	}
 	return cmd.checkTrafficPolicy(srcPod, dstPod)
 }
 
@@ -173,6 +227,140 @@ func (cmd *trafficPolicyCheckCmd) checkTrafficPolicy(srcPod, dstPod *corev1.Pod)
 	return nil
 }
 
+// checkTrafficRoutes evaluates the request against the traffic policies for each Service selecting the destination pod
+func (cmd *trafficPolicyCheckCmd) checkTrafficRoutes(srcPod, dstPod *corev1.Pod) error {
+	headers, err := parseHTTPHeaders(cmd.headers)
+	if err != nil {
+		return err
+	}
+
+	services, err := cmd.listServicesSelectingPod(dstPod)
+	if err != nil {
+		return err
+	}
+	if len(services) == 0 {
+		fmt.Fprintf(cmd.out, "[+] Pod '%s/%s' is not allowed to receive traffic from pod '%s/%s', no Service selects it\n",
+			dstPod.Namespace, dstPod.Name, srcPod.Namespace, srcPod.Name)
+		return nil
+	}
+
+	for i, svc := range services {
+		if i > 0 {
+			fmt.Fprintln(cmd.out)
+		}
+		if cmd.port != 0 && !hasServicePort(svc, cmd.port) {
+			fmt.Fprintf(cmd.out, "[+] Service '%s/%s' selects pod '%s/%s' but has no port %d\n", svc.Namespace, svc.Name, dstPod.Namespace, dstPod.Name, cmd.port)
+			continue
+		}
+
+		query := url.Values{}
+		query.Set("source", fmt.Sprintf("%s/%s", srcPod.Namespace, srcPod.Spec.ServiceAccountName))
+		query.Set("destination", fmt.Sprintf("%s/%s", svc.Namespace, svc.Name))
+		if cmd.port != 0 {
+			query.Set("port", strconv.Itoa(int(cmd.port)))
+		}
+		query.Set("method", cmd.method)
+		query.Set("path", cmd.path)
+		for name, value := range headers {
+			query.Add("header", fmt.Sprintf("%s=%s", name, value))
+		}
+
+		decision, err := cmd.getTrafficDecision(query)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.out, "[+] Request %s %s from pod '%s/%s' to service '%s/%s', which selects pod '%s/%s'\n",
+			cmd.method, cmd.path, srcPod.Namespace, srcPod.Name, svc.Namespace, svc.Name, dstPod.Namespace, dstPod.Name)
+		if err := printTrafficDecision(cmd.out, getPodTrafficDecision(decision, services, dstPod)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getTrafficDecision returns the evaluation of the request described by the given query by the osm-controller
+func (cmd *trafficPolicyCheckCmd) getTrafficDecision(query url.Values) (catalog.TrafficDecision, error) {
+	var decision catalog.TrafficDecision
+
+	response, err := cmd.getControllerDebugInfo(fmt.Sprintf("%s?%s", trafficCheckDebugPath, query.Encode()))
+	if err != nil {
+		return decision, err
+	}
+	if err := json.Unmarshal(response, &decision); err != nil {
+		return decision, errors.Errorf("Error parsing the traffic decision reported by %s: %s", constants.OSMControllerName, err)
+	}
+	return decision, nil
+}
+
+// getPodTrafficDecision returns the given decision restricted to the backends the given pod receives the request on,
+// i.e. the backend services selecting the pod for the pod's service identity. The decision is returned as is when the
+// request was denied before being evaluated against the backends.
+func getPodTrafficDecision(decision catalog.TrafficDecision, podServices []corev1.Service, pod *corev1.Pod) catalog.TrafficDecision {
+	if len(decision.Backends) == 0 {
+		return decision
+	}
+
+	selecting := make(map[string]bool)
+	for _, svc := range podServices {
+		selecting[fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)] = true
+	}
+
+	podDecision := decision
+	podDecision.Backends = nil
+	podDecision.Allowed = true
+	podDecision.Reason = fmt.Sprintf("Request is allowed on every backend selecting pod %s/%s", pod.Namespace, pod.Name)
+	for _, backend := range decision.Backends {
+		svcAccount := identity.ServiceIdentity(backend.Identity).ToK8sServiceAccount()
+		if !selecting[backend.Service] || svcAccount.Name != pod.Spec.ServiceAccountName || svcAccount.Namespace != pod.Namespace {
+			continue
+		}
+		podDecision.Backends = append(podDecision.Backends, backend)
+		if !backend.Allowed && podDecision.Allowed {
+			podDecision.Allowed = false
+			podDecision.Reason = backend.Reason
+		}
+	}
+
+	if len(podDecision.Backends) == 0 {
+		podDecision.Allowed = false
+		podDecision.Reason = fmt.Sprintf("Request is not routed to a backend selecting pod %s/%s", pod.Namespace, pod.Name)
+	}
+	return podDecision
+}
+
+// listServicesSelectingPod returns the Services in the namespace of the given pod whose selector matches its labels
+func (cmd *trafficPolicyCheckCmd) listServicesSelectingPod(pod *corev1.Pod) ([]corev1.Service, error) {
+	services, err := cmd.clientSet.CoreV1().Services(pod.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Errorf("Error listing services in namespace %s: %s", pod.Namespace, err)
+	}
+
+	var selecting []corev1.Service
+	for _, svc := range services.Items {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			selecting = append(selecting, svc)
+		}
+	}
+	sort.Slice(selecting, func(i, j int) bool {
+		return selecting[i].Name < selecting[j].Name
+	})
+	return selecting, nil
+}
+
+func hasServicePort(svc corev1.Service, port uint16) bool {
+	for _, p := range svc.Spec.Ports {
+		if p.Port == int32(port) {
+			return true
+		}
+	}
+	return false
+}
+
 func (cmd *trafficPolicyCheckCmd) getMeshedPod(namespace, podName string) (*corev1.Pod, error) {
 	// Validate the pods
 	pod, err := cmd.clientSet.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
@@ -569,3 +569,161 @@ func TestCheckTrafficPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckTrafficRoutes(t *testing.T) {
+	srcPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookbuyer-1",
+			Namespace: "bookbuyer",
+			Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: "test"},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "bookbuyer",
+		},
+	}
+	dstPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookstore-1",
+			Namespace: "bookstore",
+			Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: "test", "app": "bookstore"},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "bookstore",
+		},
+	}
+	bookstoreService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookstore",
+			Namespace: "bookstore",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "bookstore"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 14001}},
+		},
+	}
+	otherService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookwarehouse",
+			Namespace: "bookstore",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "bookwarehouse"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 14001}},
+		},
+	}
+
+	testCases := []struct {
+		name                string
+		services            []*corev1.Service
+		port                uint16
+		headers             []string
+		response            string
+		expectedPath        string
+		expectError         bool
+		expectedOutSubstr   []string
+		unexpectedOutSubstr []string
+	}{
+		{
+			name:         "request allowed by an HTTP route",
+			services:     []*corev1.Service{bookstoreService, otherService},
+			port:         14001,
+			headers:      []string{"User-Agent=curl"},
+			response:     `{"allowed":true,"reason":"Request from bookbuyer.bookbuyer.cluster.local to bookstore/bookstore is allowed on every backend","port":14001,"protocol":"http","backends":[{"service":"bookstore/bookstore","weight":100,"identity":"bookstore.bookstore.cluster.local","allowed":true,"reason":"Allowed by the HTTP route GET /books","trafficTargets":["bookstore/bookbuyer-access-bookstore"],"route":{"path":"/books","methods":["GET"]}}]}`,
+			expectedPath: "/debug/traffic-check?destination=bookstore%2Fbookstore&header=user-agent%3Dcurl&method=GET&path=%2Fbooks&port=14001&source=bookbuyer%2Fbookbuyer",
+			expectedOutSubstr: []string{
+				"[+] Request GET /books from pod 'bookbuyer/bookbuyer-1' to service 'bookstore/bookstore', which selects pod 'bookstore/bookstore-1'",
+				"[+] ALLOWED: Request is allowed on every backend selecting pod bookstore/bookstore-1",
+				"bookstore/bookbuyer-access-bookstore",
+			},
+		},
+		{
+			name:         "request split across backends not all selecting the destination pod",
+			services:     []*corev1.Service{bookstoreService},
+			response:     `{"allowed":false,"reason":"No TrafficTarget allows bookbuyer.bookbuyer.cluster.local to access bookstore-v2.bookstore.cluster.local","port":14001,"protocol":"http","backends":[{"service":"bookstore/bookstore","weight":50,"identity":"bookstore.bookstore.cluster.local","allowed":true,"reason":"Allowed by the HTTP route GET /books"},{"service":"bookstore/bookstore-v2","weight":50,"identity":"bookstore-v2.bookstore.cluster.local","allowed":false,"reason":"No TrafficTarget allows bookbuyer.bookbuyer.cluster.local to access bookstore-v2.bookstore.cluster.local"}]}`,
+			expectedPath: "/debug/traffic-check?destination=bookstore%2Fbookstore&method=GET&path=%2Fbooks&source=bookbuyer%2Fbookbuyer",
+			expectedOutSubstr: []string{
+				"[+] ALLOWED: Request is allowed on every backend selecting pod bookstore/bookstore-1",
+			},
+			unexpectedOutSubstr: []string{"bookstore/bookstore-v2"},
+		},
+		{
+			name:         "request not routed to a backend selecting the destination pod",
+			services:     []*corev1.Service{bookstoreService},
+			response:     `{"allowed":true,"reason":"Request from bookbuyer.bookbuyer.cluster.local to bookstore/bookstore is allowed on every backend","port":14001,"protocol":"http","backends":[{"service":"bookstore/bookstore-v2","weight":100,"identity":"bookstore-v2.bookstore.cluster.local","allowed":true,"reason":"Allowed by the HTTP route GET /books"}]}`,
+			expectedPath: "/debug/traffic-check?destination=bookstore%2Fbookstore&method=GET&path=%2Fbooks&source=bookbuyer%2Fbookbuyer",
+			expectedOutSubstr: []string{
+				"[+] DENIED: Request is not routed to a backend selecting pod bookstore/bookstore-1",
+			},
+			unexpectedOutSubstr: []string{"bookstore/bookstore-v2"},
+		},
+		{
+			name:         "request denied",
+			services:     []*corev1.Service{bookstoreService},
+			response:     `{"allowed":false,"reason":"No TrafficTarget allows bookbuyer.bookbuyer.cluster.local to access bookstore.bookstore.cluster.local"}`,
+			expectedPath: "/debug/traffic-check?destination=bookstore%2Fbookstore&method=GET&path=%2Fbooks&source=bookbuyer%2Fbookbuyer",
+			expectedOutSubstr: []string{
+				"[+] DENIED: No TrafficTarget allows bookbuyer.bookbuyer.cluster.local to access bookstore.bookstore.cluster.local",
+			},
+		},
+		{
+			name:              "no service selects the destination pod",
+			services:          []*corev1.Service{otherService},
+			expectedOutSubstr: []string{"[+] Pod 'bookstore/bookstore-1' is not allowed to receive traffic from pod 'bookbuyer/bookbuyer-1', no Service selects it"},
+		},
+		{
+			name:              "service selecting the destination pod without the port",
+			services:          []*corev1.Service{bookstoreService},
+			port:              8080,
+			expectedOutSubstr: []string{"[+] Service 'bookstore/bookstore' selects pod 'bookstore/bookstore-1' but has no port 8080"},
+		},
+		{
+			name:         "invalid response of the debug server",
+			services:     []*corev1.Service{bookstoreService},
+			response:     `not json`,
+			expectedPath: "/debug/traffic-check?destination=bookstore%2Fbookstore&method=GET&path=%2Fbooks&source=bookbuyer%2Fbookbuyer",
+			expectError:  true,
+		},
+		{
+			name:        "invalid header",
+			services:    []*corev1.Service{bookstoreService},
+			headers:     []string{"curl"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			fakeK8sClient := fake.NewSimpleClientset()
+			out := new(bytes.Buffer)
+
+			for _, svc := range tc.services {
+				_, err := fakeK8sClient.CoreV1().Services(svc.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+				assert.Nil(err)
+			}
+
+			cmd := trafficPolicyCheckCmd{
+				clientSet:   fakeK8sClient,
+				out:         out,
+				checkRoutes: true,
+				port:        tc.port,
+				method:      "GET",
+				path:        "/books",
+				headers:     tc.headers,
+				getControllerDebugInfo: func(path string) ([]byte, error) {
+					assert.Equal(tc.expectedPath, path)
+					return []byte(tc.response), nil
+				},
+			}
+
+			err := cmd.checkTrafficRoutes(srcPod, dstPod)
+			assert.Equal(tc.expectError, err != nil)
+			for _, expected := range tc.expectedOutSubstr {
+				assert.Contains(out.String(), expected)
+			}
+			for _, unexpected := range tc.unexpectedOutSubstr {
+				assert.NotContains(out.String(), unexpected)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/simulator"
+)
+
+const trafficPolicySimulateDescription = `
+This command evaluates traffic policies offline, without a cluster. It loads
+the Kubernetes, SMI and OSM resources of the given manifest files and
+directories, and runs the traffic policy logic of the osm-controller on them.
+
+With --from and --to, it checks whether a request from a pod to a service is
+allowed, and reports the outbound route, TrafficSplit backends, TrafficTargets
+and HTTP routes or TCP ports the request is evaluated against. With
+--proxy-config, it renders the listeners, routes, clusters and endpoints the
+osm-controller would program the Envoy proxy of a pod with.
+
+Every namespace of the resources is part of the simulated mesh, and the
+MeshConfig loaded from the manifests, if any, configures it. A pod named after
+each Deployment, StatefulSet, DaemonSet and ReplicaSet is simulated, as well
+as the Endpoints of the Services selecting pods.
+
+With --expect, the command fails when the request is not allowed or denied as
+expected, so that policy changes can be tested in CI before being applied.
+`
+
+const trafficPolicySimulateExample = `
+# Check if the pod of the 'bookbuyer' Deployment in the 'bookbuyer' namespace can send GET /books requests to the 'bookstore' service on port 14001
+osm policy simulate -f manifests/ --from bookbuyer/bookbuyer --to bookstore/bookstore:14001 --method GET --path /books
+
+# Fail if the request with a given header is not allowed
+osm policy simulate -f manifests/ --from bookbuyer/bookbuyer --to bookstore/bookstore:14001 --path /books --header user-agent=curl --expect allow
+
+# Render the Envoy configuration of the pod of the 'bookstore' Deployment in the 'bookstore' namespace
+osm policy simulate -f manifests/ --proxy-config bookstore/bookstore
+`
+
+const (
+	trafficPolicyExpectAllow = "allow"
+	trafficPolicyExpectDeny  = "deny"
+
+	trafficPolicyOutputTable = "table"
+	trafficPolicyOutputJSON  = "json"
+)
+
+type trafficPolicySimulateCmd struct {
+	out         io.Writer
+	manifests   []string
+	from        string
+	to          string
+	method      string
+	path        string
+	headers     []string
+	proxyConfig string
+	output      string
+	expect      string
+	verbosity   string
+}
+
+func newTrafficPolicySimulate(out io.Writer) *cobra.Command {
+	simulateCmd := &trafficPolicySimulateCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "evaluate traffic policies from manifests without a cluster",
+		Long:  trafficPolicySimulateDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return simulateCmd.run()
+		},
+		Example: trafficPolicySimulateExample,
+	}
+
+	f := cmd.Flags()
+	f.StringSliceVarP(&simulateCmd.manifests, "filename", "f", nil, "Manifest files or directories of the resources to simulate")
+	f.StringVar(&simulateCmd.from, "from", "", "Source pod of the request, of the form <namespace/pod>")
+	f.StringVar(&simulateCmd.to, "to", "", "Destination service of the request, of the form <namespace/service[:port]>")
+	f.StringVar(&simulateCmd.method, "method", "GET", "HTTP method of the request")
+	f.StringVar(&simulateCmd.path, "path", "/", "HTTP path of the request")
+	f.StringArrayVar(&simulateCmd.headers, "header", nil, "HTTP header of the request, of the form <name=value>, can be repeated")
+	f.StringVar(&simulateCmd.proxyConfig, "proxy-config", "", "Pod whose Envoy proxy configuration is rendered, of the form <namespace/pod>")
+	f.StringVarP(&simulateCmd.output, "output", "o", trafficPolicyOutputTable, "Output format of the request evaluation, one of: table|json")
+	f.StringVar(&simulateCmd.expect, "expect", "", "Fail unless the request is evaluated as expected, one of: allow|deny")
+	f.StringVar(&simulateCmd.verbosity, "verbosity", "disabled", fmt.Sprintf("Log level of the simulated control plane, one of: %v", logger.AllowedLevels))
+	//nolint: errcheck
+	//#nosec G104: Errors unhandled
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+func (cmd *trafficPolicySimulateCmd) run() error {
+	isRequest := cmd.from != "" || cmd.to != ""
+	if isRequest == (cmd.proxyConfig != "") {
+		return errors.New("Either --from and --to, or --proxy-config must be specified")
+	}
+	if cmd.output != trafficPolicyOutputTable && cmd.output != trafficPolicyOutputJSON {
+		return errors.Errorf("Invalid output format %q, expected one of: %s|%s", cmd.output, trafficPolicyOutputTable, trafficPolicyOutputJSON)
+	}
+	if cmd.expect != "" && cmd.expect != trafficPolicyExpectAllow && cmd.expect != trafficPolicyExpectDeny {
+		return errors.Errorf("Invalid expectation %q, expected one of: %s|%s", cmd.expect, trafficPolicyExpectAllow, trafficPolicyExpectDeny)
+	}
+	if err := logger.SetLogLevel(cmd.verbosity); err != nil {
+		return err
+	}
+
+	objects, err := simulator.LoadManifests(cmd.manifests)
+	if err != nil {
+		return err
+	}
+	sim, err := simulator.NewSimulator(objects)
+	if err != nil {
+		return errors.Errorf("Error simulating the mesh: %s", err)
+	}
+	defer sim.Stop()
+
+	if cmd.proxyConfig != "" {
+		return cmd.renderProxyConfig(sim)
+	}
+	return cmd.checkRequest(sim)
+}
+
+func (cmd *trafficPolicySimulateCmd) renderProxyConfig(sim *simulator.Simulator) error {
+	namespace, podName, err := unmarshalNamespacedPod(cmd.proxyConfig)
+	if err != nil {
+		return errors.Errorf("Invalid argument specified for the pod: %s", err)
+	}
+	config, err := sim.GetProxyConfig(namespace, podName)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return errors.Errorf("Error rendering the proxy configuration: %s", err)
+	}
+	fmt.Fprintln(cmd.out, string(out))
+	return nil
+}
+
+func (cmd *trafficPolicySimulateCmd) checkRequest(sim *simulator.Simulator) error {
+	srcNs, srcPodName, err := unmarshalNamespacedPod(cmd.from)
+	if err != nil {
+		return errors.Errorf("Invalid argument specified for the source pod: %s", err)
+	}
+	dst, port, err := unmarshalNamespacedServicePort(cmd.to)
+	if err != nil {
+		return errors.Errorf("Invalid argument specified for the destination service: %s", err)
+	}
+	headers, err := parseHTTPHeaders(cmd.headers)
+	if err != nil {
+		return err
+	}
+
+	srcPod, err := sim.GetPod(srcNs, srcPodName)
+	if err != nil {
+		return err
+	}
+
+	decision := sim.CheckTrafficRequest(catalog.TrafficRequest{
+		Source:      sim.GetPodServiceIdentity(srcPod),
+		Destination: dst,
+		Port:        port,
+		Method:      cmd.method,
+		Path:        cmd.path,
+		Headers:     headers,
+	})
+
+	if cmd.output == trafficPolicyOutputJSON {
+		out, err := json.MarshalIndent(decision, "", "  ")
+		if err != nil {
+			return errors.Errorf("Error rendering the evaluation of the request: %s", err)
+		}
+		fmt.Fprintln(cmd.out, string(out))
+	} else {
+		fmt.Fprintf(cmd.out, "[+] Request %s %s from pod '%s/%s' to service '%s'\n", cmd.method, cmd.path, srcPod.Namespace, srcPod.Name, dst)
+		if err := printTrafficDecision(cmd.out, decision); err != nil {
+			return err
+		}
+	}
+
+	if cmd.expect == trafficPolicyExpectAllow && !decision.Allowed {
+		return errors.New("The request is denied, expected it to be allowed")
+	}
+	if cmd.expect == trafficPolicyExpectDeny && decision.Allowed {
+		return errors.New("The request is allowed, expected it to be denied")
+	}
+	return nil
+}
+
+// printTrafficDecision prints the outcome of the evaluation of a request, and the decisions for each of the backends
+// the request can be routed to
+func printTrafficDecision(out io.Writer, decision catalog.TrafficDecision) error {
+	if decision.Protocol != "" {
+		fmt.Fprintf(out, "[+] Destination port %d, protocol %s\n", decision.Port, decision.Protocol)
+	}
+	result := "DENIED"
+	if decision.Allowed {
+		result = "ALLOWED"
+	}
+	fmt.Fprintf(out, "[+] %s: %s\n", result, decision.Reason)
+	if len(decision.Backends) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	w := newTabWriter(out)
+	fmt.Fprintln(w, "BACKEND\tWEIGHT\tIDENTITY\tALLOWED\tTRAFFIC TARGETS\tROUTE\tREASON")
+	for _, backend := range decision.Backends {
+		route := "-"
+		if backend.Route != nil {
+			route = backend.Route.String()
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%t\t%s\t%s\t%s\n", backend.Service, backend.Weight, orDash(backend.Identity), backend.Allowed,
+			orDash(strings.Join(backend.TrafficTargets, ",")), route, backend.Reason)
+	}
+	return w.Flush()
+}
+
+// unmarshalNamespacedServicePort returns the service and port of a string of the form <namespace/service[:port]>, the
+// port is 0 when it is omitted
+func unmarshalNamespacedServicePort(namespacedServicePort string) (service.MeshService, uint32, error) {
+	var port uint32
+	namespacedService := namespacedServicePort
+	if idx := strings.LastIndex(namespacedServicePort, ":"); idx != -1 {
+		namespacedService = namespacedServicePort[:idx]
+		p, err := strconv.ParseUint(namespacedServicePort[idx+1:], 10, 16)
+		if err != nil || p == 0 {
+			return service.MeshService{}, 0, errors.Errorf("Invalid port in %s", namespacedServicePort)
+		}
+		port = uint32(p)
+	}
+
+	// Services are namespaced the same way as pods
+	namespace, name, err := unmarshalNamespacedPod(namespacedService)
+	if err != nil {
+		return service.MeshService{}, 0, errors.Errorf("Service should be of the form <namespace/service[:port]>, got: %s", namespacedServicePort)
+	}
+	return service.MeshService{Name: name, Namespace: namespace}, port, nil
+}
+
+// parseHTTPHeaders returns the HTTP headers given in the form <name=value>
+func parseHTTPHeaders(headers []string) (map[string]string, error) {
+	parsed := make(map[string]string)
+	for _, header := range headers {
+		chunks := strings.SplitN(header, "=", 2)
+		if len(chunks) != 2 || chunks[0] == "" {
+			return nil, errors.Errorf("Header should be of the form <name=value>, got: %s", header)
+		}
+		parsed[strings.ToLower(chunks[0])] = chunks[1]
+	}
+	return parsed, nil
+}
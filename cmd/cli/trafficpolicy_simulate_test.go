@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const testSimulateManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bookbuyer
+  namespace: bookbuyer
+spec:
+  selector:
+    matchLabels:
+      app: bookbuyer
+  template:
+    metadata:
+      labels:
+        app: bookbuyer
+    spec:
+      serviceAccountName: bookbuyer
+      containers:
+      - name: bookbuyer
+        image: openservicemesh/bookbuyer
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  selector:
+    matchLabels:
+      app: bookstore
+  template:
+    metadata:
+      labels:
+        app: bookstore
+    spec:
+      serviceAccountName: bookstore
+      containers:
+      - name: bookstore
+        image: openservicemesh/bookstore
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  selector:
+    app: bookstore
+  ports:
+  - name: http
+    port: 14001
+---
+apiVersion: specs.smi-spec.io/v1alpha4
+kind: HTTPRouteGroup
+metadata:
+  name: bookstore-routes
+  namespace: bookstore
+spec:
+  matches:
+  - name: books
+    pathRegex: /books
+    methods:
+    - GET
+    headers:
+    - user-agent: curl
+---
+apiVersion: access.smi-spec.io/v1alpha3
+kind: TrafficTarget
+metadata:
+  name: bookbuyer-access-bookstore
+  namespace: bookstore
+spec:
+  destination:
+    kind: ServiceAccount
+    name: bookstore
+    namespace: bookstore
+  rules:
+  - kind: HTTPRouteGroup
+    name: bookstore-routes
+    matches:
+    - books
+  sources:
+  - kind: ServiceAccount
+    name: bookbuyer
+    namespace: bookbuyer
+`
+
+func writeTestSimulateManifest(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "osm-policy-simulate")
+	trequire.NoError(t, err)
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "bookstore.yaml"), []byte(testSimulateManifest), 0600)
+	trequire.NoError(t, err)
+	return dir, cleanup
+}
+
+func TestTrafficPolicySimulateRun(t *testing.T) {
+	dir, cleanup := writeTestSimulateManifest(t)
+	defer cleanup()
+
+	testCases := []struct {
+		name           string
+		cmd            trafficPolicySimulateCmd
+		expectError    bool
+		expectedOutput []string
+	}{
+		{
+			name: "allowed request",
+			cmd: trafficPolicySimulateCmd{
+				from:    "bookbuyer/bookbuyer",
+				to:      "bookstore/bookstore:14001",
+				method:  "GET",
+				path:    "/books",
+				headers: []string{"User-Agent=curl"},
+				expect:  trafficPolicyExpectAllow,
+			},
+			expectedOutput: []string{
+				"[+] ALLOWED: Request from bookbuyer.bookbuyer.cluster.local to bookstore/bookstore is allowed on every backend",
+				"bookstore/bookbuyer-access-bookstore",
+				"GET /books user-agent=curl",
+			},
+		},
+		{
+			name: "request denied as expected",
+			cmd: trafficPolicySimulateCmd{
+				from:   "bookbuyer/bookbuyer",
+				to:     "bookstore/bookstore",
+				method: "GET",
+				path:   "/books",
+				expect: trafficPolicyExpectDeny,
+			},
+			expectedOutput: []string{"[+] DENIED: No HTTP route allowed for bookbuyer.bookbuyer.cluster.local"},
+		},
+		{
+			name: "request denied while expected to be allowed",
+			cmd: trafficPolicySimulateCmd{
+				from:   "bookbuyer/bookbuyer",
+				to:     "bookstore/bookstore",
+				method: "DELETE",
+				path:   "/books",
+				expect: trafficPolicyExpectAllow,
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown source pod",
+			cmd: trafficPolicySimulateCmd{
+				from: "bookbuyer/unknown",
+				to:   "bookstore/bookstore",
+			},
+			expectError: true,
+		},
+		{
+			name: "proxy config",
+			cmd: trafficPolicySimulateCmd{
+				proxyConfig: "bookbuyer/bookbuyer",
+			},
+			expectedOutput: []string{`"listeners"`, `"outbound-listener"`, `"bookstore/bookstore"`},
+		},
+		{
+			name: "both a request and a proxy config",
+			cmd: trafficPolicySimulateCmd{
+				from:        "bookbuyer/bookbuyer",
+				to:          "bookstore/bookstore",
+				proxyConfig: "bookbuyer/bookbuyer",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid expectation",
+			cmd: trafficPolicySimulateCmd{
+				from:   "bookbuyer/bookbuyer",
+				to:     "bookstore/bookstore",
+				expect: "maybe",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			out := new(bytes.Buffer)
+
+			tc.cmd.out = out
+			tc.cmd.manifests = []string{dir}
+			if tc.cmd.output == "" {
+				tc.cmd.output = trafficPolicyOutputTable
+			}
+			tc.cmd.verbosity = "disabled"
+
+			err := tc.cmd.run()
+			assert.Equal(tc.expectError, err != nil, "unexpected error: %v", err)
+			for _, expected := range tc.expectedOutput {
+				assert.Contains(out.String(), expected)
+			}
+		})
+	}
+}
+
+func TestTrafficPolicySimulateJSONOutput(t *testing.T) {
+	assert := tassert.New(t)
+	dir, cleanup := writeTestSimulateManifest(t)
+	defer cleanup()
+
+	out := new(bytes.Buffer)
+	cmd := trafficPolicySimulateCmd{
+		out:       out,
+		manifests: []string{dir},
+		from:      "bookbuyer/bookbuyer",
+		to:        "bookstore/bookstore",
+		method:    "GET",
+		path:      "/books",
+		headers:   []string{"user-agent=curl"},
+		output:    trafficPolicyOutputJSON,
+		verbosity: "disabled",
+	}
+	assert.NoError(cmd.run())
+
+	var decision catalog.TrafficDecision
+	assert.NoError(json.Unmarshal(out.Bytes(), &decision))
+	assert.True(decision.Allowed)
+	assert.Equal(uint32(14001), decision.Port)
+	assert.Len(decision.Backends, 1)
+}
+
+func TestUnmarshalNamespacedServicePort(t *testing.T) {
+	testCases := []struct {
+		namespacedServicePort string
+		expectedService       service.MeshService
+		expectedPort          uint32
+		expectError           bool
+	}{
+		{"foo/bar:8080", service.MeshService{Namespace: "foo", Name: "bar"}, 8080, false},
+		{"foo/bar", service.MeshService{Namespace: "foo", Name: "bar"}, 0, false},
+		{"bar:80", service.MeshService{Namespace: "default", Name: "bar"}, 80, false},
+		{"foo/bar:http", service.MeshService{}, 0, true},
+		{"foo/bar:0", service.MeshService{}, 0, true},
+		{"foo/bar/baz", service.MeshService{}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Testing %s", tc.namespacedServicePort), func(t *testing.T) {
+			assert := tassert.New(t)
+
+			svc, port, err := unmarshalNamespacedServicePort(tc.namespacedServicePort)
+			assert.Equal(tc.expectedService, svc)
+			assert.Equal(tc.expectedPort, port)
+			assert.Equal(tc.expectError, err != nil)
+		})
+	}
+}
+
+func TestParseHTTPHeaders(t *testing.T) {
+	assert := tassert.New(t)
+
+	headers, err := parseHTTPHeaders([]string{"User-Agent=curl", "x-id=a=b"})
+	assert.NoError(err)
+	assert.Equal(map[string]string{"user-agent": "curl", "x-id": "a=b"}, headers)
+
+	_, err = parseHTTPHeaders([]string{"invalid"})
+	assert.Error(err)
+}
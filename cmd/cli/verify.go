@@ -0,0 +1,25 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const verifyCmdDescription = `
+This command consists of subcommands verifying that the mesh is
+configured as expected by inspecting the running control plane and
+sidecar proxies.
+`
+
+func newVerifyCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "verify the configuration of the mesh",
+		Long:  verifyCmdDescription,
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newVerifyConnectivityCmd(out))
+
+	return cmd
+}
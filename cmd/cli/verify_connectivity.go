@@ -0,0 +1,795 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy/secrets"
+	osmConfigClient "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/mesh"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const verifyConnectivityDescription = `
+This command verifies that the sidecar proxies of a source pod and of the pods
+of a destination service are configured to let the source pod connect to the
+service. It fetches the config dumps of both proxies and checks each link of
+the path of the connection in order:
+
+  - a meshed pod backs the port of the destination service
+  - the outbound listener of the source proxy has a filter chain for the
+    service port, matching the cluster IP of the service, or the IP of the
+    destination pod if the service is headless
+  - the outbound route or TCP proxy of the filter chain forwards to clusters
+  - the clusters exist and one of them has the destination pod as endpoint
+  - the cluster originates mTLS with the SNI of its service, and its
+    validation context matches the SAN of the identity of the destination pod
+  - the inbound listener of the destination proxy has a filter chain for the
+    SNI and target port
+  - the RBAC policies of the inbound filter chain or routes, and the
+    AuthorizationPolicy RBAC filters of the inbound HTTP connection manager,
+    allow the identity of the source pod
+
+The identities are computed with the trust domain of the MeshConfig.
+
+A pass/fail checklist is printed, and the command fails at the first broken
+link. The destination pod checked is the first meshed pod backing the service.
+`
+
+const verifyConnectivityExample = `
+# Verify that pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace can connect to port 14001 of the 'bookstore' service in the 'bookstore' namespace
+osm verify connectivity --from bookbuyer/bookbuyer-5ccf77f46d-rc5mg --to bookstore/bookstore:14001
+`
+
+const (
+	// verifyConnectivityConfigDumpQuery is the Envoy admin query returning the config dump including the endpoints
+	verifyConnectivityConfigDumpQuery = "config_dump?include_eds"
+
+	// The names below are the names given by the osm-controller to the listeners and filter chains of the proxies
+	outboundListenerName              = "outbound-listener"
+	inboundListenerName               = "inbound-listener"
+	outboundMeshHTTPFilterChainPrefix = "outbound-mesh-http-filter-chain"
+	outboundMeshTCPFilterChainPrefix  = "outbound-mesh-tcp-filter-chain"
+
+	// The names below are the names given by the osm-controller to the HTTP RBAC filters enforcing AuthorizationPolicy resources
+	authzDenyHTTPFilterName  = "osm.authz.deny"
+	authzAllowHTTPFilterName = "osm.authz.allow"
+
+	// rbacActionDeny is the action of the RBAC rules denying the requests or connections their policies match
+	rbacActionDeny = "DENY"
+)
+
+type verifyConnectivityCmd struct {
+	out              io.Writer
+	from             string
+	to               string
+	localPort        uint16
+	clientSet        kubernetes.Interface
+	meshConfigClient osmConfigClient.Interface
+
+	// getConfigDump returns the config dump, including the endpoints, of the proxy of the given pod
+	getConfigDump func(namespace, pod string) ([]byte, error)
+}
+
+// connectivityCheck is the outcome of the verification of a link of the path of a connection
+type connectivityCheck struct {
+	name   string
+	status string
+	detail string
+}
+
+const (
+	connectivityCheckPass = "PASS"
+	connectivityCheckFail = "FAIL"
+	connectivityCheckSkip = "SKIP"
+)
+
+// connectivityVerifier holds the state gathered by the checks, each check relying on the ones before it
+type connectivityVerifier struct {
+	cmd *verifyConnectivityCmd
+
+	srcPod      *corev1.Pod
+	dstService  service.MeshService
+	clusterIP   string
+	servicePort corev1.ServicePort
+	trustDomain string
+
+	dstPod     *corev1.Pod
+	targetPort uint32
+	srcDump    []byte
+	dstDump    []byte
+
+	outboundFilterChain *envoyFilterChain
+	clusters            []string
+	backend             service.MeshService
+	inboundFilterChain  *envoyFilterChain
+}
+
+func newVerifyConnectivityCmd(out io.Writer) *cobra.Command {
+	verifyCmd := &verifyConnectivityCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "connectivity",
+		Short: "verify the proxies are configured to connect a pod to a service",
+		Long:  verifyConnectivityDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+			verifyCmd.clientSet = clientset
+
+			configClient, err := osmConfigClient.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not initialize OSM Config client: %s", err)
+			}
+			verifyCmd.meshConfigClient = configClient
+
+			verifyCmd.getConfigDump = func(namespace, pod string) ([]byte, error) {
+				return cli.GetEnvoyProxyConfig(clientset, config, namespace, pod, verifyCmd.localPort, verifyConnectivityConfigDumpQuery)
+			}
+
+			return verifyCmd.run()
+		},
+		Example: verifyConnectivityExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&verifyCmd.from, "from", "", "Source pod, of the form <namespace/pod>")
+	f.StringVar(&verifyCmd.to, "to", "", "Destination service, of the form <namespace/service[:port]>")
+	f.Uint16VarP(&verifyCmd.localPort, "local-port", "p", constants.EnvoyAdminPort, "Local port to use for port forwarding")
+	//nolint: errcheck
+	//#nosec G104: Errors unhandled
+	cmd.MarkFlagRequired("from")
+	//nolint: errcheck
+	//#nosec G104: Errors unhandled
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func (cmd *verifyConnectivityCmd) run() error {
+	srcNs, srcPodName, err := unmarshalNamespacedPod(cmd.from)
+	if err != nil {
+		return errors.Errorf("Invalid argument specified for the source pod: %s", err)
+	}
+	dst, port, err := unmarshalNamespacedServicePort(cmd.to)
+	if err != nil {
+		return errors.Errorf("Invalid argument specified for the destination service: %s", err)
+	}
+
+	meshConfig, err := cmd.meshConfigClient.ConfigV1alpha1().MeshConfigs(settings.Namespace()).Get(context.TODO(), defaultOsmMeshConfigName, metav1.GetOptions{})
+	if err != nil {
+		return annotateErrorMessageWithOsmNamespace("Error fetching MeshConfig %s: %s", defaultOsmMeshConfigName, err)
+	}
+	trustDomain := meshConfig.Spec.Certificate.TrustDomain
+	if trustDomain == "" {
+		trustDomain = identity.ClusterLocalTrustDomain
+	}
+
+	srcPod, err := cmd.clientSet.CoreV1().Pods(srcNs).Get(context.TODO(), srcPodName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Errorf("Could not find pod %s in namespace %s", srcPodName, srcNs)
+	}
+	if !mesh.ProxyLabelExists(*srcPod) {
+		return errors.Errorf("Pod %s in namespace %s is not a part of a mesh", srcPodName, srcNs)
+	}
+
+	svc, err := cmd.clientSet.CoreV1().Services(dst.Namespace).Get(context.TODO(), dst.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Errorf("Could not find service %s in namespace %s", dst.Name, dst.Namespace)
+	}
+	servicePort, err := getServicePort(svc, port)
+	if err != nil {
+		return err
+	}
+
+	v := &connectivityVerifier{
+		cmd:         cmd,
+		srcPod:      srcPod,
+		dstService:  dst,
+		clusterIP:   svc.Spec.ClusterIP,
+		servicePort: servicePort,
+		trustDomain: trustDomain,
+	}
+
+	fmt.Fprintf(cmd.out, "[+] Verifying connectivity from pod '%s/%s' to service '%s' on port %d\n\n", srcPod.Namespace, srcPod.Name, dst, servicePort.Port)
+	checks := v.verify()
+
+	w := newTabWriter(cmd.out)
+	fmt.Fprintln(w, "STATUS\tCHECK\tDETAIL")
+	var broken *connectivityCheck
+	for i, check := range checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.status, check.name, check.detail)
+		if check.status == connectivityCheckFail && broken == nil {
+			broken = &checks[i]
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.out)
+
+	if broken != nil {
+		return errors.Errorf("Connectivity broken at %q: %s", broken.name, broken.detail)
+	}
+	fmt.Fprintf(cmd.out, "[+] Pod '%s/%s' can connect to service '%s' on port %d\n", srcPod.Namespace, srcPod.Name, dst, servicePort.Port)
+	return nil
+}
+
+// getServicePort returns the port of the service with the given number, or its only port if the number is 0
+func getServicePort(svc *corev1.Service, port uint32) (corev1.ServicePort, error) {
+	if port == 0 {
+		if len(svc.Spec.Ports) != 1 {
+			return corev1.ServicePort{}, errors.Errorf("Service %s/%s has %d ports, the port must be specified", svc.Namespace, svc.Name, len(svc.Spec.Ports))
+		}
+		return svc.Spec.Ports[0], nil
+	}
+	for _, p := range svc.Spec.Ports {
+		if uint32(p.Port) == port {
+			return p, nil
+		}
+	}
+	return corev1.ServicePort{}, errors.Errorf("Service %s/%s has no port %d", svc.Namespace, svc.Name, port)
+}
+
+// verify runs the checks in the order of the path of the connection, the checks after the first failing one are skipped
+func (v *connectivityVerifier) verify() []connectivityCheck {
+	steps := []struct {
+		name  string
+		check func() (bool, string)
+	}{
+		{"Destination pod", v.checkDestinationPod},
+		{"Source proxy", v.checkSourceProxy},
+		{"Outbound filter chain", v.checkOutboundFilterChain},
+		{"Outbound route", v.checkOutboundRoute},
+		{"Upstream clusters", v.checkUpstreamClusters},
+		{"Upstream endpoints", v.checkUpstreamEndpoints},
+		{"Upstream SAN", v.checkUpstreamSAN},
+		{"Destination proxy", v.checkDestinationProxy},
+		{"Inbound filter chain", v.checkInboundFilterChain},
+		{"RBAC principal", v.checkRBACPrincipal},
+	}
+
+	var checks []connectivityCheck
+	failed := false
+	for _, step := range steps {
+		if failed {
+			checks = append(checks, connectivityCheck{name: step.name, status: connectivityCheckSkip, detail: "-"})
+			continue
+		}
+		passed, detail := step.check()
+		status := connectivityCheckPass
+		if !passed {
+			status = connectivityCheckFail
+			failed = true
+		}
+		checks = append(checks, connectivityCheck{name: step.name, status: status, detail: detail})
+	}
+	return checks
+}
+
+func (v *connectivityVerifier) checkDestinationPod() (bool, string) {
+	client := v.cmd.clientSet.CoreV1()
+	endpoints, err := client.Endpoints(v.dstService.Namespace).Get(context.TODO(), v.dstService.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Sprintf("Service %s has no Endpoints", v.dstService)
+	}
+
+	var candidates []*corev1.Pod
+	for _, subset := range endpoints.Subsets {
+		var targetPort uint32
+		for _, p := range subset.Ports {
+			if p.Name == v.servicePort.Name {
+				targetPort = uint32(p.Port)
+			}
+		}
+		if targetPort == 0 {
+			continue
+		}
+		for _, address := range subset.Addresses {
+			if address.TargetRef == nil || address.TargetRef.Kind != "Pod" {
+				continue
+			}
+			pod, err := client.Pods(address.TargetRef.Namespace).Get(context.TODO(), address.TargetRef.Name, metav1.GetOptions{})
+			if err != nil || !mesh.ProxyLabelExists(*pod) {
+				continue
+			}
+			candidates = append(candidates, pod)
+			if v.targetPort == 0 {
+				v.targetPort = targetPort
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return false, fmt.Sprintf("No ready meshed pod backs port %d of service %s", v.servicePort.Port, v.dstService)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+	v.dstPod = candidates[0]
+	return true, fmt.Sprintf("Pod %s/%s (%s:%d) backs port %d of service %s",
+		v.dstPod.Namespace, v.dstPod.Name, v.dstPod.Status.PodIP, v.targetPort, v.servicePort.Port, v.dstService)
+}
+
+func (v *connectivityVerifier) checkSourceProxy() (bool, string) {
+	dump, err := v.cmd.getConfigDump(v.srcPod.Namespace, v.srcPod.Name)
+	if err != nil {
+		return false, err.Error()
+	}
+	v.srcDump = dump
+	return true, fmt.Sprintf("Fetched the config dump of the proxy of pod %s/%s", v.srcPod.Namespace, v.srcPod.Name)
+}
+
+func (v *connectivityVerifier) checkOutboundFilterChain() (bool, string) {
+	listener, err := findListener(v.srcDump, outboundListenerName)
+	if err != nil || listener == nil {
+		return false, fmt.Sprintf("The proxy of pod %s/%s has no %s", v.srcPod.Namespace, v.srcPod.Name, outboundListenerName)
+	}
+
+	// Like the osm-controller, match the cluster IP of the service, or the IP of the destination pod if the service is headless
+	ip, ipOwner := v.clusterIP, fmt.Sprintf("service %s", v.dstService)
+	if ip == "" || ip == corev1.ClusterIPNone {
+		ip, ipOwner = v.dstPod.Status.PodIP, fmt.Sprintf("pod %s/%s", v.dstPod.Namespace, v.dstPod.Name)
+	}
+
+	names := []string{
+		fmt.Sprintf("%s:%s", outboundMeshHTTPFilterChainPrefix, v.dstService),
+		fmt.Sprintf("%s:%s", outboundMeshTCPFilterChainPrefix, v.dstService),
+	}
+	for i, fc := range listener.FilterChains {
+		if !containsString(names, fc.Name) || fc.FilterChainMatch.DestinationPort != uint32(v.servicePort.Port) {
+			continue
+		}
+
+		matchesIP := len(fc.FilterChainMatch.PrefixRanges) == 0
+		for _, prefixRange := range fc.FilterChainMatch.PrefixRanges {
+			matchesIP = matchesIP || prefixRange.AddressPrefix == ip
+		}
+		if !matchesIP {
+			return false, fmt.Sprintf("Filter chain %s does not match the IP %s of %s", fc.Name, ip, ipOwner)
+		}
+
+		v.outboundFilterChain = &listener.FilterChains[i]
+		return true, fmt.Sprintf("Filter chain %s matches port %d and IP %s", fc.Name, v.servicePort.Port, ip)
+	}
+	return false, fmt.Sprintf("%s has no filter chain for service %s on port %d", outboundListenerName, v.dstService, v.servicePort.Port)
+}
+
+func (v *connectivityVerifier) checkOutboundRoute() (bool, string) {
+	for _, filter := range v.outboundFilterChain.Filters {
+		switch filter.Name {
+		case wellknown.HTTPConnectionManager:
+			routeConfigName := getRouteConfigName(filter.TypedConfig)
+			vh, err := findVirtualHost(v.srcDump, routeConfigName, v.dstService)
+			if err != nil {
+				return false, err.Error()
+			}
+			for _, route := range vh.Routes {
+				v.clusters = appendRouteClusters(v.clusters, route)
+			}
+			if len(v.clusters) == 0 {
+				return false, fmt.Sprintf("No route of virtual host %s of route configuration %s forwards to a cluster", vh.Name, routeConfigName)
+			}
+			return true, fmt.Sprintf("Virtual host %s of route configuration %s routes to clusters %s", vh.Name, routeConfigName, strings.Join(v.clusters, ","))
+
+		case wellknown.TCPProxy:
+			v.clusters = getTCPProxyClusters(filter.TypedConfig)
+			if len(v.clusters) == 0 {
+				return false, fmt.Sprintf("The TCP proxy of filter chain %s does not forward to a cluster", v.outboundFilterChain.Name)
+			}
+			return true, fmt.Sprintf("The TCP proxy of filter chain %s forwards to clusters %s", v.outboundFilterChain.Name, strings.Join(v.clusters, ","))
+		}
+	}
+	return false, fmt.Sprintf("Filter chain %s has no HTTP connection manager or TCP proxy filter", v.outboundFilterChain.Name)
+}
+
+func (v *connectivityVerifier) checkUpstreamClusters() (bool, string) {
+	clusters, err := getClusters(v.srcDump)
+	if err != nil {
+		return false, err.Error()
+	}
+	existing := make(map[string]bool)
+	for _, c := range clusters {
+		existing[c.Name] = true
+	}
+	for _, name := range v.clusters {
+		if !existing[name] {
+			return false, fmt.Sprintf("Cluster %s does not exist", name)
+		}
+	}
+	return true, fmt.Sprintf("Clusters %s exist", strings.Join(v.clusters, ","))
+}
+
+func (v *connectivityVerifier) checkUpstreamEndpoints() (bool, string) {
+	assignments, err := getClusterLoadAssignments(v.srcDump)
+	if err != nil {
+		return false, err.Error()
+	}
+	for _, assignment := range assignments {
+		if !containsString(v.clusters, assignment.ClusterName) {
+			continue
+		}
+		for _, locality := range assignment.Endpoints {
+			for _, lbEndpoint := range locality.LBEndpoints {
+				address := lbEndpoint.Endpoint.Address.SocketAddress
+				if address.Address != v.dstPod.Status.PodIP || address.PortValue != v.targetPort {
+					continue
+				}
+				chunks := strings.SplitN(assignment.ClusterName, namespaceSeparator, 2)
+				if len(chunks) != 2 {
+					return false, fmt.Sprintf("Cluster %s is not a cluster of a mesh service", assignment.ClusterName)
+				}
+				v.backend = service.MeshService{Namespace: chunks[0], Name: chunks[1]}
+				return true, fmt.Sprintf("Cluster %s has endpoint %s:%d", assignment.ClusterName, address.Address, address.PortValue)
+			}
+		}
+	}
+	return false, fmt.Sprintf("%s:%d of pod %s/%s is not an endpoint of clusters %s",
+		v.dstPod.Status.PodIP, v.targetPort, v.dstPod.Namespace, v.dstPod.Name, strings.Join(v.clusters, ","))
+}
+
+func (v *connectivityVerifier) checkUpstreamSAN() (bool, string) {
+	clusterName := v.backend.NameWithoutCluster()
+	clusters, err := getClusters(v.srcDump)
+	if err != nil {
+		return false, err.Error()
+	}
+	for _, c := range clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		if c.TransportSocket == nil {
+			return false, fmt.Sprintf("Cluster %s does not originate mTLS", clusterName)
+		}
+		if c.TransportSocket.TypedConfig.SNI != v.backend.ServerName() {
+			return false, fmt.Sprintf("Cluster %s sets the SNI %q, expected %q", clusterName, c.TransportSocket.TypedConfig.SNI, v.backend.ServerName())
+		}
+	}
+
+	secretName := secrets.SDSCert{Name: clusterName, CertType: secrets.RootCertTypeForMTLSOutbound}.String()
+	dstIdentity := getPodIdentity(v.dstPod, v.trustDomain).String()
+	allSecrets, err := getSecrets(v.srcDump)
+	if err != nil {
+		return false, err.Error()
+	}
+	for _, secret := range allSecrets {
+		if secret.Name != secretName {
+			continue
+		}
+		if secret.Secret.ValidationContext == nil {
+			return false, fmt.Sprintf("Secret %s has no validation context", secretName)
+		}
+		var sans []string
+		for _, san := range secret.Secret.ValidationContext.MatchSubjectAltNames {
+			sans = append(sans, san.Exact)
+		}
+		if !containsString(sans, dstIdentity) {
+			return false, fmt.Sprintf("Validation context %s does not match the SAN %s of pod %s/%s, expected one of: %s",
+				secretName, dstIdentity, v.dstPod.Namespace, v.dstPod.Name, strings.Join(sans, ","))
+		}
+		return true, fmt.Sprintf("Cluster %s sets the SNI %s and validates the SAN %s", clusterName, v.backend.ServerName(), dstIdentity)
+	}
+	return false, fmt.Sprintf("The proxy of pod %s/%s has no secret %s", v.srcPod.Namespace, v.srcPod.Name, secretName)
+}
+
+func (v *connectivityVerifier) checkDestinationProxy() (bool, string) {
+	dump, err := v.cmd.getConfigDump(v.dstPod.Namespace, v.dstPod.Name)
+	if err != nil {
+		return false, err.Error()
+	}
+	v.dstDump = dump
+	return true, fmt.Sprintf("Fetched the config dump of the proxy of pod %s/%s", v.dstPod.Namespace, v.dstPod.Name)
+}
+
+func (v *connectivityVerifier) checkInboundFilterChain() (bool, string) {
+	listener, err := findListener(v.dstDump, inboundListenerName)
+	if err != nil || listener == nil {
+		return false, fmt.Sprintf("The proxy of pod %s/%s has no %s", v.dstPod.Namespace, v.dstPod.Name, inboundListenerName)
+	}
+	for i, fc := range listener.FilterChains {
+		if fc.FilterChainMatch.DestinationPort == v.targetPort && containsString(fc.FilterChainMatch.ServerNames, v.backend.ServerName()) {
+			v.inboundFilterChain = &listener.FilterChains[i]
+			return true, fmt.Sprintf("Filter chain %s matches SNI %s on port %d", fc.Name, v.backend.ServerName(), v.targetPort)
+		}
+	}
+	return false, fmt.Sprintf("%s has no filter chain for SNI %s on port %d", inboundListenerName, v.backend.ServerName(), v.targetPort)
+}
+
+func (v *connectivityVerifier) checkRBACPrincipal() (bool, string) {
+	principal := getPodIdentity(v.srcPod, v.trustDomain).String()
+
+	for _, filter := range v.inboundFilterChain.Filters {
+		switch filter.Name {
+		case wellknown.RoleBasedAccessControl:
+			if rbacAllowsPrincipal(filter.TypedConfig, principal) {
+				return true, fmt.Sprintf("The RBAC filter of filter chain %s allows principal %s", v.inboundFilterChain.Name, principal)
+			}
+			return false, fmt.Sprintf("The RBAC filter of filter chain %s does not allow principal %s", v.inboundFilterChain.Name, principal)
+
+		case wellknown.HTTPConnectionManager:
+			for _, httpFilter := range getHTTPFilters(filter.TypedConfig) {
+				if httpFilter.Name != authzDenyHTTPFilterName && httpFilter.Name != authzAllowHTTPFilterName {
+					continue
+				}
+				if !rbacAllowsPrincipal(httpFilter.TypedConfig, principal) {
+					return false, fmt.Sprintf("The %s HTTP filter of filter chain %s does not allow principal %s", httpFilter.Name, v.inboundFilterChain.Name, principal)
+				}
+			}
+
+			routeConfigName := getRouteConfigName(filter.TypedConfig)
+			vh, err := findVirtualHost(v.dstDump, routeConfigName, v.backend)
+			if err != nil {
+				return false, err.Error()
+			}
+			for _, route := range vh.Routes {
+				rbacConfig, ok := route.TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl]
+				if !ok {
+					return true, fmt.Sprintf("Route %q of virtual host %s has no RBAC policy", getRouteMatch(route), vh.Name)
+				}
+				if rbacAllowsPrincipal(rbacConfig, principal) {
+					return true, fmt.Sprintf("Route %q of virtual host %s allows principal %s", getRouteMatch(route), vh.Name, principal)
+				}
+			}
+			return false, fmt.Sprintf("No route of virtual host %s of route configuration %s allows principal %s", vh.Name, routeConfigName, principal)
+		}
+	}
+	return true, fmt.Sprintf("Filter chain %s has no RBAC policy", v.inboundFilterChain.Name)
+}
+
+// getPodIdentity returns the service identity, with the given trust domain, of the given pod
+func getPodIdentity(pod *corev1.Pod, trustDomain string) identity.ServiceIdentity {
+	serviceAccount := pod.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+	return identity.K8sServiceAccount{Namespace: pod.Namespace, Name: serviceAccount}.ToServiceIdentity(trustDomain)
+}
+
+// findListener returns the listener with the given name of the given config dump, or nil if it does not exist
+func findListener(configDump []byte, name string) (*envoyListener, error) {
+	listeners, err := getListeners(configDump)
+	if err != nil {
+		return nil, err
+	}
+	for i := range listeners {
+		if listeners[i].Name == name {
+			return &listeners[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// findVirtualHost returns the virtual host of the given route configuration whose domains include the hostname of
+// the given service
+func findVirtualHost(configDump []byte, routeConfigName string, svc service.MeshService) (*envoyVirtualHost, error) {
+	routeConfigs, err := getRouteConfigs(configDump)
+	if err != nil {
+		return nil, err
+	}
+	hostnames := []string{svc.FQDN(), fmt.Sprintf("%s.%s", svc.Name, svc.Namespace)}
+	for _, rc := range routeConfigs {
+		if rc.Name != routeConfigName {
+			continue
+		}
+		for i, vh := range rc.VirtualHosts {
+			for _, hostname := range hostnames {
+				if containsString(vh.Domains, hostname) {
+					return &rc.VirtualHosts[i], nil
+				}
+			}
+		}
+		return nil, errors.Errorf("No virtual host of route configuration %s matches host %s", routeConfigName, svc.FQDN())
+	}
+	return nil, errors.Errorf("Route configuration %s does not exist", routeConfigName)
+}
+
+// getRouteConfigName returns the name of the RDS route configuration of the given HTTP connection manager config
+func getRouteConfigName(typedConfig json.RawMessage) string {
+	var hcm struct {
+		RDS *struct {
+			RouteConfigName string `json:"route_config_name"`
+		} `json:"rds"`
+	}
+	if err := json.Unmarshal(typedConfig, &hcm); err != nil || hcm.RDS == nil {
+		return ""
+	}
+	return hcm.RDS.RouteConfigName
+}
+
+// httpFilter is an HTTP filter of an HTTP connection manager config
+type httpFilter struct {
+	Name        string          `json:"name"`
+	TypedConfig json.RawMessage `json:"typed_config"`
+}
+
+// getHTTPFilters returns the HTTP filters of the given HTTP connection manager config
+func getHTTPFilters(typedConfig json.RawMessage) []httpFilter {
+	var hcm struct {
+		HTTPFilters []httpFilter `json:"http_filters"`
+	}
+	if err := json.Unmarshal(typedConfig, &hcm); err != nil {
+		return nil
+	}
+	return hcm.HTTPFilters
+}
+
+// getTCPProxyClusters returns the clusters the given TCP proxy config forwards connections to
+func getTCPProxyClusters(typedConfig json.RawMessage) []string {
+	var tcpProxy struct {
+		Cluster          string `json:"cluster"`
+		WeightedClusters *struct {
+			Clusters []struct {
+				Name string `json:"name"`
+			} `json:"clusters"`
+		} `json:"weighted_clusters"`
+	}
+	if err := json.Unmarshal(typedConfig, &tcpProxy); err != nil {
+		return nil
+	}
+	if tcpProxy.WeightedClusters == nil {
+		if tcpProxy.Cluster == "" {
+			return nil
+		}
+		return []string{tcpProxy.Cluster}
+	}
+	var clusters []string
+	for _, c := range tcpProxy.WeightedClusters.Clusters {
+		clusters = append(clusters, c.Name)
+	}
+	return clusters
+}
+
+// appendRouteClusters appends the clusters the given route forwards requests to, if not already in the list
+func appendRouteClusters(clusters []string, route envoyRoute) []string {
+	if route.Route == nil {
+		return clusters
+	}
+	names := []string{route.Route.Cluster}
+	if route.Route.WeightedClusters != nil {
+		names = nil
+		for _, c := range route.Route.WeightedClusters.Clusters {
+			names = append(names, c.Name)
+		}
+	}
+	for _, name := range names {
+		if name != "" && !containsString(clusters, name) {
+			clusters = append(clusters, name)
+		}
+	}
+	return clusters
+}
+
+// rbacAllowsPrincipal returns whether the given RBAC config, of a network RBAC filter, of an HTTP RBAC filter or of an
+// HTTP RBAC per route config, allows the given authenticated principal name. An ALLOW config allows the principal if
+// one of its policies has a principal matching it, a DENY config if none of its policies does.
+func rbacAllowsPrincipal(rbacConfig json.RawMessage, principal string) bool {
+	var config interface{}
+	if err := json.Unmarshal(rbacConfig, &config); err != nil {
+		return false
+	}
+	matches := anyPrincipalMatches(config, principal)
+	if getRBACAction(rbacConfig) == rbacActionDeny {
+		return !matches
+	}
+	return matches
+}
+
+// getRBACAction returns the action of the rules of the given RBAC config, empty if unset which is the ALLOW action
+func getRBACAction(rbacConfig json.RawMessage) string {
+	type rules struct {
+		Action string `json:"action"`
+	}
+	var config struct {
+		Rules *rules `json:"rules"`
+		RBAC  *struct {
+			Rules *rules `json:"rules"`
+		} `json:"rbac"`
+	}
+	if err := json.Unmarshal(rbacConfig, &config); err != nil {
+		return ""
+	}
+	if config.RBAC != nil && config.RBAC.Rules != nil {
+		return config.RBAC.Rules.Action
+	}
+	if config.Rules != nil {
+		return config.Rules.Action
+	}
+	return ""
+}
+
+// anyPrincipalMatches walks the given RBAC config looking for a list of principals with one matching the given name
+func anyPrincipalMatches(config interface{}, principal string) bool {
+	switch c := config.(type) {
+	case map[string]interface{}:
+		for key, value := range c {
+			if key == "principals" {
+				if principals, ok := value.([]interface{}); ok {
+					for _, p := range principals {
+						if principalMatches(p, principal) {
+							return true
+						}
+					}
+				}
+				continue
+			}
+			if key != "permissions" && anyPrincipalMatches(value, principal) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, value := range c {
+			if anyPrincipalMatches(value, principal) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// principalMatches returns whether the given RBAC principal matches the given authenticated principal name
+func principalMatches(p interface{}, principal string) bool {
+	rule, ok := p.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if isAny, ok := rule["any"].(bool); ok && isAny {
+		return true
+	}
+	if authenticated, ok := rule["authenticated"].(map[string]interface{}); ok {
+		name, _ := authenticated["principal_name"].(map[string]interface{})
+		exact, _ := name["exact"].(string)
+		return exact == principal
+	}
+	if orIDs, ok := rule["or_ids"].(map[string]interface{}); ok {
+		ids, _ := orIDs["ids"].([]interface{})
+		for _, id := range ids {
+			if principalMatches(id, principal) {
+				return true
+			}
+		}
+		return false
+	}
+	if andIDs, ok := rule["and_ids"].(map[string]interface{}); ok {
+		ids, _ := andIDs["ids"].([]interface{})
+		for _, id := range ids {
+			if !principalMatches(id, principal) {
+				return false
+			}
+		}
+		return len(ids) > 0
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
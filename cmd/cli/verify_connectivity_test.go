@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	fakeConfig "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+)
+
+const testSourceConfigDump = `{"configs":[
+{"@type":"type.googleapis.com/envoy.admin.v3.ListenersConfigDump","dynamic_listeners":[{"active_state":{"listener":{
+  "name":"outbound-listener",
+  "filter_chains":[{
+    "name":"outbound-mesh-http-filter-chain:bookstore/bookstore",
+    "filter_chain_match":{"destination_port":14001,"prefix_ranges":[{"address_prefix":"10.96.0.10","prefix_len":32}]},
+    "filters":[{"name":"envoy.filters.network.http_connection_manager","typed_config":{"rds":{"route_config_name":"rds-outbound"}}}]
+  }]
+}}}]},
+{"@type":"type.googleapis.com/envoy.admin.v3.ClustersConfigDump","dynamic_active_clusters":[{"cluster":{
+  "name":"bookstore/bookstore",
+  "transport_socket":{"typed_config":{"sni":"bookstore.bookstore.svc.cluster.local"}}
+}}]},
+{"@type":"type.googleapis.com/envoy.admin.v3.RoutesConfigDump","dynamic_route_configs":[{"route_config":{
+  "name":"rds-outbound",
+  "virtual_hosts":[{
+    "name":"outbound_virtual-host|bookstore.bookstore",
+    "domains":["bookstore.bookstore","bookstore.bookstore.svc.cluster.local"],
+    "routes":[{"match":{"prefix":"/"},"route":{"weighted_clusters":{"clusters":[{"name":"bookstore/bookstore","weight":100}]}}}]
+  }]
+}}]},
+{"@type":"type.googleapis.com/envoy.admin.v3.EndpointsConfigDump","dynamic_endpoint_configs":[{"endpoint_config":{
+  "cluster_name":"bookstore/bookstore",
+  "endpoints":[{"lb_endpoints":[{"endpoint":{"address":{"socket_address":{"address":"10.0.0.2","port_value":14001}}}}]}]
+}}]},
+{"@type":"type.googleapis.com/envoy.admin.v3.SecretsConfigDump","dynamic_active_secrets":[{
+  "name":"root-cert-for-mtls-outbound:bookstore/bookstore",
+  "secret":{"validation_context":{"match_subject_alt_names":[{"exact":"bookstore.bookstore.cluster.local"}]}}
+}]}
+]}`
+
+const testDestinationConfigDump = `{"configs":[
+{"@type":"type.googleapis.com/envoy.admin.v3.ListenersConfigDump","dynamic_listeners":[{"active_state":{"listener":{
+  "name":"inbound-listener",
+  "filter_chains":[{
+    "name":"inbound-mesh-http-filter-chain:bookstore/bookstore:14001",
+    "filter_chain_match":{"destination_port":14001,"server_names":["bookstore.bookstore.svc.cluster.local"],"transport_protocol":"tls"},
+    "filters":[{"name":"envoy.filters.network.http_connection_manager","typed_config":{"rds":{"route_config_name":"rds-inbound"}}}]
+  }]
+}}}]},
+{"@type":"type.googleapis.com/envoy.admin.v3.RoutesConfigDump","dynamic_route_configs":[{"route_config":{
+  "name":"rds-inbound",
+  "virtual_hosts":[{
+    "name":"inbound_virtual-host|bookstore.bookstore",
+    "domains":["bookstore.bookstore","bookstore.bookstore.svc.cluster.local"],
+    "routes":[{
+      "match":{"safe_regex":{"regex":"/books"}},
+      "route":{"weighted_clusters":{"clusters":[{"name":"bookstore/bookstore-local","weight":100}]}},
+      "typed_per_filter_config":{"envoy.filters.http.rbac":{"rbac":{"rules":{"policies":{"rbac-for-route":{
+        "permissions":[{"any":true}],
+        "principals":[{"or_ids":{"ids":[{"authenticated":{"principal_name":{"exact":"bookbuyer.bookbuyer.cluster.local"}}}]}}]
+      }}}}}}
+    }]
+  }]
+}}]}
+]}`
+
+// testInboundHCMConfig is the config of the inbound HTTP connection manager of the destination config dump, replaced
+// to add AuthorizationPolicy RBAC filters
+const testInboundHCMConfig = `{"rds":{"route_config_name":"rds-inbound"}}`
+
+func TestVerifyConnectivityRun(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		// srcReplace and dstReplace are replaced in the source and destination config dumps to break them
+		srcReplace  []string
+		dstReplace  []string
+		noEndpoints bool
+		headless    bool
+		trustDomain string
+
+		expectedErr     string
+		expectedOutput  []string
+		expectedSkipped int
+	}{
+		{
+			name: "connectivity verified",
+			expectedOutput: []string{
+				"Filter chain outbound-mesh-http-filter-chain:bookstore/bookstore matches port 14001 and IP 10.96.0.10",
+				"Cluster bookstore/bookstore sets the SNI bookstore.bookstore.svc.cluster.local and validates the SAN bookstore.bookstore.cluster.local",
+				"Route \"regex=/books\" of virtual host inbound_virtual-host|bookstore.bookstore allows principal bookbuyer.bookbuyer.cluster.local",
+				"[+] Pod 'bookbuyer/bookbuyer-1' can connect to service 'bookstore/bookstore' on port 14001",
+			},
+		},
+		{
+			name:        "connectivity verified with the trust domain of the MeshConfig",
+			trustDomain: "example.com",
+			srcReplace:  []string{"bookstore.bookstore.cluster.local", "bookstore.bookstore.example.com"},
+			dstReplace:  []string{"bookbuyer.bookbuyer.cluster.local", "bookbuyer.bookbuyer.example.com"},
+			expectedOutput: []string{
+				"Cluster bookstore/bookstore sets the SNI bookstore.bookstore.svc.cluster.local and validates the SAN bookstore.bookstore.example.com",
+				"Route \"regex=/books\" of virtual host inbound_virtual-host|bookstore.bookstore allows principal bookbuyer.bookbuyer.example.com",
+			},
+		},
+		{
+			name:       "connectivity verified to a headless service",
+			headless:   true,
+			srcReplace: []string{`"address_prefix":"10.96.0.10"`, `"address_prefix":"10.0.0.2"`},
+			expectedOutput: []string{
+				"Filter chain outbound-mesh-http-filter-chain:bookstore/bookstore matches port 14001 and IP 10.0.0.2",
+			},
+		},
+		{
+			name:            "outbound filter chain matching the pod IP of a service with a cluster IP",
+			srcReplace:      []string{`"address_prefix":"10.96.0.10"`, `"address_prefix":"10.0.0.2"`},
+			expectedErr:     `Connectivity broken at "Outbound filter chain": Filter chain outbound-mesh-http-filter-chain:bookstore/bookstore does not match the IP 10.96.0.10 of service bookstore/bookstore`,
+			expectedSkipped: 7,
+		},
+		{
+			name:            "no endpoints",
+			noEndpoints:     true,
+			expectedErr:     `Connectivity broken at "Destination pod": Service bookstore/bookstore has no Endpoints`,
+			expectedSkipped: 9,
+		},
+		{
+			name:            "missing outbound filter chain",
+			srcReplace:      []string{"outbound-mesh-http-filter-chain:bookstore/bookstore", "outbound-mesh-http-filter-chain:bookstore/bookstore-v2"},
+			expectedErr:     `Connectivity broken at "Outbound filter chain": outbound-listener has no filter chain for service bookstore/bookstore on port 14001`,
+			expectedSkipped: 7,
+		},
+		{
+			name:            "destination pod not an endpoint",
+			srcReplace:      []string{`"address":"10.0.0.2","port_value"`, `"address":"10.0.0.9","port_value"`},
+			expectedErr:     `Connectivity broken at "Upstream endpoints": 10.0.0.2:14001 of pod bookstore/bookstore-1 is not an endpoint of clusters bookstore/bookstore`,
+			expectedSkipped: 4,
+		},
+		{
+			name:            "upstream SAN mismatch",
+			srcReplace:      []string{`{"exact":"bookstore.bookstore.cluster.local"}`, `{"exact":"bookwarehouse.bookwarehouse.cluster.local"}`},
+			expectedErr:     `Connectivity broken at "Upstream SAN": Validation context root-cert-for-mtls-outbound:bookstore/bookstore does not match the SAN bookstore.bookstore.cluster.local of pod bookstore/bookstore-1, expected one of: bookwarehouse.bookwarehouse.cluster.local`,
+			expectedSkipped: 3,
+		},
+		{
+			name:            "missing inbound filter chain",
+			dstReplace:      []string{`"destination_port":14001`, `"destination_port":8080`},
+			expectedErr:     `Connectivity broken at "Inbound filter chain": inbound-listener has no filter chain for SNI bookstore.bookstore.svc.cluster.local on port 14001`,
+			expectedSkipped: 1,
+		},
+		{
+			name:        "principal not allowed",
+			dstReplace:  []string{`"exact":"bookbuyer.bookbuyer.cluster.local"`, `"exact":"bookthief.bookthief.cluster.local"`},
+			expectedErr: `Connectivity broken at "RBAC principal": No route of virtual host inbound_virtual-host|bookstore.bookstore of route configuration rds-inbound allows principal bookbuyer.bookbuyer.cluster.local`,
+		},
+		{
+			name: "principal denied by an AuthorizationPolicy",
+			dstReplace: []string{testInboundHCMConfig, `{"rds":{"route_config_name":"rds-inbound"},"http_filters":[
+				{"name":"osm.authz.deny","typed_config":{"rules":{"action":"DENY","policies":{"bookstore/deny-bookbuyer/0":{"permissions":[{"any":true}],"principals":[{"authenticated":{"principal_name":{"exact":"bookbuyer.bookbuyer.cluster.local"}}}]}}}}},
+				{"name":"envoy.filters.http.rbac"}]}`},
+			expectedErr: `Connectivity broken at "RBAC principal": The osm.authz.deny HTTP filter of filter chain inbound-mesh-http-filter-chain:bookstore/bookstore:14001 does not allow principal bookbuyer.bookbuyer.cluster.local`,
+		},
+		{
+			name: "principal not allowed by an AuthorizationPolicy",
+			dstReplace: []string{testInboundHCMConfig, `{"rds":{"route_config_name":"rds-inbound"},"http_filters":[
+				{"name":"osm.authz.allow","typed_config":{"rules":{"policies":{"bookstore/allow-bookthief/0":{"permissions":[{"any":true}],"principals":[{"authenticated":{"principal_name":{"exact":"bookthief.bookthief.cluster.local"}}}]}}}}},
+				{"name":"envoy.filters.http.rbac"}]}`},
+			expectedErr: `Connectivity broken at "RBAC principal": The osm.authz.allow HTTP filter of filter chain inbound-mesh-http-filter-chain:bookstore/bookstore:14001 does not allow principal bookbuyer.bookbuyer.cluster.local`,
+		},
+		{
+			name: "principal allowed by AuthorizationPolicies",
+			dstReplace: []string{testInboundHCMConfig, `{"rds":{"route_config_name":"rds-inbound"},"http_filters":[
+				{"name":"osm.authz.deny","typed_config":{"rules":{"action":"DENY","policies":{"bookstore/deny-bookthief/0":{"permissions":[{"any":true}],"principals":[{"authenticated":{"principal_name":{"exact":"bookthief.bookthief.cluster.local"}}}]}}}}},
+				{"name":"osm.authz.allow","typed_config":{"rules":{"policies":{"bookstore/allow-bookbuyer/0":{"permissions":[{"any":true}],"principals":[{"authenticated":{"principal_name":{"exact":"bookbuyer.bookbuyer.cluster.local"}}}]}}}}},
+				{"name":"envoy.filters.http.rbac"}]}`},
+			expectedOutput: []string{
+				"Route \"regex=/books\" of virtual host inbound_virtual-host|bookstore.bookstore allows principal bookbuyer.bookbuyer.cluster.local",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			out := new(bytes.Buffer)
+
+			fakeClient := fake.NewSimpleClientset()
+			createVerifyConnectivityTestResources(t, fakeClient, !tc.noEndpoints, tc.headless)
+
+			fakeConfigClient := fakeConfig.NewSimpleClientset()
+			_, err := fakeConfigClient.ConfigV1alpha1().MeshConfigs(settings.Namespace()).Create(context.TODO(), &configv1alpha1.MeshConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultOsmMeshConfigName},
+				Spec: configv1alpha1.MeshConfigSpec{
+					Certificate: configv1alpha1.CertificateSpec{TrustDomain: tc.trustDomain},
+				},
+			}, metav1.CreateOptions{})
+			trequire.NoError(t, err)
+
+			srcDump, dstDump := testSourceConfigDump, testDestinationConfigDump
+			if tc.srcReplace != nil {
+				srcDump = strings.ReplaceAll(srcDump, tc.srcReplace[0], tc.srcReplace[1])
+			}
+			if tc.dstReplace != nil {
+				dstDump = strings.ReplaceAll(dstDump, tc.dstReplace[0], tc.dstReplace[1])
+			}
+
+			cmd := &verifyConnectivityCmd{
+				out:              out,
+				from:             "bookbuyer/bookbuyer-1",
+				to:               "bookstore/bookstore:14001",
+				clientSet:        fakeClient,
+				meshConfigClient: fakeConfigClient,
+				getConfigDump: func(namespace, pod string) ([]byte, error) {
+					switch namespace + "/" + pod {
+					case "bookbuyer/bookbuyer-1":
+						return []byte(srcDump), nil
+					case "bookstore/bookstore-1":
+						return []byte(dstDump), nil
+					}
+					return nil, errors.Errorf("unexpected pod %s/%s", namespace, pod)
+				},
+			}
+
+			err = cmd.run()
+			if tc.expectedErr == "" {
+				assert.NoError(err)
+			} else {
+				trequire.Error(t, err)
+				assert.Equal(tc.expectedErr, err.Error())
+			}
+			for _, expected := range tc.expectedOutput {
+				assert.Contains(out.String(), expected)
+			}
+			assert.Equal(tc.expectedSkipped, strings.Count(out.String(), connectivityCheckSkip))
+		})
+	}
+}
+
+func createVerifyConnectivityTestResources(t *testing.T, fakeClient *fake.Clientset, withEndpoints bool, headless bool) {
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookbuyer-1",
+				Namespace: "bookbuyer",
+				Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: "6c6b3c3a-9b7d-4f0e-9c61-1b1f8c1a6f01"},
+			},
+			Spec:   corev1.PodSpec{ServiceAccountName: "bookbuyer"},
+			Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookstore-1",
+				Namespace: "bookstore",
+				Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: "0e2f6d7c-2a1b-4b2e-8a55-5d0b0f4a9c02", "app": "bookstore"},
+			},
+			Spec:   corev1.PodSpec{ServiceAccountName: "bookstore"},
+			Status: corev1.PodStatus{PodIP: "10.0.0.2"},
+		},
+	}
+	for _, pod := range pods {
+		_, err := fakeClient.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+		trequire.NoError(t, err)
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "10.96.0.10",
+			Selector:  map[string]string{"app": "bookstore"},
+			Ports:     []corev1.ServicePort{{Name: "http", Port: 14001}},
+		},
+	}
+	if headless {
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
+	}
+	_, err := fakeClient.CoreV1().Services(svc.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+	trequire.NoError(t, err)
+
+	if !withEndpoints {
+		return
+	}
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{
+				IP:        "10.0.0.2",
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "bookstore-1", Namespace: "bookstore"},
+			}},
+			Ports: []corev1.EndpointPort{{Name: "http", Port: 14001}},
+		}},
+	}
+	_, err = fakeClient.CoreV1().Endpoints(endpoints.Namespace).Create(context.TODO(), endpoints, metav1.CreateOptions{})
+	trequire.NoError(t, err)
+}
+
+func TestRBACAllowsPrincipal(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   string
+		expected bool
+	}{
+		{
+			name:     "network RBAC filter allowing the principal",
+			config:   `{"stat_prefix":"RBAC","rules":{"policies":{"bookstore/bookbuyer-access-bookstore":{"permissions":[{"any":true}],"principals":[{"authenticated":{"principal_name":{"exact":"bookbuyer.bookbuyer.cluster.local"}}}]}}}}`,
+			expected: true,
+		},
+		{
+			name:     "network RBAC filter allowing other principals",
+			config:   `{"stat_prefix":"RBAC","rules":{"policies":{"bookstore/bookthief-access-bookstore":{"permissions":[{"any":true}],"principals":[{"authenticated":{"principal_name":{"exact":"bookthief.bookthief.cluster.local"}}}]}}}}`,
+			expected: false,
+		},
+		{
+			name:     "any principal",
+			config:   `{"rbac":{"rules":{"policies":{"rbac-for-route":{"permissions":[{"any":true}],"principals":[{"any":true}]}}}}}`,
+			expected: true,
+		},
+		{
+			name:     "and principals",
+			config:   `{"rules":{"policies":{"p":{"principals":[{"and_ids":{"ids":[{"authenticated":{"principal_name":{"exact":"bookbuyer.bookbuyer.cluster.local"}}},{"any":true}]}}]}}}}`,
+			expected: true,
+		},
+		{
+			name:     "deny rules matching the principal",
+			config:   `{"rules":{"action":"DENY","policies":{"p":{"permissions":[{"any":true}],"principals":[{"authenticated":{"principal_name":{"exact":"bookbuyer.bookbuyer.cluster.local"}}}]}}}}`,
+			expected: false,
+		},
+		{
+			name:     "deny rules matching other principals",
+			config:   `{"rules":{"action":"DENY","policies":{"p":{"permissions":[{"any":true}],"principals":[{"authenticated":{"principal_name":{"exact":"bookthief.bookthief.cluster.local"}}}]}}}}`,
+			expected: true,
+		},
+		{
+			name:     "no policies",
+			config:   `{"rules":{}}`,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, rbacAllowsPrincipal([]byte(tc.config), "bookbuyer.bookbuyer.cluster.local"))
+		})
+	}
+}
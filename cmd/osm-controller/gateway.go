@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/ghodss/yaml"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/multicluster"
@@ -21,11 +23,23 @@ import (
 )
 
 const (
-	gatewayBootstrapSecretName = "osm-multicluster-gateway-bootstrap-config" // #nosec G101: Potential hardcoded credentials
-	bootstrapConfigKey         = "bootstrap.yaml"
+	gatewayBootstrapSecretName       = "osm-multicluster-gateway-bootstrap-config" // #nosec G101: Potential hardcoded credentials
+	egressGatewayBootstrapSecretName = "osm-egress-gateway-bootstrap-config"       // #nosec G101: Potential hardcoded credentials
+	bootstrapConfigKey               = "bootstrap.yaml"
 )
 
-func bootstrapOSMMulticlusterGateway(kubeClient kubernetes.Interface, certManager certificate.Manager, osmNamespace string) error {
+func bootstrapOSMMulticlusterGateway(kubeClient kubernetes.Interface, certManager certificate.Manager, osmNamespace, trustDomain string) error {
+	gatewayCN := multicluster.GetMulticlusterGatewaySubjectCommonName(osmServiceAccount, osmNamespace, trustDomain)
+	return bootstrapGateway(kubeClient, certManager, osmNamespace, gatewayBootstrapSecretName, gatewayCN)
+}
+
+func bootstrapOSMEgressGateway(kubeClient kubernetes.Interface, certManager certificate.Manager, osmNamespace, trustDomain string) error {
+	gatewayCN := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindEgressGateway, constants.EgressGatewayName, osmNamespace, trustDomain)
+	return bootstrapGateway(kubeClient, certManager, osmNamespace, egressGatewayBootstrapSecretName, gatewayCN)
+}
+
+// bootstrapGateway populates the bootstrap config in the given secret for the gateway with the given xDS certificate CN
+func bootstrapGateway(kubeClient kubernetes.Interface, certManager certificate.Manager, osmNamespace string, gatewayBootstrapSecretName string, gatewayCN certificate.CommonName) error {
 	secret, err := kubeClient.CoreV1().Secrets(osmNamespace).Get(context.Background(), gatewayBootstrapSecretName, metav1.GetOptions{})
 	if err != nil {
 		return errors.Errorf("Error fetching OSM gateway's bootstrap config %s/%s", osmNamespace, gatewayBootstrapSecretName)
@@ -40,7 +54,6 @@ func bootstrapOSMMulticlusterGateway(kubeClient kubernetes.Interface, certManage
 		return nil
 	}
 
-	gatewayCN := multicluster.GetMulticlusterGatewaySubjectCommonName(osmServiceAccount, osmNamespace)
 	bootstrapCert, err := certManager.IssueCertificate(gatewayCN, constants.XDSCertificateValidityPeriod)
 	if err != nil {
 		return errors.Errorf("Error issuing bootstrap certificate for OSM gateway: %s", err)
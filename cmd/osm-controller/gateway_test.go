@@ -13,6 +13,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/identity"
 )
 
 func TestBootstrapOSMMulticlusterGateway(t *testing.T) {
@@ -145,12 +146,41 @@ static_resources:
 				assert.Nil(err)
 			}
 
-			actual := bootstrapOSMMulticlusterGateway(fakeClient, fakeCertManager, testNs)
+			actual := bootstrapOSMMulticlusterGateway(fakeClient, fakeCertManager, testNs, identity.ClusterLocalTrustDomain)
 			assert.Equal(tc.expectError, actual != nil)
 		})
 	}
 }
 
+func TestBootstrapOSMEgressGateway(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	fakeCertManager := tresor.NewFakeCertManager(mockConfigurator)
+	mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(15 * time.Second).AnyTimes()
+	mockConfigurator.EXPECT().GetCertKeyBitSize().Return(2048).AnyTimes()
+
+	testNs := "test"
+	fakeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      egressGatewayBootstrapSecretName,
+			Namespace: testNs,
+		},
+		Data: map[string][]byte{
+			bootstrapConfigKey: []byte("-- placeholder --"),
+		},
+	})
+
+	assert.Nil(bootstrapOSMEgressGateway(fakeClient, fakeCertManager, testNs, identity.ClusterLocalTrustDomain))
+
+	secret, err := fakeClient.CoreV1().Secrets(testNs).Get(context.Background(), egressGatewayBootstrapSecretName, metav1.GetOptions{})
+	assert.Nil(err)
+	assert.True(isValidBootstrapData(secret.Data[bootstrapConfigKey]))
+	assert.Contains(string(secret.Data[bootstrapConfigKey]), ".egress-gateway.osm-egress-gateway.test")
+}
+
 func TestIsValidBootstrapData(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -23,6 +23,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/clientcmd"
+	gwapiClientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	policyClientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
@@ -43,11 +44,15 @@ import (
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/leaderelection"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/multicluster"
 	"github.com/openservicemesh/osm/pkg/policy"
 	"github.com/openservicemesh/osm/pkg/providers/kube"
+	"github.com/openservicemesh/osm/pkg/restarter"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/sharding"
 	"github.com/openservicemesh/osm/pkg/signals"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/validator"
@@ -67,6 +72,7 @@ var (
 	validatorWebhookConfigName string
 	caBundleSecretName         string
 	osmMeshConfigName          string
+	enableLeaderElection       bool
 
 	certProviderKind string
 
@@ -89,6 +95,7 @@ func init() {
 	flags.StringVar(&osmServiceAccount, "osm-service-account", "", "OSM controller's service account")
 	flags.StringVar(&validatorWebhookConfigName, "validator-webhook-config", "", "Name of the ValidatingWebhookConfiguration for the resource validator webhook")
 	flags.StringVar(&osmMeshConfigName, "osm-config-name", "osm-mesh-config", "Name of the OSM MeshConfig")
+	flags.BoolVar(&enableLeaderElection, "enable-leader-election", true, "Elect a leader among the osm-controller replicas to perform singleton work")
 
 	// Generic certificate manager/provider options
 	flags.StringVar(&certProviderKind, "certificate-manager", providers.TresorKind.String(), fmt.Sprintf("Certificate manager, one of [%v]", providers.ValidCertificateProviders))
@@ -150,6 +157,23 @@ func main() {
 	// Start the default metrics store
 	startMetricsStore()
 
+	// All replicas serve proxies, while singleton work such as updating shared resources and resource statuses
+	// is only performed by the elected leader. Certificate rotation is the exception: each replica caches the
+	// certificates it issued for its own proxies, so the rotation started by the certificate provider runs on
+	// every replica. Rotating a certificate is the same issuance every replica performs for its proxies, the new
+	// certificate only replaces the entry of the replica's cache and is pushed to its own proxies over SDS.
+	var elector *leaderelection.Elector
+	if enableLeaderElection {
+		elector = leaderelection.NewElector(kubeClient, osmNamespace, constants.OSMControllerLeaseName, controllerPod.Name)
+	}
+	runAsLeader := func(name string, task leaderelection.Task) {
+		if elector == nil {
+			go task(stop)
+			return
+		}
+		elector.RunWhenLeader(name, task)
+	}
+
 	// This component will be watching the OSM MeshConfig and will make it available
 	// to the rest of the components.
 	cfg := configurator.NewConfigurator(configClientset.NewForConfigOrDie(kubeConfig), stop, osmNamespace, osmMeshConfigName)
@@ -157,7 +181,16 @@ func main() {
 	// Start Global log level handler, reads from configurator (meshconfig)
 	StartGlobalLogLevelHandler(cfg, stop)
 
-	k8sClient, err := k8s.NewKubernetesController(kubeClient, policyClient, meshName, stop)
+	// Secrets are only cached when Egress policies, which reference them for TLS origination, are enabled
+	k8sInformers := []k8s.InformerKey{k8s.Namespaces, k8s.Services, k8s.ServiceAccounts, k8s.Pods, k8s.Endpoints}
+	if cfg.GetFeatureFlags().EnableEgressPolicy {
+		k8sInformers = append(k8sInformers, k8s.Secrets)
+	}
+	// ConfigMaps are only cached when EnvoyFilterExtension resources, which reference them for WASM modules, are enabled
+	if cfg.GetFeatureFlags().EnableEnvoyFilterExtension {
+		k8sInformers = append(k8sInformers, k8s.ConfigMaps)
+	}
+	k8sClient, err := k8s.NewKubernetesController(kubeClient, policyClient, meshName, stop, k8sInformers...)
 	if err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating Kubernetes Controller")
 	}
@@ -177,18 +210,30 @@ func main() {
 
 	if cfg.GetFeatureFlags().EnableMulticlusterMode {
 		log.Info().Msgf("Bootstrapping OSM multicluster gateway")
-		if err := bootstrapOSMMulticlusterGateway(kubeClient, certManager, osmNamespace); err != nil {
+		if err := bootstrapOSMMulticlusterGateway(kubeClient, certManager, osmNamespace, cfg.GetTrustDomain()); err != nil {
 			events.GenericEventRecorder().FatalEvent(err, events.InitializationError,
 				"Error bootstraping OSM multicluster gateway")
 		}
 	}
 
+	if cfg.GetFeatureFlags().EnableEgressGateway {
+		log.Info().Msgf("Bootstrapping OSM egress gateway")
+		if err := bootstrapOSMEgressGateway(kubeClient, certManager, osmNamespace, cfg.GetTrustDomain()); err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError,
+				"Error bootstraping OSM egress gateway")
+		}
+	}
+
 	var configClient config.Controller
 
 	if cfg.GetFeatureFlags().EnableMulticlusterMode {
 		if configClient, err = config.NewConfigController(kubeConfig, k8sClient, stop); err != nil {
 			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating Kubernetes config client")
 		}
+
+		// Keep the MultiClusterService resources in sync with the services exported by remote clusters
+		discoverer := multicluster.NewDiscoverer(kubeClient, configClientset.NewForConfigOrDie(kubeConfig), osmNamespace)
+		runAsLeader("multicluster-discoverer", discoverer.Run)
 	}
 
 	// A nil configClient is passed in if multi cluster mode is not enabled.
@@ -197,10 +242,15 @@ func main() {
 	endpointsProviders := []endpoint.Provider{kubeProvider}
 	serviceProviders := []service.Provider{kubeProvider}
 
-	ingressClient, err := ingress.NewIngressClient(kubeClient, k8sClient, stop, cfg, certManager)
+	ingressClient, err := ingress.NewIngressClient(kubeClient, gwapiClientset.NewForConfigOrDie(kubeConfig), k8sClient, stop, cfg, certManager)
 	if err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating Ingress monitor client")
 	}
+	runAsLeader("ingress-gateway-cert", func(stop <-chan struct{}) {
+		if err := ingress.ProvisionIngressGatewayCert(kubeClient, cfg, certManager, stop); err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error provisioning ingress gateway certificate")
+		}
+	})
 
 	policyController, err := policy.NewPolicyController(k8sClient, policyClient, stop)
 	if err != nil {
@@ -218,10 +268,14 @@ func main() {
 		serviceProviders,
 		endpointsProviders,
 	)
+	if elector != nil {
+		meshCatalog.SetLeaderCheck(elector.IsLeader)
+	}
+	runAsLeader("egress-status-updater", meshCatalog.StartEgressStatusUpdater)
 
 	var proxyMapper registry.ProxyServiceMapper
 	if cfg.GetFeatureFlags().EnableAsyncProxyServiceMapping {
-		m := registry.NewAsyncKubeProxyServiceMapper(k8sClient)
+		m := registry.NewAsyncKubeProxyServiceMapper(k8sClient, cfg.GetTrustDomain())
 		m.Run(stop)
 		proxyMapper = m
 	} else {
@@ -237,6 +291,22 @@ func main() {
 
 	// Create and start the ADS gRPC service
 	xdsServer := ads.NewADSServer(meshCatalog, proxyRegistry, cfg.IsDebugServerEnabled(), osmNamespace, cfg, certManager, k8sClient)
+
+	// When enabled, the proxies are sharded across the ready osm-controller replicas by consistent hashing on
+	// the proxy UUID, and each replica only serves the proxies it owns.
+	var shardDebugger debugger.ShardDebugger
+	if cfg.GetFeatureFlags().EnableADSSharding {
+		if cfg.GetFeatureFlags().EnableSnapshotCacheMode {
+			log.Warn().Msg("ADS sharding is not supported in snapshot cache mode, proxies are not sharded")
+		} else {
+			shardManager, err := sharding.NewManager(kubeClient, osmNamespace, constants.OSMControllerName, controllerPod.Name, stop)
+			if err != nil {
+				events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating the ADS shard manager")
+			}
+			xdsServer.SetShardManager(shardManager)
+			shardDebugger = shardManager
+		}
+	}
 	if err := xdsServer.Start(ctx, cancel, constants.ADSServerPort, adsCert); err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error initializing ADS server")
 	}
@@ -250,9 +320,14 @@ func main() {
 		events.GenericEventRecorder().FatalEvent(err, events.CertificateIssuanceFailure, "Error issuing certificate for the validating webhook")
 	}
 
-	if err := validator.NewValidatingWebhook(validatorWebhookConfigName, constants.ValidatorWebhookPort, webhookHandlerCert, kubeClient, stop); err != nil {
+	if err := validator.NewValidatingWebhook(constants.ValidatorWebhookPort, webhookHandlerCert, stop); err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error starting the validating webhook server")
 	}
+	runAsLeader("validating-webhook-ca-bundle", func(_ <-chan struct{}) {
+		if err := validator.UpdateValidatingWebhookCABundle(validatorWebhookConfigName, webhookHandlerCert, kubeClient); err != nil {
+			log.Error().Err(err).Msgf("Error configuring ValidatingWebhookConfiguration %s", validatorWebhookConfigName)
+		}
+	})
 
 	// Initialize OSM's http service server
 	httpServer := httpserver.NewHTTPServer(constants.OSMHTTPServerPort)
@@ -277,10 +352,21 @@ func main() {
 
 	// Create DebugServer and start its config event listener.
 	// Listener takes care to start and stop the debug server as appropriate
-	debugConfig := debugger.NewDebugConfig(certDebugger, xdsServer, meshCatalog, proxyRegistry, kubeConfig, kubeClient, cfg, k8sClient)
+	debugConfig := debugger.NewDebugConfig(certDebugger, xdsServer, meshCatalog, proxyRegistry, kubeConfig, kubeClient, cfg, k8sClient, shardDebugger)
 	debugConfig.StartDebugServerConfigListener()
 
-	k8s.PatchSecretHandler(kubeClient)
+	// Start the restarter, which performs rolling restarts of workloads with outdated sidecars when enabled in the MeshConfig
+	runAsLeader("restarter", restarter.NewRestarter(kubeClient, k8sClient, cfg).Run)
+
+	runAsLeader("bootstrap-secret-patcher", func(stop <-chan struct{}) {
+		handlerStop := k8s.PatchSecretHandler(kubeClient)
+		<-stop
+		close(handlerStop)
+	})
+
+	if elector != nil {
+		elector.Run(stop)
+	}
 
 	<-stop
 	log.Info().Msgf("Stopping osm-controller %s; %s; %s", version.Version, version.GitCommit, version.BuildDate)
@@ -293,10 +379,19 @@ func startMetricsStore() {
 		metricsstore.DefaultMetricsStore.ProxyConnectCount,
 		metricsstore.DefaultMetricsStore.ProxyReconnectCount,
 		metricsstore.DefaultMetricsStore.ProxyConfigUpdateTime,
+		metricsstore.DefaultMetricsStore.ProxyXDSPushCount,
+		metricsstore.DefaultMetricsStore.ProxyXDSAckCount,
+		metricsstore.DefaultMetricsStore.ProxyXDSNackCount,
+		metricsstore.DefaultMetricsStore.ProxyXDSAckTime,
 		metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount,
+		metricsstore.DefaultMetricsStore.ProxyTargetedBroadcastEventCount,
+		metricsstore.DefaultMetricsStore.ProxyPushAvoidedCount,
 		metricsstore.DefaultMetricsStore.CertIssuedCount,
 		metricsstore.DefaultMetricsStore.CertIssuedTime,
 		metricsstore.DefaultMetricsStore.ErrCodeCounter,
+		metricsstore.DefaultMetricsStore.ControllerIsLeader,
+		metricsstore.DefaultMetricsStore.ControllerShardReplicas,
+		metricsstore.DefaultMetricsStore.ProxyShardRedirectCount,
 	)
 }
 
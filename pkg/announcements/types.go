@@ -22,6 +22,9 @@ const (
 	// ProxyBroadcast is used to notify all Proxy streams that they need to trigger an update
 	ProxyBroadcast AnnouncementType = "proxy-broadcast"
 
+	// ADSShardsRebalanced is used to notify all Proxy streams that the proxies were redistributed across the osm-controller replicas
+	ADSShardsRebalanced AnnouncementType = "ads-shards-rebalanced"
+
 	// PodAdded is the type of announcement emitted when we observe an addition of a Kubernetes Pod
 	PodAdded AnnouncementType = "pod-added"
 
@@ -77,6 +80,28 @@ const (
 
 	// ---
 
+	// SecretAdded is the type of announcement emitted when we observe an addition of a Kubernetes Secret
+	SecretAdded AnnouncementType = "secret-added"
+
+	// SecretDeleted the type of announcement emitted when we observe the deletion of a Kubernetes Secret
+	SecretDeleted AnnouncementType = "secret-deleted"
+
+	// SecretUpdated is the type of announcement emitted when we observe an update to a Kubernetes Secret
+	SecretUpdated AnnouncementType = "secret-updated"
+
+	// ---
+
+	// ConfigMapAdded is the type of announcement emitted when we observe an addition of a Kubernetes ConfigMap
+	ConfigMapAdded AnnouncementType = "configmap-added"
+
+	// ConfigMapDeleted the type of announcement emitted when we observe the deletion of a Kubernetes ConfigMap
+	ConfigMapDeleted AnnouncementType = "configmap-deleted"
+
+	// ConfigMapUpdated is the type of announcement emitted when we observe an update to a Kubernetes ConfigMap
+	ConfigMapUpdated AnnouncementType = "configmap-updated"
+
+	// ---
+
 	// TrafficSplitAdded is the type of announcement emitted when we observe an addition of a Kubernetes TrafficSplit
 	TrafficSplitAdded AnnouncementType = "trafficsplit-added"
 
@@ -132,6 +157,26 @@ const (
 
 	// ---
 
+	// GatewayAdded is the type of announcement emitted when we observe an addition of a networking.x-k8s.io Gateway
+	GatewayAdded AnnouncementType = "gateway-added"
+
+	// GatewayDeleted the type of announcement emitted when we observe the deletion of a networking.x-k8s.io Gateway
+	GatewayDeleted AnnouncementType = "gateway-deleted"
+
+	// GatewayUpdated is the type of announcement emitted when we observe an update to a networking.x-k8s.io Gateway
+	GatewayUpdated AnnouncementType = "gateway-updated"
+
+	// HTTPRouteAdded is the type of announcement emitted when we observe an addition of a networking.x-k8s.io HTTPRoute
+	HTTPRouteAdded AnnouncementType = "httproute-added"
+
+	// HTTPRouteDeleted the type of announcement emitted when we observe the deletion of a networking.x-k8s.io HTTPRoute
+	HTTPRouteDeleted AnnouncementType = "httproute-deleted"
+
+	// HTTPRouteUpdated is the type of announcement emitted when we observe an update to a networking.x-k8s.io HTTPRoute
+	HTTPRouteUpdated AnnouncementType = "httproute-updated"
+
+	// ---
+
 	// CertificateRotated is the type of announcement emitted when a certificate is rotated by the certificate provider
 	CertificateRotated AnnouncementType = "certificate-rotated"
 
@@ -166,6 +211,28 @@ const (
 	// IngressBackendUpdated is the type of announcement emitted when we observe an update to ingressbackends.policy.openservicemesh.io
 	IngressBackendUpdated AnnouncementType = "ingressbackend-updated"
 
+	// AuthorizationPolicyAdded is the type of announcement emitted when we observe an addition of authorizationpolicies.policy.openservicemesh.io
+	AuthorizationPolicyAdded AnnouncementType = "authorizationpolicy-added"
+
+	// AuthorizationPolicyDeleted the type of announcement emitted when we observe a deletion of authorizationpolicies.policy.openservicemesh.io
+	AuthorizationPolicyDeleted AnnouncementType = "authorizationpolicy-deleted"
+
+	// AuthorizationPolicyUpdated is the type of announcement emitted when we observe an update to authorizationpolicies.policy.openservicemesh.io
+	AuthorizationPolicyUpdated AnnouncementType = "authorizationpolicy-updated"
+
+	// EnvoyFilterExtensionAdded is the type of announcement emitted when we observe an addition of envoyfilterextensions.policy.openservicemesh.io
+	EnvoyFilterExtensionAdded AnnouncementType = "envoyfilterextension-added"
+
+	// EnvoyFilterExtensionDeleted the type of announcement emitted when we observe a deletion of envoyfilterextensions.policy.openservicemesh.io
+	EnvoyFilterExtensionDeleted AnnouncementType = "envoyfilterextension-deleted"
+
+	// EnvoyFilterExtensionUpdated is the type of announcement emitted when we observe an update to envoyfilterextensions.policy.openservicemesh.io
+	EnvoyFilterExtensionUpdated AnnouncementType = "envoyfilterextension-updated"
+
+	// WASMModulePulled is the type of announcement emitted when the WASM module of an image referenced by
+	// envoyfilterextensions.policy.openservicemesh.io has been pulled
+	WASMModulePulled AnnouncementType = "wasm-module-pulled"
+
 	// ---
 
 	// MultiClusterServiceAdded is the type of announcement emitted when we observe an addition of a multiclusterservice.config.openservicemesh.io
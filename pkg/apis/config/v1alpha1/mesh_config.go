@@ -63,8 +63,28 @@ type SidecarSpec struct {
 	// ConfigResyncInterval defines the resync interval for regular proxy broadcast updates.
 	ConfigResyncInterval string `json:"configResyncInterval,omitempty"`
 
+	// ConfigUpdateDebounceWindow defines the window over which configuration changes are coalesced before the proxies
+	// depending on them are updated. The window restarts on every change.
+	ConfigUpdateDebounceWindow string `json:"configUpdateDebounceWindow,omitempty"`
+
+	// ConfigUpdateMaxDelay defines the maximum delay of a proxy update while configuration changes keep being coalesced.
+	ConfigUpdateMaxDelay string `json:"configUpdateMaxDelay,omitempty"`
+
 	// Resources defines the compute resources for the sidecar.
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// EnableStaleSidecarRestart defines a boolean indicating whether workloads running a sidecar that is out of date
+	// with the configured sidecar images are automatically restarted.
+	EnableStaleSidecarRestart bool `json:"enableStaleSidecarRestart,omitempty"`
+
+	// StaleSidecarRestartInterval defines the minimum interval between two automatic workload restarts.
+	StaleSidecarRestartInterval string `json:"staleSidecarRestartInterval,omitempty"`
+
+	// WASMImageRegistries defines the hosts, optionally with a port, of the registries the WASM modules of
+	// EnvoyFilterExtension resources may be pulled from. Images from any other registry are rejected. The
+	// authorization server of a registry must be on the registry's host or on an allowed host, e.g. auth.docker.io
+	// for docker.io.
+	WASMImageRegistries []string `json:"wasmImageRegistries,omitempty"`
 }
 
 // TrafficSpec is the type used to represent OSM's traffic management configuration.
@@ -102,6 +122,103 @@ type ObservabilitySpec struct {
 
 	// Tracing defines OSM's tracing configuration.
 	Tracing TracingSpec `json:"tracing,omitempty"`
+
+	// AccessLog defines the access logging configuration of the sidecars.
+	// It can be overridden for the sidecars in a namespace using the 'openservicemesh.io/access-log' namespace annotation.
+	// +optional
+	AccessLog AccessLogSpec `json:"accessLog,omitempty"`
+}
+
+// AccessLogSpec is the type to represent the access logging configuration of the sidecars.
+type AccessLogSpec struct {
+	// Enable defines whether access logging is enabled. Defaults to true if unspecified.
+	// +optional
+	Enable *bool `json:"enable,omitempty"`
+
+	// Format defines the format of the access log entries. Defaults to json if unspecified.
+	// It is not applicable to the grpc sink, which uses the Envoy access log service format.
+	// +optional
+	Format AccessLogFormat `json:"format,omitempty"`
+
+	// TextFormat defines the Envoy format string of the access log entries when the format is text.
+	// Defaults to Envoy's default format string if unspecified.
+	// +optional
+	TextFormat string `json:"textFormat,omitempty"`
+
+	// AdditionalFields defines additional fields added to the JSON access log entries, as a map of
+	// field names to Envoy command operators, ex. "peer_identity": "%DOWNSTREAM_PEER_URI_SAN%".
+	// +optional
+	AdditionalFields map[string]string `json:"additionalFields,omitempty"`
+
+	// Filter defines the filters restricting the requests that are logged.
+	// +optional
+	Filter AccessLogFilterSpec `json:"filter,omitempty"`
+
+	// SamplingPercentage defines the percentage of requests that are logged, from 0 to 100.
+	// Defaults to 100 if unspecified.
+	// +optional
+	SamplingPercentage *float64 `json:"samplingPercentage,omitempty"`
+
+	// Sink defines where the access log entries are sent to. Defaults to stdout if unspecified.
+	// +optional
+	Sink AccessLogSinkSpec `json:"sink,omitempty"`
+}
+
+// AccessLogFormat is the type to represent the format of access log entries.
+type AccessLogFormat string
+
+const (
+	// AccessLogFormatJSON is the JSON access log format
+	AccessLogFormatJSON AccessLogFormat = "json"
+
+	// AccessLogFormatText is the text access log format
+	AccessLogFormatText AccessLogFormat = "text"
+)
+
+// AccessLogFilterSpec is the type to represent the filters restricting the requests that are logged.
+type AccessLogFilterSpec struct {
+	// MinStatusCode defines the minimum response status code of the requests that are logged,
+	// ex. 400 to only log failed requests.
+	// +optional
+	MinStatusCode uint32 `json:"minStatusCode,omitempty"`
+
+	// ExcludePathPrefixes defines the path prefixes of the requests that are not logged,
+	// ex. the paths of health check requests.
+	// +optional
+	ExcludePathPrefixes []string `json:"excludePathPrefixes,omitempty"`
+}
+
+// AccessLogSinkType is the type to represent the type of an access log sink.
+type AccessLogSinkType string
+
+const (
+	// AccessLogSinkStdout is the sink type writing access log entries to the sidecar's stdout
+	AccessLogSinkStdout AccessLogSinkType = "stdout"
+
+	// AccessLogSinkGRPC is the sink type sending access log entries to an Envoy gRPC access log service (ALS)
+	AccessLogSinkGRPC AccessLogSinkType = "grpc"
+
+	// AccessLogSinkOpenTelemetry is the sink type sending access log entries to an OpenTelemetry collector using OTLP over gRPC
+	AccessLogSinkOpenTelemetry AccessLogSinkType = "opentelemetry"
+)
+
+// AccessLogSinkSpec is the type to represent the sink access log entries are sent to.
+type AccessLogSinkSpec struct {
+	// Type defines the type of the sink.
+	// +optional
+	Type AccessLogSinkType `json:"type,omitempty"`
+
+	// Address defines the hostname of the collector, required for the grpc and opentelemetry sinks.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Port defines the port of the collector, required for the grpc and opentelemetry sinks.
+	// +optional
+	Port uint16 `json:"port,omitempty"`
+
+	// LogName defines the name of the log reported to the collector. Defaults to 'osm' if unspecified.
+	// +optional
+	LogName string `json:"logName,omitempty"`
 }
 
 // TracingSpec is the type to represent OSM's tracing configuration.
@@ -116,7 +233,57 @@ type TracingSpec struct {
 	Address string `json:"address,omitempty"`
 
 	// Endpoint defines the API endpoint for tracing requests sent to the collector.
+	// It is only applicable to the Zipkin tracing provider.
 	Endpoint string `json:"endpoint,omitempty"`
+
+	// Provider defines the tracing provider the sidecars use to export spans to the collector.
+	// Defaults to zipkin if unspecified. Tracing is disabled on the sidecars if the provider is not supported.
+	// +optional
+	Provider TracingProvider `json:"provider,omitempty"`
+
+	// SamplingPercentage defines the percentage of requests that are sampled for tracing, from 0 to 100.
+	// Defaults to 100 if unspecified.
+	// +optional
+	SamplingPercentage *float64 `json:"samplingPercentage,omitempty"`
+
+	// CustomTags defines the custom tags added to the spans.
+	// +optional
+	CustomTags []TracingCustomTagSpec `json:"customTags,omitempty"`
+}
+
+// TracingProvider is the type to represent a tracing provider.
+type TracingProvider string
+
+const (
+	// TracingProviderZipkin is the Zipkin tracing provider, exporting spans using the Zipkin HTTP JSON API.
+	TracingProviderZipkin TracingProvider = "zipkin"
+
+	// TracingProviderDatadog is the Datadog tracing provider, exporting spans to a Datadog agent.
+	TracingProviderDatadog TracingProvider = "datadog"
+
+	// TracingProviderOpenTelemetry is the OpenTelemetry tracing provider, exporting spans to an OpenTelemetry
+	// collector over OTLP/gRPC. The spans of a proxy are reported under its service identity as service name,
+	// which requires an Envoy sidecar image v1.24 or later.
+	TracingProviderOpenTelemetry TracingProvider = "opentelemetry"
+)
+
+// TracingCustomTagSpec is the type to represent a custom tag added to the spans.
+// Exactly one of Literal, RequestHeader or Environment must be specified.
+type TracingCustomTagSpec struct {
+	// Tag defines the name of the tag.
+	Tag string `json:"tag"`
+
+	// Literal defines a static value for the tag.
+	// +optional
+	Literal string `json:"literal,omitempty"`
+
+	// RequestHeader defines the name of the request header whose value is used for the tag.
+	// +optional
+	RequestHeader string `json:"requestHeader,omitempty"`
+
+	// Environment defines the name of the sidecar's environment variable whose value is used for the tag.
+	// +optional
+	Environment string `json:"environment,omitempty"`
 }
 
 // ExternalAuthzSpec is a type to represent external authorization configuration.
@@ -150,6 +317,12 @@ type CertificateSpec struct {
 	// CertKeyBitSize defines the certicate key bit size.
 	CertKeyBitSize int `json:"certKeyBitSize,omitempty"`
 
+	// TrustDomain defines the trust domain of the service identities in this cluster, defaults to cluster.local.
+	// In multicluster mode, each cluster is expected to use the trust domain <cluster-name>.cluster.local
+	// so that the identities of remote clusters can be verified.
+	// +optional
+	TrustDomain string `json:"trustDomain,omitempty"`
+
 	// IngressGateway defines the certificate specification for an ingress gateway.
 	// +optional
 	IngressGateway *IngressGatewayCertSpec `json:"ingressGateway,omitempty"`
@@ -179,14 +352,22 @@ type MeshConfigList struct {
 // FeatureFlags is a type to represent OSM's feature flags.
 type FeatureFlags struct {
 	// EnableWASMStats defines if WASM Stats are enabled.
+	// TCP stats are only recorded for outbound connections, the peer workload of an inbound TCP connection is not known to the proxy.
 	EnableWASMStats bool `json:"enableWASMStats,omitempty"`
 
 	// EnableEgressPolicy defines if OSM's Egress policy is enabled.
+	// The secrets referenced by Egress policies for TLS origination are only watched if the feature is
+	// enabled when osm-controller starts.
 	EnableEgressPolicy bool `json:"enableEgressPolicy,omitempty"`
 
 	// EnableMulticlusterMode defines if Multicluster mode is enabled.
 	EnableMulticlusterMode bool `json:"enableMulticlusterMode,omitempty"`
 
+	// EnableEgressGateway defines if HTTP traffic allowed by Egress policies is routed through the egress gateway
+	// instead of being sent to external hosts directly from the sidecar. When enabled, the HTTPS and TCP ports and
+	// the wildcard hosts of Egress policies are ignored, as the egress gateway cannot proxy this traffic.
+	EnableEgressGateway bool `json:"enableEgressGateway,omitempty"`
+
 	// EnableSnapshotCacheMode defines if XDS server starts with snapshot cache.
 	EnableSnapshotCacheMode bool `json:"enableSnapshotCacheMode,omitempty"`
 
@@ -203,4 +384,19 @@ type FeatureFlags struct {
 	// EnableEnvoyActiveHealthChecks defines if OSM will Envoy active health
 	// checks between services allowed to communicate.
 	EnableEnvoyActiveHealthChecks bool `json:"enableEnvoyActiveHealthChecks,omitempty"`
+
+	// EnableAuthorizationPolicy defines if OSM's AuthorizationPolicy API is enabled to allow or deny
+	// inbound HTTP requests in addition to SMI traffic policies.
+	EnableAuthorizationPolicy bool `json:"enableAuthorizationPolicy,omitempty"`
+
+	// EnableADSSharding defines if the proxies are distributed across the osm-controller replicas by
+	// consistent hashing on the proxy UUID, such that each replica only serves its shard of the proxies.
+	// A proxy connecting to a replica that does not own it reconnects to the next replica, which takes up to
+	// N-1 retries with Envoy's exponential backoff for N replicas. Pods injected before sharding is enabled
+	// must be restarted to connect to all the replicas.
+	EnableADSSharding bool `json:"enableADSSharding,omitempty"`
+
+	// EnableEnvoyFilterExtension defines if OSM's EnvoyFilterExtension API is enabled to add user-supplied
+	// WASM and Lua HTTP filters to the proxies.
+	EnableEnvoyFilterExtension bool `json:"enableEnvoyFilterExtension,omitempty"`
 }
@@ -42,7 +42,8 @@ type MultiClusterServiceSpec struct {
 // ClusterSpec is the type used to represent a remote cluster in multicluster scenarios.
 type ClusterSpec struct {
 
-	// Address defines the remote IP address of the gateway
+	// Address defines the remote address of the gateway as <host>:<port>,
+	// where host is an IP address or a DNS name.
 	Address string `json:"address,omitempty"`
 
 	// Name defines the name of the remote cluster.
@@ -41,6 +41,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&MeshConfigList{},
 		&MultiClusterService{},
 		&MultiClusterServiceList{},
+		&RemoteCluster{},
+		&RemoteClusterList{},
 	)
 
 	metav1.AddToGroupVersion(
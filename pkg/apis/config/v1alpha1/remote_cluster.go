@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemoteCluster is the type used to represent a remote cluster whose exported services are discovered
+// and kept in sync with the MultiClusterService resources in the local cluster.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RemoteCluster struct {
+	// Object's type metadata.
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+
+	// Object's metadata.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// Spec is the RemoteCluster specification.
+	Spec RemoteClusterSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// RemoteClusterSpec is the type used to represent the remote cluster specification.
+type RemoteClusterSpec struct {
+	// KubeconfigSecret defines the Secret holding the kubeconfig used to access the remote cluster.
+	// The kubeconfig is read from the 'kubeconfig' key of the Secret.
+	KubeconfigSecret corev1.SecretReference `json:"kubeconfigSecret"`
+
+	// Gateway defines the multicluster gateway Service of the remote cluster.
+	// +optional
+	Gateway RemoteGatewaySpec `json:"gateway,omitempty"`
+}
+
+// RemoteGatewaySpec is the type used to represent the multicluster gateway Service of a remote cluster.
+type RemoteGatewaySpec struct {
+	// Name defines the name of the gateway Service, defaults to osm-multicluster-gateway.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace defines the namespace of the gateway Service, defaults to the OSM namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// RemoteClusterList defines the list of RemoteCluster objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RemoteClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []RemoteCluster `json:"items"`
+}
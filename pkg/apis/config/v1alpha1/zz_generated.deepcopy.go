@@ -21,6 +21,78 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogFilterSpec) DeepCopyInto(out *AccessLogFilterSpec) {
+	*out = *in
+	if in.ExcludePathPrefixes != nil {
+		in, out := &in.ExcludePathPrefixes, &out.ExcludePathPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogFilterSpec.
+func (in *AccessLogFilterSpec) DeepCopy() *AccessLogFilterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogFilterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogSinkSpec) DeepCopyInto(out *AccessLogSinkSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogSinkSpec.
+func (in *AccessLogSinkSpec) DeepCopy() *AccessLogSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogSpec) DeepCopyInto(out *AccessLogSpec) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdditionalFields != nil {
+		in, out := &in.AdditionalFields, &out.AdditionalFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Filter.DeepCopyInto(&out.Filter)
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(float64)
+		**out = **in
+	}
+	out.Sink = in.Sink
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogSpec.
+func (in *AccessLogSpec) DeepCopy() *AccessLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 	*out = *in
@@ -177,7 +249,7 @@ func (in *MeshConfigSpec) DeepCopyInto(out *MeshConfigSpec) {
 	*out = *in
 	in.Sidecar.DeepCopyInto(&out.Sidecar)
 	in.Traffic.DeepCopyInto(&out.Traffic)
-	out.Observability = in.Observability
+	in.Observability.DeepCopyInto(&out.Observability)
 	in.Certificate.DeepCopyInto(&out.Certificate)
 	out.FeatureFlags = in.FeatureFlags
 	return
@@ -282,7 +354,8 @@ func (in *MultiClusterServiceSpec) DeepCopy() *MultiClusterServiceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObservabilitySpec) DeepCopyInto(out *ObservabilitySpec) {
 	*out = *in
-	out.Tracing = in.Tracing
+	in.Tracing.DeepCopyInto(&out.Tracing)
+	in.AccessLog.DeepCopyInto(&out.AccessLog)
 	return
 }
 
@@ -312,10 +385,109 @@ func (in *PortSpec) DeepCopy() *PortSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCluster) DeepCopyInto(out *RemoteCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCluster.
+func (in *RemoteCluster) DeepCopy() *RemoteCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterList) DeepCopyInto(out *RemoteClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterList.
+func (in *RemoteClusterList) DeepCopy() *RemoteClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterSpec) DeepCopyInto(out *RemoteClusterSpec) {
+	*out = *in
+	out.KubeconfigSecret = in.KubeconfigSecret
+	out.Gateway = in.Gateway
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterSpec.
+func (in *RemoteClusterSpec) DeepCopy() *RemoteClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteGatewaySpec) DeepCopyInto(out *RemoteGatewaySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteGatewaySpec.
+func (in *RemoteGatewaySpec) DeepCopy() *RemoteGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarSpec) DeepCopyInto(out *SidecarSpec) {
 	*out = *in
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.WASMImageRegistries != nil {
+		in, out := &in.WASMImageRegistries, &out.WASMImageRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -329,9 +501,35 @@ func (in *SidecarSpec) DeepCopy() *SidecarSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingCustomTagSpec) DeepCopyInto(out *TracingCustomTagSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingCustomTagSpec.
+func (in *TracingCustomTagSpec) DeepCopy() *TracingCustomTagSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingCustomTagSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracingSpec) DeepCopyInto(out *TracingSpec) {
 	*out = *in
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(float64)
+		**out = **in
+	}
+	if in.CustomTags != nil {
+		in, out := &in.CustomTags, &out.CustomTags
+		*out = make([]TracingCustomTagSpec, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -0,0 +1,115 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuthorizationPolicy is the type used to represent an authorization policy.
+// An authorization policy allows or denies inbound HTTP requests to one or more services
+// in its namespace, in addition to the SMI traffic policies applicable to the services.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AuthorizationPolicy struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the authorization policy specification
+	// +optional
+	Spec AuthorizationPolicySpec `json:"spec,omitempty"`
+}
+
+// AuthorizationAction is the type used to represent the action of an authorization policy.
+type AuthorizationAction string
+
+const (
+	// AuthorizationActionAllow is the action corresponding to allowing the requests matching an authorization policy.
+	// When ALLOW policies apply to a service, a request to the service must match at least one of them.
+	AuthorizationActionAllow AuthorizationAction = "ALLOW"
+
+	// AuthorizationActionDeny is the action corresponding to denying the requests matching an authorization policy.
+	AuthorizationActionDeny AuthorizationAction = "DENY"
+)
+
+// AuthorizationPolicySpec is the type used to represent the AuthorizationPolicy specification.
+type AuthorizationPolicySpec struct {
+	// Services defines the names of the services in the policy's namespace the policy applies to.
+	// If unspecified, the policy applies to all the services in the namespace.
+	// +optional
+	Services []string `json:"services,omitempty"`
+
+	// Action defines whether the requests matching the policy are allowed or denied.
+	Action AuthorizationAction `json:"action"`
+
+	// Rules defines the list of rules a request is matched against. A request matches the policy
+	// if it matches any of the rules. An ALLOW policy must specify at least one rule.
+	Rules []AuthorizationRule `json:"rules"`
+
+	// JWTIssuers defines the issuers of the JWTs whose principals are matched by the rules' request principals.
+	// A JWT presented in a request to a service is authenticated against the issuers of all the policies
+	// applicable to the service, and a JWT that fails authentication has no request principal.
+	// +optional
+	JWTIssuers []JWTIssuerSpec `json:"jwtIssuers,omitempty"`
+}
+
+// JWTIssuerSpec is the type used to represent the issuer of the JWTs authenticated for an AuthorizationPolicy.
+type JWTIssuerSpec struct {
+	// Issuer defines the issuer of the JWTs, matched against their 'iss' claim.
+	Issuer string `json:"issuer"`
+
+	// JWKS defines the JSON Web Key Set used to verify the signature of the JWTs.
+	JWKS string `json:"jwks"`
+}
+
+// AuthorizationRule is the type used to represent a rule in an AuthorizationPolicy specification.
+// A request matches the rule if it matches all the fields specified in the rule. A field is matched
+// if the request matches any of its values.
+type AuthorizationRule struct {
+	// Principals defines the service accounts of the clients in the form <namespace>/<name>.
+	// +optional
+	Principals []string `json:"principals,omitempty"`
+
+	// Namespaces defines the namespaces of the clients' service accounts.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// RequestPrincipals defines the principals of the JWT presented in the request, in the form <issuer>/<subject>.
+	// The issuer must be one of the policy's JWT issuers.
+	// +optional
+	RequestPrincipals []string `json:"requestPrincipals,omitempty"`
+
+	// Methods defines the HTTP methods of the requests.
+	// +optional
+	Methods []string `json:"methods,omitempty"`
+
+	// Paths defines the HTTP paths of the requests. A path ending with '*' is matched as a prefix.
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// Headers defines the HTTP headers the requests must have. A request must have all the headers
+	// with the specified values.
+	// +optional
+	Headers []HeaderSpec `json:"headers,omitempty"`
+}
+
+// HeaderSpec is the type used to represent an HTTP header in an AuthorizationRule.
+type HeaderSpec struct {
+	// Name defines the name of the header.
+	Name string `json:"name"`
+
+	// Value defines the exact value of the header.
+	Value string `json:"value"`
+}
+
+// AuthorizationPolicyList defines the list of AuthorizationPolicy objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AuthorizationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AuthorizationPolicy `json:"items"`
+}
@@ -10,7 +10,6 @@ import (
 // external to the service mesh or cluster based on the specified
 // rules in the policy.
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type Egress struct {
 	// Object's type metadata
@@ -23,6 +22,10 @@ type Egress struct {
 	// Spec is the Egress policy specification
 	// +optional
 	Spec EgressSpec `json:"spec,omitempty"`
+
+	// Status is the status of the Egress policy.
+	// +optional
+	Status EgressStatus `json:"status,omitempty"`
 }
 
 // EgressSpec is the type used to represent the Egress policy specification.
@@ -40,6 +43,10 @@ type EgressSpec struct {
 	// in the TLS handshake is matched against the list of Hosts specified.
 	//
 	// - For non-HTTP(s) based protocols, the Hosts field is ignored.
+	//
+	// A host may be prefixed with a '*.' wildcard to match all its subdomains,
+	// ex. '*.example.com'. HTTP traffic to a wildcard host is routed to the
+	// original destination of the request since it cannot be resolved using DNS.
 	// +optional
 	Hosts []string `json:"hosts,omitempty"`
 
@@ -56,6 +63,26 @@ type EgressSpec struct {
 	// Matches defines the list of object references the Egress policy should match on.
 	// +optional
 	Matches []corev1.TypedLocalObjectReference `json:"matches,omitempty"`
+
+	// TLS defines the TLS origination configuration for HTTP ports in the Egress policy.
+	// When specified, the application sends plaintext HTTP to the sidecar, which
+	// originates a TLS connection to the external host.
+	// +optional
+	TLS *EgressTLSSpec `json:"tls,omitempty"`
+}
+
+// EgressTLSSpec is the type used to represent the TLS origination configuration in an Egress policy specification.
+type EgressTLSSpec struct {
+	// CABundleSecret defines the secret containing the CA bundle, under the 'ca.crt' key,
+	// used to validate the certificate presented by the external host.
+	// The secret must be in the namespace of the Egress policy, which is used if the namespace is unspecified.
+	CABundleSecret corev1.SecretReference `json:"caBundleSecret"`
+
+	// ClientCertificateSecret defines the secret containing the client certificate and key,
+	// under the 'tls.crt' and 'tls.key' keys, presented to the external host for mutual TLS.
+	// The secret must be in the namespace of the Egress policy, which is used if the namespace is unspecified.
+	// +optional
+	ClientCertificateSecret *corev1.SecretReference `json:"clientCertificateSecret,omitempty"`
 }
 
 // EgressSourceSpec is the type used to represent the Source in the list of Sources specified in an Egress policy specification.
@@ -87,3 +114,26 @@ type EgressList struct {
 
 	Items []Egress `json:"items"`
 }
+
+// EgressStatus is the type used to represent the status of an Egress resource.
+type EgressStatus struct {
+	// CurrentStatus defines the current status of an Egress resource, one of
+	// EgressStatusAccepted, EgressStatusConflicting or EgressStatusInvalid.
+	// +optional
+	CurrentStatus string `json:"currentStatus,omitempty"`
+
+	// Reason defines the reason for the current status of an Egress resource.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+const (
+	// EgressStatusAccepted is the status of an Egress policy that is applied as specified.
+	EgressStatusAccepted = "accepted"
+
+	// EgressStatusConflicting is the status of an Egress policy that conflicts with another Egress policy.
+	EgressStatusConflicting = "conflicting"
+
+	// EgressStatusInvalid is the status of an Egress policy that cannot be applied as specified.
+	EgressStatusInvalid = "invalid"
+)
@@ -0,0 +1,113 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EnvoyFilterExtension is the type used to represent a user-supplied HTTP filter.
+// An Envoy filter extension adds a WASM or Lua HTTP filter to the inbound or outbound HTTP filter chains
+// of the proxies in its namespace, after the filters managed by OSM.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type EnvoyFilterExtension struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the Envoy filter extension specification
+	// +optional
+	Spec EnvoyFilterExtensionSpec `json:"spec,omitempty"`
+}
+
+// EnvoyFilterDirection is the type used to represent the direction of the traffic an Envoy filter extension applies to.
+type EnvoyFilterDirection string
+
+const (
+	// EnvoyFilterDirectionInbound is the direction corresponding to the HTTP traffic received by a proxy.
+	EnvoyFilterDirectionInbound EnvoyFilterDirection = "inbound"
+
+	// EnvoyFilterDirectionOutbound is the direction corresponding to the HTTP traffic sent by a proxy.
+	EnvoyFilterDirectionOutbound EnvoyFilterDirection = "outbound"
+)
+
+// EnvoyFilterPosition is the type used to represent the position of an Envoy filter extension in the HTTP filter chain.
+// Extensions are always positioned after the filters managed by OSM and before the router filter.
+type EnvoyFilterPosition string
+
+const (
+	// EnvoyFilterPositionFirst is the position corresponding to the extensions placed right after the filters managed by OSM.
+	EnvoyFilterPositionFirst EnvoyFilterPosition = "First"
+
+	// EnvoyFilterPositionLast is the position corresponding to the extensions placed right before the router filter.
+	EnvoyFilterPositionLast EnvoyFilterPosition = "Last"
+)
+
+// EnvoyFilterExtensionSpec is the type used to represent the EnvoyFilterExtension specification.
+// Exactly one of WASM and Lua must be specified.
+type EnvoyFilterExtensionSpec struct {
+	// ServiceAccounts defines the names of the service accounts in the extension's namespace
+	// whose proxies the filter is added to.
+	// If unspecified, the filter is added to all the proxies in the namespace.
+	// +optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// Direction defines whether the filter is added to the inbound or outbound HTTP filter chains.
+	Direction EnvoyFilterDirection `json:"direction"`
+
+	// Position defines the position of the filter among the extensions of the HTTP filter chain.
+	// Extensions with the same position are ordered by namespace and name.
+	// Defaults to Last if unspecified.
+	// +optional
+	Position EnvoyFilterPosition `json:"position,omitempty"`
+
+	// WASM defines the WASM module run by the filter.
+	// +optional
+	WASM *WASMFilterSpec `json:"wasm,omitempty"`
+
+	// Lua defines the Lua script run by the filter.
+	// +optional
+	Lua *LuaFilterSpec `json:"lua,omitempty"`
+}
+
+// WASMFilterSpec is the type used to represent the WASM module of an EnvoyFilterExtension.
+// Exactly one of ConfigMap and Image must be specified.
+type WASMFilterSpec struct {
+	// ConfigMap defines the key of a ConfigMap in the extension's namespace whose binary data is the WASM module.
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+
+	// Image defines the OCI image containing the WASM module, referenced by digest in the form
+	// <registry>/<repository>@sha256:<digest>.
+	// The image is pulled in the background, and the extension is added to the proxies once it has been pulled.
+	// The registry must be listed in the MeshConfig's spec.sidecar.wasmImageRegistries.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// RootID defines the root ID of the WASM module's context handling the requests.
+	// +optional
+	RootID string `json:"rootID,omitempty"`
+
+	// Configuration defines the configuration passed to the WASM module as a string.
+	// +optional
+	Configuration string `json:"configuration,omitempty"`
+}
+
+// LuaFilterSpec is the type used to represent the Lua script of an EnvoyFilterExtension.
+type LuaFilterSpec struct {
+	// InlineCode defines the Lua script, which must define the envoy_on_request and/or envoy_on_response functions.
+	InlineCode string `json:"inlineCode"`
+}
+
+// EnvoyFilterExtensionList defines the list of EnvoyFilterExtension objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type EnvoyFilterExtensionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []EnvoyFilterExtension `json:"items"`
+}
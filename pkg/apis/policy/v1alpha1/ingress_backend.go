@@ -51,6 +51,27 @@ type BackendSpec struct {
 	// TLS defines the specification for the backend's TLS configuration.
 	// +optional
 	TLS TLSSpec `json:"tls,omitempty"`
+
+	// HTTPRules defines the HTTP rules that restrict the requests the sources are allowed to make
+	// to the backend. A request is allowed if it matches any of the rules. If unspecified, all
+	// requests are allowed.
+	// +optional
+	HTTPRules []HTTPRuleSpec `json:"httpRules,omitempty"`
+}
+
+// HTTPRuleSpec is the type used to represent an HTTP rule that restricts the requests allowed to a backend.
+type HTTPRuleSpec struct {
+	// Hostnames defines the hostnames a request must be destined to. If unspecified, all hostnames are allowed.
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// PathPrefixes defines the path prefixes a request's path must start with. If unspecified, all paths are allowed.
+	// +optional
+	PathPrefixes []string `json:"pathPrefixes,omitempty"`
+
+	// Methods defines the HTTP methods a request must use. If unspecified, all methods are allowed.
+	// +optional
+	Methods []string `json:"methods,omitempty"`
 }
 
 const (
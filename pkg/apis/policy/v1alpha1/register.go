@@ -37,8 +37,12 @@ func Resource(resource string) schema.GroupResource {
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&AuthorizationPolicy{},
+		&AuthorizationPolicyList{},
 		&Egress{},
 		&EgressList{},
+		&EnvoyFilterExtension{},
+		&EnvoyFilterExtensionList{},
 		&IngressBackend{},
 		&IngressBackendList{},
 	)
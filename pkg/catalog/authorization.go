@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// GetAuthorizationTrafficPolicy returns the authorization traffic policy for the given mesh service, derived
+// from the AuthorizationPolicy resources applicable to the service. It returns nil if the AuthorizationPolicy
+// API is disabled or if no AuthorizationPolicy applies to the service.
+func (mc *MeshCatalog) GetAuthorizationTrafficPolicy(svc service.MeshService) *trafficpolicy.AuthorizationTrafficPolicy {
+	if !mc.configurator.GetFeatureFlags().EnableAuthorizationPolicy {
+		return nil
+	}
+
+	authzPolicies := mc.policyController.ListAuthorizationPolicies(svc)
+	if len(authzPolicies) == 0 {
+		return nil
+	}
+
+	trustDomain := mc.configurator.GetTrustDomain()
+	authzTrafficPolicy := &trafficpolicy.AuthorizationTrafficPolicy{}
+
+	jwksByIssuer := make(map[string]string)
+	for _, authzPolicy := range authzPolicies {
+		if authzPolicy.Spec.Action == policyV1alpha1.AuthorizationActionAllow {
+			// Requests must be denied by an ALLOW policy whose rules are all invalid rather than allowed
+			authzTrafficPolicy.EnforceAllowRules = true
+		}
+
+		for _, issuerSpec := range authzPolicy.Spec.JWTIssuers {
+			jwks, ok := jwksByIssuer[issuerSpec.Issuer]
+			if !ok {
+				jwksByIssuer[issuerSpec.Issuer] = issuerSpec.JWKS
+				authzTrafficPolicy.JWTIssuers = append(authzTrafficPolicy.JWTIssuers, &trafficpolicy.JWTIssuer{
+					Issuer: issuerSpec.Issuer,
+					JWKS:   issuerSpec.JWKS,
+				})
+			} else if jwks != issuerSpec.JWKS {
+				log.Error().Msgf("Ignoring JWKS of JWT issuer %s in AuthorizationPolicy %s/%s, which conflicts with the JWKS of the same issuer in another policy",
+					issuerSpec.Issuer, authzPolicy.Namespace, authzPolicy.Name)
+			}
+		}
+
+		for i, ruleSpec := range authzPolicy.Spec.Rules {
+			rule, err := getAuthorizationRule(ruleSpec, authzPolicy.Spec.JWTIssuers, trustDomain)
+			if err != nil {
+				if authzPolicy.Spec.Action != policyV1alpha1.AuthorizationActionDeny {
+					log.Error().Err(err).Msgf("Skipping invalid rule %d in AuthorizationPolicy %s/%s", i, authzPolicy.Namespace, authzPolicy.Name)
+					continue
+				}
+				// Skipping an invalid DENY rule would allow the requests it is meant to deny, so it denies all requests instead
+				log.Error().Err(err).Msgf("Denying all requests for invalid DENY rule %d in AuthorizationPolicy %s/%s", i, authzPolicy.Namespace, authzPolicy.Name)
+				rule = &trafficpolicy.AuthorizationRule{}
+			}
+			rule.Name = fmt.Sprintf("%s/%s/rule-%d", authzPolicy.Namespace, authzPolicy.Name, i)
+
+			switch authzPolicy.Spec.Action {
+			case policyV1alpha1.AuthorizationActionAllow:
+				authzTrafficPolicy.AllowRules = append(authzTrafficPolicy.AllowRules, rule)
+
+			case policyV1alpha1.AuthorizationActionDeny:
+				authzTrafficPolicy.DenyRules = append(authzTrafficPolicy.DenyRules, rule)
+
+			default:
+				log.Error().Msgf("Skipping AuthorizationPolicy %s/%s with unsupported action %q", authzPolicy.Namespace, authzPolicy.Name, authzPolicy.Spec.Action)
+			}
+		}
+	}
+
+	return authzTrafficPolicy
+}
+
+// getAuthorizationRule returns the authorization rule corresponding to the given AuthorizationRule spec,
+// with the principals qualified by the given trust domain and the request principals issued by the given JWT issuers
+func getAuthorizationRule(ruleSpec policyV1alpha1.AuthorizationRule, jwtIssuers []policyV1alpha1.JWTIssuerSpec, trustDomain string) (*trafficpolicy.AuthorizationRule, error) {
+	rule := &trafficpolicy.AuthorizationRule{
+		Methods: ruleSpec.Methods,
+		Paths:   ruleSpec.Paths,
+	}
+
+	for _, principal := range ruleSpec.Principals {
+		chunks := strings.Split(principal, "/")
+		if len(chunks) != 2 || chunks[0] == "" || chunks[1] == "" {
+			return nil, errors.Errorf("Invalid principal %q, expected <namespace>/<name>", principal)
+		}
+		svcAccount := identity.K8sServiceAccount{Namespace: chunks[0], Name: chunks[1]}
+		rule.Principals = append(rule.Principals, identity.GetKubernetesServiceIdentity(svcAccount, trustDomain))
+	}
+
+	for _, requestPrincipal := range ruleSpec.RequestPrincipals {
+		principal, err := getRequestPrincipal(requestPrincipal, jwtIssuers)
+		if err != nil {
+			return nil, err
+		}
+		rule.RequestPrincipals = append(rule.RequestPrincipals, principal)
+	}
+
+	for _, ns := range ruleSpec.Namespaces {
+		rule.PrincipalSuffixes = append(rule.PrincipalSuffixes, fmt.Sprintf(".%s.%s", ns, trustDomain))
+	}
+
+	for _, header := range ruleSpec.Headers {
+		if rule.Headers == nil {
+			rule.Headers = make(map[string]string)
+		}
+		rule.Headers[strings.ToLower(header.Name)] = header.Value
+	}
+
+	return rule, nil
+}
+
+// getRequestPrincipal returns the request principal corresponding to the given request principal of the form
+// <issuer>/<subject>, where the issuer is one of the given JWT issuers. Since issuers are usually URLs and contain
+// '/', the longest issuer the request principal is prefixed with is used.
+func getRequestPrincipal(requestPrincipal string, jwtIssuers []policyV1alpha1.JWTIssuerSpec) (trafficpolicy.RequestPrincipal, error) {
+	var principal trafficpolicy.RequestPrincipal
+	for _, issuerSpec := range jwtIssuers {
+		if len(issuerSpec.Issuer) > len(principal.Issuer) && strings.HasPrefix(requestPrincipal, issuerSpec.Issuer+"/") {
+			principal.Issuer = issuerSpec.Issuer
+		}
+	}
+	if principal.Issuer == "" {
+		return principal, errors.Errorf("Invalid request principal %q, expected <issuer>/<subject> with a JWT issuer of the policy", requestPrincipal)
+	}
+
+	principal.Subject = strings.TrimPrefix(requestPrincipal, principal.Issuer+"/")
+	if principal.Subject == "" {
+		return principal, errors.Errorf("Invalid request principal %q, the subject is empty", requestPrincipal)
+	}
+	return principal, nil
+}
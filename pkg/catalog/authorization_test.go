@@ -0,0 +1,192 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetAuthorizationTrafficPolicy(t *testing.T) {
+	svc := service.MeshService{Name: "backend", Namespace: "test"}
+
+	testCases := []struct {
+		name           string
+		enabled        bool
+		authzPolicies  []*policyV1alpha1.AuthorizationPolicy
+		expectedPolicy *trafficpolicy.AuthorizationTrafficPolicy
+	}{
+		{
+			name:           "AuthorizationPolicy API is disabled",
+			enabled:        false,
+			expectedPolicy: nil,
+		},
+		{
+			name:           "no AuthorizationPolicy applies to the service",
+			enabled:        true,
+			authzPolicies:  nil,
+			expectedPolicy: nil,
+		},
+		{
+			name:    "ALLOW and DENY policies with valid and invalid rules",
+			enabled: true,
+			authzPolicies: []*policyV1alpha1.AuthorizationPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "allow", Namespace: "test"},
+					Spec: policyV1alpha1.AuthorizationPolicySpec{
+						Action: policyV1alpha1.AuthorizationActionAllow,
+						Rules: []policyV1alpha1.AuthorizationRule{
+							{
+								Principals: []string{"test/frontend"},
+								Namespaces: []string{"web"},
+								Methods:    []string{"GET"},
+								Paths:      []string{"/api*"},
+								Headers: []policyV1alpha1.HeaderSpec{
+									{Name: "X-Version", Value: "v1"},
+								},
+							},
+							{
+								// invalid principal, rule is skipped
+								Principals: []string{"frontend"},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "deny", Namespace: "test"},
+					Spec: policyV1alpha1.AuthorizationPolicySpec{
+						Action: policyV1alpha1.AuthorizationActionDeny,
+						Rules: []policyV1alpha1.AuthorizationRule{
+							{
+								Methods: []string{"DELETE"},
+							},
+							{
+								RequestPrincipals: []string{"https://issuer.example.com/user1", "https://issuer.example.com/tenant/user2"},
+							},
+							{
+								// invalid principal, rule denies all requests
+								Principals: []string{"frontend"},
+							},
+							{
+								// request principal of an issuer the policy does not specify, rule denies all requests
+								RequestPrincipals: []string{"https://other.example.com/user1"},
+							},
+						},
+						JWTIssuers: []policyV1alpha1.JWTIssuerSpec{
+							{Issuer: "https://issuer.example.com", JWKS: "jwks"},
+							{Issuer: "https://issuer.example.com/tenant", JWKS: "tenant-jwks"},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "deny-conflicting-issuer", Namespace: "test"},
+					Spec: policyV1alpha1.AuthorizationPolicySpec{
+						Action: policyV1alpha1.AuthorizationActionDeny,
+						Rules: []policyV1alpha1.AuthorizationRule{
+							{
+								RequestPrincipals: []string{"https://issuer.example.com/user3"},
+							},
+						},
+						JWTIssuers: []policyV1alpha1.JWTIssuerSpec{
+							// conflicts with the JWKS of the issuer in the 'deny' policy, the JWKS is ignored
+							{Issuer: "https://issuer.example.com", JWKS: "other-jwks"},
+						},
+					},
+				},
+			},
+			expectedPolicy: &trafficpolicy.AuthorizationTrafficPolicy{
+				EnforceAllowRules: true,
+				AllowRules: []*trafficpolicy.AuthorizationRule{
+					{
+						Name:              "test/allow/rule-0",
+						Principals:        []identity.ServiceIdentity{"frontend.test.cluster.local"},
+						PrincipalSuffixes: []string{".web.cluster.local"},
+						Methods:           []string{"GET"},
+						Paths:             []string{"/api*"},
+						Headers:           map[string]string{"x-version": "v1"},
+					},
+				},
+				DenyRules: []*trafficpolicy.AuthorizationRule{
+					{
+						Name:    "test/deny/rule-0",
+						Methods: []string{"DELETE"},
+					},
+					{
+						Name: "test/deny/rule-1",
+						RequestPrincipals: []trafficpolicy.RequestPrincipal{
+							{Issuer: "https://issuer.example.com", Subject: "user1"},
+							{Issuer: "https://issuer.example.com/tenant", Subject: "user2"},
+						},
+					},
+					{
+						Name: "test/deny/rule-2",
+					},
+					{
+						Name: "test/deny/rule-3",
+					},
+					{
+						Name: "test/deny-conflicting-issuer/rule-0",
+						RequestPrincipals: []trafficpolicy.RequestPrincipal{
+							{Issuer: "https://issuer.example.com", Subject: "user3"},
+						},
+					},
+				},
+				JWTIssuers: []*trafficpolicy.JWTIssuer{
+					{Issuer: "https://issuer.example.com", JWKS: "jwks"},
+					{Issuer: "https://issuer.example.com/tenant", JWKS: "tenant-jwks"},
+				},
+			},
+		},
+		{
+			name:    "ALLOW policy without valid rules enforces ALLOW rules",
+			enabled: true,
+			authzPolicies: []*policyV1alpha1.AuthorizationPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "allow", Namespace: "test"},
+					Spec: policyV1alpha1.AuthorizationPolicySpec{
+						Action: policyV1alpha1.AuthorizationActionAllow,
+						Rules: []policyV1alpha1.AuthorizationRule{
+							{Principals: []string{"frontend"}},
+						},
+					},
+				},
+			},
+			expectedPolicy: &trafficpolicy.AuthorizationTrafficPolicy{
+				EnforceAllowRules: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mc := &MeshCatalog{
+				configurator:     mockCfg,
+				policyController: mockPolicyController,
+			}
+
+			mockCfg.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableAuthorizationPolicy: tc.enabled}).Times(1)
+			mockCfg.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
+			if tc.enabled {
+				mockPolicyController.EXPECT().ListAuthorizationPolicies(svc).Return(tc.authzPolicies).Times(1)
+			}
+
+			actual := mc.GetAuthorizationTrafficPolicy(svc)
+			assert.Equal(tc.expectedPolicy, actual)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/oci"
 	"github.com/openservicemesh/osm/pkg/policy"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/smi"
@@ -26,6 +27,7 @@ func NewMeshCatalog(kubeController k8s.Controller, meshSpec smi.MeshSpec, certMa
 
 		kubeController: kubeController,
 	}
+	mc.wasmPuller = oci.NewPuller(publishWASMModulePulled, mc.isImageReferenced, cfg.GetWASMImageRegistries)
 
 	go mc.dispatcher()
 	ticker.InitTicker(cfg)
@@ -33,6 +35,16 @@ func NewMeshCatalog(kubeController k8s.Controller, meshSpec smi.MeshSpec, certMa
 	return &mc
 }
 
+// SetLeaderCheck sets the function determining whether this controller replica is the leader
+func (mc *MeshCatalog) SetLeaderCheck(leaderCheck func() bool) {
+	mc.leaderCheck = leaderCheck
+}
+
+// isLeader returns true if this controller replica is the leader
+func (mc *MeshCatalog) isLeader() bool {
+	return mc.leaderCheck == nil || mc.leaderCheck()
+}
+
 // GetKubeController returns the kube controller instance handling the current cluster
 func (mc *MeshCatalog) GetKubeController() k8s.Controller {
 	return mc.kubeController
@@ -0,0 +1,21 @@
+package catalog
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestIsLeader(t *testing.T) {
+	assert := tassert.New(t)
+
+	mc := &MeshCatalog{}
+	assert.True(mc.isLeader())
+
+	isLeader := false
+	mc.SetLeaderCheck(func() bool { return isLeader })
+	assert.False(mc.isLeader())
+
+	isLeader = true
+	assert.True(mc.isLeader())
+}
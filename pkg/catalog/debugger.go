@@ -6,6 +6,7 @@ import (
 	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
 
 	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 // ListSMIPolicies returns all policies OSM is aware of.
@@ -28,3 +29,13 @@ func (mc *MeshCatalog) ListMonitoredNamespaces() []string {
 
 	return namespaces
 }
+
+// ListServicesForServiceIdentity returns the services whose pods run as the given service identity.
+func (mc *MeshCatalog) ListServicesForServiceIdentity(svcIdentity identity.ServiceIdentity) []service.MeshService {
+	services, err := mc.getServicesForServiceIdentity(svcIdentity)
+	if err != nil {
+		return nil
+	}
+
+	return services
+}
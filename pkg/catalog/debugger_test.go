@@ -4,6 +4,8 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -34,4 +36,16 @@ var _ = Describe("Test catalog proxy register/unregister", func() {
 
 		})
 	})
+
+	Context("Test ListServicesForServiceIdentity", func() {
+		It("lists the services of the service identity", func() {
+			actual := mc.ListServicesForServiceIdentity(tests.BookbuyerServiceIdentity)
+			Expect(actual).To(Equal([]service.MeshService{tests.BookbuyerService}))
+		})
+
+		It("lists no services for a service identity without services", func() {
+			actual := mc.ListServicesForServiceIdentity(identity.K8sServiceAccount{Name: "unknown", Namespace: "default"}.ToServiceIdentity(identity.ClusterLocalTrustDomain))
+			Expect(actual).To(BeNil())
+		})
+	})
 })
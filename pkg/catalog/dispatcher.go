@@ -10,15 +10,6 @@ import (
 	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
-const (
-	// maxBroadcastDeadlineTime is the max time we will delay a global proxy update
-	// if multiple events that would trigger it get coalesced over time.
-	maxBroadcastDeadlineTime = 15 * time.Second
-	// maxGraceDeadlineTime is the time we will wait for an additional global proxy update
-	// trigger if we just received one.
-	maxGraceDeadlineTime = 3 * time.Second
-)
-
 // isDeltaUpdate assesses and returns if a pubsub message contains an actual delta in config
 func isDeltaUpdate(psubMsg events.PubSubMessage) bool {
 	return !(strings.HasSuffix(psubMsg.AnnouncementType.String(), "updated") &&
@@ -26,9 +17,8 @@ func isDeltaUpdate(psubMsg events.PubSubMessage) bool {
 }
 
 func (mc *MeshCatalog) dispatcher() {
-	// This will be finely tuned in near future, we can instrument other modules
-	// to take ownership of certain events, and just notify dispatcher through
-	// ScheduleBroadcastUpdate announcement type
+	// Events that can be attributed to a set of services only update the proxies depending on these
+	// services, other modules can request a full update through the ScheduleProxyBroadcast announcement type
 	subChannel := events.Subscribe(
 		a.ScheduleProxyBroadcast,                              // Other modules requesting a global envoy update
 		a.EndpointAdded, a.EndpointDeleted, a.EndpointUpdated, // endpoint
@@ -38,32 +28,42 @@ func (mc *MeshCatalog) dispatcher() {
 		a.ServiceAdded, a.ServiceDeleted, a.ServiceUpdated, // service
 		a.MultiClusterServiceAdded, a.MultiClusterServiceDeleted, a.MultiClusterServiceUpdated, // Multicluster Service
 		a.ServiceAccountAdded, a.ServiceAccountDeleted, a.ServiceAccountUpdated, // serviceaccount
+		a.SecretAdded, a.SecretDeleted, a.SecretUpdated, // secret
+		a.ConfigMapAdded, a.ConfigMapDeleted, a.ConfigMapUpdated, // configmap
 		a.TrafficSplitAdded, a.TrafficSplitDeleted, a.TrafficSplitUpdated, // traffic split
 		a.TrafficTargetAdded, a.TrafficTargetDeleted, a.TrafficTargetUpdated, // traffic target
 		a.IngressAdded, a.IngressDeleted, a.IngressUpdated, // Ingress
+		a.GatewayAdded, a.GatewayDeleted, a.GatewayUpdated, // Gateway API Gateway
+		a.HTTPRouteAdded, a.HTTPRouteDeleted, a.HTTPRouteUpdated, // Gateway API HTTPRoute
 		a.TCPRouteAdded, a.TCPRouteDeleted, a.TCPRouteUpdated, // TCProute
 		a.EgressAdded, a.EgressDeleted, a.EgressUpdated, // Egress
 		a.IngressBackendAdded, a.IngressBackendDeleted, a.IngressBackendUpdated, // IngressBackend
+		a.AuthorizationPolicyAdded, a.AuthorizationPolicyDeleted, a.AuthorizationPolicyUpdated, // AuthorizationPolicy
+		a.EnvoyFilterExtensionAdded, a.EnvoyFilterExtensionDeleted, a.EnvoyFilterExtensionUpdated, // EnvoyFilterExtension
+		a.WASMModulePulled, // WASM module of an EnvoyFilterExtension pulled from its image
 	)
 
 	// State and channels for event-coalescing
-	broadcastScheduled := false
+	var pendingUpdate *ProxyUpdate
+	var debounceWindow time.Duration
 	chanMovingDeadline := make(<-chan time.Time)
 	chanMaxDeadline := make(<-chan time.Time)
 
-	// tl;dr "When a broadcast request is scheduled, we will wait (3s) in case we receive another broadcast request
+	// tl;dr "When a proxy update is scheduled, we will wait (3s) in case we receive another change
 	// during this delay that can be coalesced (and restart the (3s) count if we do) up to a maximum of (15s) delay"
 
-	// When there is no broadcast scheduled (broadcastScheduled == false) we start a max deadline (15s)
-	// and a moving deadline (3s) timers.
+	// When there is no update scheduled (pendingUpdate == nil) we start a max deadline (15s)
+	// and a moving deadline (3s) timers. Both durations are configurable in the MeshConfig.
 	// The max deadline (15s) is the guaranteed hard max time we will wait till the next
-	// envoy global broadcast is actually published to update all envoys.
-	// Max deadline is used to limit the amount of times we might delay issuing the update, as new broadcast
-	// requests can keep on delaying the moving deadline potentially forever.
+	// envoy update is actually published.
+	// Max deadline is used to limit the amount of times we might delay issuing the update, as new changes
+	// can keep on delaying the moving deadline potentially forever.
 	// The moving deadline resets if a new delta/change/request is detected in the next (3s). This is used to coalesce updates
-	// and avoid issuing global envoy reconfiguration at large if new updates are meant to be received shortly after.
-	// Either deadline will trigger the broadcast, whichever happens first, given previous conditions.
-	// This mechanism is reset when the broadcast is published.
+	// and avoid issuing envoy reconfiguration at large if new updates are meant to be received shortly after.
+	// Either deadline will trigger the update, whichever happens first, given previous conditions.
+	// The scope of the coalesced changes is published with the update, such that only the proxies depending
+	// on the changed services are updated. Changes that can not be scoped result in a full update of all proxies.
+	// This mechanism is reset when the update is published.
 
 	for {
 		select {
@@ -78,48 +78,62 @@ func (mc *MeshCatalog) dispatcher() {
 			delta := isDeltaUpdate(psubMessage)
 			log.Debug().Msgf("[Pubsub] %s - delta: %v", psubMessage.AnnouncementType, delta)
 
-			// Schedule an envoy broadcast update if we either:
+			// Schedule an envoy update if we either:
 			// - detected a config delta
 			// - another module requested a broadcast through ScheduleProxyBroadcast
-			if delta || psubMessage.AnnouncementType == a.ScheduleProxyBroadcast {
-				if !broadcastScheduled {
-					broadcastScheduled = true
-					chanMaxDeadline = time.After(maxBroadcastDeadlineTime)
-					chanMovingDeadline = time.After(maxGraceDeadlineTime)
-					log.Info().Msg("Broadcast scheduled by config changes")
-				} else {
-					// If a broadcast is already scheduled, just reset the moving deadline
-					chanMovingDeadline = time.After(maxGraceDeadlineTime)
-				}
-			} else {
+			if !delta && psubMessage.AnnouncementType != a.ScheduleProxyBroadcast {
 				// Do nothing on non-delta updates
 				continue
 			}
 
-		// A select-fallthrough doesn't exist, we are copying some code here
+			mc.pullWASMModule(psubMessage)
+
+			scope := mc.getProxyUpdateScope(psubMessage)
+			if scope.isEmpty() {
+				// The change does not affect the configuration of any proxy, ex. a secret not referenced by a policy
+				continue
+			}
+			if pendingUpdate == nil {
+				pendingUpdate = scope
+				debounceWindow = mc.configurator.GetConfigUpdateDebounceWindow()
+				chanMaxDeadline = time.After(mc.configurator.GetConfigUpdateMaxDelay())
+				chanMovingDeadline = time.After(debounceWindow)
+				log.Info().Msg("Proxy update scheduled by config changes")
+			} else {
+				// If an update is already scheduled, extend its scope and reset the moving deadline
+				pendingUpdate = pendingUpdate.merge(scope)
+				chanMovingDeadline = time.After(debounceWindow)
+			}
+
 		case <-chanMovingDeadline:
 			log.Info().Msgf("Moving deadline trigger - Broadcast envoy update")
-			events.Publish(events.PubSubMessage{
-				AnnouncementType: a.ProxyBroadcast,
-			})
-			metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount.Inc()
+			publishProxyUpdate(pendingUpdate)
 
-			// broadcast done, reset timer channels
-			broadcastScheduled = false
+			// update done, reset state and timer channels
+			pendingUpdate = nil
 			chanMovingDeadline = make(<-chan time.Time)
 			chanMaxDeadline = make(<-chan time.Time)
 
 		case <-chanMaxDeadline:
 			log.Info().Msgf("Max deadline trigger - Broadcast envoy update")
-			events.Publish(events.PubSubMessage{
-				AnnouncementType: a.ProxyBroadcast,
-			})
-			metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount.Inc()
+			publishProxyUpdate(pendingUpdate)
 
-			// broadcast done, reset timer channels
-			broadcastScheduled = false
+			// update done, reset state and timer channels
+			pendingUpdate = nil
 			chanMovingDeadline = make(<-chan time.Time)
 			chanMaxDeadline = make(<-chan time.Time)
 		}
 	}
 }
+
+// publishProxyUpdate notifies the proxy streams of the given update
+func publishProxyUpdate(update *ProxyUpdate) {
+	events.Publish(events.PubSubMessage{
+		AnnouncementType: a.ProxyBroadcast,
+		NewObj:           update,
+	})
+	metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount.Inc()
+	if !update.IsFull() {
+		metricsstore.DefaultMetricsStore.ProxyTargetedBroadcastEventCount.Inc()
+	}
+}
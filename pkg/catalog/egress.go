@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
+	"github.com/pkg/errors"
 	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	corev1 "k8s.io/api/core/v1"
 
 	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 
@@ -17,9 +19,21 @@ import (
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
+const (
+	// egressCABundleKey is the key in the CA bundle secret referenced by an Egress policy holding the CA bundle
+	egressCABundleKey = "ca.crt"
+
+	// egressSourceKindSvcAccount is the ServiceAccount kind for a source defined in an Egress policy
+	egressSourceKindSvcAccount = "ServiceAccount"
+
+	// wildcardHostPrefix is the prefix of a wildcard host specified in an Egress policy
+	wildcardHostPrefix = "*."
+)
+
 // GetEgressTrafficPolicy returns the Egress traffic policy associated with the given service identity
 func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error) {
-	if !mc.configurator.GetFeatureFlags().EnableEgressPolicy {
+	featureFlags := mc.configurator.GetFeatureFlags()
+	if !featureFlags.EnableEgressPolicy {
 		return nil, nil
 	}
 
@@ -30,11 +44,34 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 
 	for _, egress := range egressResources {
 		for _, portSpec := range egress.Spec.Ports {
+			if featureFlags.EnableEgressGateway && strings.ToLower(portSpec.Protocol) != constants.ProtocolHTTP {
+				// The egress gateway only proxies HTTP traffic, so allowing other protocols from the sidecar
+				// would let this traffic leave the mesh without going through the egress gateway
+				log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrEgressUnsupportedByGateway)).
+					Msgf("Protocol %s of port %d in egress policy %s/%s is not supported by the egress gateway, ignoring port",
+						portSpec.Protocol, portSpec.Number, egress.Namespace, egress.Name)
+				continue
+			}
+
 			switch strings.ToLower(portSpec.Protocol) {
 			case constants.ProtocolHTTP:
 				// ---
+				// Build the TLS origination config if the Egress policy requires the proxy to originate TLS
+				var tlsConfig *trafficpolicy.EgressTLSConfig
+				if egress.Spec.TLS != nil {
+					var err error
+					if tlsConfig, err = mc.getEgressTLSConfig(egress); err != nil {
+						log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingEgressTLSSecret)).
+							Msgf("Error building TLS origination config for egress policy %s/%s, ignoring port %d", egress.Namespace, egress.Name, portSpec.Number)
+						continue
+					}
+				}
+
 				// Build the HTTP route configs for the given Egress policy
-				httpRouteConfigs, httpClusterConfigs := mc.buildHTTPRouteConfigs(egress, portSpec.Number)
+				httpRouteConfigs, httpClusterConfigs := mc.buildHTTPRouteConfigs(egress, portSpec.Number, tlsConfig)
+				if featureFlags.EnableEgressGateway {
+					httpRouteConfigs, httpClusterConfigs = routeViaEgressGateway(egress, httpRouteConfigs, httpClusterConfigs)
+				}
 				portToRouteConfigMap[portSpec.Number] = append(portToRouteConfigMap[portSpec.Number], httpRouteConfigs...)
 				clusterConfigs = append(clusterConfigs, httpClusterConfigs...)
 
@@ -105,7 +142,112 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 	}, nil
 }
 
-func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress, port int) ([]*trafficpolicy.EgressHTTPRouteConfig, []*trafficpolicy.EgressClusterConfig) {
+// GetEgressGatewayTrafficPolicy returns the Egress traffic policy programmed on the egress gateway.
+// The egress gateway serves the HTTP routes of all Egress policies for non-wildcard hosts, and permits
+// each route only for the sources specified in the Egress policy the route is derived from.
+func (mc *MeshCatalog) GetEgressGatewayTrafficPolicy() (*trafficpolicy.EgressTrafficPolicy, error) {
+	featureFlags := mc.configurator.GetFeatureFlags()
+	if !featureFlags.EnableEgressPolicy || !featureFlags.EnableEgressGateway {
+		return nil, nil
+	}
+
+	var clusterConfigs []*trafficpolicy.EgressClusterConfig
+	portToRouteConfigMap := make(map[int][]*trafficpolicy.EgressHTTPRouteConfig)
+	trustDomain := mc.configurator.GetTrustDomain()
+
+	for _, egress := range mc.policyController.ListEgressPolicies() {
+		allowedIdentities := mapset.NewSet()
+		for _, source := range egress.Spec.Sources {
+			if source.Kind != egressSourceKindSvcAccount {
+				continue
+			}
+			allowedIdentities.Add(identity.K8sServiceAccount{Name: source.Name, Namespace: source.Namespace}.ToServiceIdentity(trustDomain))
+		}
+		if allowedIdentities.Cardinality() == 0 {
+			// An RBAC policy without principals allows any downstream, so skip policies without valid sources
+			continue
+		}
+
+		for _, portSpec := range egress.Spec.Ports {
+			if strings.ToLower(portSpec.Protocol) != constants.ProtocolHTTP {
+				continue
+			}
+
+			var tlsConfig *trafficpolicy.EgressTLSConfig
+			if egress.Spec.TLS != nil {
+				var err error
+				if tlsConfig, err = mc.getEgressTLSConfig(egress); err != nil {
+					log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingEgressTLSSecret)).
+						Msgf("Error building TLS origination config for egress policy %s/%s, ignoring port %d", egress.Namespace, egress.Name, portSpec.Number)
+					continue
+				}
+			}
+
+			httpRouteConfigs, httpClusterConfigs := mc.buildHTTPRouteConfigs(egress, portSpec.Number, tlsConfig)
+			for _, routeConfig := range httpRouteConfigs {
+				if isWildcardHost(routeConfig.Name) {
+					continue
+				}
+				for _, rule := range routeConfig.RoutingRules {
+					rule.AllowedServiceIdentities = allowedIdentities
+				}
+				portToRouteConfigMap[portSpec.Number] = append(portToRouteConfigMap[portSpec.Number], routeConfig)
+			}
+			for _, clusterConfig := range httpClusterConfigs {
+				if isWildcardHost(clusterConfig.Host) {
+					continue
+				}
+				clusterConfigs = append(clusterConfigs, clusterConfig)
+			}
+		}
+	}
+
+	clusterConfigs, err := trafficpolicy.DeduplicateClusterConfigs(clusterConfigs)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrDedupEgressClusterConfigs)).
+			Msg("Error deduplicating egress clusters configs for the egress gateway")
+		return nil, err
+	}
+
+	return &trafficpolicy.EgressTrafficPolicy{
+		HTTPRouteConfigsPerPort: portToRouteConfigMap,
+		ClustersConfigs:         clusterConfigs,
+	}, nil
+}
+
+// routeViaEgressGateway marks the given HTTP route and cluster configs of the given Egress policy to be routed
+// through the egress gateway, and drops those of wildcard hosts. Wildcard hosts are resolved to their original
+// destination, which is only known to the proxy intercepting the request, so the egress gateway cannot proxy them.
+func routeViaEgressGateway(egress *policyV1alpha1.Egress, routeConfigs []*trafficpolicy.EgressHTTPRouteConfig,
+	clusterConfigs []*trafficpolicy.EgressClusterConfig) ([]*trafficpolicy.EgressHTTPRouteConfig, []*trafficpolicy.EgressClusterConfig) {
+	var gatewayRouteConfigs []*trafficpolicy.EgressHTTPRouteConfig
+	for _, routeConfig := range routeConfigs {
+		if isWildcardHost(routeConfig.Name) {
+			log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrEgressUnsupportedByGateway)).
+				Msgf("Wildcard host %s in egress policy %s/%s is not supported by the egress gateway, ignoring host", routeConfig.Name, egress.Namespace, egress.Name)
+			continue
+		}
+		routeConfig.ViaEgressGateway = true
+		gatewayRouteConfigs = append(gatewayRouteConfigs, routeConfig)
+	}
+
+	var gatewayClusterConfigs []*trafficpolicy.EgressClusterConfig
+	for _, clusterConfig := range clusterConfigs {
+		if isWildcardHost(clusterConfig.Host) {
+			continue
+		}
+		clusterConfig.ViaEgressGateway = true
+		gatewayClusterConfigs = append(gatewayClusterConfigs, clusterConfig)
+	}
+
+	return gatewayRouteConfigs, gatewayClusterConfigs
+}
+
+func isWildcardHost(host string) bool {
+	return strings.HasPrefix(host, wildcardHostPrefix)
+}
+
+func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress, port int, tlsConfig *trafficpolicy.EgressTLSConfig) ([]*trafficpolicy.EgressHTTPRouteConfig, []*trafficpolicy.EgressClusterConfig) {
 	if egressPolicy == nil {
 		return nil, nil
 	}
@@ -173,6 +315,7 @@ func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress
 			Name: clusterName,
 			Host: host,
 			Port: port,
+			TLS:  tlsConfig,
 		}
 		clusterConfigs = append(clusterConfigs, clusterConfig)
 
@@ -205,6 +348,58 @@ func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress
 	return routeConfigs, clusterConfigs
 }
 
+// getEgressTLSConfig returns the TLS origination config for the given Egress policy using the
+// CA bundle and client certificate secrets it references.
+func (mc *MeshCatalog) getEgressTLSConfig(egressPolicy *policyV1alpha1.Egress) (*trafficpolicy.EgressTLSConfig, error) {
+	tlsSpec := egressPolicy.Spec.TLS
+
+	caSecret, err := mc.getEgressSecret(tlsSpec.CABundleSecret, egressPolicy.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	caBundle, ok := caSecret.Data[egressCABundleKey]
+	if !ok {
+		return nil, errors.Errorf("CA bundle secret %s/%s is missing the %s key", caSecret.Namespace, caSecret.Name, egressCABundleKey)
+	}
+
+	tlsConfig := &trafficpolicy.EgressTLSConfig{
+		CABundle: caBundle,
+	}
+
+	if tlsSpec.ClientCertificateSecret == nil {
+		return tlsConfig, nil
+	}
+
+	clientSecret, err := mc.getEgressSecret(*tlsSpec.ClientCertificateSecret, egressPolicy.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	clientCert, certOk := clientSecret.Data[corev1.TLSCertKey]
+	clientKey, keyOk := clientSecret.Data[corev1.TLSPrivateKeyKey]
+	if !certOk || !keyOk {
+		return nil, errors.Errorf("Client certificate secret %s/%s is missing the %s or %s key",
+			clientSecret.Namespace, clientSecret.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	tlsConfig.ClientCertificate = clientCert
+	tlsConfig.ClientKey = clientKey
+	tlsConfig.ClientCertificateSecret = fmt.Sprintf("%s/%s", clientSecret.Namespace, clientSecret.Name)
+
+	return tlsConfig, nil
+}
+
+// getEgressSecret returns the secret referenced by an Egress policy, which must be in the policy's namespace
+// so that a policy cannot expose the secrets of other namespaces to its clients
+func (mc *MeshCatalog) getEgressSecret(ref corev1.SecretReference, policyNamespace string) (*corev1.Secret, error) {
+	if ref.Namespace != "" && ref.Namespace != policyNamespace {
+		return nil, errors.Errorf("Secret %s/%s must be in the namespace %s of the Egress policy", ref.Namespace, ref.Name, policyNamespace)
+	}
+	secret := mc.kubeController.GetSecret(ref.Name, policyNamespace)
+	if secret == nil {
+		return nil, errors.Errorf("Secret %s/%s not found", policyNamespace, ref.Name)
+	}
+	return secret, nil
+}
+
 func getHTTPRouteMatchesFromHTTPRouteGroup(httpRouteGroup *smiSpecs.HTTPRouteGroup) []trafficpolicy.HTTPRouteMatch {
 	if httpRouteGroup == nil {
 		return nil
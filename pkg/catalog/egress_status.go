@@ -0,0 +1,198 @@
+package catalog
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+
+	a "github.com/openservicemesh/osm/pkg/announcements"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+)
+
+// StartEgressStatusUpdater starts updating the status of Egress policies whenever the Egress policies
+// or the resources referenced by them change, until the given stop channel is closed
+func (mc *MeshCatalog) StartEgressStatusUpdater(stop <-chan struct{}) {
+	go mc.egressStatusUpdater(stop)
+}
+
+func (mc *MeshCatalog) egressStatusUpdater(stop <-chan struct{}) {
+	subChannel := events.Subscribe(
+		a.EgressAdded, a.EgressDeleted, a.EgressUpdated, // Egress
+		a.RouteGroupAdded, a.RouteGroupDeleted, a.RouteGroupUpdated, // routegroup
+		a.SecretAdded, a.SecretDeleted, a.SecretUpdated, // secret
+		a.MeshConfigUpdated, // the egress gateway feature flag
+	)
+	defer events.Unsub(subChannel)
+
+	mc.updateEgressStatuses()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-subChannel:
+			mc.updateEgressStatuses()
+		}
+	}
+}
+
+// updateEgressStatuses computes the status of all Egress policies and updates the status of the
+// policies whose status has changed
+func (mc *MeshCatalog) updateEgressStatuses() {
+	featureFlags := mc.configurator.GetFeatureFlags()
+	if !featureFlags.EnableEgressPolicy {
+		return
+	}
+
+	egressPolicies := mc.policyController.ListEgressPolicies()
+	statuses := mc.getEgressStatuses(egressPolicies, featureFlags.EnableEgressGateway)
+
+	for _, egress := range egressPolicies {
+		status := statuses[getEgressKey(egress)]
+		if egress.Status == status {
+			continue
+		}
+
+		// The original pointer returned by cache.Store must not be modified for thread safety.
+		egressWithStatus := *egress
+		egressWithStatus.Status = status
+		if _, err := mc.kubeController.UpdateStatus(&egressWithStatus); err != nil {
+			log.Error().Err(err).Msgf("Error updating status for Egress %s", getEgressKey(egress))
+		}
+	}
+}
+
+// getEgressStatuses returns the status of each of the given Egress policies keyed by their namespaced name.
+// A policy that cannot be applied as specified, including when it allows traffic the egress gateway cannot
+// proxy while the egress gateway is enabled, is invalid. A policy that allows a source to access a port
+// that another policy for the same source allows using a different protocol is conflicting, because the
+// protocol the port is proxied with is then ambiguous.
+func (mc *MeshCatalog) getEgressStatuses(egressPolicies []*policyV1alpha1.Egress, egressGateway bool) map[string]policyV1alpha1.EgressStatus {
+	sorted := make([]*policyV1alpha1.Egress, len(egressPolicies))
+	copy(sorted, egressPolicies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return getEgressKey(sorted[i]) < getEgressKey(sorted[j])
+	})
+
+	statuses := make(map[string]policyV1alpha1.EgressStatus)
+	for i, egress := range sorted {
+		reason := mc.getEgressInvalidReason(egress)
+		if reason == "" && egressGateway {
+			reason = getEgressGatewayUnsupportedReason(egress)
+		}
+		if reason != "" {
+			statuses[getEgressKey(egress)] = policyV1alpha1.EgressStatus{
+				CurrentStatus: policyV1alpha1.EgressStatusInvalid,
+				Reason:        reason,
+			}
+			continue
+		}
+
+		status := policyV1alpha1.EgressStatus{
+			CurrentStatus: policyV1alpha1.EgressStatusAccepted,
+			Reason:        "successfully committed by the system",
+		}
+		for j, other := range sorted {
+			if i == j {
+				continue
+			}
+			if reason := getEgressConflictReason(egress, other); reason != "" {
+				status = policyV1alpha1.EgressStatus{
+					CurrentStatus: policyV1alpha1.EgressStatusConflicting,
+					Reason:        reason,
+				}
+				break
+			}
+		}
+		statuses[getEgressKey(egress)] = status
+	}
+
+	return statuses
+}
+
+// getEgressInvalidReason returns the reason the given Egress policy cannot be applied as specified,
+// or an empty string if the policy is valid
+func (mc *MeshCatalog) getEgressInvalidReason(egress *policyV1alpha1.Egress) string {
+	for _, source := range egress.Spec.Sources {
+		if source.Kind != egressSourceKindSvcAccount {
+			return fmt.Sprintf("unsupported source kind %s", source.Kind)
+		}
+	}
+
+	for _, ipRange := range egress.Spec.IPAddresses {
+		if _, _, err := net.ParseCIDR(ipRange); err != nil {
+			return fmt.Sprintf("invalid IP range %s", ipRange)
+		}
+	}
+
+	for _, match := range egress.Spec.Matches {
+		if match.APIGroup == nil || *match.APIGroup != smiSpecs.SchemeGroupVersion.String() || match.Kind != httpRouteGroupKind {
+			return fmt.Sprintf("unsupported match %s/%s", match.Kind, match.Name)
+		}
+		if mc.meshSpec.GetHTTPRouteGroup(fmt.Sprintf("%s/%s", egress.Namespace, match.Name)) == nil {
+			return fmt.Sprintf("HTTPRouteGroup %s/%s not found", egress.Namespace, match.Name)
+		}
+	}
+
+	if egress.Spec.TLS != nil {
+		if _, err := mc.getEgressTLSConfig(egress); err != nil {
+			return fmt.Sprintf("invalid TLS origination config: %s", err)
+		}
+	}
+
+	return ""
+}
+
+// getEgressGatewayUnsupportedReason returns the reason the given Egress policy allows traffic that the egress
+// gateway cannot proxy, or an empty string if all the traffic it allows is routed through the egress gateway
+func getEgressGatewayUnsupportedReason(egress *policyV1alpha1.Egress) string {
+	for _, port := range egress.Spec.Ports {
+		if !strings.EqualFold(port.Protocol, constants.ProtocolHTTP) {
+			return fmt.Sprintf("port %d with protocol %s is not supported by the egress gateway", port.Number, port.Protocol)
+		}
+	}
+	for _, host := range egress.Spec.Hosts {
+		if isWildcardHost(host) {
+			return fmt.Sprintf("wildcard host %s is not supported by the egress gateway", host)
+		}
+	}
+	return ""
+}
+
+// getEgressConflictReason returns the reason the given Egress policy conflicts with the other Egress policy,
+// or an empty string if the policies do not conflict
+func getEgressConflictReason(egress, other *policyV1alpha1.Egress) string {
+	if !haveCommonEgressSource(egress, other) {
+		return ""
+	}
+
+	for _, port := range egress.Spec.Ports {
+		for _, otherPort := range other.Spec.Ports {
+			if port.Number == otherPort.Number && !strings.EqualFold(port.Protocol, otherPort.Protocol) {
+				return fmt.Sprintf("port %d with protocol %s conflicts with protocol %s in Egress %s",
+					port.Number, port.Protocol, otherPort.Protocol, getEgressKey(other))
+			}
+		}
+	}
+
+	return ""
+}
+
+func haveCommonEgressSource(egress, other *policyV1alpha1.Egress) bool {
+	for _, source := range egress.Spec.Sources {
+		for _, otherSource := range other.Spec.Sources {
+			if source == otherSource {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getEgressKey(egress *policyV1alpha1.Egress) string {
+	return fmt.Sprintf("%s/%s", egress.Namespace, egress.Name)
+}
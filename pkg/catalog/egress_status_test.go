@@ -0,0 +1,177 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+func newEgressForStatus(name string, source string, port int, protocol string) *policyV1alpha1.Egress {
+	return &policyV1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec: policyV1alpha1.EgressSpec{
+			Sources: []policyV1alpha1.EgressSourceSpec{{Kind: "ServiceAccount", Name: source, Namespace: "ns"}},
+			Hosts:   []string{"foo.com"},
+			Ports:   []policyV1alpha1.PortSpec{{Number: port, Protocol: protocol}},
+		},
+	}
+}
+
+func TestGetEgressStatuses(t *testing.T) {
+	invalidIPRange := newEgressForStatus("invalid-ip", "sa-1", 90, "tcp")
+	invalidIPRange.Spec.IPAddresses = []string{"1.1.1.1"}
+
+	invalidSource := newEgressForStatus("invalid-source", "sa-1", 90, "tcp")
+	invalidSource.Spec.Sources[0].Kind = "Service"
+
+	missingRouteGroup := newEgressForStatus("missing-route-group", "sa-1", 90, "http")
+	missingRouteGroup.Spec.Matches = []corev1.TypedLocalObjectReference{{APIGroup: pointer.StringPtr("specs.smi-spec.io/v1alpha4"), Kind: "HTTPRouteGroup", Name: "missing"}}
+
+	missingSecret := newEgressForStatus("missing-secret", "sa-1", 90, "http")
+	missingSecret.Spec.TLS = &policyV1alpha1.EgressTLSSpec{CABundleSecret: corev1.SecretReference{Name: "ca"}}
+
+	wildcardHost := newEgressForStatus("wildcard-host", "sa-1", 80, "http")
+	wildcardHost.Spec.Hosts = []string{"*.foo.com"}
+
+	testCases := []struct {
+		name             string
+		egressPolicies   []*policyV1alpha1.Egress
+		egressGateway    bool
+		expectedStatuses map[string]string
+	}{
+		{
+			name: "policies for different sources on the same port do not conflict",
+			egressPolicies: []*policyV1alpha1.Egress{
+				newEgressForStatus("egress-1", "sa-1", 80, "http"),
+				newEgressForStatus("egress-2", "sa-2", 80, "tcp"),
+			},
+			expectedStatuses: map[string]string{
+				"ns/egress-1": policyV1alpha1.EgressStatusAccepted,
+				"ns/egress-2": policyV1alpha1.EgressStatusAccepted,
+			},
+		},
+		{
+			name: "policies for the same source on the same port with the same protocol do not conflict",
+			egressPolicies: []*policyV1alpha1.Egress{
+				newEgressForStatus("egress-1", "sa-1", 80, "http"),
+				newEgressForStatus("egress-2", "sa-1", 80, "HTTP"),
+			},
+			expectedStatuses: map[string]string{
+				"ns/egress-1": policyV1alpha1.EgressStatusAccepted,
+				"ns/egress-2": policyV1alpha1.EgressStatusAccepted,
+			},
+		},
+		{
+			name: "policies for the same source on the same port with different protocols conflict",
+			egressPolicies: []*policyV1alpha1.Egress{
+				newEgressForStatus("egress-1", "sa-1", 80, "http"),
+				newEgressForStatus("egress-2", "sa-1", 80, "tcp"),
+				newEgressForStatus("egress-3", "sa-1", 90, "tcp"),
+			},
+			expectedStatuses: map[string]string{
+				"ns/egress-1": policyV1alpha1.EgressStatusConflicting,
+				"ns/egress-2": policyV1alpha1.EgressStatusConflicting,
+				"ns/egress-3": policyV1alpha1.EgressStatusAccepted,
+			},
+		},
+		{
+			name:           "invalid policies",
+			egressPolicies: []*policyV1alpha1.Egress{invalidIPRange, invalidSource, missingRouteGroup, missingSecret},
+			expectedStatuses: map[string]string{
+				"ns/invalid-ip":          policyV1alpha1.EgressStatusInvalid,
+				"ns/invalid-source":      policyV1alpha1.EgressStatusInvalid,
+				"ns/missing-route-group": policyV1alpha1.EgressStatusInvalid,
+				"ns/missing-secret":      policyV1alpha1.EgressStatusInvalid,
+			},
+		},
+		{
+			name: "policies allowing traffic the egress gateway cannot proxy",
+			egressPolicies: []*policyV1alpha1.Egress{
+				newEgressForStatus("http", "sa-1", 80, "http"),
+				newEgressForStatus("https", "sa-1", 443, "https"),
+				newEgressForStatus("tcp", "sa-1", 3306, "tcp"),
+				wildcardHost,
+			},
+			egressGateway: true,
+			expectedStatuses: map[string]string{
+				"ns/http":          policyV1alpha1.EgressStatusAccepted,
+				"ns/https":         policyV1alpha1.EgressStatusInvalid,
+				"ns/tcp":           policyV1alpha1.EgressStatusInvalid,
+				"ns/wildcard-host": policyV1alpha1.EgressStatusInvalid,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+			mockMeshSpec.EXPECT().GetHTTPRouteGroup(gomock.Any()).Return(nil).AnyTimes()
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockKubeController.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			mc := &MeshCatalog{
+				meshSpec:       mockMeshSpec,
+				kubeController: mockKubeController,
+			}
+
+			statuses := mc.getEgressStatuses(tc.egressPolicies, tc.egressGateway)
+			assert.Len(statuses, len(tc.expectedStatuses))
+			for key, expected := range tc.expectedStatuses {
+				assert.Equal(expected, statuses[key].CurrentStatus, key)
+				assert.NotEmpty(statuses[key].Reason, key)
+			}
+		})
+	}
+}
+
+func TestUpdateEgressStatuses(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+
+	mc := &MeshCatalog{
+		configurator:     mockCfg,
+		policyController: mockPolicyController,
+		kubeController:   mockKubeController,
+	}
+
+	upToDate := newEgressForStatus("egress-1", "sa-1", 80, "http")
+	upToDate.Status = policyV1alpha1.EgressStatus{
+		CurrentStatus: policyV1alpha1.EgressStatusAccepted,
+		Reason:        "successfully committed by the system",
+	}
+	outdated := newEgressForStatus("egress-2", "sa-2", 80, "http")
+
+	mockCfg.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableEgressPolicy: true}).Times(1)
+	mockPolicyController.EXPECT().ListEgressPolicies().Return([]*policyV1alpha1.Egress{upToDate, outdated}).Times(1)
+	mockKubeController.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(resource interface{}) (metav1.Object, error) {
+		updated := resource.(*policyV1alpha1.Egress)
+		assert.Equal("egress-2", updated.Name)
+		assert.Equal(policyV1alpha1.EgressStatusAccepted, updated.Status.CurrentStatus)
+		return updated, nil
+	}).Times(1)
+
+	mc.updateEgressStatuses()
+
+	// The status of the policy in the cache must not be modified
+	assert.Empty(outdated.Status.CurrentStatus)
+}
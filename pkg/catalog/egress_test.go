@@ -17,6 +17,7 @@ import (
 	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/policy"
 
 	"github.com/openservicemesh/osm/pkg/service"
@@ -662,13 +663,203 @@ func TestBuildHTTPRouteConfigs(t *testing.T) {
 				meshSpec: mockMeshSpec,
 			}
 
-			routeConfigs, clusterConfigs := mc.buildHTTPRouteConfigs(tc.egressPolicy, tc.egressPort)
+			routeConfigs, clusterConfigs := mc.buildHTTPRouteConfigs(tc.egressPolicy, tc.egressPort, nil)
 			assert.ElementsMatch(tc.expectedRouteConfigs, routeConfigs)
 			assert.ElementsMatch(tc.expectedClusterConfigs, clusterConfigs)
 		})
 	}
 }
 
+func TestGetEgressGatewayTrafficPolicy(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		configurator:     mockCfg,
+		policyController: mockPolicyController,
+	}
+
+	egressPolicies := []*policyV1alpha1.Egress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "egress-1", Namespace: "ns-1"},
+			Spec: policyV1alpha1.EgressSpec{
+				Sources: []policyV1alpha1.EgressSourceSpec{
+					{Kind: "ServiceAccount", Name: "sa-1", Namespace: "ns-1"},
+				},
+				Hosts: []string{"foo.com", "*.bar.com"},
+				Ports: []policyV1alpha1.PortSpec{
+					{Number: 80, Protocol: "http"},
+					{Number: 443, Protocol: "https"},
+				},
+			},
+		},
+		{
+			// No valid sources, must be ignored
+			ObjectMeta: metav1.ObjectMeta{Name: "egress-2", Namespace: "ns-1"},
+			Spec: policyV1alpha1.EgressSpec{
+				Hosts: []string{"baz.com"},
+				Ports: []policyV1alpha1.PortSpec{{Number: 80, Protocol: "http"}},
+			},
+		},
+	}
+
+	mockCfg.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableEgressPolicy: true, EnableEgressGateway: true}).Times(1)
+	mockCfg.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).Times(1)
+	mockPolicyController.EXPECT().ListEgressPolicies().Return(egressPolicies).Times(1)
+
+	actual, err := mc.GetEgressGatewayTrafficPolicy()
+	assert.Nil(err)
+	assert.Nil(actual.TrafficMatches)
+	assert.Equal([]*trafficpolicy.EgressClusterConfig{{Name: "foo.com:80", Host: "foo.com", Port: 80}}, actual.ClustersConfigs)
+	assert.Len(actual.HTTPRouteConfigsPerPort, 1)
+	assert.Len(actual.HTTPRouteConfigsPerPort[80], 1)
+
+	routeConfig := actual.HTTPRouteConfigsPerPort[80][0]
+	assert.Equal("foo.com", routeConfig.Name)
+	assert.Len(routeConfig.RoutingRules, 1)
+	assert.Equal(mapset.NewSet(identity.ServiceIdentity("sa-1.ns-1.cluster.local")), routeConfig.RoutingRules[0].AllowedServiceIdentities)
+
+	// Egress gateway disabled
+	mockCfg.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableEgressPolicy: true}).Times(1)
+	actual, err = mc.GetEgressGatewayTrafficPolicy()
+	assert.Nil(err)
+	assert.Nil(actual)
+}
+
+func TestGetEgressTrafficPolicyViaEgressGateway(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		configurator:     mockCfg,
+		policyController: mockPolicyController,
+	}
+
+	egressPolicies := []*policyV1alpha1.Egress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "egress-1", Namespace: "ns-1"},
+			Spec: policyV1alpha1.EgressSpec{
+				Sources: []policyV1alpha1.EgressSourceSpec{
+					{Kind: "ServiceAccount", Name: "sa-1", Namespace: "ns-1"},
+				},
+				Hosts: []string{"foo.com", "*.bar.com"},
+				Ports: []policyV1alpha1.PortSpec{
+					{Number: 80, Protocol: "http"},
+					{Number: 443, Protocol: "https"},
+					{Number: 3306, Protocol: "tcp"},
+				},
+				IPAddresses: []string{"10.0.0.0/24"},
+			},
+		},
+	}
+
+	mockCfg.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableEgressPolicy: true, EnableEgressGateway: true}).Times(1)
+	mockPolicyController.EXPECT().ListEgressPoliciesForSourceIdentity(gomock.Any()).Return(egressPolicies).Times(1)
+
+	actual, err := mc.GetEgressTrafficPolicy(identity.ServiceIdentity("sa-1.ns-1.cluster.local"))
+	assert.Nil(err)
+
+	// Only the HTTP traffic to non-wildcard hosts is allowed, through the egress gateway
+	assert.Equal([]*trafficpolicy.EgressClusterConfig{{Name: "foo.com:80", Host: "foo.com", Port: 80, ViaEgressGateway: true}}, actual.ClustersConfigs)
+	assert.Equal([]*trafficpolicy.TrafficMatch{{DestinationPort: 80, DestinationProtocol: "http"}}, actual.TrafficMatches)
+	assert.Len(actual.HTTPRouteConfigsPerPort, 1)
+	assert.Len(actual.HTTPRouteConfigsPerPort[80], 1)
+	assert.Equal("foo.com", actual.HTTPRouteConfigsPerPort[80][0].Name)
+	assert.True(actual.HTTPRouteConfigsPerPort[80][0].ViaEgressGateway)
+}
+
+func TestGetEgressTLSConfig(t *testing.T) {
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "egress-ns"},
+		Data:       map[string][]byte{"ca.crt": []byte("ca-bundle")},
+	}
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: "egress-ns"},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")},
+	}
+
+	testCases := []struct {
+		name              string
+		tlsSpec           *policyV1alpha1.EgressTLSSpec
+		expectedTLSConfig *trafficpolicy.EgressTLSConfig
+		expectError       bool
+	}{
+		{
+			name:              "CA bundle in the egress policy's namespace",
+			tlsSpec:           &policyV1alpha1.EgressTLSSpec{CABundleSecret: corev1.SecretReference{Name: "ca"}},
+			expectedTLSConfig: &trafficpolicy.EgressTLSConfig{CABundle: []byte("ca-bundle")},
+		},
+		{
+			name: "CA bundle and client certificate",
+			tlsSpec: &policyV1alpha1.EgressTLSSpec{
+				CABundleSecret:          corev1.SecretReference{Name: "ca", Namespace: "egress-ns"},
+				ClientCertificateSecret: &corev1.SecretReference{Name: "client"},
+			},
+			expectedTLSConfig: &trafficpolicy.EgressTLSConfig{
+				CABundle:                []byte("ca-bundle"),
+				ClientCertificate:       []byte("cert"),
+				ClientKey:               []byte("key"),
+				ClientCertificateSecret: "egress-ns/client",
+			},
+		},
+		{
+			name: "client certificate secret in another namespace",
+			tlsSpec: &policyV1alpha1.EgressTLSSpec{
+				CABundleSecret:          corev1.SecretReference{Name: "ca"},
+				ClientCertificateSecret: &corev1.SecretReference{Name: "client", Namespace: "other-ns"},
+			},
+			expectError: true,
+		},
+		{
+			name:        "CA bundle secret not found",
+			tlsSpec:     &policyV1alpha1.EgressTLSSpec{CABundleSecret: corev1.SecretReference{Name: "missing"}},
+			expectError: true,
+		},
+		{
+			name: "client certificate secret missing keys",
+			tlsSpec: &policyV1alpha1.EgressTLSSpec{
+				CABundleSecret:          corev1.SecretReference{Name: "ca"},
+				ClientCertificateSecret: &corev1.SecretReference{Name: "ca"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockKubeController.EXPECT().GetSecret(gomock.Any(), gomock.Any()).DoAndReturn(func(name, namespace string) *corev1.Secret {
+				for _, secret := range []*corev1.Secret{caSecret, clientSecret} {
+					if secret.Name == name && secret.Namespace == namespace {
+						return secret
+					}
+				}
+				return nil
+			}).AnyTimes()
+
+			mc := &MeshCatalog{kubeController: mockKubeController}
+			egress := &policyV1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{Name: "egress", Namespace: "egress-ns"},
+				Spec:       policyV1alpha1.EgressSpec{TLS: tc.tlsSpec},
+			}
+
+			tlsConfig, err := mc.getEgressTLSConfig(egress)
+			assert.Equal(tc.expectError, err != nil)
+			assert.Equal(tc.expectedTLSConfig, tlsConfig)
+		})
+	}
+}
+
 func TestGetHTTPRouteMatchesFromHTTPRouteGroup(t *testing.T) {
 	assert := tassert.New(t)
 
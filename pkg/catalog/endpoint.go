@@ -49,8 +49,8 @@ func (mc *MeshCatalog) ListEndpointsForServiceIdentity(downstreamIdentity identi
 	}
 	outboundEndpointsSet := make(map[string][]endpoint.Endpoint)
 	for _, ep := range outboundEndpoints {
-		ipStr := ep.IP.String()
-		outboundEndpointsSet[ipStr] = append(outboundEndpointsSet[ipStr], ep)
+		host := ep.Host()
+		outboundEndpointsSet[host] = append(outboundEndpointsSet[host], ep)
 	}
 
 	destSvcIdentities, err := mc.ListOutboundServiceIdentities(downstreamIdentity)
@@ -64,11 +64,11 @@ func (mc *MeshCatalog) ListEndpointsForServiceIdentity(downstreamIdentity identi
 	var allowedEndpoints []endpoint.Endpoint
 	for _, destSvcIdentity := range destSvcIdentities {
 		for _, ep := range mc.listEndpointsForServiceIdentity(destSvcIdentity) {
-			epIPStr := ep.IP.String()
-			// check if endpoint IP is allowed
-			if _, ok := outboundEndpointsSet[epIPStr]; ok {
+			epHost := ep.Host()
+			// check if endpoint IP or hostname is allowed
+			if _, ok := outboundEndpointsSet[epHost]; ok {
 				// add all allowed endpoints on the pod to result list
-				allowedEndpoints = append(allowedEndpoints, outboundEndpointsSet[epIPStr]...)
+				allowedEndpoints = append(allowedEndpoints, outboundEndpointsSet[epHost]...)
 			}
 		}
 	}
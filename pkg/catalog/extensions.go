@@ -0,0 +1,143 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	a "github.com/openservicemesh/osm/pkg/announcements"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// GetEnvoyFilterExtensions returns the Envoy filter extensions of the given direction for the proxies of the given
+// service identity, derived from the EnvoyFilterExtension resources applicable to the identity. The extensions are
+// ordered by position, then by name.
+// The extensions that can not be built, such as those whose WASM module is still being pulled, are skipped so that
+// the rest of the proxy's configuration is not held back. The proxies are updated once the module has been pulled.
+func (mc *MeshCatalog) GetEnvoyFilterExtensions(svcIdentity identity.ServiceIdentity, direction policyV1alpha1.EnvoyFilterDirection) []*trafficpolicy.EnvoyFilterExtension {
+	var extensionSpecs []*policyV1alpha1.EnvoyFilterExtension
+	for _, extension := range mc.policyController.ListEnvoyFilterExtensions(svcIdentity.ToK8sServiceAccount()) {
+		if extension.Spec.Direction == direction {
+			extensionSpecs = append(extensionSpecs, extension)
+		}
+	}
+
+	// The extensions are listed by name, so extensions with the same position remain ordered by name
+	sort.SliceStable(extensionSpecs, func(i, j int) bool {
+		return extensionSpecs[i].Spec.Position == policyV1alpha1.EnvoyFilterPositionFirst &&
+			extensionSpecs[j].Spec.Position != policyV1alpha1.EnvoyFilterPositionFirst
+	})
+
+	var extensions []*trafficpolicy.EnvoyFilterExtension
+	for _, extensionSpec := range extensionSpecs {
+		extension, err := mc.getEnvoyFilterExtension(extensionSpec)
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingEnvoyFilterExtension)).
+				Msgf("Error building EnvoyFilterExtension %s/%s for proxies with identity %s, skipping it", extensionSpec.Namespace, extensionSpec.Name, svcIdentity)
+			continue
+		}
+		extensions = append(extensions, extension)
+	}
+
+	return extensions
+}
+
+// getEnvoyFilterExtension returns the Envoy filter extension derived from the given EnvoyFilterExtension resource
+func (mc *MeshCatalog) getEnvoyFilterExtension(extensionSpec *policyV1alpha1.EnvoyFilterExtension) (*trafficpolicy.EnvoyFilterExtension, error) {
+	extension := &trafficpolicy.EnvoyFilterExtension{
+		Name: fmt.Sprintf("%s/%s", extensionSpec.Namespace, extensionSpec.Name),
+	}
+
+	switch {
+	case extensionSpec.Spec.Lua != nil && extensionSpec.Spec.WASM == nil:
+		extension.LuaInlineCode = extensionSpec.Spec.Lua.InlineCode
+
+	case extensionSpec.Spec.WASM != nil && extensionSpec.Spec.Lua == nil:
+		code, err := mc.getWASMModuleCode(extensionSpec.Spec.WASM, extensionSpec.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		extension.WASM = &trafficpolicy.WASMModule{
+			Code:          code,
+			RootID:        extensionSpec.Spec.WASM.RootID,
+			Configuration: extensionSpec.Spec.WASM.Configuration,
+		}
+
+	default:
+		return nil, errors.New("Expected exactly one of 'wasm' and 'lua' to be specified")
+	}
+
+	return extension, nil
+}
+
+// getWASMModuleCode returns the code of the given WASM module, from the ConfigMap in the extension's namespace or the OCI image it references
+func (mc *MeshCatalog) getWASMModuleCode(wasmSpec *policyV1alpha1.WASMFilterSpec, extensionNamespace string) ([]byte, error) {
+	switch {
+	case wasmSpec.ConfigMap != nil && wasmSpec.Image == "":
+		configMap := mc.kubeController.GetConfigMap(wasmSpec.ConfigMap.Name, extensionNamespace)
+		if configMap == nil {
+			return nil, errors.Errorf("ConfigMap %s/%s not found", extensionNamespace, wasmSpec.ConfigMap.Name)
+		}
+		code, ok := configMap.BinaryData[wasmSpec.ConfigMap.Key]
+		if !ok {
+			return nil, errors.Errorf("ConfigMap %s/%s is missing the binary data key %s", extensionNamespace, wasmSpec.ConfigMap.Name, wasmSpec.ConfigMap.Key)
+		}
+		return code, nil
+
+	case wasmSpec.Image != "" && wasmSpec.ConfigMap == nil:
+		return mc.wasmPuller.GetWASMModule(wasmSpec.Image)
+
+	default:
+		return nil, errors.New("Expected exactly one of 'configMap' and 'image' to be specified for the WASM module")
+	}
+}
+
+// listIdentitiesForEnvoyFilterExtension returns the service identities of the proxies the given EnvoyFilterExtension applies to
+func (mc *MeshCatalog) listIdentitiesForEnvoyFilterExtension(extension *policyV1alpha1.EnvoyFilterExtension) []identity.ServiceIdentity {
+	var identities []identity.ServiceIdentity
+	if len(extension.Spec.ServiceAccounts) == 0 {
+		for _, svcAccount := range mc.kubeController.ListServiceAccounts() {
+			if svcAccount.Namespace == extension.Namespace {
+				identities = append(identities, identity.K8sServiceAccount{Name: svcAccount.Name, Namespace: svcAccount.Namespace}.ToServiceIdentity(mc.configurator.GetTrustDomain()))
+			}
+		}
+		return identities
+	}
+
+	for _, name := range extension.Spec.ServiceAccounts {
+		identities = append(identities, identity.K8sServiceAccount{Name: name, Namespace: extension.Namespace}.ToServiceIdentity(mc.configurator.GetTrustDomain()))
+	}
+	return identities
+}
+
+// pullWASMModule starts pulling the WASM module of the image referenced by the EnvoyFilterExtension resource of the
+// given event in the background, so that it is available by the time the proxies it applies to are updated
+func (mc *MeshCatalog) pullWASMModule(msg events.PubSubMessage) {
+	if msg.AnnouncementType != a.EnvoyFilterExtensionAdded && msg.AnnouncementType != a.EnvoyFilterExtensionUpdated {
+		return
+	}
+	extension, ok := msg.NewObj.(*policyV1alpha1.EnvoyFilterExtension)
+	if !ok || extension.Spec.WASM == nil || extension.Spec.WASM.Image == "" {
+		return
+	}
+	mc.wasmPuller.Pull(extension.Spec.WASM.Image)
+}
+
+// publishWASMModulePulled notifies the dispatcher that the WASM module of the given image has been pulled
+func publishWASMModulePulled(image string) {
+	events.Publish(events.PubSubMessage{
+		AnnouncementType: a.WASMModulePulled,
+		NewObj:           image,
+	})
+}
+
+// isImageReferenced returns true if the WASM module of an EnvoyFilterExtension resource references the given image
+func (mc *MeshCatalog) isImageReferenced(image string) bool {
+	return len(mc.listIdentitiesReferencingImage(image)) > 0
+}
@@ -0,0 +1,152 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/oci"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetEnvoyFilterExtensions(t *testing.T) {
+	svcAccount := identity.K8sServiceAccount{Name: "backend", Namespace: "test"}
+	luaCode := "function envoy_on_request(request_handle) end"
+	wasmCode := []byte("\x00asm\x01\x00\x00\x00")
+
+	newExtension := func(name string, direction policyV1alpha1.EnvoyFilterDirection, position policyV1alpha1.EnvoyFilterPosition) *policyV1alpha1.EnvoyFilterExtension {
+		return &policyV1alpha1.EnvoyFilterExtension{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+				Direction: direction,
+				Position:  position,
+				Lua:       &policyV1alpha1.LuaFilterSpec{InlineCode: luaCode},
+			},
+		}
+	}
+	wasmExtension := &policyV1alpha1.EnvoyFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "wasm", Namespace: "test"},
+		Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+			Direction: policyV1alpha1.EnvoyFilterDirectionInbound,
+			WASM: &policyV1alpha1.WASMFilterSpec{
+				ConfigMap:     &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "filter"}, Key: "filter.wasm"},
+				RootID:        "normalize",
+				Configuration: `{"lowercase": true}`,
+			},
+		},
+	}
+	missingConfigMapExtension := &policyV1alpha1.EnvoyFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "test"},
+		Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+			Direction: policyV1alpha1.EnvoyFilterDirectionInbound,
+			WASM: &policyV1alpha1.WASMFilterSpec{
+				ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "filter.wasm"},
+			},
+		},
+	}
+	pendingImageExtension := &policyV1alpha1.EnvoyFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "test"},
+		Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+			Direction: policyV1alpha1.EnvoyFilterDirectionInbound,
+			WASM: &policyV1alpha1.WASMFilterSpec{
+				Image: "localhost:1/org/filter@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		extensions         []*policyV1alpha1.EnvoyFilterExtension
+		direction          policyV1alpha1.EnvoyFilterDirection
+		expectedExtensions []*trafficpolicy.EnvoyFilterExtension
+	}{
+		{
+			name:               "no EnvoyFilterExtension applies to the identity",
+			direction:          policyV1alpha1.EnvoyFilterDirectionInbound,
+			expectedExtensions: nil,
+		},
+		{
+			name: "extensions of the direction ordered by position then name",
+			extensions: []*policyV1alpha1.EnvoyFilterExtension{
+				newExtension("a-last", policyV1alpha1.EnvoyFilterDirectionInbound, ""),
+				newExtension("b-first", policyV1alpha1.EnvoyFilterDirectionInbound, policyV1alpha1.EnvoyFilterPositionFirst),
+				newExtension("c-outbound", policyV1alpha1.EnvoyFilterDirectionOutbound, policyV1alpha1.EnvoyFilterPositionFirst),
+				newExtension("d-last", policyV1alpha1.EnvoyFilterDirectionInbound, policyV1alpha1.EnvoyFilterPositionLast),
+				newExtension("e-first", policyV1alpha1.EnvoyFilterDirectionInbound, policyV1alpha1.EnvoyFilterPositionFirst),
+				wasmExtension,
+			},
+			direction: policyV1alpha1.EnvoyFilterDirectionInbound,
+			expectedExtensions: []*trafficpolicy.EnvoyFilterExtension{
+				{Name: "test/b-first", LuaInlineCode: luaCode},
+				{Name: "test/e-first", LuaInlineCode: luaCode},
+				{Name: "test/a-last", LuaInlineCode: luaCode},
+				{Name: "test/d-last", LuaInlineCode: luaCode},
+				{Name: "test/wasm", WASM: &trafficpolicy.WASMModule{Code: wasmCode, RootID: "normalize", Configuration: `{"lowercase": true}`}},
+			},
+		},
+		{
+			name: "extension referencing a missing ConfigMap is skipped",
+			extensions: []*policyV1alpha1.EnvoyFilterExtension{
+				newExtension("a-last", policyV1alpha1.EnvoyFilterDirectionInbound, ""),
+				missingConfigMapExtension,
+			},
+			direction: policyV1alpha1.EnvoyFilterDirectionInbound,
+			expectedExtensions: []*trafficpolicy.EnvoyFilterExtension{
+				{Name: "test/a-last", LuaInlineCode: luaCode},
+			},
+		},
+		{
+			name:               "extension whose image is being pulled is skipped",
+			extensions:         []*policyV1alpha1.EnvoyFilterExtension{pendingImageExtension},
+			direction:          policyV1alpha1.EnvoyFilterDirectionInbound,
+			expectedExtensions: nil,
+		},
+		{
+			name: "extension with both a WASM module and a Lua script",
+			extensions: []*policyV1alpha1.EnvoyFilterExtension{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "both", Namespace: "test"},
+					Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+						Direction: policyV1alpha1.EnvoyFilterDirectionOutbound,
+						WASM:      wasmExtension.Spec.WASM,
+						Lua:       &policyV1alpha1.LuaFilterSpec{InlineCode: luaCode},
+					},
+				},
+			},
+			direction:          policyV1alpha1.EnvoyFilterDirectionOutbound,
+			expectedExtensions: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mc := &MeshCatalog{
+				kubeController:   mockKubeController,
+				policyController: mockPolicyController,
+				wasmPuller:       oci.NewPuller(nil, nil, nil),
+			}
+
+			mockPolicyController.EXPECT().ListEnvoyFilterExtensions(svcAccount).Return(tc.extensions)
+			mockKubeController.EXPECT().GetConfigMap("filter", "test").Return(&corev1.ConfigMap{
+				BinaryData: map[string][]byte{"filter.wasm": wasmCode},
+			}).AnyTimes()
+			mockKubeController.EXPECT().GetConfigMap("missing", "test").Return(nil).AnyTimes()
+
+			actual := mc.GetEnvoyFilterExtensions(svcAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain), tc.direction)
+			assert.Equal(tc.expectedExtensions, actual)
+		})
+	}
+}
@@ -113,16 +113,16 @@ func (mc *MeshCatalog) listInboundPoliciesForTrafficSplits(upstreamIdentity iden
 				servicePolicy := trafficpolicy.NewInboundTrafficPolicy(apexService.FQDN(), hostnames)
 				weightedCluster := getDefaultWeightedClusterForService(upstreamSvc)
 
-				for _, sourceServiceAccount := range trafficTargetIdentitiesToSvcAccounts(t.Spec.Sources) {
+				for _, sourceIdentity := range mc.getTrafficTargetSourceIdentities(t) {
 					for _, routeMatch := range routeMatches {
 						// If the traffic target has a route with host headers
 						// we need to create a new inbound traffic policy with the host header as the required hostnames
 						// else the hosnames will be hostnames corresponding to the service
 						if _, ok := routeMatch.Headers[hostHeaderKey]; !ok {
-							servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceServiceAccount.ToServiceIdentity())
+							servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceIdentity)
 						} else {
 							servicePolicyWithHostHeader := trafficpolicy.NewInboundTrafficPolicy(routeMatch.Headers[hostHeaderKey], []string{routeMatch.Headers[hostHeaderKey]})
-							servicePolicyWithHostHeader.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceServiceAccount.ToServiceIdentity())
+							servicePolicyWithHostHeader.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceIdentity)
 							inboundPolicies = trafficpolicy.MergeInboundPolicies(AllowPartialHostnamesMatch, inboundPolicies, servicePolicyWithHostHeader)
 						}
 					}
@@ -155,16 +155,16 @@ func (mc *MeshCatalog) buildInboundPolicies(t *access.TrafficTarget, svc service
 	servicePolicy := trafficpolicy.NewInboundTrafficPolicy(svc.FQDN(), hostnames)
 	weightedCluster := getDefaultWeightedClusterForService(svc)
 
-	for _, sourceServiceAccount := range trafficTargetIdentitiesToSvcAccounts(t.Spec.Sources) {
+	for _, sourceIdentity := range mc.getTrafficTargetSourceIdentities(t) {
 		for _, routeMatch := range routeMatches {
 			// If the traffic target has a route with host headers
 			// we need to create a new inbound traffic policy with the host header as the required hostnames
 			// else the hosnames will be hostnames corresponding to the service
 			if _, ok := routeMatch.Headers[hostHeaderKey]; !ok {
-				servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceServiceAccount.ToServiceIdentity())
+				servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceIdentity)
 			} else {
 				servicePolicyWithHostHeader := trafficpolicy.NewInboundTrafficPolicy(routeMatch.Headers[hostHeaderKey], []string{routeMatch.Headers[hostHeaderKey]})
-				servicePolicyWithHostHeader.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceServiceAccount.ToServiceIdentity())
+				servicePolicyWithHostHeader.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceIdentity)
 				inboundPolicies = trafficpolicy.MergeInboundPolicies(AllowPartialHostnamesMatch, inboundPolicies, servicePolicyWithHostHeader)
 			}
 		}
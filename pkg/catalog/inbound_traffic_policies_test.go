@@ -111,7 +111,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -124,7 +124,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -223,7 +223,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -236,7 +236,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -266,7 +266,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -279,7 +279,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -379,7 +379,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -409,7 +409,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -428,7 +428,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -517,6 +517,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 			}
 
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(tc.permissiveMode).AnyTimes()
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 
 			for _, ms := range tc.meshServices {
 				locality := service.LocalCluster
@@ -555,11 +556,11 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 			downstreamSA: identity.K8sServiceAccount{
 				Name:      "bookbuyer",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamSA: identity.K8sServiceAccount{
 				Name:      "bookstore",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamServices: []service.MeshService{{
 				Name:      "bookstore",
 				Namespace: "default",
@@ -607,11 +608,11 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 			downstreamSA: identity.K8sServiceAccount{
 				Name:      "bookbuyer",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamSA: identity.K8sServiceAccount{
 				Name:      "bookstore",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamServices: []service.MeshService{{
 				Name:      "bookstore",
 				Namespace: "default",
@@ -699,7 +700,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -712,7 +713,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -723,11 +724,11 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 			downstreamSA: identity.K8sServiceAccount{
 				Name:      "bookbuyer",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamSA: identity.K8sServiceAccount{
 				Name:      "bookstore",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamServices: []service.MeshService{{
 				Name:      "bookstore",
 				Namespace: "default",
@@ -815,7 +816,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -828,7 +829,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -839,11 +840,11 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 			downstreamSA: identity.K8sServiceAccount{
 				Name:      "bookbuyer",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamSA: identity.K8sServiceAccount{
 				Name:      "bookstore",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamServices: []service.MeshService{{
 				Name:      "bookstore",
 				Namespace: "default",
@@ -932,7 +933,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -951,7 +952,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -967,14 +968,18 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 
 			mc := MeshCatalog{
 				kubeController:     mockKubeController,
 				meshSpec:           mockMeshSpec,
 				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 				serviceProviders:   []service.Provider{mockServiceProvider},
+				configurator:       mockConfigurator,
 			}
 
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
+
 			for _, meshSvc := range tc.meshServices {
 				k8sService := tests.NewServiceFixture(meshSvc.Name, meshSvc.Namespace, map[string]string{})
 				mockKubeController.EXPECT().GetService(meshSvc).Return(k8sService).AnyTimes()
@@ -1021,11 +1026,11 @@ func TestBuildInboundPolicies(t *testing.T) {
 			sourceSA: identity.K8sServiceAccount{
 				Name:      "bookbuyer",
 				Namespace: "bookbuyer-ns",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			destSA: identity.K8sServiceAccount{
 				Name:      "bookstore",
 				Namespace: "bookstore-ns",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			inboundService: service.MeshService{
 				Name:      "bookstore",
 				Namespace: "bookstore-ns",
@@ -1088,7 +1093,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "bookbuyer-ns",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -1101,7 +1106,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "bookbuyer-ns",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -1112,11 +1117,11 @@ func TestBuildInboundPolicies(t *testing.T) {
 			sourceSA: identity.K8sServiceAccount{
 				Name:      "bookbuyer",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			destSA: identity.K8sServiceAccount{
 				Name:      "bookstore",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			inboundService: service.MeshService{
 				Name:      "bookstore",
 				Namespace: "default",
@@ -1179,7 +1184,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -1192,7 +1197,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -1251,7 +1256,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -1270,7 +1275,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -1286,14 +1291,18 @@ func TestBuildInboundPolicies(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 
 			mc := MeshCatalog{
 				kubeController:     mockKubeController,
 				meshSpec:           mockMeshSpec,
 				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 				serviceProviders:   []service.Provider{mockServiceProvider},
+				configurator:       mockConfigurator,
 			}
 
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
+
 			destK8sService := tests.NewServiceFixture(tc.inboundService.Name, tc.inboundService.Namespace, map[string]string{})
 			mockKubeController.EXPECT().GetService(tc.inboundService).Return(destK8sService).AnyTimes()
 
@@ -1379,14 +1388,18 @@ func TestBuildInboundPermissiveModePolicies(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 
 			mc := MeshCatalog{
 				kubeController:     mockKubeController,
 				meshSpec:           mockMeshSpec,
 				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 				serviceProviders:   []service.Provider{mockServiceProvider},
+				configurator:       mockConfigurator,
 			}
 
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
+
 			k8sService := tests.NewServiceFixture(tc.meshService.Name, tc.meshService.Namespace, map[string]string{})
 
 			mockEndpointProvider.EXPECT().GetID().Return("fake").AnyTimes()
@@ -1429,11 +1442,11 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 			downstreamServiceIdentity: identity.K8sServiceAccount{
 				Name:      "bookbuyer",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamServiceIdentity: identity.K8sServiceAccount{
 				Name:      "bookstore",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamServices: []service.MeshService{{
 				Name:      "bookstore",
 				Namespace: "default",
@@ -1496,7 +1509,7 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -1509,7 +1522,7 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -1520,11 +1533,11 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 			downstreamServiceIdentity: identity.K8sServiceAccount{
 				Name:      "bookbuyer",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamServiceIdentity: identity.K8sServiceAccount{
 				Name:      "bookstore",
 				Namespace: "default",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			upstreamServices: []service.MeshService{{
 				Name:      "bookstore",
 				Namespace: "default",
@@ -1588,7 +1601,7 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -1615,7 +1628,7 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -1631,14 +1644,18 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 
 			mc := MeshCatalog{
 				kubeController:     mockKubeController,
 				meshSpec:           mockMeshSpec,
 				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 				serviceProviders:   []service.Provider{mockServiceProvider},
+				configurator:       mockConfigurator,
 			}
 
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
+
 			for _, destMeshSvc := range tc.upstreamServices {
 				destK8sService := tests.NewServiceFixture(destMeshSvc.Name, destMeshSvc.Namespace, map[string]string{})
 				mockKubeController.EXPECT().GetService(destMeshSvc).Return(destK8sService).AnyTimes()
@@ -1912,14 +1929,18 @@ func TestGetHTTPPathsPerRoute(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 
 			mc := MeshCatalog{
 				kubeController:     mockKubeController,
 				meshSpec:           mockMeshSpec,
 				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 				serviceProviders:   []service.Provider{mockServiceProvider},
+				configurator:       mockConfigurator,
 			}
 
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
+
 			mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return([]*spec.HTTPRouteGroup{&tc.trafficSpec}).AnyTimes()
 			actual, err := mc.getHTTPPathsPerRoute()
 			assert.Nil(err)
@@ -3,6 +3,7 @@ package catalog
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
@@ -41,13 +42,47 @@ var _ = regexp.MustCompile(prefixMatchPathElementsRegex)
 
 // GetIngressTrafficPolicy returns the ingress traffic policy for the given mesh service
 // Depending on if the IngressBackend API is enabled, the policies will be generated either from the IngressBackend
-// or Kubernetes Ingress API.
+// or Kubernetes Ingress API. Policies generated from Gateway API HTTPRoutes are merged with them.
 func (mc *MeshCatalog) GetIngressTrafficPolicy(svc service.MeshService) (*trafficpolicy.IngressTrafficPolicy, error) {
+	var ingressPolicy *trafficpolicy.IngressTrafficPolicy
+	var err error
 	if mc.configurator.GetFeatureFlags().EnableIngressBackendPolicy {
-		return mc.getIngressTrafficPolicy(svc)
+		ingressPolicy, err = mc.getIngressTrafficPolicy(svc)
+	} else {
+		ingressPolicy, err = mc.getIngressTrafficPolicyFromK8s(svc)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayAPIPolicy, err := mc.getIngressTrafficPolicyFromGatewayAPI(svc)
+	if err != nil {
+		// Ingress configured using other APIs must not be impacted by invalid Gateway API configurations
+		log.Error().Err(err).Msgf("Error building ingress traffic policy from Gateway API resources for service %s", svc)
+		return ingressPolicy, nil
+	}
+
+	return mergeIngressTrafficPolicies(ingressPolicy, gatewayAPIPolicy), nil
+}
+
+// ListIngressSourceServices returns the source services of the IngressBackend policy for the given mesh service,
+// whose endpoints are allowed to send ingress traffic to the service
+func (mc *MeshCatalog) ListIngressSourceServices(svc service.MeshService) []service.MeshService {
+	if !mc.configurator.GetFeatureFlags().EnableIngressBackendPolicy {
+		return nil
+	}
+	ingressBackendPolicy := mc.policyController.GetIngressBackendPolicy(svc)
+	if ingressBackendPolicy == nil {
+		return nil
 	}
 
-	return mc.getIngressTrafficPolicyFromK8s(svc)
+	var sourceServices []service.MeshService
+	for _, source := range ingressBackendPolicy.Spec.Sources {
+		if source.Kind == policyV1alpha1.KindService {
+			sourceServices = append(sourceServices, service.MeshService{Name: source.Name, Namespace: source.Namespace})
+		}
+	}
+	return sourceServices
 }
 
 // getIngressTrafficPolicy returns the ingress traffic policy for the given mesh service from corresponding IngressBackend resource
@@ -62,8 +97,8 @@ func (mc *MeshCatalog) getIngressTrafficPolicy(svc service.MeshService) (*traffi
 	// Note: The original pointer returned by cache.Store must not be modified for thread safety.
 	ingressBackendWithStatus := *ingressBackendPolicy
 
-	var trafficRoutingRules []*trafficpolicy.Rule
-	sourceServiceIdentities := mapset.NewSet()
+	// The routing rules are keyed by backend port, whose filter chain restricts the sources, and hostname
+	trafficRoutingRules := make(map[uint32]map[string][]*trafficpolicy.Rule)
 	var trafficMatches []*trafficpolicy.IngressTrafficMatch
 	for _, backend := range ingressBackendPolicy.Spec.Backends {
 		if backend.Name != svc.Name {
@@ -80,6 +115,7 @@ func (mc *MeshCatalog) getIngressTrafficPolicy(svc service.MeshService) (*traffi
 
 		var sourceIPRanges []string
 		sourceIPSet := mapset.NewSet() // Used to avoid duplicate IP ranges
+		sourceServiceIdentities := mapset.NewSet()
 		for _, source := range ingressBackendPolicy.Spec.Sources {
 			switch source.Kind {
 			case policyV1alpha1.KindService:
@@ -90,9 +126,7 @@ func (mc *MeshCatalog) getIngressTrafficPolicy(svc service.MeshService) (*traffi
 						CurrentStatus: "error",
 						Reason:        fmt.Sprintf("endpoints not found for service %s/%s", source.Namespace, source.Name),
 					}
-					if _, err := mc.kubeController.UpdateStatus(&ingressBackendWithStatus); err != nil {
-						log.Error().Err(err).Msg("Error updating status for IngressBackend")
-					}
+					mc.updateIngressBackendStatus(&ingressBackendWithStatus)
 					return nil, errors.Errorf("Could not list endpoints of the source service %s/%s specified in the IngressBackend %s/%s",
 						source.Namespace, source.Name, ingressBackendPolicy.Namespace, ingressBackendPolicy.Name)
 				}
@@ -125,43 +159,115 @@ func (mc *MeshCatalog) getIngressTrafficPolicy(svc service.MeshService) (*traffi
 		trafficMatch.SourceIPRanges = sourceIPRanges
 		trafficMatches = append(trafficMatches, trafficMatch)
 
-		// Build the routing rule for this backend and source combination.
-		// Currently IngressBackend only supports a wildcard HTTP route. The
-		// 'Matches' field in the spec can be used to extend this to perform
-		// stricter enforcement.
+		// Build the routing rules for this backend and source combination.
+		// A backend without HTTP rules allows all requests on its port.
+		port := uint32(backend.Port.Number)
+		if trafficRoutingRules[port] == nil {
+			trafficRoutingRules[port] = make(map[string][]*trafficpolicy.Rule)
+		}
 		backendCluster := getDefaultWeightedClusterForService(svc)
-		routingRule := &trafficpolicy.Rule{
-			Route: trafficpolicy.RouteWeightedClusters{
-				HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
-				WeightedClusters: mapset.NewSet(backendCluster),
-			},
-			AllowedServiceIdentities: sourceServiceIdentities,
+		for hostname, rules := range getIngressBackendRoutingRules(backend, backendCluster, sourceServiceIdentities) {
+			trafficRoutingRules[port][hostname] = append(trafficRoutingRules[port][hostname], rules...)
 		}
-		trafficRoutingRules = append(trafficRoutingRules, routingRule)
 	}
 
 	ingressBackendWithStatus.Status = policyV1alpha1.IngressBackendStatus{
 		CurrentStatus: "committed",
 		Reason:        "successfully committed by the system",
 	}
-	if _, err := mc.kubeController.UpdateStatus(&ingressBackendWithStatus); err != nil {
-		log.Error().Err(err).Msg("Error updating status for IngressBackend")
-	}
+	mc.updateIngressBackendStatus(&ingressBackendWithStatus)
 
-	// Create an inbound traffic policy from the routing rules
-	// TODO(#3779): Implement HTTP route matching from IngressBackend.Spec.Matches
-	httpRoutePolicy := &trafficpolicy.InboundTrafficPolicy{
-		Name:      fmt.Sprintf("%s_from_%s", svc, ingressBackendPolicy.Name),
-		Hostnames: []string{"*"},
-		Rules:     trafficRoutingRules,
+	httpRoutePoliciesPerPort := make(map[uint32][]*trafficpolicy.InboundTrafficPolicy)
+	for port, rules := range trafficRoutingRules {
+		httpRoutePoliciesPerPort[port] = getIngressBackendRoutePolicies(fmt.Sprintf("%s_from_%s", svc, ingressBackendPolicy.Name), rules)
 	}
 
 	return &trafficpolicy.IngressTrafficPolicy{
-		TrafficMatches:    trafficMatches,
-		HTTPRoutePolicies: []*trafficpolicy.InboundTrafficPolicy{httpRoutePolicy},
+		TrafficMatches:           trafficMatches,
+		HTTPRoutePoliciesPerPort: httpRoutePoliciesPerPort,
 	}, nil
 }
 
+// getIngressBackendRoutePolicies returns an inbound traffic policy per hostname from the given routing rules keyed
+// by hostname. Requests to hostnames without a policy do not match any route and are rejected.
+// Since a request is matched against the routes of the most specific hostname only, the rules that do not
+// restrict the hostname are added to the policy of each hostname.
+func getIngressBackendRoutePolicies(name string, trafficRoutingRules map[string][]*trafficpolicy.Rule) []*trafficpolicy.InboundTrafficPolicy {
+	var hostnames []string
+	for hostname := range trafficRoutingRules {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+
+	var httpRoutePolicies []*trafficpolicy.InboundTrafficPolicy
+	for _, hostname := range hostnames {
+		policyName := name
+		rules := trafficRoutingRules[hostname]
+		if hostname != constants.WildcardHTTPMethod {
+			policyName = fmt.Sprintf("%s|%s", name, hostname)
+			rules = append(rules, trafficRoutingRules[constants.WildcardHTTPMethod]...)
+		}
+		httpRoutePolicies = append(httpRoutePolicies, &trafficpolicy.InboundTrafficPolicy{
+			Name:      policyName,
+			Hostnames: []string{hostname},
+			Rules:     rules,
+		})
+	}
+	return httpRoutePolicies
+}
+
+// getIngressBackendRoutingRules returns the routing rules keyed by hostname for the given IngressBackend backend.
+// Rules that do not restrict the hostname are keyed by the wildcard hostname.
+func getIngressBackendRoutingRules(backend policyV1alpha1.BackendSpec, backendCluster service.WeightedCluster, allowedServiceIdentities mapset.Set) map[string][]*trafficpolicy.Rule {
+	rules := make(map[string][]*trafficpolicy.Rule)
+
+	if len(backend.HTTPRules) == 0 {
+		rules[constants.WildcardHTTPMethod] = []*trafficpolicy.Rule{
+			{
+				Route: trafficpolicy.RouteWeightedClusters{
+					HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+					WeightedClusters: mapset.NewSet(backendCluster),
+				},
+				AllowedServiceIdentities: allowedServiceIdentities,
+			},
+		}
+		return rules
+	}
+
+	for _, httpRule := range backend.HTTPRules {
+		hostnames := httpRule.Hostnames
+		if len(hostnames) == 0 {
+			hostnames = []string{constants.WildcardHTTPMethod}
+		}
+		pathPrefixes := httpRule.PathPrefixes
+		if len(pathPrefixes) == 0 {
+			pathPrefixes = []string{"/"}
+		}
+		methods := httpRule.Methods
+		if len(methods) == 0 {
+			methods = []string{constants.WildcardHTTPMethod}
+		}
+
+		for _, hostname := range hostnames {
+			for _, pathPrefix := range pathPrefixes {
+				rules[hostname] = append(rules[hostname], &trafficpolicy.Rule{
+					Route: trafficpolicy.RouteWeightedClusters{
+						HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+							Path:          pathPrefix,
+							PathMatchType: trafficpolicy.PathMatchPrefix,
+							Methods:       methods,
+						},
+						WeightedClusters: mapset.NewSet(backendCluster),
+					},
+					AllowedServiceIdentities: allowedServiceIdentities,
+				})
+			}
+		}
+	}
+
+	return rules
+}
+
 // getIngressTrafficPolicyFromK8s returns the ingress traffic policy for the given mesh service from the corresponding k8s Ingress resource
 // TODO: DEPRECATE once IngressBackend API is the default for configuring an ingress backend.
 func (mc *MeshCatalog) getIngressTrafficPolicyFromK8s(svc service.MeshService) (*trafficpolicy.IngressTrafficPolicy, error) {
@@ -215,11 +321,20 @@ func (mc *MeshCatalog) getIngressTrafficPolicyFromK8s(svc service.MeshService) (
 	}
 
 	return &trafficpolicy.IngressTrafficPolicy{
-		TrafficMatches:    trafficMatches,
-		HTTPRoutePolicies: httpRoutePolicies,
+		TrafficMatches:           trafficMatches,
+		HTTPRoutePoliciesPerPort: getHTTPRoutePoliciesPerPort(trafficMatches, httpRoutePolicies),
 	}, nil
 }
 
+// getHTTPRoutePoliciesPerPort returns the given HTTP route policies keyed by each port of the given traffic matches
+func getHTTPRoutePoliciesPerPort(trafficMatches []*trafficpolicy.IngressTrafficMatch, httpRoutePolicies []*trafficpolicy.InboundTrafficPolicy) map[uint32][]*trafficpolicy.InboundTrafficPolicy {
+	httpRoutePoliciesPerPort := make(map[uint32][]*trafficpolicy.InboundTrafficPolicy)
+	for _, trafficMatch := range trafficMatches {
+		httpRoutePoliciesPerPort[trafficMatch.Port] = httpRoutePolicies
+	}
+	return httpRoutePoliciesPerPort
+}
+
 // getIngressPoliciesFromK8s returns a list of inbound traffic policies for a service as defined in observed ingress k8s resources.
 func (mc *MeshCatalog) getIngressPoliciesFromK8s(svc service.MeshService) ([]*trafficpolicy.InboundTrafficPolicy, error) {
 	var inboundTrafficPolicies []*trafficpolicy.InboundTrafficPolicy
@@ -453,3 +568,13 @@ func (mc *MeshCatalog) getIngressPoliciesNetworkingV1(svc service.MeshService) (
 	}
 	return inboundIngressPolicies, nil
 }
+
+// updateIngressBackendStatus updates the status of the given IngressBackend when this controller replica is the leader
+func (mc *MeshCatalog) updateIngressBackendStatus(ingressBackend *policyV1alpha1.IngressBackend) {
+	if !mc.isLeader() {
+		return
+	}
+	if _, err := mc.kubeController.UpdateStatus(ingressBackend); err != nil {
+		log.Error().Err(err).Msg("Error updating status for IngressBackend")
+	}
+}
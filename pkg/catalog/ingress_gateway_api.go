@@ -0,0 +1,224 @@
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	gwapiV1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getIngressTrafficPolicyFromGatewayAPI returns the ingress traffic policy for the given mesh service from the
+// Gateway API HTTPRoute resources bound to a Gateway that forward traffic to the service.
+// Traffic from the gateway is authenticated using mTLS, with the gateway presenting the ingress gateway
+// certificate provisioned as per the MeshConfig's ingress gateway certificate spec.
+func (mc *MeshCatalog) getIngressTrafficPolicyFromGatewayAPI(svc service.MeshService) (*trafficpolicy.IngressTrafficPolicy, error) {
+	routes, err := mc.ingressMonitor.GetHTTPRoutes(svc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error retrieving HTTPRoutes for service %s", svc)
+	}
+	if len(routes) == 0 {
+		log.Trace().Msgf("No HTTPRoute resources found for service %s", svc)
+		return nil, nil
+	}
+
+	meshConfig := mc.configurator.GetMeshConfig()
+	if meshConfig == nil || meshConfig.Spec.Certificate.IngressGateway == nil || len(meshConfig.Spec.Certificate.IngressGateway.SubjectAltNames) == 0 {
+		return nil, errors.Errorf("An ingress gateway certificate must be configured in the MeshConfig to route traffic from HTTPRoutes to service %s", svc)
+	}
+	// The ingress gateway certificate is issued for the first SAN in its spec
+	gatewayIdentity := identity.ServiceIdentity(meshConfig.Spec.Certificate.IngressGateway.SubjectAltNames[0])
+
+	var httpRoutePolicies []*trafficpolicy.InboundTrafficPolicy
+	ingressWeightedCluster := getDefaultWeightedClusterForService(svc)
+
+	for _, route := range routes {
+		hostnames := []string{constants.WildcardHTTPMethod}
+		if len(route.Spec.Hostnames) > 0 {
+			hostnames = nil
+			for _, hostname := range route.Spec.Hostnames {
+				hostnames = append(hostnames, string(hostname))
+			}
+		}
+
+		for _, hostname := range hostnames {
+			routePolicy := trafficpolicy.NewInboundTrafficPolicy(getIngressTrafficPolicyName(route.Name, route.Namespace, hostname), []string{hostname})
+
+			for _, rule := range route.Spec.Rules {
+				if !ruleForwardsToService(rule, svc) {
+					continue
+				}
+
+				// A rule without matches matches all requests
+				matches := rule.Matches
+				if len(matches) == 0 {
+					matches = []gwapiV1alpha1.HTTPRouteMatch{{}}
+				}
+
+				for _, match := range matches {
+					httpRouteMatch, err := getHTTPRouteMatchFromGatewayAPI(match)
+					if err != nil {
+						log.Error().Err(err).Msgf("Ignoring unsupported match in HTTPRoute %s/%s", route.Namespace, route.Name)
+						continue
+					}
+					routePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(httpRouteMatch, []service.WeightedCluster{ingressWeightedCluster}), gatewayIdentity)
+				}
+			}
+
+			// Only create a policy if the HTTPRoute resulted in valid rules
+			if len(routePolicy.Rules) > 0 {
+				httpRoutePolicies = trafficpolicy.MergeInboundPolicies(DisallowPartialHostnamesMatch, httpRoutePolicies, routePolicy)
+			}
+		}
+	}
+
+	if len(httpRoutePolicies) == 0 {
+		return nil, nil
+	}
+
+	protocolToPortMap, err := mc.GetTargetPortToProtocolMappingForService(svc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error retrieving port to protocol mapping for service %s", svc)
+	}
+
+	var trafficMatches []*trafficpolicy.IngressTrafficMatch
+	for port, appProtocol := range protocolToPortMap {
+		// gRPC is routed using HTTPRoute as well
+		if appProtocol != constants.ProtocolHTTP && appProtocol != constants.ProtocolGRPC {
+			continue
+		}
+
+		trafficMatches = append(trafficMatches, &trafficpolicy.IngressTrafficMatch{
+			Name:     fmt.Sprintf("ingress_%s_%d_%s", svc, port, constants.ProtocolHTTPS),
+			Port:     port,
+			Protocol: constants.ProtocolHTTPS,
+		})
+	}
+
+	return &trafficpolicy.IngressTrafficPolicy{
+		TrafficMatches:           trafficMatches,
+		HTTPRoutePoliciesPerPort: getHTTPRoutePoliciesPerPort(trafficMatches, httpRoutePolicies),
+	}, nil
+}
+
+// ruleForwardsToService returns true if the given HTTPRoute rule forwards traffic to the given service
+func ruleForwardsToService(rule gwapiV1alpha1.HTTPRouteRule, svc service.MeshService) bool {
+	for _, forwardTo := range rule.ForwardTo {
+		if forwardTo.ServiceName != nil && *forwardTo.ServiceName == svc.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// getHTTPRouteMatchFromGatewayAPI returns the HTTP route match corresponding to the given HTTPRoute match
+func getHTTPRouteMatchFromGatewayAPI(match gwapiV1alpha1.HTTPRouteMatch) (trafficpolicy.HTTPRouteMatch, error) {
+	httpRouteMatch := trafficpolicy.HTTPRouteMatch{
+		Path:          "/",
+		PathMatchType: trafficpolicy.PathMatchPrefix,
+		Methods:       []string{constants.WildcardHTTPMethod},
+	}
+
+	if match.QueryParams != nil || match.ExtensionRef != nil {
+		return httpRouteMatch, errors.New("query parameter and extension matches are not supported")
+	}
+
+	if match.Path != nil {
+		pathMatchType := gwapiV1alpha1.PathMatchPrefix
+		if match.Path.Type != nil {
+			pathMatchType = *match.Path.Type
+		}
+		if match.Path.Value != nil {
+			httpRouteMatch.Path = *match.Path.Value
+		}
+
+		switch pathMatchType {
+		case gwapiV1alpha1.PathMatchExact:
+			httpRouteMatch.PathMatchType = trafficpolicy.PathMatchExact
+
+		case gwapiV1alpha1.PathMatchPrefix:
+			httpRouteMatch.PathMatchType = trafficpolicy.PathMatchPrefix
+
+		case gwapiV1alpha1.PathMatchRegularExpression:
+			httpRouteMatch.PathMatchType = trafficpolicy.PathMatchRegex
+
+		case gwapiV1alpha1.PathMatchImplementationSpecific:
+			// If the path looks like a regex, use regex matching, else use string based prefix matching
+			if strings.ContainsAny(httpRouteMatch.Path, commonRegexChars) {
+				httpRouteMatch.PathMatchType = trafficpolicy.PathMatchRegex
+			} else {
+				httpRouteMatch.PathMatchType = trafficpolicy.PathMatchPrefix
+			}
+
+		default:
+			return httpRouteMatch, errors.Errorf("unsupported path match type %s", pathMatchType)
+		}
+	}
+
+	if match.Headers != nil && len(match.Headers.Values) > 0 {
+		headerMatchType := gwapiV1alpha1.HeaderMatchExact
+		if match.Headers.Type != nil {
+			headerMatchType = *match.Headers.Type
+		}
+
+		// Headers are matched as regular expressions
+		httpRouteMatch.Headers = make(map[string]string)
+		for name, value := range match.Headers.Values {
+			switch headerMatchType {
+			case gwapiV1alpha1.HeaderMatchExact:
+				httpRouteMatch.Headers[strings.ToLower(name)] = regexp.QuoteMeta(value)
+
+			case gwapiV1alpha1.HeaderMatchRegularExpression, gwapiV1alpha1.HeaderMatchImplementationSpecific:
+				httpRouteMatch.Headers[strings.ToLower(name)] = value
+
+			default:
+				return httpRouteMatch, errors.Errorf("unsupported header match type %s", headerMatchType)
+			}
+		}
+	}
+
+	return httpRouteMatch, nil
+}
+
+// mergeIngressTrafficPolicies merges the given ingress traffic policies for a service.
+// A traffic match of the latest policy on a port that the original policy already matches on
+// is ignored along with the HTTP route policies of the latest policy on that port, because both
+// cannot be programmed on the same port.
+func mergeIngressTrafficPolicies(original, latest *trafficpolicy.IngressTrafficPolicy) *trafficpolicy.IngressTrafficPolicy {
+	if original == nil {
+		return latest
+	}
+	if latest == nil {
+		return original
+	}
+
+	originalPorts := make(map[uint32]bool)
+	for _, trafficMatch := range original.TrafficMatches {
+		originalPorts[trafficMatch.Port] = true
+	}
+	for _, trafficMatch := range latest.TrafficMatches {
+		if originalPorts[trafficMatch.Port] {
+			log.Warn().Msgf("Ignoring ingress traffic match %s, port %d is already configured for ingress", trafficMatch.Name, trafficMatch.Port)
+			continue
+		}
+		original.TrafficMatches = append(original.TrafficMatches, trafficMatch)
+	}
+
+	if original.HTTPRoutePoliciesPerPort == nil {
+		original.HTTPRoutePoliciesPerPort = make(map[uint32][]*trafficpolicy.InboundTrafficPolicy)
+	}
+	for port, httpRoutePolicies := range latest.HTTPRoutePoliciesPerPort {
+		if originalPorts[port] {
+			continue
+		}
+		original.HTTPRoutePoliciesPerPort[port] = trafficpolicy.MergeInboundPolicies(DisallowPartialHostnamesMatch, original.HTTPRoutePoliciesPerPort[port], httpRoutePolicies...)
+	}
+
+	return original
+}
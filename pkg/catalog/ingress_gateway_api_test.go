@@ -0,0 +1,188 @@
+package catalog
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+	gwapiV1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func pathMatchTypePtr(matchType gwapiV1alpha1.PathMatchType) *gwapiV1alpha1.PathMatchType {
+	return &matchType
+}
+
+func headerMatchTypePtr(matchType gwapiV1alpha1.HeaderMatchType) *gwapiV1alpha1.HeaderMatchType {
+	return &matchType
+}
+
+func TestGetHTTPRouteMatchFromGatewayAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		match         gwapiV1alpha1.HTTPRouteMatch
+		expectedMatch trafficpolicy.HTTPRouteMatch
+		expectError   bool
+	}{
+		{
+			name:  "empty match matches all paths",
+			match: gwapiV1alpha1.HTTPRouteMatch{},
+			expectedMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/",
+				PathMatchType: trafficpolicy.PathMatchPrefix,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+		},
+		{
+			name: "path match type defaults to prefix",
+			match: gwapiV1alpha1.HTTPRouteMatch{
+				Path: &gwapiV1alpha1.HTTPPathMatch{Value: pointer.StringPtr("/foo")},
+			},
+			expectedMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/foo",
+				PathMatchType: trafficpolicy.PathMatchPrefix,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+		},
+		{
+			name: "regular expression path match",
+			match: gwapiV1alpha1.HTTPRouteMatch{
+				Path: &gwapiV1alpha1.HTTPPathMatch{
+					Type:  pathMatchTypePtr(gwapiV1alpha1.PathMatchRegularExpression),
+					Value: pointer.StringPtr("/foo/.*"),
+				},
+			},
+			expectedMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/foo/.*",
+				PathMatchType: trafficpolicy.PathMatchRegex,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+		},
+		{
+			name: "implementation specific path match that looks like a regex",
+			match: gwapiV1alpha1.HTTPRouteMatch{
+				Path: &gwapiV1alpha1.HTTPPathMatch{
+					Type:  pathMatchTypePtr(gwapiV1alpha1.PathMatchImplementationSpecific),
+					Value: pointer.StringPtr("/foo*"),
+				},
+			},
+			expectedMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/foo*",
+				PathMatchType: trafficpolicy.PathMatchRegex,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+		},
+		{
+			name: "exact header match is escaped",
+			match: gwapiV1alpha1.HTTPRouteMatch{
+				Headers: &gwapiV1alpha1.HTTPHeaderMatch{
+					Values: map[string]string{"User-Agent": "v1.0"},
+				},
+			},
+			expectedMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/",
+				PathMatchType: trafficpolicy.PathMatchPrefix,
+				Methods:       []string{constants.WildcardHTTPMethod},
+				Headers:       map[string]string{"user-agent": `v1\.0`},
+			},
+		},
+		{
+			name: "regular expression header match",
+			match: gwapiV1alpha1.HTTPRouteMatch{
+				Headers: &gwapiV1alpha1.HTTPHeaderMatch{
+					Type:   headerMatchTypePtr(gwapiV1alpha1.HeaderMatchRegularExpression),
+					Values: map[string]string{"x-version": "v1.*"},
+				},
+			},
+			expectedMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/",
+				PathMatchType: trafficpolicy.PathMatchPrefix,
+				Methods:       []string{constants.WildcardHTTPMethod},
+				Headers:       map[string]string{"x-version": "v1.*"},
+			},
+		},
+		{
+			name: "query parameter match is not supported",
+			match: gwapiV1alpha1.HTTPRouteMatch{
+				QueryParams: &gwapiV1alpha1.HTTPQueryParamMatch{
+					Values: map[string]string{"foo": "bar"},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual, err := getHTTPRouteMatchFromGatewayAPI(tc.match)
+			assert.Equal(tc.expectError, err != nil)
+			if !tc.expectError {
+				assert.Equal(tc.expectedMatch, actual)
+			}
+		})
+	}
+}
+
+func TestMergeIngressTrafficPolicies(t *testing.T) {
+	ingressRoutePolicy := &trafficpolicy.InboundTrafficPolicy{Name: "ingress", Hostnames: []string{"*"}}
+	gatewayRoutePolicy := &trafficpolicy.InboundTrafficPolicy{Name: "gateway", Hostnames: []string{"*"}}
+
+	testCases := []struct {
+		name     string
+		original *trafficpolicy.IngressTrafficPolicy
+		latest   *trafficpolicy.IngressTrafficPolicy
+		expected *trafficpolicy.IngressTrafficPolicy
+	}{
+		{
+			name:   "no original policy",
+			latest: &trafficpolicy.IngressTrafficPolicy{TrafficMatches: []*trafficpolicy.IngressTrafficMatch{{Name: "gateway", Port: 80}}},
+			expected: &trafficpolicy.IngressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.IngressTrafficMatch{{Name: "gateway", Port: 80}},
+			},
+		},
+		{
+			name: "policies on different ports are merged",
+			original: &trafficpolicy.IngressTrafficPolicy{
+				TrafficMatches:           []*trafficpolicy.IngressTrafficMatch{{Name: "ingress", Port: 80}},
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{80: {ingressRoutePolicy}},
+			},
+			latest: &trafficpolicy.IngressTrafficPolicy{
+				TrafficMatches:           []*trafficpolicy.IngressTrafficMatch{{Name: "gateway", Port: 8080}},
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{8080: {gatewayRoutePolicy}},
+			},
+			expected: &trafficpolicy.IngressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.IngressTrafficMatch{{Name: "ingress", Port: 80}, {Name: "gateway", Port: 8080}},
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					80:   {ingressRoutePolicy},
+					8080: {gatewayRoutePolicy},
+				},
+			},
+		},
+		{
+			name: "routes of a traffic match ignored on a port already configured are ignored",
+			original: &trafficpolicy.IngressTrafficPolicy{
+				TrafficMatches:           []*trafficpolicy.IngressTrafficMatch{{Name: "ingress", Port: 80}},
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{80: {ingressRoutePolicy}},
+			},
+			latest: &trafficpolicy.IngressTrafficPolicy{
+				TrafficMatches:           []*trafficpolicy.IngressTrafficMatch{{Name: "gateway", Port: 80}},
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{80: {gatewayRoutePolicy}},
+			},
+			expected: &trafficpolicy.IngressTrafficPolicy{
+				TrafficMatches:           []*trafficpolicy.IngressTrafficMatch{{Name: "ingress", Port: 80}},
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{80: {ingressRoutePolicy}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, mergeIngressTrafficPolicies(tc.original, tc.latest))
+		})
+	}
+}
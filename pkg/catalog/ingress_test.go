@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
+	gwapiV1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
 
 	configV1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
@@ -2164,6 +2165,7 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 		meshSvc                     service.MeshService
 		ingressV1                   []*networkingV1.Ingress
 		ingressBackend              *policyV1alpha1.IngressBackend
+		httpRoutes                  []*gwapiV1alpha1.HTTPRoute
 		expectedPolicy              *trafficpolicy.IngressTrafficPolicy
 		expectError                 bool
 	}{
@@ -2210,26 +2212,28 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 			},
 			ingressBackend: nil,
 			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
-				HTTPRoutePolicies: []*trafficpolicy.InboundTrafficPolicy{
-					{
-						Name: "ingress-1.testns|fake1.com",
-						Hostnames: []string{
-							"fake1.com",
-						},
-						Rules: []*trafficpolicy.Rule{
-							{
-								Route: trafficpolicy.RouteWeightedClusters{
-									HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
-										Path:          "/fake1-path1",
-										PathMatchType: trafficpolicy.PathMatchPrefix,
-										Methods:       []string{constants.WildcardHTTPMethod},
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					80: {
+						{
+							Name: "ingress-1.testns|fake1.com",
+							Hostnames: []string{
+								"fake1.com",
+							},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+											Path:          "/fake1-path1",
+											PathMatchType: trafficpolicy.PathMatchPrefix,
+											Methods:       []string{constants.WildcardHTTPMethod},
+										},
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
 									},
-									WeightedClusters: mapset.NewSet(service.WeightedCluster{
-										ClusterName: "testns/foo",
-										Weight:      100,
-									}),
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 								},
-								AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 							},
 						},
 					},
@@ -2287,26 +2291,28 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 			},
 			ingressBackend: nil,
 			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
-				HTTPRoutePolicies: []*trafficpolicy.InboundTrafficPolicy{
-					{
-						Name: "ingress-1.testns|fake1.com",
-						Hostnames: []string{
-							"fake1.com",
-						},
-						Rules: []*trafficpolicy.Rule{
-							{
-								Route: trafficpolicy.RouteWeightedClusters{
-									HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
-										Path:          "/fake1-path1",
-										PathMatchType: trafficpolicy.PathMatchPrefix,
-										Methods:       []string{constants.WildcardHTTPMethod},
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					80: {
+						{
+							Name: "ingress-1.testns|fake1.com",
+							Hostnames: []string{
+								"fake1.com",
+							},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+											Path:          "/fake1-path1",
+											PathMatchType: trafficpolicy.PathMatchPrefix,
+											Methods:       []string{constants.WildcardHTTPMethod},
+										},
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
 									},
-									WeightedClusters: mapset.NewSet(service.WeightedCluster{
-										ClusterName: "testns/foo",
-										Weight:      100,
-									}),
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 								},
-								AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 							},
 						},
 					},
@@ -2367,22 +2373,146 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 				},
 			},
 			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
-				HTTPRoutePolicies: []*trafficpolicy.InboundTrafficPolicy{
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					80: {
+						{
+							Name: "testns/foo_from_ingress-backend-1",
+							Hostnames: []string{
+								"*",
+							},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
+								},
+							},
+						},
+					},
+				},
+				TrafficMatches: []*trafficpolicy.IngressTrafficMatch{
 					{
-						Name: "testns/foo_from_ingress-backend-1",
-						Hostnames: []string{
-							"*",
+						Name:           "ingress_testns/foo_80_http",
+						Protocol:       "http",
+						Port:           80,
+						SourceIPRanges: []string{"10.0.0.10/32"}, // Endpoint of 'ingressSourceSvc' referenced as a source
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:                        "HTTP ingress with HTTP rules using the IngressBackend API",
+			ingressBackendPolicyEnabled: true,
+			meshSvc:                     service.MeshService{Name: "foo", Namespace: "testns"},
+			ingressBackend: &policyV1alpha1.IngressBackend{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-backend-1",
+					Namespace: "testns",
+				},
+				Spec: policyV1alpha1.IngressBackendSpec{
+					Backends: []policyV1alpha1.BackendSpec{
+						{
+							Name: "foo",
+							Port: policyV1alpha1.PortSpec{
+								Number:   80,
+								Protocol: "http",
+							},
+							HTTPRules: []policyV1alpha1.HTTPRuleSpec{
+								{
+									Hostnames:    []string{"foo.example.com"},
+									PathPrefixes: []string{"/api", "/static"},
+									Methods:      []string{"GET"},
+								},
+								{
+									PathPrefixes: []string{"/health"},
+								},
+							},
 						},
-						Rules: []*trafficpolicy.Rule{
-							{
-								Route: trafficpolicy.RouteWeightedClusters{
-									HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
-									WeightedClusters: mapset.NewSet(service.WeightedCluster{
-										ClusterName: "testns/foo",
-										Weight:      100,
-									}),
+					},
+					Sources: []policyV1alpha1.IngressSourceSpec{
+						{
+							Kind:      policyV1alpha1.KindService,
+							Name:      ingressSourceSvc.Name,
+							Namespace: ingressSourceSvc.Namespace,
+						},
+					},
+				},
+			},
+			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					80: {
+						{
+							Name:      "testns/foo_from_ingress-backend-1",
+							Hostnames: []string{"*"},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+											Path:          "/health",
+											PathMatchType: trafficpolicy.PathMatchPrefix,
+											Methods:       []string{constants.WildcardHTTPMethod},
+										},
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
+								},
+							},
+						},
+						{
+							Name:      "testns/foo_from_ingress-backend-1|foo.example.com",
+							Hostnames: []string{"foo.example.com"},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+											Path:          "/api",
+											PathMatchType: trafficpolicy.PathMatchPrefix,
+											Methods:       []string{"GET"},
+										},
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
+								},
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+											Path:          "/static",
+											PathMatchType: trafficpolicy.PathMatchPrefix,
+											Methods:       []string{"GET"},
+										},
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
+								},
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+											Path:          "/health",
+											PathMatchType: trafficpolicy.PathMatchPrefix,
+											Methods:       []string{constants.WildcardHTTPMethod},
+										},
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 								},
-								AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 							},
 						},
 					},
@@ -2398,6 +2528,109 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name:                        "HTTP ingress with multiple backends on a service using the IngressBackend API",
+			ingressBackendPolicyEnabled: true,
+			meshSvc:                     service.MeshService{Name: "foo", Namespace: "testns"},
+			ingressBackend: &policyV1alpha1.IngressBackend{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-backend-1",
+					Namespace: "testns",
+				},
+				Spec: policyV1alpha1.IngressBackendSpec{
+					Backends: []policyV1alpha1.BackendSpec{
+						{
+							Name: "foo",
+							Port: policyV1alpha1.PortSpec{
+								Number:   80,
+								Protocol: "http",
+							},
+						},
+						{
+							Name: "foo",
+							Port: policyV1alpha1.PortSpec{
+								Number:   90,
+								Protocol: "http",
+							},
+							HTTPRules: []policyV1alpha1.HTTPRuleSpec{
+								{
+									Hostnames:    []string{"foo.example.com"},
+									PathPrefixes: []string{"/api"},
+									Methods:      []string{"GET"},
+								},
+							},
+						},
+					},
+					Sources: []policyV1alpha1.IngressSourceSpec{
+						{
+							Kind:      policyV1alpha1.KindService,
+							Name:      ingressSourceSvc.Name,
+							Namespace: ingressSourceSvc.Namespace,
+						},
+					},
+				},
+			},
+			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					// The backend without HTTP rules allows all requests on its port only
+					80: {
+						{
+							Name:      "testns/foo_from_ingress-backend-1",
+							Hostnames: []string{"*"},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
+								},
+							},
+						},
+					},
+					90: {
+						{
+							Name:      "testns/foo_from_ingress-backend-1|foo.example.com",
+							Hostnames: []string{"foo.example.com"},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+											Path:          "/api",
+											PathMatchType: trafficpolicy.PathMatchPrefix,
+											Methods:       []string{"GET"},
+										},
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
+								},
+							},
+						},
+					},
+				},
+				TrafficMatches: []*trafficpolicy.IngressTrafficMatch{
+					{
+						Name:           "ingress_testns/foo_80_http",
+						Protocol:       "http",
+						Port:           80,
+						SourceIPRanges: []string{"10.0.0.10/32"}, // Endpoint of 'ingressSourceSvc' referenced as a source
+					},
+					{
+						Name:           "ingress_testns/foo_90_http",
+						Protocol:       "http",
+						Port:           90,
+						SourceIPRanges: []string{"10.0.0.10/32"}, // Endpoint of 'ingressSourceSvc' referenced as a source
+					},
+				},
+			},
+			expectError: false,
+		},
 		{
 			name:                        "HTTPS ingress with mTLS using the IngressBackend API",
 			ingressBackendPolicyEnabled: true,
@@ -2435,22 +2668,24 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 				},
 			},
 			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
-				HTTPRoutePolicies: []*trafficpolicy.InboundTrafficPolicy{
-					{
-						Name: "testns/foo_from_ingress-backend-1",
-						Hostnames: []string{
-							"*",
-						},
-						Rules: []*trafficpolicy.Rule{
-							{
-								Route: trafficpolicy.RouteWeightedClusters{
-									HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
-									WeightedClusters: mapset.NewSet(service.WeightedCluster{
-										ClusterName: "testns/foo",
-										Weight:      100,
-									}),
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					80: {
+						{
+							Name: "testns/foo_from_ingress-backend-1",
+							Hostnames: []string{
+								"*",
+							},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.ServiceIdentity("ingressGw.ingressGwNs.cluster.local")),
 								},
-								AllowedServiceIdentities: mapset.NewSet(identity.ServiceIdentity("ingressGw.ingressGwNs.cluster.local")),
 							},
 						},
 					},
@@ -2504,22 +2739,24 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 				},
 			},
 			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
-				HTTPRoutePolicies: []*trafficpolicy.InboundTrafficPolicy{
-					{
-						Name: "testns/foo_from_ingress-backend-1",
-						Hostnames: []string{
-							"*",
-						},
-						Rules: []*trafficpolicy.Rule{
-							{
-								Route: trafficpolicy.RouteWeightedClusters{
-									HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
-									WeightedClusters: mapset.NewSet(service.WeightedCluster{
-										ClusterName: "testns/foo",
-										Weight:      100,
-									}),
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					80: {
+						{
+							Name: "testns/foo_from_ingress-backend-1",
+							Hostnames: []string{
+								"*",
+							},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 								},
-								AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 							},
 						},
 					},
@@ -2567,6 +2804,160 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 			expectedPolicy: nil,
 			expectError:    true,
 		},
+		{
+			name:                        "HTTPS ingress with mTLS using the Gateway API",
+			ingressBackendPolicyEnabled: false,
+			meshSvc:                     service.MeshService{Name: "foo", Namespace: "testns"},
+			httpRoutes: []*gwapiV1alpha1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "route-1",
+						Namespace: "testns",
+					},
+					Spec: gwapiV1alpha1.HTTPRouteSpec{
+						Hostnames: []gwapiV1alpha1.Hostname{"foo.example.com"},
+						Rules: []gwapiV1alpha1.HTTPRouteRule{
+							{
+								Matches: []gwapiV1alpha1.HTTPRouteMatch{
+									{
+										Path: &gwapiV1alpha1.HTTPPathMatch{
+											Type:  pathMatchTypePtr(gwapiV1alpha1.PathMatchExact),
+											Value: pointer.StringPtr("/foo"),
+										},
+									},
+								},
+								ForwardTo: []gwapiV1alpha1.HTTPRouteForwardTo{
+									{ServiceName: pointer.StringPtr("foo")},
+								},
+							},
+							{
+								// Does not forward to the service
+								ForwardTo: []gwapiV1alpha1.HTTPRouteForwardTo{
+									{ServiceName: pointer.StringPtr("bar")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					80: {
+						{
+							Name:      "route-1.testns|foo.example.com",
+							Hostnames: []string{"foo.example.com"},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+											Path:          "/foo",
+											PathMatchType: trafficpolicy.PathMatchExact,
+											Methods:       []string{constants.WildcardHTTPMethod},
+										},
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.ServiceIdentity("ingress-gateway.osm-system.cluster.local")),
+								},
+							},
+						},
+					},
+				},
+				TrafficMatches: []*trafficpolicy.IngressTrafficMatch{
+					{
+						Name:     "ingress_testns/foo_80_https",
+						Protocol: "https",
+						Port:     80,
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:                        "HTTP ingress with k8s ingress enabled and a Gateway API route on the same port",
+			ingressBackendPolicyEnabled: false,
+			enableHTTPSIngress:          false,
+			meshSvc:                     service.MeshService{Name: "foo", Namespace: "testns"},
+			ingressV1: []*networkingV1.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "ingress-1",
+						Namespace: "testns",
+					},
+					Spec: networkingV1.IngressSpec{
+						DefaultBackend: &networkingV1.IngressBackend{
+							Service: &networkingV1.IngressServiceBackend{
+								Name: "foo",
+								Port: networkingV1.ServiceBackendPort{
+									Number: 80,
+								},
+							},
+						},
+					},
+				},
+			},
+			httpRoutes: []*gwapiV1alpha1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "route-1",
+						Namespace: "testns",
+					},
+					Spec: gwapiV1alpha1.HTTPRouteSpec{
+						Hostnames: []gwapiV1alpha1.Hostname{"foo.example.com"},
+						Rules: []gwapiV1alpha1.HTTPRouteRule{
+							{
+								ForwardTo: []gwapiV1alpha1.HTTPRouteForwardTo{
+									{ServiceName: pointer.StringPtr("foo")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
+				HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+					80: {
+						{
+							Name:      "ingress-1.testns|*",
+							Hostnames: []string{"*"},
+							Rules: []*trafficpolicy.Rule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+										WeightedClusters: mapset.NewSet(service.WeightedCluster{
+											ClusterName: "testns/foo",
+											Weight:      100,
+										}),
+									},
+									AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
+								},
+							},
+						},
+					},
+				},
+				// The Gateway API traffic match on port 80 and its routes are ignored
+				TrafficMatches: []*trafficpolicy.IngressTrafficMatch{
+					{
+						Name:     "ingress_testns/foo_80_http",
+						Protocol: "http",
+						Port:     80,
+					},
+				},
+			},
+			expectError: false,
+		},
+	}
+
+	meshConfig := &configV1alpha1.MeshConfig{
+		Spec: configV1alpha1.MeshConfigSpec{
+			Certificate: configV1alpha1.CertificateSpec{
+				IngressGateway: &configV1alpha1.IngressGatewayCertSpec{
+					SubjectAltNames: []string{"ingress-gateway.osm-system.cluster.local"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2597,6 +2988,8 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 			mockCfg.EXPECT().UseHTTPSIngress().Return(tc.enableHTTPSIngress).Times(1).AnyTimes()
 			mockIngressMonitor.EXPECT().GetIngressNetworkingV1(gomock.Any()).Return(tc.ingressV1, nil).AnyTimes()
 			mockIngressMonitor.EXPECT().GetIngressNetworkingV1beta1(gomock.Any()).Return(nil, nil).AnyTimes()
+			mockIngressMonitor.EXPECT().GetHTTPRoutes(tc.meshSvc).Return(tc.httpRoutes, nil).AnyTimes()
+			mockCfg.EXPECT().GetMeshConfig().Return(meshConfig).AnyTimes()
 			mockPolicyController.EXPECT().GetIngressBackendPolicy(tc.meshSvc).Return(tc.ingressBackend).AnyTimes()
 			mockServiceProvider.EXPECT().GetID().Return("mock").AnyTimes()
 			mockServiceProvider.EXPECT().GetTargetPortToProtocolMappingForService(tc.meshSvc).Return(portToProtocolMapping, nil).AnyTimes()
@@ -2611,3 +3004,77 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestListIngressSourceServices(t *testing.T) {
+	meshSvc := service.MeshService{Name: "foo", Namespace: "testns"}
+	ingressBackend := &policyV1alpha1.IngressBackend{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ingress-backend-1",
+			Namespace: "testns",
+		},
+		Spec: policyV1alpha1.IngressBackendSpec{
+			Backends: []policyV1alpha1.BackendSpec{
+				{
+					Name: "foo",
+				},
+			},
+			Sources: []policyV1alpha1.IngressSourceSpec{
+				{
+					Kind:      policyV1alpha1.KindService,
+					Name:      "ingress",
+					Namespace: "ingress-ns",
+				},
+				{
+					Kind: policyV1alpha1.KindAuthenticatedPrincipal,
+					Name: "ingress-gw.ingress-ns.cluster.local",
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                        string
+		ingressBackendPolicyEnabled bool
+		ingressBackend              *policyV1alpha1.IngressBackend
+		expected                    []service.MeshService
+	}{
+		{
+			name:                        "IngressBackend API disabled",
+			ingressBackendPolicyEnabled: false,
+			ingressBackend:              ingressBackend,
+			expected:                    nil,
+		},
+		{
+			name:                        "no IngressBackend for the service",
+			ingressBackendPolicyEnabled: true,
+			ingressBackend:              nil,
+			expected:                    nil,
+		},
+		{
+			name:                        "IngressBackend with a service and a principal source",
+			ingressBackendPolicyEnabled: true,
+			ingressBackend:              ingressBackend,
+			expected:                    []service.MeshService{{Name: "ingress", Namespace: "ingress-ns"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			meshCatalog := &MeshCatalog{
+				configurator:     mockCfg,
+				policyController: mockPolicyController,
+			}
+
+			mockCfg.EXPECT().GetFeatureFlags().Return(configV1alpha1.FeatureFlags{EnableIngressBackendPolicy: tc.ingressBackendPolicyEnabled}).Times(1)
+			mockPolicyController.EXPECT().GetIngressBackendPolicy(meshSvc).Return(tc.ingressBackend).AnyTimes()
+
+			assert.Equal(tc.expected, meshCatalog.ListIngressSourceServices(meshSvc))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	endpoint "github.com/openservicemesh/osm/pkg/endpoint"
 	identity "github.com/openservicemesh/osm/pkg/identity"
 	k8s "github.com/openservicemesh/osm/pkg/k8s"
@@ -38,6 +39,35 @@ func (m *MockMeshCataloger) EXPECT() *MockMeshCatalogerMockRecorder {
 	return m.recorder
 }
 
+// GetAuthorizationTrafficPolicy mocks base method
+func (m *MockMeshCataloger) GetAuthorizationTrafficPolicy(arg0 service.MeshService) *trafficpolicy.AuthorizationTrafficPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorizationTrafficPolicy", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.AuthorizationTrafficPolicy)
+	return ret0
+}
+
+// GetAuthorizationTrafficPolicy indicates an expected call of GetAuthorizationTrafficPolicy
+func (mr *MockMeshCatalogerMockRecorder) GetAuthorizationTrafficPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizationTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetAuthorizationTrafficPolicy), arg0)
+}
+
+// GetEgressGatewayTrafficPolicy mocks base method
+func (m *MockMeshCataloger) GetEgressGatewayTrafficPolicy() (*trafficpolicy.EgressTrafficPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEgressGatewayTrafficPolicy")
+	ret0, _ := ret[0].(*trafficpolicy.EgressTrafficPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEgressGatewayTrafficPolicy indicates an expected call of GetEgressGatewayTrafficPolicy
+func (mr *MockMeshCatalogerMockRecorder) GetEgressGatewayTrafficPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressGatewayTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetEgressGatewayTrafficPolicy))
+}
+
 // GetEgressTrafficPolicy mocks base method
 func (m *MockMeshCataloger) GetEgressTrafficPolicy(arg0 identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error) {
 	m.ctrl.T.Helper()
@@ -53,6 +83,20 @@ func (mr *MockMeshCatalogerMockRecorder) GetEgressTrafficPolicy(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetEgressTrafficPolicy), arg0)
 }
 
+// GetEnvoyFilterExtensions mocks base method
+func (m *MockMeshCataloger) GetEnvoyFilterExtensions(arg0 identity.ServiceIdentity, arg1 v1alpha1.EnvoyFilterDirection) []*trafficpolicy.EnvoyFilterExtension {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyFilterExtensions", arg0, arg1)
+	ret0, _ := ret[0].([]*trafficpolicy.EnvoyFilterExtension)
+	return ret0
+}
+
+// GetEnvoyFilterExtensions indicates an expected call of GetEnvoyFilterExtensions
+func (mr *MockMeshCatalogerMockRecorder) GetEnvoyFilterExtensions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyFilterExtensions", reflect.TypeOf((*MockMeshCataloger)(nil).GetEnvoyFilterExtensions), arg0, arg1)
+}
+
 // GetIngressTrafficPolicy mocks base method
 func (m *MockMeshCataloger) GetIngressTrafficPolicy(arg0 service.MeshService) (*trafficpolicy.IngressTrafficPolicy, error) {
 	m.ctrl.T.Helper()
@@ -244,6 +288,20 @@ func (mr *MockMeshCatalogerMockRecorder) ListOutboundServiceIdentities(arg0 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutboundServiceIdentities", reflect.TypeOf((*MockMeshCataloger)(nil).ListOutboundServiceIdentities), arg0)
 }
 
+// ListIngressSourceServices mocks base method
+func (m *MockMeshCataloger) ListIngressSourceServices(arg0 service.MeshService) []service.MeshService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngressSourceServices", arg0)
+	ret0, _ := ret[0].([]service.MeshService)
+	return ret0
+}
+
+// ListIngressSourceServices indicates an expected call of ListIngressSourceServices
+func (mr *MockMeshCatalogerMockRecorder) ListIngressSourceServices(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngressSourceServices", reflect.TypeOf((*MockMeshCataloger)(nil).ListIngressSourceServices), arg0)
+}
+
 // ListOutboundServicesForIdentity mocks base method
 func (m *MockMeshCataloger) ListOutboundServicesForIdentity(arg0 identity.ServiceIdentity) []service.MeshService {
 	m.ctrl.T.Helper()
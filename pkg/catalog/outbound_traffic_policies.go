@@ -128,7 +128,7 @@ func (mc *MeshCatalog) ListOutboundServicesForIdentity(serviceIdentity identity.
 					Name:      t.Spec.Destination.Name,
 					Namespace: t.Spec.Destination.Namespace,
 				}
-				destServices, err := mc.getServicesForServiceIdentity(sa.ToServiceIdentity())
+				destServices, err := mc.getServicesForServiceIdentity(sa.ToServiceIdentity(mc.configurator.GetTrustDomain()))
 				if err != nil {
 					log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrNoMatchingServiceForServiceAccount)).
 						Msgf("No Services found matching Service Account %s in Namespace %s", t.Spec.Destination.Name, t.Namespace)
@@ -255,7 +255,7 @@ func (mc *MeshCatalog) getDestinationServicesFromTrafficTarget(t *access.Traffic
 		Name:      t.Spec.Destination.Name,
 		Namespace: t.Spec.Destination.Namespace,
 	}
-	destServices, err := mc.getServicesForServiceIdentity(sa.ToServiceIdentity())
+	destServices, err := mc.getServicesForServiceIdentity(sa.ToServiceIdentity(mc.configurator.GetTrustDomain()))
 	if err != nil {
 		return nil, errors.Errorf("Error finding Services for Service Account %#v: %v", sa, err)
 	}
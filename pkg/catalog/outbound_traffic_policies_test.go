@@ -344,6 +344,7 @@ func TestListOutboundTrafficPolicies(t *testing.T) {
 			mockEndpointProvider.EXPECT().GetID().Return("fake").AnyTimes()
 			mockServiceProvider.EXPECT().GetID().Return("fake").AnyTimes()
 			mockConfigurator.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableMulticlusterMode: true}).AnyTimes()
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 			for _, ms := range tc.apexMeshServices {
 				apexK8sService := tests.NewServiceFixture(ms.Name, ms.Namespace, map[string]string{})
 				mockKubeController.EXPECT().GetService(ms).Return(apexK8sService).AnyTimes()
@@ -368,7 +369,7 @@ func TestListOutboundTrafficPolicies(t *testing.T) {
 				mockMeshSpec.EXPECT().ListTrafficSplits().Return(tc.trafficsplits).AnyTimes()
 				mockMeshSpec.EXPECT().ListTrafficTargets().Return(tc.traffictargets).AnyTimes()
 				mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return(tc.trafficspecs).AnyTimes()
-				mockServiceProvider.EXPECT().GetServicesForServiceIdentity(tests.BookstoreServiceAccount.ToServiceIdentity()).Return([]service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service, tests.BookstoreApexService}, nil).AnyTimes()
+				mockServiceProvider.EXPECT().GetServicesForServiceIdentity(tests.BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)).Return([]service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service, tests.BookstoreApexService}, nil).AnyTimes()
 				mockKubeController.EXPECT().GetService(tests.BookstoreApexService).Return(tests.NewServiceFixture(tests.BookstoreApexService.Name, tests.BookstoreApexService.Namespace, map[string]string{})).AnyTimes()
 			}
 
@@ -708,6 +709,9 @@ func TestListOutboundTrafficPoliciesForTrafficSplits(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 
 			for _, ms := range tc.apexMeshServices {
 				apexK8sService := tests.NewServiceFixture(ms.Name, ms.Namespace, map[string]string{})
@@ -720,6 +724,7 @@ func TestListOutboundTrafficPoliciesForTrafficSplits(t *testing.T) {
 				meshSpec:           mockMeshSpec,
 				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 				serviceProviders:   []service.Provider{mockServiceProvider},
+				configurator:       mockConfigurator,
 			}
 
 			for _, ms := range tc.apexMeshServices {
@@ -761,7 +766,7 @@ func TestListOutboundServicesForIdentity(t *testing.T) {
 			svcIdentity: identity.K8sServiceAccount{
 				Name:      "some-name",
 				Namespace: "some-ns",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			expectedList:   nil,
 			permissiveMode: false,
 		},
@@ -799,12 +804,16 @@ func TestBuildOutboundPermissiveModePolicies(t *testing.T) {
 	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 	mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 	mockServiceProvider := service.NewMockProvider(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 
 	mc := MeshCatalog{
 		kubeController:     mockKubeController,
 		meshSpec:           mockMeshSpec,
 		endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 		serviceProviders:   []service.Provider{mockServiceProvider},
+		configurator:       mockConfigurator,
 	}
 
 	testCases := []struct {
@@ -991,28 +1000,32 @@ func TestBuildOutboundPolicies(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 
 			mc := MeshCatalog{
 				kubeController:     mockKubeController,
 				meshSpec:           mockMeshSpec,
 				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 				serviceProviders:   []service.Provider{mockServiceProvider},
+				configurator:       mockConfigurator,
 			}
 
 			destK8sService := tests.NewServiceFixture(tc.destMeshService.Name, tc.destMeshService.Namespace, map[string]string{})
 
 			mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return([]*spec.HTTPRouteGroup{&tc.trafficSpec}).AnyTimes()
 			mockMeshSpec.EXPECT().ListTrafficSplits().Return([]*split.TrafficSplit{&tc.trafficSplit}).AnyTimes()
-			mockServiceProvider.EXPECT().GetServicesForServiceIdentity(tc.destSA.ToServiceIdentity()).Return([]service.MeshService{tc.destMeshService}, nil).AnyTimes()
+			mockServiceProvider.EXPECT().GetServicesForServiceIdentity(tc.destSA.ToServiceIdentity(identity.ClusterLocalTrustDomain)).Return([]service.MeshService{tc.destMeshService}, nil).AnyTimes()
 			mockEndpointProvider.EXPECT().GetID().Return("fake").AnyTimes()
 			mockServiceProvider.EXPECT().GetID().Return("fake").AnyTimes()
 			mockKubeController.EXPECT().GetService(tc.destMeshService).Return(destK8sService).AnyTimes()
 
-			trafficTarget := tests.NewSMITrafficTarget(tc.sourceSA.ToServiceIdentity(), tc.destSA.ToServiceIdentity())
+			trafficTarget := tests.NewSMITrafficTarget(tc.sourceSA.ToServiceIdentity(identity.ClusterLocalTrustDomain), tc.destSA.ToServiceIdentity(identity.ClusterLocalTrustDomain))
 
 			mockServiceProvider.EXPECT().GetHostnamesForService(tc.destMeshService, service.LocalNS).Return(tests.ExpectedHostnames[tc.destMeshService.Name], nil).AnyTimes()
 
-			actual := mc.buildOutboundPolicies(tc.sourceSA.ToServiceIdentity(), &trafficTarget)
+			actual := mc.buildOutboundPolicies(tc.sourceSA.ToServiceIdentity(identity.ClusterLocalTrustDomain), &trafficTarget)
 			assert.ElementsMatch(tc.expectedOutbound, actual)
 		})
 	}
@@ -1109,6 +1122,9 @@ func TestListOutboundPoliciesForTrafficTargets(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 
 			for _, ms := range tc.apexMeshServices {
 				apexK8sService := tests.NewServiceFixture(ms.Name, ms.Namespace, map[string]string{})
@@ -1117,7 +1133,7 @@ func TestListOutboundPoliciesForTrafficTargets(t *testing.T) {
 			mockMeshSpec.EXPECT().ListTrafficTargets().Return(tc.traffictargets).AnyTimes()
 			mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return(tc.trafficspecs).AnyTimes()
 			mockMeshSpec.EXPECT().ListTrafficSplits().Return(tc.trafficsplits).AnyTimes()
-			mockServiceProvider.EXPECT().GetServicesForServiceIdentity(tests.BookstoreServiceAccount.ToServiceIdentity()).Return([]service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service, tests.BookstoreApexService}, nil).AnyTimes()
+			mockServiceProvider.EXPECT().GetServicesForServiceIdentity(tests.BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)).Return([]service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service, tests.BookstoreApexService}, nil).AnyTimes()
 			mockEndpointProvider.EXPECT().GetID().Return("fake").AnyTimes()
 			mockServiceProvider.EXPECT().GetID().Return("fake").AnyTimes()
 			mockKubeController.EXPECT().GetService(tests.BookstoreV1Service).Return(tests.NewServiceFixture(tests.BookstoreV1Service.Name, tests.BookstoreV1Service.Namespace, map[string]string{})).AnyTimes()
@@ -1129,6 +1145,7 @@ func TestListOutboundPoliciesForTrafficTargets(t *testing.T) {
 				meshSpec:           mockMeshSpec,
 				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 				serviceProviders:   []service.Provider{mockServiceProvider},
+				configurator:       mockConfigurator,
 			}
 
 			meshServices := []service.MeshService{
@@ -1157,11 +1174,15 @@ func TestGetDestinationServicesFromTrafficTarget(t *testing.T) {
 	mockKubeController := k8s.NewMockController(mockCtrl)
 	mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 	mockServiceProvider := service.NewMockProvider(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 
 	mc := MeshCatalog{
 		kubeController:     mockKubeController,
 		endpointsProviders: []endpoint.Provider{mockEndpointProvider},
 		serviceProviders:   []service.Provider{mockServiceProvider},
+		configurator:       mockConfigurator,
 	}
 
 	destSA := identity.K8sServiceAccount{
@@ -1175,7 +1196,7 @@ func TestGetDestinationServicesFromTrafficTarget(t *testing.T) {
 	}
 
 	destK8sService := tests.NewServiceFixture(destMeshService.Name, destMeshService.Namespace, map[string]string{})
-	mockServiceProvider.EXPECT().GetServicesForServiceIdentity(destSA.ToServiceIdentity()).Return([]service.MeshService{destMeshService}, nil).AnyTimes()
+	mockServiceProvider.EXPECT().GetServicesForServiceIdentity(destSA.ToServiceIdentity(identity.ClusterLocalTrustDomain)).Return([]service.MeshService{destMeshService}, nil).AnyTimes()
 	mockEndpointProvider.EXPECT().GetID().Return("fake").AnyTimes()
 	mockServiceProvider.EXPECT().GetID().Return("fake").AnyTimes()
 	mockKubeController.EXPECT().GetService(destMeshService).Return(destK8sService).AnyTimes()
@@ -0,0 +1,236 @@
+package catalog
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	a "github.com/openservicemesh/osm/pkg/announcements"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// newProxyUpdate returns an empty ProxyUpdate scoped to a set of services and proxies
+func newProxyUpdate() *ProxyUpdate {
+	return &ProxyUpdate{
+		Services:   make(map[service.MeshService]struct{}),
+		ProxyUUIDs: make(map[string]struct{}),
+		Identities: make(map[identity.ServiceIdentity]struct{}),
+	}
+}
+
+// newFullProxyUpdate returns a ProxyUpdate applying to all proxies
+func newFullProxyUpdate() *ProxyUpdate {
+	return &ProxyUpdate{Full: true}
+}
+
+// IsFull returns true if the update applies to all proxies
+func (u *ProxyUpdate) IsFull() bool {
+	return u == nil || u.Full
+}
+
+// isEmpty returns true if the update applies to no proxy
+func (u *ProxyUpdate) isEmpty() bool {
+	return !u.IsFull() && len(u.Services) == 0 && len(u.ProxyUUIDs) == 0 && len(u.Identities) == 0
+}
+
+// merge extends the scope of the update with the scope of the given update
+func (u *ProxyUpdate) merge(other *ProxyUpdate) *ProxyUpdate {
+	if u.IsFull() || other.IsFull() {
+		return newFullProxyUpdate()
+	}
+	for svc := range other.Services {
+		u.Services[svc] = struct{}{}
+	}
+	for proxyUUID := range other.ProxyUUIDs {
+		u.ProxyUUIDs[proxyUUID] = struct{}{}
+	}
+	for si := range other.Identities {
+		u.Identities[si] = struct{}{}
+	}
+	return u
+}
+
+// getProxyUpdateScope returns the scope of the proxy update required by the given change. Changes to
+// endpoints, services, pods and IngressBackend policies are scoped to the services they relate to, changes
+// to secrets to the sources of the Egress policies referencing them, and changes to EnvoyFilterExtension
+// resources, the ConfigMaps they reference and their pulled WASM modules to the proxies they apply to. All other changes require
+// a full update as they may affect the configuration of any proxy.
+func (mc *MeshCatalog) getProxyUpdateScope(msg events.PubSubMessage) *ProxyUpdate {
+	update := newProxyUpdate()
+
+	switch msg.AnnouncementType {
+	case a.EndpointAdded, a.EndpointDeleted, a.EndpointUpdated,
+		a.PodAdded, a.PodDeleted, a.PodUpdated,
+		a.IngressBackendAdded, a.IngressBackendDeleted, a.IngressBackendUpdated,
+		a.SecretAdded, a.SecretDeleted, a.SecretUpdated,
+		a.ConfigMapAdded, a.ConfigMapDeleted, a.ConfigMapUpdated,
+		a.EnvoyFilterExtensionAdded, a.EnvoyFilterExtensionDeleted, a.EnvoyFilterExtensionUpdated:
+		// Scoped below from the changed objects
+
+	case a.WASMModulePulled:
+		image, ok := msg.NewObj.(string)
+		if !ok {
+			return newFullProxyUpdate()
+		}
+		for _, si := range mc.listIdentitiesReferencingImage(image) {
+			update.Identities[si] = struct{}{}
+		}
+		return update
+
+	case a.ServiceUpdated:
+		oldSvc, oldOk := msg.OldObj.(*corev1.Service)
+		newSvc, newOk := msg.NewObj.(*corev1.Service)
+		// A change of the selector changes the pods backing the service, which are not known to depend on it yet
+		if !oldOk || !newOk || !labels.Equals(oldSvc.Spec.Selector, newSvc.Spec.Selector) {
+			return newFullProxyUpdate()
+		}
+		update.Services[service.MeshService{Namespace: newSvc.Namespace, Name: newSvc.Name}] = struct{}{}
+		return update
+
+	default:
+		return newFullProxyUpdate()
+	}
+
+	for _, obj := range []interface{}{msg.OldObj, msg.NewObj} {
+		if obj == nil {
+			continue
+		}
+
+		switch msg.AnnouncementType {
+		case a.EndpointAdded, a.EndpointDeleted, a.EndpointUpdated:
+			endpoints, ok := obj.(*corev1.Endpoints)
+			if !ok {
+				return newFullProxyUpdate()
+			}
+			update.Services[service.MeshService{Namespace: endpoints.Namespace, Name: endpoints.Name}] = struct{}{}
+
+		case a.PodAdded, a.PodDeleted, a.PodUpdated:
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return newFullProxyUpdate()
+			}
+			if proxyUUID, found := pod.Labels[constants.EnvoyUniqueIDLabelName]; found {
+				update.ProxyUUIDs[proxyUUID] = struct{}{}
+			}
+			for _, svc := range mc.listServicesSelectingPod(pod) {
+				update.Services[svc] = struct{}{}
+			}
+
+		case a.IngressBackendAdded, a.IngressBackendDeleted, a.IngressBackendUpdated:
+			ingressBackend, ok := obj.(*policyV1alpha1.IngressBackend)
+			if !ok {
+				return newFullProxyUpdate()
+			}
+			for _, backend := range ingressBackend.Spec.Backends {
+				update.Services[service.MeshService{Namespace: ingressBackend.Namespace, Name: backend.Name}] = struct{}{}
+			}
+
+		case a.SecretAdded, a.SecretDeleted, a.SecretUpdated:
+			secret, ok := obj.(*corev1.Secret)
+			if !ok {
+				return newFullProxyUpdate()
+			}
+			for _, si := range mc.listEgressSourcesReferencingSecret(secret) {
+				update.Identities[si] = struct{}{}
+			}
+
+		case a.ConfigMapAdded, a.ConfigMapDeleted, a.ConfigMapUpdated:
+			configMap, ok := obj.(*corev1.ConfigMap)
+			if !ok {
+				return newFullProxyUpdate()
+			}
+			for _, si := range mc.listIdentitiesReferencingConfigMap(configMap) {
+				update.Identities[si] = struct{}{}
+			}
+
+		case a.EnvoyFilterExtensionAdded, a.EnvoyFilterExtensionDeleted, a.EnvoyFilterExtensionUpdated:
+			extension, ok := obj.(*policyV1alpha1.EnvoyFilterExtension)
+			if !ok {
+				return newFullProxyUpdate()
+			}
+			for _, si := range mc.listIdentitiesForEnvoyFilterExtension(extension) {
+				update.Identities[si] = struct{}{}
+			}
+		}
+	}
+
+	return update
+}
+
+// listEgressSourcesReferencingSecret returns the source identities of the Egress policies whose TLS origination
+// config references the given secret
+func (mc *MeshCatalog) listEgressSourcesReferencingSecret(secret *corev1.Secret) []identity.ServiceIdentity {
+	var sources []identity.ServiceIdentity
+	for _, egress := range mc.policyController.ListEgressPolicies() {
+		// Egress policies can only reference the secrets in their namespace
+		if egress.Namespace != secret.Namespace || egress.Spec.TLS == nil {
+			continue
+		}
+		tlsSpec := egress.Spec.TLS
+		if tlsSpec.CABundleSecret.Name != secret.Name &&
+			(tlsSpec.ClientCertificateSecret == nil || tlsSpec.ClientCertificateSecret.Name != secret.Name) {
+			continue
+		}
+		for _, source := range egress.Spec.Sources {
+			if source.Kind != egressSourceKindSvcAccount {
+				continue
+			}
+			sources = append(sources, identity.K8sServiceAccount{Name: source.Name, Namespace: source.Namespace}.ToServiceIdentity(mc.configurator.GetTrustDomain()))
+		}
+	}
+	return sources
+}
+
+// listIdentitiesReferencingConfigMap returns the service identities of the proxies with an EnvoyFilterExtension
+// whose WASM module is read from the given ConfigMap
+func (mc *MeshCatalog) listIdentitiesReferencingConfigMap(configMap *corev1.ConfigMap) []identity.ServiceIdentity {
+	var identities []identity.ServiceIdentity
+	for _, svcAccount := range mc.kubeController.ListServiceAccounts() {
+		// EnvoyFilterExtension resources can only reference the ConfigMaps in their namespace
+		if svcAccount.Namespace != configMap.Namespace {
+			continue
+		}
+		k8sSvcAccount := identity.K8sServiceAccount{Name: svcAccount.Name, Namespace: svcAccount.Namespace}
+		for _, extension := range mc.policyController.ListEnvoyFilterExtensions(k8sSvcAccount) {
+			wasmSpec := extension.Spec.WASM
+			if wasmSpec != nil && wasmSpec.ConfigMap != nil && wasmSpec.ConfigMap.Name == configMap.Name {
+				identities = append(identities, k8sSvcAccount.ToServiceIdentity(mc.configurator.GetTrustDomain()))
+				break
+			}
+		}
+	}
+	return identities
+}
+
+// listIdentitiesReferencingImage returns the service identities of the proxies with an EnvoyFilterExtension
+// whose WASM module is pulled from the given image
+func (mc *MeshCatalog) listIdentitiesReferencingImage(image string) []identity.ServiceIdentity {
+	var identities []identity.ServiceIdentity
+	for _, svcAccount := range mc.kubeController.ListServiceAccounts() {
+		k8sSvcAccount := identity.K8sServiceAccount{Name: svcAccount.Name, Namespace: svcAccount.Namespace}
+		for _, extension := range mc.policyController.ListEnvoyFilterExtensions(k8sSvcAccount) {
+			if extension.Spec.WASM != nil && extension.Spec.WASM.Image == image {
+				identities = append(identities, k8sSvcAccount.ToServiceIdentity(mc.configurator.GetTrustDomain()))
+				break
+			}
+		}
+	}
+	return identities
+}
+
+// listServicesSelectingPod returns the services whose selector matches the labels of the given pod
+func (mc *MeshCatalog) listServicesSelectingPod(pod *corev1.Pod) []service.MeshService {
+	var services []service.MeshService
+	for _, svc := range mc.kubeController.ListServices() {
+		if svc.Namespace != pod.Namespace || len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.Set(svc.Spec.Selector).AsSelector().Matches(labels.Set(pod.Labels)) {
+			services = append(services, service.MeshService{Namespace: svc.Namespace, Name: svc.Name})
+		}
+	}
+	return services
+}
@@ -0,0 +1,319 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	a "github.com/openservicemesh/osm/pkg/announcements"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestGetProxyUpdateScope(t *testing.T) {
+	bookstore := service.MeshService{Namespace: "default", Name: "bookstore"}
+	bookstoreV1 := service.MeshService{Namespace: "default", Name: "bookstore-v1"}
+
+	newService := func(name string, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Selector: selector},
+		}
+	}
+	services := []*corev1.Service{
+		newService("bookstore", map[string]string{"app": "bookstore"}),
+		newService("bookstore-v1", map[string]string{"app": "bookstore", "version": "v1"}),
+		newService("bookbuyer", map[string]string{"app": "bookbuyer"}),
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookstore-v1-pod",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":                            "bookstore",
+				"version":                        "v1",
+				constants.EnvoyUniqueIDLabelName: "proxy-uuid",
+			},
+		},
+	}
+
+	bookbuyerIdentity := identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "default"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
+	egressPolicies := []*policyV1alpha1.Egress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "without-tls", Namespace: "egress"},
+			Spec: policyV1alpha1.EgressSpec{
+				Sources: []policyV1alpha1.EgressSourceSpec{{Kind: "ServiceAccount", Name: "bookstore", Namespace: "default"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "with-tls", Namespace: "egress"},
+			Spec: policyV1alpha1.EgressSpec{
+				Sources: []policyV1alpha1.EgressSourceSpec{{Kind: "ServiceAccount", Name: "bookbuyer", Namespace: "default"}},
+				TLS: &policyV1alpha1.EgressTLSSpec{
+					CABundleSecret:          corev1.SecretReference{Name: "ca"},
+					ClientCertificateSecret: &corev1.SecretReference{Name: "client"},
+				},
+			},
+		},
+	}
+
+	serviceAccounts := []*corev1.ServiceAccount{
+		{ObjectMeta: metav1.ObjectMeta{Name: "bookbuyer", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bookthief", Namespace: "other"}},
+	}
+	bookstoreIdentity := identity.K8sServiceAccount{Name: "bookstore", Namespace: "default"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
+	wasmExtension := &policyV1alpha1.EnvoyFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "wasm", Namespace: "default"},
+		Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+			ServiceAccounts: []string{"bookstore"},
+			Direction:       policyV1alpha1.EnvoyFilterDirectionInbound,
+			WASM: &policyV1alpha1.WASMFilterSpec{
+				ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "filter"}, Key: "filter.wasm"},
+			},
+		},
+	}
+	wasmImage := "example.com/org/filter@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	wasmImageExtension := &policyV1alpha1.EnvoyFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "wasm-image", Namespace: "default"},
+		Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+			ServiceAccounts: []string{"bookstore"},
+			Direction:       policyV1alpha1.EnvoyFilterDirectionInbound,
+			WASM:            &policyV1alpha1.WASMFilterSpec{Image: wasmImage},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		msg      events.PubSubMessage
+		expected *ProxyUpdate
+	}{
+		{
+			name:     "broadcast requested by another module",
+			msg:      events.PubSubMessage{AnnouncementType: a.ScheduleProxyBroadcast},
+			expected: newFullProxyUpdate(),
+		},
+		{
+			name: "traffic target change",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.TrafficTargetAdded,
+				NewObj:           struct{}{},
+			},
+			expected: newFullProxyUpdate(),
+		},
+		{
+			name: "endpoints change",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.EndpointUpdated,
+				OldObj:           &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "default"}},
+				NewObj:           &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "default"}},
+			},
+			expected: &ProxyUpdate{
+				Services:   map[service.MeshService]struct{}{bookstore: {}},
+				ProxyUUIDs: map[string]struct{}{},
+				Identities: map[identity.ServiceIdentity]struct{}{},
+			},
+		},
+		{
+			name: "deleted endpoints in unknown final state",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.EndpointDeleted,
+				OldObj:           cache.DeletedFinalStateUnknown{Key: "default/bookstore"},
+			},
+			expected: newFullProxyUpdate(),
+		},
+		{
+			name: "service updated without selector change",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.ServiceUpdated,
+				OldObj:           newService("bookstore", map[string]string{"app": "bookstore"}),
+				NewObj:           newService("bookstore", map[string]string{"app": "bookstore"}),
+			},
+			expected: &ProxyUpdate{
+				Services:   map[service.MeshService]struct{}{bookstore: {}},
+				ProxyUUIDs: map[string]struct{}{},
+				Identities: map[identity.ServiceIdentity]struct{}{},
+			},
+		},
+		{
+			name: "service selector change",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.ServiceUpdated,
+				OldObj:           newService("bookstore", map[string]string{"app": "bookstore"}),
+				NewObj:           newService("bookstore", map[string]string{"app": "bookstore-v2"}),
+			},
+			expected: newFullProxyUpdate(),
+		},
+		{
+			name: "service added",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.ServiceAdded,
+				NewObj:           newService("bookstore", map[string]string{"app": "bookstore"}),
+			},
+			expected: newFullProxyUpdate(),
+		},
+		{
+			name: "pod added",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.PodAdded,
+				NewObj:           pod,
+			},
+			expected: &ProxyUpdate{
+				Services:   map[service.MeshService]struct{}{bookstore: {}, bookstoreV1: {}},
+				ProxyUUIDs: map[string]struct{}{"proxy-uuid": {}},
+				Identities: map[identity.ServiceIdentity]struct{}{},
+			},
+		},
+		{
+			name: "ingress backend added",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.IngressBackendAdded,
+				NewObj: &policyV1alpha1.IngressBackend{
+					ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "default"},
+					Spec: policyV1alpha1.IngressBackendSpec{
+						Backends: []policyV1alpha1.BackendSpec{{Name: "bookstore"}},
+					},
+				},
+			},
+			expected: &ProxyUpdate{
+				Services:   map[service.MeshService]struct{}{bookstore: {}},
+				ProxyUUIDs: map[string]struct{}{},
+				Identities: map[identity.ServiceIdentity]struct{}{},
+			},
+		},
+		{
+			name: "secret referenced by an egress policy updated",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.SecretUpdated,
+				OldObj:           &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: "egress"}},
+				NewObj:           &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: "egress"}},
+			},
+			expected: &ProxyUpdate{
+				Services:   map[service.MeshService]struct{}{},
+				ProxyUUIDs: map[string]struct{}{},
+				Identities: map[identity.ServiceIdentity]struct{}{bookbuyerIdentity: {}},
+			},
+		},
+		{
+			name: "secret not referenced by an egress policy added",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.SecretAdded,
+				NewObj:           &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: "default"}},
+			},
+			expected: newProxyUpdate(),
+		},
+		{
+			name: "configmap referenced by an envoy filter extension updated",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.ConfigMapUpdated,
+				OldObj:           &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "filter", Namespace: "default"}},
+				NewObj:           &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "filter", Namespace: "default"}},
+			},
+			expected: &ProxyUpdate{
+				Services:   map[service.MeshService]struct{}{},
+				ProxyUUIDs: map[string]struct{}{},
+				Identities: map[identity.ServiceIdentity]struct{}{bookstoreIdentity: {}},
+			},
+		},
+		{
+			name: "envoy filter extension for all the service accounts of its namespace added",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.EnvoyFilterExtensionAdded,
+				NewObj: &policyV1alpha1.EnvoyFilterExtension{
+					ObjectMeta: metav1.ObjectMeta{Name: "lua", Namespace: "default"},
+					Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+						Direction: policyV1alpha1.EnvoyFilterDirectionOutbound,
+						Lua:       &policyV1alpha1.LuaFilterSpec{InlineCode: "function envoy_on_request(request_handle) end"},
+					},
+				},
+			},
+			expected: &ProxyUpdate{
+				Services:   map[service.MeshService]struct{}{},
+				ProxyUUIDs: map[string]struct{}{},
+				Identities: map[identity.ServiceIdentity]struct{}{bookbuyerIdentity: {}, bookstoreIdentity: {}},
+			},
+		},
+		{
+			name: "WASM module of an image referenced by an envoy filter extension pulled",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.WASMModulePulled,
+				NewObj:           wasmImage,
+			},
+			expected: &ProxyUpdate{
+				Services:   map[service.MeshService]struct{}{},
+				ProxyUUIDs: map[string]struct{}{},
+				Identities: map[identity.ServiceIdentity]struct{}{bookstoreIdentity: {}},
+			},
+		},
+		{
+			name: "WASM module of an image no longer referenced pulled",
+			msg: events.PubSubMessage{
+				AnnouncementType: a.WASMModulePulled,
+				NewObj:           "example.com/org/other@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			},
+			expected: newProxyUpdate(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockKubeController.EXPECT().ListServices().Return(services).AnyTimes()
+			mockKubeController.EXPECT().ListServiceAccounts().Return(serviceAccounts).AnyTimes()
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockPolicyController.EXPECT().ListEgressPolicies().Return(egressPolicies).AnyTimes()
+			mockPolicyController.EXPECT().ListEnvoyFilterExtensions(identity.K8sServiceAccount{Name: "bookstore", Namespace: "default"}).
+				Return([]*policyV1alpha1.EnvoyFilterExtension{wasmExtension, wasmImageExtension}).AnyTimes()
+			mockPolicyController.EXPECT().ListEnvoyFilterExtensions(gomock.Any()).Return(nil).AnyTimes()
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
+
+			mc := &MeshCatalog{kubeController: mockKubeController, policyController: mockPolicyController, configurator: mockConfigurator}
+			assert.Equal(tc.expected, mc.getProxyUpdateScope(tc.msg))
+		})
+	}
+}
+
+func TestProxyUpdateMerge(t *testing.T) {
+	assert := tassert.New(t)
+
+	bookstore := service.MeshService{Namespace: "default", Name: "bookstore"}
+	bookbuyer := service.MeshService{Namespace: "default", Name: "bookbuyer"}
+
+	update := newProxyUpdate()
+	update.Services[bookstore] = struct{}{}
+	other := newProxyUpdate()
+	other.Services[bookbuyer] = struct{}{}
+	other.ProxyUUIDs["proxy-uuid"] = struct{}{}
+	other.Identities["sa.default"] = struct{}{}
+
+	assert.True(newProxyUpdate().isEmpty())
+	assert.False(other.isEmpty())
+	assert.False(newFullProxyUpdate().isEmpty())
+
+	merged := update.merge(other)
+	assert.False(merged.IsFull())
+	assert.Equal(map[service.MeshService]struct{}{bookstore: {}, bookbuyer: {}}, merged.Services)
+	assert.Equal(map[string]struct{}{"proxy-uuid": {}}, merged.ProxyUUIDs)
+	assert.Equal(map[identity.ServiceIdentity]struct{}{"sa.default": {}}, merged.Identities)
+
+	// A full update absorbs any scoped update
+	assert.True(merged.merge(newFullProxyUpdate()).IsFull())
+	assert.True(newFullProxyUpdate().merge(other).IsFull())
+
+	var nilUpdate *ProxyUpdate
+	assert.True(nilUpdate.IsFull())
+}
@@ -0,0 +1,435 @@
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// TrafficRequest is a request sent by a source identity to a destination service, whose reachability is
+// evaluated against the traffic policies of the mesh
+type TrafficRequest struct {
+	Source      identity.ServiceIdentity
+	Destination service.MeshService
+
+	// Port is the port of the destination service the request is sent to, it can be omitted when the service has a single port
+	Port uint32
+
+	// Method, Path and Headers are the attributes of the request matched against the HTTP routes, they are ignored for TCP traffic
+	Method  string
+	Path    string
+	Headers map[string]string
+}
+
+// TrafficDecision is the outcome of the evaluation of a TrafficRequest
+type TrafficDecision struct {
+	// Allowed is whether the request is allowed on every backend it can be routed to
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+
+	Port     uint32 `json:"port,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+
+	// Backends are the decisions for each service identity behind the backend services the request can be routed to
+	Backends []BackendDecision `json:"backends,omitempty"`
+}
+
+// BackendDecision is the outcome of the evaluation of a TrafficRequest for a service identity behind one of the
+// backend services the request can be routed to
+type BackendDecision struct {
+	Service  string `json:"service"`
+	Weight   int    `json:"weight"`
+	Identity string `json:"identity,omitempty"`
+	Allowed  bool   `json:"allowed"`
+	Reason   string `json:"reason"`
+
+	// TrafficTargets are the SMI TrafficTargets allowing the source to access the identity
+	TrafficTargets []string `json:"trafficTargets,omitempty"`
+
+	// Route is the inbound HTTP route the request matched
+	Route *RouteDecision `json:"route,omitempty"`
+}
+
+// RouteDecision is an HTTP route match of a traffic policy
+type RouteDecision struct {
+	Path    string            `json:"path"`
+	Methods []string          `json:"methods,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// String returns the route match in the form: <methods> <path> [<header>=<value> ...]
+func (r RouteDecision) String() string {
+	str := fmt.Sprintf("%s %s", strings.Join(r.Methods, ","), r.Path)
+	var headers []string
+	for name, value := range r.Headers {
+		headers = append(headers, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(headers)
+	if len(headers) > 0 {
+		str = fmt.Sprintf("%s %s", str, strings.Join(headers, " "))
+	}
+	return str
+}
+
+// CheckTrafficRequest evaluates whether the given request is allowed by the traffic policies of the mesh. It walks
+// the same outbound and inbound traffic policies the proxies of the source and the destination are programmed with:
+// 1. the destination service must be an upstream of the source, on a port of the service
+// 2. for HTTP, a route of the outbound traffic policy matching the host of the request selects the backend services
+// 3. every service identity behind the backend services must allow the source, on an HTTP route or TCP port matching the request
+func (mc *MeshCatalog) CheckTrafficRequest(req TrafficRequest) TrafficDecision {
+	var decision TrafficDecision
+	dst := req.Destination
+
+	k8sSvc := mc.kubeController.GetService(dst)
+	if k8sSvc == nil {
+		decision.Reason = fmt.Sprintf("Service %s not found", dst)
+		return decision
+	}
+
+	protocols, err := mc.GetPortToProtocolMappingForService(dst)
+	if err != nil || len(protocols) == 0 {
+		decision.Reason = fmt.Sprintf("Service %s has no ports with endpoints", dst)
+		return decision
+	}
+	decision.Port = req.Port
+	if decision.Port == 0 {
+		if len(protocols) > 1 {
+			decision.Reason = fmt.Sprintf("Service %s has several ports, the port of the request must be specified", dst)
+			return decision
+		}
+		for port := range protocols {
+			decision.Port = port
+		}
+	}
+	protocol, ok := protocols[decision.Port]
+	if !ok {
+		decision.Reason = fmt.Sprintf("Service %s has no port %d", dst, decision.Port)
+		return decision
+	}
+	decision.Protocol = protocol
+	isHTTP := protocol == constants.ProtocolHTTP || protocol == constants.ProtocolGRPC
+
+	isUpstream := false
+	for _, upstream := range mc.ListMeshServicesForIdentity(req.Source) {
+		if upstream == dst {
+			isUpstream = true
+			break
+		}
+	}
+	if !isUpstream {
+		decision.Reason = fmt.Sprintf("Service %s is not an upstream of %s, no TrafficTarget allows %s to access the identities of its pods", dst, req.Source, req.Source)
+		return decision
+	}
+
+	req = withRequestDefaults(req)
+	var weightedClusters []service.WeightedCluster
+	if isHTTP {
+		route, err := mc.getOutboundRoute(req)
+		if err != nil {
+			decision.Reason = err.Error()
+			return decision
+		}
+		for cluster := range route.WeightedClusters.Iter() {
+			weightedClusters = append(weightedClusters, cluster.(service.WeightedCluster))
+		}
+	} else {
+		weightedClusters = mc.GetWeightedClustersForUpstream(dst)
+		if len(weightedClusters) == 0 {
+			weightedClusters = []service.WeightedCluster{getDefaultWeightedClusterForService(dst)}
+		}
+	}
+	sort.Slice(weightedClusters, func(i, j int) bool {
+		return weightedClusters[i].ClusterName < weightedClusters[j].ClusterName
+	})
+
+	decision.Allowed = true
+	for _, cluster := range weightedClusters {
+		backend := clusterToMeshService(cluster.ClusterName)
+		for _, backendDecision := range mc.checkBackendTrafficRequest(req, backend, decision.Port, isHTTP) {
+			backendDecision.Weight = cluster.Weight
+			decision.Backends = append(decision.Backends, backendDecision)
+			if !backendDecision.Allowed && decision.Allowed {
+				decision.Allowed = false
+				decision.Reason = backendDecision.Reason
+			}
+		}
+	}
+
+	if decision.Allowed {
+		decision.Reason = fmt.Sprintf("Request from %s to %s is allowed on every backend", req.Source, dst)
+	}
+	return decision
+}
+
+// getOutboundRoute returns the route of the source's outbound traffic policies matching the given HTTP request
+func (mc *MeshCatalog) getOutboundRoute(req TrafficRequest) (*trafficpolicy.RouteWeightedClusters, error) {
+	host := getHeader(req.Headers, hostHeaderKey)
+	if host == "" {
+		host = req.Destination.FQDN()
+	}
+
+	for _, policy := range mc.ListOutboundTrafficPolicies(req.Source) {
+		if !containsString(policy.Hostnames, host) {
+			continue
+		}
+		for _, route := range policy.Routes {
+			if matchesHTTPRoute(route.HTTPRouteMatch, req) {
+				return route, nil
+			}
+		}
+		return nil, errors.Errorf("No outbound route of %s for host %s matches %s %s", req.Source, host, req.Method, req.Path)
+	}
+	return nil, errors.Errorf("No outbound traffic policy of %s matches host %s", req.Source, host)
+}
+
+// checkBackendTrafficRequest evaluates the given request against the inbound traffic policies of each service identity
+// behind the given backend service
+func (mc *MeshCatalog) checkBackendTrafficRequest(req TrafficRequest, backend service.MeshService, port uint32, isHTTP bool) []BackendDecision {
+	identities, err := mc.ListServiceIdentitiesForService(backend)
+	if err != nil || len(identities) == 0 {
+		return []BackendDecision{{
+			Service: backend.String(),
+			Reason:  fmt.Sprintf("Service %s does not select any pod", backend),
+		}}
+	}
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i] < identities[j]
+	})
+
+	var decisions []BackendDecision
+	for _, upstreamIdentity := range identities {
+		decision := BackendDecision{
+			Service:        backend.String(),
+			Identity:       upstreamIdentity.String(),
+			TrafficTargets: mc.listTrafficTargetsAllowingSource(req.Source, upstreamIdentity, 0),
+		}
+		if isHTTP {
+			mc.checkInboundHTTPRequest(req, backend, upstreamIdentity, &decision)
+		} else {
+			mc.checkInboundTCPRequest(req, backend, upstreamIdentity, port, &decision)
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions
+}
+
+func (mc *MeshCatalog) checkInboundHTTPRequest(req TrafficRequest, backend service.MeshService, upstreamIdentity identity.ServiceIdentity, decision *BackendDecision) {
+	host := getHeader(req.Headers, hostHeaderKey)
+	if host == "" {
+		host = req.Destination.FQDN()
+	}
+	source := req.Source.WithTrustDomain(mc.configurator.GetTrustDomain())
+
+	for _, policy := range mc.ListInboundTrafficPolicies(upstreamIdentity, []service.MeshService{backend}) {
+		if !containsString(policy.Hostnames, host) {
+			continue
+		}
+
+		sourceAllowed := false
+		for _, rule := range policy.Rules {
+			if !rule.AllowedServiceIdentities.Contains(source) && !rule.AllowedServiceIdentities.Contains(identity.WildcardServiceIdentity) {
+				continue
+			}
+			sourceAllowed = true
+			if !matchesHTTPRoute(rule.Route.HTTPRouteMatch, req) {
+				continue
+			}
+
+			decision.Allowed = true
+			decision.Route = &RouteDecision{
+				Path:    rule.Route.HTTPRouteMatch.Path,
+				Methods: rule.Route.HTTPRouteMatch.Methods,
+				Headers: rule.Route.HTTPRouteMatch.Headers,
+			}
+			if mc.configurator.IsPermissiveTrafficPolicyMode() {
+				decision.Reason = "Allowed in permissive traffic policy mode"
+			} else {
+				decision.Reason = fmt.Sprintf("Allowed by the HTTP route %s", decision.Route)
+			}
+			return
+		}
+
+		if sourceAllowed {
+			decision.Reason = fmt.Sprintf("No HTTP route allowed for %s on %s matches %s %s", req.Source, upstreamIdentity, req.Method, req.Path)
+		} else {
+			decision.Reason = fmt.Sprintf("No TrafficTarget allows %s to access %s", req.Source, upstreamIdentity)
+		}
+		return
+	}
+	decision.Reason = fmt.Sprintf("No inbound traffic policy of %s matches host %s", upstreamIdentity, host)
+}
+
+func (mc *MeshCatalog) checkInboundTCPRequest(req TrafficRequest, backend service.MeshService, upstreamIdentity identity.ServiceIdentity, port uint32, decision *BackendDecision) {
+	if mc.configurator.IsPermissiveTrafficPolicyMode() {
+		decision.Allowed = true
+		decision.Reason = "Allowed in permissive traffic policy mode"
+		return
+	}
+
+	if len(decision.TrafficTargets) == 0 {
+		decision.Reason = fmt.Sprintf("No TrafficTarget allows %s to access %s", req.Source, upstreamIdentity)
+		return
+	}
+
+	// TCPRoute ports are matched against the port the traffic is received on by the backend
+	targetPort := getTargetPort(mc.kubeController.GetService(backend), port)
+	decision.TrafficTargets = mc.listTrafficTargetsAllowingSource(req.Source, upstreamIdentity, targetPort)
+	if len(decision.TrafficTargets) == 0 {
+		decision.Reason = fmt.Sprintf("No TCPRoute allowed for %s on %s matches port %d", req.Source, upstreamIdentity, targetPort)
+		return
+	}
+	decision.Allowed = true
+	decision.Reason = fmt.Sprintf("Allowed on port %d by TrafficTarget %s", targetPort, strings.Join(decision.TrafficTargets, ", "))
+}
+
+// listTrafficTargetsAllowingSource returns the names of the TrafficTargets allowing the source to access the upstream
+// identity, on the given TCP port if it is not 0
+func (mc *MeshCatalog) listTrafficTargetsAllowingSource(source, upstream identity.ServiceIdentity, tcpPort uint32) []string {
+	trafficTargets, err := mc.ListInboundTrafficTargetsWithRoutes(upstream)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing the inbound TrafficTargets of %s", upstream)
+		return nil
+	}
+
+	source = source.WithTrustDomain(mc.configurator.GetTrustDomain())
+	var names []string
+	for _, trafficTarget := range trafficTargets {
+		sourceAllowed := false
+		for _, allowed := range trafficTarget.Sources {
+			if allowed == source {
+				sourceAllowed = true
+				break
+			}
+		}
+		if !sourceAllowed {
+			continue
+		}
+		if tcpPort != 0 && !matchesTCPRoutes(trafficTarget.TCPRouteMatches, tcpPort) {
+			continue
+		}
+		names = append(names, trafficTarget.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// matchesTCPRoutes returns whether the given port is allowed by the TCP route matches, which allow every port when
+// none of them restricts the ports
+func matchesTCPRoutes(routeMatches []trafficpolicy.TCPRouteMatch, port uint32) bool {
+	allPorts := true
+	for _, routeMatch := range routeMatches {
+		for _, p := range routeMatch.Ports {
+			allPorts = false
+			if uint32(p) == port {
+				return true
+			}
+		}
+	}
+	return allPorts
+}
+
+// matchesHTTPRoute returns whether the request matches the path, method and headers of the route match, the way
+// Envoy matches the routes programmed from it
+func matchesHTTPRoute(routeMatch trafficpolicy.HTTPRouteMatch, req TrafficRequest) bool {
+	switch routeMatch.PathMatchType {
+	case trafficpolicy.PathMatchExact:
+		if req.Path != routeMatch.Path {
+			return false
+		}
+	case trafficpolicy.PathMatchPrefix:
+		if !strings.HasPrefix(req.Path, routeMatch.Path) {
+			return false
+		}
+	default:
+		if !matchesRegex(routeMatch.Path, req.Path) {
+			return false
+		}
+	}
+
+	methodMatched := false
+	for _, method := range routeMatch.Methods {
+		if method == constants.WildcardHTTPMethod || strings.EqualFold(method, req.Method) {
+			methodMatched = true
+			break
+		}
+	}
+	if !methodMatched {
+		return false
+	}
+
+	for name, value := range routeMatch.Headers {
+		if !matchesRegex(value, getHeader(req.Headers, name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesRegex returns whether the whole value matches the regex
+func matchesRegex(regex, value string) bool {
+	re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", regex))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error compiling regex %s", regex)
+		return false
+	}
+	return re.MatchString(value)
+}
+
+func getHeader(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+func withRequestDefaults(req TrafficRequest) TrafficRequest {
+	if req.Method == "" {
+		req.Method = "GET"
+	}
+	if req.Path == "" {
+		req.Path = "/"
+	}
+	return req
+}
+
+func containsString(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// clusterToMeshService returns the mesh service of a cluster named <namespace>/<name>
+func clusterToMeshService(cluster service.ClusterName) service.MeshService {
+	chunks := strings.SplitN(cluster.String(), "/", 2)
+	if len(chunks) != 2 {
+		return service.MeshService{Name: cluster.String()}
+	}
+	return service.MeshService{Namespace: chunks[0], Name: chunks[1]}
+}
+
+// getTargetPort returns the target port of the given service port, or the service port when the target port is not numeric
+func getTargetPort(svc *corev1.Service, port uint32) uint32 {
+	if svc == nil {
+		return port
+	}
+	for _, svcPort := range svc.Spec.Ports {
+		if uint32(svcPort.Port) == port && svcPort.TargetPort.IntValue() != 0 {
+			return uint32(svcPort.TargetPort.IntValue())
+		}
+	}
+	return port
+}
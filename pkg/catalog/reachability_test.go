@@ -0,0 +1,104 @@
+package catalog
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestMatchesHTTPRoute(t *testing.T) {
+	testCases := []struct {
+		name       string
+		routeMatch trafficpolicy.HTTPRouteMatch
+		req        TrafficRequest
+		expected   bool
+	}{
+		{
+			name:       "wildcard route",
+			routeMatch: trafficpolicy.WildCardRouteMatch,
+			req:        TrafficRequest{Method: "POST", Path: "/books"},
+			expected:   true,
+		},
+		{
+			name: "regex path and method match",
+			routeMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/books/.*",
+				PathMatchType: trafficpolicy.PathMatchRegex,
+				Methods:       []string{"GET"},
+			},
+			req:      TrafficRequest{Method: "get", Path: "/books/1"},
+			expected: true,
+		},
+		{
+			name: "regex path matching part of the path only",
+			routeMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/books",
+				PathMatchType: trafficpolicy.PathMatchRegex,
+				Methods:       []string{"GET"},
+			},
+			req:      TrafficRequest{Method: "GET", Path: "/books/1"},
+			expected: false,
+		},
+		{
+			name: "prefix path match with another method",
+			routeMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/books",
+				PathMatchType: trafficpolicy.PathMatchPrefix,
+				Methods:       []string{"GET", "PUT"},
+			},
+			req:      TrafficRequest{Method: "DELETE", Path: "/books/1"},
+			expected: false,
+		},
+		{
+			name: "exact path and header match",
+			routeMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/books",
+				PathMatchType: trafficpolicy.PathMatchExact,
+				Methods:       []string{"*"},
+				Headers:       map[string]string{"user-agent": ".*Mozilla.*"},
+			},
+			req:      TrafficRequest{Method: "GET", Path: "/books", Headers: map[string]string{"User-Agent": "Mozilla/5.0"}},
+			expected: true,
+		},
+		{
+			name: "missing header",
+			routeMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          ".*",
+				PathMatchType: trafficpolicy.PathMatchRegex,
+				Methods:       []string{"*"},
+				Headers:       map[string]string{"user-agent": ".*Mozilla.*"},
+			},
+			req:      TrafficRequest{Method: "GET", Path: "/books"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, matchesHTTPRoute(tc.routeMatch, tc.req))
+		})
+	}
+}
+
+func TestMatchesTCPRoutes(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.True(matchesTCPRoutes(nil, 3306))
+	assert.True(matchesTCPRoutes([]trafficpolicy.TCPRouteMatch{{}}, 3306))
+	assert.True(matchesTCPRoutes([]trafficpolicy.TCPRouteMatch{{Ports: []int{8080}}, {Ports: []int{3306}}}, 3306))
+	assert.False(matchesTCPRoutes([]trafficpolicy.TCPRouteMatch{{Ports: []int{8080}}}, 3306))
+}
+
+func TestRouteDecisionString(t *testing.T) {
+	assert := tassert.New(t)
+
+	route := RouteDecision{
+		Path:    "/books/.*",
+		Methods: []string{"GET", "PUT"},
+		Headers: map[string]string{"user-agent": "curl.*", "host": "bookstore"},
+	}
+	assert.Equal("GET,PUT /books/.* host=bookstore user-agent=curl.*", route.String())
+}
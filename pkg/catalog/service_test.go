@@ -230,16 +230,16 @@ func TestListServiceIdentitiesForService(t *testing.T) {
 		{
 			service.MeshService{Name: "foo", Namespace: "ns-1"},
 			[]identity.ServiceIdentity{
-				identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
-				identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-1"}.ToServiceIdentity(),
+				identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+				identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			},
 			nil,
 		},
 		{
 			service.MeshService{Name: "foo", Namespace: "ns-1"},
 			[]identity.ServiceIdentity{
-				identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
-				identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-1"}.ToServiceIdentity(),
+				identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+				identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			},
 			nil,
 		},
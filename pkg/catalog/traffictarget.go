@@ -2,10 +2,12 @@ package catalog
 
 import (
 	"fmt"
+	"strings"
 
 	mapset "github.com/deckarep/golang-set"
 	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
@@ -48,13 +50,11 @@ func (mc *MeshCatalog) ListInboundTrafficTargetsWithRoutes(upstream identity.Ser
 			continue
 		}
 
-		destinationSvcIdentity := trafficTargetIdentityToSvcAccount(t.Spec.Destination).ToServiceIdentity()
-		if destinationSvcIdentity != upstream {
+		destinationIdentity := trafficTargetIdentityToServiceIdentity(t.Spec.Destination, mc.configurator.GetTrustDomain())
+		if destinationIdentity != upstream {
 			continue
 		}
 
-		destinationIdentity := trafficTargetIdentityToServiceIdentity(t.Spec.Destination)
-
 		// Create a traffic target for this destination identity
 		trafficTarget := trafficpolicy.TrafficTargetWithRoutes{
 			Name:        fmt.Sprintf("%s/%s", t.Namespace, t.Name),
@@ -62,12 +62,7 @@ func (mc *MeshCatalog) ListInboundTrafficTargetsWithRoutes(upstream identity.Ser
 		}
 
 		// Source identifies for this traffic target
-		var sourceIdentities []identity.ServiceIdentity
-		for _, source := range t.Spec.Sources {
-			srcIdentity := trafficTargetIdentityToServiceIdentity(source)
-			sourceIdentities = append(sourceIdentities, srcIdentity)
-		}
-		trafficTarget.Sources = sourceIdentities
+		trafficTarget.Sources = mc.getTrafficTargetSourceIdentities(t)
 
 		// TCP routes for this traffic target
 		if tcpRouteMatches, err := mc.getTCPRouteMatchesFromTrafficTarget(*t); err != nil {
@@ -138,7 +133,7 @@ func (mc *MeshCatalog) getAllowedDirectionalServiceAccounts(svcIdentity identity
 
 	var allowedSvcIdentities []identity.ServiceIdentity
 	for svcAccount := range allowed.Iter() {
-		allowedSvcIdentities = append(allowedSvcIdentities, svcAccount.(identity.K8sServiceAccount).ToServiceIdentity())
+		allowedSvcIdentities = append(allowedSvcIdentities, svcAccount.(identity.K8sServiceAccount).ToServiceIdentity(mc.configurator.GetTrustDomain()))
 	}
 
 	return allowedSvcIdentities, nil
@@ -151,10 +146,41 @@ func trafficTargetIdentityToSvcAccount(identitySubject smiAccess.IdentityBinding
 	}
 }
 
-// trafficTargetIdentityToServiceIdentity returns an identity of the form <namespace>/<service-account>
-func trafficTargetIdentityToServiceIdentity(identitySubject smiAccess.IdentityBindingSubject) identity.ServiceIdentity {
+// trafficTargetIdentityToServiceIdentity returns an identity of the form <service-account>.<namespace>.<trust-domain>
+func trafficTargetIdentityToServiceIdentity(identitySubject smiAccess.IdentityBindingSubject, trustDomain string) identity.ServiceIdentity {
 	svcAccount := trafficTargetIdentityToSvcAccount(identitySubject)
-	return identity.GetKubernetesServiceIdentity(svcAccount, identity.ClusterLocalTrustDomain)
+	return identity.GetKubernetesServiceIdentity(svcAccount, trustDomain)
+}
+
+// getTrafficTargetSourceIdentities returns the service identities of the sources of the given traffic target.
+// The sources belong to the local cluster unless the traffic target lists the clusters its sources belong to
+// in the SourceClustersAnnotation, in which case a source identity is returned for each listed cluster.
+func (mc *MeshCatalog) getTrafficTargetSourceIdentities(t *smiAccess.TrafficTarget) []identity.ServiceIdentity {
+	localTrustDomain := mc.configurator.GetTrustDomain()
+
+	trustDomains := []string{localTrustDomain}
+	if clusters, ok := t.Annotations[constants.SourceClustersAnnotation]; ok {
+		trustDomains = nil
+		for _, cluster := range strings.Split(clusters, ",") {
+			cluster = strings.TrimSpace(cluster)
+			switch cluster {
+			case "":
+				continue
+			case constants.LocalClusterName:
+				trustDomains = append(trustDomains, localTrustDomain)
+			default:
+				trustDomains = append(trustDomains, identity.GetTrustDomainForCluster(cluster))
+			}
+		}
+	}
+
+	var sourceIdentities []identity.ServiceIdentity
+	for _, source := range t.Spec.Sources {
+		for _, trustDomain := range trustDomains {
+			sourceIdentities = append(sourceIdentities, trafficTargetIdentityToServiceIdentity(source, trustDomain))
+		}
+	}
+	return sourceIdentities
 }
 
 // trafficTargetIdentitiesToSvcAccounts returns a list of Service Accounts from the given list of identities from a Traffic Target
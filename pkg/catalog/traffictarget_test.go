@@ -11,7 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
-
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
@@ -23,8 +23,11 @@ func TestListInboundServiceIdentities(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 	meshCatalog := MeshCatalog{
-		meshSpec: mockMeshSpec,
+		meshSpec:     mockMeshSpec,
+		configurator: mockConfigurator,
 	}
 
 	testCases := []struct {
@@ -87,14 +90,14 @@ func TestListInboundServiceIdentities(t *testing.T) {
 			identity.K8sServiceAccount{
 				Name:      "sa-2",
 				Namespace: "ns-2",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			// allowed inbound service accounts: 1 match
 			[]identity.ServiceIdentity{
 				identity.K8sServiceAccount{
 					Name:      "sa-1",
 					Namespace: "ns-1",
-				}.ToServiceIdentity(),
+				}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			},
 
 			false, // no errors expected
@@ -133,7 +136,7 @@ func TestListInboundServiceIdentities(t *testing.T) {
 			identity.K8sServiceAccount{
 				Name:      "sa-1",
 				Namespace: "ns-1",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			// allowed inbound service accounts: no match
 			nil,
@@ -174,7 +177,7 @@ func TestListInboundServiceIdentities(t *testing.T) {
 			identity.K8sServiceAccount{
 				Name:      "sa-1",
 				Namespace: "ns-1",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			// allowed inbound service accounts: no match
 			nil,
@@ -202,8 +205,11 @@ func TestListOutboundServiceIdentities(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 	meshCatalog := MeshCatalog{
-		meshSpec: mockMeshSpec,
+		meshSpec:     mockMeshSpec,
+		configurator: mockConfigurator,
 	}
 
 	testCases := []struct {
@@ -266,18 +272,18 @@ func TestListOutboundServiceIdentities(t *testing.T) {
 			identity.K8sServiceAccount{
 				Name:      "sa-1",
 				Namespace: "ns-1",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			// allowed inbound service accounts: 2 matches
 			[]identity.ServiceIdentity{
 				identity.K8sServiceAccount{
 					Name:      "sa-2",
 					Namespace: "ns-2",
-				}.ToServiceIdentity(),
+				}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 				identity.K8sServiceAccount{
 					Name:      "sa-3",
 					Namespace: "ns-3",
-				}.ToServiceIdentity(),
+				}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			},
 
 			false, // no errors expected
@@ -316,7 +322,7 @@ func TestListOutboundServiceIdentities(t *testing.T) {
 			identity.K8sServiceAccount{
 				Name:      "sa-2",
 				Namespace: "ns-2",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			// allowed inbound service accounts: no match
 			nil,
@@ -357,7 +363,7 @@ func TestListOutboundServiceIdentities(t *testing.T) {
 			identity.K8sServiceAccount{
 				Name:      "sa-1",
 				Namespace: "ns-1",
-			}.ToServiceIdentity(),
+			}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			// allowed inbound service accounts: no match
 			nil,
@@ -418,6 +424,66 @@ func TestTrafficTargetIdentityToSvcAccount(t *testing.T) {
 	}
 }
 
+func TestGetTrafficTargetSourceIdentities(t *testing.T) {
+	testCases := []struct {
+		name               string
+		trustDomain        string
+		annotations        map[string]string
+		expectedIdentities []identity.ServiceIdentity
+	}{
+		{
+			name:               "sources default to the local cluster",
+			expectedIdentities: []identity.ServiceIdentity{"sa-1.ns-1.cluster.local"},
+		},
+		{
+			name:               "sources belong to the configured trust domain",
+			trustDomain:        "example.com",
+			expectedIdentities: []identity.ServiceIdentity{"sa-1.ns-1.example.com"},
+		},
+		{
+			name:               "local source belongs to the configured trust domain",
+			trustDomain:        "example.com",
+			annotations:        map[string]string{constants.SourceClustersAnnotation: "local"},
+			expectedIdentities: []identity.ServiceIdentity{"sa-1.ns-1.example.com"},
+		},
+		{
+			name:               "sources from local and remote clusters",
+			annotations:        map[string]string{constants.SourceClustersAnnotation: "local, east"},
+			expectedIdentities: []identity.ServiceIdentity{"sa-1.ns-1.cluster.local", "sa-1.ns-1.east.cluster.local"},
+		},
+		{
+			name:               "sources from a remote cluster only",
+			annotations:        map[string]string{constants.SourceClustersAnnotation: "west"},
+			expectedIdentities: []identity.ServiceIdentity{"sa-1.ns-1.west.cluster.local"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			trustDomain := tc.trustDomain
+			if trustDomain == "" {
+				trustDomain = identity.ClusterLocalTrustDomain
+			}
+			mockConfigurator.EXPECT().GetTrustDomain().Return(trustDomain).AnyTimes()
+			mc := MeshCatalog{configurator: mockConfigurator}
+
+			trafficTarget := &smiAccess.TrafficTarget{
+				ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "ns-2", Annotations: tc.annotations},
+				Spec: smiAccess.TrafficTargetSpec{
+					Sources: []smiAccess.IdentityBindingSubject{{Kind: "ServiceAccount", Name: "sa-1", Namespace: "ns-1"}},
+				},
+			}
+
+			assert.Equal(tc.expectedIdentities, mc.getTrafficTargetSourceIdentities(trafficTarget))
+		})
+	}
+}
+
 func TestTrafficTargetIdentitiesToSvcAccounts(t *testing.T) {
 	assert := tassert.New(t)
 	input := []smiAccess.IdentityBindingSubject{
@@ -512,7 +578,7 @@ func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 				},
 			},
 
-			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(),
+			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			expectedTrafficTargets: []trafficpolicy.TrafficTargetWithRoutes{
 				{
@@ -599,7 +665,7 @@ func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 				},
 			},
 
-			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(),
+			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			expectedTrafficTargets: []trafficpolicy.TrafficTargetWithRoutes{
 				{
@@ -747,7 +813,7 @@ func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 				},
 			},
 
-			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(),
+			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			expectedTrafficTargets: []trafficpolicy.TrafficTargetWithRoutes{
 				{
@@ -834,7 +900,7 @@ func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 				},
 			},
 
-			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(),
+			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			expectedTrafficTargets: []trafficpolicy.TrafficTargetWithRoutes{
 				{
@@ -867,6 +933,7 @@ func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 			}
 
 			mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
 
 			// Mock TrafficTargets returned by MeshSpec, should return all TrafficTargets relevant for this test
 			mockMeshSpec.EXPECT().ListTrafficTargets().Return(tc.trafficTargets).AnyTimes()
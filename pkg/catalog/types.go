@@ -5,6 +5,8 @@
 package catalog
 
 import (
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
@@ -12,6 +14,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/oci"
 	"github.com/openservicemesh/osm/pkg/policy"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/smi"
@@ -39,6 +42,31 @@ type MeshCatalog struct {
 	// policyController implements the functionality related to the resources part of the policy.openrservicemesh.io
 	// API group, such as egress.
 	policyController policy.Controller
+
+	// leaderCheck determines whether this controller replica is the leader, which is the only replica
+	// updating the status of policy resources. Every replica is considered a leader when unset.
+	leaderCheck func() bool
+
+	// wasmPuller pulls the WASM modules of the EnvoyFilterExtension resources referencing OCI images
+	wasmPuller *oci.Puller
+}
+
+// ProxyUpdate is the scope of the proxy update published with the ProxyBroadcast announcement, coalescing
+// the configuration changes observed during the debounce window. A nil ProxyUpdate is a full update.
+type ProxyUpdate struct {
+	// Full indicates all proxies must be updated, ex. when a change can not be attributed to a set of services
+	Full bool
+
+	// Services is the set of services whose changes triggered the update. The proxies whose configuration
+	// depends on any of these services must be updated.
+	Services map[service.MeshService]struct{}
+
+	// ProxyUUIDs is the set of UUIDs of the proxies directly affected by the update, ex. on pod changes
+	ProxyUUIDs map[string]struct{}
+
+	// Identities is the set of identities whose proxies are directly affected by the update, ex. on changes
+	// to the secrets referenced by the Egress policies applied to these identities
+	Identities map[identity.ServiceIdentity]struct{}
 }
 
 // MeshCataloger is the mechanism by which the Service Mesh controller discovers all Envoy proxies connected to the catalog.
@@ -76,6 +104,15 @@ type MeshCataloger interface {
 	// GetIngressTrafficPolicy returns the ingress traffic policy for the given mesh service
 	GetIngressTrafficPolicy(service.MeshService) (*trafficpolicy.IngressTrafficPolicy, error)
 
+	// ListIngressSourceServices returns the source services of the IngressBackend policy for the given mesh service
+	ListIngressSourceServices(service.MeshService) []service.MeshService
+
+	// GetAuthorizationTrafficPolicy returns the authorization traffic policy for the given mesh service
+	GetAuthorizationTrafficPolicy(service.MeshService) *trafficpolicy.AuthorizationTrafficPolicy
+
+	// GetEnvoyFilterExtensions returns the Envoy filter extensions of the given direction for the given service identity, in filter chain order
+	GetEnvoyFilterExtensions(identity.ServiceIdentity, policyV1alpha1.EnvoyFilterDirection) []*trafficpolicy.EnvoyFilterExtension
+
 	// GetTargetPortToProtocolMappingForService returns a mapping of the service's ports to their corresponding application protocol.
 	// The ports returned are the actual ports on which the application exposes the service derived from the service's endpoints,
 	// ie. 'spec.ports[].targetPort' instead of 'spec.ports[].port' for a Kubernetes service.
@@ -98,6 +135,9 @@ type MeshCataloger interface {
 	// GetEgressTrafficPolicy returns the Egress traffic policy associated with the given service identity.
 	GetEgressTrafficPolicy(identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error)
 
+	// GetEgressGatewayTrafficPolicy returns the Egress traffic policy programmed on the egress gateway
+	GetEgressGatewayTrafficPolicy() (*trafficpolicy.EgressTrafficPolicy, error)
+
 	// GetKubeController returns the kube controller instance handling the current cluster
 	GetKubeController() k8s.Controller
 
@@ -278,7 +278,7 @@ func NewCertManager(
 	}
 
 	// Instantiating a new certificate rotation mechanism will start a goroutine for certificate rotation.
-	rotor.New(cm).Start(checkCertificateExpirationInterval)
+	rotor.New(cm).Start(rotor.DefaultCheckInterval)
 
 	return cm, nil
 }
@@ -15,12 +15,6 @@ import (
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
-const (
-	// checkCertificateExpirationInterval is the interval to check whether a
-	// certificate is close to expiration and needs renewal.
-	checkCertificateExpirationInterval = 5 * time.Second
-)
-
 var (
 	log = logger.New("cert-manager")
 )
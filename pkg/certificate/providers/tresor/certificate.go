@@ -8,10 +8,6 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 )
 
-const (
-	checkCertificateExpirationInterval = 5 * time.Second
-)
-
 // GetCommonName implements certificate.Certificater and returns the CN of the cert.
 func (c Certificate) GetCommonName() certificate.CommonName {
 	return c.commonName
@@ -63,7 +59,7 @@ func NewCertManager(
 	}
 
 	// Instantiating a new certificate rotation mechanism will start a goroutine for certificate rotation.
-	rotor.New(&certManager).Start(checkCertificateExpirationInterval)
+	rotor.New(&certManager).Start(rotor.DefaultCheckInterval)
 
 	return &certManager, nil
 }
@@ -29,8 +29,7 @@ const (
 	commonNameField   = "common_name"
 	ttlField          = "ttl"
 
-	checkCertificateExpirationInterval = 5 * time.Second
-	decade                             = 8765 * time.Hour
+	decade = 8765 * time.Hour
 )
 
 // NewCertManager implements certificate.Manager and wraps a Hashi Vault with methods to allow easy certificate issuance.
@@ -71,7 +70,7 @@ func NewCertManager(
 	}
 
 	// Instantiating a new certificate rotation mechanism will start a goroutine for certificate rotation.
-	rotor.New(c).Start(checkCertificateExpirationInterval)
+	rotor.New(c).Start(rotor.DefaultCheckInterval)
 
 	return c, nil
 }
@@ -9,6 +9,9 @@ import (
 )
 
 const (
+	// DefaultCheckInterval is the interval at which the certificates are checked for rotation
+	DefaultCheckInterval = 5 * time.Second
+
 	// How much earlier (before expiration) should a certificate be renewed
 	renewBeforeCertExpires = 30 * time.Second
 
@@ -28,15 +31,23 @@ func New(certManager certificate.Manager) *CertRotor {
 
 // Start starts a new facility for automatic certificate rotation.
 func (r CertRotor) Start(checkInterval time.Duration) {
+	go r.Run(checkInterval, nil)
+}
+
+// Run rotates the expiring certificates at the given check interval until the given stop channel is closed.
+func (r CertRotor) Run(checkInterval time.Duration, stop <-chan struct{}) {
 	// iterate over the list of certificates
 	// when a cert needs to be rotated - call RotateCertificate()
 	ticker := time.NewTicker(checkInterval)
-	go func() {
-		for {
-			r.checkAndRotate()
-			<-ticker.C
+	defer ticker.Stop()
+	for {
+		r.checkAndRotate()
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
 		}
-	}()
+	}
 }
 
 func (r *CertRotor) checkAndRotate() {
@@ -92,4 +92,23 @@ var _ = Describe("Test Rotor", func() {
 		})
 	})
 
+	Context("Testing stopping the certificate rotation", func() {
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetCertKeyBitSize().Return(2048).AnyTimes()
+
+		certManager := tresor.NewFakeCertManager(mockConfigurator)
+
+		It("returns when the stop channel is closed", func() {
+			stop := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				rotor.New(certManager).Run(10*time.Millisecond, stop)
+				close(done)
+			}()
+
+			close(stop)
+			Eventually(done).Should(BeClosed())
+		})
+	})
+
 })
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+// GetControllerDebugInfo queries the given path of the debug server of every running osm-controller replica
+// in the given namespace, and returns the responses keyed by the name of the replica's pod.
+// The debug server must be enabled in the MeshConfig.
+func GetControllerDebugInfo(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, localPort uint16, path string) (map[string][]byte, error) {
+	pods, err := listRunningControllerPods(clientSet, osmNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(map[string][]byte)
+	for _, pod := range pods {
+		if responses[pod.Name], err = queryControllerDebugServer(clientSet, config, osmNamespace, pod.Name, localPort, path); err != nil {
+			return nil, err
+		}
+	}
+	return responses, nil
+}
+
+// GetControllerReplicaDebugInfo queries the given path of the debug server of a single running osm-controller replica
+// in the given namespace, for the debug information that is the same on every replica.
+// The debug server must be enabled in the MeshConfig.
+func GetControllerReplicaDebugInfo(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, localPort uint16, path string) ([]byte, error) {
+	pods, err := listRunningControllerPods(clientSet, osmNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return queryControllerDebugServer(clientSet, config, osmNamespace, pods[0].Name, localPort, path)
+}
+
+// listRunningControllerPods returns the running osm-controller pods in the given namespace sorted by name, and an
+// error if there is none
+func listRunningControllerPods(clientSet kubernetes.Interface, osmNamespace string) ([]corev1.Pod, error) {
+	pods, err := clientSet.CoreV1().Pods(osmNamespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app=%s", constants.OSMControllerName),
+	})
+	if err != nil {
+		return nil, errors.Errorf("Error listing %s pods in namespace %s: %s", constants.OSMControllerName, osmNamespace, err)
+	}
+
+	var running []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning {
+			running = append(running, pod)
+		}
+	}
+	if len(running) == 0 {
+		return nil, errors.Errorf("No running %s pods found in namespace %s", constants.OSMControllerName, osmNamespace)
+	}
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].Name < running[j].Name
+	})
+	return running, nil
+}
+
+// queryControllerDebugServer returns the response of the debug server of the given osm-controller pod for the given path
+func queryControllerDebugServer(clientSet kubernetes.Interface, config *rest.Config, osmNamespace, podName string, localPort uint16, path string) ([]byte, error) {
+	dialer, err := k8s.DialerToPod(config, clientSet, podName, osmNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", localPort, constants.DebugPort))
+	if err != nil {
+		return nil, errors.Errorf("Error setting up port forwarding: %s", err)
+	}
+
+	var response []byte
+	err = portForwarder.Start(func(pf *k8s.PortForwarder) error {
+		defer pf.Stop()
+		url := fmt.Sprintf("http://localhost:%d%s", localPort, path)
+
+		// #nosec G107: Potential HTTP request made with variable url
+		resp, err := http.Get(url)
+		if err != nil {
+			return errors.Errorf("Error fetching url %s: %s", url, err)
+		}
+		defer resp.Body.Close() //nolint: errcheck,gosec
+
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("Error fetching url %s: %s", url, resp.Status)
+		}
+
+		response, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Errorf("Error rendering HTTP response: %s", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Errorf("Error querying the debug server of %s pod %s, ensure the debug server is enabled in the MeshConfig: %s",
+			constants.OSMControllerName, podName, err)
+	}
+	return response, nil
+}
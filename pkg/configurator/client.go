@@ -2,6 +2,7 @@ package configurator
 
 import (
 	"fmt"
+	"reflect"
 
 	"k8s.io/client-go/tools/cache"
 
@@ -152,6 +153,10 @@ func meshConfigUpdatedMessageHandler(psubMsg *events.PubSubMessage) {
 	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevSpec.Observability.Tracing.Address != newSpec.Observability.Tracing.Address)
 	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevSpec.Observability.Tracing.Endpoint != newSpec.Observability.Tracing.Endpoint)
 	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevSpec.Observability.Tracing.Port != newSpec.Observability.Tracing.Port)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevSpec.Observability.Tracing.Provider != newSpec.Observability.Tracing.Provider)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || !reflect.DeepEqual(prevSpec.Observability.Tracing.SamplingPercentage, newSpec.Observability.Tracing.SamplingPercentage)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || !reflect.DeepEqual(prevSpec.Observability.Tracing.CustomTags, newSpec.Observability.Tracing.CustomTags)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || !reflect.DeepEqual(prevSpec.Observability.AccessLog, newSpec.Observability.AccessLog)
 	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevSpec.Traffic.InboundExternalAuthorization.Enable != newSpec.Traffic.InboundExternalAuthorization.Enable)
 
 	// Do not trigger updates on the inner configuration changes of ExtAuthz if disabled,
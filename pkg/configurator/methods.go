@@ -12,6 +12,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/auth"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/identity"
 )
 
 const (
@@ -26,6 +27,18 @@ const (
 
 	// maxCertKeyBitSize is the maximum certificate key bit size
 	maxCertKeyBitSize = 4096
+
+	// defaultTracingSamplingPercentage is the default percentage of requests sampled for tracing
+	defaultTracingSamplingPercentage = 100.0
+
+	// defaultStaleSidecarRestartInterval is the default minimum interval between two automatic workload restarts
+	defaultStaleSidecarRestartInterval = 1 * time.Minute
+
+	// defaultConfigUpdateDebounceWindow is the default window over which configuration changes are coalesced
+	defaultConfigUpdateDebounceWindow = 3 * time.Second
+
+	// defaultConfigUpdateMaxDelay is the default maximum delay of a proxy update while configuration changes are coalesced
+	defaultConfigUpdateMaxDelay = 15 * time.Second
 )
 
 // The functions in this file implement the configurator.Configurator interface
@@ -108,6 +121,35 @@ func (c *Client) GetTracingEndpoint() string {
 	return constants.DefaultTracingEndpoint
 }
 
+// GetTracingProvider returns the tracing provider used to export spans to the collector, defaulting to
+// Zipkin if the provider is unspecified. An unsupported provider is returned as is for the caller to report.
+func (c *Client) GetTracingProvider() configv1alpha1.TracingProvider {
+	tracingProvider := c.getMeshConfig().Spec.Observability.Tracing.Provider
+	if tracingProvider != "" {
+		return tracingProvider
+	}
+	return configv1alpha1.TracingProviderZipkin
+}
+
+// GetTracingSamplingPercentage returns the percentage of requests that are sampled for tracing
+func (c *Client) GetTracingSamplingPercentage() float64 {
+	samplingPercentage := c.getMeshConfig().Spec.Observability.Tracing.SamplingPercentage
+	if samplingPercentage != nil {
+		return *samplingPercentage
+	}
+	return defaultTracingSamplingPercentage
+}
+
+// GetTracingCustomTags returns the custom tags added to the spans
+func (c *Client) GetTracingCustomTags() []configv1alpha1.TracingCustomTagSpec {
+	return c.getMeshConfig().Spec.Observability.Tracing.CustomTags
+}
+
+// GetAccessLogConfig returns the mesh-wide access logging configuration of the sidecars
+func (c *Client) GetAccessLogConfig() configv1alpha1.AccessLogSpec {
+	return c.getMeshConfig().Spec.Observability.AccessLog
+}
+
 // UseHTTPSIngress determines whether traffic between ingress and backend pods should use HTTPS protocol
 func (c *Client) UseHTTPSIngress() bool {
 	return c.getMeshConfig().Spec.Traffic.UseHTTPSIngress
@@ -177,6 +219,15 @@ func (c *Client) GetCertKeyBitSize() int {
 	return bitSize
 }
 
+// GetTrustDomain returns the trust domain of the service identities in this cluster
+func (c *Client) GetTrustDomain() string {
+	trustDomain := c.getMeshConfig().Spec.Certificate.TrustDomain
+	if trustDomain == "" {
+		return identity.ClusterLocalTrustDomain
+	}
+	return trustDomain
+}
+
 // GetOutboundIPRangeExclusionList returns the list of IP ranges of the form x.x.x.x/y to exclude from outbound sidecar interception
 func (c *Client) GetOutboundIPRangeExclusionList() []string {
 	return c.getMeshConfig().Spec.Traffic.OutboundIPRangeExclusionList
@@ -209,6 +260,56 @@ func (c *Client) GetConfigResyncInterval() time.Duration {
 	return duration
 }
 
+// IsStaleSidecarRestartEnabled returns whether workloads with out of date sidecars should be restarted automatically
+func (c *Client) IsStaleSidecarRestartEnabled() bool {
+	return c.getMeshConfig().Spec.Sidecar.EnableStaleSidecarRestart
+}
+
+// GetStaleSidecarRestartInterval returns the minimum interval between two automatic workload restarts,
+// and a default in case of an invalid or non-positive duration
+func (c *Client) GetStaleSidecarRestartInterval() time.Duration {
+	durationStr := c.getMeshConfig().Spec.Sidecar.StaleSidecarRestartInterval
+	interval, err := time.ParseDuration(durationStr)
+	if err != nil || interval <= 0 {
+		log.Debug().Err(err).Msgf("Invalid stale sidecar restart interval %q, defaulting to %s", durationStr, defaultStaleSidecarRestartInterval)
+		return defaultStaleSidecarRestartInterval
+	}
+	return interval
+}
+
+// GetWASMImageRegistries returns the registries the WASM modules of EnvoyFilterExtension resources may be pulled from
+func (c *Client) GetWASMImageRegistries() []string {
+	return c.getMeshConfig().Spec.Sidecar.WASMImageRegistries
+}
+
+// GetConfigUpdateDebounceWindow returns the window over which configuration changes are coalesced before
+// the proxies are updated, and a default in case of an invalid or non-positive duration
+func (c *Client) GetConfigUpdateDebounceWindow() time.Duration {
+	durationStr := c.getMeshConfig().Spec.Sidecar.ConfigUpdateDebounceWindow
+	window, err := time.ParseDuration(durationStr)
+	if err != nil || window <= 0 {
+		log.Debug().Err(err).Msgf("Invalid config update debounce window %q, defaulting to %s", durationStr, defaultConfigUpdateDebounceWindow)
+		return defaultConfigUpdateDebounceWindow
+	}
+	return window
+}
+
+// GetConfigUpdateMaxDelay returns the maximum delay of a proxy update while configuration changes keep being
+// coalesced, and a default in case of an invalid or non-positive duration. The max delay is never shorter
+// than the debounce window.
+func (c *Client) GetConfigUpdateMaxDelay() time.Duration {
+	durationStr := c.getMeshConfig().Spec.Sidecar.ConfigUpdateMaxDelay
+	maxDelay, err := time.ParseDuration(durationStr)
+	if err != nil || maxDelay <= 0 {
+		log.Debug().Err(err).Msgf("Invalid config update max delay %q, defaulting to %s", durationStr, defaultConfigUpdateMaxDelay)
+		maxDelay = defaultConfigUpdateMaxDelay
+	}
+	if window := c.GetConfigUpdateDebounceWindow(); maxDelay < window {
+		return window
+	}
+	return maxDelay
+}
+
 // GetProxyResources returns the `Resources` configured for proxies, if any
 func (c *Client) GetProxyResources() corev1.ResourceRequirements {
 	return c.getMeshConfig().Spec.Sidecar.Resources
@@ -180,6 +180,9 @@ func TestCreateUpdateConfig(t *testing.T) {
 				assert.Equal("myjaeger", cfg.GetTracingHost())
 				assert.Equal(uint32(12121), cfg.GetTracingPort())
 				assert.Equal("/my/endpoint", cfg.GetTracingEndpoint())
+				assert.Equal(v1alpha1.TracingProviderZipkin, cfg.GetTracingProvider())
+				assert.Equal(100.0, cfg.GetTracingSamplingPercentage())
+				assert.Nil(cfg.GetTracingCustomTags())
 			},
 			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
 				Observability: v1alpha1.ObservabilitySpec{
@@ -195,6 +198,74 @@ func TestCreateUpdateConfig(t *testing.T) {
 				assert.False(cfg.IsTracingEnabled())
 			},
 		},
+		{
+			name: "GetTracingProvider",
+			initialMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Observability: v1alpha1.ObservabilitySpec{
+					Tracing: v1alpha1.TracingSpec{
+						Enable:             true,
+						Provider:           v1alpha1.TracingProviderOpenTelemetry,
+						SamplingPercentage: func() *float64 { p := 12.5; return &p }(),
+						CustomTags: []v1alpha1.TracingCustomTagSpec{
+							{Tag: "cluster", Literal: "east"},
+						},
+					},
+				},
+			},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(v1alpha1.TracingProviderOpenTelemetry, cfg.GetTracingProvider())
+				assert.Equal(12.5, cfg.GetTracingSamplingPercentage())
+				assert.Equal([]v1alpha1.TracingCustomTagSpec{{Tag: "cluster", Literal: "east"}}, cfg.GetTracingCustomTags())
+			},
+			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Observability: v1alpha1.ObservabilitySpec{
+					Tracing: v1alpha1.TracingSpec{
+						Enable:   true,
+						Provider: v1alpha1.TracingProviderDatadog,
+					},
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(v1alpha1.TracingProviderDatadog, cfg.GetTracingProvider())
+				assert.Equal(100.0, cfg.GetTracingSamplingPercentage())
+			},
+		},
+		{
+			name: "GetAccessLogConfig",
+			initialMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Observability: v1alpha1.ObservabilitySpec{
+					AccessLog: v1alpha1.AccessLogSpec{
+						Format: v1alpha1.AccessLogFormatText,
+						Filter: v1alpha1.AccessLogFilterSpec{
+							MinStatusCode: 400,
+						},
+					},
+				},
+			},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(v1alpha1.AccessLogSpec{
+					Format: v1alpha1.AccessLogFormatText,
+					Filter: v1alpha1.AccessLogFilterSpec{
+						MinStatusCode: 400,
+					},
+				}, cfg.GetAccessLogConfig())
+			},
+			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Observability: v1alpha1.ObservabilitySpec{
+					AccessLog: v1alpha1.AccessLogSpec{
+						Sink: v1alpha1.AccessLogSinkSpec{
+							Type:    v1alpha1.AccessLogSinkGRPC,
+							Address: "als.observability.svc.cluster.local",
+							Port:    9001,
+						},
+					},
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(v1alpha1.AccessLogSinkGRPC, cfg.GetAccessLogConfig().Sink.Type)
+				assert.Equal(uint32(0), cfg.GetAccessLogConfig().Filter.MinStatusCode)
+			},
+		},
 		{
 			name: "UseHTTPSIngress",
 			initialMeshConfigData: &v1alpha1.MeshConfigSpec{
@@ -233,7 +304,7 @@ func TestCreateUpdateConfig(t *testing.T) {
 			name:                  "GetEnvoyImage",
 			initialMeshConfigData: &v1alpha1.MeshConfigSpec{},
 			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
-				assert.Equal("envoyproxy/envoy-alpine:v1.18.3", cfg.GetEnvoyImage())
+				assert.Equal("envoyproxy/envoy-distroless:v1.24.0", cfg.GetEnvoyImage())
 			},
 			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
 				Sidecar: v1alpha1.SidecarSpec{
@@ -297,6 +368,21 @@ func TestCreateUpdateConfig(t *testing.T) {
 				assert.Equal(defaultCertKeyBitSize, cfg.GetCertKeyBitSize())
 			},
 		},
+		{
+			name:                  "GetTrustDomain",
+			initialMeshConfigData: &v1alpha1.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal("cluster.local", cfg.GetTrustDomain())
+			},
+			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Certificate: v1alpha1.CertificateSpec{
+					TrustDomain: "east.cluster.local",
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal("east.cluster.local", cfg.GetTrustDomain())
+			},
+		},
 		{
 			name:                  "GetOutboundIPRangeExclusionList",
 			initialMeshConfigData: &v1alpha1.MeshConfigSpec{},
@@ -395,6 +481,83 @@ func TestCreateUpdateConfig(t *testing.T) {
 				assert.Equal(interval, time.Duration(0))
 			},
 		},
+		{
+			name:                  "IsStaleSidecarRestartEnabled",
+			initialMeshConfigData: &v1alpha1.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.False(cfg.IsStaleSidecarRestartEnabled())
+			},
+			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Sidecar: v1alpha1.SidecarSpec{
+					EnableStaleSidecarRestart: true,
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.True(cfg.IsStaleSidecarRestartEnabled())
+			},
+		},
+		{
+			name:                  "GetStaleSidecarRestartInterval",
+			initialMeshConfigData: &v1alpha1.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(defaultStaleSidecarRestartInterval, cfg.GetStaleSidecarRestartInterval())
+			},
+			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Sidecar: v1alpha1.SidecarSpec{
+					StaleSidecarRestartInterval: "5m",
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(5*time.Minute, cfg.GetStaleSidecarRestartInterval())
+			},
+		},
+		{
+			name:                  "GetWASMImageRegistries",
+			initialMeshConfigData: &v1alpha1.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Nil(cfg.GetWASMImageRegistries())
+			},
+			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Sidecar: v1alpha1.SidecarSpec{
+					WASMImageRegistries: []string{"ghcr.io", "registry.example.com:5000"},
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal([]string{"ghcr.io", "registry.example.com:5000"}, cfg.GetWASMImageRegistries())
+			},
+		},
+		{
+			name:                  "GetConfigUpdateDebounceWindow",
+			initialMeshConfigData: &v1alpha1.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(defaultConfigUpdateDebounceWindow, cfg.GetConfigUpdateDebounceWindow())
+			},
+			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Sidecar: v1alpha1.SidecarSpec{
+					ConfigUpdateDebounceWindow: "500ms",
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(500*time.Millisecond, cfg.GetConfigUpdateDebounceWindow())
+			},
+		},
+		{
+			name:                  "GetConfigUpdateMaxDelay",
+			initialMeshConfigData: &v1alpha1.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(defaultConfigUpdateMaxDelay, cfg.GetConfigUpdateMaxDelay())
+			},
+			updatedMeshConfigData: &v1alpha1.MeshConfigSpec{
+				Sidecar: v1alpha1.SidecarSpec{
+					ConfigUpdateDebounceWindow: "10s",
+					ConfigUpdateMaxDelay:       "5s",
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				// The max delay is never shorter than the debounce window
+				assert.Equal(10*time.Second, cfg.GetConfigUpdateMaxDelay())
+			},
+		},
 		{
 			name:                  "GetMaxDataplaneConnections",
 			initialMeshConfigData: &v1alpha1.MeshConfigSpec{},
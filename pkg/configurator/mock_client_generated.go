@@ -37,6 +37,20 @@ func (m *MockConfigurator) EXPECT() *MockConfiguratorMockRecorder {
 	return m.recorder
 }
 
+// GetAccessLogConfig mocks base method
+func (m *MockConfigurator) GetAccessLogConfig() v1alpha1.AccessLogSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessLogConfig")
+	ret0, _ := ret[0].(v1alpha1.AccessLogSpec)
+	return ret0
+}
+
+// GetAccessLogConfig indicates an expected call of GetAccessLogConfig
+func (mr *MockConfiguratorMockRecorder) GetAccessLogConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessLogConfig", reflect.TypeOf((*MockConfigurator)(nil).GetAccessLogConfig))
+}
+
 // GetCertKeyBitSize mocks base method
 func (m *MockConfigurator) GetCertKeyBitSize() int {
 	m.ctrl.T.Helper()
@@ -65,6 +79,34 @@ func (mr *MockConfiguratorMockRecorder) GetConfigResyncInterval() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigResyncInterval", reflect.TypeOf((*MockConfigurator)(nil).GetConfigResyncInterval))
 }
 
+// GetConfigUpdateDebounceWindow mocks base method
+func (m *MockConfigurator) GetConfigUpdateDebounceWindow() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigUpdateDebounceWindow")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetConfigUpdateDebounceWindow indicates an expected call of GetConfigUpdateDebounceWindow
+func (mr *MockConfiguratorMockRecorder) GetConfigUpdateDebounceWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigUpdateDebounceWindow", reflect.TypeOf((*MockConfigurator)(nil).GetConfigUpdateDebounceWindow))
+}
+
+// GetConfigUpdateMaxDelay mocks base method
+func (m *MockConfigurator) GetConfigUpdateMaxDelay() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigUpdateMaxDelay")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetConfigUpdateMaxDelay indicates an expected call of GetConfigUpdateMaxDelay
+func (mr *MockConfiguratorMockRecorder) GetConfigUpdateMaxDelay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigUpdateMaxDelay", reflect.TypeOf((*MockConfigurator)(nil).GetConfigUpdateMaxDelay))
+}
+
 // GetEnvoyImage mocks base method
 func (m *MockConfigurator) GetEnvoyImage() string {
 	m.ctrl.T.Helper()
@@ -248,6 +290,20 @@ func (mr *MockConfiguratorMockRecorder) GetOutboundIPRangeExclusionList() *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboundIPRangeExclusionList", reflect.TypeOf((*MockConfigurator)(nil).GetOutboundIPRangeExclusionList))
 }
 
+// GetWASMImageRegistries mocks base method
+func (m *MockConfigurator) GetWASMImageRegistries() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWASMImageRegistries")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetWASMImageRegistries indicates an expected call of GetWASMImageRegistries
+func (mr *MockConfiguratorMockRecorder) GetWASMImageRegistries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWASMImageRegistries", reflect.TypeOf((*MockConfigurator)(nil).GetWASMImageRegistries))
+}
+
 // GetOutboundPortExclusionList mocks base method
 func (m *MockConfigurator) GetOutboundPortExclusionList() []int {
 	m.ctrl.T.Helper()
@@ -290,6 +346,34 @@ func (mr *MockConfiguratorMockRecorder) GetServiceCertValidityPeriod() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceCertValidityPeriod", reflect.TypeOf((*MockConfigurator)(nil).GetServiceCertValidityPeriod))
 }
 
+// GetStaleSidecarRestartInterval mocks base method
+func (m *MockConfigurator) GetStaleSidecarRestartInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStaleSidecarRestartInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetStaleSidecarRestartInterval indicates an expected call of GetStaleSidecarRestartInterval
+func (mr *MockConfiguratorMockRecorder) GetStaleSidecarRestartInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStaleSidecarRestartInterval", reflect.TypeOf((*MockConfigurator)(nil).GetStaleSidecarRestartInterval))
+}
+
+// GetTracingCustomTags mocks base method
+func (m *MockConfigurator) GetTracingCustomTags() []v1alpha1.TracingCustomTagSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracingCustomTags")
+	ret0, _ := ret[0].([]v1alpha1.TracingCustomTagSpec)
+	return ret0
+}
+
+// GetTracingCustomTags indicates an expected call of GetTracingCustomTags
+func (mr *MockConfiguratorMockRecorder) GetTracingCustomTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingCustomTags", reflect.TypeOf((*MockConfigurator)(nil).GetTracingCustomTags))
+}
+
 // GetTracingEndpoint mocks base method
 func (m *MockConfigurator) GetTracingEndpoint() string {
 	m.ctrl.T.Helper()
@@ -332,6 +416,48 @@ func (mr *MockConfiguratorMockRecorder) GetTracingPort() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingPort", reflect.TypeOf((*MockConfigurator)(nil).GetTracingPort))
 }
 
+// GetTracingProvider mocks base method
+func (m *MockConfigurator) GetTracingProvider() v1alpha1.TracingProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracingProvider")
+	ret0, _ := ret[0].(v1alpha1.TracingProvider)
+	return ret0
+}
+
+// GetTracingProvider indicates an expected call of GetTracingProvider
+func (mr *MockConfiguratorMockRecorder) GetTracingProvider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingProvider", reflect.TypeOf((*MockConfigurator)(nil).GetTracingProvider))
+}
+
+// GetTracingSamplingPercentage mocks base method
+func (m *MockConfigurator) GetTracingSamplingPercentage() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracingSamplingPercentage")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetTracingSamplingPercentage indicates an expected call of GetTracingSamplingPercentage
+func (mr *MockConfiguratorMockRecorder) GetTracingSamplingPercentage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingSamplingPercentage", reflect.TypeOf((*MockConfigurator)(nil).GetTracingSamplingPercentage))
+}
+
+// GetTrustDomain mocks base method
+func (m *MockConfigurator) GetTrustDomain() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustDomain")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTrustDomain indicates an expected call of GetTrustDomain
+func (mr *MockConfiguratorMockRecorder) GetTrustDomain() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustDomain", reflect.TypeOf((*MockConfigurator)(nil).GetTrustDomain))
+}
+
 // IsDebugServerEnabled mocks base method
 func (m *MockConfigurator) IsDebugServerEnabled() bool {
 	m.ctrl.T.Helper()
@@ -388,6 +514,20 @@ func (mr *MockConfiguratorMockRecorder) IsPrivilegedInitContainer() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrivilegedInitContainer", reflect.TypeOf((*MockConfigurator)(nil).IsPrivilegedInitContainer))
 }
 
+// IsStaleSidecarRestartEnabled mocks base method
+func (m *MockConfigurator) IsStaleSidecarRestartEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsStaleSidecarRestartEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsStaleSidecarRestartEnabled indicates an expected call of IsStaleSidecarRestartEnabled
+func (mr *MockConfiguratorMockRecorder) IsStaleSidecarRestartEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStaleSidecarRestartEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsStaleSidecarRestartEnabled))
+}
+
 // IsTracingEnabled mocks base method
 func (m *MockConfigurator) IsTracingEnabled() bool {
 	m.ctrl.T.Helper()
@@ -58,6 +58,18 @@ type Configurator interface {
 	// GetTracingEndpoint returns the collector endpoint
 	GetTracingEndpoint() string
 
+	// GetTracingProvider returns the tracing provider used to export spans to the collector
+	GetTracingProvider() configv1alpha1.TracingProvider
+
+	// GetTracingSamplingPercentage returns the percentage of requests that are sampled for tracing
+	GetTracingSamplingPercentage() float64
+
+	// GetTracingCustomTags returns the custom tags added to the spans
+	GetTracingCustomTags() []configv1alpha1.TracingCustomTagSpec
+
+	// GetAccessLogConfig returns the mesh-wide access logging configuration of the sidecars
+	GetAccessLogConfig() configv1alpha1.AccessLogSpec
+
 	// UseHTTPSIngress determines whether protocol used for traffic from ingress to backend pods should be HTTPS.
 	UseHTTPSIngress() bool
 
@@ -85,6 +97,9 @@ type Configurator interface {
 	// GetCertKeyBitSize returns the certificate key bit size
 	GetCertKeyBitSize() int
 
+	// GetTrustDomain returns the trust domain of the service identities in this cluster
+	GetTrustDomain() string
+
 	// GetOutboundIPRangeExclusionList returns the list of IP ranges of the form x.x.x.x/y to exclude from outbound sidecar interception
 	GetOutboundIPRangeExclusionList() []string
 
@@ -101,6 +116,21 @@ type Configurator interface {
 	// If error or non-parsable value, returns 0 duration
 	GetConfigResyncInterval() time.Duration
 
+	// IsStaleSidecarRestartEnabled returns whether workloads with out of date sidecars should be restarted automatically
+	IsStaleSidecarRestartEnabled() bool
+
+	// GetStaleSidecarRestartInterval returns the minimum interval between two automatic workload restarts
+	GetStaleSidecarRestartInterval() time.Duration
+
+	// GetWASMImageRegistries returns the registries the WASM modules of EnvoyFilterExtension resources may be pulled from
+	GetWASMImageRegistries() []string
+
+	// GetConfigUpdateDebounceWindow returns the window over which configuration changes are coalesced before the proxies are updated
+	GetConfigUpdateDebounceWindow() time.Duration
+
+	// GetConfigUpdateMaxDelay returns the maximum delay of a proxy update while configuration changes keep being coalesced
+	GetConfigUpdateMaxDelay() time.Duration
+
 	// GetProxyResources returns the `Resources` configured for proxies, if any
 	GetProxyResources() corev1.ResourceRequirements
 
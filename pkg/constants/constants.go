@@ -46,6 +46,9 @@ const (
 	// EnvoyTracingCluster is the default name to refer to the tracing cluster.
 	EnvoyTracingCluster = "envoy-tracing-cluster"
 
+	// EnvoyAccessLogCluster is the name of the cluster used to send access logs to a gRPC access log collector.
+	EnvoyAccessLogCluster = "envoy-access-log-cluster"
+
 	// DefaultTracingEndpoint is the default endpoint route.
 	DefaultTracingEndpoint = "/api/v2/spans"
 
@@ -62,12 +65,12 @@ const (
 	DefaultOSMLogLevel = "info"
 
 	// DefaultEnvoyImage is the default envoy proxy sidecar image if not defined in the osm MeshConfig
-	DefaultEnvoyImage = "envoyproxy/envoy-alpine:v1.18.3"
+	DefaultEnvoyImage = "envoyproxy/envoy-distroless:v1.24.0"
 
 	// DefaultEnvoyWindowsImage is the default envoy proxy windows sidecar image if not defined in the osm MeshConfig
 	// TODO(#3864): This should be updated to the nanoserver based image when it becomes available
 	// See https://github.com/envoyproxy/envoy/issues/16759
-	DefaultEnvoyWindowsImage = "envoyproxy/envoy-windows:v1.18.3"
+	DefaultEnvoyWindowsImage = "envoyproxy/envoy-windows:v1.24.0"
 
 	// DefaultInitContainerImage is the default init container image if not defined in the osm MeshConfig
 	DefaultInitContainerImage = "openservicemesh/init:v0.9.1"
@@ -90,9 +93,28 @@ const (
 	// OSMControllerName is the name of the OSM Controller (formerly ADS service).
 	OSMControllerName = "osm-controller"
 
+	// OSMControllerLeaseName is the name of the Lease used to elect the osm-controller replica performing singleton work
+	OSMControllerLeaseName = "osm-controller-leader"
+
+	// OSMControllerHeadlessServiceName is the name of the headless Service resolving to the ready osm-controller replicas,
+	// used by proxies to connect to the replica serving their shard when ADS sharding is enabled
+	OSMControllerHeadlessServiceName = "osm-controller-headless"
+
 	// ADSServerPort is the port on which the Aggregated Discovery Service (ADS) listens for new gRPC connections from Envoy proxies
 	ADSServerPort = 15128
 
+	// EgressGatewayName is the name of the egress gateway's Service, ServiceAccount and Deployment
+	EgressGatewayName = "osm-egress-gateway"
+
+	// EgressGatewayPort is the port on which the egress gateway accepts mTLS connections from sidecars
+	EgressGatewayPort = 15080
+
+	// EgressGatewayPortHeader is the HTTP header set by sidecars on requests routed through the egress gateway,
+	// indicating the destination port of the original request. Sidecars overwrite any value set by the application.
+	// The egress gateway only routes requests carrying the header, matches its routes on the header's value and
+	// removes it before forwarding the request to the external host.
+	EgressGatewayPortHeader = "x-osm-egress-port"
+
 	// PrometheusScrapePath is the path for prometheus to scrap envoy metrics from
 	PrometheusScrapePath = "/stats/prometheus"
 
@@ -173,12 +195,36 @@ const (
 
 	// MetricsAnnotation is the annotation used for enabling/disabling metrics
 	MetricsAnnotation = "openservicemesh.io/metrics"
+
+	// SidecarVersionAnnotation is the annotation applied to pods with the version of the sidecar injected into the pod
+	SidecarVersionAnnotation = "openservicemesh.io/sidecar-version"
+
+	// RestartedAtAnnotation is the pod template annotation used to trigger a rolling restart of a workload,
+	// as done by 'kubectl rollout restart'
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+	// AccessLogAnnotation is the namespace annotation used to override the mesh-wide access logging configuration
+	// for the sidecars in the namespace. Its value is a JSON object with the fields of MeshConfig's 'spec.observability.accessLog'.
+	AccessLogAnnotation = "openservicemesh.io/access-log"
+
+	// SourceClustersAnnotation is the TrafficTarget annotation listing the comma separated clusters its sources
+	// belong to. The LocalClusterName refers to the local cluster. Sources belong to the local cluster by default.
+	SourceClustersAnnotation = "openservicemesh.io/source-clusters"
 )
 
+// LocalClusterName is the name used to refer to the local cluster in multicluster configurations
+const LocalClusterName = "local"
+
 // Labels used by the control plane
 const (
 	// IgnoreLabel is the label used to ignore a resource
 	IgnoreLabel = "openservicemesh.io/ignore"
+
+	// MulticlusterExportLabel is the label used to export a service in a remote cluster to the other clusters
+	MulticlusterExportLabel = "openservicemesh.io/multicluster-export"
+
+	// MulticlusterDiscoveredLabel is the label set on MultiClusterService resources managed by remote cluster discovery
+	MulticlusterDiscoveredLabel = "openservicemesh.io/multicluster-discovered"
 )
 
 // Annotations used for Metrics
@@ -16,6 +16,8 @@ func serveMultiClusterServiceConversion(w http.ResponseWriter, r *http.Request)
 
 // convertMultiClusterService contains the business logic to convert multiclusterservices.config.openservicemesh.io CRD
 // Example implementation reference : https://github.com/kubernetes/kubernetes/blob/release-1.21/test/images/agnhost/crd-conversion-webhook/converter/example_converter.go
+// The hostname form of the cluster addresses was added to the v1alpha1 version, which is the only version served, so
+// the addresses do not need to be converted.
 func convertMultiClusterService(Object *unstructured.Unstructured, toVersion string) (*unstructured.Unstructured, metav1.Status) {
 	convertedObject := Object.DeepCopy()
 	fromVersion := Object.GetAPIVersion()
@@ -32,21 +32,27 @@ const (
 	httpRouteGroupConverterPath        = "/convert/httproutegroup"
 	meshConfigConverterPath            = "/convert/meshconfig"
 	multiclusterServiceConverterPath   = "/convert/multiclusterservice"
+	remoteClusterConverterPath         = "/convert/remotecluster"
 	egressPolicyConverterPath          = "/convert/egresspolicy"
 	trafficSplitConverterPath          = "/convert/trafficsplit"
 	tcpRoutesConverterPath             = "/convert/tcproutes"
 	ingressBackendsPolicyConverterPath = "/convert/ingressbackendspolicy"
+	authorizationPolicyConverterPath   = "/convert/authorizationpolicy"
+	envoyFilterExtensionConverterPath  = "/convert/envoyfilterextension"
 )
 
 var crdConversionWebhookConfiguration = map[string]string{
-	"traffictargets.access.smi-spec.io":              trafficAccessConverterPath,
-	"httproutegroups.specs.smi-spec.io":              httpRouteGroupConverterPath,
-	"meshconfigs.config.openservicemesh.io":          meshConfigConverterPath,
-	"multiclusterservices.config.openservicemesh.io": multiclusterServiceConverterPath,
-	"egresses.policy.openservicemesh.io":             egressPolicyConverterPath,
-	"trafficsplits.split.smi-spec.io":                trafficSplitConverterPath,
-	"tcproutes.specs.smi-spec.io":                    tcpRoutesConverterPath,
-	"ingressbackends.policy.openservicemesh.io":      ingressBackendsPolicyConverterPath,
+	"traffictargets.access.smi-spec.io":               trafficAccessConverterPath,
+	"httproutegroups.specs.smi-spec.io":               httpRouteGroupConverterPath,
+	"meshconfigs.config.openservicemesh.io":           meshConfigConverterPath,
+	"multiclusterservices.config.openservicemesh.io":  multiclusterServiceConverterPath,
+	"remoteclusters.config.openservicemesh.io":        remoteClusterConverterPath,
+	"egresses.policy.openservicemesh.io":              egressPolicyConverterPath,
+	"trafficsplits.split.smi-spec.io":                 trafficSplitConverterPath,
+	"tcproutes.specs.smi-spec.io":                     tcpRoutesConverterPath,
+	"ingressbackends.policy.openservicemesh.io":       ingressBackendsPolicyConverterPath,
+	"authorizationpolicies.policy.openservicemesh.io": authorizationPolicyConverterPath,
+	"envoyfilterextensions.policy.openservicemesh.io": envoyFilterExtensionConverterPath,
 }
 
 var conversionReviewVersions = []string{"v1beta1", "v1"}
@@ -94,10 +100,13 @@ func (crdWh *crdConversionWebhook) run(stop <-chan struct{}) {
 	webhookMux.HandleFunc(trafficAccessConverterPath, serveTrafficAccessConversion)
 	webhookMux.HandleFunc(httpRouteGroupConverterPath, serveHTTPRouteGroupConversion)
 	webhookMux.HandleFunc(multiclusterServiceConverterPath, serveMultiClusterServiceConversion)
+	webhookMux.HandleFunc(remoteClusterConverterPath, serveRemoteClusterConversion)
 	webhookMux.HandleFunc(egressPolicyConverterPath, serveEgressPolicyConversion)
 	webhookMux.HandleFunc(trafficSplitConverterPath, serveTrafficSplitConversion)
 	webhookMux.HandleFunc(tcpRoutesConverterPath, serveTCPRouteConversion)
 	webhookMux.HandleFunc(ingressBackendsPolicyConverterPath, serveIngressBackendsPolicyConversion)
+	webhookMux.HandleFunc(authorizationPolicyConverterPath, serveAuthorizationPolicyConversion)
+	webhookMux.HandleFunc(envoyFilterExtensionConverterPath, serveEnvoyFilterExtensionConversion)
 
 	webhookServer := &http.Server{
 		Addr:    fmt.Sprintf(":%d", crdWh.config.ListenPort),
@@ -0,0 +1,325 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	graphFormatQueryKey = "format"
+	graphFormatJSON     = "json"
+	graphFormatDOT      = "dot"
+
+	// httpRouteGroupKind is the kind of the HTTP route rules in an SMI TrafficTarget policy
+	httpRouteGroupKind = "HTTPRouteGroup"
+)
+
+// serviceGraph is the graph of the traffic allowed between the service identities of the mesh
+type serviceGraph struct {
+	// PermissiveTrafficPolicyMode is true when all traffic is allowed regardless of the SMI policies the graph is built from
+	PermissiveTrafficPolicyMode bool `json:"permissiveTrafficPolicyMode"`
+
+	Nodes []graphNode `json:"nodes"`
+	Edges []graphEdge `json:"edges"`
+}
+
+// graphNode is a service identity, of the form <namespace>/<service account>, and its services
+type graphNode struct {
+	Identity string         `json:"identity"`
+	Services []graphService `json:"services,omitempty"`
+}
+
+type graphService struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Ports maps the ports of the service to their application protocol
+	Ports map[uint32]string `json:"ports,omitempty"`
+}
+
+// graphEdge is the traffic allowed from a source to a destination service identity
+type graphEdge struct {
+	Source         string           `json:"source"`
+	Destination    string           `json:"destination"`
+	TrafficTargets []string         `json:"trafficTargets,omitempty"`
+	Protocols      []string         `json:"protocols,omitempty"`
+	HTTPRoutes     []graphHTTPRoute `json:"httpRoutes,omitempty"`
+	TCPPorts       []int            `json:"tcpPorts,omitempty"`
+	Splits         []graphSplit     `json:"splits,omitempty"`
+}
+
+type graphHTTPRoute struct {
+	RouteGroup string   `json:"routeGroup"`
+	Match      string   `json:"match"`
+	PathRegex  string   `json:"pathRegex,omitempty"`
+	Methods    []string `json:"methods,omitempty"`
+}
+
+// graphSplit is the weight of a destination service among the backends of a TrafficSplit
+type graphSplit struct {
+	Service string `json:"service"`
+	Backend string `json:"backend"`
+	Weight  int    `json:"weight"`
+}
+
+func (ds DebugConfig) getGraphHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := graphFormatJSON
+		if r != nil && r.URL.Query().Get(graphFormatQueryKey) != "" {
+			format = r.URL.Query().Get(graphFormatQueryKey)
+		}
+
+		switch format {
+		case graphFormatJSON:
+			jsonGraph, err := json.Marshal(ds.buildServiceGraph())
+			if err != nil {
+				log.Error().Err(err).Msg("Error marshalling service graph")
+				http.Error(w, fmt.Sprintf("Error marshalling service graph: %s", err), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, string(jsonGraph))
+
+		case graphFormatDOT:
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			writeDOTGraph(w, ds.buildServiceGraph())
+
+		default:
+			http.Error(w, fmt.Sprintf("Invalid format %q, expected %s or %s", format, graphFormatJSON, graphFormatDOT), http.StatusBadRequest)
+		}
+	})
+}
+
+// buildServiceGraph returns the graph of the traffic allowed by the SMI TrafficTarget policies between the service accounts of the mesh
+func (ds DebugConfig) buildServiceGraph() serviceGraph {
+	trafficSplits, serviceAccounts, routeGroups, trafficTargets := ds.meshCatalogDebugger.ListSMIPolicies()
+	trustDomain := ds.configurator.GetTrustDomain()
+
+	graph := serviceGraph{
+		PermissiveTrafficPolicyMode: ds.configurator.IsPermissiveTrafficPolicyMode(),
+		Nodes:                       []graphNode{},
+		Edges:                       []graphEdge{},
+	}
+
+	trafficTargetsByName := make(map[string]*access.TrafficTarget)
+	for _, t := range trafficTargets {
+		trafficTargetsByName[fmt.Sprintf("%s/%s", t.Namespace, t.Name)] = t
+	}
+	routeGroupsByName := make(map[string]*spec.HTTPRouteGroup)
+	for _, rg := range routeGroups {
+		routeGroupsByName[fmt.Sprintf("%s/%s", rg.Namespace, rg.Name)] = rg
+	}
+
+	nodes := make(map[identity.K8sServiceAccount]*graphNode)
+	addNode := func(svcAccount identity.K8sServiceAccount) *graphNode {
+		if node, ok := nodes[svcAccount]; ok {
+			return node
+		}
+		node := &graphNode{Identity: svcAccount.String()}
+		for _, svc := range ds.meshCatalogDebugger.ListServicesForServiceIdentity(svcAccount.ToServiceIdentity(trustDomain)) {
+			ports, err := ds.meshCatalogDebugger.GetPortToProtocolMappingForService(svc)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error getting the protocols of the ports of service %s", svc)
+			}
+			node.Services = append(node.Services, graphService{Name: svc.Name, Namespace: svc.Namespace, Ports: ports})
+		}
+		sort.Slice(node.Services, func(i, j int) bool {
+			if node.Services[i].Namespace != node.Services[j].Namespace {
+				return node.Services[i].Namespace < node.Services[j].Namespace
+			}
+			return node.Services[i].Name < node.Services[j].Name
+		})
+		nodes[svcAccount] = node
+		return node
+	}
+
+	// The service accounts are listed for every TrafficTarget they are a source or destination of, so they may be listed several times
+	sources := make(map[identity.K8sServiceAccount]bool)
+	inboundTrafficTargets := make(map[identity.K8sServiceAccount][]trafficpolicy.TrafficTargetWithRoutes)
+	for _, source := range serviceAccounts {
+		if sources[source] {
+			continue
+		}
+		sources[source] = true
+		addNode(source)
+
+		upstreams, err := ds.meshCatalogDebugger.ListOutboundServiceIdentities(source.ToServiceIdentity(trustDomain))
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing the outbound service identities of %s", source)
+			continue
+		}
+
+		for _, upstream := range upstreams {
+			destination := upstream.ToK8sServiceAccount()
+			destinationNode := addNode(destination)
+
+			if _, ok := inboundTrafficTargets[destination]; !ok {
+				targets, err := ds.meshCatalogDebugger.ListInboundTrafficTargetsWithRoutes(upstream)
+				if err != nil {
+					log.Error().Err(err).Msgf("Error listing the inbound traffic targets of %s", destination)
+				}
+				inboundTrafficTargets[destination] = targets
+			}
+
+			edge := graphEdge{
+				Source:      source.String(),
+				Destination: destination.String(),
+			}
+
+			for _, target := range inboundTrafficTargets[destination] {
+				if !hasSource(target, source) {
+					continue
+				}
+				edge.TrafficTargets = append(edge.TrafficTargets, target.Name)
+				for _, tcpRouteMatch := range target.TCPRouteMatches {
+					edge.TCPPorts = append(edge.TCPPorts, tcpRouteMatch.Ports...)
+				}
+				if t, ok := trafficTargetsByName[target.Name]; ok {
+					edge.HTTPRoutes = append(edge.HTTPRoutes, getGraphHTTPRoutes(t, routeGroupsByName)...)
+				}
+			}
+
+			protocols := make(map[string]bool)
+			for _, svc := range destinationNode.Services {
+				for _, protocol := range svc.Ports {
+					protocols[protocol] = true
+				}
+				for _, split := range trafficSplits {
+					if split.Namespace != svc.Namespace {
+						continue
+					}
+					for _, backend := range split.Spec.Backends {
+						if backend.Service == svc.Name {
+							edge.Splits = append(edge.Splits, graphSplit{
+								Service: fmt.Sprintf("%s/%s", split.Namespace, split.Spec.Service),
+								Backend: fmt.Sprintf("%s/%s", svc.Namespace, svc.Name),
+								Weight:  backend.Weight,
+							})
+						}
+					}
+				}
+			}
+			for protocol := range protocols {
+				edge.Protocols = append(edge.Protocols, protocol)
+			}
+			sort.Strings(edge.Protocols)
+			sort.Strings(edge.TrafficTargets)
+			sort.Ints(edge.TCPPorts)
+
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	for _, node := range nodes {
+		graph.Nodes = append(graph.Nodes, *node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].Identity < graph.Nodes[j].Identity
+	})
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].Source != graph.Edges[j].Source {
+			return graph.Edges[i].Source < graph.Edges[j].Source
+		}
+		return graph.Edges[i].Destination < graph.Edges[j].Destination
+	})
+
+	return graph
+}
+
+// hasSource returns whether the given service account is a source of the given traffic target
+func hasSource(target trafficpolicy.TrafficTargetWithRoutes, svcAccount identity.K8sServiceAccount) bool {
+	for _, source := range target.Sources {
+		if source.ToK8sServiceAccount() == svcAccount {
+			return true
+		}
+	}
+	return false
+}
+
+// getGraphHTTPRoutes returns the HTTP route matches referenced by the rules of the given traffic target
+func getGraphHTTPRoutes(t *access.TrafficTarget, routeGroupsByName map[string]*spec.HTTPRouteGroup) []graphHTTPRoute {
+	var routes []graphHTTPRoute
+	for _, rule := range t.Spec.Rules {
+		if rule.Kind != httpRouteGroupKind {
+			continue
+		}
+		routeGroupName := fmt.Sprintf("%s/%s", t.Namespace, rule.Name)
+		rg, ok := routeGroupsByName[routeGroupName]
+		if !ok {
+			continue
+		}
+
+		// A rule without matches allows all the matches of the route group
+		allowed := make(map[string]bool)
+		for _, match := range rule.Matches {
+			allowed[match] = true
+		}
+		for _, match := range rg.Spec.Matches {
+			if len(allowed) > 0 && !allowed[match.Name] {
+				continue
+			}
+			routes = append(routes, graphHTTPRoute{
+				RouteGroup: routeGroupName,
+				Match:      match.Name,
+				PathRegex:  match.PathRegex,
+				Methods:    match.Methods,
+			})
+		}
+	}
+	return routes
+}
+
+// writeDOTGraph writes the given graph in the Graphviz DOT language
+func writeDOTGraph(w io.Writer, graph serviceGraph) {
+	_, _ = fmt.Fprintln(w, "digraph mesh {")
+	_, _ = fmt.Fprintln(w, "  node [shape=box];")
+	if graph.PermissiveTrafficPolicyMode {
+		_, _ = fmt.Fprintln(w, `  label="Permissive traffic policy mode: all traffic is allowed";`)
+	}
+
+	for _, node := range graph.Nodes {
+		label := []string{node.Identity}
+		for _, svc := range node.Services {
+			var ports []string
+			for port, protocol := range svc.Ports {
+				ports = append(ports, fmt.Sprintf("%d/%s", port, protocol))
+			}
+			sort.Strings(ports)
+			label = append(label, fmt.Sprintf("%s (%s)", svc.Name, strings.Join(ports, ", ")))
+		}
+		_, _ = fmt.Fprintf(w, "  %q [label=%q];\n", node.Identity, strings.Join(label, "\n"))
+	}
+
+	for _, edge := range graph.Edges {
+		var label []string
+		if len(edge.Protocols) > 0 {
+			label = append(label, strings.Join(edge.Protocols, ", "))
+		}
+		for _, route := range edge.HTTPRoutes {
+			label = append(label, strings.TrimSpace(fmt.Sprintf("%s %s", strings.Join(route.Methods, ","), route.PathRegex)))
+		}
+		if len(edge.TCPPorts) > 0 {
+			var ports []string
+			for _, port := range edge.TCPPorts {
+				ports = append(ports, fmt.Sprint(port))
+			}
+			label = append(label, "tcp "+strings.Join(ports, ","))
+		}
+		for _, split := range edge.Splits {
+			label = append(label, fmt.Sprintf("%s: %s weight %d", split.Service, split.Backend, split.Weight))
+		}
+		_, _ = fmt.Fprintf(w, "  %q -> %q [label=%q];\n", edge.Source, edge.Destination, strings.Join(label, "\n"))
+	}
+
+	_, _ = fmt.Fprintln(w, "}")
+}
@@ -0,0 +1,176 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// Tests getGraphHandler through HTTP handler returns the graph of the allowed traffic in the requested format
+func TestGetGraphHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedStatus       int
+		expectedResponseBody string
+	}{
+		{
+			name:           "JSON graph",
+			query:          "",
+			expectedStatus: http.StatusOK,
+			expectedResponseBody: `{"permissiveTrafficPolicyMode":false,"nodes":[` +
+				`{"identity":"default/bookbuyer"},` +
+				`{"identity":"default/bookstore","services":[{"name":"bookstore-v1","namespace":"default","ports":{"8888":"http"}}]}],` +
+				`"edges":[{"source":"default/bookbuyer","destination":"default/bookstore","trafficTargets":["default/bookbuyer-access-bookstore"],"protocols":["http"],` +
+				`"httpRoutes":[{"routeGroup":"default/bookstore-service-routes","match":"buy-books","pathRegex":"/buy","methods":["GET"]},` +
+				`{"routeGroup":"default/bookstore-service-routes","match":"sell-books","pathRegex":"/sell","methods":["GET"]}],` +
+				`"splits":[{"service":"default/bookstore-apex","backend":"default/bookstore-v1","weight":90}]}]}`,
+		},
+		{
+			name:           "DOT graph",
+			query:          "?format=dot",
+			expectedStatus: http.StatusOK,
+			expectedResponseBody: "digraph mesh {\n" +
+				"  node [shape=box];\n" +
+				"  \"default/bookbuyer\" [label=\"default/bookbuyer\"];\n" +
+				"  \"default/bookstore\" [label=\"default/bookstore\\nbookstore-v1 (8888/http)\"];\n" +
+				"  \"default/bookbuyer\" -> \"default/bookstore\" [label=\"http\\nGET /buy\\nGET /sell\\ndefault/bookstore-apex: default/bookstore-v1 weight 90\"];\n" +
+				"}\n",
+		},
+		{
+			name:                 "invalid format",
+			query:                "?format=svg",
+			expectedStatus:       http.StatusBadRequest,
+			expectedResponseBody: "Invalid format \"svg\", expected json or dot\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockCatalogDebugger := NewMockMeshCatalogDebugger(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			ds := DebugConfig{
+				meshCatalogDebugger: mockCatalogDebugger,
+				configurator:        mockConfigurator,
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				bookbuyer := tests.BookbuyerServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)
+				bookstore := tests.BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)
+
+				mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false)
+				mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain)
+				mockCatalogDebugger.EXPECT().ListSMIPolicies().Return(
+					[]*split.TrafficSplit{&tests.TrafficSplit},
+					[]identity.K8sServiceAccount{tests.BookbuyerServiceAccount},
+					[]*spec.HTTPRouteGroup{&tests.HTTPRouteGroup},
+					[]*access.TrafficTarget{&tests.TrafficTarget},
+				)
+				mockCatalogDebugger.EXPECT().ListServicesForServiceIdentity(bookbuyer).Return(nil)
+				mockCatalogDebugger.EXPECT().ListServicesForServiceIdentity(bookstore).Return([]service.MeshService{tests.BookstoreV1Service})
+				mockCatalogDebugger.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{tests.ServicePort: "http"}, nil)
+				mockCatalogDebugger.EXPECT().ListOutboundServiceIdentities(bookbuyer).Return([]identity.ServiceIdentity{bookstore}, nil)
+				mockCatalogDebugger.EXPECT().ListInboundTrafficTargetsWithRoutes(bookstore).Return([]trafficpolicy.TrafficTargetWithRoutes{
+					{
+						Name:        "default/bookbuyer-access-bookstore",
+						Destination: bookstore,
+						Sources:     []identity.ServiceIdentity{bookbuyer.WithTrustDomain(identity.ClusterLocalTrustDomain)},
+					},
+				}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/graph"+tc.query, nil)
+			responseRecorder := httptest.NewRecorder()
+			ds.getGraphHandler().ServeHTTP(responseRecorder, req)
+			assert.Equal(tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(tc.expectedResponseBody, responseRecorder.Body.String())
+		})
+	}
+}
+
+// Tests buildServiceGraph builds a single node per service identity and a single edge per source and destination
+// when the service identities are referenced by several TrafficTargets
+func TestBuildServiceGraphWithMultipleTrafficTargets(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockCatalogDebugger := NewMockMeshCatalogDebugger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	ds := DebugConfig{
+		meshCatalogDebugger: mockCatalogDebugger,
+		configurator:        mockConfigurator,
+	}
+
+	bookbuyer := tests.BookbuyerServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)
+	bookstore := tests.BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)
+	bookstoreV2 := tests.BookstoreV2ServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)
+
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false)
+	mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain)
+	// The source of both TrafficTargets is listed once per TrafficTarget
+	mockCatalogDebugger.EXPECT().ListSMIPolicies().Return(
+		nil,
+		[]identity.K8sServiceAccount{tests.BookbuyerServiceAccount, tests.BookstoreServiceAccount, tests.BookbuyerServiceAccount, tests.BookstoreV2ServiceAccount},
+		[]*spec.HTTPRouteGroup{&tests.HTTPRouteGroup},
+		[]*access.TrafficTarget{&tests.TrafficTarget, &tests.BookstoreV2TrafficTarget},
+	)
+	mockCatalogDebugger.EXPECT().ListServicesForServiceIdentity(bookbuyer).Return(nil)
+	mockCatalogDebugger.EXPECT().ListServicesForServiceIdentity(bookstore).Return([]service.MeshService{tests.BookstoreV1Service})
+	mockCatalogDebugger.EXPECT().ListServicesForServiceIdentity(bookstoreV2).Return([]service.MeshService{tests.BookstoreV2Service})
+	mockCatalogDebugger.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{tests.ServicePort: "http"}, nil)
+	mockCatalogDebugger.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV2Service).Return(map[uint32]string{tests.ServicePort: "http"}, nil)
+	mockCatalogDebugger.EXPECT().ListOutboundServiceIdentities(bookbuyer).Return([]identity.ServiceIdentity{bookstore, bookstoreV2}, nil)
+	mockCatalogDebugger.EXPECT().ListOutboundServiceIdentities(bookstore).Return(nil, nil)
+	mockCatalogDebugger.EXPECT().ListOutboundServiceIdentities(bookstoreV2).Return(nil, nil)
+	mockCatalogDebugger.EXPECT().ListInboundTrafficTargetsWithRoutes(bookstore).Return([]trafficpolicy.TrafficTargetWithRoutes{
+		{Name: "default/" + tests.TrafficTargetName, Destination: bookstore, Sources: []identity.ServiceIdentity{bookbuyer}},
+	}, nil)
+	mockCatalogDebugger.EXPECT().ListInboundTrafficTargetsWithRoutes(bookstoreV2).Return([]trafficpolicy.TrafficTargetWithRoutes{
+		{Name: "default/" + tests.BookstoreV2TrafficTargetName, Destination: bookstoreV2, Sources: []identity.ServiceIdentity{bookbuyer}},
+	}, nil)
+
+	httpRoutes := []graphHTTPRoute{
+		{RouteGroup: "default/bookstore-service-routes", Match: tests.BuyBooksMatchName, PathRegex: tests.BookstoreBuyPath, Methods: []string{"GET"}},
+		{RouteGroup: "default/bookstore-service-routes", Match: tests.SellBooksMatchName, PathRegex: tests.BookstoreSellPath, Methods: []string{"GET"}},
+	}
+	expected := serviceGraph{
+		Nodes: []graphNode{
+			{Identity: "default/bookbuyer"},
+			{Identity: "default/bookstore", Services: []graphService{{Name: tests.BookstoreV1Service.Name, Namespace: "default", Ports: map[uint32]string{tests.ServicePort: "http"}}}},
+			{Identity: "default/bookstore-v2", Services: []graphService{{Name: tests.BookstoreV2Service.Name, Namespace: "default", Ports: map[uint32]string{tests.ServicePort: "http"}}}},
+		},
+		Edges: []graphEdge{
+			{
+				Source:         "default/bookbuyer",
+				Destination:    "default/bookstore",
+				TrafficTargets: []string{"default/" + tests.TrafficTargetName},
+				Protocols:      []string{"http"},
+				HTTPRoutes:     httpRoutes,
+			},
+			{
+				Source:         "default/bookbuyer",
+				Destination:    "default/bookstore-v2",
+				TrafficTargets: []string{"default/" + tests.BookstoreV2TrafficTargetName},
+				Protocols:      []string{"http"},
+				HTTPRoutes:     httpRoutes,
+			},
+		},
+	}
+
+	assert.Equal(expected, ds.buildServiceGraph())
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/openservicemesh/osm/pkg/debugger (interfaces: CertificateManagerDebugger,MeshCatalogDebugger,XDSDebugger)
+// Source: github.com/openservicemesh/osm/pkg/debugger (interfaces: CertificateManagerDebugger,MeshCatalogDebugger,ShardDebugger,XDSDebugger)
 
 // Package debugger is a generated GoMock package.
 package debugger
@@ -9,9 +9,12 @@ import (
 	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	catalog "github.com/openservicemesh/osm/pkg/catalog"
 	certificate "github.com/openservicemesh/osm/pkg/certificate"
 	envoy "github.com/openservicemesh/osm/pkg/envoy"
 	identity "github.com/openservicemesh/osm/pkg/identity"
+	service "github.com/openservicemesh/osm/pkg/service"
+	trafficpolicy "github.com/openservicemesh/osm/pkg/trafficpolicy"
 	v1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 	v1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 	v1alpha2 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
@@ -77,6 +80,64 @@ func (m *MockMeshCatalogDebugger) EXPECT() *MockMeshCatalogDebuggerMockRecorder
 	return m.recorder
 }
 
+// CheckTrafficRequest mocks base method
+func (m *MockMeshCatalogDebugger) CheckTrafficRequest(arg0 catalog.TrafficRequest) catalog.TrafficDecision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTrafficRequest", arg0)
+	ret0, _ := ret[0].(catalog.TrafficDecision)
+	return ret0
+}
+
+// CheckTrafficRequest indicates an expected call of CheckTrafficRequest
+func (mr *MockMeshCatalogDebuggerMockRecorder) CheckTrafficRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTrafficRequest", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).CheckTrafficRequest), arg0)
+}
+
+// GetPortToProtocolMappingForService mocks base method
+func (m *MockMeshCatalogDebugger) GetPortToProtocolMappingForService(arg0 service.MeshService) (map[uint32]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortToProtocolMappingForService", arg0)
+	ret0, _ := ret[0].(map[uint32]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortToProtocolMappingForService indicates an expected call of GetPortToProtocolMappingForService
+func (mr *MockMeshCatalogDebuggerMockRecorder) GetPortToProtocolMappingForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortToProtocolMappingForService", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).GetPortToProtocolMappingForService), arg0)
+}
+
+// ListInboundTrafficTargetsWithRoutes mocks base method
+func (m *MockMeshCatalogDebugger) ListInboundTrafficTargetsWithRoutes(arg0 identity.ServiceIdentity) ([]trafficpolicy.TrafficTargetWithRoutes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInboundTrafficTargetsWithRoutes", arg0)
+	ret0, _ := ret[0].([]trafficpolicy.TrafficTargetWithRoutes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInboundTrafficTargetsWithRoutes indicates an expected call of ListInboundTrafficTargetsWithRoutes
+func (mr *MockMeshCatalogDebuggerMockRecorder) ListInboundTrafficTargetsWithRoutes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInboundTrafficTargetsWithRoutes", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).ListInboundTrafficTargetsWithRoutes), arg0)
+}
+
+// ListServicesForServiceIdentity mocks base method
+func (m *MockMeshCatalogDebugger) ListServicesForServiceIdentity(arg0 identity.ServiceIdentity) []service.MeshService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServicesForServiceIdentity", arg0)
+	ret0, _ := ret[0].([]service.MeshService)
+	return ret0
+}
+
+// ListServicesForServiceIdentity indicates an expected call of ListServicesForServiceIdentity
+func (mr *MockMeshCatalogDebuggerMockRecorder) ListServicesForServiceIdentity(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServicesForServiceIdentity", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).ListServicesForServiceIdentity), arg0)
+}
+
 // ListMonitoredNamespaces mocks base method
 func (m *MockMeshCatalogDebugger) ListMonitoredNamespaces() []string {
 	m.ctrl.T.Helper()
@@ -91,6 +152,21 @@ func (mr *MockMeshCatalogDebuggerMockRecorder) ListMonitoredNamespaces() *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMonitoredNamespaces", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).ListMonitoredNamespaces))
 }
 
+// ListOutboundServiceIdentities mocks base method
+func (m *MockMeshCatalogDebugger) ListOutboundServiceIdentities(arg0 identity.ServiceIdentity) ([]identity.ServiceIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOutboundServiceIdentities", arg0)
+	ret0, _ := ret[0].([]identity.ServiceIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutboundServiceIdentities indicates an expected call of ListOutboundServiceIdentities
+func (mr *MockMeshCatalogDebuggerMockRecorder) ListOutboundServiceIdentities(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutboundServiceIdentities", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).ListOutboundServiceIdentities), arg0)
+}
+
 // ListSMIPolicies mocks base method
 func (m *MockMeshCatalogDebugger) ListSMIPolicies() ([]*v1alpha2.TrafficSplit, []identity.K8sServiceAccount, []*v1alpha4.HTTPRouteGroup, []*v1alpha3.TrafficTarget) {
 	m.ctrl.T.Helper()
@@ -108,6 +184,71 @@ func (mr *MockMeshCatalogDebuggerMockRecorder) ListSMIPolicies() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSMIPolicies", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).ListSMIPolicies))
 }
 
+// MockShardDebugger is a mock of ShardDebugger interface
+type MockShardDebugger struct {
+	ctrl     *gomock.Controller
+	recorder *MockShardDebuggerMockRecorder
+}
+
+// MockShardDebuggerMockRecorder is the mock recorder for MockShardDebugger
+type MockShardDebuggerMockRecorder struct {
+	mock *MockShardDebugger
+}
+
+// NewMockShardDebugger creates a new mock instance
+func NewMockShardDebugger(ctrl *gomock.Controller) *MockShardDebugger {
+	mock := &MockShardDebugger{ctrl: ctrl}
+	mock.recorder = &MockShardDebuggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockShardDebugger) EXPECT() *MockShardDebuggerMockRecorder {
+	return m.recorder
+}
+
+// GetIdentity mocks base method
+func (m *MockShardDebugger) GetIdentity() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetIdentity indicates an expected call of GetIdentity
+func (mr *MockShardDebuggerMockRecorder) GetIdentity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockShardDebugger)(nil).GetIdentity))
+}
+
+// GetOwner mocks base method
+func (m *MockShardDebugger) GetOwner(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwner", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetOwner indicates an expected call of GetOwner
+func (mr *MockShardDebuggerMockRecorder) GetOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwner", reflect.TypeOf((*MockShardDebugger)(nil).GetOwner), arg0)
+}
+
+// ListReplicas mocks base method
+func (m *MockShardDebugger) ListReplicas() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplicas")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// ListReplicas indicates an expected call of ListReplicas
+func (mr *MockShardDebuggerMockRecorder) ListReplicas() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplicas", reflect.TypeOf((*MockShardDebugger)(nil).ListReplicas))
+}
+
 // MockXDSDebugger is a mock of XDSDebugger interface
 type MockXDSDebugger struct {
 	ctrl     *gomock.Controller
@@ -1,7 +1,9 @@
 package debugger
 
 import (
+	"encoding/json"
 	"fmt"
+	"html"
 	"net/http"
 	"sort"
 	"time"
@@ -13,8 +15,18 @@ import (
 const (
 	specificProxyQueryKey = "proxy"
 	proxyConfigQueryKey   = "cfg"
+	proxyNACKQueryKey     = "nacks"
 )
 
+// proxyNACK is the last configuration rejected by a connected proxy
+type proxyNACK struct {
+	CommonName string `json:"commonName"`
+	Namespace  string `json:"namespace,omitempty"`
+	Pod        string `json:"pod,omitempty"`
+
+	NACK *envoy.NACK `json:"nack"`
+}
+
 func (ds DebugConfig) getProxies() http.Handler {
 	// This function is needed to convert the list of connected proxies to
 	// the type (map) required by the printProxies function.
@@ -28,12 +40,16 @@ func (ds DebugConfig) getProxies() http.Handler {
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
-		if proxyConfigDump, ok := r.URL.Query()[proxyConfigQueryKey]; ok {
+		if _, ok := r.URL.Query()[proxyNACKQueryKey]; ok {
+			w.Header().Set("Content-Type", "application/json")
+			ds.getNACKs(w)
+		} else if proxyConfigDump, ok := r.URL.Query()[proxyConfigQueryKey]; ok {
 			ds.getConfigDump(certificate.CommonName(proxyConfigDump[0]), w)
 		} else if specificProxy, ok := r.URL.Query()[specificProxyQueryKey]; ok {
 			ds.getProxy(certificate.CommonName(specificProxy[0]), w)
 		} else {
 			printProxies(w, listConnected(), "Connected")
+			printNACKs(w, ds.listNACKs())
 			// TODO(#2481): Print expected proxies once #2481 is addressed
 			printProxies(w, ds.proxyRegistry.ListDisconnectedProxies(), "Disconnected")
 		}
@@ -59,6 +75,56 @@ func printProxies(w http.ResponseWriter, proxies map[certificate.CommonName]time
 	_, _ = fmt.Fprint(w, `</table>`)
 }
 
+// listNACKs returns the last configuration rejected by each connected proxy, sorted by CommonName
+func (ds DebugConfig) listNACKs() []proxyNACK {
+	var nacks []proxyNACK
+	for cn, proxy := range ds.proxyRegistry.ListConnectedProxies() {
+		nack := proxy.GetLastNACK()
+		if nack == nil {
+			continue
+		}
+		proxyNACK := proxyNACK{
+			CommonName: cn.String(),
+			NACK:       nack,
+		}
+		if proxy.PodMetadata != nil {
+			proxyNACK.Namespace = proxy.PodMetadata.Namespace
+			proxyNACK.Pod = proxy.PodMetadata.Name
+		}
+		nacks = append(nacks, proxyNACK)
+	}
+
+	sort.Slice(nacks, func(i, j int) bool {
+		return nacks[i].CommonName < nacks[j].CommonName
+	})
+	return nacks
+}
+
+func printNACKs(w http.ResponseWriter, nacks []proxyNACK) {
+	_, _ = fmt.Fprintf(w, "<h1>Proxies with a rejected configuration (%d):</h1>", len(nacks))
+	_, _ = fmt.Fprint(w, `<table>`)
+	_, _ = fmt.Fprint(w, "<tr><td>#</td><td>Envoy's certificate CN</td><td>Type</td><td>Nonce</td><td>Applied version</td><td>Error</td><td>How long ago</td></tr>")
+	for idx, nack := range nacks {
+		_, _ = fmt.Fprintf(w, `<tr><td>%d:</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>(%+v ago)</td></tr>`,
+			idx, nack.CommonName, nack.NACK.TypeURI.Short(), nack.NACK.Nonce, nack.NACK.AppliedVersion,
+			html.EscapeString(nack.NACK.Error), time.Since(nack.NACK.Timestamp))
+	}
+	_, _ = fmt.Fprint(w, `</table>`)
+}
+
+func (ds DebugConfig) getNACKs(w http.ResponseWriter) {
+	nacks := ds.listNACKs()
+	if nacks == nil {
+		nacks = []proxyNACK{}
+	}
+
+	jsonNACKs, err := json.Marshal(nacks)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling proxy NACKs %+v", nacks)
+	}
+	_, _ = fmt.Fprint(w, string(jsonNACKs))
+}
+
 func (ds DebugConfig) getConfigDump(cn certificate.CommonName, w http.ResponseWriter) {
 	pod, err := envoy.GetPodFromCertificate(cn, ds.kubeController)
 	if err != nil {
@@ -0,0 +1,46 @@
+package debugger
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+// Tests getProxies through HTTP handler returns the last configuration rejected by the connected proxies
+func TestProxyNACKsHandler(t *testing.T) {
+	assert := tassert.New(t)
+
+	proxyRegistry := registry.NewProxyRegistry(nil)
+	ds := DebugConfig{
+		proxyRegistry: proxyRegistry,
+	}
+
+	responseRecorder := httptest.NewRecorder()
+	ds.getProxies().ServeHTTP(responseRecorder, httptest.NewRequest("GET", "/debug/proxy?nacks", nil))
+	assert.Equal(`[]`, responseRecorder.Body.String())
+
+	proxy, err := envoy.NewProxy(certificate.CommonName(tests.ProxyUUID+".sidecar.bookbuyer.default"), "1", nil)
+	assert.Nil(err)
+	proxy.PodMetadata = &envoy.PodMetadata{Name: "bookbuyer", Namespace: "default"}
+	proxy.SetLastNACK(&envoy.NACK{
+		TypeURI:        envoy.TypeCDS,
+		Nonce:          "2",
+		AppliedVersion: "1",
+		Error:          "invalid cluster",
+		Timestamp:      time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC),
+	})
+	proxyRegistry.RegisterProxy(proxy)
+
+	responseRecorder = httptest.NewRecorder()
+	ds.getProxies().ServeHTTP(responseRecorder, httptest.NewRequest("GET", "/debug/proxy?nacks", nil))
+	assert.Equal(`[{"commonName":"`+tests.ProxyUUID+`.sidecar.bookbuyer.default","namespace":"default","pod":"bookbuyer",`+
+		`"nack":{"typeURI":"type.googleapis.com/envoy.config.cluster.v3.Cluster","nonce":"2","appliedVersion":"1","error":"invalid cluster","timestamp":"2021-06-01T00:00:00Z"}}]`,
+		responseRecorder.Body.String())
+}
@@ -15,13 +15,17 @@ import (
 // GetHandlers implements DebugConfig interface and returns the rest of URLs and the handling functions.
 func (ds DebugConfig) GetHandlers() map[string]http.Handler {
 	handlers := map[string]http.Handler{
-		"/debug/certs":         ds.getCertHandler(),
-		"/debug/xds":           ds.getXDSHandler(),
-		"/debug/proxy":         ds.getProxies(),
-		"/debug/policies":      ds.getSMIPoliciesHandler(),
-		"/debug/config":        ds.getOSMConfigHandler(),
-		"/debug/namespaces":    ds.getMonitoredNamespacesHandler(),
-		"/debug/feature-flags": ds.getFeatureFlags(),
+		"/debug/certs":          ds.getCertHandler(),
+		"/debug/xds":            ds.getXDSHandler(),
+		"/debug/proxy":          ds.getProxies(),
+		"/debug/policies":       ds.getSMIPoliciesHandler(),
+		"/debug/config":         ds.getOSMConfigHandler(),
+		"/debug/namespaces":     ds.getMonitoredNamespacesHandler(),
+		"/debug/feature-flags":  ds.getFeatureFlags(),
+		"/debug/stale-sidecars": ds.getStaleSidecarsHandler(),
+		"/debug/shards":         ds.getShardsHandler(),
+		"/debug/graph":          ds.getGraphHandler(),
+		"/debug/traffic-check":  ds.getTrafficCheckHandler(),
 
 		// Pprof handlers
 		"/debug/pprof/":        http.HandlerFunc(pprof.Index),
@@ -38,7 +42,8 @@ func (ds DebugConfig) GetHandlers() map[string]http.Handler {
 }
 
 // NewDebugConfig returns an implementation of DebugConfig interface.
-func NewDebugConfig(certDebugger CertificateManagerDebugger, xdsDebugger XDSDebugger, meshCatalogDebugger MeshCatalogDebugger, proxyRegistry *registry.ProxyRegistry, kubeConfig *rest.Config, kubeClient kubernetes.Interface, cfg configurator.Configurator, kubeController k8s.Controller) DebugConfig {
+// The shardDebugger is nil when the proxies are not sharded across osm-controller replicas.
+func NewDebugConfig(certDebugger CertificateManagerDebugger, xdsDebugger XDSDebugger, meshCatalogDebugger MeshCatalogDebugger, proxyRegistry *registry.ProxyRegistry, kubeConfig *rest.Config, kubeClient kubernetes.Interface, cfg configurator.Configurator, kubeController k8s.Controller, shardDebugger ShardDebugger) DebugConfig {
 	return DebugConfig{
 		certDebugger:        certDebugger,
 		xdsDebugger:         xdsDebugger,
@@ -50,6 +55,7 @@ func NewDebugConfig(certDebugger CertificateManagerDebugger, xdsDebugger XDSDebu
 		// We need the Kubernetes config to be able to establish port forwarding to the Envoy pod we want to debug.
 		kubeConfig: kubeConfig,
 
-		configurator: cfg,
+		configurator:  cfg,
+		shardDebugger: shardDebugger,
 	}
 }
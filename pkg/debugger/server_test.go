@@ -32,7 +32,8 @@ func TestGetHandlers(t *testing.T) {
 		nil,
 		client,
 		mockConfig,
-		mockKubeController)
+		mockKubeController,
+		nil)
 
 	handlers := ds.GetHandlers()
 
@@ -43,6 +44,9 @@ func TestGetHandlers(t *testing.T) {
 		"/debug/policies",
 		"/debug/config",
 		"/debug/namespaces",
+		"/debug/stale-sidecars",
+		"/debug/shards",
+		"/debug/traffic-check",
 		// Pprof handlers
 		"/debug/pprof/",
 		"/debug/pprof/cmdline",
@@ -0,0 +1,81 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+type shardOwnership struct {
+	Enabled  bool   `json:"enabled"`
+	Identity string `json:"identity,omitempty"`
+
+	// Replicas maps the name of each ready osm-controller replica to the number of proxies it owns
+	Replicas map[string]int `json:"replicas,omitempty"`
+
+	Proxies []proxyShard `json:"proxies,omitempty"`
+}
+
+type proxyShard struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	ProxyUUID string `json:"proxyUUID"`
+	Owner     string `json:"owner"`
+
+	// Connected is true when the proxy is connected to the replica serving the debug server
+	Connected bool `json:"connected"`
+}
+
+func (ds DebugConfig) getShardsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ownership := shardOwnership{}
+
+		if ds.shardDebugger != nil {
+			ownership.Enabled = true
+			ownership.Identity = ds.shardDebugger.GetIdentity()
+			ownership.Replicas = make(map[string]int)
+			for _, replica := range ds.shardDebugger.ListReplicas() {
+				ownership.Replicas[replica] = 0
+			}
+
+			connectedProxies := make(map[string]bool)
+			for _, proxy := range ds.proxyRegistry.ListConnectedProxies() {
+				connectedProxies[proxy.GetUUID().String()] = true
+			}
+
+			for _, pod := range ds.kubeController.ListPods() {
+				proxyUUID, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]
+				if !ok {
+					continue
+				}
+				owner := ds.shardDebugger.GetOwner(proxyUUID)
+				ownership.Replicas[owner]++
+				ownership.Proxies = append(ownership.Proxies, proxyShard{
+					Namespace: pod.Namespace,
+					Pod:       pod.Name,
+					ProxyUUID: proxyUUID,
+					Owner:     owner,
+					Connected: connectedProxies[proxyUUID],
+				})
+			}
+
+			sort.Slice(ownership.Proxies, func(i, j int) bool {
+				if ownership.Proxies[i].Namespace != ownership.Proxies[j].Namespace {
+					return ownership.Proxies[i].Namespace < ownership.Proxies[j].Namespace
+				}
+				return ownership.Proxies[i].Pod < ownership.Proxies[j].Pod
+			})
+		}
+
+		jsonOwnership, err := json.Marshal(ownership)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling shard ownership %+v", ownership)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonOwnership))
+	})
+}
@@ -0,0 +1,95 @@
+package debugger
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+// Tests getShardsHandler through HTTP handler returns the shard ownership of the proxies
+func TestShardsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		sharded              bool
+		expectedResponseBody string
+	}{
+		{
+			name:                 "sharding disabled",
+			sharded:              false,
+			expectedResponseBody: `{"enabled":false}`,
+		},
+		{
+			name:    "sharding enabled",
+			sharded: true,
+			expectedResponseBody: `{"enabled":true,"identity":"osm-controller-1","replicas":{"osm-controller-1":1,"osm-controller-2":1},"proxies":[` +
+				`{"namespace":"default","pod":"bookbuyer","proxyUUID":"` + tests.ProxyUUID + `","owner":"osm-controller-1","connected":true},` +
+				`{"namespace":"default","pod":"bookstore","proxyUUID":"11111111-2222-3333-4444-555555555555","owner":"osm-controller-2","connected":false}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockShardDebugger := NewMockShardDebugger(mockCtrl)
+
+			proxyRegistry := registry.NewProxyRegistry(nil)
+			proxy, err := envoy.NewProxy(certificate.CommonName(tests.ProxyUUID+".sidecar.bookbuyer.default"), "1", nil)
+			assert.Nil(err)
+			proxyRegistry.RegisterProxy(proxy)
+
+			ds := DebugConfig{
+				kubeController: mockKubeController,
+				proxyRegistry:  proxyRegistry,
+			}
+
+			if tc.sharded {
+				ds.shardDebugger = mockShardDebugger
+				otherProxyUUID := "11111111-2222-3333-4444-555555555555"
+
+				mockShardDebugger.EXPECT().GetIdentity().Return("osm-controller-1")
+				mockShardDebugger.EXPECT().ListReplicas().Return([]string{"osm-controller-1", "osm-controller-2"})
+				mockShardDebugger.EXPECT().GetOwner(tests.ProxyUUID).Return("osm-controller-1")
+				mockShardDebugger.EXPECT().GetOwner(otherProxyUUID).Return("osm-controller-2")
+				mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "bookstore",
+							Namespace: "default",
+							Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: otherProxyUUID},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "bookbuyer",
+							Namespace: "default",
+							Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: tests.ProxyUUID},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "not-in-mesh",
+							Namespace: "default",
+						},
+					},
+				})
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			ds.getShardsHandler().ServeHTTP(responseRecorder, nil)
+			assert.Equal(tc.expectedResponseBody, responseRecorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/mesh"
+	"github.com/openservicemesh/osm/pkg/restarter"
+)
+
+type staleSidecar struct {
+	Namespace       string `json:"namespace"`
+	Pod             string `json:"pod"`
+	ProxyUUID       string `json:"proxyUUID"`
+	InjectedVersion string `json:"injectedVersion"`
+	ExpectedVersion string `json:"expectedVersion"`
+}
+
+func (ds DebugConfig) getStaleSidecarsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		staleSidecars := []staleSidecar{}
+		for _, pod := range restarter.ListStalePods(ds.kubeController, ds.configurator) {
+			staleSidecars = append(staleSidecars, staleSidecar{
+				Namespace:       pod.Namespace,
+				Pod:             pod.Name,
+				ProxyUUID:       pod.Labels[constants.EnvoyUniqueIDLabelName],
+				InjectedVersion: mesh.InjectedSidecarVersion(*pod),
+				ExpectedVersion: mesh.ExpectedSidecarVersion(*pod, ds.configurator.GetEnvoyImage(), ds.configurator.GetEnvoyWindowsImage(), ds.configurator.GetInitContainerImage()),
+			})
+		}
+
+		jsonStaleSidecars, err := json.Marshal(staleSidecars)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling stale sidecars %+v", staleSidecars)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonStaleSidecars))
+	})
+}
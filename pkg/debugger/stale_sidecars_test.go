@@ -0,0 +1,57 @@
+package debugger
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+// Tests getStaleSidecarsHandler through HTTP handler returns the pods running an outdated sidecar
+func TestStaleSidecarsHandler(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	ds := DebugConfig{
+		kubeController: mockKubeController,
+		configurator:   mockConfigurator,
+	}
+
+	mockConfigurator.EXPECT().GetEnvoyImage().Return("envoy:v2").AnyTimes()
+	mockConfigurator.EXPECT().GetEnvoyWindowsImage().Return("envoy-windows:v2").AnyTimes()
+	mockConfigurator.EXPECT().GetInitContainerImage().Return("init:v2").AnyTimes()
+	mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "bookstore",
+				Namespace:   "default",
+				Labels:      map[string]string{constants.EnvoyUniqueIDLabelName: tests.ProxyUUID},
+				Annotations: map[string]string{constants.SidecarVersionAnnotation: "envoy:v1,init:v2"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "bookbuyer",
+				Namespace:   "default",
+				Labels:      map[string]string{constants.EnvoyUniqueIDLabelName: tests.ProxyUUID},
+				Annotations: map[string]string{constants.SidecarVersionAnnotation: "envoy:v2,init:v2"},
+			},
+		},
+	})
+
+	responseRecorder := httptest.NewRecorder()
+	ds.getStaleSidecarsHandler().ServeHTTP(responseRecorder, nil)
+	expectedResponseBody := `[{"namespace":"default","pod":"bookstore","proxyUUID":"` + tests.ProxyUUID +
+		`","injectedVersion":"envoy:v1,init:v2","expectedVersion":"envoy:v2,init:v2"}]`
+	assert.Equal(expectedResponseBody, responseRecorder.Body.String())
+}
@@ -0,0 +1,100 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// trafficCheckSourceQueryKey is the source service account of the request, of the form <namespace>/<name>
+	trafficCheckSourceQueryKey = "source"
+
+	// trafficCheckDestinationQueryKey is the destination service of the request, of the form <namespace>/<name>
+	trafficCheckDestinationQueryKey = "destination"
+
+	trafficCheckPortQueryKey   = "port"
+	trafficCheckMethodQueryKey = "method"
+	trafficCheckPathQueryKey   = "path"
+
+	// trafficCheckHeaderQueryKey is an HTTP header of the request, of the form <name>=<value>, it can be repeated
+	trafficCheckHeaderQueryKey = "header"
+)
+
+func (ds DebugConfig) getTrafficCheckHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := parseTrafficRequest(r.URL.Query(), ds.configurator.GetTrustDomain())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		decision := ds.meshCatalogDebugger.CheckTrafficRequest(req)
+		jsonDecision, err := json.Marshal(decision)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling traffic decision %+v", decision)
+			http.Error(w, fmt.Sprintf("Error marshalling traffic decision: %s", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonDecision))
+	})
+}
+
+// parseTrafficRequest returns the request to evaluate described by the query parameters of the traffic check endpoint,
+// the source service account is resolved to an identity in the given trust domain
+func parseTrafficRequest(query url.Values, trustDomain string) (catalog.TrafficRequest, error) {
+	req := catalog.TrafficRequest{
+		Method:  query.Get(trafficCheckMethodQueryKey),
+		Path:    query.Get(trafficCheckPathQueryKey),
+		Headers: make(map[string]string),
+	}
+
+	srcNamespace, srcName, err := splitNamespacedName(query.Get(trafficCheckSourceQueryKey))
+	if err != nil {
+		return req, errors.Errorf("Invalid %s: %s", trafficCheckSourceQueryKey, err)
+	}
+	req.Source = identity.K8sServiceAccount{Namespace: srcNamespace, Name: srcName}.ToServiceIdentity(trustDomain)
+
+	dstNamespace, dstName, err := splitNamespacedName(query.Get(trafficCheckDestinationQueryKey))
+	if err != nil {
+		return req, errors.Errorf("Invalid %s: %s", trafficCheckDestinationQueryKey, err)
+	}
+	req.Destination = service.MeshService{Namespace: dstNamespace, Name: dstName}
+
+	if port := query.Get(trafficCheckPortQueryKey); port != "" {
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return req, errors.Errorf("Invalid %s %q", trafficCheckPortQueryKey, port)
+		}
+		req.Port = uint32(p)
+	}
+
+	for _, header := range query[trafficCheckHeaderQueryKey] {
+		chunks := strings.SplitN(header, "=", 2)
+		if len(chunks) != 2 || chunks[0] == "" {
+			return req, errors.Errorf("Invalid %s %q, expected <name>=<value>", trafficCheckHeaderQueryKey, header)
+		}
+		req.Headers[strings.ToLower(chunks[0])] = chunks[1]
+	}
+
+	return req, nil
+}
+
+func splitNamespacedName(namespacedName string) (string, string, error) {
+	chunks := strings.Split(namespacedName, "/")
+	if len(chunks) != 2 || chunks[0] == "" || chunks[1] == "" {
+		return "", "", errors.Errorf("expected <namespace>/<name>, got %q", namespacedName)
+	}
+	return chunks[0], chunks[1], nil
+}
@@ -0,0 +1,90 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// Tests getTrafficCheckHandler through HTTP handler returns the evaluation of the request described by the query
+func TestGetTrafficCheckHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedRequest      *catalog.TrafficRequest
+		decision             catalog.TrafficDecision
+		expectedStatus       int
+		expectedResponseBody string
+	}{
+		{
+			name:  "HTTP request",
+			query: "?source=bookbuyer/bookbuyer&destination=bookstore/bookstore&port=14001&method=GET&path=/books&header=User-Agent%3Dcurl",
+			expectedRequest: &catalog.TrafficRequest{
+				Source:      identity.K8sServiceAccount{Namespace: "bookbuyer", Name: "bookbuyer"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+				Destination: service.MeshService{Namespace: "bookstore", Name: "bookstore"},
+				Port:        14001,
+				Method:      "GET",
+				Path:        "/books",
+				Headers:     map[string]string{"user-agent": "curl"},
+			},
+			decision: catalog.TrafficDecision{
+				Allowed: false,
+				Reason:  "Service bookstore/bookstore not found",
+			},
+			expectedStatus:       http.StatusOK,
+			expectedResponseBody: `{"allowed":false,"reason":"Service bookstore/bookstore not found"}`,
+		},
+		{
+			name:                 "missing destination",
+			query:                "?source=bookbuyer/bookbuyer",
+			expectedStatus:       http.StatusBadRequest,
+			expectedResponseBody: "Invalid destination: expected <namespace>/<name>, got \"\"\n",
+		},
+		{
+			name:                 "invalid port",
+			query:                "?source=bookbuyer/bookbuyer&destination=bookstore/bookstore&port=http",
+			expectedStatus:       http.StatusBadRequest,
+			expectedResponseBody: "Invalid port \"http\"\n",
+		},
+		{
+			name:                 "invalid header",
+			query:                "?source=bookbuyer/bookbuyer&destination=bookstore/bookstore&header=curl",
+			expectedStatus:       http.StatusBadRequest,
+			expectedResponseBody: "Invalid header \"curl\", expected <name>=<value>\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockCatalogDebugger := NewMockMeshCatalogDebugger(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			ds := DebugConfig{
+				meshCatalogDebugger: mockCatalogDebugger,
+				configurator:        mockConfigurator,
+			}
+
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).AnyTimes()
+
+			if tc.expectedRequest != nil {
+				mockCatalogDebugger.EXPECT().CheckTrafficRequest(*tc.expectedRequest).Return(tc.decision)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/traffic-check"+tc.query, nil)
+			responseRecorder := httptest.NewRecorder()
+			ds.getTrafficCheckHandler().ServeHTTP(responseRecorder, req)
+			assert.Equal(tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(tc.expectedResponseBody, responseRecorder.Body.String())
+		})
+	}
+}
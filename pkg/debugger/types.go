@@ -10,6 +10,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -17,6 +18,8 @@ import (
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 var log = logger.New("debugger")
@@ -31,6 +34,7 @@ type DebugConfig struct {
 	kubeClient          kubernetes.Interface
 	kubeController      k8s.Controller
 	configurator        configurator.Configurator
+	shardDebugger       ShardDebugger
 }
 
 // CertificateManagerDebugger is an interface with methods for debugging certificate issuance.
@@ -46,6 +50,21 @@ type MeshCatalogDebugger interface {
 
 	// ListMonitoredNamespaces lists the namespaces that the control plan knows about.
 	ListMonitoredNamespaces() []string
+
+	// ListOutboundServiceIdentities lists the upstream service identities the given service identity is allowed to connect to.
+	ListOutboundServiceIdentities(identity.ServiceIdentity) ([]identity.ServiceIdentity, error)
+
+	// ListInboundTrafficTargetsWithRoutes returns the traffic targets, composed of their routes, for the given destination service identity.
+	ListInboundTrafficTargetsWithRoutes(identity.ServiceIdentity) ([]trafficpolicy.TrafficTargetWithRoutes, error)
+
+	// ListServicesForServiceIdentity lists the services whose pods run as the given service identity.
+	ListServicesForServiceIdentity(identity.ServiceIdentity) []service.MeshService
+
+	// GetPortToProtocolMappingForService returns a mapping of the service's ports to their corresponding application protocol.
+	GetPortToProtocolMappingForService(service.MeshService) (map[uint32]string, error)
+
+	// CheckTrafficRequest evaluates whether the given request is allowed by the traffic policies of the mesh.
+	CheckTrafficRequest(catalog.TrafficRequest) catalog.TrafficDecision
 }
 
 // XDSDebugger is an interface providing debugging server with methods introspecting XDS.
@@ -53,3 +72,15 @@ type XDSDebugger interface {
 	// GetXDSLog returns a log of the XDS responses sent to Envoy proxies.
 	GetXDSLog() *map[certificate.CommonName]map[envoy.TypeURI][]time.Time
 }
+
+// ShardDebugger is an interface with methods for debugging the sharding of proxies across osm-controller replicas.
+type ShardDebugger interface {
+	// GetIdentity returns the name of the osm-controller replica serving the debug server.
+	GetIdentity() string
+
+	// ListReplicas returns the names of the ready osm-controller replicas sharing the proxies.
+	ListReplicas() []string
+
+	// GetOwner returns the osm-controller replica owning the proxy with the given UUID.
+	GetOwner(proxyUUID string) string
+}
@@ -15,3 +15,14 @@ func Test(t *testing.T) {
 	}
 	assert.Equal(ept.String(), "(ip=9.9.9.9, port=1234)")
 }
+
+func TestHost(t *testing.T) {
+	assert := tassert.New(t)
+
+	ept := Endpoint{IP: net.ParseIP("9.9.9.9"), Port: 1234}
+	assert.Equal("9.9.9.9", ept.Host())
+
+	ept = Endpoint{Hostname: "gateway.example.com", Port: 15443}
+	assert.Equal("gateway.example.com", ept.Host())
+	assert.Equal("(hostname=gateway.example.com, port=15443)", ept.String())
+}
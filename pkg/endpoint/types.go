@@ -32,11 +32,30 @@ type Provider interface {
 type Endpoint struct {
 	net.IP `json:"ip"`
 	Port   `json:"port"`
+
+	// Hostname is the DNS name of the endpoint, set instead of the IP for remote gateways
+	// that are addressed by their DNS name.
+	Hostname string `json:"hostname,omitempty"`
+
+	// Zone is the name of the remote cluster the endpoint belongs to.
+	// It is empty for endpoints in the local cluster.
+	Zone string `json:"zone,omitempty"`
 }
 
 func (ep Endpoint) String() string {
+	if ep.Hostname != "" {
+		return fmt.Sprintf("(hostname=%s, port=%d)", ep.Hostname, ep.Port)
+	}
 	return fmt.Sprintf("(ip=%s, port=%d)", ep.IP, ep.Port)
 }
 
+// Host returns the hostname of the endpoint if set, and its IP otherwise
+func (ep Endpoint) Host() string {
+	if ep.Hostname != "" {
+		return ep.Hostname
+	}
+	return ep.IP.String()
+}
+
 // Port is a numerical type representing a port on which a service is exposed
 type Port uint32
@@ -0,0 +1,314 @@
+package envoy
+
+import (
+	"encoding/json"
+	"sort"
+
+	xds_accesslog_filter "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_grpc_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
+	xds_otel_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/open_telemetry/v3"
+	xds_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/stream/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	otlp_common "go.opentelemetry.io/proto/otlp/common/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// HTTPGRPCAccessLoggerName is the name of the Envoy HTTP gRPC access logger
+	HTTPGRPCAccessLoggerName = "envoy.access_loggers.http_grpc"
+
+	// TCPGRPCAccessLoggerName is the name of the Envoy TCP gRPC access logger
+	TCPGRPCAccessLoggerName = "envoy.access_loggers.tcp_grpc"
+
+	// OpenTelemetryAccessLoggerName is the name of the Envoy OpenTelemetry access logger
+	OpenTelemetryAccessLoggerName = "envoy.access_loggers.open_telemetry"
+
+	// defaultAccessLogName is the log name reported to gRPC access log collectors when unspecified
+	defaultAccessLogName = "osm"
+
+	// accessLogSamplingRuntimeKey is the runtime key used to sample access log entries
+	accessLogSamplingRuntimeKey = "osm.access_log.sampling"
+
+	// defaultAccessLogTextFormat mirrors Envoy's default access log format string
+	defaultAccessLogTextFormat = `[%START_TIME%] "%REQ(:METHOD)% %REQ(X-ENVOY-ORIGINAL-PATH?:PATH)% %PROTOCOL%" ` +
+		`%RESPONSE_CODE% %RESPONSE_FLAGS% %BYTES_RECEIVED% %BYTES_SENT% %DURATION% ` +
+		`%RESP(X-ENVOY-UPSTREAM-SERVICE-TIME)% "%REQ(X-FORWARDED-FOR)%" "%REQ(USER-AGENT)%" ` +
+		`"%REQ(X-REQUEST-ID)%" "%REQ(:AUTHORITY)%" "%UPSTREAM_HOST%"` + "\n"
+)
+
+// GetAccessLogConfigForNamespace returns the access logging configuration of the sidecars in the given namespace,
+// which is the mesh-wide configuration overridden by the fields set in the namespace's access log annotation.
+func GetAccessLogConfigForNamespace(meshWide configv1alpha1.AccessLogSpec, ns *corev1.Namespace) configv1alpha1.AccessLogSpec {
+	if ns == nil {
+		return meshWide
+	}
+	annotation, ok := ns.Annotations[constants.AccessLogAnnotation]
+	if !ok {
+		return meshWide
+	}
+
+	config := meshWide.DeepCopy()
+	if err := json.Unmarshal([]byte(annotation), config); err != nil {
+		log.Error().Err(err).Msgf("Invalid value for annotation %s on namespace %s, using the mesh-wide access log configuration",
+			constants.AccessLogAnnotation, ns.Name)
+		return meshWide
+	}
+	return *config
+}
+
+// UsesAccessLogCluster returns true if the given access logging configuration sends access logs to a gRPC collector,
+// which requires the access log cluster.
+func UsesAccessLogCluster(config configv1alpha1.AccessLogSpec) bool {
+	if config.Enable != nil && !*config.Enable {
+		return false
+	}
+	return config.Sink.Type == configv1alpha1.AccessLogSinkGRPC || config.Sink.Type == configv1alpha1.AccessLogSinkOpenTelemetry
+}
+
+// GetHTTPAccessLogs returns the Envoy access loggers for HTTP connection managers for the given access logging configuration.
+func GetHTTPAccessLogs(config configv1alpha1.AccessLogSpec) []*xds_accesslog_filter.AccessLog {
+	return getAccessLogs(config, true)
+}
+
+// GetTCPAccessLogs returns the Envoy access loggers for TCP proxies for the given access logging configuration.
+// Filters on HTTP attributes, such as the status code and path, do not apply to TCP connections.
+func GetTCPAccessLogs(config configv1alpha1.AccessLogSpec) []*xds_accesslog_filter.AccessLog {
+	return getAccessLogs(config, false)
+}
+
+func getAccessLogs(config configv1alpha1.AccessLogSpec, isHTTP bool) []*xds_accesslog_filter.AccessLog {
+	if config.Enable != nil && !*config.Enable {
+		return nil
+	}
+
+	name, typedConfig, err := getAccessLogTypedConfig(config, isHTTP)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building access log config for sink %s", config.Sink.Type)
+		return nil
+	}
+
+	return []*xds_accesslog_filter.AccessLog{{
+		Name:   name,
+		Filter: getAccessLogFilter(config, isHTTP),
+		ConfigType: &xds_accesslog_filter.AccessLog_TypedConfig{
+			TypedConfig: typedConfig,
+		},
+	}}
+}
+
+func getAccessLogTypedConfig(config configv1alpha1.AccessLogSpec, isHTTP bool) (string, *any.Any, error) {
+	var name string
+	var accessLogger proto.Message
+
+	switch config.Sink.Type {
+	case configv1alpha1.AccessLogSinkGRPC:
+		if isHTTP {
+			name = HTTPGRPCAccessLoggerName
+			accessLogger = &xds_grpc_accesslog.HttpGrpcAccessLogConfig{
+				CommonConfig: getCommonGRPCAccessLogConfig(config),
+			}
+		} else {
+			name = TCPGRPCAccessLoggerName
+			accessLogger = &xds_grpc_accesslog.TcpGrpcAccessLogConfig{
+				CommonConfig: getCommonGRPCAccessLogConfig(config),
+			}
+		}
+
+	case configv1alpha1.AccessLogSinkOpenTelemetry:
+		name = OpenTelemetryAccessLoggerName
+		accessLogger = getOpenTelemetryAccessLog(config)
+
+	case configv1alpha1.AccessLogSinkStdout, "":
+		name = AccessLoggerName
+		accessLogger = &xds_accesslog.StdoutAccessLog{
+			AccessLogFormat: &xds_accesslog.StdoutAccessLog_LogFormat{
+				LogFormat: getAccessLogFormat(config),
+			},
+		}
+
+	default:
+		return "", nil, errors.Errorf("unsupported access log sink type %s", config.Sink.Type)
+	}
+
+	marshalled, err := ptypes.MarshalAny(accessLogger)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error marshalling access log config")
+	}
+	return name, marshalled, nil
+}
+
+func getCommonGRPCAccessLogConfig(config configv1alpha1.AccessLogSpec) *xds_grpc_accesslog.CommonGrpcAccessLogConfig {
+	logName := config.Sink.LogName
+	if logName == "" {
+		logName = defaultAccessLogName
+	}
+	return &xds_grpc_accesslog.CommonGrpcAccessLogConfig{
+		LogName: logName,
+		GrpcService: &xds_core.GrpcService{
+			TargetSpecifier: &xds_core.GrpcService_EnvoyGrpc_{
+				EnvoyGrpc: &xds_core.GrpcService_EnvoyGrpc{
+					ClusterName: constants.EnvoyAccessLogCluster,
+				},
+			},
+		},
+		TransportApiVersion: xds_core.ApiVersion_V3,
+	}
+}
+
+// getOpenTelemetryAccessLog returns the OpenTelemetry access logger. The access log fields are sent as attributes,
+// while the body is the text format string when the text format is used.
+func getOpenTelemetryAccessLog(config configv1alpha1.AccessLogSpec) *xds_otel_accesslog.OpenTelemetryAccessLogConfig {
+	accessLogger := &xds_otel_accesslog.OpenTelemetryAccessLogConfig{
+		CommonConfig: getCommonGRPCAccessLogConfig(config),
+	}
+
+	if config.Format == configv1alpha1.AccessLogFormatText {
+		accessLogger.Body = &otlp_common.AnyValue{
+			Value: &otlp_common.AnyValue_StringValue{StringValue: getAccessLogTextFormat(config)},
+		}
+		return accessLogger
+	}
+
+	fields := getAccessLogJSONFields(config)
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := &otlp_common.KeyValueList{}
+	for _, key := range keys {
+		attributes.Values = append(attributes.Values, &otlp_common.KeyValue{
+			Key:   key,
+			Value: &otlp_common.AnyValue{Value: &otlp_common.AnyValue_StringValue{StringValue: fields[key]}},
+		})
+	}
+	accessLogger.Attributes = attributes
+	return accessLogger
+}
+
+func getAccessLogFormat(config configv1alpha1.AccessLogSpec) *xds_core.SubstitutionFormatString {
+	if config.Format == configv1alpha1.AccessLogFormatText {
+		return &xds_core.SubstitutionFormatString{
+			Format: &xds_core.SubstitutionFormatString_TextFormatSource{
+				TextFormatSource: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_InlineString{
+						InlineString: getAccessLogTextFormat(config),
+					},
+				},
+			},
+		}
+	}
+
+	jsonFields := make(map[string]*structpb.Value)
+	for key, value := range getAccessLogJSONFields(config) {
+		jsonFields[key] = pbStringValue(value)
+	}
+	return &xds_core.SubstitutionFormatString{
+		Format: &xds_core.SubstitutionFormatString_JsonFormat{
+			JsonFormat: &structpb.Struct{
+				Fields: jsonFields,
+			},
+		},
+	}
+}
+
+func getAccessLogTextFormat(config configv1alpha1.AccessLogSpec) string {
+	if config.TextFormat == "" {
+		return defaultAccessLogTextFormat
+	}
+	return config.TextFormat
+}
+
+// getAccessLogJSONFields returns the default JSON access log fields along with the additional fields, which take
+// precedence over the default fields with the same name.
+func getAccessLogJSONFields(config configv1alpha1.AccessLogSpec) map[string]string {
+	fields := make(map[string]string)
+	for key, value := range getStdoutAccessLog().GetLogFormat().GetJsonFormat().GetFields() {
+		fields[key] = value.GetStringValue()
+	}
+	for key, value := range config.AdditionalFields {
+		fields[key] = value
+	}
+	return fields
+}
+
+// getAccessLogFilter returns the filter restricting the logged requests, or nil if every request is logged.
+func getAccessLogFilter(config configv1alpha1.AccessLogSpec, isHTTP bool) *xds_accesslog_filter.AccessLogFilter {
+	var filters []*xds_accesslog_filter.AccessLogFilter
+
+	if isHTTP && config.Filter.MinStatusCode > 0 {
+		filters = append(filters, &xds_accesslog_filter.AccessLogFilter{
+			FilterSpecifier: &xds_accesslog_filter.AccessLogFilter_StatusCodeFilter{
+				StatusCodeFilter: &xds_accesslog_filter.StatusCodeFilter{
+					Comparison: &xds_accesslog_filter.ComparisonFilter{
+						Op: xds_accesslog_filter.ComparisonFilter_GE,
+						Value: &xds_core.RuntimeUInt32{
+							DefaultValue: config.Filter.MinStatusCode,
+							RuntimeKey:   "osm.access_log.min_status_code",
+						},
+					},
+				},
+			},
+		})
+	}
+
+	if isHTTP {
+		for _, prefix := range config.Filter.ExcludePathPrefixes {
+			filters = append(filters, &xds_accesslog_filter.AccessLogFilter{
+				FilterSpecifier: &xds_accesslog_filter.AccessLogFilter_HeaderFilter{
+					HeaderFilter: &xds_accesslog_filter.HeaderFilter{
+						Header: &xds_route.HeaderMatcher{
+							Name: ":path",
+							HeaderMatchSpecifier: &xds_route.HeaderMatcher_PrefixMatch{
+								PrefixMatch: prefix,
+							},
+							InvertMatch: true,
+						},
+					},
+				},
+			})
+		}
+	}
+
+	if config.SamplingPercentage != nil && *config.SamplingPercentage < 100 {
+		filters = append(filters, &xds_accesslog_filter.AccessLogFilter{
+			FilterSpecifier: &xds_accesslog_filter.AccessLogFilter_RuntimeFilter{
+				RuntimeFilter: &xds_accesslog_filter.RuntimeFilter{
+					RuntimeKey: accessLogSamplingRuntimeKey,
+					PercentSampled: &xds_type.FractionalPercent{
+						// Sampling percentages are converted to parts per million to preserve their precision
+						Numerator:   uint32(*config.SamplingPercentage * 10000),
+						Denominator: xds_type.FractionalPercent_MILLION,
+					},
+					UseIndependentRandomness: true,
+				},
+			},
+		})
+	}
+
+	switch len(filters) {
+	case 0:
+		return nil
+	case 1:
+		return filters[0]
+	default:
+		return &xds_accesslog_filter.AccessLogFilter{
+			FilterSpecifier: &xds_accesslog_filter.AccessLogFilter_AndFilter{
+				AndFilter: &xds_accesslog_filter.AndFilter{
+					Filters: filters,
+				},
+			},
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package envoy
+
+import (
+	"testing"
+
+	xds_accesslog_filter "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	xds_grpc_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
+	xds_otel_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/open_telemetry/v3"
+	xds_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/stream/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestGetAccessLogConfigForNamespace(t *testing.T) {
+	enabled := true
+	meshWide := configv1alpha1.AccessLogSpec{
+		Enable: &enabled,
+		Format: configv1alpha1.AccessLogFormatJSON,
+		Sink: configv1alpha1.AccessLogSinkSpec{
+			Type: configv1alpha1.AccessLogSinkStdout,
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		ns       *corev1.Namespace
+		expected configv1alpha1.AccessLogSpec
+	}{
+		{
+			name:     "namespace not found",
+			ns:       nil,
+			expected: meshWide,
+		},
+		{
+			name: "namespace without annotation",
+			ns: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "ns"},
+			},
+			expected: meshWide,
+		},
+		{
+			name: "namespace with invalid annotation",
+			ns: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ns",
+					Annotations: map[string]string{constants.AccessLogAnnotation: "invalid"},
+				},
+			},
+			expected: meshWide,
+		},
+		{
+			name: "namespace overriding the format and filter",
+			ns: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ns",
+					Annotations: map[string]string{constants.AccessLogAnnotation: `{"format": "text", "filter": {"minStatusCode": 400}}`},
+				},
+			},
+			expected: configv1alpha1.AccessLogSpec{
+				Enable: &enabled,
+				Format: configv1alpha1.AccessLogFormatText,
+				Filter: configv1alpha1.AccessLogFilterSpec{
+					MinStatusCode: 400,
+				},
+				Sink: configv1alpha1.AccessLogSinkSpec{
+					Type: configv1alpha1.AccessLogSinkStdout,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, GetAccessLogConfigForNamespace(meshWide, tc.ns))
+		})
+	}
+}
+
+func TestGetHTTPAccessLogs(t *testing.T) {
+	disabled := false
+	samplingPercentage := 10.0
+
+	testCases := []struct {
+		name                   string
+		config                 configv1alpha1.AccessLogSpec
+		expectedName           string
+		expectedFilterCount    int
+		validateTypedConfig    func(*tassert.Assertions, *xds_accesslog_filter.AccessLog)
+		expectedAccessLogCount int
+	}{
+		{
+			name:                   "disabled",
+			config:                 configv1alpha1.AccessLogSpec{Enable: &disabled},
+			expectedAccessLogCount: 0,
+		},
+		{
+			name:                   "default stdout JSON access log",
+			config:                 configv1alpha1.AccessLogSpec{},
+			expectedAccessLogCount: 1,
+			expectedName:           AccessLoggerName,
+			validateTypedConfig: func(assert *tassert.Assertions, accessLog *xds_accesslog_filter.AccessLog) {
+				stdout := &xds_accesslog.StdoutAccessLog{}
+				assert.Nil(ptypes.UnmarshalAny(accessLog.GetTypedConfig(), stdout))
+				assert.Contains(stdout.GetLogFormat().GetJsonFormat().GetFields(), "response_code")
+			},
+		},
+		{
+			name: "stdout text access log",
+			config: configv1alpha1.AccessLogSpec{
+				Format:     configv1alpha1.AccessLogFormatText,
+				TextFormat: "%RESPONSE_CODE%\n",
+			},
+			expectedAccessLogCount: 1,
+			expectedName:           AccessLoggerName,
+			validateTypedConfig: func(assert *tassert.Assertions, accessLog *xds_accesslog_filter.AccessLog) {
+				stdout := &xds_accesslog.StdoutAccessLog{}
+				assert.Nil(ptypes.UnmarshalAny(accessLog.GetTypedConfig(), stdout))
+				assert.Equal("%RESPONSE_CODE%\n", stdout.GetLogFormat().GetTextFormatSource().GetInlineString())
+			},
+		},
+		{
+			name: "gRPC access log with filters and sampling",
+			config: configv1alpha1.AccessLogSpec{
+				Filter: configv1alpha1.AccessLogFilterSpec{
+					MinStatusCode:       400,
+					ExcludePathPrefixes: []string{"/healthz"},
+				},
+				SamplingPercentage: &samplingPercentage,
+				Sink: configv1alpha1.AccessLogSinkSpec{
+					Type: configv1alpha1.AccessLogSinkGRPC,
+				},
+			},
+			expectedAccessLogCount: 1,
+			expectedName:           HTTPGRPCAccessLoggerName,
+			expectedFilterCount:    3,
+			validateTypedConfig: func(assert *tassert.Assertions, accessLog *xds_accesslog_filter.AccessLog) {
+				grpcConfig := &xds_grpc_accesslog.HttpGrpcAccessLogConfig{}
+				assert.Nil(ptypes.UnmarshalAny(accessLog.GetTypedConfig(), grpcConfig))
+				assert.Equal(defaultAccessLogName, grpcConfig.CommonConfig.LogName)
+				assert.Equal(constants.EnvoyAccessLogCluster, grpcConfig.CommonConfig.GrpcService.GetEnvoyGrpc().ClusterName)
+
+				runtimeFilter := accessLog.Filter.GetAndFilter().Filters[2].GetRuntimeFilter()
+				assert.Equal(uint32(100000), runtimeFilter.PercentSampled.Numerator)
+				assert.Equal(xds_type.FractionalPercent_MILLION, runtimeFilter.PercentSampled.Denominator)
+			},
+		},
+		{
+			name: "OpenTelemetry access log with additional fields",
+			config: configv1alpha1.AccessLogSpec{
+				AdditionalFields: map[string]string{"peer_identity": "%DOWNSTREAM_PEER_URI_SAN%"},
+				Filter: configv1alpha1.AccessLogFilterSpec{
+					MinStatusCode: 500,
+				},
+				Sink: configv1alpha1.AccessLogSinkSpec{
+					Type:    configv1alpha1.AccessLogSinkOpenTelemetry,
+					LogName: "mesh",
+				},
+			},
+			expectedAccessLogCount: 1,
+			expectedName:           OpenTelemetryAccessLoggerName,
+			expectedFilterCount:    1,
+			validateTypedConfig: func(assert *tassert.Assertions, accessLog *xds_accesslog_filter.AccessLog) {
+				otelConfig := &xds_otel_accesslog.OpenTelemetryAccessLogConfig{}
+				assert.Nil(ptypes.UnmarshalAny(accessLog.GetTypedConfig(), otelConfig))
+				assert.Equal("mesh", otelConfig.CommonConfig.LogName)
+
+				var found bool
+				for _, attribute := range otelConfig.Attributes.Values {
+					if attribute.Key == "peer_identity" {
+						found = true
+						assert.Equal("%DOWNSTREAM_PEER_URI_SAN%", attribute.Value.GetStringValue())
+					}
+				}
+				assert.True(found)
+				assert.Equal(uint32(500), accessLog.Filter.GetStatusCodeFilter().Comparison.Value.DefaultValue)
+			},
+		},
+		{
+			name: "unsupported sink",
+			config: configv1alpha1.AccessLogSpec{
+				Sink: configv1alpha1.AccessLogSinkSpec{Type: "file"},
+			},
+			expectedAccessLogCount: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			accessLogs := GetHTTPAccessLogs(tc.config)
+			assert.Len(accessLogs, tc.expectedAccessLogCount)
+			if tc.expectedAccessLogCount == 0 {
+				return
+			}
+
+			assert.Equal(tc.expectedName, accessLogs[0].Name)
+			switch tc.expectedFilterCount {
+			case 0:
+				assert.Nil(accessLogs[0].Filter)
+			case 1:
+				assert.Nil(accessLogs[0].Filter.GetAndFilter())
+			default:
+				assert.Len(accessLogs[0].Filter.GetAndFilter().Filters, tc.expectedFilterCount)
+			}
+			tc.validateTypedConfig(assert, accessLogs[0])
+		})
+	}
+}
+
+func TestGetTCPAccessLogs(t *testing.T) {
+	assert := tassert.New(t)
+
+	accessLogs := GetTCPAccessLogs(configv1alpha1.AccessLogSpec{
+		Filter: configv1alpha1.AccessLogFilterSpec{
+			MinStatusCode:       400,
+			ExcludePathPrefixes: []string{"/healthz"},
+		},
+		Sink: configv1alpha1.AccessLogSinkSpec{
+			Type: configv1alpha1.AccessLogSinkGRPC,
+		},
+	})
+	assert.Len(accessLogs, 1)
+	assert.Equal(TCPGRPCAccessLoggerName, accessLogs[0].Name)
+	// HTTP filters do not apply to TCP connections
+	assert.Nil(accessLogs[0].Filter)
+}
+
+func TestUsesAccessLogCluster(t *testing.T) {
+	assert := tassert.New(t)
+	disabled := false
+
+	assert.False(UsesAccessLogCluster(configv1alpha1.AccessLogSpec{}))
+	assert.True(UsesAccessLogCluster(configv1alpha1.AccessLogSpec{Sink: configv1alpha1.AccessLogSinkSpec{Type: configv1alpha1.AccessLogSinkGRPC}}))
+	assert.True(UsesAccessLogCluster(configv1alpha1.AccessLogSpec{Sink: configv1alpha1.AccessLogSinkSpec{Type: configv1alpha1.AccessLogSinkOpenTelemetry}}))
+	assert.False(UsesAccessLogCluster(configv1alpha1.AccessLogSpec{Enable: &disabled, Sink: configv1alpha1.AccessLogSinkSpec{Type: configv1alpha1.AccessLogSinkGRPC}}))
+}
@@ -1,21 +1,25 @@
 package ads
 
 import (
+	"context"
 	"fmt"
 	"net"
 
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
 // Routine which fulfills listening to proxy broadcasts
@@ -23,21 +27,35 @@ func (s *Server) broadcastListener() {
 	// Register to Envoy global broadcast updates
 	broadcastUpdate := events.Subscribe(announcements.ProxyBroadcast)
 	for {
-		<-broadcastUpdate
-		s.allPodUpdater()
+		broadcastMsg := <-broadcastUpdate
+		update, _ := broadcastMsg.(events.PubSubMessage).NewObj.(*catalog.ProxyUpdate)
+		s.allPodUpdater(update)
 	}
 }
 
-func (s *Server) allPodUpdater() {
+// allPodUpdater queues an update for the proxies of all pods depending on the given update.
+// A nil update is a full update of all proxies.
+func (s *Server) allPodUpdater(update *catalog.ProxyUpdate) {
 	allpods := s.kubecontroller.ListPods()
+	proxyCNs := make(map[certificate.CommonName]struct{})
+
+	// There are no streams to track the proxies going away in snapshot cache mode, so the index
+	// only retains the proxies of the existing pods
+	defer s.dependencies.retain(proxyCNs)
 
 	for _, pod := range allpods {
-		proxy, err := GetProxyFromPod(pod)
+		proxy, err := GetProxyFromPod(pod, s.cfg.GetTrustDomain())
 		if err != nil {
 			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingProxyFromPod)).
 				Msgf("Could not get proxy from pod %s/%s", pod.Namespace, pod.Name)
 			continue
 		}
+		proxyCNs[proxy.GetCertificateCommonName()] = struct{}{}
+
+		if !s.dependencies.dependsOn(proxy, update) {
+			metricsstore.DefaultMetricsStore.ProxyPushAvoidedCount.Inc()
+			continue
+		}
 
 		// Queue update for this proxy/pod
 		job := proxyResponseJob{
@@ -57,7 +75,7 @@ func (s *Server) allPodUpdater() {
 // however snapshotcache has no need to provide visibility on proxies whatsoever.
 // All verticals use the proxy structure to infer the pod later, so the actual only mandatory
 // data for the verticals to be functional is the common name, which links proxy <-> pod
-func GetProxyFromPod(pod *v1.Pod) (*envoy.Proxy, error) {
+func GetProxyFromPod(pod *v1.Pod, trustDomain string) (*envoy.Proxy, error) {
 	var serviceAccount string
 	var namespace string
 
@@ -75,7 +93,7 @@ func GetProxyFromPod(pod *v1.Pod) (*envoy.Proxy, error) {
 
 	// construct CN for this pod/proxy
 	// TODO: Infer proxy type from Pod
-	commonName := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, serviceAccount, namespace)
+	commonName := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, serviceAccount, namespace, trustDomain)
 	tempProxy, err := envoy.NewProxy(certificate.CommonName(commonName), "NoSerial", &net.IPAddr{IP: net.IPv4zero})
 
 	return tempProxy, err
@@ -89,19 +107,23 @@ func (s *Server) RecordFullSnapshot(proxy *envoy.Proxy, snapshotResources map[en
 	s.configVersion[proxy.GetCertificateCommonName().String()]++
 	s.configVerMutex.Unlock()
 
-	snapshot := cache.NewSnapshot(
+	snapshot, err := cache.NewSnapshot(
 		fmt.Sprintf("%d", s.configVersion[proxy.GetCertificateCommonName().String()]),
-		snapshotResources[envoy.TypeEDS],
-		snapshotResources[envoy.TypeCDS],
-		snapshotResources[envoy.TypeRDS],
-		snapshotResources[envoy.TypeLDS],
-		[]types.Resource{}, // Runtimes
-		snapshotResources[envoy.TypeSDS],
+		map[resource.Type][]types.Resource{
+			resource.EndpointType: snapshotResources[envoy.TypeEDS],
+			resource.ClusterType:  snapshotResources[envoy.TypeCDS],
+			resource.RouteType:    snapshotResources[envoy.TypeRDS],
+			resource.ListenerType: snapshotResources[envoy.TypeLDS],
+			resource.SecretType:   snapshotResources[envoy.TypeSDS],
+		},
 	)
+	if err != nil {
+		return err
+	}
 
 	if err := snapshot.Consistent(); err != nil {
 		log.Warn().Msgf("Snapshot for for proxy %s not consistent: %v", proxy.GetCertificateCommonName(), err)
 	}
 
-	return s.ch.SetSnapshot(proxy.GetCertificateCommonName().String(), snapshot)
+	return s.ch.SetSnapshot(context.Background(), proxy.GetCertificateCommonName().String(), snapshot)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
 )
 
 func TestGetProxyFromPod(t *testing.T) {
@@ -22,7 +23,7 @@ func TestGetProxyFromPod(t *testing.T) {
 		namespace       = "namespace"
 		serviceAccount  = "serviceAccount"
 		validUUID       = uuid.New()
-		validCommonName = envoy.NewXDSCertCommonName(validUUID, envoy.KindSidecar, serviceAccount, namespace)
+		validCommonName = envoy.NewXDSCertCommonName(validUUID, envoy.KindSidecar, serviceAccount, namespace, identity.ClusterLocalTrustDomain)
 	)
 
 	testCases := []struct {
@@ -85,7 +86,7 @@ func TestGetProxyFromPod(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		proxyResult, err := GetProxyFromPod(testCase.pod)
+		proxyResult, err := GetProxyFromPod(testCase.pod, identity.ClusterLocalTrustDomain)
 
 		if testCase.expectErr {
 			assert.Error(err)
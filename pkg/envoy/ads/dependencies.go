@@ -0,0 +1,135 @@
+package ads
+
+import (
+	"sync"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// dependencyIndex maps the services to the proxies whose configuration depends on them, such that an update
+// scoped to a set of services only updates the proxies depending on these services
+type dependencyIndex struct {
+	mutex sync.RWMutex
+
+	// dependents maps a service to the proxies whose configuration depends on it
+	dependents map[service.MeshService]map[certificate.CommonName]struct{}
+
+	// dependencies maps a proxy to the services its configuration depends on
+	dependencies map[certificate.CommonName][]service.MeshService
+}
+
+func newDependencyIndex() *dependencyIndex {
+	return &dependencyIndex{
+		dependents:   make(map[service.MeshService]map[certificate.CommonName]struct{}),
+		dependencies: make(map[certificate.CommonName][]service.MeshService),
+	}
+}
+
+// record replaces the services the configuration of the proxy with the given CN depends on
+func (d *dependencyIndex) record(cn certificate.CommonName, services []service.MeshService) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.removeLocked(cn)
+	for _, svc := range services {
+		if _, ok := d.dependents[svc]; !ok {
+			d.dependents[svc] = make(map[certificate.CommonName]struct{})
+		}
+		d.dependents[svc][cn] = struct{}{}
+	}
+	d.dependencies[cn] = services
+}
+
+// remove removes the proxy with the given CN from the index
+func (d *dependencyIndex) remove(cn certificate.CommonName) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.removeLocked(cn)
+}
+
+// retain removes the proxies whose CN is not in the given set from the index
+func (d *dependencyIndex) retain(cns map[certificate.CommonName]struct{}) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for cn := range d.dependencies {
+		if _, ok := cns[cn]; !ok {
+			d.removeLocked(cn)
+		}
+	}
+}
+
+func (d *dependencyIndex) removeLocked(cn certificate.CommonName) {
+	for _, svc := range d.dependencies[cn] {
+		delete(d.dependents[svc], cn)
+		if len(d.dependents[svc]) == 0 {
+			delete(d.dependents, svc)
+		}
+	}
+	delete(d.dependencies, cn)
+}
+
+// dependsOn returns true if the configuration of the given proxy depends on the given update. Full updates,
+// gateways and the proxies whose dependencies are not known yet are always updated.
+func (d *dependencyIndex) dependsOn(proxy *envoy.Proxy, update *catalog.ProxyUpdate) bool {
+	if update.IsFull() || proxy.Kind() != envoy.KindSidecar {
+		return true
+	}
+	if _, ok := update.ProxyUUIDs[proxy.GetUUID().String()]; ok {
+		return true
+	}
+
+	cn := proxy.GetCertificateCommonName()
+	if len(update.Identities) > 0 {
+		if proxyIdentity, err := envoy.GetServiceIdentityFromProxyCertificate(cn); err == nil {
+			if _, ok := update.Identities[proxyIdentity]; ok {
+				return true
+			}
+		}
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if _, indexed := d.dependencies[cn]; !indexed {
+		return true
+	}
+	for svc := range update.Services {
+		if _, ok := d.dependents[svc][cn]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// recordProxyDependencies indexes the services the configuration of the given proxy depends on: the services
+// the proxy belongs to, the services it is allowed to connect to, and the IngressBackend source services whose
+// endpoints are allowed to send ingress traffic to the proxy's services
+func (s *Server) recordProxyDependencies(proxy *envoy.Proxy) {
+	if proxy.Kind() != envoy.KindSidecar {
+		return
+	}
+
+	cn := proxy.GetCertificateCommonName()
+	proxyServices, err := s.proxyRegistry.ListProxyServices(proxy)
+	if err != nil {
+		// Without known dependencies, the proxy is updated on every change
+		log.Debug().Err(err).Msgf("Error listing services for proxy %s, not indexing its dependencies", proxy.String())
+		s.dependencies.remove(cn)
+		return
+	}
+	proxyIdentity, err := envoy.GetServiceIdentityFromProxyCertificate(cn)
+	if err != nil {
+		log.Debug().Err(err).Msgf("Error looking up identity for proxy %s, not indexing its dependencies", proxy.String())
+		s.dependencies.remove(cn)
+		return
+	}
+
+	services := append(proxyServices, s.catalog.ListOutboundServicesForIdentity(proxyIdentity)...)
+	for _, svc := range proxyServices {
+		services = append(services, s.catalog.ListIngressSourceServices(svc)...)
+	}
+	s.dependencies.record(cn, services)
+}
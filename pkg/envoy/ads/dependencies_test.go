@@ -0,0 +1,126 @@
+package ads
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestDependencyIndex(t *testing.T) {
+	assert := tassert.New(t)
+
+	bookstore := service.MeshService{Namespace: "default", Name: "bookstore"}
+	bookbuyer := service.MeshService{Namespace: "default", Name: "bookbuyer"}
+	bookwarehouse := service.MeshService{Namespace: "default", Name: "bookwarehouse"}
+
+	newProxy := func(kind envoy.ProxyKind) *envoy.Proxy {
+		proxy, err := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.svc-acc.default", uuid.New(), kind)), "1", nil)
+		assert.Nil(err)
+		return proxy
+	}
+	newUpdate := func(proxyUUIDs []string, services ...service.MeshService) *catalog.ProxyUpdate {
+		update := &catalog.ProxyUpdate{
+			Services:   make(map[service.MeshService]struct{}),
+			ProxyUUIDs: make(map[string]struct{}),
+		}
+		for _, svc := range services {
+			update.Services[svc] = struct{}{}
+		}
+		for _, proxyUUID := range proxyUUIDs {
+			update.ProxyUUIDs[proxyUUID] = struct{}{}
+		}
+		return update
+	}
+
+	index := newDependencyIndex()
+	bookbuyerProxy := newProxy(envoy.KindSidecar)
+	bookstoreProxy := newProxy(envoy.KindSidecar)
+	gateway := newProxy(envoy.KindGateway)
+
+	// Proxies whose dependencies are not known yet are always updated
+	assert.True(index.dependsOn(bookbuyerProxy, newUpdate(nil, bookwarehouse)))
+
+	index.record(bookbuyerProxy.GetCertificateCommonName(), []service.MeshService{bookbuyer, bookstore})
+	index.record(bookstoreProxy.GetCertificateCommonName(), []service.MeshService{bookstore, bookwarehouse})
+
+	// Full updates and gateways are not scoped
+	assert.True(index.dependsOn(bookbuyerProxy, nil))
+	assert.True(index.dependsOn(bookbuyerProxy, &catalog.ProxyUpdate{Full: true}))
+	assert.True(index.dependsOn(gateway, newUpdate(nil, bookwarehouse)))
+
+	assert.True(index.dependsOn(bookbuyerProxy, newUpdate(nil, bookstore)))
+	assert.True(index.dependsOn(bookstoreProxy, newUpdate(nil, bookstore)))
+	assert.False(index.dependsOn(bookbuyerProxy, newUpdate(nil, bookwarehouse)))
+	assert.True(index.dependsOn(bookstoreProxy, newUpdate(nil, bookwarehouse)))
+
+	// Updates directly affecting a proxy
+	assert.True(index.dependsOn(bookbuyerProxy, newUpdate([]string{bookbuyerProxy.GetUUID().String()})))
+	assert.False(index.dependsOn(bookbuyerProxy, newUpdate([]string{bookstoreProxy.GetUUID().String()})))
+
+	// Updates directly affecting the proxies of an identity
+	identityUpdate := newUpdate(nil)
+	identityUpdate.Identities = map[identity.ServiceIdentity]struct{}{"svc-acc.default": {}}
+	assert.True(index.dependsOn(bookbuyerProxy, identityUpdate))
+	identityUpdate.Identities = map[identity.ServiceIdentity]struct{}{"other.default": {}}
+	assert.False(index.dependsOn(bookbuyerProxy, identityUpdate))
+
+	// Recording replaces the previous dependencies
+	index.record(bookbuyerProxy.GetCertificateCommonName(), []service.MeshService{bookwarehouse})
+	assert.False(index.dependsOn(bookbuyerProxy, newUpdate(nil, bookstore)))
+	assert.True(index.dependsOn(bookbuyerProxy, newUpdate(nil, bookwarehouse)))
+
+	index.remove(bookbuyerProxy.GetCertificateCommonName())
+	assert.Len(index.dependencies, 1)
+	assert.Equal(map[service.MeshService]map[certificate.CommonName]struct{}{
+		bookstore:     {bookstoreProxy.GetCertificateCommonName(): {}},
+		bookwarehouse: {bookstoreProxy.GetCertificateCommonName(): {}},
+	}, index.dependents)
+
+	index.retain(map[certificate.CommonName]struct{}{})
+	assert.Empty(index.dependencies)
+	assert.Empty(index.dependents)
+}
+
+func TestRecordProxyDependencies(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	bookstore := service.MeshService{Namespace: "default", Name: "bookstore"}
+	bookwarehouse := service.MeshService{Namespace: "default", Name: "bookwarehouse"}
+	ingressSource := service.MeshService{Namespace: "ingress-ns", Name: "ingress"}
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	s := &Server{
+		catalog: mockCatalog,
+		proxyRegistry: registry.NewProxyRegistry(registry.ExplicitProxyServiceMapper(func(*envoy.Proxy) ([]service.MeshService, error) {
+			return []service.MeshService{bookstore}, nil
+		})),
+		dependencies: newDependencyIndex(),
+	}
+
+	proxy, err := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.bookstore.default", uuid.New(), envoy.KindSidecar)), "1", nil)
+	assert.Nil(err)
+
+	mockCatalog.EXPECT().ListOutboundServicesForIdentity(identity.ServiceIdentity("bookstore.default")).Return([]service.MeshService{bookwarehouse}).Times(1)
+	mockCatalog.EXPECT().ListIngressSourceServices(bookstore).Return([]service.MeshService{ingressSource}).Times(1)
+	s.recordProxyDependencies(proxy)
+
+	// The endpoints of the IngressBackend source services are allowed ingress traffic sources of the proxy
+	update := &catalog.ProxyUpdate{Services: map[service.MeshService]struct{}{ingressSource: {}}}
+	assert.True(s.dependencies.dependsOn(proxy, update))
+	update = &catalog.ProxyUpdate{Services: map[service.MeshService]struct{}{bookwarehouse: {}}}
+	assert.True(s.dependencies.dependsOn(proxy, update))
+	update = &catalog.ProxyUpdate{Services: map[service.MeshService]struct{}{{Namespace: "default", Name: "bookbuyer"}: {}}}
+	assert.False(s.dependencies.dependsOn(proxy, update))
+}
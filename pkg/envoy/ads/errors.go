@@ -8,5 +8,6 @@ var errUnknownTypeURL = errors.New("unknown TypeUrl")
 var errCreatingResponse = errors.New("creating response")
 var errGrpcClosed = errors.New("grpc closed")
 var errTooManyConnections = errors.New("too many connections")
+var errProxyNotOwned = errors.New("proxy is served by another osm-controller replica")
 var errServiceAccountMismatch = errors.New("service account mismatch in nodeid vs xds certificate common name")
 var errUnsuportedXDSRequest = errors.New("Unsupported XDS server connection type")
@@ -30,6 +30,45 @@ func xdsPathTimeTrack(startedAt time.Time, log *zerolog.Event, typeURI envoy.Typ
 		Observe(elapsed.Seconds())
 }
 
+// trackXDSPush records an xDS response of the given TypeURI sent to the proxy
+func trackXDSPush(proxy *envoy.Proxy, typeURI envoy.TypeURI) {
+	proxy.SetLastSentAt(typeURI, time.Now())
+	metricsstore.DefaultMetricsStore.ProxyXDSPushCount.
+		WithLabelValues(typeURI.String(), string(proxy.Kind())).Inc()
+}
+
+// trackXDSAck records the proxy acknowledging the last xDS response of the given TypeURI sent to it.
+// The proxy sends further requests with the nonce of that response when it subscribes to other resources
+// of the TypeURI, only the first one is recorded as the acknowledgement of the response.
+func trackXDSAck(proxy *envoy.Proxy, typeURI envoy.TypeURI) {
+	proxy.ClearLastNACK(typeURI)
+
+	sentAt := proxy.GetLastSentAt(typeURI)
+	if sentAt.IsZero() {
+		return
+	}
+	proxy.SetLastSentAt(typeURI, time.Time{})
+
+	metricsstore.DefaultMetricsStore.ProxyXDSAckCount.
+		WithLabelValues(typeURI.String(), string(proxy.Kind())).Inc()
+	metricsstore.DefaultMetricsStore.ProxyXDSAckTime.
+		WithLabelValues(typeURI.String(), string(proxy.Kind())).
+		Observe(time.Since(sentAt).Seconds())
+}
+
+// trackXDSNack records the proxy rejecting the xDS response of the given TypeURI
+func trackXDSNack(proxy *envoy.Proxy, typeURI envoy.TypeURI, request *xds_discovery.DiscoveryRequest) {
+	proxy.SetLastNACK(&envoy.NACK{
+		TypeURI:        typeURI,
+		Nonce:          request.ResponseNonce,
+		AppliedVersion: request.VersionInfo,
+		Error:          request.ErrorDetail.GetMessage(),
+		Timestamp:      time.Now(),
+	})
+	metricsstore.DefaultMetricsStore.ProxyXDSNackCount.
+		WithLabelValues(typeURI.String(), string(proxy.Kind())).Inc()
+}
+
 func (s *Server) trackXDSLog(cn certificate.CommonName, typeURL envoy.TypeURI) {
 	s.withXdsLogMutex(func() {
 		if _, ok := s.xdsLog[cn]; !ok {
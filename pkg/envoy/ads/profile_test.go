@@ -8,10 +8,13 @@ import (
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/status"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -92,3 +95,57 @@ func TestValidateResourcesRequestResponse(t *testing.T) {
 		assert.Equal(test.expectDifference, diff)
 	}
 }
+
+func TestTrackXDSAckNack(t *testing.T) {
+	assert := tassert.New(t)
+	proxy, err := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.sidecar.foo.bar", uuid.New())), certificate.SerialNumber("123"), tests.NewMockAddress("1.2.3.4"))
+	assert.Nil(err)
+
+	trackXDSPush(proxy, envoy.TypeCDS)
+	assert.False(proxy.GetLastSentAt(envoy.TypeCDS).IsZero())
+
+	trackXDSNack(proxy, envoy.TypeCDS, &xds_discovery.DiscoveryRequest{
+		TypeUrl:       envoy.TypeCDS.String(),
+		VersionInfo:   "1",
+		ResponseNonce: "nonce-2",
+		ErrorDetail:   &status.Status{Message: "invalid cluster"},
+	})
+	nack := proxy.GetLastNACK()
+	assert.NotNil(nack)
+	assert.Equal(envoy.TypeCDS, nack.TypeURI)
+	assert.Equal("nonce-2", nack.Nonce)
+	assert.Equal("1", nack.AppliedVersion)
+	assert.Equal("invalid cluster", nack.Error)
+
+	// An ACK for another TypeURI does not clear the NACK
+	trackXDSAck(proxy, envoy.TypeLDS)
+	assert.NotNil(proxy.GetLastNACK())
+
+	trackXDSAck(proxy, envoy.TypeCDS)
+	assert.Nil(proxy.GetLastNACK())
+}
+
+func TestTrackXDSAckOncePerResponse(t *testing.T) {
+	assert := tassert.New(t)
+	proxy, err := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.sidecar.foo.bar", uuid.New())), certificate.SerialNumber("123"), tests.NewMockAddress("1.2.3.4"))
+	assert.Nil(err)
+
+	ackCount := func() int {
+		return int(testutil.ToFloat64(metricsstore.DefaultMetricsStore.ProxyXDSAckCount.
+			WithLabelValues(envoy.TypeRDS.String(), string(proxy.Kind()))))
+	}
+	initial := ackCount()
+
+	trackXDSPush(proxy, envoy.TypeRDS)
+	trackXDSAck(proxy, envoy.TypeRDS)
+	assert.True(proxy.GetLastSentAt(envoy.TypeRDS).IsZero())
+	assert.Equal(initial+1, ackCount())
+
+	// A request subscribing to other resources with the nonce of the same response is not another ACK
+	trackXDSAck(proxy, envoy.TypeRDS)
+	assert.Equal(initial+1, ackCount())
+
+	trackXDSPush(proxy, envoy.TypeRDS)
+	trackXDSAck(proxy, envoy.TypeRDS)
+	assert.Equal(initial+2, ackCount())
+}
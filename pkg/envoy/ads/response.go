@@ -8,7 +8,7 @@ import (
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
-	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -54,6 +54,14 @@ func (s *Server) sendResponse(proxy *envoy.Proxy, server *xds_discovery.Aggregat
 	osmDrivenUpdate := request == nil
 	cacheResourceMap := map[envoy.TypeURI][]types.Resource{}
 
+	// CDS is part of every full update, (re)index the services the proxy configuration depends on
+	for _, typeURI := range typeURIsToSend {
+		if typeURI == envoy.TypeCDS {
+			s.recordProxyDependencies(proxy)
+			break
+		}
+	}
+
 	// Order is important: CDS, EDS, LDS, RDS
 	// See: https://github.com/envoyproxy/go-control-plane/issues/59
 	for _, typeURI := range typeURIsToSend {
@@ -127,7 +135,7 @@ func (s *Server) SendDiscoveryResponse(proxy *envoy.Proxy, request *xds_discover
 	resourcesSent := mapset.NewSet()
 	subscribedResources := proxy.GetSubscribedResources(typeURI)
 	for _, res := range resourcesToSend {
-		proto, err := ptypes.MarshalAny(res)
+		proto, err := anypb.New(res)
 		if err != nil {
 			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
 				Msgf("Error marshalling resource %s for proxy %s", typeURI, proxy.GetCertificateSerialNumber())
@@ -167,6 +175,7 @@ func (s *Server) SendDiscoveryResponse(proxy *envoy.Proxy, request *xds_discover
 	// Sending discovery response succeeded, record last resources sent
 	// TODO: increase version and nonce only if Send succeeded
 	proxy.SetLastResourcesSent(typeURI, resourcesSent)
+	trackXDSPush(proxy, typeURI)
 
 	return nil
 }
@@ -30,10 +30,10 @@ func TestMakeRequestForAllSecrets(t *testing.T) {
 		expectedDiscoveryRequest *xds_discovery.DiscoveryRequest
 	}
 
-	proxyServiceIdentity := identity.K8sServiceAccount{Name: "test-sa", Namespace: "ns-1"}.ToServiceIdentity()
+	proxyServiceIdentity := identity.K8sServiceAccount{Name: "test-sa", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
 	proxySvcAccount := proxyServiceIdentity.ToK8sServiceAccount()
 	certSerialNumber := certificate.SerialNumber("123456")
-	proxyXDSCertCN := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, proxySvcAccount.Name, proxySvcAccount.Namespace)
+	proxyXDSCertCN := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, proxySvcAccount.Name, proxySvcAccount.Namespace, identity.ClusterLocalTrustDomain)
 	testProxy, err := envoy.NewProxy(proxyXDSCertCN, certSerialNumber, nil)
 	assert.Nil(err)
 
@@ -22,6 +22,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy/sds"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/sharding"
 	"github.com/openservicemesh/osm/pkg/utils"
 	"github.com/openservicemesh/osm/pkg/workerpool"
 )
@@ -56,11 +57,24 @@ func NewADSServer(meshCatalog catalog.MeshCataloger, proxyRegistry *registry.Pro
 		cacheEnabled:   cfg.GetFeatureFlags().EnableSnapshotCacheMode,
 		configVerMutex: sync.Mutex{},
 		configVersion:  make(map[string]uint64),
+		dependencies:   newDependencyIndex(),
 	}
 
 	return &server
 }
 
+// SetShardManager sets the shard manager determining the proxies served by this replica when the proxies
+// are sharded across the osm-controller replicas. Sharding is not supported in snapshot cache mode.
+func (s *Server) SetShardManager(shards *sharding.Manager) {
+	s.shards = shards
+}
+
+// ownsProxy returns true if the given proxy is served by this replica. Only sidecars are sharded,
+// gateways are served by any replica they connect to.
+func (s *Server) ownsProxy(proxy *envoy.Proxy) bool {
+	return proxy.Kind() != envoy.KindSidecar || s.shards.Owns(proxy.GetUUID().String())
+}
+
 // withXdsLogMutex helper to run code that touches xdsLog map, to protect by mutex
 func (s *Server) withXdsLogMutex(f func()) {
 	s.xdsMapLogMutex.Lock()
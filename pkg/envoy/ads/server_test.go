@@ -0,0 +1,54 @@
+package ads
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/sharding"
+)
+
+func TestOwnsProxy(t *testing.T) {
+	assert := tassert.New(t)
+
+	sidecar, err := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.svc-acc.namespace", uuid.New(), envoy.KindSidecar)), "1", nil)
+	assert.Nil(err)
+	gateway, err := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.svc-acc.namespace", uuid.New(), envoy.KindGateway)), "2", nil)
+	assert.Nil(err)
+
+	// Without sharding, all proxies are served
+	s := &Server{}
+	assert.True(s.ownsProxy(sidecar))
+	assert.True(s.ownsProxy(gateway))
+
+	// This replica is not ready, so all sidecars are owned by the other replica
+	kubeClient := testclient.NewSimpleClientset(&corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "osm-controller",
+			Namespace: "osm-system",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{
+					{IP: "10.0.0.2", TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "osm-controller-2"}},
+				},
+			},
+		},
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	shards, err := sharding.NewManager(kubeClient, "osm-system", "osm-controller", "osm-controller-1", stop)
+	assert.Nil(err)
+
+	s.SetShardManager(shards)
+	assert.False(s.ownsProxy(sidecar))
+	// Gateways are not sharded
+	assert.True(s.ownsProxy(gateway))
+}
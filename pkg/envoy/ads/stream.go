@@ -9,8 +9,11 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -50,6 +53,19 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 		return err
 	}
 
+	// When the proxies are sharded across the osm-controller replicas, reject the proxies owned by another
+	// replica. The proxy reconnects to the next replica resolved from the headless Service until it reaches its owner.
+	// The XDS cluster round robins over the N ready replicas, so the owner is reached within N-1 rejected streams.
+	// Envoy delays each retry with a jittered exponential backoff starting at 500ms and capped at 30s, which bounds
+	// the reconnect latency to about 0.5s*(2^(N-1)-1) for small N. The proxy keeps its last received config meanwhile.
+	if !s.ownsProxy(proxy) {
+		log.Debug().Msgf("Proxy with certificate SerialNumber=%s is owned by osm-controller replica %s, closing stream",
+			certSerialNumber, s.shards.GetOwner(proxy.GetUUID().String()))
+		metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
+		metricsstore.DefaultMetricsStore.ProxyShardRedirectCount.Inc()
+		return status.Error(codes.Unavailable, errProxyNotOwned.Error())
+	}
+
 	if err := s.recordPodMetadata(proxy); err == errServiceAccountMismatch {
 		// Service Account mismatch
 		log.Error().Err(err).Msgf("Mismatched service account for proxy with certificate SerialNumber=%s", certSerialNumber)
@@ -59,6 +75,7 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 	s.proxyRegistry.RegisterProxy(proxy)
 
 	defer s.proxyRegistry.UnregisterProxy(proxy)
+	defer s.dependencies.remove(proxy.GetCertificateCommonName())
 
 	ctx, cancel := context.WithCancel(server.Context())
 	defer cancel()
@@ -76,6 +93,10 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 	// Register for certificate rotation updates
 	certAnnouncement := events.Subscribe(announcements.CertificateRotated)
 
+	// Register for the redistribution of proxies across the osm-controller replicas
+	shardsRebalanced := events.Subscribe(announcements.ADSShardsRebalanced)
+	defer events.Unsub(shardsRebalanced)
+
 	newJob := func(typeURIs []envoy.TypeURI, discoveryRequest *xds_discovery.DiscoveryRequest) *proxyResponseJob {
 		return &proxyResponseJob{
 			typeURIs:  typeURIs,
@@ -116,7 +137,13 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 			<-s.workqueues.AddJob(newJob(typesRequest, &discoveryRequest))
 
-		case <-broadcastUpdate:
+		case broadcastMsg := <-broadcastUpdate:
+			update, _ := broadcastMsg.(events.PubSubMessage).NewObj.(*catalog.ProxyUpdate)
+			if !s.dependencies.dependsOn(proxy, update) {
+				log.Debug().Msgf("Proxy %s does not depend on the broadcast update, skipping", proxy.String())
+				metricsstore.DefaultMetricsStore.ProxyPushAvoidedCount.Inc()
+				continue
+			}
 			log.Info().Msgf("Broadcast update received for proxy %s", proxy.String())
 
 			// Per protocol, we have to wait for the proxy to go through init phase (initial no-nonce request),
@@ -131,6 +158,16 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 			// Do not send SDS, let envoy figure out what certs does it want.
 			<-s.workqueues.AddJob(newJob([]envoy.TypeURI{envoy.TypeCDS, envoy.TypeEDS, envoy.TypeLDS, envoy.TypeRDS}, nil))
 
+		case <-shardsRebalanced:
+			if !s.ownsProxy(proxy) {
+				// Closing the stream makes the proxy reconnect to the replica now owning it, with the latency bound above
+				log.Info().Msgf("Proxy %s is now owned by osm-controller replica %s, closing stream",
+					proxy.String(), s.shards.GetOwner(proxy.GetUUID().String()))
+				metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
+				metricsstore.DefaultMetricsStore.ProxyShardRedirectCount.Inc()
+				return status.Error(codes.Unavailable, errProxyNotOwned.Error())
+			}
+
 		case certUpdateMsg := <-certAnnouncement:
 			cert := certUpdateMsg.(events.PubSubMessage).NewObj.(certificate.Certificater)
 			if isCNforProxy(proxy, cert.GetCommonName()) {
@@ -210,6 +247,7 @@ func respondToRequest(proxy *envoy.Proxy, discoveryRequest *xds_discovery.Discov
 	if discoveryRequest.ErrorDetail != nil {
 		log.Error().Msgf("Proxy %s: [NACK] err: \"%s\" for nonce %s, last version applied on request %s",
 			proxy.String(), discoveryRequest.ErrorDetail, discoveryRequest.ResponseNonce, discoveryRequest.VersionInfo)
+		trackXDSNack(proxy, typeURL, discoveryRequest)
 		// TODO: if NACK's on our latest nonce, we can also update lastAppliedVersion
 		// TODO: if the NACK's nonce is our latest nonce, we should retry to avoid leaving the envoy in a wrong config state and update
 		// last applied version to this requests one's, as it tells us what version is the proxy using.
@@ -253,6 +291,7 @@ func respondToRequest(proxy *envoy.Proxy, discoveryRequest *xds_discovery.Discov
 	// At this point, there is no error and nonces match. It can either be an ACK or envoy could still be
 	// requesting a different set of resources on the current version for non-wildcard TypeURIs.
 	proxy.SetLastAppliedVersion(typeURL, requestVersion)
+	trackXDSAck(proxy, typeURL)
 
 	// For Wildcard TypeURIs we are done. Resource names in requests are always empty, nonce alone is enough
 	// to ACK wildcard types.
@@ -340,6 +379,10 @@ func (s *Server) recordPodMetadata(p *envoy.Proxy) error {
 		log.Debug().Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Proxy with serial no %s is a Multicluster gateway, skipping recording pod metadata", p.GetCertificateSerialNumber())
 		return nil
 	}
+	if p.Kind() == envoy.KindEgressGateway {
+		log.Debug().Msgf("Proxy with serial no %s is an egress gateway, skipping recording pod metadata", p.GetCertificateSerialNumber())
+		return nil
+	}
 
 	pod, err := envoy.GetPodFromCertificate(p.GetCertificateCommonName(), s.kubecontroller)
 	if err != nil {
@@ -17,6 +17,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/sharding"
 	"github.com/openservicemesh/osm/pkg/workerpool"
 )
 
@@ -38,6 +39,13 @@ type Server struct {
 	workqueues     *workerpool.WorkerPool
 	kubecontroller k8s.Controller
 
+	// shards determines the proxies served by this replica when the proxies are sharded across the
+	// osm-controller replicas, nil when sharding is disabled
+	shards *sharding.Manager
+
+	// dependencies indexes the services the configuration of the connected proxies depends on
+	dependencies *dependencyIndex
+
 	// ---
 	// SnapshotCache implementation structrues below
 	cacheEnabled bool
@@ -143,7 +143,7 @@ func BuildFromConfig(config Config) (*xds_bootstrap.Bootstrap, error) {
 					Name:           config.XDSClusterName,
 					ConnectTimeout: durationpb.New(time.Millisecond * 250),
 					ClusterDiscoveryType: &xds_cluster.Cluster_Type{
-						Type: xds_cluster.Cluster_LOGICAL_DNS,
+						Type: getXDSClusterDiscoveryType(config.XDSSharded),
 					},
 					TypedExtensionProtocolOptions: map[string]*any.Any{
 						"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": pbHTTPProtocolOptions,
@@ -187,3 +187,13 @@ func BuildFromConfig(config Config) (*xds_bootstrap.Bootstrap, error) {
 
 	return bootstrap, nil
 }
+
+// getXDSClusterDiscoveryType returns the discovery type of the XDS cluster. When the XDS server replicas
+// are sharding the proxies, all the resolved replica addresses are used as endpoints so that the proxy
+// can reach the replica owning it, otherwise a single resolved address is used.
+func getXDSClusterDiscoveryType(sharded bool) xds_cluster.Cluster_DiscoveryType {
+	if sharded {
+		return xds_cluster.Cluster_STRICT_DNS
+	}
+	return xds_cluster.Cluster_LOGICAL_DNS
+}
@@ -3,6 +3,7 @@ package bootstrap
 import (
 	"testing"
 
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	tassert "github.com/stretchr/testify/assert"
 
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
@@ -96,3 +97,26 @@ static_resources:
 `
 	assert.Equal(expectedYAML, string(actualYAML))
 }
+
+func TestBuildFromConfigSharded(t *testing.T) {
+	assert := tassert.New(t)
+	cert := tresor.NewFakeCertificate()
+
+	bootstrapConfig, err := BuildFromConfig(Config{
+		NodeID:           cert.GetCommonName().String(),
+		AdminPort:        15000,
+		XDSClusterName:   "osm-controller",
+		TrustedCA:        cert.GetIssuingCA(),
+		CertificateChain: cert.GetCertificateChain(),
+		PrivateKey:       cert.GetPrivateKey(),
+		XDSHost:          "osm-controller-headless.osm-system.svc.cluster.local",
+		XDSPort:          15128,
+		XDSSharded:       true,
+	})
+	assert.Nil(err)
+
+	xdsCluster := bootstrapConfig.StaticResources.Clusters[0]
+	assert.Equal(xds_cluster.Cluster_STRICT_DNS, xdsCluster.GetType())
+	assert.Equal("osm-controller-headless.osm-system.svc.cluster.local",
+		xdsCluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress().Address)
+}
@@ -21,6 +21,10 @@ type Config struct {
 	// XDSPort is the port of the XDS cluster to connect to
 	XDSPort uint32
 
+	// XDSSharded indicates the XDSHost resolves to all the XDS server replicas sharing the proxies, in which
+	// case the proxy connects to the next resolved replica until it reaches the replica owning it
+	XDSSharded bool
+
 	// NodeID is the proxy's node ID
 	NodeID string
 
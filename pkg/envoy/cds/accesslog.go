@@ -0,0 +1,67 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_upstream_http "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// getAccessLogCluster returns the cluster used to send access logs to the gRPC collector of the given sink
+func getAccessLogCluster(sink configv1alpha1.AccessLogSinkSpec) (*xds_cluster.Cluster, error) {
+	protocolOptions, err := getHTTP2ProtocolOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_cluster.Cluster{
+		Name:           constants.EnvoyAccessLogCluster,
+		AltStatName:    constants.EnvoyAccessLogCluster,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_LOGICAL_DNS,
+		},
+		LbPolicy: xds_cluster.Cluster_ROUND_ROBIN,
+		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+			ClusterName: constants.EnvoyAccessLogCluster,
+			Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: envoy.GetAddress(sink.Address, uint32(sink.Port)),
+							},
+						},
+					}},
+				},
+			},
+		},
+		TypedExtensionProtocolOptions: protocolOptions,
+	}, nil
+}
+
+// getHTTP2ProtocolOptions returns the typed extension protocol options configuring explicit HTTP/2 to the upstream,
+// as required for gRPC collectors.
+func getHTTP2ProtocolOptions() (map[string]*any.Any, error) {
+	marshalledHTTPProtocolOptions, err := ptypes.MarshalAny(&xds_upstream_http.HttpProtocolOptions{
+		UpstreamProtocolOptions: &xds_upstream_http.HttpProtocolOptions_ExplicitHttpConfig_{
+			ExplicitHttpConfig: &xds_upstream_http.HttpProtocolOptions_ExplicitHttpConfig{
+				ProtocolConfig: &xds_upstream_http.HttpProtocolOptions_ExplicitHttpConfig_Http2ProtocolOptions{
+					Http2ProtocolOptions: &xds_core.Http2ProtocolOptions{},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]*any.Any{
+		"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": marshalledHTTPProtocolOptions,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package cds
+
+import (
+	"testing"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	tassert "github.com/stretchr/testify/assert"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestGetAccessLogCluster(t *testing.T) {
+	assert := tassert.New(t)
+
+	cluster, err := getAccessLogCluster(configv1alpha1.AccessLogSinkSpec{
+		Type:    configv1alpha1.AccessLogSinkOpenTelemetry,
+		Address: "otel-collector.observability.svc.cluster.local",
+		Port:    4317,
+	})
+	assert.Nil(err)
+	assert.Equal(constants.EnvoyAccessLogCluster, cluster.Name)
+	assert.Equal(xds_cluster.Cluster_LOGICAL_DNS, cluster.GetType())
+
+	address := cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress()
+	assert.Equal("otel-collector.observability.svc.cluster.local", address.Address)
+	assert.Equal(uint32(4317), address.GetPortValue())
+	assert.Contains(cluster.TypedExtensionProtocolOptions, "envoy.extensions.upstreams.http.v3.HttpProtocolOptions")
+}
@@ -7,16 +7,23 @@ import (
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_aggregate "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/eds"
+	"github.com/openservicemesh/osm/pkg/envoy/secrets"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -26,6 +33,32 @@ import (
 const (
 	// clusterConnectTimeout is the timeout duration used by Envoy to timeout connections to the cluster
 	clusterConnectTimeout = 1 * time.Second
+
+	// outlierDetectionConsecutive5xx is the number of consecutive 5xx responses or connection failures
+	// after which an upstream host is ejected. This is Envoy's default, which tolerates the occasional
+	// failed request of a healthy host.
+	outlierDetectionConsecutive5xx = 5
+
+	// outlierDetectionInterval is the interval between ejection analysis sweeps. It bounds the time an
+	// unhealthy host keeps receiving traffic once it reached the consecutive 5xx threshold, and is
+	// Envoy's default.
+	outlierDetectionInterval = 10 * time.Second
+
+	// outlierDetectionBaseEjectionTime is the base duration a host is ejected for, multiplied by the number
+	// of times the host was ejected, so that a host failing repeatedly stays out of the pool for longer.
+	// This is Envoy's default.
+	outlierDetectionBaseEjectionTime = 30 * time.Second
+
+	// outlierDetectionMaxEjectionPercent is the maximum percentage of the hosts of a cluster that can be
+	// ejected. Envoy's 10% default would keep most unhealthy local hosts in the load balancing pool, which
+	// prevents the traffic from failing over to the lower priority remote endpoints.
+	outlierDetectionMaxEjectionPercent = 100
+
+	// aggregateClusterType is the name of Envoy's aggregate cluster extension
+	aggregateClusterType = "envoy.clusters.aggregate"
+
+	// wildcardHostPrefix is the prefix of a wildcard host in an egress cluster config
+	wildcardHostPrefix = "*."
 )
 
 // replacer used to configure an Envoy cluster's altStatName
@@ -34,6 +67,8 @@ var replacer = strings.NewReplacer(".", "_", ":", "_")
 type clusterOptions struct {
 	permissive             bool
 	withActiveHealthChecks bool
+	withLocalityFailover   bool
+	dnsEndpoints           []endpoint.Endpoint
 }
 
 // clusterOption is type of function that edits the defaults of the options struct.
@@ -50,6 +85,20 @@ func withActiveHealthChecks(o *clusterOptions) {
 	o.withActiveHealthChecks = true
 }
 
+// withLocalityFailover is an option to eject unhealthy upstream hosts so that traffic
+// fails over from the local cluster to the lower priority remote clusters.
+func withLocalityFailover(o *clusterOptions) {
+	o.withLocalityFailover = true
+}
+
+// withDNSResolvableEndpoints is an option to configure the given remote gateways addressed by hostname on a
+// cluster resolving them using DNS, as Envoy cannot resolve the hostnames of endpoints served over EDS.
+func withDNSResolvableEndpoints(endpoints []endpoint.Endpoint) clusterOption {
+	return func(o *clusterOptions) {
+		o.dnsEndpoints = endpoints
+	}
+}
+
 // getUpstreamServiceCluster returns an Envoy Cluster corresponding to the given upstream service
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
 func getUpstreamServiceCluster(downstreamIdentity identity.ServiceIdentity, upstreamSvc service.MeshService, opts ...clusterOption) (*xds_cluster.Cluster, error) {
@@ -95,10 +144,67 @@ func getUpstreamServiceCluster(downstreamIdentity identity.ServiceIdentity, upst
 	if o.withActiveHealthChecks {
 		enableHealthChecksOnCluster(remoteCluster, upstreamSvc)
 	}
+	if o.withLocalityFailover {
+		enableOutlierDetectionOnCluster(remoteCluster)
+	}
 	return remoteCluster, nil
 }
 
-// getMulticlusterGatewayUpstreamServiceCluster returns an Envoy Cluster corresponding to the given upstream service for the multicluster gateway
+// getUpstreamServiceClusters returns the clusters of the given upstream service. The service cluster is the EDS
+// cluster returned by getUpstreamServiceCluster, unless the service has remote gateways addressed by hostname.
+// The service cluster then aggregates the EDS cluster of the local and IP addressed endpoints with a STRICT_DNS
+// cluster of the remote gateways addressed by hostname, which only receives traffic when no endpoint of the EDS
+// cluster is healthy.
+func getUpstreamServiceClusters(downstreamIdentity identity.ServiceIdentity, upstreamSvc service.MeshService, opts ...clusterOption) ([]*xds_cluster.Cluster, error) {
+	o := &clusterOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	edsCluster, err := getUpstreamServiceCluster(downstreamIdentity, upstreamSvc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.permissive || len(o.dnsEndpoints) == 0 {
+		return []*xds_cluster.Cluster{edsCluster}, nil
+	}
+
+	serviceClusterName := edsCluster.Name
+	edsCluster.Name = envoy.GetEDSClusterNameForServiceCluster(serviceClusterName)
+
+	// The DNS cluster shares the TLS, health checking and outlier detection config of the EDS cluster
+	dnsCluster := proto.Clone(edsCluster).(*xds_cluster.Cluster)
+	dnsCluster.Name = envoy.GetDNSClusterNameForServiceCluster(serviceClusterName)
+	dnsCluster.ClusterDiscoveryType = &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_STRICT_DNS}
+	dnsCluster.EdsClusterConfig = nil
+	dnsCluster.DnsLookupFamily = xds_cluster.Cluster_V4_ONLY
+	dnsCluster.LoadAssignment = eds.NewClusterLoadAssignment(upstreamSvc, o.dnsEndpoints)
+	dnsCluster.LoadAssignment.ClusterName = dnsCluster.Name
+
+	aggregateConfig, err := ptypes.MarshalAny(&xds_aggregate.ClusterConfig{
+		Clusters: []string{edsCluster.Name, dnsCluster.Name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	aggregateCluster := &xds_cluster.Cluster{
+		Name:           serviceClusterName,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_ClusterType{
+			ClusterType: &xds_cluster.Cluster_CustomClusterType{
+				Name:        aggregateClusterType,
+				TypedConfig: aggregateConfig,
+			},
+		},
+		LbPolicy: xds_cluster.Cluster_CLUSTER_PROVIDED,
+	}
+
+	return []*xds_cluster.Cluster{aggregateCluster, edsCluster, dnsCluster}, nil
+}
+
+// getMulticlusterGatewayUpstreamServiceCluster returns an Envoy Cluster corresponding to the given upstream service for the multicluster gateway.
+// The gateway forwards the traffic it receives from remote clusters to the local service by its DNS name, so the
+// cluster does not depend on the addresses of the remote gateways, which may be IP addresses or hostnames.
 func getMulticlusterGatewayUpstreamServiceCluster(catalog catalog.MeshCataloger, upstreamSvc service.MeshService, opts ...clusterOption) (*xds_cluster.Cluster, error) {
 	o := &clusterOptions{}
 	for _, opt := range opts {
@@ -179,6 +285,17 @@ func enableHealthChecksOnCluster(cluster *xds_cluster.Cluster, upstreamSvc servi
 	}
 }
 
+// enableOutlierDetectionOnCluster configures outlier detection on the given cluster. All hosts of a
+// priority level may be ejected, which shifts the traffic to the next priority level.
+func enableOutlierDetectionOnCluster(cluster *xds_cluster.Cluster) {
+	cluster.OutlierDetection = &xds_cluster.OutlierDetection{
+		Consecutive_5Xx:    wrapperspb.UInt32(outlierDetectionConsecutive5xx),
+		Interval:           durationpb.New(outlierDetectionInterval),
+		BaseEjectionTime:   durationpb.New(outlierDetectionBaseEjectionTime),
+		MaxEjectionPercent: wrapperspb.UInt32(outlierDetectionMaxEjectionPercent),
+	}
+}
+
 // getLocalServiceCluster returns an Envoy Cluster corresponding to the local service
 func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, clusterName string) (*xds_cluster.Cluster, error) {
 	HTTP2ProtocolOptions, err := envoy.GetHTTP2ProtocolOptions()
@@ -269,15 +386,24 @@ func getPrometheusCluster() *xds_cluster.Cluster {
 
 // getEgressClusters returns a slice of XDS cluster objects for the given egress cluster configs.
 // If the cluster config is invalid, an error is logged and the corresponding cluster config is ignored.
-func getEgressClusters(clusterConfigs []*trafficpolicy.EgressClusterConfig) []*xds_cluster.Cluster {
+func getEgressClusters(clusterConfigs []*trafficpolicy.EgressClusterConfig, downstreamIdentity identity.ServiceIdentity, osmNamespace string) []*xds_cluster.Cluster {
 	if clusterConfigs == nil {
 		return nil
 	}
 
 	var egressClusters []*xds_cluster.Cluster
 	for _, config := range clusterConfigs {
-		switch config.Host {
-		case "":
+		switch {
+		case config.ViaEgressGateway:
+			// Cluster config is routed through the egress gateway, which originates the connection to the
+			// external host. Used for HTTP based clusters
+			if cluster, err := getEgressGatewayUpstreamCluster(config, downstreamIdentity, osmNamespace); err != nil {
+				log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingDNSEgressCluster)).
+					Msg("Error building the egress gateway cluster for the given egress cluster config")
+			} else {
+				egressClusters = append(egressClusters, cluster)
+			}
+		case config.Host == "":
 			// Cluster config does not have a Host specified, route it to its original destination.
 			// Used for TCP based clusters
 			if originalDestinationEgressCluster, err := getOriginalDestinationEgressCluster(config.Name); err != nil {
@@ -286,6 +412,16 @@ func getEgressClusters(clusterConfigs []*trafficpolicy.EgressClusterConfig) []*x
 			} else {
 				egressClusters = append(egressClusters, originalDestinationEgressCluster)
 			}
+		case strings.HasPrefix(config.Host, wildcardHostPrefix):
+			// Cluster config has a wildcard Host specified which cannot be resolved using DNS,
+			// route it to its original destination.
+			// Used for HTTP based clusters
+			if cluster, err := getWildcardHostEgressCluster(config); err != nil {
+				log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingOrgDstEgressCluster)).
+					Msg("Error building the original destination cluster for the given wildcard host egress cluster config")
+			} else {
+				egressClusters = append(egressClusters, cluster)
+			}
 		default:
 			// Cluster config has a Host specified, route it based on the Host resolved using DNS.
 			// Used for HTTP based clusters
@@ -301,6 +437,37 @@ func getEgressClusters(clusterConfigs []*trafficpolicy.EgressClusterConfig) []*x
 	return egressClusters
 }
 
+// getEgressGatewayUpstreamCluster returns an XDS cluster object for the given egress cluster config that
+// routes traffic to the egress gateway over mTLS instead of the external host.
+func getEgressGatewayUpstreamCluster(config *trafficpolicy.EgressClusterConfig, downstreamIdentity identity.ServiceIdentity, osmNamespace string) (*xds_cluster.Cluster, error) {
+	gatewayService := service.MeshService{
+		Name:      constants.EgressGatewayName,
+		Namespace: osmNamespace,
+	}
+	gatewayConfig := &trafficpolicy.EgressClusterConfig{
+		Name: config.Name,
+		Host: gatewayService.FQDN(),
+		Port: constants.EgressGatewayPort,
+	}
+	cluster, err := getDNSResolvableEgressCluster(gatewayConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	marshalledUpstreamTLSContext, err := ptypes.MarshalAny(envoy.GetUpstreamTLSContext(downstreamIdentity, gatewayService))
+	if err != nil {
+		return nil, err
+	}
+	cluster.TransportSocket = &xds_core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &xds_core.TransportSocket_TypedConfig{
+			TypedConfig: marshalledUpstreamTLSContext,
+		},
+	}
+
+	return cluster, nil
+}
+
 // getDNSResolvableEgressCluster returns an XDS cluster object that is resolved using DNS for the given egress cluster config.
 // If the egress cluster config is invalid, an error is returned.
 func getDNSResolvableEgressCluster(config *trafficpolicy.EgressClusterConfig) (*xds_cluster.Cluster, error) {
@@ -317,7 +484,7 @@ func getDNSResolvableEgressCluster(config *trafficpolicy.EgressClusterConfig) (*
 		return nil, errors.New("Invalid egress cluster config: Port unspecified")
 	}
 
-	return &xds_cluster.Cluster{
+	cluster := &xds_cluster.Cluster{
 		Name:           config.Name,
 		AltStatName:    formatAltStatNameForPrometheus(config.Name),
 		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
@@ -342,6 +509,103 @@ func getDNSResolvableEgressCluster(config *trafficpolicy.EgressClusterConfig) (*
 				},
 			},
 		},
+	}
+
+	if config.TLS != nil {
+		transportSocket, err := getEgressTLSTransportSocket(config.TLS, config.Host)
+		if err != nil {
+			return nil, err
+		}
+		cluster.TransportSocket = transportSocket
+	}
+
+	return cluster, nil
+}
+
+// getWildcardHostEgressCluster returns an XDS cluster object that routes traffic to its original destination
+// for the given egress cluster config whose Host is a wildcard. If TLS origination is configured, the SNI and
+// the SAN validated on the upstream certificate are derived from the Host header of each request.
+func getWildcardHostEgressCluster(config *trafficpolicy.EgressClusterConfig) (*xds_cluster.Cluster, error) {
+	if config.Name == "" {
+		return nil, errors.New("Invalid egress cluster config: Name unspecified")
+	}
+
+	cluster := &xds_cluster.Cluster{
+		Name:           config.Name,
+		AltStatName:    formatAltStatNameForPrometheus(config.Name),
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_ORIGINAL_DST,
+		},
+		LbPolicy: xds_cluster.Cluster_CLUSTER_PROVIDED,
+	}
+
+	if config.TLS != nil {
+		transportSocket, err := getEgressTLSTransportSocket(config.TLS, "")
+		if err != nil {
+			return nil, err
+		}
+		cluster.TransportSocket = transportSocket
+		cluster.UpstreamHttpProtocolOptions = &xds_core.UpstreamHttpProtocolOptions{
+			AutoSni:           true,
+			AutoSanValidation: true,
+		}
+	}
+
+	return cluster, nil
+}
+
+// getEgressTLSTransportSocket returns the transport socket used to originate TLS connections to an external host
+// using the given TLS config. When sni is specified, it is set as the SNI and is required to match a SAN in the
+// certificate presented by the external host.
+func getEgressTLSTransportSocket(tlsConfig *trafficpolicy.EgressTLSConfig, sni string) (*xds_core.TransportSocket, error) {
+	validationContext := &xds_auth.CertificateValidationContext{
+		TrustedCa: &xds_core.DataSource{
+			Specifier: &xds_core.DataSource_InlineBytes{
+				InlineBytes: tlsConfig.CABundle,
+			},
+		},
+	}
+	if sni != "" {
+		validationContext.MatchSubjectAltNames = []*xds_matcher.StringMatcher{{
+			MatchPattern: &xds_matcher.StringMatcher_Exact{
+				Exact: sni,
+			},
+		}}
+	}
+
+	tlsContext := &xds_auth.UpstreamTlsContext{
+		Sni: sni,
+		CommonTlsContext: &xds_auth.CommonTlsContext{
+			TlsParams: envoy.GetTLSParams(),
+			ValidationContextType: &xds_auth.CommonTlsContext_ValidationContext{
+				ValidationContext: validationContext,
+			},
+		},
+	}
+
+	if tlsConfig.ClientCertificateSecret != "" {
+		// The client certificate and key are served over SDS so that the private key is not a part of the cluster config
+		clientCert := secrets.SDSCert{
+			Name:     tlsConfig.ClientCertificateSecret,
+			CertType: secrets.EgressClientCertType,
+		}
+		tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs = []*xds_auth.SdsSecretConfig{{
+			Name:      clientCert.String(),
+			SdsConfig: envoy.GetADSConfigSource(),
+		}}
+	}
+
+	marshalledTLSContext, err := ptypes.MarshalAny(tlsContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &xds_core.TransportSocket_TypedConfig{
+			TypedConfig: marshalledTLSContext,
+		},
 	}, nil
 }
 
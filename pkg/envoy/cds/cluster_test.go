@@ -8,6 +8,8 @@ import (
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_aggregate "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
@@ -31,6 +34,7 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 		expectedClusterType xds_cluster.Cluster_DiscoveryType
 		expectedLbPolicy    xds_cluster.Cluster_LbPolicy
 		addHealthCheck      bool
+		localityFailover    bool
 	}{
 		{
 			name:                "Returns an EDS based cluster when permissive mode is disabled",
@@ -60,6 +64,20 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 			expectedLbPolicy:    xds_cluster.Cluster_CLUSTER_PROVIDED,
 			addHealthCheck:      false,
 		},
+		{
+			name:                "Adds outlier detection when locality failover is configured",
+			permissiveMode:      false,
+			expectedClusterType: xds_cluster.Cluster_EDS,
+			expectedLbPolicy:    xds_cluster.Cluster_ROUND_ROBIN,
+			localityFailover:    true,
+		},
+		{
+			name:                "Adds outlier detection when locality failover is configured in permissive mode",
+			permissiveMode:      true,
+			expectedClusterType: xds_cluster.Cluster_ORIGINAL_DST,
+			expectedLbPolicy:    xds_cluster.Cluster_CLUSTER_PROVIDED,
+			localityFailover:    true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -73,6 +91,9 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 			if tc.addHealthCheck {
 				opts = append(opts, withActiveHealthChecks)
 			}
+			if tc.localityFailover {
+				opts = append(opts, withLocalityFailover)
+			}
 
 			remoteCluster, err := getUpstreamServiceCluster(downstreamSvcAccount, upstreamSvc, opts...)
 			assert.NoError(err)
@@ -84,6 +105,14 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 			} else {
 				assert.Nil(remoteCluster.HealthChecks)
 			}
+
+			if tc.localityFailover {
+				assert.NotNil(remoteCluster.OutlierDetection)
+				assert.Equal(uint32(outlierDetectionMaxEjectionPercent), remoteCluster.OutlierDetection.MaxEjectionPercent.Value)
+				assert.Equal(uint32(outlierDetectionConsecutive5xx), remoteCluster.OutlierDetection.Consecutive_5Xx.Value)
+			} else {
+				assert.Nil(remoteCluster.OutlierDetection)
+			}
 		})
 	}
 }
@@ -390,18 +419,54 @@ func TestGetEgressClusters(t *testing.T) {
 			},
 			expectedClusterCount: 2,
 		},
+		{
+			name: "wildcard host cluster config",
+			clusterConfigs: []*trafficpolicy.EgressClusterConfig{
+				{
+					Name: "*.foo.com:80",
+					Host: "*.foo.com",
+					Port: 80,
+				},
+			},
+			expectedClusterCount: 1,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert := tassert.New(t)
 
-			actual := getEgressClusters(tc.clusterConfigs)
+			actual := getEgressClusters(tc.clusterConfigs, tests.BookbuyerServiceIdentity, "osm-system")
 			assert.Len(actual, tc.expectedClusterCount)
 		})
 	}
 }
 
+func TestGetEgressGatewayUpstreamCluster(t *testing.T) {
+	assert := tassert.New(t)
+
+	config := &trafficpolicy.EgressClusterConfig{
+		Name:             "foo.com:80",
+		Host:             "foo.com",
+		Port:             80,
+		ViaEgressGateway: true,
+	}
+
+	clusters := getEgressClusters([]*trafficpolicy.EgressClusterConfig{config}, tests.BookbuyerServiceIdentity, "osm-system")
+	assert.Len(clusters, 1)
+
+	cluster := clusters[0]
+	assert.Equal("foo.com:80", cluster.Name)
+	assert.Equal(xds_cluster.Cluster_STRICT_DNS, cluster.GetType())
+	socketAddress := cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress()
+	assert.Equal("osm-egress-gateway.osm-system.svc.cluster.local", socketAddress.Address)
+	assert.Equal(uint32(constants.EgressGatewayPort), socketAddress.GetPortValue())
+
+	upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+	assert.Nil(ptypes.UnmarshalAny(cluster.TransportSocket.GetTypedConfig(), upstreamTLSContext))
+	assert.Equal("osm-egress-gateway.osm-system.svc.cluster.local", upstreamTLSContext.Sni)
+}
+
 func TestGetDNSResolvableEgressCluster(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -490,6 +555,78 @@ func TestGetDNSResolvableEgressCluster(t *testing.T) {
 	}
 }
 
+func TestGetEgressClustersWithTLSOrigination(t *testing.T) {
+	tlsConfig := &trafficpolicy.EgressTLSConfig{
+		CABundle:                []byte("ca-bundle"),
+		ClientCertificate:       []byte("cert"),
+		ClientKey:               []byte("key"),
+		ClientCertificateSecret: "egress-ns/client",
+	}
+
+	testCases := []struct {
+		name                 string
+		clusterConfig        *trafficpolicy.EgressClusterConfig
+		expectedType         xds_cluster.Cluster_DiscoveryType
+		expectedSNI          string
+		expectedSANs         []string
+		expectAutoSNIEnabled bool
+	}{
+		{
+			name: "TLS origination for an exact host",
+			clusterConfig: &trafficpolicy.EgressClusterConfig{
+				Name: "foo.com:443",
+				Host: "foo.com",
+				Port: 443,
+				TLS:  tlsConfig,
+			},
+			expectedType: xds_cluster.Cluster_STRICT_DNS,
+			expectedSNI:  "foo.com",
+			expectedSANs: []string{"foo.com"},
+		},
+		{
+			name: "TLS origination for a wildcard host",
+			clusterConfig: &trafficpolicy.EgressClusterConfig{
+				Name: "*.foo.com:443",
+				Host: "*.foo.com",
+				Port: 443,
+				TLS:  tlsConfig,
+			},
+			expectedType:         xds_cluster.Cluster_ORIGINAL_DST,
+			expectAutoSNIEnabled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			clusters := getEgressClusters([]*trafficpolicy.EgressClusterConfig{tc.clusterConfig}, tests.BookbuyerServiceIdentity, "osm-system")
+			assert.Len(clusters, 1)
+			cluster := clusters[0]
+			assert.Equal(tc.expectedType, cluster.GetType())
+			assert.Equal(tc.expectAutoSNIEnabled, cluster.GetUpstreamHttpProtocolOptions().GetAutoSni())
+			assert.Equal(tc.expectAutoSNIEnabled, cluster.GetUpstreamHttpProtocolOptions().GetAutoSanValidation())
+
+			tlsContext := &xds_auth.UpstreamTlsContext{}
+			assert.Nil(ptypes.UnmarshalAny(cluster.GetTransportSocket().GetTypedConfig(), tlsContext))
+			assert.Equal(tc.expectedSNI, tlsContext.Sni)
+
+			validationContext := tlsContext.CommonTlsContext.GetValidationContext()
+			assert.Equal(tlsConfig.CABundle, validationContext.GetTrustedCa().GetInlineBytes())
+			var actualSANs []string
+			for _, san := range validationContext.GetMatchSubjectAltNames() {
+				actualSANs = append(actualSANs, san.GetExact())
+			}
+			assert.Equal(tc.expectedSANs, actualSANs)
+
+			// The client certificate and key are served over SDS instead of being inlined in the cluster
+			assert.Empty(tlsContext.CommonTlsContext.TlsCertificates)
+			assert.Len(tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs, 1)
+			assert.Equal("egress-client-cert:egress-ns/client", tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs[0].Name)
+		})
+	}
+}
+
 func TestFormatAltStatNameForPrometheus(t *testing.T) {
 	testCases := []struct {
 		name                string
@@ -522,3 +659,51 @@ func TestFormatAltStatNameForPrometheus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUpstreamServiceClusters(t *testing.T) {
+	assert := tassert.New(t)
+
+	clusters, err := getUpstreamServiceClusters(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service, withLocalityFailover)
+	assert.NoError(err)
+	assert.Len(clusters, 1)
+	assert.Equal(tests.BookstoreV1Service.String(), clusters[0].Name)
+	assert.Equal(xds_cluster.Cluster_EDS, clusters[0].GetType())
+
+	hostnameEndpoints := []endpoint.Endpoint{{Hostname: "gateway.east.example.com", Port: 15443, Zone: "east"}}
+	clusters, err = getUpstreamServiceClusters(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service, withLocalityFailover, withDNSResolvableEndpoints(hostnameEndpoints))
+	assert.NoError(err)
+	assert.Len(clusters, 3)
+
+	// The service cluster aggregates the EDS cluster, which is tried first, and the DNS cluster
+	aggregateCluster, edsCluster, dnsCluster := clusters[0], clusters[1], clusters[2]
+	assert.Equal(tests.BookstoreV1Service.String(), aggregateCluster.Name)
+	assert.Equal(aggregateClusterType, aggregateCluster.GetClusterType().Name)
+	assert.Equal(xds_cluster.Cluster_CLUSTER_PROVIDED, aggregateCluster.LbPolicy)
+	aggregateConfig := &xds_aggregate.ClusterConfig{}
+	assert.NoError(ptypes.UnmarshalAny(aggregateCluster.GetClusterType().TypedConfig, aggregateConfig))
+	assert.Equal([]string{edsCluster.Name, dnsCluster.Name}, aggregateConfig.Clusters)
+
+	// Local and IP addressed endpoints stay on EDS
+	assert.Equal("default/bookstore-v1|eds", edsCluster.Name)
+	assert.Equal(xds_cluster.Cluster_EDS, edsCluster.GetType())
+	assert.NotNil(edsCluster.EdsClusterConfig)
+	assert.NotNil(edsCluster.TransportSocket)
+	assert.NotNil(edsCluster.OutlierDetection)
+
+	assert.Equal("default/bookstore-v1|dns", dnsCluster.Name)
+	assert.Equal(xds_cluster.Cluster_STRICT_DNS, dnsCluster.GetType())
+	assert.Nil(dnsCluster.EdsClusterConfig)
+	assert.NotNil(dnsCluster.TransportSocket)
+	assert.NotNil(dnsCluster.OutlierDetection)
+	assert.Equal(dnsCluster.Name, dnsCluster.LoadAssignment.ClusterName)
+	assert.Len(dnsCluster.LoadAssignment.Endpoints, 2)
+	assert.Empty(dnsCluster.LoadAssignment.Endpoints[0].LbEndpoints)
+	assert.Equal(uint32(1), dnsCluster.LoadAssignment.Endpoints[1].Priority)
+	assert.Equal("gateway.east.example.com", dnsCluster.LoadAssignment.Endpoints[1].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress().Address)
+
+	// Permissive mode does not use EDS, the original destination is used
+	clusters, err = getUpstreamServiceClusters(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service, permissive, withDNSResolvableEndpoints(hostnameEndpoints))
+	assert.NoError(err)
+	assert.Len(clusters, 1)
+	assert.Equal(xds_cluster.Cluster_ORIGINAL_DST, clusters[0].GetType())
+}
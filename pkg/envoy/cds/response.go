@@ -9,9 +9,12 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 // NewResponse creates a new Cluster Discovery Response.
@@ -32,6 +35,9 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 	if cfg.GetFeatureFlags().EnableEnvoyActiveHealthChecks {
 		opts = append(opts, withActiveHealthChecks)
 	}
+	if cfg.GetFeatureFlags().EnableMulticlusterMode {
+		opts = append(opts, withLocalityFailover)
+	}
 
 	if proxy.Kind() == envoy.KindGateway && cfg.GetFeatureFlags().EnableMulticlusterMode {
 		for _, dstService := range meshCatalog.ListOutboundServicesForMulticlusterGateway() {
@@ -47,16 +53,33 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		return removeDups(clusters), nil
 	}
 
+	if proxy.Kind() == envoy.KindEgressGateway {
+		egressTrafficPolicy, err := meshCatalog.GetEgressGatewayTrafficPolicy()
+		if err != nil {
+			log.Error().Err(err).Msgf("Error retrieving egress gateway policy for proxy %s", proxy.String())
+			return nil, err
+		}
+		if egressTrafficPolicy != nil {
+			clusters = append(clusters, getEgressClusters(egressTrafficPolicy.ClustersConfigs, proxyIdentity, cfg.GetOSMNamespace())...)
+		}
+		return removeDups(clusters), nil
+	}
+
 	// Build remote clusters based on allowed outbound services
 	for _, dstService := range meshCatalog.ListOutboundServicesForIdentity(proxyIdentity) {
-		cluster, err := getUpstreamServiceCluster(proxyIdentity, dstService, opts...)
+		svcOpts := append([]clusterOption{}, opts...)
+		if cfg.GetFeatureFlags().EnableMulticlusterMode && !cfg.IsPermissiveTrafficPolicyMode() {
+			svcOpts = append(svcOpts, getDNSResolvableEndpointsOption(meshCatalog, proxyIdentity, dstService)...)
+		}
+
+		serviceClusters, err := getUpstreamServiceClusters(proxyIdentity, dstService, svcOpts...)
 		if err != nil {
 			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrObtainingUpstreamServiceCluster)).
 				Msgf("Failed to construct service cluster for service %s for proxy %s", dstService.Name, proxy.String())
 			return nil, err
 		}
 
-		clusters = append(clusters, cluster)
+		clusters = append(clusters, serviceClusters...)
 	}
 
 	svcList, err := proxyRegistry.ListProxyServices(proxy)
@@ -84,7 +107,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		log.Error().Err(err).Msgf("Error retrieving egress policies for proxy with identity %s, skipping egress clusters", proxyIdentity)
 	} else {
 		if egressTrafficPolicy != nil {
-			clusters = append(clusters, getEgressClusters(egressTrafficPolicy.ClustersConfigs)...)
+			clusters = append(clusters, getEgressClusters(egressTrafficPolicy.ClustersConfigs, proxyIdentity, cfg.GetOSMNamespace())...)
 		}
 	}
 
@@ -109,7 +132,23 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 
 	// Add an outbound tracing cluster (from localhost to tracing sink)
 	if cfg.IsTracingEnabled() {
-		clusters = append(clusters, getTracingCluster(cfg))
+		if tracingCluster, err := getTracingCluster(cfg); err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+				Msgf("Error building tracing cluster for proxy %s", proxy.String())
+		} else {
+			clusters = append(clusters, tracingCluster)
+		}
+	}
+
+	// Add an outbound access log cluster (from localhost to the gRPC access log collector)
+	proxyNamespace := meshCatalog.GetKubeController().GetNamespace(proxyIdentity.ToK8sServiceAccount().Namespace)
+	if accessLog := envoy.GetAccessLogConfigForNamespace(cfg.GetAccessLogConfig(), proxyNamespace); envoy.UsesAccessLogCluster(accessLog) {
+		if accessLogCluster, err := getAccessLogCluster(accessLog.Sink); err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+				Msgf("Error building access log cluster for proxy %s", proxy.String())
+		} else {
+			clusters = append(clusters, accessLogCluster)
+		}
 	}
 
 	return removeDups(clusters), nil
@@ -130,3 +169,23 @@ func removeDups(clusters []*xds_cluster.Cluster) []types.Resource {
 
 	return cdsResources
 }
+
+// getDNSResolvableEndpointsOption returns the option to resolve the remote cluster gateways of the given upstream
+// service that are addressed by hostname using DNS
+func getDNSResolvableEndpointsOption(meshCatalog catalog.MeshCataloger, proxyIdentity identity.ServiceIdentity, dstService service.MeshService) []clusterOption {
+	endpoints, err := meshCatalog.ListEndpointsForServiceIdentity(proxyIdentity, dstService)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed listing allowed endpoints for service %s for proxy identity %s", dstService, proxyIdentity)
+		return nil
+	}
+	var hostnameEndpoints []endpoint.Endpoint
+	for _, ep := range endpoints {
+		if ep.Hostname != "" {
+			hostnameEndpoints = append(hostnameEndpoints, ep)
+		}
+	}
+	if len(hostnameEndpoints) == 0 {
+		return nil
+	}
+	return []clusterOption{withDNSResolvableEndpoints(hostnameEndpoints)}
+}
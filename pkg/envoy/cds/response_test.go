@@ -53,7 +53,7 @@ func TestNewResponse(t *testing.T) {
 
 	proxyUUID := uuid.New()
 	// The format of the CN matters
-	xdsCertificate := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, tests.BookbuyerServiceAccountName, tests.Namespace)
+	xdsCertificate := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, tests.BookbuyerServiceAccountName, tests.Namespace, identity.ClusterLocalTrustDomain)
 	certSerialNumber := certificate.SerialNumber("123456")
 	proxy, err := envoy.NewProxy(xdsCertificate, certSerialNumber, nil)
 	assert.Nil(err)
@@ -70,6 +70,9 @@ func TestNewResponse(t *testing.T) {
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(true).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).AnyTimes()
+	mockConfigurator.EXPECT().GetTracingProvider().Return(v1alpha1.TracingProviderZipkin).AnyTimes()
+	mockConfigurator.EXPECT().GetAccessLogConfig().Return(v1alpha1.AccessLogSpec{}).AnyTimes()
+	mockKubeController.EXPECT().GetNamespace(tests.Namespace).Return(nil).AnyTimes()
 	mockConfigurator.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{}).AnyTimes()
 	mockCatalog.EXPECT().GetKubeController().Return(mockKubeController).AnyTimes()
 
@@ -412,11 +415,11 @@ func TestNewResponseGetLocalServiceClusterError(t *testing.T) {
 		Name:      "svc",
 	}
 
-	proxyIdentity := identity.K8sServiceAccount{Name: "svcacc", Namespace: "ns"}.ToServiceIdentity()
+	proxyIdentity := identity.K8sServiceAccount{Name: "svcacc", Namespace: "ns"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
 	proxyRegistry := registry.NewProxyRegistry(registry.ExplicitProxyServiceMapper(func(*envoy.Proxy) ([]service.MeshService, error) {
 		return []service.MeshService{svc}, nil
 	}))
-	cn := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc", "ns")
+	cn := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain)
 	proxy, err := envoy.NewProxy(cn, "", nil)
 	tassert.Nil(t, err)
 
@@ -425,6 +428,8 @@ func TestNewResponseGetLocalServiceClusterError(t *testing.T) {
 	meshCatalog.EXPECT().GetKubeController().Return(mockKubeController).AnyTimes()
 	mockKubeController.EXPECT().ListPods().Return([]*v1.Pod{})
 	cfg.EXPECT().IsTracingEnabled().Return(false).Times(1)
+	cfg.EXPECT().GetAccessLogConfig().Return(v1alpha1.AccessLogSpec{}).AnyTimes()
+	mockKubeController.EXPECT().GetNamespace("ns").Return(nil).AnyTimes()
 	cfg.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableMulticlusterMode: false}).AnyTimes()
 	cfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
 
@@ -434,11 +439,11 @@ func TestNewResponseGetLocalServiceClusterError(t *testing.T) {
 }
 
 func TestNewResponseGetEgressTrafficPolicyError(t *testing.T) {
-	proxyIdentity := identity.K8sServiceAccount{Name: "svcacc", Namespace: "ns"}.ToServiceIdentity()
+	proxyIdentity := identity.K8sServiceAccount{Name: "svcacc", Namespace: "ns"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
 	proxyRegistry := registry.NewProxyRegistry(registry.ExplicitProxyServiceMapper(func(*envoy.Proxy) ([]service.MeshService, error) {
 		return nil, nil
 	}))
-	cn := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc", "ns")
+	cn := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain)
 	proxy, err := envoy.NewProxy(cn, "", nil)
 	tassert.Nil(t, err)
 
@@ -453,6 +458,8 @@ func TestNewResponseGetEgressTrafficPolicyError(t *testing.T) {
 	mockKubeController.EXPECT().ListPods().Return([]*v1.Pod{})
 	cfg.EXPECT().IsEgressEnabled().Return(false).Times(1)
 	cfg.EXPECT().IsTracingEnabled().Return(false).Times(1)
+	cfg.EXPECT().GetAccessLogConfig().Return(v1alpha1.AccessLogSpec{}).AnyTimes()
+	mockKubeController.EXPECT().GetNamespace("ns").Return(nil).AnyTimes()
 	cfg.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableMulticlusterMode: false}).AnyTimes()
 	cfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
 
@@ -462,11 +469,11 @@ func TestNewResponseGetEgressTrafficPolicyError(t *testing.T) {
 }
 
 func TestNewResponseGetEgressTrafficPolicyNotEmpty(t *testing.T) {
-	proxyIdentity := identity.K8sServiceAccount{Name: "svcacc", Namespace: "ns"}.ToServiceIdentity()
+	proxyIdentity := identity.K8sServiceAccount{Name: "svcacc", Namespace: "ns"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
 	proxyRegistry := registry.NewProxyRegistry(registry.ExplicitProxyServiceMapper(func(*envoy.Proxy) ([]service.MeshService, error) {
 		return nil, nil
 	}))
-	cn := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc", "ns")
+	cn := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain)
 	proxy, err := envoy.NewProxy(cn, "", nil)
 	tassert.Nil(t, err)
 
@@ -483,8 +490,11 @@ func TestNewResponseGetEgressTrafficPolicyNotEmpty(t *testing.T) {
 			{Name: "my-cluster"}, // the test ensures this duplicate is removed
 		},
 	}, nil).Times(1)
+	cfg.EXPECT().GetOSMNamespace().Return("osm-system").AnyTimes()
 	cfg.EXPECT().IsEgressEnabled().Return(false).Times(1)
 	cfg.EXPECT().IsTracingEnabled().Return(false).Times(1)
+	cfg.EXPECT().GetAccessLogConfig().Return(v1alpha1.AccessLogSpec{}).AnyTimes()
+	mockKubeController.EXPECT().GetNamespace("ns").Return(nil).AnyTimes()
 	cfg.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableMulticlusterMode: false}).AnyTimes()
 	cfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
 
@@ -500,7 +510,7 @@ func TestNewResponseForMulticlusterGateway(t *testing.T) {
 	proxyRegistry := registry.NewProxyRegistry(registry.ExplicitProxyServiceMapper(func(*envoy.Proxy) ([]service.MeshService, error) {
 		return nil, nil
 	}))
-	cn := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindGateway, "osm", "osm-system")
+	cn := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindGateway, "osm", "osm-system", identity.ClusterLocalTrustDomain)
 	proxy, err := envoy.NewProxy(cn, "", nil)
 	assert.Nil(err)
 
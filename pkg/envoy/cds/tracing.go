@@ -5,13 +5,16 @@ import (
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	"github.com/golang/protobuf/ptypes"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 )
 
-func getTracingCluster(cfg configurator.Configurator) *xds_cluster.Cluster {
-	return &xds_cluster.Cluster{
+// getTracingCluster returns the cluster used to export spans to the tracing collector.
+// The cluster uses HTTP/2 when the spans are exported to an OpenTelemetry collector over OTLP/gRPC.
+func getTracingCluster(cfg configurator.Configurator) (*xds_cluster.Cluster, error) {
+	cluster := &xds_cluster.Cluster{
 		Name:           constants.EnvoyTracingCluster,
 		AltStatName:    constants.EnvoyTracingCluster,
 		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
@@ -34,4 +37,14 @@ func getTracingCluster(cfg configurator.Configurator) *xds_cluster.Cluster {
 			},
 		},
 	}
+
+	if cfg.GetTracingProvider() == configv1alpha1.TracingProviderOpenTelemetry {
+		protocolOptions, err := getHTTP2ProtocolOptions()
+		if err != nil {
+			return nil, err
+		}
+		cluster.TypedExtensionProtocolOptions = protocolOptions
+	}
+
+	return cluster, nil
 }
@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 )
@@ -22,11 +23,25 @@ var _ = Describe("Test CDS Tracing Configuration", func() {
 		It("Returns Tracing cluster config", func() {
 			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).Times(1)
 			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).Times(1)
+			mockConfigurator.EXPECT().GetTracingProvider().Return(configv1alpha1.TracingProviderZipkin).Times(1)
 
-			actual := *getTracingCluster(mockConfigurator)
+			actual, err := getTracingCluster(mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
 			Expect(actual.Name).To(Equal(constants.EnvoyTracingCluster))
 			Expect(actual.AltStatName).To(Equal(constants.EnvoyTracingCluster))
 			Expect(len(actual.GetLoadAssignment().GetEndpoints())).To(Equal(1))
+			Expect(actual.TypedExtensionProtocolOptions).To(BeEmpty())
+		})
+
+		It("Returns an HTTP/2 Tracing cluster config for the OpenTelemetry provider", func() {
+			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).Times(1)
+			mockConfigurator.EXPECT().GetTracingPort().Return(uint32(4317)).Times(1)
+			mockConfigurator.EXPECT().GetTracingProvider().Return(configv1alpha1.TracingProviderOpenTelemetry).Times(1)
+
+			actual, err := getTracingCluster(mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.Name).To(Equal(constants.EnvoyTracingCluster))
+			Expect(actual.TypedExtensionProtocolOptions).To(HaveKey("envoy.extensions.upstreams.http.v3.HttpProtocolOptions"))
 		})
 	})
 })
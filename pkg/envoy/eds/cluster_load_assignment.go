@@ -1,6 +1,8 @@
 package eds
 
 import (
+	"sort"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 
@@ -13,20 +15,57 @@ import (
 
 const (
 	zone = "zone"
+
+	// localPriority is the priority of the endpoints in the local cluster
+	localPriority = 0
+
+	// remotePriority is the priority of the endpoints in remote clusters, which are only
+	// used when the endpoints in the local cluster are unavailable
+	remotePriority = 1
 )
 
-// newClusterLoadAssignment returns the cluster load assignments for the given service and its endpoints
-func newClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints []endpoint.Endpoint) *xds_endpoint.ClusterLoadAssignment {
+// NewClusterLoadAssignment returns the cluster load assignments for the given service and its endpoints.
+// Local endpoints are assigned the highest priority, while endpoints in remote clusters are grouped per
+// cluster at a lower priority so that traffic fails over to them when the local endpoints are unhealthy.
+func NewClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints []endpoint.Endpoint) *xds_endpoint.ClusterLoadAssignment {
+	var localEndpoints []endpoint.Endpoint
+	remoteEndpoints := make(map[string][]endpoint.Endpoint)
+	for _, ep := range serviceEndpoints {
+		if ep.Zone == "" {
+			localEndpoints = append(localEndpoints, ep)
+			continue
+		}
+		remoteEndpoints[ep.Zone] = append(remoteEndpoints[ep.Zone], ep)
+	}
+
 	cla := &xds_endpoint.ClusterLoadAssignment{
 		ClusterName: serviceName.String(),
 		Endpoints: []*xds_endpoint.LocalityLbEndpoints{
-			{
-				Locality: &xds_core.Locality{
-					Zone: zone,
-				},
-				LbEndpoints: []*xds_endpoint.LbEndpoint{},
-			},
+			newLocalityLbEndpoints(serviceName, zone, localPriority, localEndpoints),
+		},
+	}
+
+	var remoteZones []string
+	for remoteZone := range remoteEndpoints {
+		remoteZones = append(remoteZones, remoteZone)
+	}
+	sort.Strings(remoteZones)
+	for _, remoteZone := range remoteZones {
+		cla.Endpoints = append(cla.Endpoints, newLocalityLbEndpoints(serviceName, remoteZone, remotePriority, remoteEndpoints[remoteZone]))
+	}
+
+	log.Debug().Msgf("[EDS] Constructed ClusterLoadAssignment: %+v", cla)
+	return cla
+}
+
+// newLocalityLbEndpoints returns the endpoints of the given locality zone at the given priority
+func newLocalityLbEndpoints(serviceName service.MeshService, localityZone string, priority uint32, serviceEndpoints []endpoint.Endpoint) *xds_endpoint.LocalityLbEndpoints {
+	localityEndpoints := &xds_endpoint.LocalityLbEndpoints{
+		Locality: &xds_core.Locality{
+			Zone: localityZone,
 		},
+		Priority:    priority,
+		LbEndpoints: []*xds_endpoint.LbEndpoint{},
 	}
 
 	lenIPs := len(serviceEndpoints)
@@ -36,19 +75,18 @@ func newClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints
 	weight := uint32(100 / lenIPs)
 
 	for _, meshEndpoint := range serviceEndpoints {
-		log.Trace().Msgf("[EDS][ClusterLoadAssignment] Adding Endpoint: Cluster=%s, Services=%s, Endpoint=%+v, Weight=%d", serviceName, serviceName, meshEndpoint, weight)
+		log.Trace().Msgf("[EDS][ClusterLoadAssignment] Adding Endpoint: Cluster=%s, Services=%s, Endpoint=%+v, Weight=%d, Priority=%d", serviceName, serviceName, meshEndpoint, weight, priority)
 		lbEpt := xds_endpoint.LbEndpoint{
 			HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
 				Endpoint: &xds_endpoint.Endpoint{
-					Address: envoy.GetAddress(meshEndpoint.IP.String(), uint32(meshEndpoint.Port)),
+					Address: envoy.GetAddress(meshEndpoint.Host(), uint32(meshEndpoint.Port)),
 				},
 			},
 			LoadBalancingWeight: &wrappers.UInt32Value{
 				Value: weight,
 			},
 		}
-		cla.Endpoints[0].LbEndpoints = append(cla.Endpoints[0].LbEndpoints, &lbEpt)
+		localityEndpoints.LbEndpoints = append(localityEndpoints.LbEndpoints, &lbEpt)
 	}
-	log.Debug().Msgf("[EDS] Constructed ClusterLoadAssignment: %+v", cla)
-	return cla
+	return localityEndpoints
 }
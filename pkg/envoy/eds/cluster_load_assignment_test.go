@@ -28,14 +28,14 @@ func TestNewClusterLoadAssignment(t *testing.T) {
 		},
 	}
 
-	cla := newClusterLoadAssignment(namespacedServices[0], allServiceEndpoints[namespacedServices[0]])
+	cla := NewClusterLoadAssignment(namespacedServices[0], allServiceEndpoints[namespacedServices[0]])
 	assert.NotNil(cla)
 	assert.Equal(cla.ClusterName, "osm/bookstore-1")
 	assert.Len(cla.Endpoints, 1)
 	assert.Len(cla.Endpoints[0].LbEndpoints, 1)
 	assert.Equal(cla.Endpoints[0].LbEndpoints[0].GetLoadBalancingWeight().Value, uint32(100))
 
-	cla2 := newClusterLoadAssignment(namespacedServices[1], allServiceEndpoints[namespacedServices[1]])
+	cla2 := NewClusterLoadAssignment(namespacedServices[1], allServiceEndpoints[namespacedServices[1]])
 	assert.NotNil(cla2)
 	assert.Equal(cla2.ClusterName, "osm/bookstore-2")
 	assert.Len(cla2.Endpoints, 1)
@@ -43,9 +43,43 @@ func TestNewClusterLoadAssignment(t *testing.T) {
 	assert.Equal(cla2.Endpoints[0].LbEndpoints[0].GetLoadBalancingWeight().Value, uint32(50))
 	assert.Equal(cla2.Endpoints[0].LbEndpoints[1].GetLoadBalancingWeight().Value, uint32(50))
 
-	cla3 := newClusterLoadAssignment(namespacedServices[0], []endpoint.Endpoint{})
+	cla3 := NewClusterLoadAssignment(namespacedServices[0], []endpoint.Endpoint{})
 	assert.NotNil(cla3)
 	assert.Equal(cla3.ClusterName, "osm/bookstore-1")
 	assert.Len(cla3.Endpoints, 1)
 	assert.Len(cla3.Endpoints[0].LbEndpoints, 0)
 }
+
+func TestNewClusterLoadAssignmentWithRemoteEndpoints(t *testing.T) {
+	assert := tassert.New(t)
+
+	svc := service.MeshService{Namespace: "osm", Name: "bookstore"}
+	cla := NewClusterLoadAssignment(svc, []endpoint.Endpoint{
+		{IP: net.ParseIP("10.0.0.1"), Port: 80},
+		{IP: net.ParseIP("2.2.2.2"), Port: 15443, Zone: "west"},
+		{IP: net.ParseIP("1.1.1.1"), Port: 15443, Zone: "east"},
+		{IP: net.ParseIP("1.1.1.2"), Port: 15443, Zone: "east"},
+	})
+
+	assert.Len(cla.Endpoints, 3)
+
+	assert.Equal(zone, cla.Endpoints[0].Locality.Zone)
+	assert.Equal(uint32(localPriority), cla.Endpoints[0].Priority)
+	assert.Len(cla.Endpoints[0].LbEndpoints, 1)
+	assert.Equal(uint32(100), cla.Endpoints[0].LbEndpoints[0].GetLoadBalancingWeight().Value)
+
+	assert.Equal("east", cla.Endpoints[1].Locality.Zone)
+	assert.Equal(uint32(remotePriority), cla.Endpoints[1].Priority)
+	assert.Len(cla.Endpoints[1].LbEndpoints, 2)
+	assert.Equal(uint32(50), cla.Endpoints[1].LbEndpoints[0].GetLoadBalancingWeight().Value)
+
+	assert.Equal("west", cla.Endpoints[2].Locality.Zone)
+	assert.Equal(uint32(remotePriority), cla.Endpoints[2].Priority)
+	assert.Len(cla.Endpoints[2].LbEndpoints, 1)
+
+	// Only remote endpoints: the local locality is empty and traffic fails over to the remote clusters
+	cla = NewClusterLoadAssignment(svc, []endpoint.Endpoint{{IP: net.ParseIP("1.1.1.1"), Port: 15443, Zone: "east"}})
+	assert.Len(cla.Endpoints, 2)
+	assert.Len(cla.Endpoints[0].LbEndpoints, 0)
+	assert.Len(cla.Endpoints[1].LbEndpoints, 1)
+}
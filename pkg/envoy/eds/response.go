@@ -56,7 +56,8 @@ func fulfillEDSRequest(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, re
 			log.Error().Err(err).Msgf("Failed listing allowed endpoints for service %s, for proxy identity %s", meshSvc, proxyIdentity)
 			continue
 		}
-		loadAssignment := newClusterLoadAssignment(meshSvc, endpoints)
+		loadAssignment := NewClusterLoadAssignment(meshSvc, withoutHostnameEndpoints(endpoints))
+		loadAssignment.ClusterName = cluster
 		rdsResources = append(rdsResources, loadAssignment)
 	}
 
@@ -79,15 +80,34 @@ func generateEDSConfig(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy) ([
 
 	var rdsResources []types.Resource
 	for svc, endpoints := range allowedEndpoints {
-		loadAssignment := newClusterLoadAssignment(svc, endpoints)
+		ipEndpoints := withoutHostnameEndpoints(endpoints)
+		loadAssignment := NewClusterLoadAssignment(svc, ipEndpoints)
+		if len(ipEndpoints) != len(endpoints) {
+			// The service cluster aggregates the EDS cluster with the DNS cluster of the hostname endpoints
+			loadAssignment.ClusterName = envoy.GetEDSClusterNameForServiceCluster(svc.String())
+		}
 		rdsResources = append(rdsResources, loadAssignment)
 	}
 
 	return rdsResources, nil
 }
 
+// withoutHostnameEndpoints returns the given endpoints except the remote gateways addressed by hostname, which
+// Envoy does not resolve when served over EDS and are configured on a DNS cluster instead
+func withoutHostnameEndpoints(endpoints []endpoint.Endpoint) []endpoint.Endpoint {
+	var ipEndpoints []endpoint.Endpoint
+	for _, ep := range endpoints {
+		if ep.Hostname == "" {
+			ipEndpoints = append(ipEndpoints, ep)
+		}
+	}
+	return ipEndpoints
+}
+
+// clusterToMeshSvc returns the service of the given service cluster, or of the given EDS cluster aggregated by a
+// service cluster
 func clusterToMeshSvc(cluster string) (service.MeshService, error) {
-	chunks := strings.Split(cluster, namespacedNameDelimiter)
+	chunks := strings.Split(envoy.GetServiceClusterNameForEDSCluster(cluster), namespacedNameDelimiter)
 	if len(chunks) != 2 {
 		return service.MeshService{}, errors.Errorf("Invalid cluster name. Expected: <namespace>/<name>, Got: %s", cluster)
 	}
@@ -2,6 +2,7 @@ package eds
 
 import (
 	"fmt"
+	"net"
 	"testing"
 
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
@@ -18,6 +19,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -84,6 +86,24 @@ func TestEndpointConfiguration(t *testing.T) {
 	// validating an endpoint
 	assert.True(ok)
 	assert.Len(loadAssignment.Endpoints, 1)
+
+	// The load assignment of an EDS cluster aggregated by a service cluster is named after the EDS cluster
+	request.ResourceNames = []string{envoy.GetEDSClusterNameForServiceCluster("default/bookstore-v1")}
+	resources, err = NewResponse(meshCatalog, proxy, request, mockConfigurator, nil, nil)
+	assert.Nil(err)
+	assert.Len(resources, 1)
+	assert.Equal("default/bookstore-v1|eds", resources[0].(*xds_endpoint.ClusterLoadAssignment).ClusterName)
+}
+
+func TestWithoutHostnameEndpoints(t *testing.T) {
+	assert := tassert.New(t)
+
+	endpoints := []endpoint.Endpoint{
+		{IP: net.ParseIP("10.0.0.1"), Port: 80},
+		{IP: net.ParseIP("1.1.1.1"), Port: 15443, Zone: "west"},
+		{Hostname: "gateway.east.example.com", Port: 15443, Zone: "east"},
+	}
+	assert.Equal(endpoints[:2], withoutHostnameEndpoints(endpoints))
 }
 
 func TestClusterToMeshSvc(t *testing.T) {
@@ -105,6 +125,15 @@ func TestClusterToMeshSvc(t *testing.T) {
 			expectedMeshSvc: service.MeshService{},
 			expectError:     true,
 		},
+		{
+			name:    "EDS cluster aggregated by a service cluster",
+			cluster: "foo/bar|eds",
+			expectedMeshSvc: service.MeshService{
+				Namespace: "foo",
+				Name:      "bar",
+			},
+			expectError: false,
+		},
 		{
 			name:    "valid cluster name",
 			cluster: "foo/bar",
@@ -0,0 +1,153 @@
+package lds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_jwt_authn "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/openservicemesh/osm/pkg/envoy/rbac"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// authzDenyHTTPFilterName is the name of the HTTP RBAC filter enforcing the DENY rules of AuthorizationPolicy resources.
+	// It must differ from the name of the HTTP RBAC filter enforcing SMI policies, whose config is overridden per route.
+	authzDenyHTTPFilterName = "osm.authz.deny"
+
+	// authzAllowHTTPFilterName is the name of the HTTP RBAC filter enforcing the ALLOW rules of AuthorizationPolicy resources
+	authzAllowHTTPFilterName = "osm.authz.allow"
+)
+
+// getAuthorizationHTTPFilters returns the HTTP RBAC filters enforcing the given authorization traffic policy.
+// A request is denied if it matches any DENY rule. If ALLOW rules are enforced, a request is denied unless
+// it matches at least one of them, so an ALLOW filter without rules denies all requests. These filters are layered on top of the HTTP RBAC filter enforcing SMI policies,
+// so a request must be permitted by both. If JWT issuers are specified, the JWT authentication filter verifying
+// the request principals matched by the rules precedes the RBAC filters.
+func getAuthorizationHTTPFilters(authzPolicy *trafficpolicy.AuthorizationTrafficPolicy) ([]*xds_hcm.HttpFilter, error) {
+	if authzPolicy == nil {
+		return nil, nil
+	}
+
+	var filters []*xds_hcm.HttpFilter
+
+	if len(authzPolicy.JWTIssuers) != 0 {
+		filter, err := getJWTAuthnHTTPFilter(authzPolicy.JWTIssuers)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	if len(authzPolicy.DenyRules) != 0 {
+		filter, err := getAuthorizationHTTPFilter(authzDenyHTTPFilterName, xds_rbac.RBAC_DENY, authzPolicy.DenyRules)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	if authzPolicy.EnforceAllowRules {
+		filter, err := getAuthorizationHTTPFilter(authzAllowHTTPFilterName, xds_rbac.RBAC_ALLOW, authzPolicy.AllowRules)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// getAuthorizationHTTPFilter returns an HTTP RBAC filter with the given name taking the given action on the requests matching any of the rules
+func getAuthorizationHTTPFilter(name string, action xds_rbac.RBAC_Action, rules []*trafficpolicy.AuthorizationRule) (*xds_hcm.HttpFilter, error) {
+	policies := make(map[string]*xds_rbac.Policy)
+	for _, rule := range rules {
+		policies[rule.Name] = rbac.GenerateAuthorizationPolicy(rule)
+	}
+
+	httpRBAC := &xds_http_rbac.RBAC{
+		Rules: &xds_rbac.RBAC{
+			Action:   action,
+			Policies: policies,
+		},
+	}
+
+	marshalledHTTPRBAC, err := ptypes.MarshalAny(httpRBAC)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error marshalling %s HTTP RBAC filter", name)
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name:       name,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{TypedConfig: marshalledHTTPRBAC},
+	}, nil
+}
+
+// getJWTAuthnHTTPFilter returns an HTTP JWT authentication filter verifying the JWTs of the given issuers and
+// storing the payload of the verified JWT in the dynamic metadata matched by the RBAC filters.
+// Requests without a JWT or with a JWT that fails verification are not rejected by the filter, they have
+// no request principal and are authorized by the RBAC filters accordingly.
+func getJWTAuthnHTTPFilter(jwtIssuers []*trafficpolicy.JWTIssuer) (*xds_hcm.HttpFilter, error) {
+	providers := make(map[string]*xds_jwt_authn.JwtProvider)
+	var requirements []*xds_jwt_authn.JwtRequirement
+	for _, jwtIssuer := range jwtIssuers {
+		providers[jwtIssuer.Issuer] = &xds_jwt_authn.JwtProvider{
+			Issuer: jwtIssuer.Issuer,
+			JwksSourceSpecifier: &xds_jwt_authn.JwtProvider_LocalJwks{
+				LocalJwks: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_InlineString{
+						InlineString: jwtIssuer.JWKS,
+					},
+				},
+			},
+			// Forward the JWT to the application
+			Forward:           true,
+			PayloadInMetadata: rbac.JWTPayloadMetadataKey,
+		}
+		requirements = append(requirements, &xds_jwt_authn.JwtRequirement{
+			RequiresType: &xds_jwt_authn.JwtRequirement_ProviderName{
+				ProviderName: jwtIssuer.Issuer,
+			},
+		})
+	}
+	requirements = append(requirements, &xds_jwt_authn.JwtRequirement{
+		RequiresType: &xds_jwt_authn.JwtRequirement_AllowMissingOrFailed{
+			AllowMissingOrFailed: &emptypb.Empty{},
+		},
+	})
+
+	jwtAuthn := &xds_jwt_authn.JwtAuthentication{
+		Providers: providers,
+		Rules: []*xds_jwt_authn.RequirementRule{
+			{
+				Match: &xds_route.RouteMatch{
+					PathSpecifier: &xds_route.RouteMatch_Prefix{Prefix: "/"},
+				},
+				RequirementType: &xds_jwt_authn.RequirementRule_Requires{
+					Requires: &xds_jwt_authn.JwtRequirement{
+						RequiresType: &xds_jwt_authn.JwtRequirement_RequiresAny{
+							RequiresAny: &xds_jwt_authn.JwtRequirementOrList{
+								Requirements: requirements,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	marshalledJWTAuthn, err := ptypes.MarshalAny(jwtAuthn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error marshalling %s HTTP filter", rbac.JWTAuthnFilterName)
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name:       rbac.JWTAuthnFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{TypedConfig: marshalledJWTAuthn},
+	}, nil
+}
@@ -0,0 +1,104 @@
+package lds
+
+import (
+	"testing"
+
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_jwt_authn "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/envoy/rbac"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetAuthorizationHTTPFilters(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		authzPolicy            *trafficpolicy.AuthorizationTrafficPolicy
+		expectedFilterNames    []string
+		expectedAllowPolicyLen int
+	}{
+		{
+			name: "no authorization policy",
+		},
+		{
+			name:                "DENY rules only",
+			authzPolicy:         &trafficpolicy.AuthorizationTrafficPolicy{DenyRules: []*trafficpolicy.AuthorizationRule{{Name: "test/deny/rule-0"}}},
+			expectedFilterNames: []string{authzDenyHTTPFilterName},
+		},
+		{
+			name: "enforced ALLOW rules",
+			authzPolicy: &trafficpolicy.AuthorizationTrafficPolicy{
+				EnforceAllowRules: true,
+				AllowRules:        []*trafficpolicy.AuthorizationRule{{Name: "test/allow/rule-0"}},
+			},
+			expectedFilterNames:    []string{authzAllowHTTPFilterName},
+			expectedAllowPolicyLen: 1,
+		},
+		{
+			name:                   "enforced ALLOW policy without valid rules denies all requests",
+			authzPolicy:            &trafficpolicy.AuthorizationTrafficPolicy{EnforceAllowRules: true},
+			expectedFilterNames:    []string{authzAllowHTTPFilterName},
+			expectedAllowPolicyLen: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			filters, err := getAuthorizationHTTPFilters(tc.authzPolicy)
+			assert.Nil(err)
+
+			var filterNames []string
+			for _, filter := range filters {
+				filterNames = append(filterNames, filter.Name)
+				if filter.Name != authzAllowHTTPFilterName {
+					continue
+				}
+				httpRBAC := &xds_http_rbac.RBAC{}
+				assert.Nil(ptypes.UnmarshalAny(filter.GetTypedConfig(), httpRBAC))
+				assert.Equal(xds_rbac.RBAC_ALLOW, httpRBAC.Rules.Action)
+				assert.Len(httpRBAC.Rules.Policies, tc.expectedAllowPolicyLen)
+			}
+			assert.Equal(tc.expectedFilterNames, filterNames)
+		})
+	}
+}
+
+func TestGetJWTAuthnHTTPFilter(t *testing.T) {
+	assert := tassert.New(t)
+
+	jwtIssuers := []*trafficpolicy.JWTIssuer{
+		{Issuer: "https://issuer-1.example.com", JWKS: "jwks-1"},
+		{Issuer: "https://issuer-2.example.com", JWKS: "jwks-2"},
+	}
+
+	filter, err := getJWTAuthnHTTPFilter(jwtIssuers)
+	assert.Nil(err)
+	assert.Equal(rbac.JWTAuthnFilterName, filter.Name)
+
+	jwtAuthn := &xds_jwt_authn.JwtAuthentication{}
+	assert.Nil(ptypes.UnmarshalAny(filter.GetTypedConfig(), jwtAuthn))
+	assert.Nil(jwtAuthn.Validate())
+
+	assert.Len(jwtAuthn.Providers, 2)
+	for _, jwtIssuer := range jwtIssuers {
+		provider := jwtAuthn.Providers[jwtIssuer.Issuer]
+		assert.NotNil(provider)
+		assert.Equal(jwtIssuer.Issuer, provider.Issuer)
+		assert.Equal(jwtIssuer.JWKS, provider.GetLocalJwks().GetInlineString())
+		assert.Equal(rbac.JWTPayloadMetadataKey, provider.PayloadInMetadata)
+		assert.True(provider.Forward)
+	}
+
+	// Requests are not rejected by the filter when the JWT is missing or fails verification
+	assert.Len(jwtAuthn.Rules, 1)
+	requirements := jwtAuthn.Rules[0].GetRequires().GetRequiresAny().Requirements
+	assert.Len(requirements, 3)
+	assert.Equal(jwtIssuers[0].Issuer, requirements[0].GetProviderName())
+	assert.Equal(jwtIssuers[1].Issuer, requirements[1].GetProviderName())
+	assert.NotNil(requirements[2].GetAllowMissingOrFailed())
+}
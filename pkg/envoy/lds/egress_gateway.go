@@ -0,0 +1,77 @@
+package lds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/rds/route"
+)
+
+const (
+	egressGatewayFilterChainName = "egress-gateway-filter-chain"
+)
+
+// buildEgressGatewayListener builds the listener for the egress gateway. The listener terminates the mTLS
+// connections from sidecars and subjects their HTTP requests to the egress gateway's route configuration,
+// which authorizes each request based on the Egress policy it matches.
+func (lb *listenerBuilder) buildEgressGatewayListener() (*xds_listener.Listener, error) {
+	connManager, err := httpConnManagerOptions{
+		direction:         inbound,
+		rdsRoutConfigName: route.EgressGatewayRouteConfigName,
+
+		// Tracing options
+		tracing:   lb.getTracingConfig(),
+		accessLog: lb.accessLog,
+	}.build()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error building egress gateway HTTP connection manager for proxy identity %s", lb.serviceIdentity)
+	}
+
+	marshalledConnManager, err := ptypes.MarshalAny(connManager)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error marshalling egress gateway HTTP connection manager for proxy identity %s", lb.serviceIdentity)
+	}
+
+	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(envoy.GetDownstreamTLSContext(lb.serviceIdentity, true /* mTLS */))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error marshalling DownstreamTLSContext for egress gateway with identity %s", lb.serviceIdentity)
+	}
+
+	return &xds_listener.Listener{
+		Name:    egressGatewayListenerName,
+		Address: envoy.GetAddress(constants.WildcardIPAddr, constants.EgressGatewayPort),
+		FilterChains: []*xds_listener.FilterChain{
+			{
+				Name: egressGatewayFilterChainName,
+				FilterChainMatch: &xds_listener.FilterChainMatch{
+					TransportProtocol: envoy.TransportProtocolTLS,
+
+					// In-mesh proxies will advertise this, set in the UpstreamTlsContext by GetUpstreamTLSContext()
+					ApplicationProtocols: envoy.ALPNInMesh,
+				},
+				Filters: []*xds_listener.Filter{
+					{
+						Name:       wellknown.HTTPConnectionManager,
+						ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledConnManager},
+					},
+				},
+				TransportSocket: &xds_core.TransportSocket{
+					Name: wellknown.TransportSocketTls,
+					ConfigType: &xds_core.TransportSocket_TypedConfig{
+						TypedConfig: marshalledDownstreamTLSContext,
+					},
+				},
+			},
+		},
+		ListenerFilters: []*xds_listener.ListenerFilter{
+			{
+				Name: wellknown.TlsInspector,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,46 @@
+package lds
+
+import (
+	"testing"
+
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy/rds/route"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+func TestBuildEgressGatewayListener(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetTracingEndpoint().Return("").AnyTimes()
+
+	lb := &listenerBuilder{
+		cfg:             mockConfigurator,
+		serviceIdentity: identity.K8sServiceAccount{Name: constants.EgressGatewayName, Namespace: "osm-system"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+	}
+
+	listener, err := lb.buildEgressGatewayListener()
+	assert.Nil(err)
+	assert.Equal(egressGatewayListenerName, listener.Name)
+	assert.Equal(uint32(constants.EgressGatewayPort), listener.Address.GetSocketAddress().GetPortValue())
+	assert.Len(listener.FilterChains, 1)
+
+	filterChain := listener.FilterChains[0]
+	downstreamTLSContext := &xds_auth.DownstreamTlsContext{}
+	assert.Nil(ptypes.UnmarshalAny(filterChain.TransportSocket.GetTypedConfig(), downstreamTLSContext))
+	assert.True(downstreamTLSContext.RequireClientCertificate.Value)
+
+	connManager := &xds_hcm.HttpConnectionManager{}
+	assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), connManager))
+	assert.Equal(route.EgressGatewayRouteConfigName, connManager.GetRds().RouteConfigName)
+}
@@ -0,0 +1,82 @@
+package lds
+
+import (
+	xds_lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	xds_wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getFilterExtensions returns the Envoy filter extensions of the given direction for the proxy
+func (lb *listenerBuilder) getFilterExtensions(direction connectionDirection) []*trafficpolicy.EnvoyFilterExtension {
+	if !lb.cfg.GetFeatureFlags().EnableEnvoyFilterExtension {
+		return nil
+	}
+
+	extensionDirection := policyV1alpha1.EnvoyFilterDirectionInbound
+	if direction == outbound {
+		extensionDirection = policyV1alpha1.EnvoyFilterDirectionOutbound
+	}
+	return lb.meshCatalog.GetEnvoyFilterExtensions(lb.serviceIdentity, extensionDirection)
+}
+
+// getEnvoyFilterExtensionHTTPFilters returns the HTTP filters of the given Envoy filter extensions, in the same order
+func getEnvoyFilterExtensionHTTPFilters(extensions []*trafficpolicy.EnvoyFilterExtension) ([]*xds_hcm.HttpFilter, error) {
+	var filters []*xds_hcm.HttpFilter
+
+	for _, extension := range extensions {
+		var filter *xds_hcm.HttpFilter
+		var err error
+
+		if extension.WASM != nil {
+			filter, err = getWASMExtensionHTTPFilter(extension)
+		} else {
+			filter, err = getLuaExtensionHTTPFilter(extension)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error building HTTP filter for Envoy filter extension %s", extension.Name)
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+func getWASMExtensionHTTPFilter(extension *trafficpolicy.EnvoyFilterExtension) (*xds_hcm.HttpFilter, error) {
+	pluginConfig, err := getWASMPluginConfig(extension.Name, extension.WASM.RootID, extension.WASM.Code, extension.WASM.Configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	wasmAny, err := ptypes.MarshalAny(&xds_wasm.Wasm{Config: pluginConfig})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling Wasm config")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: wasmHTTPFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: wasmAny,
+		},
+	}, nil
+}
+
+func getLuaExtensionHTTPFilter(extension *trafficpolicy.EnvoyFilterExtension) (*xds_hcm.HttpFilter, error) {
+	luaAny, err := ptypes.MarshalAny(&xds_lua.Lua{InlineCode: extension.LuaInlineCode})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling Lua filter")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: wellknown.Lua,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: luaAny,
+		},
+	}, nil
+}
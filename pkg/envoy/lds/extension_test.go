@@ -0,0 +1,113 @@
+package lds
+
+import (
+	"testing"
+
+	xds_lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	xds_wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	tassert "github.com/stretchr/testify/assert"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetEnvoyFilterExtensionHTTPFilters(t *testing.T) {
+	assert := tassert.New(t)
+
+	extensions := []*trafficpolicy.EnvoyFilterExtension{
+		{
+			Name: "test/legacy-auth",
+			WASM: &trafficpolicy.WASMModule{
+				Code:          []byte("\x00asm\x01\x00\x00\x00"),
+				RootID:        "auth",
+				Configuration: "realm=legacy",
+			},
+		},
+		{
+			Name:          "test/normalize-headers",
+			LuaInlineCode: "function envoy_on_request(request_handle) end",
+		},
+	}
+
+	filters, err := getEnvoyFilterExtensionHTTPFilters(extensions)
+	assert.Nil(err)
+	assert.Len(filters, 2)
+
+	assert.Equal(wasmHTTPFilterName, filters[0].Name)
+	wasmConfig := &xds_wasm.Wasm{}
+	assert.Nil(ptypes.UnmarshalAny(filters[0].GetTypedConfig(), wasmConfig))
+	assert.Equal("test/legacy-auth", wasmConfig.Config.Name)
+	assert.Equal("auth", wasmConfig.Config.RootId)
+	assert.Equal(extensions[0].WASM.Code, wasmConfig.Config.GetVmConfig().Code.GetLocal().GetInlineBytes())
+	configuration := &wrappers.StringValue{}
+	assert.Nil(ptypes.UnmarshalAny(wasmConfig.Config.Configuration, configuration))
+	assert.Equal("realm=legacy", configuration.Value)
+
+	assert.Equal(wellknown.Lua, filters[1].Name)
+	luaConfig := &xds_lua.Lua{}
+	assert.Nil(ptypes.UnmarshalAny(filters[1].GetTypedConfig(), luaConfig))
+	assert.Equal(extensions[1].LuaInlineCode, luaConfig.InlineCode)
+}
+
+func TestGetFilterExtensions(t *testing.T) {
+	testCases := []struct {
+		name              string
+		enabled           bool
+		direction         connectionDirection
+		expectedDirection policyV1alpha1.EnvoyFilterDirection
+	}{
+		{
+			name:      "EnvoyFilterExtension API is disabled",
+			enabled:   false,
+			direction: inbound,
+		},
+		{
+			name:              "inbound extensions",
+			enabled:           true,
+			direction:         inbound,
+			expectedDirection: policyV1alpha1.EnvoyFilterDirectionInbound,
+		},
+		{
+			name:              "outbound extensions",
+			enabled:           true,
+			direction:         outbound,
+			expectedDirection: policyV1alpha1.EnvoyFilterDirectionOutbound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			lb := &listenerBuilder{
+				serviceIdentity: tests.BookstoreServiceIdentity,
+				meshCatalog:     mockCatalog,
+				cfg:             mockConfigurator,
+			}
+
+			expected := []*trafficpolicy.EnvoyFilterExtension{{Name: "test/extension", LuaInlineCode: "function envoy_on_request(request_handle) end"}}
+			mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{EnableEnvoyFilterExtension: tc.enabled})
+			if tc.enabled {
+				mockCatalog.EXPECT().GetEnvoyFilterExtensions(tests.BookstoreServiceIdentity, tc.expectedDirection).Return(expected)
+			} else {
+				expected = nil
+			}
+
+			actual := lb.getFilterExtensions(tc.direction)
+			assert.Equal(expected, actual)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -22,7 +23,7 @@ const (
 
 func (lb *listenerBuilder) buildMulticlusterGatewayListener() (*xds_listener.Listener, error) {
 	upstreamServices := lb.meshCatalog.ListOutboundServicesForMulticlusterGateway()
-	filterChains, err := getMulticlusterGatewayFilterChains(upstreamServices)
+	filterChains, err := getMulticlusterGatewayFilterChains(upstreamServices, lb.accessLog)
 	if err != nil {
 		log.Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msg("[Multicluster] Error creating Multicluster gateway filter chain")
 		return nil, err
@@ -40,13 +41,13 @@ func (lb *listenerBuilder) buildMulticlusterGatewayListener() (*xds_listener.Lis
 	}, nil
 }
 
-func getMulticlusterGatewayFilterChains(upstreamServices []service.MeshService) ([]*xds_listener.FilterChain, error) {
+func getMulticlusterGatewayFilterChains(upstreamServices []service.MeshService, accessLog configv1alpha1.AccessLogSpec) ([]*xds_listener.FilterChain, error) {
 	var filterChains []*xds_listener.FilterChain
 	for _, upstreamSvc := range upstreamServices {
 		tcpProxy := &xds_tcp_proxy.TcpProxy{
 			StatPrefix:       upstreamSvc.String(),
 			ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: upstreamSvc.String()},
-			AccessLog:        envoy.GetAccessLog(),
+			AccessLog:        envoy.GetTCPAccessLogs(accessLog),
 		}
 
 		marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
@@ -26,7 +26,7 @@ func TestBuildMulticlusterGatewayListeners(t *testing.T) {
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 	mockConfigurator.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableMulticlusterMode: true}).AnyTimes()
 
-	id := identity.K8sServiceAccount{Name: "osm", Namespace: "osm-system"}.ToServiceIdentity()
+	id := identity.K8sServiceAccount{Name: "osm", Namespace: "osm-system"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
 	meshServices := []service.MeshService{
 		tests.BookstoreV1Service,
 		tests.BookstoreV2Service,
@@ -51,7 +51,7 @@ func TestGetGatewayFilterChains(t *testing.T) {
 	assert := tassert.New(t)
 
 	meshServices := []service.MeshService{tests.BookstoreV1Service}
-	filterChains, err := getMulticlusterGatewayFilterChains(meshServices)
+	filterChains, err := getMulticlusterGatewayFilterChains(meshServices, v1alpha1.AccessLogSpec{})
 	assert.Nil(err)
 	assert.NotNil(filterChains)
 	assert.Equal(len(filterChains), 1)
@@ -9,9 +9,11 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/pkg/errors"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/auth"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // connectionDirection defines, for filter terms, the direction of a connection from
@@ -38,11 +40,17 @@ type httpConnManagerOptions struct {
 	// Additional filters
 	wasmStatsHeaders         map[string]string
 	extAuthConfig            *auth.ExtAuthConfig
+	authzPolicy              *trafficpolicy.AuthorizationTrafficPolicy
 	enableActiveHealthChecks bool
 
-	// Tracing options
-	enableTracing      bool
-	tracingAPIEndpoint string
+	// User-supplied filters, added after the filters managed by OSM
+	filterExtensions []*trafficpolicy.EnvoyFilterExtension
+
+	// Tracing options, nil when tracing is disabled
+	tracing *tracingConfig
+
+	// Access logging options
+	accessLog configv1alpha1.AccessLogSpec
 }
 
 func (options httpConnManagerOptions) build() (*xds_hcm.HttpConnectionManager, error) {
@@ -64,7 +72,16 @@ func (options httpConnManagerOptions) build() (*xds_hcm.HttpConnectionManager, e
 				RouteConfigName: options.rdsRoutConfigName,
 			},
 		},
-		AccessLog: envoy.GetAccessLog(),
+		AccessLog: envoy.GetHTTPAccessLogs(options.accessLog),
+	}
+
+	// For inbound connections, add the AuthorizationPolicy RBAC filters
+	if options.direction == inbound && options.authzPolicy != nil {
+		authzFilters, err := getAuthorizationHTTPFilters(options.authzPolicy)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error getting authorization filters for HTTP connection manager")
+		}
+		connManager.HttpFilters = append(connManager.HttpFilters, authzFilters...)
 	}
 
 	// For inbound connections, add the Authz filter
@@ -73,8 +90,8 @@ func (options httpConnManagerOptions) build() (*xds_hcm.HttpConnectionManager, e
 	}
 
 	// Enable tracing if requested
-	if options.enableTracing {
-		tracing, err := getHTTPTracingConfig(options.tracingAPIEndpoint)
+	if options.tracing != nil {
+		tracing, err := getHTTPTracingConfig(options.tracing)
 		if err != nil {
 			return nil, errors.Wrap(err, "Error getting tracing config for HTTP connection manager")
 		}
@@ -103,13 +120,22 @@ func (options httpConnManagerOptions) build() (*xds_hcm.HttpConnectionManager, e
 		connManager.HttpFilters = append(connManager.HttpFilters, hc)
 	}
 
+	// The user-supplied filters must be positioned after the filters managed by OSM
+	if len(options.filterExtensions) > 0 {
+		extensionFilters, err := getEnvoyFilterExtensionHTTPFilters(options.filterExtensions)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error getting Envoy filter extensions for HTTP connection manager")
+		}
+		connManager.HttpFilters = append(connManager.HttpFilters, extensionFilters...)
+	}
+
 	// *IMPORTANT NOTE*: The Router filter must always be the last filter
 	connManager.HttpFilters = append(connManager.HttpFilters, &xds_hcm.HttpFilter{Name: wellknown.Router})
 
 	return connManager, nil
 }
 
-func getPrometheusConnectionManager() *xds_hcm.HttpConnectionManager {
+func getPrometheusConnectionManager(accessLog configv1alpha1.AccessLogSpec) *xds_hcm.HttpConnectionManager {
 	return &xds_hcm.HttpConnectionManager{
 		StatPrefix: prometheusHTTPConnManagerStatPrefix,
 		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
@@ -139,6 +165,6 @@ func getPrometheusConnectionManager() *xds_hcm.HttpConnectionManager {
 				}},
 			},
 		},
-		AccessLog: envoy.GetAccessLog(),
+		AccessLog: envoy.GetHTTPAccessLogs(accessLog),
 	}
 }
@@ -8,7 +8,11 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/stretchr/testify/assert"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+
 	"github.com/openservicemesh/osm/pkg/auth"
+	"github.com/openservicemesh/osm/pkg/envoy/rbac"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 func TestHTTPConnbuild(t *testing.T) {
@@ -41,8 +45,11 @@ func TestHTTPConnbuild(t *testing.T) {
 		{
 			name: "tracing config when tracing is enabled",
 			option: httpConnManagerOptions{
-				enableTracing:      true,
-				tracingAPIEndpoint: "/api/v1/trace",
+				tracing: &tracingConfig{
+					provider:           configv1alpha1.TracingProviderZipkin,
+					apiEndpoint:        "/api/v1/trace",
+					samplingPercentage: 100,
+				},
 			},
 			assertFunc: func(a *assert.Assertions, connManager *xds_hcm.HttpConnectionManager) {
 				a.NotNil(connManager.Tracing)
@@ -53,7 +60,7 @@ func TestHTTPConnbuild(t *testing.T) {
 		{
 			name: "tracing config when tracing is disabled",
 			option: httpConnManagerOptions{
-				enableTracing: false,
+				tracing: nil,
 			},
 			assertFunc: func(a *assert.Assertions, connManager *xds_hcm.HttpConnectionManager) {
 				a.Nil(connManager.Tracing)
@@ -111,6 +118,53 @@ func TestHTTPConnbuild(t *testing.T) {
 				a.True(notContains(connManager.HttpFilters, wellknown.HTTPExternalAuthorization))
 			},
 		},
+		{
+			name: "AuthorizationPolicy filters follow the SMI RBAC filter for inbound",
+			option: httpConnManagerOptions{
+				direction: inbound,
+				authzPolicy: &trafficpolicy.AuthorizationTrafficPolicy{
+					DenyRules:         []*trafficpolicy.AuthorizationRule{{Name: "test/deny/rule-0", Methods: []string{"DELETE"}}},
+					EnforceAllowRules: true,
+					AllowRules:        []*trafficpolicy.AuthorizationRule{{Name: "test/allow/rule-0", Paths: []string{"/api*"}}},
+				},
+			},
+			assertFunc: func(a *assert.Assertions, connManager *xds_hcm.HttpConnectionManager) {
+				a.Equal(wellknown.HTTPRoleBasedAccessControl, connManager.HttpFilters[0].Name)
+				a.Equal(authzDenyHTTPFilterName, connManager.HttpFilters[1].Name)
+				a.Equal(authzAllowHTTPFilterName, connManager.HttpFilters[2].Name)
+			},
+		},
+		{
+			name: "JWT authentication filter precedes the AuthorizationPolicy filters matching request principals",
+			option: httpConnManagerOptions{
+				direction: inbound,
+				authzPolicy: &trafficpolicy.AuthorizationTrafficPolicy{
+					EnforceAllowRules: true,
+					AllowRules: []*trafficpolicy.AuthorizationRule{{
+						Name:              "test/allow/rule-0",
+						RequestPrincipals: []trafficpolicy.RequestPrincipal{{Issuer: "https://issuer.example.com", Subject: "user1"}},
+					}},
+					JWTIssuers: []*trafficpolicy.JWTIssuer{{Issuer: "https://issuer.example.com", JWKS: "{}"}},
+				},
+			},
+			assertFunc: func(a *assert.Assertions, connManager *xds_hcm.HttpConnectionManager) {
+				a.Equal(wellknown.HTTPRoleBasedAccessControl, connManager.HttpFilters[0].Name)
+				a.Equal(rbac.JWTAuthnFilterName, connManager.HttpFilters[1].Name)
+				a.Equal(authzAllowHTTPFilterName, connManager.HttpFilters[2].Name)
+			},
+		},
+		{
+			name: "AuthorizationPolicy filters are not added for outbound",
+			option: httpConnManagerOptions{
+				direction: outbound,
+				authzPolicy: &trafficpolicy.AuthorizationTrafficPolicy{
+					DenyRules: []*trafficpolicy.AuthorizationRule{{Name: "test/deny/rule-0", Methods: []string{"DELETE"}}},
+				},
+			},
+			assertFunc: func(a *assert.Assertions, connManager *xds_hcm.HttpConnectionManager) {
+				a.True(notContains(connManager.HttpFilters, authzDenyHTTPFilterName))
+			},
+		},
 		{
 			name: "health check config present when enabled",
 			option: httpConnManagerOptions{
@@ -129,6 +183,22 @@ func TestHTTPConnbuild(t *testing.T) {
 				a.True(notContains(connManager.HttpFilters, wellknown.HealthCheck))
 			},
 		},
+		{
+			name: "filter extensions are added after the filters managed by OSM",
+			option: httpConnManagerOptions{
+				direction:                inbound,
+				enableActiveHealthChecks: true,
+				filterExtensions: []*trafficpolicy.EnvoyFilterExtension{
+					{Name: "test/normalize-headers", LuaInlineCode: "function envoy_on_request(request_handle) end"},
+				},
+			},
+			assertFunc: func(a *assert.Assertions, connManager *xds_hcm.HttpConnectionManager) {
+				a.Len(connManager.HttpFilters, 4)
+				a.Equal(wellknown.HTTPRoleBasedAccessControl, connManager.HttpFilters[0].Name)
+				a.Equal(wellknown.HealthCheck, connManager.HttpFilters[1].Name)
+				a.Equal(wellknown.Lua, connManager.HttpFilters[2].Name)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
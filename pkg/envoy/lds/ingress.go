@@ -49,18 +49,24 @@ func (lb *listenerBuilder) getIngressFilterChainFromTrafficMatch(trafficMatch *t
 		return nil, errors.Errorf("Nil IngressTrafficMatch for ingress on proxy with identity %s", lb.serviceIdentity)
 	}
 
+	// Inbound filter extensions also apply to ingress traffic so that it can not bypass them
+	filterExtensions := lb.getFilterExtensions(inbound)
+
 	// Build the HTTP Connection Manager filter from its options
 	ingressConnManager, err := httpConnManagerOptions{
 		direction:         inbound,
-		rdsRoutConfigName: route.IngressRouteConfigName,
+		rdsRoutConfigName: route.GetIngressRouteConfigNameForPort(trafficMatch.Port),
 
 		// Additional filters
 		wasmStatsHeaders: nil, // no WASM Stats for ingress traffic
 		extAuthConfig:    lb.getExtAuthConfig(),
 
+		// User-supplied filters
+		filterExtensions: filterExtensions,
+
 		// Tracing options
-		enableTracing:      lb.cfg.IsTracingEnabled(),
-		tracingAPIEndpoint: lb.cfg.GetTracingEndpoint(),
+		tracing:   lb.getTracingConfig(),
+		accessLog: lb.accessLog,
 	}.build()
 	if err != nil {
 		return nil, errors.Errorf("Error building inbound HTTP connection manager for proxy with identity %s, traffic match: %v ", lb.serviceIdentity, trafficMatch)
@@ -10,6 +10,8 @@ import (
 	tassert "github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+
 	"github.com/openservicemesh/osm/pkg/auth"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -82,6 +84,7 @@ func TestGetIngressFilterChains(t *testing.T) {
 			mockCatalog.EXPECT().GetIngressTrafficPolicy(testSvc).Return(tc.ingressPolicy, nil)
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
 			mockConfigurator.EXPECT().GetTracingEndpoint().Return("test").AnyTimes()
+			mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{}).AnyTimes()
 			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(auth.ExtAuthConfig{
 				Enable: false,
 			}).AnyTimes()
@@ -171,7 +174,7 @@ func TestGetIngressFilterChainFromTrafficMatch(t *testing.T) {
 			}
 
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false)
-			mockConfigurator.EXPECT().GetTracingEndpoint().Return("test")
+			mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{})
 			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(auth.ExtAuthConfig{
 				Enable: false,
 			})
@@ -84,6 +84,8 @@ func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshServic
 		filters = append(filters, rbacFilter)
 	}
 
+	filterExtensions := lb.getFilterExtensions(inbound)
+
 	// Build the HTTP Connection Manager filter from its options
 	inboundConnManager, err := httpConnManagerOptions{
 		direction:         inbound,
@@ -92,11 +94,15 @@ func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshServic
 		// Additional filters
 		wasmStatsHeaders:         lb.getWASMStatsHeaders(),
 		extAuthConfig:            lb.getExtAuthConfig(),
+		authzPolicy:              lb.meshCatalog.GetAuthorizationTrafficPolicy(proxyService),
 		enableActiveHealthChecks: lb.cfg.GetFeatureFlags().EnableEnvoyActiveHealthChecks,
 
+		// User-supplied filters
+		filterExtensions: filterExtensions,
+
 		// Tracing options
-		enableTracing:      lb.cfg.IsTracingEnabled(),
-		tracingAPIEndpoint: lb.cfg.GetTracingEndpoint(),
+		tracing:   lb.getTracingConfig(),
+		accessLog: lb.accessLog,
 	}.build()
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error building inbound HTTP connection manager for proxy with identity %s and service %s", lb.serviceIdentity, proxyService)
@@ -237,6 +243,7 @@ func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService
 	tcpProxy := &xds_tcp_proxy.TcpProxy{
 		StatPrefix:       fmt.Sprintf("%s.%s", inboundMeshTCPProxyStatPrefix, localServiceCluster),
 		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: localServiceCluster},
+		AccessLog:        envoy.GetTCPAccessLogs(lb.accessLog),
 	}
 	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
 	if err != nil {
@@ -256,7 +263,8 @@ func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService
 // getOutboundHTTPFilter returns an HTTP connection manager network filter used to filter outbound HTTP traffic for the given route configuration
 func (lb *listenerBuilder) getOutboundHTTPFilter(routeConfigName string) (*xds_listener.Filter, error) {
 	var marshalledFilter *any.Any
-	var err error
+
+	filterExtensions := lb.getFilterExtensions(outbound)
 
 	// Build the HTTP connection manager filter from its options
 	outboundConnManager, err := httpConnManagerOptions{
@@ -267,9 +275,12 @@ func (lb *listenerBuilder) getOutboundHTTPFilter(routeConfigName string) (*xds_l
 		wasmStatsHeaders: lb.statsHeaders,
 		extAuthConfig:    nil, // Ext auth is not configured for outbound connections
 
+		// User-supplied filters
+		filterExtensions: filterExtensions,
+
 		// Tracing options
-		enableTracing:      lb.cfg.IsTracingEnabled(),
-		tracingAPIEndpoint: lb.cfg.GetTracingEndpoint(),
+		tracing:   lb.getTracingConfig(),
+		accessLog: lb.accessLog,
 	}.build()
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error building outbound HTTP connection manager for proxy identity %s", lb.serviceIdentity)
@@ -374,10 +385,26 @@ func (lb *listenerBuilder) getOutboundTCPFilterChainForService(upstream service.
 		return nil, err
 	}
 
+	filters := []*xds_listener.Filter{filter}
+
+	// Record the TCP stats of the connections to the upstream service ahead of proxying them. The filter is
+	// the same for all the upstream services, it is only built once.
+	if statsHeaders := lb.getWASMStatsHeaders(); statsHeaders != nil {
+		if lb.tcpStatsFilter == nil {
+			if lb.tcpStatsFilter, err = getTCPStatsWASMFilter(statsHeaders); err != nil {
+				log.Error().Err(err).Msgf("Error getting WASM TCP stats filter for upstream service %s", upstream)
+				return nil, err
+			}
+		}
+		if lb.tcpStatsFilter != nil {
+			filters = append([]*xds_listener.Filter{lb.tcpStatsFilter}, filters...)
+		}
+	}
+
 	filterChainName := fmt.Sprintf("%s:%s", outboundMeshTCPFilterChainPrefix, upstream)
 	return &xds_listener.FilterChain{
 		Name:             filterChainName,
-		Filters:          []*xds_listener.Filter{filter},
+		Filters:          filters,
 		FilterChainMatch: filterChainMatch,
 	}, nil
 }
@@ -385,6 +412,7 @@ func (lb *listenerBuilder) getOutboundTCPFilterChainForService(upstream service.
 func (lb *listenerBuilder) getOutboundTCPFilter(upstream service.MeshService) (*xds_listener.Filter, error) {
 	tcpProxy := &xds_tcp_proxy.TcpProxy{
 		StatPrefix: fmt.Sprintf("%s.%s", outboundMeshTCPProxyStatPrefix, upstream),
+		AccessLog:  envoy.GetTCPAccessLogs(lb.accessLog),
 	}
 
 	weightedClusters := lb.meshCatalog.GetWeightedClustersForUpstream(upstream)
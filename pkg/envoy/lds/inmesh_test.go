@@ -126,6 +126,7 @@ func TestGetOutboundTCPFilterChainForService(t *testing.T) {
 		meshCatalog:     mockCatalog,
 		cfg:             mockConfigurator,
 		serviceIdentity: tests.BookbuyerServiceIdentity,
+		statsHeaders:    map[string]string{"osm-stats-namespace": "default"},
 	}
 
 	testCases := []struct {
@@ -133,6 +134,8 @@ func TestGetOutboundTCPFilterChainForService(t *testing.T) {
 		expectedEndpoints        []endpoint.Endpoint
 		servicePort              uint32
 		expectedFilterChainMatch *xds_listener.FilterChainMatch
+		wasmStatsEnabled         bool
+		expectedFilters          []string
 		expectError              bool
 	}{
 		{
@@ -160,7 +163,18 @@ func TestGetOutboundTCPFilterChainForService(t *testing.T) {
 					},
 				},
 			},
-			expectError: false,
+			expectedFilters: []string{wellknown.TCPProxy},
+			expectError:     false,
+		},
+		{
+			name: "service with WASM stats enabled",
+			expectedEndpoints: []endpoint.Endpoint{
+				{IP: net.ParseIP("1.1.1.1"), Port: 80},
+			},
+			servicePort:      80,
+			wasmStatsEnabled: true,
+			expectedFilters:  []string{wasmNetworkFilterName, wellknown.TCPProxy},
+			expectError:      false,
 		},
 		{
 			name:                     "service with no endpoints",
@@ -177,6 +191,9 @@ func TestGetOutboundTCPFilterChainForService(t *testing.T) {
 
 			mockCatalog.EXPECT().GetResolvableServiceEndpoints(tests.BookstoreApexService).Return(tc.expectedEndpoints, nil)
 			mockCatalog.EXPECT().GetWeightedClustersForUpstream(tests.BookstoreApexService).Times(1)
+			if !tc.expectError {
+				mockConfigurator.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableWASMStats: tc.wasmStatsEnabled}).Times(1)
+			}
 
 			tcpFilterChain, err := lb.getOutboundTCPFilterChainForService(tests.BookstoreApexService, tc.servicePort)
 
@@ -188,14 +205,44 @@ func TestGetOutboundTCPFilterChainForService(t *testing.T) {
 				assert.NotNil(tcpFilterChain)
 				assert.Len(tcpFilterChain.FilterChainMatch.PrefixRanges, len(tc.expectedEndpoints))
 
+				var filters []string
 				for _, filter := range tcpFilterChain.Filters {
-					assert.Equal(wellknown.TCPProxy, filter.Name)
+					filters = append(filters, filter.Name)
 				}
+				assert.Equal(tc.expectedFilters, filters)
 			}
 		})
 	}
 }
 
+func TestGetOutboundTCPFilterChainsShareStatsFilter(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	lb := &listenerBuilder{
+		meshCatalog:     mockCatalog,
+		cfg:             mockConfigurator,
+		serviceIdentity: tests.BookbuyerServiceIdentity,
+		statsHeaders:    map[string]string{"osm-stats-namespace": "default"},
+	}
+
+	mockConfigurator.EXPECT().GetFeatureFlags().Return(v1alpha1.FeatureFlags{EnableWASMStats: true}).AnyTimes()
+	mockCatalog.EXPECT().GetResolvableServiceEndpoints(gomock.Any()).Return([]endpoint.Endpoint{{IP: net.ParseIP("1.1.1.1"), Port: 80}}, nil).AnyTimes()
+	mockCatalog.EXPECT().GetWeightedClustersForUpstream(gomock.Any()).AnyTimes()
+
+	bookstoreChain, err := lb.getOutboundTCPFilterChainForService(tests.BookstoreApexService, 80)
+	assert.Nil(err)
+	bookwarehouseChain, err := lb.getOutboundTCPFilterChainForService(tests.BookwarehouseService, 80)
+	assert.Nil(err)
+
+	assert.Equal(wasmNetworkFilterName, bookstoreChain.Filters[0].Name)
+	assert.Same(bookstoreChain.Filters[0], bookwarehouseChain.Filters[0])
+}
+
 func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -222,6 +269,9 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 
 	proxyService := tests.BookbuyerService
 
+	// Mock catalog call used to build the AuthorizationPolicy filters of the HTTP connection manager
+	mockCatalog.EXPECT().GetAuthorizationTrafficPolicy(proxyService).Return(nil).AnyTimes()
+
 	testCases := []struct {
 		name           string
 		permissiveMode bool
@@ -397,7 +447,7 @@ func TestGetOutboundFilterChainMatchForService(t *testing.T) {
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 
-	lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceIdentity, mockConfigurator, nil)
+	lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceIdentity, mockConfigurator, nil, v1alpha1.AccessLogSpec{})
 
 	testCases := []struct {
 		name        string
@@ -602,7 +652,7 @@ func TestGetOutboundTCPFilter(t *testing.T) {
 
 			mockCatalog.EXPECT().GetWeightedClustersForUpstream(tc.upstream).Return(tc.clusterWeights).Times(1)
 
-			lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceIdentity, mockConfigurator, nil)
+			lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceIdentity, mockConfigurator, nil, v1alpha1.AccessLogSpec{})
 			filter, err := lb.getOutboundTCPFilter(tc.upstream)
 
 			assert := tassert.New(t)
@@ -631,7 +681,6 @@ func TestGetOutboundHTTPFilter(t *testing.T) {
 	}
 
 	mockConfigurator.EXPECT().IsTracingEnabled()
-	mockConfigurator.EXPECT().GetTracingEndpoint()
 	mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(auth.ExtAuthConfig{
 		Enable: false,
 	}).AnyTimes()
@@ -12,6 +12,7 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/errcode"
@@ -22,6 +23,7 @@ const (
 	inboundListenerName           = "inbound-listener"
 	outboundListenerName          = "outbound-listener"
 	multiclusterListenerName      = "multicluster-listener"
+	egressGatewayListenerName     = "egress-gateway-listener"
 	prometheusListenerName        = "inbound-prometheus-listener"
 	outboundEgressFilterChainName = "outbound-egress-filter-chain"
 	egressTCPProxyStatPrefix      = "egress-tcp-proxy"
@@ -50,7 +52,7 @@ func (lb *listenerBuilder) newOutboundListener() (*xds_listener.Listener, error)
 	// mesh (SMI or permissive mode) or egress traffic policies. Traffic matching this default
 	// passthrough filter chain will be allowed to passthrough to its original destination.
 	if lb.cfg.IsEgressEnabled() {
-		egressFilterChain, err := getDefaultPassthroughFilterChain()
+		egressFilterChain, err := getDefaultPassthroughFilterChain(lb.accessLog)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error getting filter chain for Egress")
 			return nil, err
@@ -152,10 +154,11 @@ func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager) (*xds_l
 
 // getDefaultPassthroughFilterChain returns a filter chain that matches any traffic, allowing such
 // traffic to be proxied to its original destination via the OutboundPassthroughCluster.
-func getDefaultPassthroughFilterChain() (*xds_listener.FilterChain, error) {
+func getDefaultPassthroughFilterChain(accessLog configv1alpha1.AccessLogSpec) (*xds_listener.FilterChain, error) {
 	tcpProxy := &xds_tcp_proxy.TcpProxy{
 		StatPrefix:       fmt.Sprintf("%s.%s", egressTCPProxyStatPrefix, envoy.OutboundPassthroughCluster),
 		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.OutboundPassthroughCluster},
+		AccessLog:        envoy.GetTCPAccessLogs(accessLog),
 	}
 	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
 	if err != nil {
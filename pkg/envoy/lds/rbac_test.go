@@ -157,7 +157,7 @@ func TestBuildInboundRBACPolicies(t *testing.T) {
 
 	lb := &listenerBuilder{
 		meshCatalog:     mockCatalog,
-		serviceIdentity: proxySvcAccount.ToServiceIdentity(),
+		serviceIdentity: proxySvcAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 	}
 
 	testCases := []struct {
@@ -218,7 +218,7 @@ func TestBuildInboundRBACPolicies(t *testing.T) {
 			assert := tassert.New(t)
 
 			// Mock catalog calls
-			mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(proxySvcAccount.ToServiceIdentity()).Return(tc.trafficTargets, nil).Times(1)
+			mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(proxySvcAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)).Return(tc.trafficTargets, nil).Times(1)
 
 			// Test the RBAC policies
 			policy, err := lb.buildInboundRBACPolicies()
@@ -241,7 +241,7 @@ func TestBuildRBACFilter(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
-	proxySvcAccount := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity()
+	proxySvcAccount := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
 
 	lb := &listenerBuilder{
 		meshCatalog:     mockCatalog,
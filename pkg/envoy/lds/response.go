@@ -4,6 +4,7 @@ import (
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -33,7 +34,11 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		statsHeaders = proxy.StatsHeaders()
 	}
 
-	lb := newListenerBuilder(meshCatalog, proxyIdentity, cfg, statsHeaders)
+	// The mesh-wide access log configuration can be overridden for the proxies in a namespace
+	proxyNamespace := meshCatalog.GetKubeController().GetNamespace(proxyIdentity.ToK8sServiceAccount().Namespace)
+	accessLog := envoy.GetAccessLogConfigForNamespace(cfg.GetAccessLogConfig(), proxyNamespace)
+
+	lb := newListenerBuilder(meshCatalog, proxyIdentity, cfg, statsHeaders, accessLog)
 
 	if proxy.Kind() == envoy.KindGateway && cfg.GetFeatureFlags().EnableMulticlusterMode {
 		gatewayListener, err := lb.buildMulticlusterGatewayListener()
@@ -46,6 +51,16 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		return ldsResources, nil
 	}
 
+	if proxy.Kind() == envoy.KindEgressGateway {
+		egressGatewayListener, err := lb.buildEgressGatewayListener()
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building egress gateway listener for proxy %s", proxy.String())
+			return nil, err
+		}
+		ldsResources = append(ldsResources, egressGatewayListener)
+		return ldsResources, nil
+	}
+
 	// --- OUTBOUND -------------------
 	outboundListener, err := lb.newOutboundListener()
 	if err != nil {
@@ -89,7 +104,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		log.Warn().Msgf("Could not find pod for connecting proxy %s. No metadata was recorded.", proxy.GetCertificateSerialNumber())
 	} else if meshCatalog.GetKubeController().IsMetricsEnabled(pod) {
 		// Build Prometheus listener config
-		prometheusConnManager := getPrometheusConnectionManager()
+		prometheusConnManager := getPrometheusConnectionManager(accessLog)
 		if prometheusListener, err := buildPrometheusListener(prometheusConnManager); err != nil {
 			log.Error().Err(err).Msgf("Error building Prometheus listener for proxy %s", proxy.String())
 		} else {
@@ -101,11 +116,12 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 }
 
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
-func newListenerBuilder(meshCatalog catalog.MeshCataloger, svcIdentity identity.ServiceIdentity, cfg configurator.Configurator, statsHeaders map[string]string) *listenerBuilder {
+func newListenerBuilder(meshCatalog catalog.MeshCataloger, svcIdentity identity.ServiceIdentity, cfg configurator.Configurator, statsHeaders map[string]string, accessLog configv1alpha1.AccessLogSpec) *listenerBuilder {
 	return &listenerBuilder{
 		meshCatalog:     meshCatalog,
 		serviceIdentity: svcIdentity,
 		cfg:             cfg,
 		statsHeaders:    statsHeaders,
+		accessLog:       accessLog,
 	}
 }
@@ -1,26 +1,121 @@
 package lds
 
 import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_tracing "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_type_tracing "github.com/envoyproxy/go-control-plane/envoy/type/tracing/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
 )
 
+const (
+	zipkinTracerName        = "envoy.tracers.zipkin"
+	datadogTracerName       = "envoy.tracers.datadog"
+	openTelemetryTracerName = "envoy.tracers.opentelemetry"
+
+	// openTelemetryServiceNameField is the number of the service_name field of the OpenTelemetry tracer config,
+	// supported by Envoy v1.24 and later but not yet part of the go-control-plane API in use
+	openTelemetryServiceNameField protowire.Number = 2
+)
+
+// tracingConfig defines the tracing configuration of an HTTP connection manager
+type tracingConfig struct {
+	provider           configv1alpha1.TracingProvider
+	apiEndpoint        string
+	serviceName        string
+	samplingPercentage float64
+	customTags         []configv1alpha1.TracingCustomTagSpec
+}
+
+// getTracingConfig returns the tracing configuration for the HTTP connection managers of the proxy,
+// or nil if tracing is disabled or the tracing provider is not supported
+func (lb *listenerBuilder) getTracingConfig() *tracingConfig {
+	if !lb.cfg.IsTracingEnabled() {
+		return nil
+	}
+
+	provider := lb.cfg.GetTracingProvider()
+	if !isSupportedTracingProvider(provider) {
+		// A tracer the sidecar's Envoy version does not support would cause the listeners to be rejected
+		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrUnsupportedTracingProvider)).
+			Msgf("Tracing provider %q is not supported, disabling tracing for proxy with identity %s", provider, lb.serviceIdentity)
+		return nil
+	}
+
+	return &tracingConfig{
+		provider:           provider,
+		apiEndpoint:        lb.cfg.GetTracingEndpoint(),
+		serviceName:        lb.serviceIdentity.String(),
+		samplingPercentage: lb.cfg.GetTracingSamplingPercentage(),
+		customTags:         lb.cfg.GetTracingCustomTags(),
+	}
+}
+
+// isSupportedTracingProvider returns whether the given tracing provider is supported by the sidecar
+func isSupportedTracingProvider(provider configv1alpha1.TracingProvider) bool {
+	switch provider {
+	case configv1alpha1.TracingProviderZipkin, configv1alpha1.TracingProviderDatadog, configv1alpha1.TracingProviderOpenTelemetry:
+		return true
+	default:
+		return false
+	}
+}
+
 // getHTTPTracingConfig returns an HTTP configuration tracing config for the HTTP connection manager to use
-func getHTTPTracingConfig(apiEndpoint string) (*xds_hcm.HttpConnectionManager_Tracing, error) {
-	zipkinTracingConf := &xds_tracing.ZipkinConfig{
-		CollectorCluster:         constants.EnvoyTracingCluster,
-		CollectorEndpoint:        apiEndpoint,
-		CollectorEndpointVersion: xds_tracing.ZipkinConfig_HTTP_JSON,
+func getHTTPTracingConfig(config *tracingConfig) (*xds_hcm.HttpConnectionManager_Tracing, error) {
+	var tracerName string
+	var tracerConfig *any.Any
+	var err error
+
+	switch config.provider {
+	case configv1alpha1.TracingProviderZipkin:
+		tracerName = zipkinTracerName
+		tracerConfig, err = ptypes.MarshalAny(&xds_tracing.ZipkinConfig{
+			CollectorCluster:         constants.EnvoyTracingCluster,
+			CollectorEndpoint:        config.apiEndpoint,
+			CollectorEndpointVersion: xds_tracing.ZipkinConfig_HTTP_JSON,
+		})
+
+	case configv1alpha1.TracingProviderDatadog:
+		tracerName = datadogTracerName
+		tracerConfig, err = ptypes.MarshalAny(&xds_tracing.DatadogConfig{
+			CollectorCluster: constants.EnvoyTracingCluster,
+			ServiceName:      config.serviceName,
+		})
+
+	case configv1alpha1.TracingProviderOpenTelemetry:
+		tracerName = openTelemetryTracerName
+		tracerConfig, err = ptypes.MarshalAny(&xds_tracing.OpenTelemetryConfig{
+			GrpcService: &xds_core.GrpcService{
+				TargetSpecifier: &xds_core.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &xds_core.GrpcService_EnvoyGrpc{
+						ClusterName: constants.EnvoyTracingCluster,
+					},
+				},
+			},
+		})
+		if err == nil {
+			// Identify the workload as the service of its spans, the tracer defaults to "unknown_service:envoy"
+			tracerConfig.Value = protowire.AppendTag(tracerConfig.Value, openTelemetryServiceNameField, protowire.BytesType)
+			tracerConfig.Value = protowire.AppendString(tracerConfig.Value, config.serviceName)
+		}
+
+	default:
+		return nil, errors.Errorf("Unsupported tracing provider %q", config.provider)
 	}
 
-	zipkinConfMarshalled, err := ptypes.MarshalAny(zipkinTracingConf)
 	if err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
-			Msgf("Error marshalling Zipkin config")
+			Msgf("Error marshalling %s tracer config", config.provider)
 		return nil, err
 	}
 
@@ -28,12 +123,49 @@ func getHTTPTracingConfig(apiEndpoint string) (*xds_hcm.HttpConnectionManager_Tr
 		Verbose: true,
 		Provider: &xds_tracing.Tracing_Http{
 			// Name must refer to an instantiatable tracing driver
-			Name: "envoy.tracers.zipkin",
+			Name: tracerName,
 			ConfigType: &xds_tracing.Tracing_Http_TypedConfig{
-				TypedConfig: zipkinConfMarshalled,
+				TypedConfig: tracerConfig,
 			},
 		},
+		RandomSampling: &xds_type.Percent{
+			Value: config.samplingPercentage,
+		},
+		CustomTags: getTracingCustomTags(config.customTags),
 	}
 
 	return tracing, nil
 }
+
+// getTracingCustomTags returns the custom tags added to the spans
+func getTracingCustomTags(tags []configv1alpha1.TracingCustomTagSpec) []*xds_type_tracing.CustomTag {
+	var customTags []*xds_type_tracing.CustomTag
+
+	for _, tag := range tags {
+		customTag := &xds_type_tracing.CustomTag{
+			Tag: tag.Tag,
+		}
+
+		switch {
+		case tag.Literal != "":
+			customTag.Type = &xds_type_tracing.CustomTag_Literal_{
+				Literal: &xds_type_tracing.CustomTag_Literal{Value: tag.Literal},
+			}
+		case tag.RequestHeader != "":
+			customTag.Type = &xds_type_tracing.CustomTag_RequestHeader{
+				RequestHeader: &xds_type_tracing.CustomTag_Header{Name: tag.RequestHeader},
+			}
+		case tag.Environment != "":
+			customTag.Type = &xds_type_tracing.CustomTag_Environment_{
+				Environment: &xds_type_tracing.CustomTag_Environment{Name: tag.Environment},
+			}
+		default:
+			log.Error().Msgf("Ignoring custom tracing tag %q without a literal, request header or environment value", tag.Tag)
+			continue
+		}
+
+		customTags = append(customTags, customTag)
+	}
+
+	return customTags
+}
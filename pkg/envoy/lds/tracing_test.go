@@ -0,0 +1,205 @@
+package lds
+
+import (
+	"testing"
+
+	xds_tracing "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
+	xds_type_tracing "github.com/envoyproxy/go-control-plane/envoy/type/tracing/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+func TestGetTracingConfig(t *testing.T) {
+	testCases := []struct {
+		name           string
+		enabled        bool
+		provider       configv1alpha1.TracingProvider
+		expectedConfig bool
+	}{
+		{
+			name:           "tracing is disabled",
+			enabled:        false,
+			expectedConfig: false,
+		},
+		{
+			name:           "supported provider",
+			enabled:        true,
+			provider:       configv1alpha1.TracingProviderDatadog,
+			expectedConfig: true,
+		},
+		{
+			name:           "opentelemetry provider",
+			enabled:        true,
+			provider:       configv1alpha1.TracingProviderOpenTelemetry,
+			expectedConfig: true,
+		},
+		{
+			name:           "unsupported provider disables tracing",
+			enabled:        true,
+			provider:       "unknown",
+			expectedConfig: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			lb := &listenerBuilder{
+				cfg:             mockConfigurator,
+				serviceIdentity: identity.ServiceIdentity("sa.ns.cluster.local"),
+			}
+
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(tc.enabled).Times(1)
+			mockConfigurator.EXPECT().GetTracingProvider().Return(tc.provider).AnyTimes()
+			mockConfigurator.EXPECT().GetTracingEndpoint().Return("/api/v2/spans").AnyTimes()
+			mockConfigurator.EXPECT().GetTracingSamplingPercentage().Return(100.0).AnyTimes()
+			mockConfigurator.EXPECT().GetTracingCustomTags().Return(nil).AnyTimes()
+
+			actual := lb.getTracingConfig()
+			if !tc.expectedConfig {
+				assert.Nil(actual)
+				return
+			}
+			assert.NotNil(actual)
+			assert.Equal(tc.provider, actual.provider)
+		})
+	}
+}
+
+func TestGetHTTPTracingConfig(t *testing.T) {
+	testCases := []struct {
+		name               string
+		config             *tracingConfig
+		expectedTracerName string
+		expectedTypeURL    string
+		expectError        bool
+	}{
+		{
+			name: "zipkin provider",
+			config: &tracingConfig{
+				provider:           configv1alpha1.TracingProviderZipkin,
+				apiEndpoint:        "/api/v2/spans",
+				samplingPercentage: 100,
+			},
+			expectedTracerName: "envoy.tracers.zipkin",
+			expectedTypeURL:    "type.googleapis.com/envoy.config.trace.v3.ZipkinConfig",
+		},
+		{
+			name: "datadog provider",
+			config: &tracingConfig{
+				provider:           configv1alpha1.TracingProviderDatadog,
+				serviceName:        "bookstore.default.cluster.local",
+				samplingPercentage: 50,
+			},
+			expectedTracerName: "envoy.tracers.datadog",
+			expectedTypeURL:    "type.googleapis.com/envoy.config.trace.v3.DatadogConfig",
+		},
+		{
+			name: "opentelemetry provider",
+			config: &tracingConfig{
+				provider:           configv1alpha1.TracingProviderOpenTelemetry,
+				samplingPercentage: 10,
+			},
+			expectedTracerName: "envoy.tracers.opentelemetry",
+			expectedTypeURL:    "type.googleapis.com/envoy.config.trace.v3.OpenTelemetryConfig",
+		},
+		{
+			name: "unsupported provider",
+			config: &tracingConfig{
+				provider: "unknown",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual, err := getHTTPTracingConfig(tc.config)
+			assert.Equal(tc.expectError, err != nil)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(tc.expectedTracerName, actual.Provider.Name)
+			assert.Equal(tc.expectedTypeURL, actual.Provider.GetTypedConfig().TypeUrl)
+			assert.Equal(tc.config.samplingPercentage, actual.RandomSampling.Value)
+		})
+	}
+}
+
+func TestGetTracingCustomTags(t *testing.T) {
+	assert := tassert.New(t)
+
+	actual := getTracingCustomTags([]configv1alpha1.TracingCustomTagSpec{
+		{Tag: "cluster", Literal: "east"},
+		{Tag: "user-agent", RequestHeader: "User-Agent"},
+		{Tag: "pod", Environment: "POD_NAME"},
+		{Tag: "invalid"},
+	})
+
+	assert.Equal([]*xds_type_tracing.CustomTag{
+		{
+			Tag:  "cluster",
+			Type: &xds_type_tracing.CustomTag_Literal_{Literal: &xds_type_tracing.CustomTag_Literal{Value: "east"}},
+		},
+		{
+			Tag:  "user-agent",
+			Type: &xds_type_tracing.CustomTag_RequestHeader{RequestHeader: &xds_type_tracing.CustomTag_Header{Name: "User-Agent"}},
+		},
+		{
+			Tag:  "pod",
+			Type: &xds_type_tracing.CustomTag_Environment_{Environment: &xds_type_tracing.CustomTag_Environment{Name: "POD_NAME"}},
+		},
+	}, actual)
+}
+
+func TestGetHTTPTracingConfigZipkin(t *testing.T) {
+	assert := tassert.New(t)
+
+	actual, err := getHTTPTracingConfig(&tracingConfig{
+		provider:    configv1alpha1.TracingProviderZipkin,
+		apiEndpoint: "/api/v2/spans",
+	})
+	assert.Nil(err)
+
+	zipkinConfig := &xds_tracing.ZipkinConfig{}
+	assert.Nil(ptypes.UnmarshalAny(actual.Provider.GetTypedConfig(), zipkinConfig))
+	assert.Equal(constants.EnvoyTracingCluster, zipkinConfig.CollectorCluster)
+	assert.Equal("/api/v2/spans", zipkinConfig.CollectorEndpoint)
+	assert.Equal(xds_tracing.ZipkinConfig_HTTP_JSON, zipkinConfig.CollectorEndpointVersion)
+}
+
+func TestGetHTTPTracingConfigOpenTelemetry(t *testing.T) {
+	assert := tassert.New(t)
+
+	actual, err := getHTTPTracingConfig(&tracingConfig{
+		provider:    configv1alpha1.TracingProviderOpenTelemetry,
+		serviceName: "bookstore.default.cluster.local",
+	})
+	assert.Nil(err)
+
+	otelConfig := &xds_tracing.OpenTelemetryConfig{}
+	assert.Nil(ptypes.UnmarshalAny(actual.Provider.GetTypedConfig(), otelConfig))
+	assert.Equal(constants.EnvoyTracingCluster, otelConfig.GrpcService.GetEnvoyGrpc().ClusterName)
+
+	// The service_name field is unknown to the go-control-plane API in use
+	num, typ, n := protowire.ConsumeTag(otelConfig.ProtoReflect().GetUnknown())
+	assert.Equal(openTelemetryServiceNameField, num)
+	assert.Equal(protowire.BytesType, typ)
+	serviceName, _ := protowire.ConsumeString(otelConfig.ProtoReflect().GetUnknown()[n:])
+	assert.Equal("bookstore.default.cluster.local", serviceName)
+}
@@ -2,6 +2,9 @@
 package lds
 
 import (
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 
@@ -19,4 +22,10 @@ type listenerBuilder struct {
 	meshCatalog     catalog.MeshCataloger
 	cfg             configurator.Configurator
 	statsHeaders    map[string]string
+
+	// accessLog is the access logging configuration of the proxy, with the overrides for its namespace applied
+	accessLog configv1alpha1.AccessLogSpec
+
+	// tcpStatsFilter is the WASM stats filter shared by the outbound TCP filter chains of the proxy, built with the first one
+	tcpStatsFilter *xds_listener.Filter
 }
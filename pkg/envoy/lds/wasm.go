@@ -3,20 +3,46 @@ package lds
 import (
 	_ "embed" // required to embed resources
 	"fmt"
+	"sort"
 	"strings"
 
 	envoy_config_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	xds_lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	xds_wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_network_wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/wasm/v3"
 	xds_wasm_ext "github.com/envoyproxy/go-control-plane/envoy/extensions/wasm/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/pkg/errors"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
 )
 
+const (
+	// tcpStatsRootID is the root ID of the WASM stats module's context recording TCP stats
+	tcpStatsRootID = "osm_tcp_stats"
+
+	// statsVMID is the ID of the VM running the WASM stats module, shared by all the stats filters of a proxy
+	statsVMID = "osm_stats"
+
+	// wasmNetworkFilterName is the name of Envoy's WASM network filter
+	wasmNetworkFilterName = "envoy.filters.network.wasm"
+
+	// wasmHTTPFilterName is the name of Envoy's WASM HTTP filter
+	wasmHTTPFilterName = "envoy.filters.http.wasm"
+)
+
+// tcpStatsHeaderLabels maps the stats headers to the source labels of the TCP stats recorded by the WASM stats module
+var tcpStatsHeaderLabels = map[string]string{
+	"osm-stats-namespace": "source_namespace",
+	"osm-stats-kind":      "source_kind",
+	"osm-stats-name":      "source_name",
+	"osm-stats-pod":       "source_pod",
+}
+
 //go:embed stats.wasm
 var statsWASMBytes []byte
 
@@ -110,25 +136,12 @@ func getStatsWASMFilter() (*xds_hcm.HttpFilter, error) {
 	if len(statsWASMBytes) == 0 {
 		return nil, nil
 	}
+	pluginConfig, err := getStatsWASMPluginConfig("", "")
+	if err != nil {
+		return nil, err
+	}
 	wasmPlug := &xds_wasm.Wasm{
-		Config: &xds_wasm_ext.PluginConfig{
-			Name: "stats",
-			Vm: &xds_wasm_ext.PluginConfig_VmConfig{
-				VmConfig: &xds_wasm_ext.VmConfig{
-					Runtime: "envoy.wasm.runtime.v8",
-					Code: &envoy_config_core_v3.AsyncDataSource{
-						Specifier: &envoy_config_core_v3.AsyncDataSource_Local{
-							Local: &envoy_config_core_v3.DataSource{
-								Specifier: &envoy_config_core_v3.DataSource_InlineBytes{
-									InlineBytes: statsWASMBytes,
-								},
-							},
-						},
-					},
-					AllowPrecompiled: true,
-				},
-			},
-		},
+		Config: pluginConfig,
 	}
 
 	wasmAny, err := ptypes.MarshalAny(wasmPlug)
@@ -137,9 +150,94 @@ func getStatsWASMFilter() (*xds_hcm.HttpFilter, error) {
 	}
 
 	return &xds_hcm.HttpFilter{
-		Name: "envoy.filters.http.wasm",
+		Name: wasmHTTPFilterName,
 		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
 			TypedConfig: wasmAny,
 		},
 	}, nil
 }
+
+// getTCPStatsWASMFilter returns the network filter recording the TCP stats of the outbound connections of the proxy.
+// The source labels are derived from the given stats headers, as TCP connections carry no headers. The configuration
+// is the same for all the upstream services, the WASM module deriving the destination labels from the name of the
+// upstream cluster, so that a single filter is shared by all the outbound TCP filter chains of the proxy.
+// Inbound TCP connections are not recorded, as their peer workload is not known to the proxy.
+func getTCPStatsWASMFilter(statsHeaders map[string]string) (*xds_listener.Filter, error) {
+	if len(statsWASMBytes) == 0 {
+		return nil, nil
+	}
+
+	// The configuration is passed to the WASM module as newline separated 'label=value' pairs,
+	// sorted for the configuration to be deterministic.
+	var pairs []string
+	for header, label := range tcpStatsHeaderLabels {
+		if v, ok := statsHeaders[header]; ok {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", label, v))
+		}
+	}
+	sort.Strings(pairs)
+
+	pluginConfig, err := getStatsWASMPluginConfig(tcpStatsRootID, strings.Join(pairs, "\n"))
+	if err != nil {
+		return nil, err
+	}
+	wasmPlug := &xds_network_wasm.Wasm{
+		Config: pluginConfig,
+	}
+
+	wasmAny, err := ptypes.MarshalAny(wasmPlug)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling network Wasm config")
+	}
+
+	return &xds_listener.Filter{
+		Name: wasmNetworkFilterName,
+		ConfigType: &xds_listener.Filter_TypedConfig{
+			TypedConfig: wasmAny,
+		},
+	}, nil
+}
+
+// getStatsWASMPluginConfig returns the configuration of the WASM stats module for the given root context,
+// the empty root ID being the context recording HTTP stats. All the stats filters of a proxy run in the same VM.
+func getStatsWASMPluginConfig(rootID string, configuration string) (*xds_wasm_ext.PluginConfig, error) {
+	pluginConfig, err := getWASMPluginConfig("stats", rootID, statsWASMBytes, configuration)
+	if err != nil {
+		return nil, err
+	}
+	pluginConfig.GetVmConfig().VmId = statsVMID
+	return pluginConfig, nil
+}
+
+// getWASMPluginConfig returns the configuration of a WASM plugin running the given module inlined in the configuration
+func getWASMPluginConfig(name string, rootID string, code []byte, configuration string) (*xds_wasm_ext.PluginConfig, error) {
+	pluginConfig := &xds_wasm_ext.PluginConfig{
+		Name:   name,
+		RootId: rootID,
+		Vm: &xds_wasm_ext.PluginConfig_VmConfig{
+			VmConfig: &xds_wasm_ext.VmConfig{
+				Runtime: "envoy.wasm.runtime.v8",
+				Code: &envoy_config_core_v3.AsyncDataSource{
+					Specifier: &envoy_config_core_v3.AsyncDataSource_Local{
+						Local: &envoy_config_core_v3.DataSource{
+							Specifier: &envoy_config_core_v3.DataSource_InlineBytes{
+								InlineBytes: code,
+							},
+						},
+					},
+				},
+				AllowPrecompiled: true,
+			},
+		},
+	}
+
+	if configuration != "" {
+		configAny, err := ptypes.MarshalAny(&wrappers.StringValue{Value: configuration})
+		if err != nil {
+			return nil, errors.Wrap(err, "Error marshalling Wasm plugin configuration")
+		}
+		pluginConfig.Configuration = configAny
+	}
+
+	return pluginConfig, nil
+}
@@ -3,7 +3,10 @@ package lds
 import (
 	"testing"
 
+	xds_network_wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/wasm/v3"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
@@ -51,3 +54,31 @@ func TestGetWASMStatsHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTCPStatsWASMFilter(t *testing.T) {
+	a := assert.New(t)
+
+	statsHeaders := map[string]string{
+		"osm-stats-namespace": "bookbuyer",
+		"osm-stats-kind":      "Deployment",
+		"osm-stats-name":      "bookbuyer",
+		"osm-stats-pod":       "bookbuyer-123",
+	}
+
+	filter, err := getTCPStatsWASMFilter(statsHeaders)
+	a.Nil(err)
+	a.Equal(wasmNetworkFilterName, filter.Name)
+
+	wasmPlug := &xds_network_wasm.Wasm{}
+	a.Nil(ptypes.UnmarshalAny(filter.GetTypedConfig(), wasmPlug))
+	a.Equal(tcpStatsRootID, wasmPlug.Config.RootId)
+	a.Equal(statsVMID, wasmPlug.Config.GetVmConfig().VmId)
+
+	// The destination labels are derived by the WASM module from the upstream cluster
+	configuration := &wrappers.StringValue{}
+	a.Nil(ptypes.UnmarshalAny(wasmPlug.Config.Configuration, configuration))
+	a.Equal("source_kind=Deployment\n"+
+		"source_name=bookbuyer\n"+
+		"source_namespace=bookbuyer\n"+
+		"source_pod=bookbuyer-123", configuration.Value)
+}
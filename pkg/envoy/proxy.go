@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	mapset "github.com/deckarep/golang-set"
@@ -33,6 +34,13 @@ type Proxy struct {
 	lastAppliedVersion map[TypeURI]uint64
 	lastNonce          map[TypeURI]string
 
+	// The time the last response for a given TypeURI was sent to the proxy
+	lastSentAt map[TypeURI]time.Time
+
+	// The last configuration rejected (NACKed) by the proxy, stored as a *NACK that is nil when the proxy
+	// ACKed the configurations sent since. It is read by the debug server while the proxy's stream updates it.
+	lastNACK atomic.Value
+
 	// Contains the last resource names sent for a given proxy and TypeURL
 	lastxDSResourcesSent map[TypeURI]mapset.Set
 
@@ -45,6 +53,9 @@ type Proxy struct {
 	// kind is the proxy's kind (ex. sidecar, gateway)
 	kind ProxyKind
 
+	// uuid is the proxy's UUID present in its certificate's CommonName
+	uuid uuid.UUID
+
 	// Records metadata around the Kubernetes Pod on which this Envoy Proxy is installed.
 	// This could be nil if the Envoy is not operating in a Kubernetes cluster (VM for example)
 	// NOTE: This field may be not be set at the time Proxy struct is initialized. This would
@@ -162,6 +173,36 @@ func (p *Proxy) SetNewNonce(typeURI TypeURI) string {
 	return p.lastNonce[typeURI]
 }
 
+// GetLastSentAt returns the time the last response for the given TypeURI was sent to the proxy.
+func (p *Proxy) GetLastSentAt(typeURI TypeURI) time.Time {
+	return p.lastSentAt[typeURI]
+}
+
+// SetLastSentAt records the time the last response for the given TypeURI was sent to the proxy.
+func (p *Proxy) SetLastSentAt(typeURI TypeURI, sentAt time.Time) {
+	p.lastSentAt[typeURI] = sentAt
+}
+
+// GetLastNACK returns the last configuration rejected by the proxy, or nil if the proxy
+// ACKed the configurations sent since.
+func (p *Proxy) GetLastNACK() *NACK {
+	nack, _ := p.lastNACK.Load().(*NACK)
+	return nack
+}
+
+// SetLastNACK records the last configuration rejected by the proxy.
+func (p *Proxy) SetLastNACK(nack *NACK) {
+	p.lastNACK.Store(nack)
+}
+
+// ClearLastNACK clears the last configuration rejected by the proxy if it is of the given TypeURI,
+// to be called once the proxy ACKs a configuration of this TypeURI.
+func (p *Proxy) ClearLastNACK(typeURI TypeURI) {
+	if nack := p.GetLastNACK(); nack != nil && nack.TypeURI == typeURI {
+		p.lastNACK.Store((*NACK)(nil))
+	}
+}
+
 // PodMetadataString returns relevant pod metadata as a string
 func (p *Proxy) PodMetadataString() string {
 	if p.PodMetadata == nil {
@@ -230,6 +271,11 @@ func (p *Proxy) Kind() ProxyKind {
 	return p.kind
 }
 
+// GetUUID returns the proxy's UUID
+func (p *Proxy) GetUUID() uuid.UUID {
+	return p.uuid
+}
+
 // NewProxy creates a new instance of an Envoy proxy connected to the xDS servers.
 func NewProxy(certCommonName certificate.CommonName, certSerialNumber certificate.SerialNumber, ip net.Addr) (*Proxy, error) {
 	// Get CommonName hash for this proxy
@@ -255,14 +301,16 @@ func NewProxy(certCommonName certificate.CommonName, certSerialNumber certificat
 		lastNonce:            make(map[TypeURI]string),
 		lastSentVersion:      make(map[TypeURI]uint64),
 		lastAppliedVersion:   make(map[TypeURI]uint64),
+		lastSentAt:           make(map[TypeURI]time.Time),
 		lastxDSResourcesSent: make(map[TypeURI]mapset.Set),
 		subscribedResources:  make(map[TypeURI]mapset.Set),
 
 		kind: cnMeta.ProxyKind,
+		uuid: cnMeta.ProxyUUID,
 	}, nil
 }
 
-// NewXDSCertCommonName returns a newly generated CommonName for a certificate of the form: <ProxyUUID>.<kind>.<serviceAccount>.<namespace>
-func NewXDSCertCommonName(proxyUUID uuid.UUID, kind ProxyKind, serviceAccount, namespace string) certificate.CommonName {
-	return certificate.CommonName(fmt.Sprintf("%s.%s.%s.%s.%s", proxyUUID.String(), kind, serviceAccount, namespace, identity.ClusterLocalTrustDomain))
+// NewXDSCertCommonName returns a newly generated CommonName for a certificate of the form: <ProxyUUID>.<kind>.<serviceAccount>.<namespace>.<trustDomain>
+func NewXDSCertCommonName(proxyUUID uuid.UUID, kind ProxyKind, serviceAccount, namespace, trustDomain string) certificate.CommonName {
+	return certificate.CommonName(fmt.Sprintf("%s.%s.%s.%s.%s", proxyUUID.String(), kind, serviceAccount, namespace, trustDomain))
 }
@@ -29,7 +29,8 @@ import (
 )
 
 var _ = Describe("Test proxy methods", func() {
-	certCommonName := certificate.CommonName(fmt.Sprintf("%s.%s.svc-acc.namespace", uuid.New(), KindSidecar))
+	proxyUUID := uuid.New()
+	certCommonName := certificate.CommonName(fmt.Sprintf("%s.%s.svc-acc.namespace", proxyUUID, KindSidecar))
 	certSerialNumber := certificate.SerialNumber("123456")
 	podUID := uuid.New().String()
 	proxy, err := NewProxy(certCommonName, certSerialNumber, tests.NewMockAddress("1.2.3.4"))
@@ -39,6 +40,12 @@ var _ = Describe("Test proxy methods", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	Context("test GetUUID()", func() {
+		It("returns the UUID from the certificate CommonName", func() {
+			Expect(proxy.GetUUID()).To(Equal(proxyUUID))
+		})
+	})
+
 	Context("test GetLastAppliedVersion()", func() {
 		It("returns correct values", func() {
 			actual := proxy.GetLastAppliedVersion(TypeCDS)
@@ -408,13 +415,13 @@ var _ = Describe("Test XDS certificate tooling", func() {
 		})
 	})
 
-	Context("Test NewXDSCertCommonName() and getCertificateCommonNameMeta() together", func() {
+	Context("Test NewXDSCertCommonName(, identity.ClusterLocalTrustDomain) and getCertificateCommonNameMeta() together", func() {
 		It("returns the the CommonName of the form <proxyID>.<kind>.<service-account>.<namespace>", func() {
 			proxyUUID := uuid.New()
 			serviceAccount := uuid.New().String()
 			namespace := uuid.New().String()
 
-			cn := NewXDSCertCommonName(proxyUUID, KindSidecar, serviceAccount, namespace)
+			cn := NewXDSCertCommonName(proxyUUID, KindSidecar, serviceAccount, namespace, identity.ClusterLocalTrustDomain)
 			Expect(cn).To(Equal(certificate.CommonName(fmt.Sprintf("%s.%s.%s.%s.%s", proxyUUID, KindSidecar, serviceAccount, namespace, identity.ClusterLocalTrustDomain))))
 
 			actualMeta, err := getCertificateCommonNameMeta(cn)
@@ -499,3 +506,19 @@ func TestSubscribedResources(t *testing.T) {
 	assert.True(res.Contains("B"))
 	assert.True(res.Contains("C"))
 }
+
+func TestLastNACK(t *testing.T) {
+	assert := tassert.New(t)
+
+	p := Proxy{}
+	assert.Nil(p.GetLastNACK())
+
+	p.SetLastNACK(&NACK{TypeURI: TypeRDS, Error: "invalid route"})
+	assert.Equal(&NACK{TypeURI: TypeRDS, Error: "invalid route"}, p.GetLastNACK())
+
+	p.ClearLastNACK(TypeCDS)
+	assert.NotNil(p.GetLastNACK())
+
+	p.ClearLastNACK(TypeRDS)
+	assert.Nil(p.GetLastNACK())
+}
@@ -0,0 +1,181 @@
+package rbac
+
+import (
+	"sort"
+	"strings"
+
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// JWTAuthnFilterName is the name of the JWT authentication filter whose dynamic metadata holds the verified JWT payload
+	JWTAuthnFilterName = "envoy.filters.http.jwt_authn"
+
+	// JWTPayloadMetadataKey is the dynamic metadata key at which the JWT authentication filter stores the verified JWT payload
+	JWTPayloadMetadataKey = "jwt_payload"
+
+	// methodHeader is the pseudo header for the HTTP method of a request
+	methodHeader = ":method"
+)
+
+// GenerateAuthorizationPolicy constructs an RBAC policy matching the requests that match the given authorization rule.
+// Each attribute specified in the rule is matched with AND semantics, and each value of an attribute with OR semantics.
+// An attribute that is not specified in the rule matches any request.
+func GenerateAuthorizationPolicy(rule *trafficpolicy.AuthorizationRule) *xds_rbac.Policy {
+	// Construct the Principals ------------------------
+	var principalRules []*xds_rbac.Principal
+
+	var peerPrincipals []*xds_rbac.Principal
+	for _, principal := range rule.Principals {
+		peerPrincipals = append(peerPrincipals, GetAuthenticatedPrincipal(principal.String()))
+	}
+	for _, suffix := range rule.PrincipalSuffixes {
+		peerPrincipals = append(peerPrincipals, getAuthenticatedPrincipalSuffix(suffix))
+	}
+	if len(peerPrincipals) != 0 {
+		principalRules = append(principalRules, orPrincipals(peerPrincipals))
+	}
+
+	var requestPrincipals []*xds_rbac.Principal
+	for _, requestPrincipal := range rule.RequestPrincipals {
+		requestPrincipals = append(requestPrincipals, getRequestPrincipal(requestPrincipal))
+	}
+	if len(requestPrincipals) != 0 {
+		principalRules = append(principalRules, orPrincipals(requestPrincipals))
+	}
+
+	principal := getAnyPrincipal()
+	if len(principalRules) != 0 {
+		principal = andPrincipals(principalRules)
+	}
+
+	// Construct the Permissions ---------------------------
+	var permissionRules []*xds_rbac.Permission
+
+	var methodPermissions []*xds_rbac.Permission
+	for _, method := range rule.Methods {
+		methodPermissions = append(methodPermissions, getHeaderPermission(methodHeader, method))
+	}
+	if len(methodPermissions) != 0 {
+		permissionRules = append(permissionRules, orPermissions(methodPermissions))
+	}
+
+	var pathPermissions []*xds_rbac.Permission
+	for _, path := range rule.Paths {
+		pathPermissions = append(pathPermissions, getPathPermission(path))
+	}
+	if len(pathPermissions) != 0 {
+		permissionRules = append(permissionRules, orPermissions(pathPermissions))
+	}
+
+	if len(rule.Headers) != 0 {
+		var headerPermissions []*xds_rbac.Permission
+		for _, name := range sortedKeys(rule.Headers) {
+			headerPermissions = append(headerPermissions, getHeaderPermission(name, rule.Headers[name]))
+		}
+		permissionRules = append(permissionRules, andPermissions(headerPermissions))
+	}
+
+	permission := getAnyPermission()
+	if len(permissionRules) != 0 {
+		permission = andPermissions(permissionRules)
+	}
+
+	return &xds_rbac.Policy{
+		Principals:  []*xds_rbac.Principal{principal},
+		Permissions: []*xds_rbac.Permission{permission},
+	}
+}
+
+// getAuthenticatedPrincipalSuffix returns an authenticated RBAC principal object matching principals with the given suffix
+func getAuthenticatedPrincipalSuffix(suffix string) *xds_rbac.Principal {
+	return &xds_rbac.Principal{
+		Identifier: &xds_rbac.Principal_Authenticated_{
+			Authenticated: &xds_rbac.Principal_Authenticated{
+				PrincipalName: &xds_matcher.StringMatcher{
+					MatchPattern: &xds_matcher.StringMatcher_Suffix{
+						Suffix: suffix,
+					},
+				},
+			},
+		},
+	}
+}
+
+// getRequestPrincipal returns an RBAC principal object matching the issuer and subject of the JWT verified
+// by the JWT authentication filter
+func getRequestPrincipal(requestPrincipal trafficpolicy.RequestPrincipal) *xds_rbac.Principal {
+	return andPrincipals([]*xds_rbac.Principal{
+		getJWTClaimPrincipal("iss", requestPrincipal.Issuer),
+		getJWTClaimPrincipal("sub", requestPrincipal.Subject),
+	})
+}
+
+// getJWTClaimPrincipal returns an RBAC principal object matching the given claim of the verified JWT
+func getJWTClaimPrincipal(claim string, value string) *xds_rbac.Principal {
+	return &xds_rbac.Principal{
+		Identifier: &xds_rbac.Principal_Metadata{
+			Metadata: &xds_matcher.MetadataMatcher{
+				Filter: JWTAuthnFilterName,
+				Path: []*xds_matcher.MetadataMatcher_PathSegment{
+					{Segment: &xds_matcher.MetadataMatcher_PathSegment_Key{Key: JWTPayloadMetadataKey}},
+					{Segment: &xds_matcher.MetadataMatcher_PathSegment_Key{Key: claim}},
+				},
+				Value: &xds_matcher.ValueMatcher{
+					MatchPattern: &xds_matcher.ValueMatcher_StringMatch{
+						StringMatch: &xds_matcher.StringMatcher{
+							MatchPattern: &xds_matcher.StringMatcher_Exact{Exact: value},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getHeaderPermission returns an RBAC permission matching the exact value of the given header
+func getHeaderPermission(name string, value string) *xds_rbac.Permission {
+	return &xds_rbac.Permission{
+		Rule: &xds_rbac.Permission_Header{
+			Header: &xds_route.HeaderMatcher{
+				Name: name,
+				HeaderMatchSpecifier: &xds_route.HeaderMatcher_ExactMatch{
+					ExactMatch: value,
+				},
+			},
+		},
+	}
+}
+
+// getPathPermission returns an RBAC permission matching the given path, where a path ending with '*' is matched as a prefix
+func getPathPermission(path string) *xds_rbac.Permission {
+	stringMatcher := &xds_matcher.StringMatcher{
+		MatchPattern: &xds_matcher.StringMatcher_Exact{Exact: path},
+	}
+	if strings.HasSuffix(path, "*") {
+		stringMatcher = &xds_matcher.StringMatcher{
+			MatchPattern: &xds_matcher.StringMatcher_Prefix{Prefix: strings.TrimSuffix(path, "*")},
+		}
+	}
+
+	return &xds_rbac.Permission{
+		Rule: &xds_rbac.Permission_UrlPath{
+			UrlPath: &xds_matcher.PathMatcher{
+				Rule: &xds_matcher.PathMatcher_Path{Path: stringMatcher},
+			},
+		},
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,89 @@
+package rbac
+
+import (
+	"testing"
+
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGenerateAuthorizationPolicy(t *testing.T) {
+	testCases := []struct {
+		name                string
+		rule                *trafficpolicy.AuthorizationRule
+		expectedPrincipals  []*xds_rbac.Principal
+		expectedPermissions []*xds_rbac.Permission
+	}{
+		{
+			name:                "rule without attributes matches any request",
+			rule:                &trafficpolicy.AuthorizationRule{Name: "test"},
+			expectedPrincipals:  []*xds_rbac.Principal{getAnyPrincipal()},
+			expectedPermissions: []*xds_rbac.Permission{getAnyPermission()},
+		},
+		{
+			name: "rule with all attributes",
+			rule: &trafficpolicy.AuthorizationRule{
+				Name:              "test",
+				Principals:        []identity.ServiceIdentity{"sa-1.ns-1.cluster.local"},
+				PrincipalSuffixes: []string{".ns-2.cluster.local"},
+				RequestPrincipals: []trafficpolicy.RequestPrincipal{{Issuer: "https://issuer.example.com", Subject: "user1"}},
+				Methods:           []string{"GET", "POST"},
+				Paths:             []string{"/exact", "/prefix/*"},
+				Headers:           map[string]string{"x-b": "2", "x-a": "1"},
+			},
+			expectedPrincipals: []*xds_rbac.Principal{
+				andPrincipals([]*xds_rbac.Principal{
+					orPrincipals([]*xds_rbac.Principal{
+						GetAuthenticatedPrincipal("sa-1.ns-1.cluster.local"),
+						getAuthenticatedPrincipalSuffix(".ns-2.cluster.local"),
+					}),
+					orPrincipals([]*xds_rbac.Principal{
+						andPrincipals([]*xds_rbac.Principal{
+							getJWTClaimPrincipal("iss", "https://issuer.example.com"),
+							getJWTClaimPrincipal("sub", "user1"),
+						}),
+					}),
+				}),
+			},
+			expectedPermissions: []*xds_rbac.Permission{
+				andPermissions([]*xds_rbac.Permission{
+					orPermissions([]*xds_rbac.Permission{
+						getHeaderPermission(":method", "GET"),
+						getHeaderPermission(":method", "POST"),
+					}),
+					orPermissions([]*xds_rbac.Permission{
+						getPathPermission("/exact"),
+						getPathPermission("/prefix/*"),
+					}),
+					andPermissions([]*xds_rbac.Permission{
+						getHeaderPermission("x-a", "1"),
+						getHeaderPermission("x-b", "2"),
+					}),
+				}),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			policy := GenerateAuthorizationPolicy(tc.rule)
+			assert.Equal(tc.expectedPrincipals, policy.Principals)
+			assert.Equal(tc.expectedPermissions, policy.Permissions)
+		})
+	}
+}
+
+func TestGetPathPermission(t *testing.T) {
+	assert := tassert.New(t)
+
+	exact := getPathPermission("/foo").GetUrlPath().GetPath()
+	assert.Equal("/foo", exact.GetExact())
+
+	prefix := getPathPermission("/foo/*").GetUrlPath().GetPath()
+	assert.Equal("/foo/", prefix.GetPrefix())
+}
@@ -20,7 +20,7 @@ import (
 func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryReq *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager, proxyRegistry *registry.ProxyRegistry) ([]types.Resource, error) {
 	var inboundTrafficPolicies []*trafficpolicy.InboundTrafficPolicy
 	var outboundTrafficPolicies []*trafficpolicy.OutboundTrafficPolicy
-	var ingressTrafficPolicies []*trafficpolicy.InboundTrafficPolicy
+	ingressTrafficPolicies := make(map[uint32][]*trafficpolicy.InboundTrafficPolicy)
 
 	proxyIdentity, err := envoy.GetServiceIdentityFromProxyCertificate(proxy.GetCertificateCommonName())
 	if err != nil {
@@ -29,6 +29,10 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 		return nil, err
 	}
 
+	if proxy.Kind() == envoy.KindEgressGateway {
+		return newEgressGatewayResponse(cataloger, proxy, discoveryReq)
+	}
+
 	services, err := proxyRegistry.ListProxyServices(proxy)
 	if err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrFetchingServiceList)).
@@ -59,10 +63,11 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 			log.Trace().Msgf("No ingress policy confiugred for service %s", svc)
 			continue
 		}
-		ingressTrafficPolicies = trafficpolicy.MergeInboundPolicies(catalog.AllowPartialHostnamesMatch, ingressTrafficPolicies, ingressPolicy.HTTPRoutePolicies...)
+		for port, httpRoutePolicies := range ingressPolicy.HTTPRoutePoliciesPerPort {
+			ingressTrafficPolicies[port] = trafficpolicy.MergeInboundPolicies(catalog.AllowPartialHostnamesMatch, ingressTrafficPolicies[port], httpRoutePolicies...)
+		}
 	}
-	if len(ingressTrafficPolicies) > 0 {
-		ingressRouteConfig := route.BuildIngressConfiguration(ingressTrafficPolicies)
+	for _, ingressRouteConfig := range route.BuildIngressConfiguration(ingressTrafficPolicies) {
 		rdsResources = append(rdsResources, ingressRouteConfig)
 	}
 
@@ -88,6 +93,26 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 	return rdsResources, nil
 }
 
+// newEgressGatewayResponse creates the Route Discovery Response for the egress gateway
+func newEgressGatewayResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryReq *xds_discovery.DiscoveryRequest) ([]types.Resource, error) {
+	egressTrafficPolicy, err := cataloger.GetEgressGatewayTrafficPolicy()
+	if err != nil {
+		log.Error().Err(err).Msgf("Error retrieving egress gateway policy for proxy %s", proxy.String())
+		return nil, err
+	}
+
+	var rdsResources []types.Resource
+	if egressTrafficPolicy != nil {
+		rdsResources = append(rdsResources, route.BuildEgressGatewayRouteConfiguration(egressTrafficPolicy.HTTPRouteConfigsPerPort))
+	}
+
+	if discoveryReq != nil {
+		rdsResources = ensureRDSRequestCompletion(discoveryReq, rdsResources)
+	}
+
+	return rdsResources, nil
+}
+
 // ensureRDSRequestCompletion computes delta between requested resources and response resources.
 // If any resources requested were not responded to, this function will fill those in with empty RouteConfig stubs
 func ensureRDSRequestCompletion(discoveryReq *xds_discovery.DiscoveryRequest, rdsResources []types.Resource) []types.Resource {
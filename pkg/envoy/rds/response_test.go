@@ -118,7 +118,7 @@ func TestNewResponse(t *testing.T) {
 								},
 								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 							},
-							AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity()),
+							AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -135,7 +135,7 @@ func TestNewResponse(t *testing.T) {
 								},
 								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 							},
-							AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity()),
+							AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -167,7 +167,7 @@ func TestNewResponse(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -180,7 +180,7 @@ func TestNewResponse(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -210,7 +210,7 @@ func TestNewResponse(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -223,7 +223,7 @@ func TestNewResponse(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -283,7 +283,7 @@ func TestNewResponse(t *testing.T) {
 
 			mockCatalog.EXPECT().ListInboundTrafficPolicies(gomock.Any(), gomock.Any()).Return(tc.expectedInboundPolicies).AnyTimes()
 			mockCatalog.EXPECT().ListOutboundTrafficPolicies(gomock.Any()).Return(tc.expectedOutboundPolicies).AnyTimes()
-			mockCatalog.EXPECT().GetIngressTrafficPolicy(gomock.Any()).Return(&trafficpolicy.IngressTrafficPolicy{HTTPRoutePolicies: tc.ingressInboundPolicies}, nil).AnyTimes()
+			mockCatalog.EXPECT().GetIngressTrafficPolicy(gomock.Any()).Return(&trafficpolicy.IngressTrafficPolicy{HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{80: tc.ingressInboundPolicies}}, nil).AnyTimes()
 			mockCatalog.EXPECT().GetEgressTrafficPolicy(gomock.Any()).Return(nil, nil).AnyTimes()
 
 			// Empty discovery request
@@ -298,7 +298,7 @@ func TestNewResponse(t *testing.T) {
 			// The RDS response will have two route configurations
 			// 1. rds-inbound
 			// 2. rds-outbound
-			// 3. rds-ingress
+			// 3. rds-ingress.80
 			assert.Equal(3, len(resources))
 
 			// Check the inbound route configuration
@@ -350,6 +350,7 @@ func TestNewResponse(t *testing.T) {
 			// Check the ingress route configuration
 			routeConfig, ok = resources[2].(*xds_route.RouteConfiguration)
 			assert.True(ok)
+			assert.Equal("rds-ingress.80", routeConfig.Name)
 
 			// ingress_virtual-host|bookstore-v1-default-bookstore-v1.default.svc.cluster.local
 			assert.Equal("ingress_virtual-host|bookstore-v1-default-bookstore-v1.default.svc.cluster.local", routeConfig.VirtualHosts[0].Name)
@@ -456,7 +457,7 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 						},
 						WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 					},
-					AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 		},
@@ -502,7 +503,7 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 						},
 						WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 					},
-					AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 		},
@@ -519,7 +520,7 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 						},
 						WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 					},
-					AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(tests.BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 		},
@@ -530,7 +531,7 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 
 	mockCatalog.EXPECT().ListInboundTrafficPolicies(gomock.Any(), gomock.Any()).Return(testPermissiveInbound).AnyTimes()
 	mockCatalog.EXPECT().ListOutboundTrafficPolicies(gomock.Any()).Return(testPermissiveOutbound).AnyTimes()
-	mockCatalog.EXPECT().GetIngressTrafficPolicy(gomock.Any()).Return(&trafficpolicy.IngressTrafficPolicy{HTTPRoutePolicies: testIngressInbound}, nil).AnyTimes()
+	mockCatalog.EXPECT().GetIngressTrafficPolicy(gomock.Any()).Return(&trafficpolicy.IngressTrafficPolicy{HTTPRoutePoliciesPerPort: map[uint32][]*trafficpolicy.InboundTrafficPolicy{80: testIngressInbound}}, nil).AnyTimes()
 	mockCatalog.EXPECT().GetEgressTrafficPolicy(gomock.Any()).Return(nil, nil).AnyTimes()
 
 	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
@@ -569,6 +570,7 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 	routeConfig, ok = resources[2].(*xds_route.RouteConfiguration)
 	assert.True(ok)
 
+	assert.Equal("rds-ingress.80", routeConfig.Name)
 	assert.Equal("ingress_virtual-host|bookstore-v1-default-bookstore-v1.default.svc.cluster.local", routeConfig.VirtualHosts[0].Name)
 	assert.Equal([]string{"bookstore-v1.default.svc.cluster.local"}, routeConfig.VirtualHosts[0].Domains)
 	assert.Equal(1, len(routeConfig.VirtualHosts[0].Routes))
@@ -32,8 +32,8 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 				},
 				AllowedServiceIdentities: mapset.NewSetFromSlice([]interface{}{
-					identity.K8sServiceAccount{Name: "foo", Namespace: "ns-1"}.ToServiceIdentity(),
-					identity.K8sServiceAccount{Name: "bar", Namespace: "ns-2"}.ToServiceIdentity(),
+					identity.K8sServiceAccount{Name: "foo", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+					identity.K8sServiceAccount{Name: "bar", Namespace: "ns-2"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 				}),
 			},
 			expectedRBACPolicy: &xds_rbac.Policy{
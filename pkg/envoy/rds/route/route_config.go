@@ -3,6 +3,7 @@ package route
 import (
 	"fmt"
 	"sort"
+	"strconv"
 
 	mapset "github.com/deckarep/golang-set"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -36,8 +37,11 @@ const (
 	// OutboundRouteConfigName is the name of the outbound mesh RDS route configuration
 	OutboundRouteConfigName = "rds-outbound"
 
-	// IngressRouteConfigName is the name of the ingress RDS route configuration
-	IngressRouteConfigName = "rds-ingress"
+	// ingressRouteConfigNamePrefix is the prefix for the name of the ingress RDS route configuration
+	ingressRouteConfigNamePrefix = "rds-ingress"
+
+	// EgressGatewayRouteConfigName is the name of the egress gateway RDS route configuration
+	EgressGatewayRouteConfigName = "rds-egress-gateway"
 
 	// egressRouteConfigNamePrefix is the prefix for the name of the egress RDS route configuration
 	egressRouteConfigNamePrefix = "rds-egress"
@@ -103,19 +107,29 @@ func BuildRouteConfiguration(inbound []*trafficpolicy.InboundTrafficPolicy, outb
 }
 
 // BuildIngressConfiguration constructs the Envoy constructs ([]*xds_route.RouteConfiguration) for implementing ingress routes
-func BuildIngressConfiguration(ingress []*trafficpolicy.InboundTrafficPolicy) *xds_route.RouteConfiguration {
-	if len(ingress) == 0 {
-		return nil
+func BuildIngressConfiguration(portSpecificIngress map[uint32][]*trafficpolicy.InboundTrafficPolicy) []*xds_route.RouteConfiguration {
+	var ports []uint32
+	for port, ingress := range portSpecificIngress {
+		if len(ingress) > 0 {
+			ports = append(ports, port)
+		}
 	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
 
-	ingressRouteConfig := NewRouteConfigurationStub(IngressRouteConfigName)
-	for _, in := range ingress {
-		virtualHost := buildVirtualHostStub(ingressVirtualHost, in.Name, in.Hostnames)
-		virtualHost.Routes = buildInboundRoutes(in.Rules)
-		ingressRouteConfig.VirtualHosts = append(ingressRouteConfig.VirtualHosts, virtualHost)
+	// An Envoy RouteConfiguration will exist for each ingress port, such that the routes
+	// allowed for the sources of one port do not apply to the traffic received on another port.
+	var routeConfigs []*xds_route.RouteConfiguration
+	for _, port := range ports {
+		ingressRouteConfig := NewRouteConfigurationStub(GetIngressRouteConfigNameForPort(port))
+		for _, in := range portSpecificIngress[port] {
+			virtualHost := buildVirtualHostStub(ingressVirtualHost, in.Name, in.Hostnames)
+			virtualHost.Routes = buildInboundRoutes(in.Rules)
+			ingressRouteConfig.VirtualHosts = append(ingressRouteConfig.VirtualHosts, virtualHost)
+		}
+		routeConfigs = append(routeConfigs, ingressRouteConfig)
 	}
 
-	return ingressRouteConfig
+	return routeConfigs
 }
 
 // BuildEgressRouteConfiguration constructs the Envoy construct (*xds_route.RouteConfiguration) for the given egress route configs
@@ -130,6 +144,17 @@ func BuildEgressRouteConfiguration(portSpecificRouteConfigs map[int][]*trafficpo
 		for _, config := range configs {
 			virtualHost := buildVirtualHostStub(egressVirtualHost, config.Name, config.Hostnames)
 			virtualHost.Routes = buildEgressRoutes(config.RoutingRules)
+			if config.ViaEgressGateway {
+				// The egress gateway serves all egress ports on a single listener, so the port
+				// the request was destined to is conveyed to it in a header.
+				virtualHost.RequestHeadersToAdd = append(virtualHost.RequestHeadersToAdd, &core.HeaderValueOption{
+					Header: &core.HeaderValue{
+						Key:   constants.EgressGatewayPortHeader,
+						Value: strconv.Itoa(port),
+					},
+					Append: &wrappers.BoolValue{Value: false},
+				})
+			}
 			routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
 		}
 		routeConfigs = append(routeConfigs, routeConfig)
@@ -138,6 +163,42 @@ func BuildEgressRouteConfiguration(portSpecificRouteConfigs map[int][]*trafficpo
 	return routeConfigs
 }
 
+// BuildEgressGatewayRouteConfiguration constructs the Envoy construct (*xds_route.RouteConfiguration) for the egress gateway
+// from the given egress route configs. Since the egress gateway serves all egress ports on a single listener, the routes
+// for a host on different ports are merged into a single virtual host and are matched on the port header set by the
+// sidecar that routed the request to the egress gateway.
+func BuildEgressGatewayRouteConfiguration(portSpecificRouteConfigs map[int][]*trafficpolicy.EgressHTTPRouteConfig) *xds_route.RouteConfiguration {
+	routeConfig := NewRouteConfigurationStub(EgressGatewayRouteConfigName)
+	routeConfig.RequestHeadersToRemove = []string{constants.EgressGatewayPortHeader}
+
+	var ports []int
+	for port := range portSpecificRouteConfigs {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+
+	virtualHostsByHost := make(map[string]*xds_route.VirtualHost)
+	for _, port := range ports {
+		portHeader := map[string]string{constants.EgressGatewayPortHeader: strconv.Itoa(port)}
+		for _, config := range portSpecificRouteConfigs[port] {
+			virtualHost, ok := virtualHostsByHost[config.Name]
+			if !ok {
+				virtualHost = buildVirtualHostStub(egressVirtualHost, config.Name, nil)
+				virtualHostsByHost[config.Name] = virtualHost
+				routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
+			}
+			for _, hostname := range config.Hostnames {
+				if !containsDomain(virtualHost.Domains, hostname) {
+					virtualHost.Domains = append(virtualHost.Domains, hostname)
+				}
+			}
+			virtualHost.Routes = append(virtualHost.Routes, buildEgressGatewayRoutes(config.RoutingRules, portHeader)...)
+		}
+	}
+
+	return routeConfig
+}
+
 //NewRouteConfigurationStub creates the route configuration placeholder
 func NewRouteConfigurationStub(routeConfigName string) *xds_route.RouteConfiguration {
 	routeConfiguration := xds_route.RouteConfiguration{
@@ -213,6 +274,39 @@ func buildEgressRoutes(routingRules []*trafficpolicy.EgressHTTPRoutingRule) []*x
 	return routes
 }
 
+// buildEgressGatewayRoutes builds the egress gateway routes for the given routing rules. Each route matches the given
+// port header and is associated with an RBAC policy permitting the service identities allowed by the routing rule.
+func buildEgressGatewayRoutes(routingRules []*trafficpolicy.EgressHTTPRoutingRule, portHeader map[string]string) []*xds_route.Route {
+	var routes []*xds_route.Route
+	for _, rule := range routingRules {
+		rbacPolicyForRoute, err := buildInboundRBACFilterForRule(&trafficpolicy.Rule{
+			Route:                    rule.Route,
+			AllowedServiceIdentities: rule.AllowedServiceIdentities,
+		})
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrBuildingRBACPolicyForRoute)).
+				Msgf("Error building RBAC policy for egress rule [%v], skipping route addition", rule)
+			continue
+		}
+
+		for _, httpMethod := range sanitizeHTTPMethods(rule.Route.HTTPRouteMatch.Methods) {
+			route := buildRoute(rule.Route.HTTPRouteMatch.PathMatchType, rule.Route.HTTPRouteMatch.Path, httpMethod, portHeader, rule.Route.WeightedClusters, rule.Route.TotalClustersWeight(), outboundRoute)
+			route.TypedPerFilterConfig = rbacPolicyForRoute
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+func containsDomain(domains []string, domain string) bool {
+	for _, d := range domains {
+		if d == domain {
+			return true
+		}
+	}
+	return false
+}
+
 func buildRoute(pathMatchTypeType trafficpolicy.PathMatchType, path string, method string, headersMap map[string]string, weightedClusters mapset.Set, totalWeight int, direction Direction) *xds_route.Route {
 	route := xds_route.Route{
 		Match: &xds_route.RouteMatch{
@@ -364,6 +458,11 @@ func getRegexForMethod(httpMethod string) string {
 	return methodRegex
 }
 
+// GetIngressRouteConfigNameForPort returns the ingress route configuration object's name given the port
+func GetIngressRouteConfigNameForPort(port uint32) string {
+	return fmt.Sprintf("%s.%d", ingressRouteConfigNamePrefix, port)
+}
+
 // GetEgressRouteConfigNameForPort returns the Egress route configuration object's name given the port it is targeted to
 func GetEgressRouteConfigNameForPort(port int) string {
 	return fmt.Sprintf("%s.%d", egressRouteConfigNamePrefix, port)
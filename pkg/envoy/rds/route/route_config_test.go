@@ -34,14 +34,14 @@ func TestBuildRouteConfiguration(t *testing.T) {
 					HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
 					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 				},
-				AllowedServiceIdentities: mapset.NewSet(tests.BookbuyerServiceAccount.ToServiceIdentity()),
+				AllowedServiceIdentities: mapset.NewSet(tests.BookbuyerServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 			},
 			{
 				Route: trafficpolicy.RouteWeightedClusters{
 					HTTPRouteMatch:   tests.BookstoreSellHTTPRoute,
 					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 				},
-				AllowedServiceIdentities: mapset.NewSet(tests.BookbuyerServiceAccount.ToServiceIdentity()),
+				AllowedServiceIdentities: mapset.NewSet(tests.BookbuyerServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 			},
 		},
 	}
@@ -136,8 +136,8 @@ func TestBuildRouteConfiguration(t *testing.T) {
 func TestBuildIngressRouteConfiguration(t *testing.T) {
 	testCases := []struct {
 		name                      string
-		ingressPolicies           []*trafficpolicy.InboundTrafficPolicy
-		expectedRouteConfigFields *xds_route.RouteConfiguration
+		ingressPolicies           map[uint32][]*trafficpolicy.InboundTrafficPolicy
+		expectedRouteConfigFields []*xds_route.RouteConfiguration
 	}{
 		{
 			name:                      "no ingress policies",
@@ -146,60 +146,127 @@ func TestBuildIngressRouteConfiguration(t *testing.T) {
 		},
 		{
 			name: "multiple ingress policies",
-			ingressPolicies: []*trafficpolicy.InboundTrafficPolicy{
-				{
-					Name:      "bookstore-v1-default",
-					Hostnames: []string{"bookstore-v1.default.svc.cluster.local"},
-					Rules: []*trafficpolicy.Rule{
-						{
-							Route: trafficpolicy.RouteWeightedClusters{
-								HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
-								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+			ingressPolicies: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+				80: {
+					{
+						Name:      "bookstore-v1-default",
+						Hostnames: []string{"bookstore-v1.default.svc.cluster.local"},
+						Rules: []*trafficpolicy.Rule{
+							{
+								Route: trafficpolicy.RouteWeightedClusters{
+									HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
+									WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+								},
+								AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
+							},
+							{
+								Route: trafficpolicy.RouteWeightedClusters{
+									HTTPRouteMatch:   tests.BookstoreSellHTTPRoute,
+									WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+								},
+								AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 							},
-							AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 						},
-						{
-							Route: trafficpolicy.RouteWeightedClusters{
-								HTTPRouteMatch:   tests.BookstoreSellHTTPRoute,
-								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+					},
+					{
+						Name:      "foo.com",
+						Hostnames: []string{"foo.com"},
+						Rules: []*trafficpolicy.Rule{
+							{
+								Route: trafficpolicy.RouteWeightedClusters{
+									HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
+									WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+								},
+								AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 							},
-							AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 						},
 					},
 				},
+			},
+			expectedRouteConfigFields: []*xds_route.RouteConfiguration{
 				{
-					Name:      "foo.com",
-					Hostnames: []string{"foo.com"},
-					Rules: []*trafficpolicy.Rule{
+					Name: "rds-ingress.80",
+					VirtualHosts: []*xds_route.VirtualHost{
+						{
+							Name: "ingress_virtual-host|bookstore-v1-default",
+							Routes: []*xds_route.Route{
+								{
+									// corresponds to ingressPolicies[0].Rules[0]
+								},
+								{
+									// corresponds to ingressPolicies[0].Rules[1]
+								},
+							},
+						},
 						{
-							Route: trafficpolicy.RouteWeightedClusters{
-								HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
-								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+							Name: "ingress_virtual-host|foo.com",
+							Routes: []*xds_route.Route{
+								{
+									// corresponds to ingressPolicies[1].Rules[0]
+								},
 							},
-							AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 						},
 					},
 				},
 			},
-			expectedRouteConfigFields: &xds_route.RouteConfiguration{
-				Name: "rds-ingress",
-				VirtualHosts: []*xds_route.VirtualHost{
+		},
+		{
+			name: "ingress policies on multiple ports",
+			ingressPolicies: map[uint32][]*trafficpolicy.InboundTrafficPolicy{
+				90: {
 					{
-						Name: "ingress_virtual-host|bookstore-v1.default.svc.cluster.local",
-						Routes: []*xds_route.Route{
+						Name:      "foo.com",
+						Hostnames: []string{"foo.com"},
+						Rules: []*trafficpolicy.Rule{
 							{
-								// corresponds to ingressPolicies[0].Rules[0]
-							},
-							{
-								// corresponds to ingressPolicies[0].Rules[1]
+								Route: trafficpolicy.RouteWeightedClusters{
+									HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
+									WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+								},
+								AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
 							},
 						},
 					},
+				},
+				80: {
 					{
-						Name: "ingress_virtual-host|foo.com",
-						Routes: []*xds_route.Route{
+						Name:      "bookstore-v1-default",
+						Hostnames: []string{"*"},
+						Rules: []*trafficpolicy.Rule{
 							{
-								// corresponds to ingressPolicies[1].Rules[0]
+								Route: trafficpolicy.RouteWeightedClusters{
+									HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+									WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+								},
+								AllowedServiceIdentities: mapset.NewSet(identity.WildcardServiceIdentity),
+							},
+						},
+					},
+				},
+			},
+			expectedRouteConfigFields: []*xds_route.RouteConfiguration{
+				{
+					Name: "rds-ingress.80",
+					VirtualHosts: []*xds_route.VirtualHost{
+						{
+							Name: "ingress_virtual-host|bookstore-v1-default",
+							Routes: []*xds_route.Route{
+								{
+									// corresponds to ingressPolicies[80][0].Rules[0]
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "rds-ingress.90",
+					VirtualHosts: []*xds_route.VirtualHost{
+						{
+							Name: "ingress_virtual-host|foo.com",
+							Routes: []*xds_route.Route{
+								{
+									// corresponds to ingressPolicies[90][0].Rules[0]
+								},
 							},
 						},
 					},
@@ -213,22 +280,61 @@ func TestBuildIngressRouteConfiguration(t *testing.T) {
 			assert := tassert.New(t)
 			actual := BuildIngressConfiguration(tc.ingressPolicies)
 
-			if tc.expectedRouteConfigFields == nil {
-				assert.Nil(actual)
-				return
-			}
-
-			assert.NotNil(actual)
-			assert.Equal(tc.expectedRouteConfigFields.Name, actual.Name)
-			assert.Len(actual.VirtualHosts, len(tc.expectedRouteConfigFields.VirtualHosts))
+			assert.Len(actual, len(tc.expectedRouteConfigFields))
+			for i, routeConfig := range actual {
+				expected := tc.expectedRouteConfigFields[i]
+				assert.Equal(expected.Name, routeConfig.Name)
+				assert.Len(routeConfig.VirtualHosts, len(expected.VirtualHosts))
 
-			for i, vh := range actual.VirtualHosts {
-				assert.Len(vh.Routes, len(tc.expectedRouteConfigFields.VirtualHosts[i].Routes))
+				for j, vh := range routeConfig.VirtualHosts {
+					assert.Equal(expected.VirtualHosts[j].Name, vh.Name)
+					assert.Len(vh.Routes, len(expected.VirtualHosts[j].Routes))
+				}
 			}
 		})
 	}
 }
 
+func TestBuildIngressRouteConfigurationWithHTTPRules(t *testing.T) {
+	assert := tassert.New(t)
+
+	ingressPolicies := []*trafficpolicy.InboundTrafficPolicy{
+		{
+			Name:      "default/bookstore_from_ingress-backend|foo.com",
+			Hostnames: []string{"foo.com"},
+			Rules: []*trafficpolicy.Rule{
+				{
+					Route: trafficpolicy.RouteWeightedClusters{
+						HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+							Path:          "/api",
+							PathMatchType: trafficpolicy.PathMatchPrefix,
+							Methods:       []string{"GET", "POST"},
+						},
+						WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+					},
+					AllowedServiceIdentities: mapset.NewSet(identity.ServiceIdentity("ingress-gateway.osm-system.cluster.local")),
+				},
+			},
+		},
+	}
+
+	routeConfigs := BuildIngressConfiguration(map[uint32][]*trafficpolicy.InboundTrafficPolicy{80: ingressPolicies})
+	assert.Len(routeConfigs, 1)
+	actual := routeConfigs[0]
+	assert.Len(actual.VirtualHosts, 1)
+	assert.Equal([]string{"foo.com"}, actual.VirtualHosts[0].Domains)
+
+	// Each allowed method corresponds to a route restricted to the path prefix, with its own RBAC policy
+	routes := actual.VirtualHosts[0].Routes
+	assert.Len(routes, 2)
+	for i, method := range []string{"GET", "POST"} {
+		assert.Equal("/api", routes[i].GetMatch().GetPrefix())
+		assert.Equal(methodHeaderKey, routes[i].GetMatch().GetHeaders()[0].Name)
+		assert.Equal(method, routes[i].GetMatch().GetHeaders()[0].GetSafeRegexMatch().Regex)
+		assert.NotNil(routes[i].TypedPerFilterConfig)
+	}
+}
+
 func TestBuildVirtualHostStub(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -288,7 +394,7 @@ func TestBuildInboundRoutes(t *testing.T) {
 						WeightedClusters: mapset.NewSet(testWeightedCluster),
 					},
 					AllowedServiceIdentities: mapset.NewSetFromSlice(
-						[]interface{}{identity.K8sServiceAccount{Name: "foo", Namespace: "bar"}.ToServiceIdentity()},
+						[]interface{}{identity.K8sServiceAccount{Name: "foo", Namespace: "bar"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)},
 					),
 				},
 			},
@@ -1217,6 +1323,75 @@ func TestBuildEgressRouteConfiguration(t *testing.T) {
 	}
 }
 
+func TestBuildEgressRouteConfigurationViaEgressGateway(t *testing.T) {
+	assert := tassert.New(t)
+
+	routeConfigs := BuildEgressRouteConfiguration(map[int][]*trafficpolicy.EgressHTTPRouteConfig{
+		80: {
+			{Name: "foo.com", Hostnames: []string{"foo.com", "foo.com:80"}, ViaEgressGateway: true},
+			{Name: "*.bar.com", Hostnames: []string{"*.bar.com", "*.bar.com:80"}},
+		},
+	})
+	assert.Len(routeConfigs, 1)
+	assert.Len(routeConfigs[0].VirtualHosts, 2)
+
+	gatewayHost := routeConfigs[0].VirtualHosts[0]
+	assert.Len(gatewayHost.RequestHeadersToAdd, 1)
+	assert.Equal(constants.EgressGatewayPortHeader, gatewayHost.RequestHeadersToAdd[0].Header.Key)
+	assert.Equal("80", gatewayHost.RequestHeadersToAdd[0].Header.Value)
+	assert.False(gatewayHost.RequestHeadersToAdd[0].Append.Value)
+
+	assert.Empty(routeConfigs[0].VirtualHosts[1].RequestHeadersToAdd)
+}
+
+func TestBuildEgressGatewayRouteConfiguration(t *testing.T) {
+	assert := tassert.New(t)
+
+	allowed := mapset.NewSet(identity.ServiceIdentity("sa-1.ns-1.cluster.local"))
+	newRule := func(cluster string) *trafficpolicy.EgressHTTPRoutingRule {
+		return &trafficpolicy.EgressHTTPRoutingRule{
+			Route: trafficpolicy.RouteWeightedClusters{
+				HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+				WeightedClusters: mapset.NewSetFromSlice([]interface{}{
+					service.WeightedCluster{ClusterName: service.ClusterName(cluster), Weight: 100},
+				}),
+			},
+			AllowedServiceIdentities: allowed,
+		}
+	}
+
+	routeConfig := BuildEgressGatewayRouteConfiguration(map[int][]*trafficpolicy.EgressHTTPRouteConfig{
+		80: {
+			{Name: "foo.com", Hostnames: []string{"foo.com", "foo.com:80"}, RoutingRules: []*trafficpolicy.EgressHTTPRoutingRule{newRule("foo.com:80")}},
+		},
+		8080: {
+			{Name: "foo.com", Hostnames: []string{"foo.com", "foo.com:8080"}, RoutingRules: []*trafficpolicy.EgressHTTPRoutingRule{newRule("foo.com:8080")}},
+		},
+	})
+
+	assert.Equal(EgressGatewayRouteConfigName, routeConfig.Name)
+	assert.Equal([]string{constants.EgressGatewayPortHeader}, routeConfig.RequestHeadersToRemove)
+	assert.Len(routeConfig.VirtualHosts, 1)
+
+	virtualHost := routeConfig.VirtualHosts[0]
+	assert.Equal([]string{"foo.com", "foo.com:80", "foo.com:8080"}, virtualHost.Domains)
+	assert.Len(virtualHost.Routes, 2)
+
+	for i, port := range []string{"80", "8080"} {
+		route := virtualHost.Routes[i]
+		assert.NotNil(route.TypedPerFilterConfig)
+
+		var portHeaderMatched bool
+		for _, header := range route.Match.Headers {
+			if header.Name == constants.EgressGatewayPortHeader {
+				portHeaderMatched = header.GetSafeRegexMatch().Regex == port
+			}
+		}
+		assert.True(portHeaderMatched)
+		assert.Equal("foo.com:"+port, route.GetRoute().GetWeightedClusters().Clusters[0].Name)
+	}
+}
+
 func TestGetEgressRouteConfigNameForPort(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -66,16 +66,19 @@ func (k *KubeProxyServiceMapper) ListProxyServices(p *envoy.Proxy) ([]service.Me
 // to Kubernetes events.
 type AsyncKubeProxyServiceMapper struct {
 	kubeController k8s.Controller
+	trustDomain    string
 	kubeEvents     chan interface{}
 	servicesForCN  map[certificate.CommonName][]service.MeshService
 	cnsForService  map[service.MeshService]map[certificate.CommonName]struct{}
 	cacheLock      sync.RWMutex
 }
 
-// NewAsyncKubeProxyServiceMapper initializes a KubeProxyServiceMapper with an empty cache.
-func NewAsyncKubeProxyServiceMapper(controller k8s.Controller) *AsyncKubeProxyServiceMapper {
+// NewAsyncKubeProxyServiceMapper initializes a KubeProxyServiceMapper with an empty cache, the certificate common names
+// of the proxies are computed in the given trust domain.
+func NewAsyncKubeProxyServiceMapper(controller k8s.Controller, trustDomain string) *AsyncKubeProxyServiceMapper {
 	return &AsyncKubeProxyServiceMapper{
 		kubeController: controller,
+		trustDomain:    trustDomain,
 		servicesForCN:  make(map[certificate.CommonName][]service.MeshService),
 		cnsForService:  make(map[service.MeshService]map[certificate.CommonName]struct{}),
 		kubeEvents: events.Subscribe(
@@ -140,7 +143,7 @@ func (k *AsyncKubeProxyServiceMapper) handlePodUpdate(pod *v1.Pod) {
 	if pod == nil {
 		return
 	}
-	cn, err := getCertCommonNameForPod(*pod)
+	cn, err := getCertCommonNameForPod(*pod, k.trustDomain)
 	if err != nil {
 		log.Error().Err(err).Msgf("ignoring updated pod %s/%s", pod.Namespace, pod.Name)
 		return
@@ -168,7 +171,7 @@ func (k *AsyncKubeProxyServiceMapper) handlePodDelete(pod *v1.Pod) {
 	if pod == nil {
 		return
 	}
-	cn, err := getCertCommonNameForPod(*pod)
+	cn, err := getCertCommonNameForPod(*pod, k.trustDomain)
 	if err != nil {
 		log.Error().Err(err).Msgf("ignoring deleted pod %s/%s", pod.Namespace, pod.Name)
 		return
@@ -196,7 +199,7 @@ func (k *AsyncKubeProxyServiceMapper) handleServiceUpdate(svc *v1.Service) {
 
 	pods := listPodsForService(svc, k.kubeController)
 	for _, pod := range pods {
-		cn, err := getCertCommonNameForPod(pod)
+		cn, err := getCertCommonNameForPod(pod, k.trustDomain)
 		if err != nil {
 			log.Error().Err(err)
 			continue
@@ -308,7 +311,7 @@ func listPodsForService(service *v1.Service, kubeController k8s.Controller) []v1
 	return matchedPods
 }
 
-func getCertCommonNameForPod(pod v1.Pod) (certificate.CommonName, error) {
+func getCertCommonNameForPod(pod v1.Pod, trustDomain string) (certificate.CommonName, error) {
 	proxyUIDStr, exists := pod.Labels[constants.EnvoyUniqueIDLabelName]
 	if !exists {
 		return "", errors.Errorf("no %s label", constants.EnvoyUniqueIDLabelName)
@@ -317,6 +320,6 @@ func getCertCommonNameForPod(pod v1.Pod) (certificate.CommonName, error) {
 	if err != nil {
 		return "", errors.Wrapf(err, "invalid UID value for %s label", constants.EnvoyUniqueIDLabelName)
 	}
-	cn := envoy.NewXDSCertCommonName(proxyUID, envoy.KindSidecar, pod.Spec.ServiceAccountName, pod.Namespace)
+	cn := envoy.NewXDSCertCommonName(proxyUID, envoy.KindSidecar, pod.Spec.ServiceAccountName, pod.Namespace, trustDomain)
 	return cn, nil
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/k8s/events"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -56,7 +57,7 @@ var _ = Describe("Test Proxy-Service mapping", func() {
 			svc2 := tests.NewServiceFixture(svcName2, tests.Namespace, selector)
 			mockKubeController.EXPECT().ListServices().Return([]*v1.Service{svc, svc2}).Times(1)
 
-			certCommonName := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, tests.BookstoreServiceAccountName, tests.Namespace)
+			certCommonName := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, tests.BookstoreServiceAccountName, tests.Namespace, identity.ClusterLocalTrustDomain)
 			certSerialNumber := certificate.SerialNumber("123456")
 			proxy, err := envoy.NewProxy(certCommonName, certSerialNumber, nil)
 			Expect(err).ToNot(HaveOccurred())
@@ -250,8 +251,8 @@ var _ = Describe("Test Proxy-Service mapping", func() {
 })
 
 func TestAsyncKubeProxyServiceMapperListServicesForProxy(t *testing.T) {
-	cn1 := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc1", "ns1")
-	cn2 := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc2", "ns2")
+	cn1 := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc1", "ns1", identity.ClusterLocalTrustDomain)
+	cn2 := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "svcacc2", "ns2", identity.ClusterLocalTrustDomain)
 	svc1 := service.MeshService{Namespace: "ns1", Name: "svc1"}
 	mapper := &AsyncKubeProxyServiceMapper{
 		servicesForCN: map[certificate.CommonName][]service.MeshService{
@@ -283,7 +284,7 @@ func TestAsyncKubeProxyServiceMapperRun(t *testing.T) {
 	stop := make(chan struct{})
 	defer close(stop)
 
-	k := NewAsyncKubeProxyServiceMapper(kubeController)
+	k := NewAsyncKubeProxyServiceMapper(kubeController, identity.ClusterLocalTrustDomain)
 
 	assert.Empty(k.servicesForCN)
 
@@ -300,7 +301,7 @@ func TestAsyncKubeProxyServiceMapperRun(t *testing.T) {
 			ServiceAccountName: "my-service-acc",
 		},
 	}
-	cn := envoy.NewXDSCertCommonName(proxyUID, envoy.KindSidecar, pod.Spec.ServiceAccountName, pod.Namespace)
+	cn := envoy.NewXDSCertCommonName(proxyUID, envoy.KindSidecar, pod.Spec.ServiceAccountName, pod.Namespace, identity.ClusterLocalTrustDomain)
 	svc := &v1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "svc",
@@ -393,7 +394,7 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
 		},
@@ -425,7 +426,7 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -434,7 +435,7 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -477,7 +478,7 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc1",
 						Namespace: "ns",
@@ -490,10 +491,10 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc1", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "svc2", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -511,7 +512,7 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "other-svc1",
 						Namespace: "ns",
@@ -520,12 +521,12 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "other-svc1", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): nil,
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "other-svc1",
 						Namespace: "ns",
@@ -534,7 +535,7 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "other-svc1", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -550,10 +551,10 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
 		},
@@ -585,7 +586,7 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -594,17 +595,17 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
 					},
 				},
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -613,8 +614,8 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -627,6 +628,7 @@ func TestKubeHandlePodUpdate(t *testing.T) {
 			kubeController.EXPECT().ListServices().Return(test.existingSvcs)
 
 			k := &AsyncKubeProxyServiceMapper{
+				trustDomain:    identity.ClusterLocalTrustDomain,
 				kubeController: kubeController,
 				servicesForCN:  test.existingCNsToServices,
 				cnsForService:  test.existingServicesToCNs,
@@ -669,10 +671,10 @@ func TestKubeHandlePodDelete(t *testing.T) {
 			name: "delete nil pod",
 			pod:  nil,
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
 		},
@@ -688,10 +690,10 @@ func TestKubeHandlePodDelete(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
 		},
@@ -709,10 +711,10 @@ func TestKubeHandlePodDelete(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
 		},
@@ -730,7 +732,7 @@ func TestKubeHandlePodDelete(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
@@ -749,11 +751,11 @@ func TestKubeHandlePodDelete(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): nil,
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
 		},
@@ -771,27 +773,27 @@ func TestKubeHandlePodDelete(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{Namespace: "ns", Name: "svc"},
 				},
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{Namespace: "ns", Name: "svc"},
 				},
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Namespace: "ns", Name: "svc"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{Namespace: "ns", Name: "svc"},
 				},
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Namespace: "ns", Name: "svc"}: {
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -800,6 +802,7 @@ func TestKubeHandlePodDelete(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			k := &AsyncKubeProxyServiceMapper{
+				trustDomain:   identity.ClusterLocalTrustDomain,
 				servicesForCN: test.existingCNsToServices,
 				cnsForService: test.existingServicesToCNs,
 			}
@@ -835,7 +838,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			name:    "add nil service",
 			service: nil,
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -843,7 +846,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -861,7 +864,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "not-svc",
 						Namespace: "ns",
@@ -870,11 +873,11 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "not-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "not-svc",
 						Namespace: "ns",
@@ -883,7 +886,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "not-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "svc", Namespace: "ns"}: {},
 			},
@@ -918,7 +921,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -927,7 +930,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -959,7 +962,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "other-svc",
 						Namespace: "ns",
@@ -968,11 +971,11 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "other-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "other-svc",
 						Namespace: "ns",
@@ -981,7 +984,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "other-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "svc", Namespace: "ns"}: {},
 			},
@@ -1028,13 +1031,13 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
 					},
 				},
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -1043,8 +1046,8 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -1088,7 +1091,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "not-svc",
 						Namespace: "ns",
@@ -1098,7 +1101,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 						Namespace: "ns",
 					},
 				},
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -1111,18 +1114,18 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "not-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "svc", Namespace: "not-ns"}: {
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "not-svc",
 						Namespace: "ns",
@@ -1132,7 +1135,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 						Namespace: "ns",
 					},
 				},
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -1145,14 +1148,14 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "not-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "svc", Namespace: "not-ns"}: {
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -1165,6 +1168,7 @@ func TestKubeHandleServiceUpdate(t *testing.T) {
 			kubeController.EXPECT().ListPods().Return(test.existingPods)
 
 			k := &AsyncKubeProxyServiceMapper{
+				trustDomain:    identity.ClusterLocalTrustDomain,
 				kubeController: kubeController,
 				servicesForCN:  test.existingCNsToServices,
 				cnsForService:  test.existingServicesToCNs,
@@ -1206,7 +1210,7 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 			name:    "delete nil service",
 			service: nil,
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -1215,11 +1219,11 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCnsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -1228,7 +1232,7 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -1241,7 +1245,7 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "not-svc",
 						Namespace: "ns",
@@ -1254,14 +1258,14 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "not-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "svc", Namespace: "not-ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCnsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "not-svc",
 						Namespace: "ns",
@@ -1274,10 +1278,10 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "not-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "svc", Namespace: "not-ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -1290,7 +1294,7 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -1299,11 +1303,11 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCnsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): nil,
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): nil,
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{},
 		},
@@ -1316,7 +1320,7 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 				},
 			},
 			existingCNsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "ns",
@@ -1326,7 +1330,7 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 						Namespace: "not-ns",
 					},
 				},
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "not-svc",
 						Namespace: "ns",
@@ -1339,24 +1343,24 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 			},
 			existingServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "not-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "svc", Namespace: "not-ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 			expectedCnsToServices: map[certificate.CommonName][]service.MeshService{
-				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "svc",
 						Namespace: "not-ns",
 					},
 				},
-				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {
+				envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {
 					{
 						Name:      "not-svc",
 						Namespace: "ns",
@@ -1365,10 +1369,10 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 			},
 			expectedServicesToCNs: map[service.MeshService]map[certificate.CommonName]struct{}{
 				{Name: "not-svc", Namespace: "ns"}: {
-					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid2, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 				{Name: "svc", Namespace: "not-ns"}: {
-					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns"): {},
+					envoy.NewXDSCertCommonName(uid1, envoy.KindSidecar, "svcacc", "ns", identity.ClusterLocalTrustDomain): {},
 				},
 			},
 		},
@@ -1377,6 +1381,7 @@ func TestKubeHandleServiceDelete(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			k := &AsyncKubeProxyServiceMapper{
+				trustDomain:   identity.ClusterLocalTrustDomain,
 				servicesForCN: test.existingCNsToServices,
 				cnsForService: test.existingServicesToCNs,
 			}
@@ -1628,7 +1633,7 @@ func TestGetCertCommonNameForPod(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			assert := tassert.New(t)
-			cn, err := getCertCommonNameForPod(test.pod)
+			cn, err := getCertCommonNameForPod(test.pod, identity.ClusterLocalTrustDomain)
 			if test.shouldErr {
 				assert.Empty(cn)
 				assert.Error(err)
@@ -1644,7 +1649,7 @@ func TestAsyncKubeProxyServiceMapperRace(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	kubeController := k8s.NewMockController(mockCtrl)
 
-	k := NewAsyncKubeProxyServiceMapper(kubeController)
+	k := NewAsyncKubeProxyServiceMapper(kubeController, identity.ClusterLocalTrustDomain)
 
 	stop := make(chan struct{})
 
@@ -1667,7 +1672,7 @@ func TestAsyncKubeProxyServiceMapperRace(t *testing.T) {
 			},
 		},
 	}
-	cn := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, pod.Spec.ServiceAccountName, pod.Namespace)
+	cn := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, pod.Spec.ServiceAccountName, pod.Namespace, identity.ClusterLocalTrustDomain)
 	proxy, err := envoy.NewProxy(cn, "", nil)
 	tassert.NoError(t, err)
 
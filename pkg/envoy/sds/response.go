@@ -6,15 +6,18 @@ import (
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/pkg/errors"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/envoy/secrets"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // NewResponse creates a new Secrets Discovery Response.
@@ -44,7 +47,9 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, request
 	log.Info().Msgf("Creating SDS response for request for resources %v for proxy %s", requestedCerts, proxy.String())
 
 	// 1. Issue a service certificate for this proxy
-	cert, err := certManager.IssueCertificate(certificate.CommonName(s.serviceIdentity), cfg.GetServiceCertValidityPeriod())
+	// The certificate's CN is qualified by the trust domain of this cluster so that peers in other clusters
+	// can tell local and remote identities apart.
+	cert, err := certManager.IssueCertificate(certificate.CommonName(s.serviceIdentity.WithTrustDomain(cfg.GetTrustDomain())), cfg.GetServiceCertValidityPeriod())
 	if err != nil {
 		log.Error().Err(err).Msgf("Error issuing a certificate for proxy %s", proxy.String())
 		return nil, err
@@ -64,6 +69,7 @@ func (s *sdsImpl) getSDSSecrets(cert certificate.Certificater, requestedCerts []
 	// - "service-cert:namespace/service-account"
 	// - "root-cert-for-mtls-outbound:namespace/service"
 	// - "root-cert-for-mtls-inbound:namespace/service-service-account"
+	// - "egress-client-cert:namespace/secret-name"
 
 	// The Envoy makes a request for a list of resources (aka certificates), which we will send as a response to the SDS request.
 	for _, requestedCertificate := range requestedCerts {
@@ -96,6 +102,15 @@ func (s *sdsImpl) getSDSSecrets(cert certificate.Certificater, requestedCerts []
 			}
 			certs = append(certs, envoySecret)
 
+		// A client certificate used to originate TLS to an external host is requested
+		case secrets.EgressClientCertType:
+			envoySecret, err := s.getEgressClientCertSecret(*sdsCert, proxy)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error creating cert %s for proxy %s", requestedCertificate, proxy.String())
+				continue
+			}
+			certs = append(certs, envoySecret)
+
 		default:
 			log.Error().Msgf("Unexpected certificate type %s requested for proxy %s", requestedCertificate, proxy)
 		}
@@ -128,6 +143,50 @@ func getServiceCertSecret(cert certificate.Certificater, name string) (*xds_auth
 	return secret, nil
 }
 
+// getEgressClientCertSecret returns the client certificate referenced by the TLS origination config of the egress
+// policies applied to the proxy. Proxies are only served the client certificates of the policies that apply to them.
+func (s *sdsImpl) getEgressClientCertSecret(sdscert secrets.SDSCert, proxy *envoy.Proxy) (*xds_auth.Secret, error) {
+	var egressTrafficPolicy *trafficpolicy.EgressTrafficPolicy
+	var err error
+	if proxy.Kind() == envoy.KindEgressGateway {
+		egressTrafficPolicy, err = s.meshCatalog.GetEgressGatewayTrafficPolicy()
+	} else {
+		egressTrafficPolicy, err = s.meshCatalog.GetEgressTrafficPolicy(s.serviceIdentity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if egressTrafficPolicy != nil {
+		for _, clusterConfig := range egressTrafficPolicy.ClustersConfigs {
+			tlsConfig := clusterConfig.TLS
+			if tlsConfig == nil || tlsConfig.ClientCertificateSecret != sdscert.Name {
+				continue
+			}
+			return &xds_auth.Secret{
+				// The Name field must match the tls_context.common_tls_context.tls_certificate_sds_secret_configs.name
+				Name: sdscert.String(),
+				Type: &xds_auth.Secret_TlsCertificate{
+					TlsCertificate: &xds_auth.TlsCertificate{
+						CertificateChain: &xds_core.DataSource{
+							Specifier: &xds_core.DataSource_InlineBytes{
+								InlineBytes: tlsConfig.ClientCertificate,
+							},
+						},
+						PrivateKey: &xds_core.DataSource{
+							Specifier: &xds_core.DataSource_InlineBytes{
+								InlineBytes: tlsConfig.ClientKey,
+							},
+						},
+					},
+				},
+			}, nil
+		}
+	}
+
+	return nil, errors.Errorf("Client certificate secret %s is not referenced by the egress policies applied to proxy %s", sdscert.Name, proxy.String())
+}
+
 func (s *sdsImpl) getRootCert(cert certificate.Certificater, sdscert secrets.SDSCert) (*xds_auth.Secret, error) {
 	secret := &xds_auth.Secret{
 		// The Name field must match the tls_context.common_tls_context.tls_certificate_sds_secret_configs.name
@@ -155,15 +214,91 @@ func (s *sdsImpl) getRootCert(cert certificate.Certificater, sdscert secrets.SDS
 		return secret, nil
 	}
 
+	if gatewayIdentity, ok := s.getEgressGatewayIdentity(sdscert); ok {
+		// The egress gateway is deployed in the OSM namespace, which is not a part of the mesh, so its
+		// identity cannot be derived from the upstream service.
+		secret.GetValidationContext().MatchSubjectAltNames = getSubjectAltNamesFromSvcIdentities([]identity.ServiceIdentity{gatewayIdentity})
+		return secret, nil
+	}
+
 	svcIdentitiesInCertRequest, err := getServiceIdentitiesFromCert(sdscert, s.serviceIdentity, s.meshCatalog)
 	if err != nil {
 		return nil, err
 	}
+	svcIdentitiesInCertRequest = s.getUpstreamIdentitiesWithTrustDomains(sdscert, svcIdentitiesInCertRequest)
 
 	secret.GetValidationContext().MatchSubjectAltNames = getSubjectAltNamesFromSvcIdentities(svcIdentitiesInCertRequest)
 	return secret, nil
 }
 
+// getEgressGatewayIdentity returns the identity of the egress gateway if the given outbound root cert request
+// corresponds to the egress gateway service.
+func (s *sdsImpl) getEgressGatewayIdentity(sdscert secrets.SDSCert) (identity.ServiceIdentity, bool) {
+	if sdscert.CertType != secrets.RootCertTypeForMTLSOutbound || !s.cfg.GetFeatureFlags().EnableEgressGateway {
+		return "", false
+	}
+	meshSvc, err := sdscert.GetMeshService()
+	if err != nil {
+		return "", false
+	}
+	osmNamespace := s.cfg.GetOSMNamespace()
+	if meshSvc.Name != constants.EgressGatewayName || meshSvc.Namespace != osmNamespace {
+		return "", false
+	}
+	return identity.K8sServiceAccount{Name: constants.EgressGatewayName, Namespace: osmNamespace}.ToServiceIdentity(s.cfg.GetTrustDomain()), true
+}
+
+// getUpstreamIdentitiesWithTrustDomains qualifies the upstream service identities with the trust domain of the
+// cluster they are expected to be served from. In multicluster mode, an upstream service may also be served by
+// endpoints in remote clusters, whose certificates are issued for the trust domain of the remote cluster.
+func (s *sdsImpl) getUpstreamIdentitiesWithTrustDomains(sdscert secrets.SDSCert, svcIdentities []identity.ServiceIdentity) []identity.ServiceIdentity {
+	localTrustDomain := s.cfg.GetTrustDomain()
+	var qualified []identity.ServiceIdentity
+	for _, si := range svcIdentities {
+		qualified = append(qualified, si.WithTrustDomain(localTrustDomain))
+	}
+
+	if !s.cfg.GetFeatureFlags().EnableMulticlusterMode {
+		return qualified
+	}
+
+	meshSvc, err := sdscert.GetMeshService()
+	if err != nil {
+		return qualified
+	}
+	endpoints, err := s.meshCatalog.ListEndpointsForServiceIdentity(s.serviceIdentity, *meshSvc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing endpoints of upstream service %s for proxy with identity %s", meshSvc, s.serviceIdentity)
+		return qualified
+	}
+
+	remoteTrustDomains := make(map[string]bool)
+	for _, ep := range endpoints {
+		if ep.Zone == "" {
+			continue
+		}
+		remoteTrustDomains[identity.GetTrustDomainForCluster(ep.Zone)] = true
+	}
+	for _, si := range svcIdentities {
+		for trustDomain := range remoteTrustDomains {
+			if remote := si.WithTrustDomain(trustDomain); !containsIdentity(qualified, remote) {
+				qualified = append(qualified, remote)
+			}
+		}
+	}
+
+	return qualified
+}
+
+func containsIdentity(svcIdentities []identity.ServiceIdentity, si identity.ServiceIdentity) bool {
+	for _, existing := range svcIdentities {
+		if existing == si {
+			return true
+		}
+	}
+	return false
+}
+
 // Given a requested SDS Cert, this function returns the Service Identities, which match that SDS Cert
 // Example: given "service-cert:namespace/service-account", this will return ServiceIdentity("namespace.service-account.cluster.local")
 func getServiceIdentitiesFromCert(sdscert secrets.SDSCert, serviceIdentity identity.ServiceIdentity, meshCatalog catalog.MeshCataloger) ([]identity.ServiceIdentity, error) {
@@ -195,7 +330,7 @@ func getServiceIdentitiesFromCert(sdscert secrets.SDSCert, serviceIdentity ident
 			return nil, err
 		}
 
-		if svcAccountInRequest.ToServiceIdentity() != serviceIdentity {
+		if *svcAccountInRequest != serviceIdentity.ToK8sServiceAccount() {
 			log.Error().Err(errCertMismatch).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrSDSCertMismatch)).
 				Msgf("Request for SDS cert %s does not belong to proxy with identity %s", sdscert.Name, serviceIdentity)
 			return nil, errCertMismatch
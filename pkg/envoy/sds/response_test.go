@@ -2,6 +2,7 @@ package sds
 
 import (
 	"fmt"
+	"net"
 	"testing"
 
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
@@ -15,13 +16,16 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy/secrets"
 	configFake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // TestNewResponse sets up a fake kube client, then a pod and makes an SDS request,
@@ -108,7 +112,7 @@ func TestGetRootCert(t *testing.T) {
 				Name:     "ns-1/sa-1",
 				CertType: secrets.RootCertTypeForMTLSInbound,
 			},
-			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			prepare: func(d *dynamicMock) {
 				d.mockCertificater.EXPECT().GetIssuingCA().Return([]byte("foo")).Times(1)
@@ -127,18 +131,20 @@ func TestGetRootCert(t *testing.T) {
 				Name:     "ns-2/service-2",
 				CertType: secrets.RootCertTypeForMTLSOutbound,
 			},
-			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			prepare: func(d *dynamicMock) {
 				associatedSvcAccounts := []identity.ServiceIdentity{
-					identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-2"}.ToServiceIdentity(),
-					identity.K8sServiceAccount{Name: "sa-3", Namespace: "ns-2"}.ToServiceIdentity(),
+					identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-2"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+					identity.K8sServiceAccount{Name: "sa-3", Namespace: "ns-2"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 				}
 				d.mockCatalog.EXPECT().ListServiceIdentitiesForService(service.MeshService{
 					Name:      "service-2",
 					Namespace: "ns-2",
 				}).Return(associatedSvcAccounts, nil).Times(1)
 				d.mockCertificater.EXPECT().GetIssuingCA().Return([]byte("foo")).Times(1)
+				d.mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).Times(1)
+				d.mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{}).Times(2)
 			},
 
 			// expectations
@@ -146,6 +152,62 @@ func TestGetRootCert(t *testing.T) {
 			expectError:  false,
 		},
 		// Test case 2 end -------------------------------
+
+		// Test case 3: tests SDS secret for outbound TLS secret with remote cluster endpoints -------------------------------
+		{
+			name: "test outbound MTLS certificate validation with multicluster endpoints",
+			sdsCert: secrets.SDSCert{
+				Name:     "ns-2/service-2",
+				CertType: secrets.RootCertTypeForMTLSOutbound,
+			},
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+
+			prepare: func(d *dynamicMock) {
+				svc := service.MeshService{
+					Name:      "service-2",
+					Namespace: "ns-2",
+				}
+				associatedSvcAccounts := []identity.ServiceIdentity{
+					identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-2"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+				}
+				d.mockCatalog.EXPECT().ListServiceIdentitiesForService(svc).Return(associatedSvcAccounts, nil).Times(1)
+				d.mockCatalog.EXPECT().ListEndpointsForServiceIdentity(identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain), svc).
+					Return([]endpoint.Endpoint{
+						{IP: net.ParseIP("10.0.0.1"), Port: 80},
+						{IP: net.ParseIP("1.1.1.1"), Port: 15443, Zone: "east"},
+					}, nil).Times(1)
+				d.mockCertificater.EXPECT().GetIssuingCA().Return([]byte("foo")).Times(1)
+				d.mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).Times(1)
+				d.mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{EnableMulticlusterMode: true}).Times(2)
+			},
+
+			// expectations
+			expectedSANs: []string{"sa-2.ns-2.cluster.local", "sa-2.ns-2.east.cluster.local"},
+			expectError:  false,
+		},
+		// Test case 3 end -------------------------------
+
+		// Test case 4: tests SDS secret for outbound TLS secret to the egress gateway -------------------------------
+		{
+			name: "test outbound MTLS certificate validation for the egress gateway",
+			sdsCert: secrets.SDSCert{
+				Name:     "osm-system/osm-egress-gateway",
+				CertType: secrets.RootCertTypeForMTLSOutbound,
+			},
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+
+			prepare: func(d *dynamicMock) {
+				d.mockCertificater.EXPECT().GetIssuingCA().Return([]byte("foo")).Times(1)
+				d.mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).Times(1)
+				d.mockConfigurator.EXPECT().GetOSMNamespace().Return("osm-system").Times(1)
+				d.mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{EnableEgressGateway: true}).Times(1)
+			},
+
+			// expectations
+			expectedSANs: []string{"osm-egress-gateway.osm-system.cluster.local"},
+			expectError:  false,
+		},
+		// Test case 4 end -------------------------------
 	}
 
 	for i, tc := range testCases {
@@ -178,7 +240,7 @@ func TestGetRootCert(t *testing.T) {
 			sdsSecret, err := s.getRootCert(d.mockCertificater, tc.sdsCert)
 			assert.Equal(err != nil, tc.expectError)
 
-			if err != nil {
+			if err == nil {
 				actualSANs := subjectAltNamesToStr(sdsSecret.GetValidationContext().GetMatchSubjectAltNames())
 				assert.ElementsMatch(actualSANs, tc.expectedSANs)
 			}
@@ -259,7 +321,7 @@ func TestGetSDSSecrets(t *testing.T) {
 		// Test case 1: root-cert-for-mtls-inbound requested -------------------------------
 		{
 			name:            "test root-cert-for-mtls-inbound cert type request",
-			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			prepare: func(d *dynamicMock) {
 				d.mockCertificater.EXPECT().GetIssuingCA().Return([]byte("foo")).Times(1)
@@ -277,12 +339,12 @@ func TestGetSDSSecrets(t *testing.T) {
 		// Test case 2: root-cert-for-mtls-outbound requested -------------------------------
 		{
 			name:            "test root-cert-for-mtls-outbound cert type request",
-			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			prepare: func(d *dynamicMock) {
 				associatedSvcAccounts := []identity.ServiceIdentity{
-					identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-2"}.ToServiceIdentity(),
-					identity.K8sServiceAccount{Name: "sa-3", Namespace: "ns-2"}.ToServiceIdentity(),
+					identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-2"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+					identity.K8sServiceAccount{Name: "sa-3", Namespace: "ns-2"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 				}
 				svc := service.MeshService{
 					Name:      "service-2",
@@ -290,6 +352,8 @@ func TestGetSDSSecrets(t *testing.T) {
 				}
 				d.mockCatalog.EXPECT().ListServiceIdentitiesForService(svc).Return(associatedSvcAccounts, nil).Times(1)
 				d.mockCertificater.EXPECT().GetIssuingCA().Return([]byte("foo")).Times(1)
+				d.mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).Times(1)
+				d.mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{}).Times(2)
 			},
 
 			sdsCertType:    secrets.RootCertTypeForMTLSOutbound,
@@ -304,7 +368,7 @@ func TestGetSDSSecrets(t *testing.T) {
 		// Test case 3: service-cert requested -------------------------------
 		{
 			name:            "test service-cert cert type request",
-			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			prepare: func(d *dynamicMock) {
 				d.mockCertificater.EXPECT().GetCertificateChain().Return([]byte("foo")).Times(1)
@@ -323,7 +387,7 @@ func TestGetSDSSecrets(t *testing.T) {
 		// Test case 4: invalid cert type requested -------------------------------
 		{
 			name:            "test invalid cert type request",
-			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 
 			prepare: nil,
 
@@ -335,6 +399,50 @@ func TestGetSDSSecrets(t *testing.T) {
 			expectedSecretCount: 0, // error is logged and no SDS secret is created
 		},
 		// Test case 4 end -------------------------------
+
+		// Test case 5: egress-client-cert referenced by the proxy's egress policy requested -------------------------------
+		{
+			name:            "test egress-client-cert cert type request",
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+
+			prepare: func(d *dynamicMock) {
+				d.mockCatalog.EXPECT().GetEgressTrafficPolicy(identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)).Return(&trafficpolicy.EgressTrafficPolicy{
+					ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
+						{Name: "foo.com:80", Host: "foo.com", Port: 80},
+						{Name: "bar.com:80", Host: "bar.com", Port: 80, TLS: &trafficpolicy.EgressTLSConfig{
+							CABundle:                []byte("ca"),
+							ClientCertificate:       []byte("cert"),
+							ClientKey:               []byte("key"),
+							ClientCertificateSecret: "ns-1/client",
+						}},
+					},
+				}, nil).Times(1)
+			},
+
+			sdsCertType:    secrets.EgressClientCertType,
+			requestedCerts: []string{"egress-client-cert:ns-1/client"},
+
+			// expectations
+			expectedSecretCount: 1,
+		},
+		// Test case 5 end -------------------------------
+
+		// Test case 6: egress-client-cert not referenced by the proxy's egress policy requested -------------------------------
+		{
+			name:            "test egress-client-cert cert type request for a secret of another policy",
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+
+			prepare: func(d *dynamicMock) {
+				d.mockCatalog.EXPECT().GetEgressTrafficPolicy(identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)).Return(nil, nil).Times(1)
+			},
+
+			sdsCertType:    secrets.EgressClientCertType,
+			requestedCerts: []string{"egress-client-cert:ns-2/client"},
+
+			// expectations
+			expectedSecretCount: 0, // error is logged and no SDS secret is created
+		},
+		// Test case 6 end -------------------------------
 	}
 
 	for i, tc := range testCases {
@@ -393,6 +501,11 @@ func TestGetSDSSecrets(t *testing.T) {
 			case secrets.ServiceCertType:
 				assert.NotNil(sdsSecret.GetTlsCertificate().GetCertificateChain().GetInlineBytes())
 				assert.NotNil(sdsSecret.GetTlsCertificate().GetPrivateKey().GetInlineBytes())
+
+			case secrets.EgressClientCertType:
+				assert.Equal(tc.requestedCerts[0], sdsSecret.Name)
+				assert.Equal([]byte("cert"), sdsSecret.GetTlsCertificate().GetCertificateChain().GetInlineBytes())
+				assert.Equal([]byte("key"), sdsSecret.GetTlsCertificate().GetPrivateKey().GetInlineBytes())
 			}
 		})
 	}
@@ -407,8 +520,8 @@ func TestGetSubjectAltNamesFromSvcAccount(t *testing.T) {
 	testCases := []testCase{
 		{
 			serviceIdentities: []identity.ServiceIdentity{
-				identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
-				identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-2"}.ToServiceIdentity(),
+				identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+				identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-2"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
 			},
 			expectedSANMatchers: []*xds_matcher.StringMatcher{
 				{
@@ -43,6 +43,9 @@ const (
 
 	// RootCertTypeForMTLSInbound is the prefix for the mTLS root certificate resource name for downstream connectivity. Example: "root-cert-for-mtls-inbound:ns/name"
 	RootCertTypeForMTLSInbound SDSCertType = "root-cert-for-mtls-inbound"
+
+	// EgressClientCertType is the prefix for the client certificate resource name used to originate TLS to an external host. Example: "egress-client-cert:ns/secret-name"
+	EgressClientCertType SDSCertType = "egress-client-cert"
 )
 
 // Defines valid cert types
@@ -50,4 +53,5 @@ var validCertTypes = map[SDSCertType]struct{}{
 	ServiceCertType:             {},
 	RootCertTypeForMTLSOutbound: {},
 	RootCertTypeForMTLSInbound:  {},
+	EgressClientCertType:        {},
 }
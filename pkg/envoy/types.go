@@ -3,6 +3,8 @@
 package envoy
 
 import (
+	"time"
+
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -91,6 +93,12 @@ const (
 	// The local cluster refers to the cluster corresponding to the service the proxy is fronting, accessible over localhost by the proxy.
 	localClusterSuffix = "-local"
 
+	// edsClusterSuffix and dnsClusterSuffix are the tags to append to the names of the EDS and DNS clusters aggregated
+	// by a service cluster whose remote gateways are partly addressed by hostname. The '|' delimiter is not allowed in
+	// Kubernetes names, which keeps these names distinct from the names of other service clusters.
+	edsClusterSuffix = "|eds"
+	dnsClusterSuffix = "|dns"
+
 	// EnvoyActiveHealthCheckPath is the HTTP endpoint to be used to receive
 	// active health checks.
 	EnvoyActiveHealthCheckPath = "/healthz/osm"
@@ -109,4 +117,25 @@ const (
 
 	// KindGateway implies the proxy is a gateway
 	KindGateway ProxyKind = "gateway"
+
+	// KindEgressGateway implies the proxy is an egress gateway
+	KindEgressGateway ProxyKind = "egress-gateway"
 )
+
+// NACK describes a configuration rejected by an Envoy proxy
+type NACK struct {
+	// TypeURI is the TypeURI of the rejected configuration
+	TypeURI TypeURI `json:"typeURI"`
+
+	// Nonce is the nonce of the rejected response
+	Nonce string `json:"nonce"`
+
+	// AppliedVersion is the version of the configuration the proxy kept applied
+	AppliedVersion string `json:"appliedVersion"`
+
+	// Error is the error detail reported by the proxy
+	Error string `json:"error"`
+
+	// Timestamp is the time the NACK was received
+	Timestamp time.Time `json:"timestamp"`
+}
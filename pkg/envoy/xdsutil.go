@@ -313,6 +313,24 @@ func GetLocalClusterNameForServiceCluster(clusterName string) string {
 	return fmt.Sprintf("%s%s", clusterName, localClusterSuffix)
 }
 
+// GetEDSClusterNameForServiceCluster returns the name of the EDS cluster of the local and IP addressed endpoints
+// of the given service cluster, when the service cluster aggregates it with a DNS cluster
+func GetEDSClusterNameForServiceCluster(clusterName string) string {
+	return fmt.Sprintf("%s%s", clusterName, edsClusterSuffix)
+}
+
+// GetDNSClusterNameForServiceCluster returns the name of the DNS cluster of the remote gateways addressed by
+// hostname of the given service cluster, when the service cluster aggregates it with an EDS cluster
+func GetDNSClusterNameForServiceCluster(clusterName string) string {
+	return fmt.Sprintf("%s%s", clusterName, dnsClusterSuffix)
+}
+
+// GetServiceClusterNameForEDSCluster returns the name of the service cluster aggregating the given EDS cluster, or
+// the given cluster name when it is not the name of an aggregated EDS cluster
+func GetServiceClusterNameForEDSCluster(clusterName string) string {
+	return strings.TrimSuffix(clusterName, edsClusterSuffix)
+}
+
 // certificateCommonNameMeta is the type that stores the metadata present in the CommonName field in a proxy's certificate
 type certificateCommonNameMeta struct {
 	ProxyUUID uuid.UUID
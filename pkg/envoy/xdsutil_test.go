@@ -109,7 +109,7 @@ var _ = Describe("Test Envoy tools", func() {
 	Context("Test GetDownstreamTLSContext()", func() {
 		It("should return TLS context", func() {
 			svcAccount := identity.K8sServiceAccount{Name: "foo", Namespace: "test"}
-			tlsContext := GetDownstreamTLSContext(svcAccount.ToServiceIdentity(), true)
+			tlsContext := GetDownstreamTLSContext(svcAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain), true)
 
 			expectedTLSContext := &auth.DownstreamTlsContext{
 				CommonTlsContext: &auth.CommonTlsContext{
@@ -86,6 +86,15 @@ const (
 
 	// ErrInvalidSourceKind	indicated an applied SMI TrafficTarget policy has an invalid source kind
 	ErrInvalidSourceKind
+
+	// ErrGettingEgressTLSSecret indicates the secret referenced in the TLS origination config of an egress policy could not be retrieved
+	ErrGettingEgressTLSSecret
+
+	// ErrEgressUnsupportedByGateway indicates an egress policy allows traffic the egress gateway cannot proxy
+	ErrEgressUnsupportedByGateway
+
+	// ErrGettingEnvoyFilterExtension indicates the Envoy filter extension of an EnvoyFilterExtension resource could not be built
+	ErrGettingEnvoyFilterExtension
 )
 
 // Range 3000-3500 is reserved for errors related to k8s constructs (service accounts, namespaces, etc.)
@@ -306,6 +315,9 @@ const (
 
 	// ErrSDSCertMismatch indicates the indentity obtained from the SDSCert request does not match the identity of the proxy
 	ErrSDSCertMismatch
+
+	// ErrUnsupportedTracingProvider indicates the tracing provider configured in the MeshConfig is not supported by the sidecar
+	ErrUnsupportedTracingProvider
 )
 
 // Range 6000-6500 reserved for errors related to the OSM Injector
@@ -509,6 +521,29 @@ An applied SMI TrafficTarget policy has an invalid source kind.
 	ErrGettingInboundTrafficTargets: `
 The inbound TrafficTargets composed of their routes for a given destination
 ServiceIdentity could not be configured.
+`,
+
+	ErrGettingEgressTLSSecret: `
+The secret referenced in the TLS origination configuration of an egress policy
+could not be retrieved or is missing the expected keys.
+The HTTP routes for the associated egress policy and port were ignored by the system.
+Please verify that the referenced secrets exist and contain the 'ca.crt' key, and the
+'tls.crt' and 'tls.key' keys for a client certificate.
+`,
+
+	ErrEgressUnsupportedByGateway: `
+An egress policy allows HTTPS or TCP traffic, or HTTP traffic to a wildcard host, while the
+egress gateway is enabled. The egress gateway only proxies HTTP traffic to non-wildcard hosts,
+so this traffic is not allowed, as it would otherwise leave the mesh from the sidecars directly.
+The associated egress policy ports and hosts were ignored by the system.
+`,
+
+	ErrGettingEnvoyFilterExtension: `
+The Envoy filter extension of an EnvoyFilterExtension resource could not be built, either
+because its WASM module could not be read from the referenced ConfigMap, or because the
+referenced image is still being pulled or could not be pulled.
+The extension was not added to the HTTP filter chains of the proxies until it can be built.
+Failed image pulls are retried in the background with an exponential backoff.
 `,
 
 	//
@@ -825,6 +860,12 @@ The SDS secret does not correspond to a ServiceAccount.
 The identity obtained from the SDS certificate request does not match the
 identity of the proxy.
 The corresponding certificate request was ignored by the system.
+`,
+
+	ErrUnsupportedTracingProvider: `
+The tracing provider configured in the MeshConfig is not supported by the Envoy version
+of the sidecar. The supported providers are 'zipkin', 'datadog' and 'opentelemetry'.
+Tracing was disabled on the sidecars until a supported provider is configured.
 `,
 
 	//
@@ -33,6 +33,10 @@ func (c *FakeConfigV1alpha1) MultiClusterServices(namespace string) v1alpha1.Mul
 	return &FakeMultiClusterServices{c, namespace}
 }
 
+func (c *FakeConfigV1alpha1) RemoteClusters(namespace string) v1alpha1.RemoteClusterInterface {
+	return &FakeRemoteClusters{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeConfigV1alpha1) RESTClient() rest.Interface {
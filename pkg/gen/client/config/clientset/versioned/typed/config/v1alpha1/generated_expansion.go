@@ -18,3 +18,5 @@ package v1alpha1
 type MeshConfigExpansion interface{}
 
 type MultiClusterServiceExpansion interface{}
+
+type RemoteClusterExpansion interface{}
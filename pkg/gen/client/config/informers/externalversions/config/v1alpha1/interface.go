@@ -25,6 +25,8 @@ type Interface interface {
 	MeshConfigs() MeshConfigInformer
 	// MultiClusterServices returns a MultiClusterServiceInformer.
 	MultiClusterServices() MultiClusterServiceInformer
+	// RemoteClusters returns a RemoteClusterInformer.
+	RemoteClusters() RemoteClusterInformer
 }
 
 type version struct {
@@ -47,3 +49,8 @@ func (v *version) MeshConfigs() MeshConfigInformer {
 func (v *version) MultiClusterServices() MultiClusterServiceInformer {
 	return &multiClusterServiceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// RemoteClusters returns a RemoteClusterInformer.
+func (v *version) RemoteClusters() RemoteClusterInformer {
+	return &remoteClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -30,3 +30,11 @@ type MultiClusterServiceListerExpansion interface{}
 // MultiClusterServiceNamespaceListerExpansion allows custom methods to be added to
 // MultiClusterServiceNamespaceLister.
 type MultiClusterServiceNamespaceListerExpansion interface{}
+
+// RemoteClusterListerExpansion allows custom methods to be added to
+// RemoteClusterLister.
+type RemoteClusterListerExpansion interface{}
+
+// RemoteClusterNamespaceListerExpansion allows custom methods to be added to
+// RemoteClusterNamespaceLister.
+type RemoteClusterNamespaceListerExpansion interface{}
@@ -25,10 +25,18 @@ type FakePolicyV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakePolicyV1alpha1) AuthorizationPolicies(namespace string) v1alpha1.AuthorizationPolicyInterface {
+	return &FakeAuthorizationPolicies{c, namespace}
+}
+
 func (c *FakePolicyV1alpha1) Egresses(namespace string) v1alpha1.EgressInterface {
 	return &FakeEgresses{c, namespace}
 }
 
+func (c *FakePolicyV1alpha1) EnvoyFilterExtensions(namespace string) v1alpha1.EnvoyFilterExtensionInterface {
+	return &FakeEnvoyFilterExtensions{c, namespace}
+}
+
 func (c *FakePolicyV1alpha1) IngressBackends(namespace string) v1alpha1.IngressBackendInterface {
 	return &FakeIngressBackends{c, namespace}
 }
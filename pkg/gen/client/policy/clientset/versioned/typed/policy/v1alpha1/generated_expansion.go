@@ -15,6 +15,10 @@ limitations under the License.
 
 package v1alpha1
 
+type AuthorizationPolicyExpansion interface{}
+
+type EnvoyFilterExtensionExpansion interface{}
+
 type EgressExpansion interface{}
 
 type IngressBackendExpansion interface{}
@@ -23,7 +23,9 @@ import (
 
 type PolicyV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	AuthorizationPoliciesGetter
 	EgressesGetter
+	EnvoyFilterExtensionsGetter
 	IngressBackendsGetter
 }
 
@@ -32,10 +34,18 @@ type PolicyV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *PolicyV1alpha1Client) AuthorizationPolicies(namespace string) AuthorizationPolicyInterface {
+	return newAuthorizationPolicies(c, namespace)
+}
+
 func (c *PolicyV1alpha1Client) Egresses(namespace string) EgressInterface {
 	return newEgresses(c, namespace)
 }
 
+func (c *PolicyV1alpha1Client) EnvoyFilterExtensions(namespace string) EnvoyFilterExtensionInterface {
+	return newEnvoyFilterExtensions(c, namespace)
+}
+
 func (c *PolicyV1alpha1Client) IngressBackends(namespace string) IngressBackendInterface {
 	return newIngressBackends(c, namespace)
 }
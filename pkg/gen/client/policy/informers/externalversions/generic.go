@@ -50,8 +50,12 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=policy.openservicemesh.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("authorizationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().AuthorizationPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("egresses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().Egresses().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("envoyfilterextensions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().EnvoyFilterExtensions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("ingressbackends"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().IngressBackends().Informer()}, nil
 
@@ -21,8 +21,12 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AuthorizationPolicies returns a AuthorizationPolicyInformer.
+	AuthorizationPolicies() AuthorizationPolicyInformer
 	// Egresses returns a EgressInformer.
 	Egresses() EgressInformer
+	// EnvoyFilterExtensions returns a EnvoyFilterExtensionInformer.
+	EnvoyFilterExtensions() EnvoyFilterExtensionInformer
 	// IngressBackends returns a IngressBackendInformer.
 	IngressBackends() IngressBackendInformer
 }
@@ -38,11 +42,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AuthorizationPolicies returns a AuthorizationPolicyInformer.
+func (v *version) AuthorizationPolicies() AuthorizationPolicyInformer {
+	return &authorizationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Egresses returns a EgressInformer.
 func (v *version) Egresses() EgressInformer {
 	return &egressInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// EnvoyFilterExtensions returns a EnvoyFilterExtensionInformer.
+func (v *version) EnvoyFilterExtensions() EnvoyFilterExtensionInformer {
+	return &envoyFilterExtensionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // IngressBackends returns a IngressBackendInformer.
 func (v *version) IngressBackends() IngressBackendInformer {
 	return &ingressBackendInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
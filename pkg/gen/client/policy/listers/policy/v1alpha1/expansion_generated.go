@@ -15,6 +15,14 @@ limitations under the License.
 
 package v1alpha1
 
+// AuthorizationPolicyListerExpansion allows custom methods to be added to
+// AuthorizationPolicyLister.
+type AuthorizationPolicyListerExpansion interface{}
+
+// AuthorizationPolicyNamespaceListerExpansion allows custom methods to be added to
+// AuthorizationPolicyNamespaceLister.
+type AuthorizationPolicyNamespaceListerExpansion interface{}
+
 // EgressListerExpansion allows custom methods to be added to
 // EgressLister.
 type EgressListerExpansion interface{}
@@ -23,6 +31,14 @@ type EgressListerExpansion interface{}
 // EgressNamespaceLister.
 type EgressNamespaceListerExpansion interface{}
 
+// EnvoyFilterExtensionListerExpansion allows custom methods to be added to
+// EnvoyFilterExtensionLister.
+type EnvoyFilterExtensionListerExpansion interface{}
+
+// EnvoyFilterExtensionNamespaceListerExpansion allows custom methods to be added to
+// EnvoyFilterExtensionNamespaceLister.
+type EnvoyFilterExtensionNamespaceListerExpansion interface{}
+
 // IngressBackendListerExpansion allows custom methods to be added to
 // IngressBackendLister.
 type IngressBackendListerExpansion interface{}
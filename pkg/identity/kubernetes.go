@@ -11,6 +11,11 @@ const (
 	identityDelimiter = "."
 )
 
+// GetTrustDomainForCluster returns the trust domain of the service identities in the given remote cluster
+func GetTrustDomainForCluster(clusterName string) string {
+	return strings.Join([]string{clusterName, ClusterLocalTrustDomain}, identityDelimiter)
+}
+
 // GetKubernetesServiceIdentity returns the ServiceIdentity based on Kubernetes ServiceAccount and a trust domain
 func GetKubernetesServiceIdentity(svcAccount K8sServiceAccount, trustDomain string) ServiceIdentity {
 	si := strings.Join([]string{svcAccount.Name, svcAccount.Namespace, trustDomain}, identityDelimiter)
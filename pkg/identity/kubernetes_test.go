@@ -38,3 +38,8 @@ func TestGetKubernetesServiceIdentity(t *testing.T) {
 
 	assert.Equal(ServiceIdentity("foo").String(), "foo")
 }
+
+func TestGetTrustDomainForCluster(t *testing.T) {
+	assert := tassert.New(t)
+	assert.Equal("east.cluster.local", GetTrustDomainForCluster("east"))
+}
@@ -28,6 +28,23 @@ func (si ServiceIdentity) IsWildcard() bool {
 	return si == WildcardServiceIdentity
 }
 
+// GetTrustDomain returns the trust domain of the ServiceIdentity, ie. the part following <ServiceAccount>.<Namespace>
+func (si ServiceIdentity) GetTrustDomain() string {
+	chunks := strings.SplitN(si.String(), identityDelimiter, 3)
+	if len(chunks) < 3 {
+		return ""
+	}
+	return chunks[2]
+}
+
+// WithTrustDomain returns the ServiceIdentity with its trust domain replaced by the given trust domain
+func (si ServiceIdentity) WithTrustDomain(trustDomain string) ServiceIdentity {
+	if si.IsWildcard() || si.GetTrustDomain() == trustDomain {
+		return si
+	}
+	return GetKubernetesServiceIdentity(si.ToK8sServiceAccount(), trustDomain)
+}
+
 // ToK8sServiceAccount converts a ServiceIdentity to a K8sServiceAccount to help with transition from K8sServiceAccount to ServiceIdentity
 func (si ServiceIdentity) ToK8sServiceAccount() K8sServiceAccount {
 	// By convention as of release-v0.8 ServiceIdentity is in the format: <ServiceAccount>.<Namespace>.cluster.local
@@ -52,8 +69,8 @@ func (sa K8sServiceAccount) String() string {
 	return fmt.Sprintf("%s%s%s", sa.Namespace, namespaceNameSeparator, sa.Name)
 }
 
-// ToServiceIdentity converts K8sServiceAccount to the newer ServiceIdentity
+// ToServiceIdentity converts K8sServiceAccount to the newer ServiceIdentity in the given trust domain
 // TODO(draychev): ToServiceIdentity is used in many places to ease with transition from K8sServiceAccount to ServiceIdentity and should be removed (not everywhere) - [https://github.com/openservicemesh/osm/issues/2218]
-func (sa K8sServiceAccount) ToServiceIdentity() ServiceIdentity {
-	return ServiceIdentity(fmt.Sprintf("%s.%s.%s", sa.Name, sa.Namespace, ClusterLocalTrustDomain))
+func (sa K8sServiceAccount) ToServiceIdentity(trustDomain string) ServiceIdentity {
+	return GetKubernetesServiceIdentity(sa, trustDomain)
 }
@@ -21,6 +21,16 @@ func TestServiceIdentityType(t *testing.T) {
 
 	// Test ToK8sServiceAccount()
 	assert.Equal(K8sServiceAccount{Name: "foo", Namespace: "bar"}, si.ToK8sServiceAccount())
+
+	// Test GetTrustDomain()
+	assert.Equal("cluster.local", si.GetTrustDomain())
+	assert.Equal("east.cluster.local", ServiceIdentity("foo.bar.east.cluster.local").GetTrustDomain())
+	assert.Equal("", wildcard.GetTrustDomain())
+
+	// Test WithTrustDomain()
+	assert.Equal(ServiceIdentity("foo.bar.east.cluster.local"), si.WithTrustDomain("east.cluster.local"))
+	assert.Equal(si, ServiceIdentity("foo.bar.east.cluster.local").WithTrustDomain("cluster.local"))
+	assert.Equal(wildcard, wildcard.WithTrustDomain("east.cluster.local"))
 }
 
 func TestK8sServiceAccountType(t *testing.T) {
@@ -31,5 +41,5 @@ func TestK8sServiceAccountType(t *testing.T) {
 	assert.Equal("bar/foo", svcAccount.String())
 
 	// Test ToServiceIdentity
-	assert.Equal(ServiceIdentity("foo.bar.cluster.local"), svcAccount.ToServiceIdentity())
+	assert.Equal(ServiceIdentity("foo.bar.cluster.local"), svcAccount.ToServiceIdentity(ClusterLocalTrustDomain))
 }
@@ -1,7 +1,6 @@
 package ingress
 
 import (
-	"github.com/pkg/errors"
 	networkingV1 "k8s.io/api/networking/v1"
 	networkingV1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,6 +10,7 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	gwapiClientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -29,7 +29,8 @@ const (
 var candidateVersions = []string{networkingV1.SchemeGroupVersion.String(), networkingV1beta1.SchemeGroupVersion.String()}
 
 // NewIngressClient implements ingress.Monitor and creates the Kubernetes client to monitor Ingress resources.
-func NewIngressClient(kubeClient kubernetes.Interface, kubeController k8s.Controller, stop chan struct{}, cfg configurator.Configurator, certProvider certificate.Manager) (Monitor, error) {
+// If the k8s API server serves the Gateway API, Gateway and HTTPRoute resources are monitored as well.
+func NewIngressClient(kubeClient kubernetes.Interface, gatewayClient gwapiClientset.Interface, kubeController k8s.Controller, stop chan struct{}, cfg configurator.Configurator, certProvider certificate.Manager) (Monitor, error) {
 	supportedIngressVersions, err := getSupportedIngressVersions(kubeClient.Discovery())
 	if err != nil {
 		// TODO: Need to push metric?
@@ -62,6 +63,7 @@ func NewIngressClient(kubeClient kubernetes.Interface, kubeController k8s.Contro
 
 	c := client{
 		kubeClient:     kubeClient,
+		gatewayClient:  gatewayClient,
 		kubeController: kubeController,
 		cfg:            cfg,
 		certProvider:   certProvider,
@@ -79,16 +81,16 @@ func NewIngressClient(kubeClient kubernetes.Interface, kubeController k8s.Contro
 		c.informerV1beta1.AddEventHandler(k8s.GetKubernetesEventHandlers("IngressV1beta1", "Kubernetes", shouldObserve, ingressEventTypes))
 	}
 
+	if gatewayAPISupported(kubeClient.Discovery()) {
+		c.initGatewayAPIInformers(shouldObserve)
+	}
+
 	if err := c.run(stop); err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.ErrStartingIngressClient.String()).
 			Msg("Could not start Kubernetes Ingress client")
 		return nil, err
 	}
 
-	if err := c.provisionIngressGatewayCert(stop); err != nil {
-		return nil, errors.Wrap(err, "Error provisioning ingress gateway certificate")
-	}
-
 	return c, nil
 }
 
@@ -104,6 +106,8 @@ func (c *client) run(stop <-chan struct{}) error {
 	informerCollection := map[string]cache.SharedIndexInformer{
 		"IngressV1":      c.informerV1,
 		"IngressV1beta1": c.informerV1beta1,
+		"Gateway":        c.gatewayInformer,
+		"HTTPRoute":      c.routeInformer,
 	}
 
 	var pendingCacheSync []cache.InformerSynced
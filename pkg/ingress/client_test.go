@@ -16,8 +16,8 @@ import (
 	fakeDiscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/pointer"
+	gwapiFake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 
-	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -315,23 +315,12 @@ func TestGetIngressNetworkingV1AndVebeta1(t *testing.T) {
 				},
 			}
 
-			// Mock calls for ingress gateway cert provisioning
-			mockConfigurator.EXPECT().GetMeshConfig().Return(&configv1alpha1.MeshConfig{
-				Spec: configv1alpha1.MeshConfigSpec{
-					Certificate: configv1alpha1.CertificateSpec{
-						IngressGateway: nil,
-					},
-				},
-			}).Times(1)
-
-			// pub-sub is implemented as a singleton, so even though this test does not require to issue any
-			// certificates, the gorutine it spawns could be triggered by another test within the suite.
 			fakeCertProvider := tresor.NewFakeCertManager(mockConfigurator)
 
 			stopChan := make(chan struct{})
 			defer close(stopChan)
 
-			c, err := NewIngressClient(fakeClient, mockKubeController, stopChan, mockConfigurator, fakeCertProvider)
+			c, err := NewIngressClient(fakeClient, gwapiFake.NewSimpleClientset(), mockKubeController, stopChan, mockConfigurator, fakeCertProvider)
 			assert.Nil(err)
 
 			switch tc.version {
@@ -9,14 +9,28 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
 	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/k8s/events"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
+// ProvisionIngressGatewayCert provisions the ingress gateway certificate and secret referenced in the MeshConfig,
+// and keeps them up to date until the given stop channel is closed. Only a single controller replica should
+// provision the certificate, as the secret is shared.
+func ProvisionIngressGatewayCert(kubeClient kubernetes.Interface, cfg configurator.Configurator, certProvider certificate.Manager, stop <-chan struct{}) error {
+	c := client{
+		kubeClient:   kubeClient,
+		cfg:          cfg,
+		certProvider: certProvider,
+	}
+	return c.provisionIngressGatewayCert(stop)
+}
+
 // provisionIngressGatewayCert does the following:
 // 1. If an ingress gateway certificate spec is specified in the MeshConfig resource, issues a certificate
 //    for it and stores it in the referenced secret.
@@ -105,8 +119,10 @@ func (c client) storeCertInSecret(cert certificate.Certificater, secret corev1.S
 // when the corresponding gateway certificate is rotated.
 func (c client) handleCertificateChange(currentCertSpec *configv1alpha1.IngressGatewayCertSpec, stop <-chan struct{}) {
 	meshConfigUpdated := events.Subscribe(announcements.MeshConfigUpdated)
+	defer events.Unsub(meshConfigUpdated)
 
 	certRotated := events.Subscribe(announcements.CertificateRotated)
+	defer events.Unsub(certRotated)
 
 	for {
 		select {
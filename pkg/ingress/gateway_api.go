@@ -0,0 +1,212 @@
+package ingress
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/discovery"
+	gwapiV1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+	gwapiInformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// gatewayKind denotes the Kind attribute of the Gateway API Gateway resource
+	gatewayKind = "Gateway"
+
+	// httpRouteKind denotes the Kind attribute of the Gateway API HTTPRoute resource
+	httpRouteKind = "HTTPRoute"
+)
+
+// gatewayAPISupported returns true if the k8s API server serves the Gateway and HTTPRoute resources of the Gateway API.
+// GRPCRoute is not part of the Gateway API version served by networking.x-k8s.io/v1alpha1, gRPC backends are
+// routed to using HTTPRoute.
+func gatewayAPISupported(client discovery.ServerResourcesInterface) bool {
+	list, err := client.ServerResourcesForGroupVersion(gwapiV1alpha1.SchemeGroupVersion.String())
+	if err != nil || list == nil {
+		log.Info().Msgf("Gateway API %s is not served by the k8s API server, Gateway and HTTPRoute resources will be ignored", gwapiV1alpha1.SchemeGroupVersion)
+		return false
+	}
+
+	kinds := make(map[string]bool)
+	for _, elem := range list.APIResources {
+		kinds[elem.Kind] = true
+	}
+	return kinds[gatewayKind] && kinds[httpRouteKind]
+}
+
+// initGatewayAPIInformers initializes the informers and caches for the Gateway and HTTPRoute resources
+func (c *client) initGatewayAPIInformers(shouldObserve func(obj interface{}) bool) {
+	// Ignore resources that have the ignore label
+	ignoreLabel, _ := labels.NewRequirement(constants.IgnoreLabel, selection.DoesNotExist, nil)
+	option := gwapiInformers.WithTweakListOptions(func(opt *metav1.ListOptions) {
+		opt.LabelSelector = ignoreLabel.String()
+	})
+	informerFactory := gwapiInformers.NewSharedInformerFactoryWithOptions(c.gatewayClient, k8s.DefaultKubeEventResyncInterval, option)
+
+	c.gatewayInformer = informerFactory.Networking().V1alpha1().Gateways().Informer()
+	c.gatewayCache = c.gatewayInformer.GetStore()
+	c.gatewayInformer.AddEventHandler(k8s.GetKubernetesEventHandlers("Gateway", "Kubernetes", shouldObserve, k8s.EventTypes{
+		Add:    announcements.GatewayAdded,
+		Update: announcements.GatewayUpdated,
+		Delete: announcements.GatewayDeleted,
+	}))
+
+	c.routeInformer = informerFactory.Networking().V1alpha1().HTTPRoutes().Informer()
+	c.routeCache = c.routeInformer.GetStore()
+	c.routeInformer.AddEventHandler(k8s.GetKubernetesEventHandlers("HTTPRoute", "Kubernetes", shouldObserve, k8s.EventTypes{
+		Add:    announcements.HTTPRouteAdded,
+		Update: announcements.HTTPRouteUpdated,
+		Delete: announcements.HTTPRouteDeleted,
+	}))
+}
+
+// GetHTTPRoutes returns the networking.x-k8s.io HTTPRoute resources bound to a Gateway whose backends correspond to the service
+func (c client) GetHTTPRoutes(meshService service.MeshService) ([]*gwapiV1alpha1.HTTPRoute, error) {
+	if c.routeCache == nil || c.gatewayCache == nil {
+		// The Gateway API is not served by the k8s API server, return an empty list
+		return nil, nil
+	}
+
+	var gateways []*gwapiV1alpha1.Gateway
+	for _, gatewayInterface := range c.gatewayCache.List() {
+		gateway, ok := gatewayInterface.(*gwapiV1alpha1.Gateway)
+		if !ok {
+			log.Error().Msg("Failed type assertion for Gateway in gateway cache")
+			continue
+		}
+		gateways = append(gateways, gateway)
+	}
+
+	var routes []*gwapiV1alpha1.HTTPRoute
+	for _, routeInterface := range c.routeCache.List() {
+		route, ok := routeInterface.(*gwapiV1alpha1.HTTPRoute)
+		if !ok {
+			log.Error().Msg("Failed type assertion for HTTPRoute in HTTPRoute cache")
+			continue
+		}
+
+		// Extra safety - make sure we do not pay attention to HTTPRoutes outside of observed namespaces
+		if !c.kubeController.IsMonitoredNamespace(route.Namespace) {
+			continue
+		}
+
+		// Backends are referenced by name, so they belong to the same namespace as the HTTPRoute
+		if route.Namespace != meshService.Namespace || !routeForwardsToService(route, meshService) {
+			continue
+		}
+
+		for _, gateway := range gateways {
+			if c.isRouteBoundToGateway(route, gateway) {
+				routes = append(routes, route)
+				break
+			}
+		}
+	}
+
+	return routes, nil
+}
+
+// routeForwardsToService returns true if any of the rules of the given HTTPRoute forward traffic to the given service
+func routeForwardsToService(route *gwapiV1alpha1.HTTPRoute, meshService service.MeshService) bool {
+	for _, rule := range route.Spec.Rules {
+		for _, forwardTo := range rule.ForwardTo {
+			if forwardTo.ServiceName != nil && *forwardTo.ServiceName == meshService.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isRouteBoundToGateway returns true if the given HTTPRoute is bound to the given Gateway, ie. the route allows
+// the Gateway to use it and at least one of the Gateway's HTTP(S) listeners selects the route.
+func (c client) isRouteBoundToGateway(route *gwapiV1alpha1.HTTPRoute, gateway *gwapiV1alpha1.Gateway) bool {
+	if !routeAllowsGateway(route, gateway) {
+		return false
+	}
+
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.Protocol != gwapiV1alpha1.HTTPProtocolType && listener.Protocol != gwapiV1alpha1.HTTPSProtocolType {
+			continue
+		}
+		if c.listenerSelectsRoute(listener, gateway.Namespace, route) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeAllowsGateway returns true if the given HTTPRoute allows the given Gateway to use it
+func routeAllowsGateway(route *gwapiV1alpha1.HTTPRoute, gateway *gwapiV1alpha1.Gateway) bool {
+	// Only Gateways in the same namespace can use the route by default
+	allow := gwapiV1alpha1.GatewayAllowSameNamespace
+	if route.Spec.Gateways != nil && route.Spec.Gateways.Allow != nil {
+		allow = *route.Spec.Gateways.Allow
+	}
+
+	switch allow {
+	case gwapiV1alpha1.GatewayAllowAll:
+		return true
+
+	case gwapiV1alpha1.GatewayAllowFromList:
+		for _, ref := range route.Spec.Gateways.GatewayRefs {
+			if ref.Name == gateway.Name && ref.Namespace == gateway.Namespace {
+				return true
+			}
+		}
+		return false
+
+	default:
+		return route.Namespace == gateway.Namespace
+	}
+}
+
+// listenerSelectsRoute returns true if the given Gateway listener selects the given HTTPRoute
+func (c client) listenerSelectsRoute(listener gwapiV1alpha1.Listener, gatewayNamespace string, route *gwapiV1alpha1.HTTPRoute) bool {
+	routes := listener.Routes
+	if routes.Kind != httpRouteKind || (routes.Group != nil && *routes.Group != gwapiV1alpha1.GroupName) {
+		return false
+	}
+
+	if routes.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(routes.Selector)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid route selector in Gateway listener on port %d in namespace %s", listener.Port, gatewayNamespace)
+			return false
+		}
+		if !selector.Matches(labels.Set(route.Labels)) {
+			return false
+		}
+	}
+
+	// Only routes in the same namespace as the Gateway are selected by default
+	from := gwapiV1alpha1.RouteSelectSame
+	if routes.Namespaces != nil && routes.Namespaces.From != nil {
+		from = *routes.Namespaces.From
+	}
+
+	switch from {
+	case gwapiV1alpha1.RouteSelectAll:
+		return true
+
+	case gwapiV1alpha1.RouteSelectSelector:
+		if routes.Namespaces.Selector == nil {
+			return false
+		}
+		selector, err := metav1.LabelSelectorAsSelector(routes.Namespaces.Selector)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid namespace selector in Gateway listener on port %d in namespace %s", listener.Port, gatewayNamespace)
+			return false
+		}
+		ns := c.kubeController.GetNamespace(route.Namespace)
+		return ns != nil && selector.Matches(labels.Set(ns.Labels))
+
+	default:
+		return route.Namespace == gatewayNamespace
+	}
+}
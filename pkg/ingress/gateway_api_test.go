@@ -0,0 +1,194 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+	gwapiV1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestGatewayAPISupported(t *testing.T) {
+	testCases := []struct {
+		name            string
+		discoveryClient *k8s.FakeDiscoveryClient
+		expected        bool
+	}{
+		{
+			name: "Gateway API is served",
+			discoveryClient: &k8s.FakeDiscoveryClient{
+				Resources: map[string]metav1.APIResourceList{
+					"networking.x-k8s.io/v1alpha1": {APIResources: []metav1.APIResource{
+						{Kind: "Gateway"},
+						{Kind: "HTTPRoute"},
+					}},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "HTTPRoute is not served",
+			discoveryClient: &k8s.FakeDiscoveryClient{
+				Resources: map[string]metav1.APIResourceList{
+					"networking.x-k8s.io/v1alpha1": {APIResources: []metav1.APIResource{
+						{Kind: "Gateway"},
+					}},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "Gateway API group is not served",
+			discoveryClient: &k8s.FakeDiscoveryClient{
+				Err: errors.New("not found"),
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, gatewayAPISupported(tc.discoveryClient))
+		})
+	}
+}
+
+func TestGetHTTPRoutes(t *testing.T) {
+	meshSvc := service.MeshService{Name: "foo", Namespace: "testns"}
+	allowAll := gwapiV1alpha1.GatewayAllowAll
+	allowFromList := gwapiV1alpha1.GatewayAllowFromList
+	selectAll := gwapiV1alpha1.RouteSelectAll
+	selectSelector := gwapiV1alpha1.RouteSelectSelector
+
+	newGateway := func(namespace string, listener gwapiV1alpha1.Listener) *gwapiV1alpha1.Gateway {
+		return &gwapiV1alpha1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: namespace},
+			Spec: gwapiV1alpha1.GatewaySpec{
+				GatewayClassName: "test",
+				Listeners:        []gwapiV1alpha1.Listener{listener},
+			},
+		}
+	}
+	newRoute := func(backend string, gateways *gwapiV1alpha1.RouteGateways) *gwapiV1alpha1.HTTPRoute {
+		return &gwapiV1alpha1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "testns", Labels: map[string]string{"app": "foo"}},
+			Spec: gwapiV1alpha1.HTTPRouteSpec{
+				Gateways: gateways,
+				Rules: []gwapiV1alpha1.HTTPRouteRule{
+					{
+						ForwardTo: []gwapiV1alpha1.HTTPRouteForwardTo{{ServiceName: pointer.StringPtr(backend)}},
+					},
+				},
+			},
+		}
+	}
+	httpListener := gwapiV1alpha1.Listener{
+		Port:     80,
+		Protocol: gwapiV1alpha1.HTTPProtocolType,
+		Routes:   gwapiV1alpha1.RouteBindingSelector{Kind: "HTTPRoute"},
+	}
+
+	testCases := []struct {
+		name               string
+		gateway            *gwapiV1alpha1.Gateway
+		route              *gwapiV1alpha1.HTTPRoute
+		expectedMatchCount int
+	}{
+		{
+			name:               "route bound to a gateway in the same namespace",
+			gateway:            newGateway("testns", httpListener),
+			route:              newRoute("foo", nil),
+			expectedMatchCount: 1,
+		},
+		{
+			name:               "route does not forward to the service",
+			gateway:            newGateway("testns", httpListener),
+			route:              newRoute("bar", nil),
+			expectedMatchCount: 0,
+		},
+		{
+			name:               "route does not allow gateways in other namespaces",
+			gateway:            newGateway("gateway-ns", httpListener),
+			route:              newRoute("foo", &gwapiV1alpha1.RouteGateways{Allow: &allowAll}),
+			expectedMatchCount: 0,
+		},
+		{
+			name: "route allows a gateway in another namespace that selects routes from all namespaces",
+			gateway: newGateway("gateway-ns", gwapiV1alpha1.Listener{
+				Port:     443,
+				Protocol: gwapiV1alpha1.HTTPSProtocolType,
+				Routes: gwapiV1alpha1.RouteBindingSelector{
+					Kind:       "HTTPRoute",
+					Namespaces: &gwapiV1alpha1.RouteNamespaces{From: &selectAll},
+				},
+			}),
+			route: newRoute("foo", &gwapiV1alpha1.RouteGateways{
+				Allow:       &allowFromList,
+				GatewayRefs: []gwapiV1alpha1.GatewayReference{{Name: "gateway", Namespace: "gateway-ns"}},
+			}),
+			expectedMatchCount: 1,
+		},
+		{
+			name: "gateway selects routes from namespaces matching a selector",
+			gateway: newGateway("gateway-ns", gwapiV1alpha1.Listener{
+				Port:     80,
+				Protocol: gwapiV1alpha1.HTTPProtocolType,
+				Routes: gwapiV1alpha1.RouteBindingSelector{
+					Kind:     "HTTPRoute",
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+					Namespaces: &gwapiV1alpha1.RouteNamespaces{
+						From:     &selectSelector,
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"ingress": "allowed"}},
+					},
+				},
+			}),
+			route:              newRoute("foo", &gwapiV1alpha1.RouteGateways{Allow: &allowAll}),
+			expectedMatchCount: 1,
+		},
+		{
+			name: "gateway listener does not select HTTPRoutes",
+			gateway: newGateway("testns", gwapiV1alpha1.Listener{
+				Port:     80,
+				Protocol: gwapiV1alpha1.TCPProtocolType,
+				Routes:   gwapiV1alpha1.RouteBindingSelector{Kind: "TCPRoute"},
+			}),
+			route:              newRoute("foo", nil),
+			expectedMatchCount: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockKubeController.EXPECT().IsMonitoredNamespace(gomock.Any()).Return(true).AnyTimes()
+			mockKubeController.EXPECT().GetNamespace("testns").Return(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "testns", Labels: map[string]string{"ingress": "allowed"}},
+			}).AnyTimes()
+
+			c := client{
+				kubeController: mockKubeController,
+				gatewayCache:   cache.NewStore(cache.MetaNamespaceKeyFunc),
+				routeCache:     cache.NewStore(cache.MetaNamespaceKeyFunc),
+			}
+			assert.Nil(c.gatewayCache.Add(tc.gateway))
+			assert.Nil(c.routeCache.Add(tc.route))
+
+			routes, err := c.GetHTTPRoutes(meshSvc)
+			assert.Nil(err)
+			assert.Len(routes, tc.expectedMatchCount)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	service "github.com/openservicemesh/osm/pkg/service"
 	v1 "k8s.io/api/networking/v1"
 	v1beta1 "k8s.io/api/networking/v1beta1"
+	v1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
 )
 
 // MockMonitor is a mock of Monitor interface
@@ -36,6 +37,21 @@ func (m *MockMonitor) EXPECT() *MockMonitorMockRecorder {
 	return m.recorder
 }
 
+// GetHTTPRoutes mocks base method
+func (m *MockMonitor) GetHTTPRoutes(arg0 service.MeshService) ([]*v1alpha1.HTTPRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTPRoutes", arg0)
+	ret0, _ := ret[0].([]*v1alpha1.HTTPRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHTTPRoutes indicates an expected call of GetHTTPRoutes
+func (mr *MockMonitorMockRecorder) GetHTTPRoutes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTPRoutes", reflect.TypeOf((*MockMonitor)(nil).GetHTTPRoutes), arg0)
+}
+
 // GetIngressNetworkingV1 mocks base method
 func (m *MockMonitor) GetIngressNetworkingV1(arg0 service.MeshService) ([]*v1.Ingress, error) {
 	m.ctrl.T.Helper()
@@ -6,6 +6,8 @@ import (
 	networkingV1beta1 "k8s.io/api/networking/v1beta1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	gwapiV1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+	gwapiClientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -25,6 +27,11 @@ type client struct {
 	cacheV1         cache.Store
 	informerV1beta1 cache.SharedIndexInformer
 	cacheV1Beta1    cache.Store
+	gatewayInformer cache.SharedIndexInformer
+	gatewayCache    cache.Store
+	routeInformer   cache.SharedIndexInformer
+	routeCache      cache.Store
+	gatewayClient   gwapiClientset.Interface
 	kubeController  k8s.Controller
 	cfg             configurator.Configurator
 	certProvider    certificate.Manager
@@ -37,4 +44,7 @@ type Monitor interface {
 
 	// GetIngressNetworkingV1 returns the networking.k8s.io/v1 ingress resources whose backends correspond to the service
 	GetIngressNetworkingV1(service.MeshService) ([]*networkingV1.Ingress, error)
+
+	// GetHTTPRoutes returns the networking.x-k8s.io HTTPRoute resources bound to a Gateway whose backends correspond to the service
+	GetHTTPRoutes(service.MeshService) ([]*gwapiV1alpha1.HTTPRoute, error)
 }
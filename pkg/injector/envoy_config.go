@@ -38,6 +38,7 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 		PrivateKey:       config.Key,
 		XDSHost:          config.XDSHost,
 		XDSPort:          config.XDSPort,
+		XDSSharded:       config.XDSSharded,
 	})
 	if err != nil {
 		log.Error().Err(err).Msgf("Error building Envoy boostrap config")
@@ -104,6 +105,10 @@ func getProbeResources(config envoyBootstrapConfigMeta) ([]*xds_listener.Listene
 	return listeners, clusters, nil
 }
 
+// createEnvoyBootstrapConfig creates the secret holding the bootstrap config of the Envoy proxy. When the proxies are
+// sharded across the osm-controller replicas, the proxy connects to the headless Service resolving to all the ready
+// replicas so that it can reach the replica owning it. Proxies injected before sharding is enabled keep connecting
+// through the osm-controller Service until their pods are restarted.
 func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace string, cert certificate.Certificater, originalHealthProbes healthProbes) (*corev1.Secret, error) {
 	configMeta := envoyBootstrapConfigMeta{
 		EnvoyAdminPort: constants.EnvoyAdminPort,
@@ -114,13 +119,17 @@ func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamesp
 		Cert:     cert.GetCertificateChain(),
 		Key:      cert.GetPrivateKey(),
 
-		XDSHost: fmt.Sprintf("%s.%s.svc.cluster.local", constants.OSMControllerName, osmNamespace),
-		XDSPort: constants.ADSServerPort,
+		XDSHost:    fmt.Sprintf("%s.%s.svc.cluster.local", constants.OSMControllerName, osmNamespace),
+		XDSPort:    constants.ADSServerPort,
+		XDSSharded: wh.configurator.GetFeatureFlags().EnableADSSharding,
 
 		// OriginalHealthProbes stores the path and port for liveness, readiness, and startup health probes as initially
 		// defined on the Pod Spec.
 		OriginalHealthProbes: originalHealthProbes,
 	}
+	if configMeta.XDSSharded {
+		configMeta.XDSHost = fmt.Sprintf("%s.%s.svc.cluster.local", constants.OSMControllerHeadlessServiceName, osmNamespace)
+	}
 	yamlContent, err := getEnvoyConfigYAML(configMeta, wh.configurator)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating Envoy bootstrap YAML")
@@ -151,6 +160,12 @@ func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamesp
 }
 
 func getXdsCluster(config envoyBootstrapConfigMeta) (*xds_cluster.Cluster, error) {
+	// When sharded, all the resolved osm-controller replicas are endpoints of the cluster
+	xdsClusterType := xds_cluster.Cluster_LOGICAL_DNS
+	if config.XDSSharded {
+		xdsClusterType = xds_cluster.Cluster_STRICT_DNS
+	}
+
 	httpProtocolOptions := &xds_upstream_http.HttpProtocolOptions{
 		UpstreamProtocolOptions: &xds_upstream_http.HttpProtocolOptions_ExplicitHttpConfig_{
 			ExplicitHttpConfig: &xds_upstream_http.HttpProtocolOptions_ExplicitHttpConfig{
@@ -210,7 +225,7 @@ func getXdsCluster(config envoyBootstrapConfigMeta) (*xds_cluster.Cluster, error
 		Name:           config.XDSClusterName,
 		ConnectTimeout: durationpb.New(time.Millisecond * 250),
 		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
-			Type: xds_cluster.Cluster_LOGICAL_DNS,
+			Type: xdsClusterType,
 		},
 		TypedExtensionProtocolOptions: map[string]*any.Any{
 			"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": pbHTTPProtocolOptions,
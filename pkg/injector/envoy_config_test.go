@@ -17,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -147,7 +148,9 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 				kubeController:      k8s.NewMockController(gomock.NewController(GinkgoT())),
 				nonInjectNamespaces: mapset.NewSet(),
 				meshName:            "some-mesh",
+				configurator:        mockConfigurator,
 			}
+			mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{}).Times(1)
 			name := uuid.New().String()
 			namespace := "a"
 			osmNamespace := "b"
@@ -179,6 +182,25 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 			// Now check the entire struct
 			Expect(*secret).To(Equal(expected))
 		})
+
+		It("Creates bootstrap config connecting to all the osm-controller replicas when sharded", func() {
+			wh := &mutatingWebhook{
+				kubeClient:          fake.NewSimpleClientset(),
+				kubeController:      k8s.NewMockController(gomock.NewController(GinkgoT())),
+				nonInjectNamespaces: mapset.NewSet(),
+				meshName:            "some-mesh",
+				configurator:        mockConfigurator,
+			}
+			mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{EnableADSSharding: true}).Times(1)
+
+			// A replica rejecting the proxy makes it reconnect to the next resolved replica until it reaches its owner
+			secret, err := wh.createEnvoyBootstrapConfig(uuid.New().String(), "a", "b", cert, probes)
+			Expect(err).ToNot(HaveOccurred())
+
+			bootstrapYAML := string(secret.Data[envoyBootstrapConfigFile])
+			Expect(bootstrapYAML).To(ContainSubstring("address: osm-controller-headless.b.svc.cluster.local"))
+			Expect(bootstrapYAML).To(ContainSubstring("type: STRICT_DNS"))
+		})
 	})
 
 	Context("Test getXdsCluster()", func() {
@@ -16,9 +16,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -51,6 +53,9 @@ func TestCreatePatch(t *testing.T) {
 				// Add Envoy UID Label
 				`"path":"/metadata/labels"`,
 				fmt.Sprintf(`"value":{"osm-proxy-uuid":"%v"`, proxyUUID),
+				// Add sidecar version Annotation
+				`"path":"/metadata/annotations"`,
+				`"value":{"openservicemesh.io/sidecar-version":"envoy:v1,init:v1"}`,
 				// Add Volumes
 				`"path":"/spec/volumes"`,
 				fmt.Sprintf(`"value":[{"name":"envoy-bootstrap-config-volume","secret":{"secretName":"envoy-bootstrap-config-%v"}}]}`, proxyUUID),
@@ -74,6 +79,9 @@ func TestCreatePatch(t *testing.T) {
 				// Add Envoy UID Label
 				`"path":"/metadata/labels"`,
 				fmt.Sprintf(`"value":{"osm-proxy-uuid":"%v"`, proxyUUID),
+				// Add sidecar version Annotation
+				`"path":"/metadata/annotations"`,
+				`"value":{"openservicemesh.io/sidecar-version":"envoy-windows:v1"}`,
 				// Add Volumes
 				`"path":"/spec/volumes"`,
 				fmt.Sprintf(`"value":[{"name":"envoy-bootstrap-config-volume","secret":{"secretName":"envoy-bootstrap-config-%v"}}]}`, proxyUUID),
@@ -97,7 +105,7 @@ func TestCreatePatch(t *testing.T) {
 				fmt.Sprintf(`"value":{"osm-proxy-uuid":"%v"`, proxyUUID),
 				// Add metrics Annotations
 				`"path":"/metadata/annotations"`,
-				`"value":{"prometheus.io/path":"/stats/prometheus","prometheus.io/port":"15010","prometheus.io/scrape":"true"}`,
+				`"value":{"openservicemesh.io/sidecar-version":"envoy:v1,init:v1","prometheus.io/path":"/stats/prometheus","prometheus.io/port":"15010","prometheus.io/scrape":"true"}`,
 				// Add Volumes
 				`"path":"/spec/volumes"`,
 				fmt.Sprintf(`"value":[{"name":"envoy-bootstrap-config-volume","secret":{"secretName":"envoy-bootstrap-config-%v"}}]}`, proxyUUID),
@@ -129,11 +137,13 @@ func TestCreatePatch(t *testing.T) {
 				nonInjectNamespaces: mapset.NewSet(),
 			}
 
-			mockConfigurator.EXPECT().GetEnvoyWindowsImage().Return("").AnyTimes()
-			mockConfigurator.EXPECT().GetEnvoyImage().Return("").AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyWindowsImage().Return("envoy-windows:v1").AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyImage().Return("envoy:v1").AnyTimes()
+			mockConfigurator.EXPECT().GetTrustDomain().Return(identity.ClusterLocalTrustDomain).Times(1)
+			mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha1.FeatureFlags{}).Times(1)
 
 			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
-			mockConfigurator.EXPECT().GetInitContainerImage().Return("").Times(1)
+			mockConfigurator.EXPECT().GetInitContainerImage().Return("init:v1").Times(1)
 			mockConfigurator.EXPECT().IsPrivilegedInitContainer().Return(false).Times(1)
 			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
 			mockConfigurator.EXPECT().GetOutboundPortExclusionList().Return(nil).Times(1)
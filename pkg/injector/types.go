@@ -51,6 +51,9 @@ type envoyBootstrapConfigMeta struct {
 	XDSHost string
 	XDSPort uint32
 
+	// XDSSharded indicates the XDSHost resolves to all the osm-controller replicas, which may shard the proxies
+	XDSSharded bool
+
 	// The bootstrap Envoy config will be affected by the liveness, readiness, startup probes set on
 	// the pod this Envoy is fronting.
 	OriginalHealthProbes healthProbes
@@ -20,6 +20,7 @@ func PatchSecretHandler(kubeClient kubernetes.Interface) chan struct{} {
 	stop := make(chan struct{})
 
 	go func() {
+		defer events.Unsub(podAddSubscription)
 		for {
 			select {
 			case <-stop:
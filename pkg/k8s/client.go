@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	mapset "github.com/deckarep/golang-set"
@@ -40,9 +41,12 @@ func NewKubernetesController(kubeClient kubernetes.Interface, policyClient polic
 		ServiceAccounts: client.initServiceAccountsMonitor,
 		Pods:            client.initPodMonitor,
 		Endpoints:       client.initEndpointMonitor,
+		Secrets:         client.initSecretMonitor,
+		ConfigMaps:      client.initConfigMapMonitor,
 	}
 
-	// If specific informers are not selected to be initialized, initialize all informers
+	// If specific informers are not selected to be initialized, initialize all informers except the Secrets and
+	// ConfigMaps ones, which must be selected explicitly as they cache sensitive or large data across all namespaces
 	if len(selectInformers) == 0 {
 		selectInformers = []InformerKey{Namespaces, Services, ServiceAccounts, Pods, Endpoints}
 	}
@@ -141,6 +145,36 @@ func (c *Client) initEndpointMonitor() {
 	c.informers[Endpoints].AddEventHandler(GetKubernetesEventHandlers((string)(Endpoints), providerName, c.shouldObserve, eptEventTypes))
 }
 
+// Initializes Secret monitoring
+func (c *Client) initSecretMonitor() {
+	// Service account tokens and Helm releases are never referenced by policies, so they are not cached
+	option := informers.WithTweakListOptions(func(opt *metav1.ListOptions) {
+		opt.FieldSelector = secretFieldSelector
+	})
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(c.kubeClient, DefaultKubeEventResyncInterval, option)
+	c.informers[Secrets] = informerFactory.Core().V1().Secrets().Informer()
+
+	secretEventTypes := EventTypes{
+		Add:    announcements.SecretAdded,
+		Update: announcements.SecretUpdated,
+		Delete: announcements.SecretDeleted,
+	}
+	c.informers[Secrets].AddEventHandler(GetKubernetesEventHandlers((string)(Secrets), providerName, c.shouldObserve, secretEventTypes))
+}
+
+// Initializes ConfigMap monitoring
+func (c *Client) initConfigMapMonitor() {
+	informerFactory := informers.NewSharedInformerFactory(c.kubeClient, DefaultKubeEventResyncInterval)
+	c.informers[ConfigMaps] = informerFactory.Core().V1().ConfigMaps().Informer()
+
+	configMapEventTypes := EventTypes{
+		Add:    announcements.ConfigMapAdded,
+		Update: announcements.ConfigMapUpdated,
+		Delete: announcements.ConfigMapDeleted,
+	}
+	c.informers[ConfigMaps].AddEventHandler(GetKubernetesEventHandlers((string)(ConfigMaps), providerName, c.shouldObserve, configMapEventTypes))
+}
+
 func (c *Client) run(stop <-chan struct{}) error {
 	log.Info().Msg("Namespace controller client started")
 	var hasSynced []cache.InformerSynced
@@ -242,6 +276,38 @@ func (c Client) GetNamespace(ns string) *corev1.Namespace {
 	return nil
 }
 
+// GetSecret returns the Secret resource with the given name and namespace if found, nil otherwise.
+// Secrets are only found when the Secrets informer is initialized.
+func (c Client) GetSecret(name, namespace string) *corev1.Secret {
+	informer, ok := c.informers[Secrets]
+	if !ok {
+		return nil
+	}
+	// client-go cache uses <namespace>/<name> as key
+	secretIf, exists, err := informer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if exists && err == nil {
+		secret := secretIf.(*corev1.Secret)
+		return secret
+	}
+	return nil
+}
+
+// GetConfigMap returns the ConfigMap resource with the given name and namespace if found, nil otherwise.
+// ConfigMaps are only found when the ConfigMaps informer is initialized.
+func (c Client) GetConfigMap(name, namespace string) *corev1.ConfigMap {
+	informer, ok := c.informers[ConfigMaps]
+	if !ok {
+		return nil
+	}
+	// client-go cache uses <namespace>/<name> as key
+	configMapIf, exists, err := informer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if exists && err == nil {
+		configMap := configMapIf.(*corev1.ConfigMap)
+		return configMap
+	}
+	return nil
+}
+
 // ListPods returns a list of pods part of the mesh
 // Kubecontroller does not currently segment pod notifications, hence it receives notifications
 // for all k8s Pods.
@@ -324,6 +390,10 @@ func (c Client) UpdateStatus(resource interface{}) (metav1.Object, error) {
 		obj := resource.(*policyv1alpha1.IngressBackend)
 		return c.policyClient.PolicyV1alpha1().IngressBackends(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
 
+	case *policyv1alpha1.Egress:
+		obj := resource.(*policyv1alpha1.Egress)
+		return c.policyClient.PolicyV1alpha1().Egresses(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
 	default:
 		return nil, errors.Errorf("Unsupported type: %T", t)
 	}
@@ -103,6 +103,78 @@ var _ = Describe("Test Namespace KubeController Methods", func() {
 		})
 	})
 
+	Context("Testing GetSecret", func() {
+		It("should return existing secret if it exists", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			stop := make(chan struct{})
+			kubeController, err := NewKubernetesController(kubeClient, nil, testMeshName, stop, Namespaces, Secrets)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kubeController).ToNot(BeNil())
+
+			testSecret := corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "egress-ca",
+					Namespace: tests.Namespace,
+				},
+				Data: map[string][]byte{"ca.crt": []byte("ca")},
+			}
+
+			// Create it
+			secretCreate, err := kubeClient.CoreV1().Secrets(tests.Namespace).Create(context.TODO(), &testSecret, metav1.CreateOptions{})
+			Expect(err).To(BeNil())
+
+			// Check it is present
+			Eventually(func() *corev1.Secret {
+				return kubeController.GetSecret(testSecret.Name, tests.Namespace)
+			}, nsInformerSyncTimeout).Should(Equal(secretCreate))
+
+			// Delete it
+			err = kubeClient.CoreV1().Secrets(tests.Namespace).Delete(context.TODO(), testSecret.Name, metav1.DeleteOptions{})
+			Expect(err).To(BeNil())
+
+			// Check it is gone
+			Eventually(func() *corev1.Secret {
+				return kubeController.GetSecret(testSecret.Name, tests.Namespace)
+			}, nsInformerSyncTimeout).Should(BeNil())
+		})
+	})
+
+	Context("Testing GetConfigMap", func() {
+		It("should return existing configmap if it exists", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			stop := make(chan struct{})
+			kubeController, err := NewKubernetesController(kubeClient, nil, testMeshName, stop, Namespaces, ConfigMaps)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kubeController).ToNot(BeNil())
+
+			testConfigMap := corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "filter",
+					Namespace: tests.Namespace,
+				},
+				BinaryData: map[string][]byte{"filter.wasm": []byte("wasm")},
+			}
+
+			// Create it
+			configMapCreate, err := kubeClient.CoreV1().ConfigMaps(tests.Namespace).Create(context.TODO(), &testConfigMap, metav1.CreateOptions{})
+			Expect(err).To(BeNil())
+
+			// Check it is present
+			Eventually(func() *corev1.ConfigMap {
+				return kubeController.GetConfigMap(testConfigMap.Name, tests.Namespace)
+			}, nsInformerSyncTimeout).Should(Equal(configMapCreate))
+
+			// Delete it
+			err = kubeClient.CoreV1().ConfigMaps(tests.Namespace).Delete(context.TODO(), testConfigMap.Name, metav1.DeleteOptions{})
+			Expect(err).To(BeNil())
+
+			// Check it is gone
+			Eventually(func() *corev1.ConfigMap {
+				return kubeController.GetConfigMap(testConfigMap.Name, tests.Namespace)
+			}, nsInformerSyncTimeout).Should(BeNil())
+		})
+	})
+
 	Context("Testing IsMonitoredNamespace", func() {
 		It("should work as expected", func() {
 			// Create namespace controller
@@ -761,10 +833,29 @@ func TestUpdateStatus(t *testing.T) {
 					Reason:        "valid",
 				},
 			},
-		}, {
+		},
+		{
+			name: "valid Egress resource",
+			existingResource: &policyv1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "egress-1",
+					Namespace: "test",
+				},
+			},
+			updatedResource: &policyv1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "egress-1",
+					Namespace: "test",
+				},
+				Status: policyv1alpha1.EgressStatus{
+					CurrentStatus: policyv1alpha1.EgressStatusAccepted,
+				},
+			},
+		},
+		{
 			name:             "unsupported resource",
 			existingResource: &policyv1alpha1.Egress{},
-			updatedResource:  &policyv1alpha1.Egress{},
+			updatedResource:  &corev1.Pod{},
 			expectErr:        true,
 		},
 	}
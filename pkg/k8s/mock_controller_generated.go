@@ -52,6 +52,20 @@ func (mr *MockControllerMockRecorder) GetEndpoints(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpoints", reflect.TypeOf((*MockController)(nil).GetEndpoints), arg0)
 }
 
+// GetConfigMap mocks base method
+func (m *MockController) GetConfigMap(arg0, arg1 string) *v1.ConfigMap {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMap", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ConfigMap)
+	return ret0
+}
+
+// GetConfigMap indicates an expected call of GetConfigMap
+func (mr *MockControllerMockRecorder) GetConfigMap(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockController)(nil).GetConfigMap), arg0, arg1)
+}
+
 // GetNamespace mocks base method
 func (m *MockController) GetNamespace(arg0 string) *v1.Namespace {
 	m.ctrl.T.Helper()
@@ -66,6 +80,20 @@ func (mr *MockControllerMockRecorder) GetNamespace(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespace", reflect.TypeOf((*MockController)(nil).GetNamespace), arg0)
 }
 
+// GetSecret mocks base method
+func (m *MockController) GetSecret(arg0, arg1 string) *v1.Secret {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
+	ret0, _ := ret[0].(*v1.Secret)
+	return ret0
+}
+
+// GetSecret indicates an expected call of GetSecret
+func (mr *MockControllerMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockController)(nil).GetSecret), arg0, arg1)
+}
+
 // GetService mocks base method
 func (m *MockController) GetService(arg0 service.MeshService) *v1.Service {
 	m.ctrl.T.Helper()
@@ -45,6 +45,9 @@ const (
 
 	// providerName is the name of the Kubernetes event provider
 	providerName = "Kubernetes"
+
+	// secretFieldSelector selects the secrets cached by the Secrets informer
+	secretFieldSelector = "type!=kubernetes.io/service-account-token,type!=helm.sh/release.v1"
 )
 
 // InformerKey stores the different Informers we keep for K8s resources
@@ -61,6 +64,10 @@ const (
 	Endpoints InformerKey = "Endpoints"
 	// ServiceAccounts lookup identifier
 	ServiceAccounts InformerKey = "ServiceAccounts"
+	// Secrets lookup identifier
+	Secrets InformerKey = "Secrets"
+	// ConfigMaps lookup identifier
+	ConfigMaps InformerKey = "ConfigMaps"
 )
 
 // informerCollection is the type holding the collection of informers we keep
@@ -95,6 +102,12 @@ type Controller interface {
 	// GetNamespace returns k8s namespace present in cache
 	GetNamespace(ns string) *corev1.Namespace
 
+	// GetSecret returns the k8s secret with the given name and namespace present in cache, otherwise nil
+	GetSecret(name, namespace string) *corev1.Secret
+
+	// GetConfigMap returns the k8s configmap with the given name and namespace present in cache, otherwise nil
+	GetConfigMap(name, namespace string) *corev1.ConfigMap
+
 	// ListPods returns a list of pods part of the mesh
 	ListPods() []*corev1.Pod
 
@@ -0,0 +1,144 @@
+// Package leaderelection implements the leader election of osm-controller replicas using a Kubernetes Lease,
+// so that singleton work is only performed by the leader while all replicas serve proxies.
+package leaderelection
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+var log = logger.New("leader-election")
+
+const (
+	// leaseDuration is the duration followers wait before forcing the acquisition of a lease that was not renewed
+	leaseDuration = 15 * time.Second
+
+	// renewDeadline is the duration the leader retries renewing the lease before giving up leadership
+	renewDeadline = 10 * time.Second
+
+	// retryPeriod is the duration between attempts to acquire or renew the lease
+	retryPeriod = 2 * time.Second
+)
+
+// Task is singleton work run by the leader until the given stop channel is closed, which happens
+// when the leadership is lost or the elector is stopped.
+type Task func(stop <-chan struct{})
+
+// Elector elects a leader among the replicas sharing a Lease and runs the registered tasks while leading.
+type Elector struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	leaseName  string
+	identity   string
+
+	tasksMutex sync.Mutex
+	tasks      map[string]Task
+
+	// isLeader is 1 while this replica holds the lease, 0 otherwise
+	isLeader int32
+}
+
+// NewElector returns a new Elector competing for the given Lease with the given identity,
+// which must be unique among the replicas, ex. the pod name.
+func NewElector(kubeClient kubernetes.Interface, namespace, leaseName, identity string) *Elector {
+	return &Elector{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		leaseName:  leaseName,
+		identity:   identity,
+		tasks:      make(map[string]Task),
+	}
+}
+
+// RunWhenLeader registers a task that is started every time this replica becomes the leader.
+// Tasks must be registered before the elector is run.
+func (e *Elector) RunWhenLeader(name string, task Task) {
+	e.tasksMutex.Lock()
+	defer e.tasksMutex.Unlock()
+	e.tasks[name] = task
+}
+
+// IsLeader returns true if this replica currently holds the lease
+func (e *Elector) IsLeader() bool {
+	return atomic.LoadInt32(&e.isLeader) == 1
+}
+
+// Run competes for the lease until the stop channel is closed. When the leadership is lost, the tasks
+// are stopped and this replica competes for the lease again. The lease is released when stopped.
+func (e *Elector) Run(stop <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	config := leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{
+				Name:      e.leaseName,
+				Namespace: e.namespace,
+			},
+			Client: e.kubeClient.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{
+				Identity: e.identity,
+			},
+		},
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		ReleaseOnCancel: true,
+		Name:            e.leaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: e.startLeading,
+			OnStoppedLeading: e.stopLeading,
+			OnNewLeader: func(identity string) {
+				log.Info().Msgf("Replica %s is the leader of lease %s/%s", identity, e.namespace, e.leaseName)
+			},
+		},
+	}
+
+	go func() {
+		for {
+			// RunOrDie returns when the leadership is lost or the context is canceled
+			leaderelection.RunOrDie(ctx, config)
+
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				log.Warn().Msgf("Lost leadership of lease %s/%s, competing for the lease again", e.namespace, e.leaseName)
+			}
+		}
+	}()
+}
+
+// startLeading starts the registered tasks, which are stopped when the given context is canceled upon
+// losing the leadership
+func (e *Elector) startLeading(ctx context.Context) {
+	log.Info().Msgf("Replica %s acquired lease %s/%s, starting singleton tasks", e.identity, e.namespace, e.leaseName)
+	atomic.StoreInt32(&e.isLeader, 1)
+	metricsstore.DefaultMetricsStore.ControllerIsLeader.Set(1)
+
+	e.tasksMutex.Lock()
+	defer e.tasksMutex.Unlock()
+	for name, task := range e.tasks {
+		log.Debug().Msgf("Starting singleton task %s", name)
+		go task(ctx.Done())
+	}
+}
+
+func (e *Elector) stopLeading() {
+	log.Info().Msgf("Replica %s is no longer the leader of lease %s/%s, stopped singleton tasks", e.identity, e.namespace, e.leaseName)
+	atomic.StoreInt32(&e.isLeader, 0)
+	metricsstore.DefaultMetricsStore.ControllerIsLeader.Set(0)
+}
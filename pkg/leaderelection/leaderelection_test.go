@@ -0,0 +1,62 @@
+package leaderelection
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestElector(t *testing.T) {
+	assert := tassert.New(t)
+	kubeClient := testclient.NewSimpleClientset()
+
+	leader := NewElector(kubeClient, "osm-system", "osm-controller-leader", "osm-controller-1")
+	follower := NewElector(kubeClient, "osm-system", "osm-controller-leader", "osm-controller-2")
+
+	taskStarted := make(chan struct{})
+	taskStopped := make(chan struct{})
+	leader.RunWhenLeader("test", func(stop <-chan struct{}) {
+		close(taskStarted)
+		<-stop
+		close(taskStopped)
+	})
+	var followerTaskRuns int32
+	follower.RunWhenLeader("test", func(stop <-chan struct{}) {
+		atomic.AddInt32(&followerTaskRuns, 1)
+	})
+
+	leaderStop := make(chan struct{})
+	leader.Run(leaderStop)
+
+	select {
+	case <-taskStarted:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the leader to start the task")
+	}
+	assert.True(leader.IsLeader())
+
+	lease, err := kubeClient.CoordinationV1().Leases("osm-system").Get(context.Background(), "osm-controller-leader", metav1.GetOptions{})
+	assert.Nil(err)
+	assert.Equal("osm-controller-1", *lease.Spec.HolderIdentity)
+
+	followerStop := make(chan struct{})
+	defer close(followerStop)
+	follower.Run(followerStop)
+	time.Sleep(100 * time.Millisecond)
+	assert.False(follower.IsLeader())
+	assert.Zero(atomic.LoadInt32(&followerTaskRuns))
+
+	// Stopping the leader stops its tasks and releases the lease
+	close(leaderStop)
+	select {
+	case <-taskStopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the leader to stop the task")
+	}
+	assert.Eventually(func() bool { return !leader.IsLeader() }, 10*time.Second, 100*time.Millisecond)
+}
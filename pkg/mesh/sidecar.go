@@ -0,0 +1,58 @@
+package mesh
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// sidecarVersionSeparator separates the container images that make up a sidecar version
+const sidecarVersionSeparator = ","
+
+// SidecarVersion returns the sidecar version corresponding to the given Envoy and init container images.
+// The init container image is empty for pods that are not injected with an init container, such as Windows pods.
+func SidecarVersion(envoyImage, initContainerImage string) string {
+	if initContainerImage == "" {
+		return envoyImage
+	}
+	return envoyImage + sidecarVersionSeparator + initContainerImage
+}
+
+// ExpectedSidecarVersion returns the sidecar version the given pod would be injected with if it were created
+// with the given sidecar images.
+func ExpectedSidecarVersion(pod corev1.Pod, envoyImage, envoyWindowsImage, initContainerImage string) string {
+	if strings.EqualFold(pod.Spec.NodeSelector["kubernetes.io/os"], constants.OSWindows) {
+		return SidecarVersion(envoyWindowsImage, "")
+	}
+	return SidecarVersion(envoyImage, initContainerImage)
+}
+
+// InjectedSidecarVersion returns the version of the sidecar injected into the given pod.
+// Pods injected before the sidecar version annotation was introduced fall back to the
+// container images in the pod spec.
+func InjectedSidecarVersion(pod corev1.Pod) string {
+	if version, ok := pod.Annotations[constants.SidecarVersionAnnotation]; ok {
+		return version
+	}
+
+	var envoyImage, initContainerImage string
+	for _, container := range pod.Spec.Containers {
+		if container.Name == constants.EnvoyContainerName {
+			envoyImage = container.Image
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == constants.InitContainerName {
+			initContainerImage = container.Image
+		}
+	}
+	return SidecarVersion(envoyImage, initContainerImage)
+}
+
+// IsSidecarStale returns a boolean indicating if the sidecar injected into the given meshed pod
+// differs from the expected sidecar version.
+func IsSidecarStale(pod corev1.Pod, expectedVersion string) bool {
+	return ProxyLabelExists(pod) && InjectedSidecarVersion(pod) != expectedVersion
+}
@@ -0,0 +1,100 @@
+package mesh
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestExpectedSidecarVersion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pod      corev1.Pod
+		expected string
+	}{
+		{
+			name:     "linux pod",
+			pod:      corev1.Pod{},
+			expected: "envoy:v2,init:v2",
+		},
+		{
+			name: "windows pod",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/os": constants.OSWindows},
+				},
+			},
+			expected: "envoy-windows:v2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, ExpectedSidecarVersion(tc.pod, "envoy:v2", "envoy-windows:v2", "init:v2"))
+		})
+	}
+}
+
+func TestIsSidecarStale(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pod           corev1.Pod
+		expectedStale bool
+	}{
+		{
+			name: "annotated pod with current sidecar",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{constants.EnvoyUniqueIDLabelName: uuid.New().String()},
+					Annotations: map[string]string{constants.SidecarVersionAnnotation: "envoy:v2,init:v2"},
+				},
+			},
+			expectedStale: false,
+		},
+		{
+			name: "annotated pod with outdated sidecar",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{constants.EnvoyUniqueIDLabelName: uuid.New().String()},
+					Annotations: map[string]string{constants.SidecarVersionAnnotation: "envoy:v1,init:v2"},
+				},
+			},
+			expectedStale: true,
+		},
+		{
+			name: "unannotated pod falls back to container images",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{constants.EnvoyUniqueIDLabelName: uuid.New().String()},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: constants.InitContainerName, Image: "init:v2"}},
+					Containers:     []corev1.Container{{Name: "app", Image: "app:v1"}, {Name: constants.EnvoyContainerName, Image: "envoy:v2"}},
+				},
+			},
+			expectedStale: false,
+		},
+		{
+			name: "pod not in the mesh",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{constants.SidecarVersionAnnotation: "envoy:v1,init:v1"},
+				},
+			},
+			expectedStale: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expectedStale, IsSidecarStale(tc.pod, "envoy:v2,init:v2"))
+		})
+	}
+}
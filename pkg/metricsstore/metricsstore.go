@@ -34,9 +34,42 @@ type MetricsStore struct {
 	// ProxyConfigUpdateTime is the histogram to track time spent for proxy configuration and its occurrences
 	ProxyConfigUpdateTime *prometheus.HistogramVec
 
+	// ProxyXDSPushCount is the metric for the number of xDS responses pushed to the proxies
+	ProxyXDSPushCount *prometheus.CounterVec
+
+	// ProxyXDSAckCount is the metric for the number of xDS responses acknowledged (ACKed) by the proxies
+	ProxyXDSAckCount *prometheus.CounterVec
+
+	// ProxyXDSNackCount is the metric for the number of xDS responses rejected (NACKed) by the proxies
+	ProxyXDSNackCount *prometheus.CounterVec
+
+	// ProxyXDSAckTime is the histogram to track the time taken by the proxies to ACK an xDS response
+	ProxyXDSAckTime *prometheus.HistogramVec
+
 	// ProxyBroadcastEventCounter is the metric for the total number of ProxyBroadcast events published
 	ProxyBroadcastEventCount prometheus.Counter
 
+	// ProxyTargetedBroadcastEventCount is the metric for the number of ProxyBroadcast events published
+	// that only update the proxies depending on the changed services
+	ProxyTargetedBroadcastEventCount prometheus.Counter
+
+	// ProxyPushAvoidedCount is the metric for the number of proxy updates skipped because the proxy
+	// does not depend on the changes published with a ProxyBroadcast event
+	ProxyPushAvoidedCount prometheus.Counter
+
+	/*
+	 * Controller metrics
+	 */
+	// ControllerIsLeader is the metric for whether the controller replica is the leader performing singleton work
+	ControllerIsLeader prometheus.Gauge
+
+	// ControllerShardReplicas is the metric for the number of ready controller replicas the proxies are sharded across
+	ControllerShardReplicas prometheus.Gauge
+
+	// ProxyShardRedirectCount is the metric for the number of proxy connections closed because the proxy
+	// is owned by another controller replica
+	ProxyShardRedirectCount prometheus.Counter
+
 	/*
 	 * Injector metrics
 	 */
@@ -116,6 +149,47 @@ func init() {
 			"success",       // further labels if the operation succeeded or not
 		})
 
+	defaultMetricsStore.ProxyXDSPushCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "xds_push_count",
+			Help:      "Represents the number of xDS responses pushed to the proxies",
+		},
+		[]string{"resource_type", "proxy_kind"},
+	)
+
+	defaultMetricsStore.ProxyXDSAckCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "xds_ack_count",
+			Help:      "Represents the number of xDS responses acknowledged (ACKed) by the proxies",
+		},
+		[]string{"resource_type", "proxy_kind"},
+	)
+
+	defaultMetricsStore.ProxyXDSNackCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "xds_nack_count",
+			Help:      "Represents the number of xDS responses rejected (NACKed) by the proxies",
+		},
+		[]string{"resource_type", "proxy_kind"},
+	)
+
+	defaultMetricsStore.ProxyXDSAckTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "xds_ack_time",
+			Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram to track the time taken by the proxies to ACK an xDS response",
+		},
+		[]string{"resource_type", "proxy_kind"},
+	)
+
 	defaultMetricsStore.ProxyBroadcastEventCount = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: metricsRootNamespace,
 		Subsystem: "proxy",
@@ -123,6 +197,44 @@ func init() {
 		Help:      "Represents the number of ProxyBroadcast events published by the OSM controller",
 	})
 
+	defaultMetricsStore.ProxyTargetedBroadcastEventCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "targeted_broadcast_event_count",
+		Help:      "Represents the number of ProxyBroadcast events published by the OSM controller that only update the proxies depending on the changes",
+	})
+
+	defaultMetricsStore.ProxyPushAvoidedCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "push_avoided_count",
+		Help:      "Represents the number of proxy updates skipped because the proxy does not depend on the changes",
+	})
+
+	/*
+	 * Controller metrics
+	 */
+	defaultMetricsStore.ControllerIsLeader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "controller",
+		Name:      "is_leader",
+		Help:      "Represents whether the OSM controller replica is the leader (1) or a follower (0)",
+	})
+
+	defaultMetricsStore.ControllerShardReplicas = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "controller",
+		Name:      "shard_replicas",
+		Help:      "Represents the number of ready OSM controller replicas the proxies are sharded across",
+	})
+
+	defaultMetricsStore.ProxyShardRedirectCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "shard_redirect_count",
+		Help:      "Represents the number of proxy connections closed because the proxy is owned by another OSM controller replica",
+	})
+
 	/*
 	 * Injector metrics
 	 */
@@ -0,0 +1,587 @@
+package multicluster
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	configInformers "github.com/openservicemesh/osm/pkg/gen/client/config/informers/externalversions"
+	configListers "github.com/openservicemesh/osm/pkg/gen/client/config/listers/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+// remoteCacheSyncTimeout is the time to wait for the informers of a remote cluster to sync before considering
+// the cluster unreachable
+var remoteCacheSyncTimeout = 30 * time.Second
+
+const (
+	// resyncInterval is the resync interval of the informers watching the RemoteCluster resources and the services
+	// of the remote clusters. Each resync triggers a sync, which retries the remote clusters that were unreachable.
+	resyncInterval = 5 * time.Minute
+
+	// kubeconfigSecretKey is the key in the RemoteCluster Secret holding the kubeconfig of the remote cluster
+	kubeconfigSecretKey = "kubeconfig"
+
+	// defaultGatewayServiceName is the name of the multicluster gateway Service installed by the chart
+	defaultGatewayServiceName = "osm-multicluster-gateway"
+
+	// gatewayPortName is the name of the multicluster gateway Service port
+	gatewayPortName = "multicluster"
+)
+
+var log = logger.New("multicluster-discovery")
+
+// remoteClientFunc returns a Kubernetes client for a remote cluster given its kubeconfig
+type remoteClientFunc func(kubeconfig []byte) (kubernetes.Interface, error)
+
+// Discoverer keeps the MultiClusterService resources in the local cluster in sync with the services
+// exported by the clusters registered as RemoteCluster resources.
+type Discoverer struct {
+	kubeClient      kubernetes.Interface
+	configClient    configClientset.Interface
+	osmNamespace    string
+	newRemoteClient remoteClientFunc
+
+	// syncRequests is signaled when a RemoteCluster resource, a MultiClusterService resource or a watched resource
+	// of a remote cluster changes, successive changes being coalesced into a single sync
+	syncRequests chan struct{}
+}
+
+// syncer syncs the MultiClusterService resources from the local informers' caches while the Discoverer runs. It is
+// created by each call to Run, and only accessed by the sync loop of that call, except for reloadKubeconfigs.
+type syncer struct {
+	*Discoverer
+
+	remoteClusterLister       configListers.RemoteClusterLister
+	multiClusterServiceLister configListers.MultiClusterServiceLister
+	hasSynced                 []cache.InformerSynced
+
+	// remoteClusters are the watchers of the remote clusters by RemoteCluster name
+	remoteClusters map[string]*remoteClusterWatcher
+
+	// reloadKubeconfigs is set atomically when a RemoteCluster resource changes or is resynced, for the next sync
+	// to read the kubeconfig Secrets of the remote clusters again instead of reusing those of their watchers
+	reloadKubeconfigs int32
+}
+
+// remoteClusterWatcher caches the services and meshed pods of a remote cluster
+type remoteClusterWatcher struct {
+	kubeconfig []byte
+	services   corev1listers.ServiceLister
+	pods       corev1listers.PodLister
+	hasSynced  []cache.InformerSynced
+	stop       chan struct{}
+}
+
+// NewDiscoverer returns a new Discoverer
+func NewDiscoverer(kubeClient kubernetes.Interface, configClient configClientset.Interface, osmNamespace string) *Discoverer {
+	return &Discoverer{
+		kubeClient:      kubeClient,
+		configClient:    configClient,
+		osmNamespace:    osmNamespace,
+		newRemoteClient: newRemoteClientFromKubeconfig,
+		syncRequests:    make(chan struct{}, 1),
+	}
+}
+
+// Run watches the RemoteCluster resources and the services exported by the remote clusters, and syncs the
+// MultiClusterService resources on changes until the stop channel is closed
+func (d *Discoverer) Run(stop <-chan struct{}) {
+	s := d.newSyncer(stop)
+
+	go func() {
+		defer s.stopRemoteClusterWatchers(nil)
+		if !cache.WaitForCacheSync(stop, s.hasSynced...) {
+			log.Error().Str(constants.LogFieldContext, constants.LogContextMulticluster).Msg("Failed to sync the RemoteCluster and MultiClusterService caches")
+			return
+		}
+		for {
+			select {
+			case <-stop:
+				log.Info().Msg("Received stop signal, exiting remote cluster discovery")
+				return
+			case <-d.syncRequests:
+				if err := s.sync(); err != nil {
+					log.Error().Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msg("Error syncing MultiClusterService resources from remote clusters")
+				}
+			}
+		}
+	}()
+}
+
+// newSyncer returns a syncer whose informers on the RemoteCluster and MultiClusterService resources are started
+// until the stop channel is closed
+func (d *Discoverer) newSyncer(stop <-chan struct{}) *syncer {
+	s := &syncer{
+		Discoverer:     d,
+		remoteClusters: make(map[string]*remoteClusterWatcher),
+	}
+
+	remoteClusterInformerFactory := configInformers.NewSharedInformerFactoryWithOptions(d.configClient, resyncInterval, configInformers.WithNamespace(d.osmNamespace))
+	remoteClusterInformer := remoteClusterInformerFactory.Config().V1alpha1().RemoteClusters()
+	remoteClusterInformer.Informer().AddEventHandler(s.remoteClusterEventHandler())
+
+	multiClusterServiceInformerFactory := configInformers.NewSharedInformerFactory(d.configClient, resyncInterval)
+	multiClusterServiceInformer := multiClusterServiceInformerFactory.Config().V1alpha1().MultiClusterServices()
+	multiClusterServiceInformer.Informer().AddEventHandler(d.syncEventHandler())
+
+	s.remoteClusterLister = remoteClusterInformer.Lister()
+	s.multiClusterServiceLister = multiClusterServiceInformer.Lister()
+	s.hasSynced = []cache.InformerSynced{remoteClusterInformer.Informer().HasSynced, multiClusterServiceInformer.Informer().HasSynced}
+
+	remoteClusterInformerFactory.Start(stop)
+	multiClusterServiceInformerFactory.Start(stop)
+	return s
+}
+
+// requestSync signals the sync loop, without blocking when a sync is already pending
+func (d *Discoverer) requestSync() {
+	select {
+	case d.syncRequests <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Discoverer) syncEventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(_ interface{}) { d.requestSync() },
+		UpdateFunc: func(_, _ interface{}) { d.requestSync() },
+		DeleteFunc: func(_ interface{}) { d.requestSync() },
+	}
+}
+
+// remoteClusterEventHandler requests a sync reading the kubeconfig Secrets again on RemoteCluster changes, which
+// include the periodic resyncs such that rotated kubeconfigs are picked up
+func (s *syncer) remoteClusterEventHandler() cache.ResourceEventHandler {
+	reload := func() {
+		atomic.StoreInt32(&s.reloadKubeconfigs, 1)
+		s.requestSync()
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(_ interface{}) { reload() },
+		UpdateFunc: func(_, _ interface{}) { reload() },
+		DeleteFunc: func(_ interface{}) { reload() },
+	}
+}
+
+// podEventHandler requests a sync when a pod of a remote cluster is added or deleted, or when the labels or the
+// service account of a pod change, ignoring the frequent status updates of pods
+func (d *Discoverer) podEventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(_ interface{}) { d.requestSync() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPod, oldOK := oldObj.(*corev1.Pod)
+			newPod, newOK := newObj.(*corev1.Pod)
+			if oldOK && newOK && oldPod.Spec.ServiceAccountName == newPod.Spec.ServiceAccountName && labels.Equals(oldPod.Labels, newPod.Labels) {
+				return
+			}
+			d.requestSync()
+		},
+		DeleteFunc: func(_ interface{}) { d.requestSync() },
+	}
+}
+
+// getRemoteClusterWatcher returns the watcher of the given remote cluster, starting it when the cluster is new or
+// its kubeconfig changed. The kubeconfig Secret of a watched cluster is only read again when reloadKubeconfig is set.
+func (s *syncer) getRemoteClusterWatcher(rc *v1alpha1.RemoteCluster, reloadKubeconfig bool) (*remoteClusterWatcher, error) {
+	watcher, found := s.remoteClusters[rc.Name]
+	if !found || reloadKubeconfig {
+		kubeconfig, err := s.getKubeconfig(rc)
+		if err != nil {
+			return nil, err
+		}
+		if found && !bytes.Equal(watcher.kubeconfig, kubeconfig) {
+			close(watcher.stop)
+			delete(s.remoteClusters, rc.Name)
+			found = false
+		}
+		if !found {
+			if watcher, err = s.newRemoteClusterWatcher(kubeconfig); err != nil {
+				return nil, err
+			}
+			s.remoteClusters[rc.Name] = watcher
+		}
+	}
+	return watcher, nil
+}
+
+// waitForCacheSync waits for the caches of the watcher to sync, up to remoteCacheSyncTimeout. The informers keep
+// retrying an unreachable cluster, which is reported as such until its caches sync.
+func (w *remoteClusterWatcher) waitForCacheSync() bool {
+	waitStop := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(waitStop)
+		select {
+		case <-time.After(remoteCacheSyncTimeout):
+		case <-w.stop:
+		case <-done:
+		}
+	}()
+	return cache.WaitForCacheSync(waitStop, w.hasSynced...)
+}
+
+// waitForRemoteCacheSync waits concurrently for the caches of the given watchers to sync, such that an unreachable
+// remote cluster does not delay the discovery of the others, and returns the names of the synced watchers
+func waitForRemoteCacheSync(watchers map[string]*remoteClusterWatcher) sets.String {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	synced := sets.NewString()
+	for name, watcher := range watchers {
+		wg.Add(1)
+		go func(name string, watcher *remoteClusterWatcher) {
+			defer wg.Done()
+			if watcher.waitForCacheSync() {
+				mu.Lock()
+				synced.Insert(name)
+				mu.Unlock()
+			}
+		}(name, watcher)
+	}
+	wg.Wait()
+	return synced
+}
+
+// newRemoteClusterWatcher starts watching the services and the meshed pods of the remote cluster with the given
+// kubeconfig. Only meshed pods are watched, as the pods backing exported services must be part of the mesh.
+func (d *Discoverer) newRemoteClusterWatcher(kubeconfig []byte) (*remoteClusterWatcher, error) {
+	remoteClient, err := d.newRemoteClient(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceInformerFactory := informers.NewSharedInformerFactory(remoteClient, resyncInterval)
+	podInformerFactory := informers.NewSharedInformerFactoryWithOptions(remoteClient, resyncInterval, informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+		opts.LabelSelector = constants.EnvoyUniqueIDLabelName
+	}))
+	serviceInformer := serviceInformerFactory.Core().V1().Services()
+	podInformer := podInformerFactory.Core().V1().Pods()
+	serviceInformer.Informer().AddEventHandler(d.syncEventHandler())
+	podInformer.Informer().AddEventHandler(d.podEventHandler())
+
+	watcher := &remoteClusterWatcher{
+		kubeconfig: kubeconfig,
+		services:   serviceInformer.Lister(),
+		pods:       podInformer.Lister(),
+		hasSynced:  []cache.InformerSynced{serviceInformer.Informer().HasSynced, podInformer.Informer().HasSynced},
+		stop:       make(chan struct{}),
+	}
+	serviceInformerFactory.Start(watcher.stop)
+	podInformerFactory.Start(watcher.stop)
+	return watcher, nil
+}
+
+// stopRemoteClusterWatchers stops the watchers of the remote clusters that are not in the given set, or of all the
+// remote clusters when the set is nil
+func (s *syncer) stopRemoteClusterWatchers(keep sets.String) {
+	for name, watcher := range s.remoteClusters {
+		if keep.Has(name) {
+			continue
+		}
+		close(watcher.stop)
+		delete(s.remoteClusters, name)
+	}
+}
+
+// sync discovers the services exported by all remote clusters and creates, updates or deletes
+// the discovered MultiClusterService resources accordingly. MultiClusterService resources that were
+// not created by discovery are never modified.
+func (s *syncer) sync() error {
+	remoteClusters, err := s.remoteClusterLister.RemoteClusters(s.osmNamespace).List(labels.Everything())
+	if err != nil {
+		return errors.Wrapf(err, "Error listing RemoteCluster resources in namespace %s", s.osmNamespace)
+	}
+
+	existing, err := s.multiClusterServiceLister.List(labels.Everything())
+	if err != nil {
+		return errors.Wrap(err, "Error listing MultiClusterService resources")
+	}
+
+	reloadKubeconfigs := atomic.SwapInt32(&s.reloadKubeconfigs, 0) == 1
+	desired := make(map[types.NamespacedName]*v1alpha1.MultiClusterServiceSpec)
+	unreachable := make(map[string]bool)
+	registered := sets.NewString()
+	watchers := make(map[string]*remoteClusterWatcher)
+	for _, rc := range remoteClusters {
+		registered.Insert(rc.Name)
+		watcher, err := s.getRemoteClusterWatcher(rc, reloadKubeconfigs)
+		if err != nil {
+			log.Error().Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Error watching remote cluster %s", rc.Name)
+			unreachable[rc.Name] = true
+			continue
+		}
+		watchers[rc.Name] = watcher
+	}
+	s.stopRemoteClusterWatchers(registered)
+
+	synced := waitForRemoteCacheSync(watchers)
+	for _, rc := range remoteClusters {
+		watcher, found := watchers[rc.Name]
+		if !found {
+			continue
+		}
+		err := errors.Errorf("Timed out waiting for the caches of remote cluster %s to sync", rc.Name)
+		if synced.Has(rc.Name) {
+			err = s.discoverRemoteCluster(rc, watcher, desired)
+		}
+		if err != nil {
+			// Keep the previously discovered entries for this cluster until it is reachable again
+			log.Error().Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Error discovering services in remote cluster %s", rc.Name)
+			unreachable[rc.Name] = true
+		}
+	}
+
+	existingByKey := make(map[types.NamespacedName]v1alpha1.MultiClusterService)
+	for _, mcs := range existing {
+		key := types.NamespacedName{Namespace: mcs.Namespace, Name: mcs.Name}
+		existingByKey[key] = *mcs.DeepCopy()
+		if !isDiscovered(*mcs) {
+			continue
+		}
+		for _, cluster := range mcs.Spec.Clusters {
+			if !unreachable[cluster.Name] {
+				continue
+			}
+			spec := desired[key]
+			if spec == nil {
+				spec = &v1alpha1.MultiClusterServiceSpec{ServiceAccount: mcs.Spec.ServiceAccount, Ports: mcs.Spec.Ports}
+				desired[key] = spec
+			}
+			spec.Clusters = append(spec.Clusters, cluster)
+		}
+	}
+
+	for key, spec := range desired {
+		sort.Slice(spec.Clusters, func(i, j int) bool {
+			return spec.Clusters[i].Name < spec.Clusters[j].Name
+		})
+
+		mcs, found := existingByKey[key]
+		if !found {
+			newMCS := &v1alpha1.MultiClusterService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Labels:    map[string]string{constants.MulticlusterDiscoveredLabel: "true"},
+				},
+				Spec: *spec,
+			}
+			if _, err := s.configClient.ConfigV1alpha1().MultiClusterServices(key.Namespace).Create(context.Background(), newMCS, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+				log.Error().Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Error creating MultiClusterService %s", key)
+			}
+			continue
+		}
+
+		if !isDiscovered(mcs) {
+			log.Warn().Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("MultiClusterService %s was not created by remote cluster discovery, skipping update", key)
+			continue
+		}
+
+		if equality.Semantic.DeepEqual(mcs.Spec, *spec) {
+			continue
+		}
+		mcs.Spec = *spec
+		// A conflict means the cache is stale, the sync requested by the MultiClusterService event retries the update
+		if _, err := s.configClient.ConfigV1alpha1().MultiClusterServices(key.Namespace).Update(context.Background(), &mcs, metav1.UpdateOptions{}); err != nil && !apierrors.IsConflict(err) {
+			log.Error().Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Error updating MultiClusterService %s", key)
+		}
+	}
+
+	for key, mcs := range existingByKey {
+		if _, found := desired[key]; found || !isDiscovered(mcs) {
+			continue
+		}
+		err := s.configClient.ConfigV1alpha1().MultiClusterServices(mcs.Namespace).Delete(context.Background(), mcs.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error().Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Error deleting MultiClusterService %s", key)
+		}
+	}
+
+	return nil
+}
+
+// discoverRemoteCluster adds the services exported by the given remote cluster, whose watcher's caches are synced,
+// to the desired MultiClusterService specs
+func (s *syncer) discoverRemoteCluster(rc *v1alpha1.RemoteCluster, watcher *remoteClusterWatcher, desired map[types.NamespacedName]*v1alpha1.MultiClusterServiceSpec) error {
+	address, err := s.getGatewayAddress(watcher, rc)
+	if err != nil {
+		return err
+	}
+
+	exportSelector := labels.SelectorFromSet(labels.Set{constants.MulticlusterExportLabel: "true"})
+	services, err := watcher.services.List(exportSelector)
+	if err != nil {
+		return errors.Wrap(err, "Error listing exported services")
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Namespace+"/"+services[i].Name < services[j].Namespace+"/"+services[j].Name
+	})
+
+	for _, svc := range services {
+		serviceAccount, err := getServiceAccount(watcher.pods, svc)
+		if err != nil {
+			log.Warn().Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Skipping exported service %s/%s in remote cluster %s", svc.Namespace, svc.Name, rc.Name)
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+		spec := desired[key]
+		if spec == nil {
+			spec = &v1alpha1.MultiClusterServiceSpec{ServiceAccount: serviceAccount}
+			desired[key] = spec
+		} else if spec.ServiceAccount != serviceAccount {
+			log.Warn().Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Exported service %s in remote cluster %s uses service account %s instead of %s, skipping",
+				key, rc.Name, serviceAccount, spec.ServiceAccount)
+			continue
+		}
+
+		spec.Clusters = append(spec.Clusters, v1alpha1.ClusterSpec{Name: rc.Name, Address: address})
+		for _, port := range svc.Spec.Ports {
+			spec.Ports = addPort(spec.Ports, v1alpha1.PortSpec{Port: uint32(port.Port), Protocol: string(port.Protocol)})
+		}
+	}
+
+	return nil
+}
+
+// getKubeconfig returns the kubeconfig of the given remote cluster from its Secret
+func (d *Discoverer) getKubeconfig(rc *v1alpha1.RemoteCluster) ([]byte, error) {
+	secretNamespace := rc.Spec.KubeconfigSecret.Namespace
+	if secretNamespace == "" {
+		secretNamespace = rc.Namespace
+	}
+	secret, err := d.kubeClient.CoreV1().Secrets(secretNamespace).Get(context.Background(), rc.Spec.KubeconfigSecret.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting kubeconfig Secret %s/%s", secretNamespace, rc.Spec.KubeconfigSecret.Name)
+	}
+	kubeconfig, ok := secret.Data[kubeconfigSecretKey]
+	if !ok {
+		return nil, errors.Errorf("Secret %s/%s is missing the %s key", secretNamespace, rc.Spec.KubeconfigSecret.Name, kubeconfigSecretKey)
+	}
+	return kubeconfig, nil
+}
+
+// getGatewayAddress returns the host:port address of the multicluster gateway Service of the given remote cluster
+func (d *Discoverer) getGatewayAddress(watcher *remoteClusterWatcher, rc *v1alpha1.RemoteCluster) (string, error) {
+	name := rc.Spec.Gateway.Name
+	if name == "" {
+		name = defaultGatewayServiceName
+	}
+	namespace := rc.Spec.Gateway.Namespace
+	if namespace == "" {
+		namespace = d.osmNamespace
+	}
+
+	gateway, err := watcher.services.Services(namespace).Get(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error getting multicluster gateway Service %s/%s", namespace, name)
+	}
+
+	// Load balancers may be exposed by IP or by hostname
+	var host string
+	for _, ingress := range gateway.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			host = ingress.IP
+			break
+		}
+		if ingress.Hostname != "" {
+			host = ingress.Hostname
+			break
+		}
+	}
+	if host == "" && len(gateway.Spec.ExternalIPs) > 0 {
+		host = gateway.Spec.ExternalIPs[0]
+	}
+	if host == "" {
+		return "", errors.Errorf("Multicluster gateway Service %s/%s does not have an external address", namespace, name)
+	}
+
+	if len(gateway.Spec.Ports) == 0 {
+		return "", errors.Errorf("Multicluster gateway Service %s/%s does not have any ports", namespace, name)
+	}
+	port := gateway.Spec.Ports[0].Port
+	for _, p := range gateway.Spec.Ports {
+		if p.Name == gatewayPortName {
+			port = p.Port
+			break
+		}
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(port))), nil
+}
+
+// getServiceAccount returns the service account of the meshed pods backing the given service. A MultiClusterService
+// has a single service account, so a service backed by pods of different service accounts cannot be exported.
+func getServiceAccount(pods corev1listers.PodLister, svc *corev1.Service) (string, error) {
+	if len(svc.Spec.Selector) == 0 {
+		return "", errors.New("Service does not have a selector")
+	}
+	backends, err := pods.Pods(svc.Namespace).List(labels.SelectorFromSet(svc.Spec.Selector))
+	if err != nil {
+		return "", errors.Wrap(err, "Error listing pods for service")
+	}
+	if len(backends) == 0 {
+		return "", errors.New("Service does not have any meshed pods")
+	}
+
+	serviceAccounts := sets.NewString()
+	for _, pod := range backends {
+		serviceAccount := pod.Spec.ServiceAccountName
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+		serviceAccounts.Insert(serviceAccount)
+	}
+	if serviceAccounts.Len() > 1 {
+		return "", errors.Errorf("Service is backed by pods of multiple service accounts %s", strings.Join(serviceAccounts.List(), ", "))
+	}
+	return serviceAccounts.List()[0], nil
+}
+
+func addPort(ports []v1alpha1.PortSpec, port v1alpha1.PortSpec) []v1alpha1.PortSpec {
+	if port.Protocol == "" {
+		port.Protocol = string(corev1.ProtocolTCP)
+	}
+	for _, p := range ports {
+		if p == port {
+			return ports
+		}
+	}
+	return append(ports, port)
+}
+
+func isDiscovered(mcs v1alpha1.MultiClusterService) bool {
+	return mcs.Labels[constants.MulticlusterDiscoveredLabel] == "true"
+}
+
+func newRemoteClientFromKubeconfig(kubeconfig []byte) (kubernetes.Interface, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing remote cluster kubeconfig")
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
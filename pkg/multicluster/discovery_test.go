@@ -0,0 +1,407 @@
+package multicluster
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	configFake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+)
+
+const osmNamespace = "osm-system"
+
+func newRemoteCluster(name string) *v1alpha1.RemoteCluster {
+	return &v1alpha1.RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: osmNamespace},
+		Spec: v1alpha1.RemoteClusterSpec{
+			KubeconfigSecret: corev1.SecretReference{Name: name + "-kubeconfig"},
+		},
+	}
+}
+
+func newKubeconfigSecret(cluster string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: cluster + "-kubeconfig", Namespace: osmNamespace},
+		Data:       map[string][]byte{kubeconfigSecretKey: []byte(cluster)},
+	}
+}
+
+func newRemoteObjects(gatewayHost string) []runtime.Object {
+	ingress := corev1.LoadBalancerIngress{IP: gatewayHost}
+	if net.ParseIP(gatewayHost) == nil {
+		ingress = corev1.LoadBalancerIngress{Hostname: gatewayHost}
+	}
+	return []runtime.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: defaultGatewayServiceName, Namespace: osmNamespace},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "admin", Port: 15000}, {Name: gatewayPortName, Port: 15443}},
+			},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{ingress}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookstore",
+				Namespace: "bookstore-ns",
+				Labels:    map[string]string{constants.MulticlusterExportLabel: "true"},
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "bookstore"},
+				Ports:    []corev1.ServicePort{{Port: 8080, Protocol: corev1.ProtocolTCP}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "not-exported", Namespace: "bookstore-ns"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "not-exported"}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "bookstore-1", Namespace: "bookstore-ns", Labels: map[string]string{"app": "bookstore", constants.EnvoyUniqueIDLabelName: "proxy-1"}},
+			Spec:       corev1.PodSpec{ServiceAccountName: "bookstore-sa"},
+		},
+	}
+}
+
+func TestDiscovererSync(t *testing.T) {
+	testCases := []struct {
+		name             string
+		remoteClusters   []*v1alpha1.RemoteCluster
+		remoteClients    map[string]kubernetes.Interface
+		existing         []*v1alpha1.MultiClusterService
+		expectedClusters []v1alpha1.ClusterSpec
+		expectedSA       string
+		expectedPorts    []v1alpha1.PortSpec
+		expectNotFound   bool
+	}{
+		{
+			name:           "exported service in multiple remote clusters",
+			remoteClusters: []*v1alpha1.RemoteCluster{newRemoteCluster("east"), newRemoteCluster("west")},
+			remoteClients: map[string]kubernetes.Interface{
+				"east": fake.NewSimpleClientset(newRemoteObjects("1.1.1.1")...),
+				"west": fake.NewSimpleClientset(newRemoteObjects("2.2.2.2")...),
+			},
+			expectedClusters: []v1alpha1.ClusterSpec{{Name: "east", Address: "1.1.1.1:15443"}, {Name: "west", Address: "2.2.2.2:15443"}},
+			expectedSA:       "bookstore-sa",
+			expectedPorts:    []v1alpha1.PortSpec{{Port: 8080, Protocol: "TCP"}},
+		},
+		{
+			name:           "gateway exposed by hostname",
+			remoteClusters: []*v1alpha1.RemoteCluster{newRemoteCluster("east")},
+			remoteClients: map[string]kubernetes.Interface{
+				"east": fake.NewSimpleClientset(newRemoteObjects("gateway.east.example.com")...),
+			},
+			expectedClusters: []v1alpha1.ClusterSpec{{Name: "east", Address: "gateway.east.example.com:15443"}},
+			expectedSA:       "bookstore-sa",
+			expectedPorts:    []v1alpha1.PortSpec{{Port: 8080, Protocol: "TCP"}},
+		},
+		{
+			name:           "gateway address change updates a discovered MultiClusterService",
+			remoteClusters: []*v1alpha1.RemoteCluster{newRemoteCluster("east")},
+			remoteClients: map[string]kubernetes.Interface{
+				"east": fake.NewSimpleClientset(newRemoteObjects("3.3.3.3")...),
+			},
+			existing: []*v1alpha1.MultiClusterService{{
+				ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "bookstore-ns", Labels: map[string]string{constants.MulticlusterDiscoveredLabel: "true"}},
+				Spec: v1alpha1.MultiClusterServiceSpec{
+					ServiceAccount: "bookstore-sa",
+					Clusters:       []v1alpha1.ClusterSpec{{Name: "east", Address: "1.1.1.1:15443"}},
+				},
+			}},
+			expectedClusters: []v1alpha1.ClusterSpec{{Name: "east", Address: "3.3.3.3:15443"}},
+			expectedSA:       "bookstore-sa",
+			expectedPorts:    []v1alpha1.PortSpec{{Port: 8080, Protocol: "TCP"}},
+		},
+		{
+			name:           "exported service backed by multiple service accounts is skipped",
+			remoteClusters: []*v1alpha1.RemoteCluster{newRemoteCluster("east")},
+			remoteClients: map[string]kubernetes.Interface{
+				"east": fake.NewSimpleClientset(append(newRemoteObjects("1.1.1.1"), &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "bookstore-2", Namespace: "bookstore-ns", Labels: map[string]string{"app": "bookstore", constants.EnvoyUniqueIDLabelName: "proxy-2"}},
+					Spec:       corev1.PodSpec{ServiceAccountName: "bookstore-v2-sa"},
+				})...),
+			},
+			expectNotFound: true,
+		},
+		{
+			name:           "pods that are not meshed are ignored",
+			remoteClusters: []*v1alpha1.RemoteCluster{newRemoteCluster("east")},
+			remoteClients: map[string]kubernetes.Interface{
+				"east": fake.NewSimpleClientset(append(newRemoteObjects("1.1.1.1"), &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "bookstore-2", Namespace: "bookstore-ns", Labels: map[string]string{"app": "bookstore"}},
+					Spec:       corev1.PodSpec{ServiceAccountName: "bookstore-v2-sa"},
+				})...),
+			},
+			expectedClusters: []v1alpha1.ClusterSpec{{Name: "east", Address: "1.1.1.1:15443"}},
+			expectedSA:       "bookstore-sa",
+			expectedPorts:    []v1alpha1.PortSpec{{Port: 8080, Protocol: "TCP"}},
+		},
+		{
+			name:           "unreachable remote cluster keeps previously discovered entries",
+			remoteClusters: []*v1alpha1.RemoteCluster{newRemoteCluster("east")},
+			existing: []*v1alpha1.MultiClusterService{{
+				ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "bookstore-ns", Labels: map[string]string{constants.MulticlusterDiscoveredLabel: "true"}},
+				Spec: v1alpha1.MultiClusterServiceSpec{
+					ServiceAccount: "bookstore-sa",
+					Clusters:       []v1alpha1.ClusterSpec{{Name: "east", Address: "1.1.1.1:15443"}},
+				},
+			}},
+			expectedClusters: []v1alpha1.ClusterSpec{{Name: "east", Address: "1.1.1.1:15443"}},
+			expectedSA:       "bookstore-sa",
+		},
+		{
+			name: "removed remote cluster deletes discovered MultiClusterService",
+			existing: []*v1alpha1.MultiClusterService{{
+				ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "bookstore-ns", Labels: map[string]string{constants.MulticlusterDiscoveredLabel: "true"}},
+				Spec: v1alpha1.MultiClusterServiceSpec{
+					ServiceAccount: "bookstore-sa",
+					Clusters:       []v1alpha1.ClusterSpec{{Name: "east", Address: "1.1.1.1:15443"}},
+				},
+			}},
+			expectNotFound: true,
+		},
+		{
+			name:           "hand-written MultiClusterService is not modified",
+			remoteClusters: []*v1alpha1.RemoteCluster{newRemoteCluster("east")},
+			remoteClients: map[string]kubernetes.Interface{
+				"east": fake.NewSimpleClientset(newRemoteObjects("3.3.3.3")...),
+			},
+			existing: []*v1alpha1.MultiClusterService{{
+				ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "bookstore-ns"},
+				Spec: v1alpha1.MultiClusterServiceSpec{
+					ServiceAccount: "bookstore-sa",
+					Clusters:       []v1alpha1.ClusterSpec{{Name: "east", Address: "1.1.1.1:15443"}},
+				},
+			}},
+			expectedClusters: []v1alpha1.ClusterSpec{{Name: "east", Address: "1.1.1.1:15443"}},
+			expectedSA:       "bookstore-sa",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			var localObjects []runtime.Object
+			var configObjects []runtime.Object
+			for _, rc := range tc.remoteClusters {
+				localObjects = append(localObjects, newKubeconfigSecret(rc.Name))
+				configObjects = append(configObjects, rc)
+			}
+			for _, mcs := range tc.existing {
+				configObjects = append(configObjects, mcs)
+			}
+
+			configClient := configFake.NewSimpleClientset(configObjects...)
+			d := NewDiscoverer(fake.NewSimpleClientset(localObjects...), configClient, osmNamespace)
+			d.newRemoteClient = func(kubeconfig []byte) (kubernetes.Interface, error) {
+				client, ok := tc.remoteClients[string(kubeconfig)]
+				if !ok {
+					return nil, errors.New("unreachable")
+				}
+				return client, nil
+			}
+
+			stop := make(chan struct{})
+			defer close(stop)
+			s := d.newSyncer(stop)
+			assert.True(cache.WaitForCacheSync(stop, s.hasSynced...))
+			assert.Nil(s.sync())
+			defer s.stopRemoteClusterWatchers(nil)
+
+			mcs, err := configClient.ConfigV1alpha1().MultiClusterServices("bookstore-ns").Get(context.TODO(), "bookstore", metav1.GetOptions{})
+			if tc.expectNotFound {
+				assert.NotNil(err)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(tc.expectedClusters, mcs.Spec.Clusters)
+			assert.Equal(tc.expectedSA, mcs.Spec.ServiceAccount)
+			assert.Equal(tc.expectedPorts, mcs.Spec.Ports)
+
+			_, err = configClient.ConfigV1alpha1().MultiClusterServices("bookstore-ns").Get(context.TODO(), "not-exported", metav1.GetOptions{})
+			assert.NotNil(err)
+		})
+	}
+}
+
+func TestDiscovererWatchesRemoteClusters(t *testing.T) {
+	assert := tassert.New(t)
+
+	remoteClient := fake.NewSimpleClientset(newRemoteObjects("1.1.1.1")...)
+	configClient := configFake.NewSimpleClientset(newRemoteCluster("east"))
+	d := NewDiscoverer(fake.NewSimpleClientset(newKubeconfigSecret("east")), configClient, osmNamespace)
+	d.newRemoteClient = func(kubeconfig []byte) (kubernetes.Interface, error) {
+		return remoteClient, nil
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	d.Run(stop)
+
+	getClusters := func() []v1alpha1.ClusterSpec {
+		mcs, err := configClient.ConfigV1alpha1().MultiClusterServices("bookstore-ns").Get(context.TODO(), "bookstore", metav1.GetOptions{})
+		if err != nil {
+			return nil
+		}
+		return mcs.Spec.Clusters
+	}
+	assert.Eventually(func() bool {
+		return len(getClusters()) == 1 && getClusters()[0].Address == "1.1.1.1:15443"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A change of the gateway address in the remote cluster is discovered without polling
+	gateway, err := remoteClient.CoreV1().Services(osmNamespace).Get(context.TODO(), defaultGatewayServiceName, metav1.GetOptions{})
+	assert.Nil(err)
+	gateway.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "2.2.2.2"}}
+	_, err = remoteClient.CoreV1().Services(osmNamespace).Update(context.TODO(), gateway, metav1.UpdateOptions{})
+	assert.Nil(err)
+
+	assert.Eventually(func() bool {
+		return len(getClusters()) == 1 && getClusters()[0].Address == "2.2.2.2:15443"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSyncerReadsKubeconfigsOnRemoteClusterChanges(t *testing.T) {
+	assert := tassert.New(t)
+
+	localClient := fake.NewSimpleClientset(newKubeconfigSecret("east"))
+	d := NewDiscoverer(localClient, configFake.NewSimpleClientset(newRemoteCluster("east")), osmNamespace)
+	d.newRemoteClient = func(kubeconfig []byte) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(newRemoteObjects("1.1.1.1")...), nil
+	}
+	countSecretGets := func() int {
+		var count int
+		for _, action := range localClient.Actions() {
+			if action.GetVerb() == "get" && action.GetResource().Resource == "secrets" {
+				count++
+			}
+		}
+		return count
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	s := d.newSyncer(stop)
+	assert.True(cache.WaitForCacheSync(stop, s.hasSynced...))
+	defer s.stopRemoteClusterWatchers(nil)
+
+	// The kubeconfig of a new remote cluster is read
+	assert.Nil(s.sync())
+	assert.Equal(1, countSecretGets())
+
+	// Syncs triggered by changes in the remote clusters reuse the kubeconfigs of their watchers
+	assert.Nil(s.sync())
+	assert.Equal(1, countSecretGets())
+
+	// A change of the RemoteCluster resources reloads the kubeconfigs
+	s.remoteClusterEventHandler().OnUpdate(nil, nil)
+	assert.Nil(s.sync())
+	assert.Equal(2, countSecretGets())
+}
+
+func TestSyncWaitsForRemoteClustersConcurrently(t *testing.T) {
+	assert := tassert.New(t)
+
+	defaultTimeout := remoteCacheSyncTimeout
+	remoteCacheSyncTimeout = 500 * time.Millisecond
+	defer func() { remoteCacheSyncTimeout = defaultTimeout }()
+
+	// The lists of the unreachable clusters hang such that their caches never sync
+	hang := make(chan struct{})
+	defer close(hang)
+	newUnreachableClient := func() kubernetes.Interface {
+		client := fake.NewSimpleClientset()
+		client.PrependReactor("list", "*", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+			<-hang
+			return true, nil, errors.New("unreachable")
+		})
+		return client
+	}
+	remoteClients := map[string]kubernetes.Interface{
+		"east":  newUnreachableClient(),
+		"north": newUnreachableClient(),
+		"west":  fake.NewSimpleClientset(newRemoteObjects("1.1.1.1")...),
+	}
+
+	var localObjects, configObjects []runtime.Object
+	for name := range remoteClients {
+		localObjects = append(localObjects, newKubeconfigSecret(name))
+		configObjects = append(configObjects, newRemoteCluster(name))
+	}
+	configClient := configFake.NewSimpleClientset(configObjects...)
+	d := NewDiscoverer(fake.NewSimpleClientset(localObjects...), configClient, osmNamespace)
+	d.newRemoteClient = func(kubeconfig []byte) (kubernetes.Interface, error) {
+		return remoteClients[string(kubeconfig)], nil
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	s := d.newSyncer(stop)
+	assert.True(cache.WaitForCacheSync(stop, s.hasSynced...))
+	defer s.stopRemoteClusterWatchers(nil)
+
+	start := time.Now()
+	assert.Nil(s.sync())
+	assert.Less(int64(time.Since(start)), int64(2*remoteCacheSyncTimeout))
+
+	mcs, err := configClient.ConfigV1alpha1().MultiClusterServices("bookstore-ns").Get(context.TODO(), "bookstore", metav1.GetOptions{})
+	assert.Nil(err)
+	assert.Equal([]v1alpha1.ClusterSpec{{Name: "west", Address: "1.1.1.1:15443"}}, mcs.Spec.Clusters)
+}
+
+func TestPodEventHandler(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bookstore-1", Namespace: "bookstore-ns", Labels: map[string]string{"app": "bookstore"}},
+		Spec:       corev1.PodSpec{ServiceAccountName: "bookstore-sa"},
+	}
+	statusUpdate := pod.DeepCopy()
+	statusUpdate.Status.Phase = corev1.PodRunning
+	labelUpdate := pod.DeepCopy()
+	labelUpdate.Labels["app"] = "bookstore-v2"
+	serviceAccountUpdate := pod.DeepCopy()
+	serviceAccountUpdate.Spec.ServiceAccountName = "bookstore-v2-sa"
+
+	testCases := []struct {
+		name         string
+		newPod       *corev1.Pod
+		expectedSync bool
+	}{
+		{
+			name:         "status update",
+			newPod:       statusUpdate,
+			expectedSync: false,
+		},
+		{
+			name:         "label update",
+			newPod:       labelUpdate,
+			expectedSync: true,
+		},
+		{
+			name:         "service account update",
+			newPod:       serviceAccountUpdate,
+			expectedSync: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDiscoverer(fake.NewSimpleClientset(), configFake.NewSimpleClientset(), osmNamespace)
+			d.podEventHandler().OnUpdate(pod, tc.newPod)
+			tassert.Equal(t, tc.expectedSync, len(d.syncRequests) == 1)
+		})
+	}
+}
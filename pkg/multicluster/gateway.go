@@ -10,8 +10,8 @@ import (
 // GetMulticlusterGatewaySubjectCommonName creates a unique certificate.CommonName
 // specifically for a Multicluster Gateway. Each gateway will have its own unique
 // cert. The kind of Envoy (gateway) is encoded in the cert CN by convention.
-func GetMulticlusterGatewaySubjectCommonName(serviceAccount, namespace string) certificate.CommonName {
+func GetMulticlusterGatewaySubjectCommonName(serviceAccount, namespace, trustDomain string) certificate.CommonName {
 	gatewayUID := uuid.New()
 	envoyType := envoy.KindGateway
-	return envoy.NewXDSCertCommonName(gatewayUID, envoyType, serviceAccount, namespace)
+	return envoy.NewXDSCertCommonName(gatewayUID, envoyType, serviceAccount, namespace, trustDomain)
 }
@@ -8,6 +8,7 @@ import (
 	tassert "github.com/stretchr/testify/assert"
 
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
 )
 
 func TestMulticlusterHelpers(t *testing.T) {
@@ -15,7 +16,7 @@ func TestMulticlusterHelpers(t *testing.T) {
 	serviceAccount := "-svc-account-"
 	namespace := "-namespace-"
 
-	actualCN := GetMulticlusterGatewaySubjectCommonName(serviceAccount, namespace)
+	actualCN := GetMulticlusterGatewaySubjectCommonName(serviceAccount, namespace, identity.ClusterLocalTrustDomain)
 	expectedSuffix := ".gateway.-svc-account-.-namespace-.cluster.local"
 	assert.True(strings.HasSuffix(actualCN.String(), expectedSuffix), fmt.Sprintf("Expected the Proxy Cert's Common Name to end with %s", expectedSuffix))
 
@@ -0,0 +1,580 @@
+// Package oci implements pulling the WASM modules referenced by EnvoyFilterExtension resources from OCI registries.
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var log = logger.New("oci")
+
+const (
+	// digestPrefix is the prefix of the sha256 digests images must be referenced by
+	digestPrefix = "sha256:"
+
+	// wasmLayerMediaType is the media type of an image layer whose content is a WASM module
+	wasmLayerMediaType = "application/vnd.module.wasm.content.layer.v1+wasm"
+
+	// wasmModuleFileName is the name of the WASM module's file in an image layer archive
+	wasmModuleFileName = "plugin.wasm"
+
+	// manifestMediaTypes are the media types of the image manifests accepted from the registries
+	manifestMediaTypes = "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json"
+
+	// dockerHubRegistry is the registry of the images whose reference has no registry
+	dockerHubRegistry = "registry-1.docker.io"
+
+	// pullTimeout is the timeout of the requests to the registries
+	pullTimeout = 30 * time.Second
+
+	// maxModuleSize is the maximum size of a pulled image layer
+	maxModuleSize = 64 << 20
+
+	// initialRetryBackoff is the delay before retrying a failed pull, doubled on every subsequent failure
+	initialRetryBackoff = 10 * time.Second
+
+	// maxRetryBackoff is the maximum delay before retrying a failed pull
+	maxRetryBackoff = 5 * time.Minute
+
+	// maxCachedPulls is the maximum number of images whose pull is cached
+	maxCachedPulls = 64
+
+	// maxCacheSize is the maximum total size of the cached WASM modules
+	maxCacheSize = 256 << 20
+)
+
+// blockedIPRanges are the private address ranges the registries and their authorization servers may not resolve to,
+// in addition to the loopback, link-local, multicast and unspecified addresses
+var blockedIPRanges = mustParseCIDRs("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7")
+
+// ErrPullInProgress is the error returned for the WASM modules whose image is being pulled
+var ErrPullInProgress = errors.New("WASM module is being pulled")
+
+// Reference is the type used to represent the reference of an image pinned by digest.
+type Reference struct {
+	// Registry is the host of the registry serving the image
+	Registry string
+
+	// Repository is the repository of the image in the registry
+	Repository string
+
+	// Digest is the digest of the image manifest, sha256:<hex>
+	Digest string
+}
+
+// Puller pulls WASM modules from OCI registries in the background, so that the modules can be looked up
+// without waiting on a registry. The modules are cached by image as images referenced by digest are immutable,
+// and the failed pulls are cached and retried with an exponential backoff. The cache is bounded, the modules of
+// the images no longer referenced being evicted first, then the least recently used modules.
+//
+// Images are only pulled from the allowed registries, and the registries may not resolve to private addresses
+// so that EnvoyFilterExtension resources can not be used to issue requests to the cluster's internal endpoints.
+type Puller struct {
+	httpClient *http.Client
+
+	// onPulled is called with the image whose WASM module has been pulled
+	onPulled func(image string)
+
+	// isReferenced returns true if the given image is still referenced, failed pulls of the images
+	// no longer referenced are not retried
+	isReferenced func(image string) bool
+
+	// allowedRegistries returns the hosts, optionally with a port, of the registries images may be pulled from
+	allowedRegistries func() []string
+
+	mutex     sync.Mutex
+	pulls     map[string]*pull
+	cacheSize int
+}
+
+// pull is the state of the pull of an image
+type pull struct {
+	// module is the pulled WASM module, nil until the image has been pulled
+	module []byte
+
+	// err is the error of the last failed pull of the image, nil if the image has not failed to be pulled
+	err error
+
+	// backoff is the delay before retrying the last failed pull of the image
+	backoff time.Duration
+
+	// lastUsed is the last time the WASM module of the image was looked up
+	lastUsed time.Time
+}
+
+type manifest struct {
+	Layers []descriptor `json:"layers"`
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// NewPuller returns a Puller pulling images anonymously from their registry over HTTPS. The given onPulled
+// function is called once the WASM module of an image has been pulled, the given isReferenced function
+// determines whether a failed pull is retried and a cached module is kept, and the given allowedRegistries
+// function returns the registries images may be pulled from.
+func NewPuller(onPulled func(image string), isReferenced func(image string) bool, allowedRegistries func() []string) *Puller {
+	dialer := &net.Dialer{Timeout: pullTimeout, Control: checkDialAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Proxies are not used, as the addresses of the registries must be checked when dialing them
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Puller{
+		httpClient: &http.Client{
+			Timeout:       pullTimeout,
+			Transport:     transport,
+			CheckRedirect: checkRedirect,
+		},
+		onPulled:          onPulled,
+		isReferenced:      isReferenced,
+		allowedRegistries: allowedRegistries,
+		pulls:             make(map[string]*pull),
+	}
+}
+
+// Pull starts pulling the WASM module of the given image in the background, unless it has already been pulled
+// or is being pulled.
+func (p *Puller) Pull(image string) {
+	if _, err := p.parseAllowedReference(image); err != nil {
+		log.Error().Err(err).Msgf("Error pulling image %s", image)
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.getPull(image)
+}
+
+// GetWASMModule returns the WASM module of the given image without waiting on its registry. ErrPullInProgress
+// is returned while the image is first being pulled, and the error of the last failed pull while the pull is
+// being retried.
+func (p *Puller) GetWASMModule(image string) ([]byte, error) {
+	if _, err := p.parseAllowedReference(image); err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	state := p.getPull(image)
+	switch {
+	case state.module != nil:
+		return state.module, nil
+	case state.err != nil:
+		return nil, errors.Wrapf(state.err, "Error pulling image %s, retrying in the background", image)
+	default:
+		return nil, ErrPullInProgress
+	}
+}
+
+// getPull returns the state of the pull of the given image, and starts pulling the image if it was not pulled yet.
+// The mutex must be held by the caller.
+func (p *Puller) getPull(image string) *pull {
+	state, ok := p.pulls[image]
+	if !ok {
+		state = &pull{}
+		p.pulls[image] = state
+		p.evict(state)
+		go p.pull(image, state)
+	}
+	state.lastUsed = time.Now()
+	return state
+}
+
+// evict evicts the pulled WASM modules from the cache until it is within its bounds, starting with the modules
+// of the images no longer referenced and then the least recently used modules. The images being pulled and the
+// given pull are never evicted. The mutex must be held by the caller.
+func (p *Puller) evict(keep *pull) {
+	for len(p.pulls) > maxCachedPulls || p.cacheSize > maxCacheSize {
+		var evicted string
+		var evictedState *pull
+		for image, state := range p.pulls {
+			if state == keep || state.module == nil {
+				continue
+			}
+			if p.isReferenced != nil && !p.isReferenced(image) {
+				evicted, evictedState = image, state
+				break
+			}
+			if evictedState == nil || state.lastUsed.Before(evictedState.lastUsed) {
+				evicted, evictedState = image, state
+			}
+		}
+		if evictedState == nil {
+			return
+		}
+
+		delete(p.pulls, evicted)
+		p.cacheSize -= len(evictedState.module)
+		log.Debug().Msgf("Evicted WASM module of image %s from the cache", evicted)
+	}
+}
+
+// pull pulls the WASM module of the given image, and schedules a retry with an exponential backoff if it fails
+func (p *Puller) pull(image string, state *pull) {
+	module, err := p.pullWASMModule(image)
+
+	p.mutex.Lock()
+	if err == nil {
+		state.module, state.err = module, nil
+		p.cacheSize += len(module)
+		p.evict(state)
+		p.mutex.Unlock()
+
+		log.Debug().Msgf("Pulled WASM module of image %s", image)
+		if p.onPulled != nil {
+			p.onPulled(image)
+		}
+		return
+	}
+
+	state.err = err
+	state.backoff *= 2
+	if state.backoff < initialRetryBackoff {
+		state.backoff = initialRetryBackoff
+	}
+	if state.backoff > maxRetryBackoff {
+		state.backoff = maxRetryBackoff
+	}
+	backoff := state.backoff
+	p.mutex.Unlock()
+
+	log.Error().Err(err).Msgf("Error pulling WASM module of image %s, retrying in %s", image, backoff)
+	time.AfterFunc(backoff, func() {
+		if p.isReferenced != nil && !p.isReferenced(image) {
+			// The image is pulled again if it is referenced again
+			p.mutex.Lock()
+			delete(p.pulls, image)
+			p.mutex.Unlock()
+			return
+		}
+		p.pull(image, state)
+	})
+}
+
+// parseAllowedReference parses the given image reference, and returns an error if its registry is not allowed
+func (p *Puller) parseAllowedReference(image string) (Reference, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return Reference{}, err
+	}
+	if !p.isRegistryAllowed(ref.Registry) {
+		return Reference{}, errors.Errorf("Registry %s of image %s is not allowed, allowed registries are %v", ref.Registry, image, p.getAllowedRegistries())
+	}
+	return ref, nil
+}
+
+// isRegistryAllowed returns true if the given registry host, optionally with a port, is allowed.
+// docker.io is allowed as the Docker Hub registry.
+func (p *Puller) isRegistryAllowed(registry string) bool {
+	for _, allowed := range p.getAllowedRegistries() {
+		if allowed == "docker.io" {
+			allowed = dockerHubRegistry
+		}
+		if strings.EqualFold(allowed, registry) {
+			return true
+		}
+	}
+	return false
+}
+
+// getAllowedRegistries returns the registries images may be pulled from
+func (p *Puller) getAllowedRegistries() []string {
+	if p.allowedRegistries == nil {
+		return nil
+	}
+	return p.allowedRegistries()
+}
+
+// ParseReference parses an image reference of the form [<registry>/]<repository>@sha256:<digest>.
+// As for Docker, the registry defaults to Docker Hub when the first component of the reference
+// is not a host name.
+func ParseReference(image string) (Reference, error) {
+	chunks := strings.SplitN(image, "@", 2)
+	if len(chunks) != 2 {
+		return Reference{}, errors.Errorf("Image %q must be referenced by digest", image)
+	}
+	name, digest := chunks[0], chunks[1]
+
+	hexDigest := strings.TrimPrefix(digest, digestPrefix)
+	if hexDigest == digest || len(hexDigest) != sha256.Size*2 {
+		return Reference{}, errors.Errorf("Invalid digest %q for image %q, expected sha256:<64 hex characters>", digest, image)
+	}
+	if _, err := hex.DecodeString(hexDigest); err != nil {
+		return Reference{}, errors.Errorf("Invalid digest %q for image %q, expected sha256:<64 hex characters>", digest, image)
+	}
+
+	ref := Reference{Registry: dockerHubRegistry, Repository: name, Digest: digest}
+	if i := strings.Index(name, "/"); i > 0 {
+		first := name[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			ref.Registry, ref.Repository = first, name[i+1:]
+		}
+	}
+	if ref.Repository == "" || strings.ToLower(ref.Repository) != ref.Repository {
+		return Reference{}, errors.Errorf("Invalid repository for image %q", image)
+	}
+	if ref.Registry == dockerHubRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = path.Join("library", ref.Repository)
+	}
+
+	return ref, nil
+}
+
+// pullWASMModule pulls the WASM module of the given image, which is either the content of its layer with
+// the WASM media type, or the plugin.wasm file of its only layer.
+func (p *Puller) pullWASMModule(image string) ([]byte, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestBytes, err := p.fetch(ref, "manifests", ref.Digest, manifestMediaTypes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error fetching manifest of image %s", image)
+	}
+	var m manifest
+	if err := json.Unmarshal(manifestBytes, &m); err != nil {
+		return nil, errors.Wrapf(err, "Error unmarshalling manifest of image %s", image)
+	}
+
+	layer, err := getWASMLayer(m)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error finding WASM module in image %s", image)
+	}
+	layerBytes, err := p.fetch(ref, "blobs", layer.Digest, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error fetching layer %s of image %s", layer.Digest, image)
+	}
+
+	module := layerBytes
+	if layer.MediaType != wasmLayerMediaType {
+		if module, err = extractWASMModule(layerBytes); err != nil {
+			return nil, errors.Wrapf(err, "Error extracting WASM module from layer %s of image %s", layer.Digest, image)
+		}
+	}
+
+	return module, nil
+}
+
+// getWASMLayer returns the layer of the given manifest holding the WASM module
+func getWASMLayer(m manifest) (descriptor, error) {
+	for _, layer := range m.Layers {
+		if layer.MediaType == wasmLayerMediaType {
+			return layer, nil
+		}
+	}
+	if len(m.Layers) != 1 {
+		return descriptor{}, errors.Errorf("Expected a layer with media type %s or a single layer, found %d layers", wasmLayerMediaType, len(m.Layers))
+	}
+	return m.Layers[0], nil
+}
+
+// extractWASMModule returns the plugin.wasm file of the given layer archive, which may be gzip compressed
+func extractWASMModule(layer []byte) ([]byte, error) {
+	var reader io.Reader = bytes.NewReader(layer)
+	if gzipReader, err := gzip.NewReader(bytes.NewReader(layer)); err == nil {
+		reader = gzipReader
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, errors.Errorf("File %s not found in layer", wasmModuleFileName)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if path.Clean(header.Name) == wasmModuleFileName {
+			return ioutil.ReadAll(io.LimitReader(tarReader, maxModuleSize))
+		}
+	}
+}
+
+// fetch returns the content of the given manifest or blob, after verifying it matches its digest
+func (p *Puller) fetch(ref Reference, kind, digest, accept string) ([]byte, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/%s/%s", ref.Registry, ref.Repository, kind, digest)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint: errcheck,gosec
+
+	// Registries require a token even for anonymous pulls
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := p.getAnonymousToken(ref, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		if resp, err = p.httpClient.Do(req); err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close() //nolint: errcheck,gosec
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Unexpected status %s from %s", resp.Status, url)
+	}
+
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxModuleSize))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	if digestPrefix+hex.EncodeToString(sum[:]) != digest {
+		return nil, errors.Errorf("Content of %s does not match its digest", url)
+	}
+
+	return content, nil
+}
+
+// getAnonymousToken returns an anonymous token from the authorization server of the given Bearer challenge.
+// The authorization server must be served over HTTPS by the registry's host or an allowed registry.
+func (p *Puller) getAnonymousToken(ref Reference, challenge string) (string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", errors.Errorf("Unsupported authentication challenge %q", challenge)
+	}
+
+	params := make(map[string]string)
+	for _, param := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) == 2 {
+			params[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+	}
+	if params["realm"] == "" {
+		return "", errors.Errorf("Missing realm in authentication challenge %q", challenge)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", errors.Wrapf(err, "Invalid realm in authentication challenge %q", challenge)
+	}
+	if realm.Scheme != "https" {
+		return "", errors.Errorf("Realm %s of registry %s must use https", realm, ref.Registry)
+	}
+	if !strings.EqualFold(realm.Hostname(), hostname(ref.Registry)) && !p.isRegistryAllowed(realm.Host) {
+		return "", errors.Errorf("Realm %s is neither on the host of registry %s nor on an allowed registry", realm, ref.Registry)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	query := req.URL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() //nolint: errcheck,gosec
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("Unexpected status %s from %s", resp.Status, params["realm"])
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", errors.Wrap(err, "Error decoding token")
+	}
+	if tokenResp.Token != "" {
+		return tokenResp.Token, nil
+	}
+	return tokenResp.AccessToken, nil
+}
+
+// hostname returns the host of the given registry without its port
+func hostname(registry string) string {
+	if host, _, err := net.SplitHostPort(registry); err == nil {
+		return host
+	}
+	return registry
+}
+
+// checkRedirect only follows the redirects over HTTPS, the redirections being otherwise checked when dialing
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Scheme != "https" {
+		return errors.Errorf("Redirect to %s must use https", req.URL)
+	}
+	if len(via) >= 10 {
+		return errors.New("Stopped after 10 redirects")
+	}
+	return nil
+}
+
+// checkDialAddress returns an error if the resolved address being dialed is a loopback, link-local, multicast,
+// unspecified or private address
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return errors.Errorf("Invalid address %s", address)
+	}
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() {
+		return errors.Errorf("Address %s is not allowed", address)
+	}
+	for _, ipRange := range blockedIPRanges {
+		if ipRange.Contains(ip) {
+			return errors.Errorf("Private address %s is not allowed", address)
+		}
+	}
+	return nil
+}
+
+// mustParseCIDRs parses the given CIDRs, and panics if one of them is invalid
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	var ipNets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets
+}
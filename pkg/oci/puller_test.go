@@ -0,0 +1,432 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+
+	testCases := []struct {
+		name        string
+		image       string
+		expectedRef Reference
+		expectedErr bool
+	}{
+		{
+			name:        "image with registry",
+			image:       "ghcr.io/org/filter@" + digest,
+			expectedRef: Reference{Registry: "ghcr.io", Repository: "org/filter", Digest: digest},
+		},
+		{
+			name:        "image with registry port",
+			image:       "localhost:5000/filter@" + digest,
+			expectedRef: Reference{Registry: "localhost:5000", Repository: "filter", Digest: digest},
+		},
+		{
+			name:        "Docker Hub image",
+			image:       "org/filter@" + digest,
+			expectedRef: Reference{Registry: dockerHubRegistry, Repository: "org/filter", Digest: digest},
+		},
+		{
+			name:        "Docker Hub official image",
+			image:       "filter@" + digest,
+			expectedRef: Reference{Registry: dockerHubRegistry, Repository: "library/filter", Digest: digest},
+		},
+		{
+			name:        "image referenced by tag",
+			image:       "ghcr.io/org/filter:v1",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid digest",
+			image:       "ghcr.io/org/filter@sha256:abc",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			ref, err := ParseReference(tc.image)
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Equal(tc.expectedRef, ref)
+		})
+	}
+}
+
+func TestPullWASMModule(t *testing.T) {
+	module := []byte("\x00asm\x01\x00\x00\x00")
+
+	var archive bytes.Buffer
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	tassert.Nil(t, tarWriter.WriteHeader(&tar.Header{Name: "./plugin.wasm", Mode: 0600, Size: int64(len(module))}))
+	_, err := tarWriter.Write(module)
+	tassert.Nil(t, err)
+	tassert.Nil(t, tarWriter.Close())
+	tassert.Nil(t, gzipWriter.Close())
+
+	testCases := []struct {
+		name           string
+		layerMediaType string
+		layer          []byte
+		requireToken   bool
+		realm          string
+		expectedErr    bool
+	}{
+		{
+			name:           "WASM layer",
+			layerMediaType: wasmLayerMediaType,
+			layer:          module,
+		},
+		{
+			name:           "compressed archive layer",
+			layerMediaType: "application/vnd.oci.image.layer.v1.tar+gzip",
+			layer:          archive.Bytes(),
+		},
+		{
+			name:           "WASM layer from a registry requiring a token",
+			layerMediaType: wasmLayerMediaType,
+			layer:          module,
+			requireToken:   true,
+		},
+		{
+			name:           "token realm not served over HTTPS",
+			layerMediaType: wasmLayerMediaType,
+			layer:          module,
+			requireToken:   true,
+			realm:          "http://%s/token",
+			expectedErr:    true,
+		},
+		{
+			name:           "token realm on another host",
+			layerMediaType: wasmLayerMediaType,
+			layer:          module,
+			requireToken:   true,
+			realm:          "https://auth.example.com/token",
+			expectedErr:    true,
+		},
+		{
+			name:           "archive layer without WASM module",
+			layerMediaType: "application/vnd.oci.image.layer.v1.tar",
+			layer:          []byte("not an archive"),
+			expectedErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			layerDigest := getDigest(tc.layer)
+			manifestBytes, err := json.Marshal(manifest{Layers: []descriptor{{MediaType: tc.layerMediaType, Digest: layerDigest}}})
+			assert.Nil(err)
+			manifestDigest := getDigest(manifestBytes)
+
+			var server *httptest.Server
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/token" {
+					_, _ = w.Write([]byte(`{"token": "anonymous"}`))
+					return
+				}
+				if tc.requireToken && r.Header.Get("Authorization") != "Bearer anonymous" {
+					realm := server.URL + "/token"
+					if tc.realm != "" {
+						realm = strings.ReplaceAll(tc.realm, "%s", server.Listener.Addr().String())
+					}
+					w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s",service="registry",scope="repository:org/filter:pull"`, realm))
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				switch r.URL.Path {
+				case "/v2/org/filter/manifests/" + manifestDigest:
+					_, _ = w.Write(manifestBytes)
+				case "/v2/org/filter/blobs/" + layerDigest:
+					_, _ = w.Write(tc.layer)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			puller := &Puller{httpClient: server.Client()}
+			image := fmt.Sprintf("%s/org/filter@%s", server.Listener.Addr().String(), manifestDigest)
+
+			actual, err := puller.pullWASMModule(image)
+			assert.Equal(tc.expectedErr, err != nil)
+			if !tc.expectedErr {
+				assert.Equal(module, actual)
+			}
+		})
+	}
+}
+
+func TestGetWASMModule(t *testing.T) {
+	module := []byte("\x00asm\x01\x00\x00\x00")
+	layerDigest := getDigest(module)
+	manifestBytes, err := json.Marshal(manifest{Layers: []descriptor{{MediaType: wasmLayerMediaType, Digest: layerDigest}}})
+	tassert.Nil(t, err)
+	manifestDigest := getDigest(manifestBytes)
+
+	testCases := []struct {
+		name        string
+		available   bool
+		expectedErr bool
+	}{
+		{
+			name:      "module pulled in the background",
+			available: true,
+		},
+		{
+			name:        "failed pull is cached",
+			available:   false,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			var requests int32
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				switch {
+				case !tc.available:
+					w.WriteHeader(http.StatusServiceUnavailable)
+				case r.URL.Path == "/v2/org/filter/manifests/"+manifestDigest:
+					_, _ = w.Write(manifestBytes)
+				case r.URL.Path == "/v2/org/filter/blobs/"+layerDigest:
+					_, _ = w.Write(module)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			image := fmt.Sprintf("%s/org/filter@%s", server.Listener.Addr().String(), manifestDigest)
+			pulled := make(chan string, 1)
+			registry := server.Listener.Addr().String()
+			puller := NewPuller(func(image string) { pulled <- image }, func(string) bool { return false }, func() []string { return []string{registry} })
+			puller.httpClient = server.Client()
+
+			// The module is not waited on while it is being pulled
+			_, err := puller.GetWASMModule(image)
+			assert.Equal(ErrPullInProgress, err)
+
+			if tc.available {
+				select {
+				case actual := <-pulled:
+					assert.Equal(image, actual)
+				case <-time.After(5 * time.Second):
+					assert.Fail("Timed out waiting for the module to be pulled")
+				}
+			} else {
+				assert.Eventually(func() bool {
+					_, err := puller.GetWASMModule(image)
+					return err != nil && err != ErrPullInProgress
+				}, 5*time.Second, 10*time.Millisecond)
+			}
+
+			// The result of the pull is cached without pulling the image again
+			pullRequests := atomic.LoadInt32(&requests)
+			actual, err := puller.GetWASMModule(image)
+			assert.Equal(tc.expectedErr, err != nil)
+			if !tc.expectedErr {
+				assert.Equal(module, actual)
+			}
+			assert.Equal(pullRequests, atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestGetWASMModuleInvalidImage(t *testing.T) {
+	assert := tassert.New(t)
+
+	puller := NewPuller(nil, nil, func() []string { return []string{"docker.io"} })
+	_, err := puller.GetWASMModule("org/filter:latest")
+	assert.NotNil(err)
+	assert.Empty(puller.pulls)
+}
+
+func TestGetWASMModuleRegistryNotAllowed(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+
+	testCases := []struct {
+		name              string
+		image             string
+		allowedRegistries []string
+		expectedErr       bool
+	}{
+		{
+			name:              "allowed registry",
+			image:             "ghcr.io/org/filter@" + digest,
+			allowedRegistries: []string{"GHCR.io"},
+		},
+		{
+			name:              "Docker Hub allowed as docker.io",
+			image:             "org/filter@" + digest,
+			allowedRegistries: []string{"docker.io"},
+		},
+		{
+			name:              "registry not allowed",
+			image:             "registry.example.com/org/filter@" + digest,
+			allowedRegistries: []string{"ghcr.io"},
+			expectedErr:       true,
+		},
+		{
+			name:              "registry allowed on another port",
+			image:             "ghcr.io:8443/org/filter@" + digest,
+			allowedRegistries: []string{"ghcr.io"},
+			expectedErr:       true,
+		},
+		{
+			name:        "no allowed registry",
+			image:       "ghcr.io/org/filter@" + digest,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			puller := NewPuller(nil, nil, func() []string { return tc.allowedRegistries })
+			// Requests are not sent to the registries
+			puller.httpClient = &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				return nil, fmt.Errorf("unexpected request")
+			})}
+
+			_, err := puller.GetWASMModule(tc.image)
+			if tc.expectedErr {
+				assert.NotNil(err)
+				assert.NotEqual(ErrPullInProgress, err)
+				assert.Empty(puller.pulls)
+			} else {
+				assert.Equal(ErrPullInProgress, err)
+			}
+		})
+	}
+}
+
+func TestPullerRejectsPrivateAddresses(t *testing.T) {
+	assert := tassert.New(t)
+
+	var requests int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	registry := server.Listener.Addr().String()
+	puller := NewPuller(nil, nil, func() []string { return []string{registry} })
+
+	_, err := puller.pullWASMModule(fmt.Sprintf("%s/org/filter@sha256:%s", registry, strings.Repeat("ab", 32)))
+	assert.NotNil(err)
+	assert.Zero(atomic.LoadInt32(&requests))
+}
+
+func TestCheckDialAddress(t *testing.T) {
+	testCases := []struct {
+		address     string
+		expectedErr bool
+	}{
+		{address: "140.82.112.34:443"},
+		{address: "[2606:50c0:8000::154]:443"},
+		{address: "127.0.0.1:443", expectedErr: true},
+		{address: "[::1]:443", expectedErr: true},
+		{address: "169.254.169.254:80", expectedErr: true},
+		{address: "[fe80::1]:443", expectedErr: true},
+		{address: "10.0.0.1:443", expectedErr: true},
+		{address: "172.16.0.1:443", expectedErr: true},
+		{address: "192.168.1.1:443", expectedErr: true},
+		{address: "100.64.0.1:443", expectedErr: true},
+		{address: "[fd00::1]:443", expectedErr: true},
+		{address: "0.0.0.0:443", expectedErr: true},
+		{address: "224.0.0.1:443", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.address, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expectedErr, checkDialAddress("tcp", tc.address, nil) != nil)
+		})
+	}
+}
+
+func TestCheckRedirect(t *testing.T) {
+	assert := tassert.New(t)
+
+	httpsReq := httptest.NewRequest(http.MethodGet, "https://cdn.example.com/blob", nil)
+	assert.Nil(checkRedirect(httpsReq, nil))
+
+	httpReq := httptest.NewRequest(http.MethodGet, "http://cdn.example.com/blob", nil)
+	assert.NotNil(checkRedirect(httpReq, nil))
+}
+
+func TestEvict(t *testing.T) {
+	assert := tassert.New(t)
+
+	now := time.Now()
+	puller := NewPuller(nil, func(image string) bool { return image != "unreferenced" }, nil)
+	puller.pulls = map[string]*pull{
+		"unreferenced": {module: []byte("a"), lastUsed: now},
+		"least-recent": {module: []byte("b"), lastUsed: now.Add(-time.Hour)},
+		"in-progress":  {lastUsed: now.Add(-2 * time.Hour)},
+	}
+	for i := 0; i < maxCachedPulls-2; i++ {
+		puller.pulls[fmt.Sprintf("image-%d", i)] = &pull{module: []byte("c"), lastUsed: now}
+	}
+	puller.cacheSize = len(puller.pulls) - 1
+
+	// The modules of the images no longer referenced are evicted first
+	puller.evict(nil)
+	assert.Len(puller.pulls, maxCachedPulls)
+	assert.NotContains(puller.pulls, "unreferenced")
+
+	// Then the least recently used modules, the images being pulled are never evicted
+	added := &pull{lastUsed: now}
+	puller.pulls["added"] = added
+	puller.evict(added)
+	assert.Len(puller.pulls, maxCachedPulls)
+	assert.NotContains(puller.pulls, "least-recent")
+	assert.Contains(puller.pulls, "in-progress")
+	assert.Contains(puller.pulls, "added")
+	assert.Equal(maxCachedPulls-2, puller.cacheSize)
+
+	// The total size of the modules is bounded
+	delete(puller.pulls, "added")
+	puller.pulls["large"] = &pull{module: make([]byte, maxCacheSize), lastUsed: now.Add(-time.Minute)}
+	puller.cacheSize += maxCacheSize
+	puller.evict(nil)
+	assert.NotContains(puller.pulls, "large")
+	assert.Len(puller.pulls, maxCachedPulls-1)
+	assert.Equal(maxCachedPulls-2, puller.cacheSize)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func getDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -1,6 +1,8 @@
 package policy
 
 import (
+	"sort"
+
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
@@ -36,13 +38,17 @@ func newPolicyClient(policyClient policyClientset.Interface, kubeController k8s.
 	informerFactory := policyInformers.NewSharedInformerFactory(policyClient, k8s.DefaultKubeEventResyncInterval)
 
 	informerCollection := informerCollection{
-		egress:         informerFactory.Policy().V1alpha1().Egresses().Informer(),
-		ingressBackend: informerFactory.Policy().V1alpha1().IngressBackends().Informer(),
+		egress:               informerFactory.Policy().V1alpha1().Egresses().Informer(),
+		ingressBackend:       informerFactory.Policy().V1alpha1().IngressBackends().Informer(),
+		authorizationPolicy:  informerFactory.Policy().V1alpha1().AuthorizationPolicies().Informer(),
+		envoyFilterExtension: informerFactory.Policy().V1alpha1().EnvoyFilterExtensions().Informer(),
 	}
 
 	cacheCollection := cacheCollection{
-		egress:         informerCollection.egress.GetStore(),
-		ingressBackend: informerCollection.ingressBackend.GetStore(),
+		egress:               informerCollection.egress.GetStore(),
+		ingressBackend:       informerCollection.ingressBackend.GetStore(),
+		authorizationPolicy:  informerCollection.authorizationPolicy.GetStore(),
+		envoyFilterExtension: informerCollection.envoyFilterExtension.GetStore(),
 	}
 
 	client := client{
@@ -71,6 +77,18 @@ func newPolicyClient(policyClient policyClientset.Interface, kubeController k8s.
 		Delete: announcements.IngressBackendDeleted,
 	}
 	informerCollection.ingressBackend.AddEventHandler(k8s.GetKubernetesEventHandlers("IngressBackend", "Policy", shouldObserve, ingressBackendEventTypes))
+	authorizationPolicyEventTypes := k8s.EventTypes{
+		Add:    announcements.AuthorizationPolicyAdded,
+		Update: announcements.AuthorizationPolicyUpdated,
+		Delete: announcements.AuthorizationPolicyDeleted,
+	}
+	informerCollection.authorizationPolicy.AddEventHandler(k8s.GetKubernetesEventHandlers("AuthorizationPolicy", "Policy", shouldObserve, authorizationPolicyEventTypes))
+	envoyFilterExtensionEventTypes := k8s.EventTypes{
+		Add:    announcements.EnvoyFilterExtensionAdded,
+		Update: announcements.EnvoyFilterExtensionUpdated,
+		Delete: announcements.EnvoyFilterExtensionDeleted,
+	}
+	informerCollection.envoyFilterExtension.AddEventHandler(k8s.GetKubernetesEventHandlers("EnvoyFilterExtension", "Policy", shouldObserve, envoyFilterExtensionEventTypes))
 
 	err := client.run(stop)
 	if err != nil {
@@ -88,8 +106,10 @@ func (c client) run(stop <-chan struct{}) error {
 	}
 
 	sharedInformers := map[string]cache.SharedInformer{
-		"Egress":         c.informers.egress,
-		"IngressBackend": c.informers.ingressBackend,
+		"Egress":               c.informers.egress,
+		"IngressBackend":       c.informers.ingressBackend,
+		"AuthorizationPolicy":  c.informers.authorizationPolicy,
+		"EnvoyFilterExtension": c.informers.envoyFilterExtension,
 	}
 
 	var informerNames []string
@@ -135,6 +155,22 @@ func (c client) ListEgressPoliciesForSourceIdentity(source identity.K8sServiceAc
 	return policies
 }
 
+// ListEgressPolicies lists the Egress policies in the monitored namespaces
+func (c client) ListEgressPolicies() []*policyV1alpha1.Egress {
+	var policies []*policyV1alpha1.Egress
+
+	for _, egressIface := range c.caches.egress.List() {
+		egressPolicy := egressIface.(*policyV1alpha1.Egress)
+
+		if !c.kubeController.IsMonitoredNamespace(egressPolicy.Namespace) {
+			continue
+		}
+		policies = append(policies, egressPolicy)
+	}
+
+	return policies
+}
+
 // GetIngressBackendPolicy returns the IngressBackend policy for the given backend MeshService
 func (c client) GetIngressBackendPolicy(svc service.MeshService) *policyV1alpha1.IngressBackend {
 	for _, ingressBackendIface := range c.caches.ingressBackend.List() {
@@ -156,3 +192,67 @@ func (c client) GetIngressBackendPolicy(svc service.MeshService) *policyV1alpha1
 
 	return nil
 }
+
+// ListAuthorizationPolicies lists the AuthorizationPolicy policies applicable to the given MeshService.
+// The policies are sorted by name so that the resulting configuration is deterministic.
+func (c client) ListAuthorizationPolicies(svc service.MeshService) []*policyV1alpha1.AuthorizationPolicy {
+	var policies []*policyV1alpha1.AuthorizationPolicy
+
+	for _, authzPolicyIface := range c.caches.authorizationPolicy.List() {
+		authzPolicy := authzPolicyIface.(*policyV1alpha1.AuthorizationPolicy)
+
+		if authzPolicy.Namespace != svc.Namespace || !c.kubeController.IsMonitoredNamespace(authzPolicy.Namespace) {
+			continue
+		}
+
+		if len(authzPolicy.Spec.Services) == 0 {
+			policies = append(policies, authzPolicy)
+			continue
+		}
+
+		for _, svcName := range authzPolicy.Spec.Services {
+			if svcName == svc.Name {
+				policies = append(policies, authzPolicy)
+				break
+			}
+		}
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	return policies
+}
+
+// ListEnvoyFilterExtensions lists the EnvoyFilterExtension resources applicable to the proxies of the given service account.
+// The extensions are sorted by name so that the resulting configuration is deterministic.
+func (c client) ListEnvoyFilterExtensions(svcAccount identity.K8sServiceAccount) []*policyV1alpha1.EnvoyFilterExtension {
+	var extensions []*policyV1alpha1.EnvoyFilterExtension
+
+	for _, extensionIface := range c.caches.envoyFilterExtension.List() {
+		extension := extensionIface.(*policyV1alpha1.EnvoyFilterExtension)
+
+		if extension.Namespace != svcAccount.Namespace || !c.kubeController.IsMonitoredNamespace(extension.Namespace) {
+			continue
+		}
+
+		if len(extension.Spec.ServiceAccounts) == 0 {
+			extensions = append(extensions, extension)
+			continue
+		}
+
+		for _, svcAccountName := range extension.Spec.ServiceAccounts {
+			if svcAccountName == svcAccount.Name {
+				extensions = append(extensions, extension)
+				break
+			}
+		}
+	}
+
+	sort.Slice(extensions, func(i, j int) bool {
+		return extensions[i].Name < extensions[j].Name
+	})
+
+	return extensions
+}
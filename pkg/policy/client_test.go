@@ -162,6 +162,28 @@ func TestListEgressPoliciesForSourceIdentity(t *testing.T) {
 	}
 }
 
+func TestListEgressPolicies(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+	mockKubeController.EXPECT().IsMonitoredNamespace("unmonitored").Return(false).AnyTimes()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	monitored := &policyV1alpha1.Egress{ObjectMeta: metav1.ObjectMeta{Name: "egress-1", Namespace: "test"}}
+	unmonitored := &policyV1alpha1.Egress{ObjectMeta: metav1.ObjectMeta{Name: "egress-2", Namespace: "unmonitored"}}
+
+	fakepolicyClientSet := fakePolicyClient.NewSimpleClientset(monitored, unmonitored)
+	policyClient, err := newPolicyClient(fakepolicyClientSet, mockKubeController, stop)
+	assert.Nil(err)
+
+	assert.ElementsMatch([]*policyV1alpha1.Egress{monitored}, policyClient.ListEgressPolicies())
+}
+
 func TestGetIngressBackendPolicy(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -296,3 +318,197 @@ func TestGetIngressBackendPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestListAuthorizationPolicies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+
+	allowPolicy := &policyV1alpha1.AuthorizationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "allow-frontend",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.AuthorizationPolicySpec{
+			Services: []string{"backend1"},
+			Action:   policyV1alpha1.AuthorizationActionAllow,
+			Rules: []policyV1alpha1.AuthorizationRule{
+				{
+					Principals: []string{"test/frontend"},
+				},
+			},
+		},
+	}
+	denyPolicy := &policyV1alpha1.AuthorizationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deny-admin",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.AuthorizationPolicySpec{
+			// Applies to all services in the namespace
+			Action: policyV1alpha1.AuthorizationActionDeny,
+			Rules: []policyV1alpha1.AuthorizationRule{
+				{
+					Paths: []string{"/admin*"},
+				},
+			},
+		},
+	}
+	otherSvcPolicy := &policyV1alpha1.AuthorizationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "allow-other",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.AuthorizationPolicySpec{
+			Services: []string{"backend2"},
+			Action:   policyV1alpha1.AuthorizationActionAllow,
+			Rules: []policyV1alpha1.AuthorizationRule{
+				{
+					Namespaces: []string{"test"},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		allResources     []*policyV1alpha1.AuthorizationPolicy
+		svc              service.MeshService
+		expectedPolicies []*policyV1alpha1.AuthorizationPolicy
+	}{
+		{
+			name:             "no AuthorizationPolicy found",
+			allResources:     nil,
+			svc:              service.MeshService{Name: "backend1", Namespace: "test"},
+			expectedPolicies: nil,
+		},
+		{
+			name:             "AuthorizationPolicy policies for the service and namespace are found",
+			allResources:     []*policyV1alpha1.AuthorizationPolicy{denyPolicy, otherSvcPolicy, allowPolicy},
+			svc:              service.MeshService{Name: "backend1", Namespace: "test"},
+			expectedPolicies: []*policyV1alpha1.AuthorizationPolicy{allowPolicy, denyPolicy},
+		},
+		{
+			name:             "AuthorizationPolicy in a different namespace is ignored",
+			allResources:     []*policyV1alpha1.AuthorizationPolicy{allowPolicy},
+			svc:              service.MeshService{Name: "backend1", Namespace: "other"},
+			expectedPolicies: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			fakepolicyClientSet := fakePolicyClient.NewSimpleClientset()
+
+			for _, authzPolicy := range tc.allResources {
+				_, err := fakepolicyClientSet.PolicyV1alpha1().AuthorizationPolicies(authzPolicy.Namespace).Create(context.TODO(), authzPolicy, metav1.CreateOptions{})
+				assert.Nil(err)
+			}
+
+			policyClient, err := newPolicyClient(fakepolicyClientSet, mockKubeController, make(chan struct{}))
+			assert.Nil(err)
+			assert.NotNil(policyClient)
+
+			actual := policyClient.ListAuthorizationPolicies(tc.svc)
+			assert.Equal(tc.expectedPolicies, actual)
+		})
+	}
+}
+
+func TestListEnvoyFilterExtensions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+
+	headersExtension := &policyV1alpha1.EnvoyFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "normalize-headers",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+			ServiceAccounts: []string{"sa-1"},
+			Direction:       policyV1alpha1.EnvoyFilterDirectionInbound,
+			Lua: &policyV1alpha1.LuaFilterSpec{
+				InlineCode: "function envoy_on_request(request_handle) end",
+			},
+		},
+	}
+	namespaceExtension := &policyV1alpha1.EnvoyFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "legacy-auth",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+			// Applies to all proxies in the namespace
+			Direction: policyV1alpha1.EnvoyFilterDirectionOutbound,
+			WASM: &policyV1alpha1.WASMFilterSpec{
+				Image: "ghcr.io/example/legacy-auth@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			},
+		},
+	}
+	otherSvcAccountExtension := &policyV1alpha1.EnvoyFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.EnvoyFilterExtensionSpec{
+			ServiceAccounts: []string{"sa-2"},
+			Direction:       policyV1alpha1.EnvoyFilterDirectionInbound,
+			Lua: &policyV1alpha1.LuaFilterSpec{
+				InlineCode: "function envoy_on_response(response_handle) end",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		allResources       []*policyV1alpha1.EnvoyFilterExtension
+		svcAccount         identity.K8sServiceAccount
+		expectedExtensions []*policyV1alpha1.EnvoyFilterExtension
+	}{
+		{
+			name:               "no EnvoyFilterExtension found",
+			allResources:       nil,
+			svcAccount:         identity.K8sServiceAccount{Name: "sa-1", Namespace: "test"},
+			expectedExtensions: nil,
+		},
+		{
+			name:               "EnvoyFilterExtension resources for the service account and namespace are found",
+			allResources:       []*policyV1alpha1.EnvoyFilterExtension{otherSvcAccountExtension, headersExtension, namespaceExtension},
+			svcAccount:         identity.K8sServiceAccount{Name: "sa-1", Namespace: "test"},
+			expectedExtensions: []*policyV1alpha1.EnvoyFilterExtension{namespaceExtension, headersExtension},
+		},
+		{
+			name:               "EnvoyFilterExtension in a different namespace is ignored",
+			allResources:       []*policyV1alpha1.EnvoyFilterExtension{headersExtension},
+			svcAccount:         identity.K8sServiceAccount{Name: "sa-1", Namespace: "other"},
+			expectedExtensions: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			fakepolicyClientSet := fakePolicyClient.NewSimpleClientset()
+
+			for _, extension := range tc.allResources {
+				_, err := fakepolicyClientSet.PolicyV1alpha1().EnvoyFilterExtensions(extension.Namespace).Create(context.TODO(), extension, metav1.CreateOptions{})
+				assert.Nil(err)
+			}
+
+			policyClient, err := newPolicyClient(fakepolicyClientSet, mockKubeController, make(chan struct{}))
+			assert.Nil(err)
+			assert.NotNil(policyClient)
+
+			actual := policyClient.ListEnvoyFilterExtensions(tc.svcAccount)
+			assert.Equal(tc.expectedExtensions, actual)
+		})
+	}
+}
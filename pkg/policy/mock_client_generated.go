@@ -50,6 +50,48 @@ func (mr *MockControllerMockRecorder) GetIngressBackendPolicy(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressBackendPolicy", reflect.TypeOf((*MockController)(nil).GetIngressBackendPolicy), arg0)
 }
 
+// ListAuthorizationPolicies mocks base method
+func (m *MockController) ListAuthorizationPolicies(arg0 service.MeshService) []*v1alpha1.AuthorizationPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthorizationPolicies", arg0)
+	ret0, _ := ret[0].([]*v1alpha1.AuthorizationPolicy)
+	return ret0
+}
+
+// ListAuthorizationPolicies indicates an expected call of ListAuthorizationPolicies
+func (mr *MockControllerMockRecorder) ListAuthorizationPolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthorizationPolicies", reflect.TypeOf((*MockController)(nil).ListAuthorizationPolicies), arg0)
+}
+
+// ListEnvoyFilterExtensions mocks base method
+func (m *MockController) ListEnvoyFilterExtensions(arg0 identity.K8sServiceAccount) []*v1alpha1.EnvoyFilterExtension {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnvoyFilterExtensions", arg0)
+	ret0, _ := ret[0].([]*v1alpha1.EnvoyFilterExtension)
+	return ret0
+}
+
+// ListEnvoyFilterExtensions indicates an expected call of ListEnvoyFilterExtensions
+func (mr *MockControllerMockRecorder) ListEnvoyFilterExtensions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvoyFilterExtensions", reflect.TypeOf((*MockController)(nil).ListEnvoyFilterExtensions), arg0)
+}
+
+// ListEgressPolicies mocks base method
+func (m *MockController) ListEgressPolicies() []*v1alpha1.Egress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEgressPolicies")
+	ret0, _ := ret[0].([]*v1alpha1.Egress)
+	return ret0
+}
+
+// ListEgressPolicies indicates an expected call of ListEgressPolicies
+func (mr *MockControllerMockRecorder) ListEgressPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEgressPolicies", reflect.TypeOf((*MockController)(nil).ListEgressPolicies))
+}
+
 // ListEgressPoliciesForSourceIdentity mocks base method
 func (m *MockController) ListEgressPoliciesForSourceIdentity(arg0 identity.K8sServiceAccount) []*v1alpha1.Egress {
 	m.ctrl.T.Helper()
@@ -18,14 +18,18 @@ var (
 
 // informerCollection is the type used to represent the collection of informers for the policy.openservicemesh.io API group
 type informerCollection struct {
-	egress         cache.SharedIndexInformer
-	ingressBackend cache.SharedIndexInformer
+	egress               cache.SharedIndexInformer
+	ingressBackend       cache.SharedIndexInformer
+	authorizationPolicy  cache.SharedIndexInformer
+	envoyFilterExtension cache.SharedIndexInformer
 }
 
 // cacheCollection is the type used to represent the collection of caches for the policy.openservicemesh.io API group
 type cacheCollection struct {
-	egress         cache.Store
-	ingressBackend cache.Store
+	egress               cache.Store
+	ingressBackend       cache.Store
+	authorizationPolicy  cache.Store
+	envoyFilterExtension cache.Store
 }
 
 // client is the type used to represent the Kubernetes client for the policy.openservicemesh.io API group
@@ -40,6 +44,15 @@ type Controller interface {
 	// ListEgressPoliciesForSourceIdentity lists the Egress policies for the given source identity
 	ListEgressPoliciesForSourceIdentity(identity.K8sServiceAccount) []*policyV1alpha1.Egress
 
+	// ListEgressPolicies lists the Egress policies in the monitored namespaces
+	ListEgressPolicies() []*policyV1alpha1.Egress
+
 	// GetIngressBackendPolicy returns the IngressBackend policy for the given backend MeshService
 	GetIngressBackendPolicy(service.MeshService) *policyV1alpha1.IngressBackend
+
+	// ListAuthorizationPolicies lists the AuthorizationPolicy policies applicable to the given MeshService
+	ListAuthorizationPolicies(service.MeshService) []*policyV1alpha1.AuthorizationPolicy
+
+	// ListEnvoyFilterExtensions lists the EnvoyFilterExtension resources applicable to the proxies of the given service account
+	ListEnvoyFilterExtensions(identity.K8sServiceAccount) []*policyV1alpha1.EnvoyFilterExtension
 }
@@ -273,7 +273,7 @@ func (c *Client) ListServiceIdentitiesForService(svc service.MeshService) ([]ide
 
 	var serviceIdentities []identity.ServiceIdentity
 	for _, svcAccount := range serviceAccounts {
-		serviceIdentity := svcAccount.ToServiceIdentity()
+		serviceIdentity := svcAccount.ToServiceIdentity(c.meshConfigurator.GetTrustDomain())
 		serviceIdentities = append(serviceIdentities, serviceIdentity)
 	}
 
@@ -428,7 +428,7 @@ var _ = Describe("Test Kube Client Provider (/w kubecontroller)", func() {
 		// Expect a MeshService that corresponds to a Service that matches the Pod spec labels
 		expectedMeshSvc := utils.K8sSvcToMeshSvc(svc)
 
-		meshSvcs, err := client.GetServicesForServiceIdentity(givenSvcAccount.ToServiceIdentity())
+		meshSvcs, err := client.GetServicesForServiceIdentity(givenSvcAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain))
 		Expect(err).ToNot(HaveOccurred())
 		expectedMeshSvcs := []service.MeshService{expectedMeshSvc}
 		Expect(meshSvcs).To(Equal(expectedMeshSvcs))
@@ -501,7 +501,7 @@ var _ = Describe("Test Kube Client Provider (/w kubecontroller)", func() {
 		}
 
 		// Expect a MeshService that corresponds to a Service that matches the Deployment spec labels
-		svcs, err := client.GetServicesForServiceIdentity(givenSvcAccount.ToServiceIdentity())
+		svcs, err := client.GetServicesForServiceIdentity(givenSvcAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain))
 		Expect(err).To(HaveOccurred())
 		Expect(svcs).To(BeNil())
 
@@ -569,7 +569,7 @@ var _ = Describe("Test Kube Client Provider (/w kubecontroller)", func() {
 		}
 
 		// Expect a MeshService that corresponds to a Service that matches the Deployment spec labels
-		svcs, err := client.GetServicesForServiceIdentity(givenSvcAccount.ToServiceIdentity())
+		svcs, err := client.GetServicesForServiceIdentity(givenSvcAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain))
 		Expect(err).To(HaveOccurred())
 		Expect(svcs).To(BeNil())
 
@@ -655,7 +655,7 @@ var _ = Describe("Test Kube Client Provider (/w kubecontroller)", func() {
 			Name:      "test-service-account", // Should match the service account in the Deployment spec above
 		}
 
-		meshServices, err := client.GetServicesForServiceIdentity(givenSvcAccount.ToServiceIdentity())
+		meshServices, err := client.GetServicesForServiceIdentity(givenSvcAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain))
 		Expect(err).ToNot(HaveOccurred())
 		expectedServices := []service.MeshService{
 			{Name: "test-1", Namespace: testNamespace},
@@ -805,10 +805,12 @@ func TestGetMultiClusterServiceEndpointsForServiceAccount(t *testing.T) {
 		{
 			IP:   net.ParseIP("1.2.3.4"),
 			Port: 8080,
+			Zone: "remote-cluster-1",
 		},
 		{
 			IP:   net.ParseIP("5.6.7.8"),
 			Port: 8080,
+			Zone: "remote-cluster-2",
 		},
 	})
 }
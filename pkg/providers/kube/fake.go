@@ -85,7 +85,7 @@ func (f fakeClient) ListServiceIdentitiesForService(svc service.MeshService) ([]
 	var serviceIdentities []identity.ServiceIdentity
 
 	for svcID := range f.services {
-		serviceIdentities = append(serviceIdentities, svcID.ToServiceIdentity())
+		serviceIdentities = append(serviceIdentities, svcID.ToServiceIdentity(identity.ClusterLocalTrustDomain))
 	}
 	return serviceIdentities, nil
 }
@@ -3,7 +3,6 @@ package kube
 import (
 	"net"
 	"strconv"
-	"strings"
 
 	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -41,15 +40,17 @@ func (c *Client) getMultiClusterServiceEndpointsForServiceAccount(serviceAccount
 
 	for _, svc := range services {
 		for _, cluster := range svc.Spec.Clusters {
-			ip, port, err := getIPPort(cluster)
+			ip, hostname, port, err := getHostPort(cluster)
 			if err != nil {
-				log.Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Error getting IP and Port for cluster=%s for service %s", cluster.Name, svc)
+				log.Err(err).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Error getting host and Port for cluster=%s for service %s", cluster.Name, svc)
 				continue
 			}
 
 			ep := endpoint.Endpoint{
-				IP:   ip,
-				Port: endpoint.Port(port),
+				IP:       ip,
+				Hostname: hostname,
+				Port:     endpoint.Port(port),
+				Zone:     cluster.Name,
 			}
 			endpoints = append(endpoints, ep)
 		}
@@ -58,20 +59,23 @@ func (c *Client) getMultiClusterServiceEndpointsForServiceAccount(serviceAccount
 	return endpoints
 }
 
-func getIPPort(cluster v1alpha1.ClusterSpec) (ip net.IP, port int, err error) {
-	tokens := strings.Split(cluster.Address, portIPSeparator)
-	if len(tokens) != 2 {
-		log.Error().Err(errParseMulticlusterServiceIP).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Invalid address format %s. It should have IP address and port number separated by %s", cluster.Address, portIPSeparator)
-		return nil, 0, errParseMulticlusterServiceIP
+// getHostPort returns the IP or the hostname, and the port of the gateway of the given cluster.
+// The hostname is only set when the address is not a literal IP.
+func getHostPort(cluster v1alpha1.ClusterSpec) (ip net.IP, hostname string, port int, err error) {
+	host, portStr, err := net.SplitHostPort(cluster.Address)
+	if err != nil {
+		log.Error().Err(errParseMulticlusterServiceAddress).Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Invalid address format %s. It should have a host and port number separated by %s", cluster.Address, portIPSeparator)
+		return nil, "", 0, errParseMulticlusterServiceAddress
 	}
 
-	ipStr, portStr := tokens[0], tokens[1]
 	port, err = strconv.Atoi(portStr)
 	if err != nil {
 		log.Error().Str(constants.LogFieldContext, constants.LogContextMulticluster).Msgf("Invalid port number format %s for cluster address: %s", portStr, cluster.Address)
-		return nil, 0, err
+		return nil, "", 0, err
 	}
 
-	ip = net.ParseIP(ipStr)
-	return ip, port, err
+	if ip = net.ParseIP(host); ip != nil {
+		return ip, "", port, nil
+	}
+	return nil, host, port, nil
 }
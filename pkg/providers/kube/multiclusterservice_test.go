@@ -51,6 +51,7 @@ func TestHelperFunctions(t *testing.T) {
 	expectedEndpoint := []endpoint.Endpoint{{
 		IP:   net.IPv4(1, 2, 3, 4),
 		Port: 5678,
+		Zone: "alpha",
 	}}
 
 	toReturnServices := []v1alpha1.MultiClusterService{{
@@ -77,15 +78,30 @@ func TestHelperFunctions(t *testing.T) {
 	actual = client.getMultiClusterServiceEndpointsForServiceAccount(tests.BookbuyerServiceAccountName, tests.Namespace)
 	assert.Equal(actual, expectedEndpoint)
 
-	// Test getIPPort()
-	// returns the port number specified in a ClusterSpec
+	// Test getHostPort()
+	// returns the IP and port number specified in a ClusterSpec
 	clusterSpec := v1alpha1.ClusterSpec{
 		Address: "1.2.3.4:5678",
 	}
-	actualIP, actualPort, err := getIPPort(clusterSpec)
+	actualIP, actualHostname, actualPort, err := getHostPort(clusterSpec)
 	assert.Equal(err, nil)
 	expectedIP := net.ParseIP("1.2.3.4")
 	expectedPort := 5678
 	assert.Equal(actualIP, expectedIP)
+	assert.Empty(actualHostname)
 	assert.Equal(actualPort, expectedPort)
+
+	// returns the hostname and port number specified in a ClusterSpec
+	clusterSpec = v1alpha1.ClusterSpec{
+		Address: "gateway.east.example.com:15443",
+	}
+	actualIP, actualHostname, actualPort, err = getHostPort(clusterSpec)
+	assert.Equal(err, nil)
+	assert.Nil(actualIP)
+	assert.Equal("gateway.east.example.com", actualHostname)
+	assert.Equal(15443, actualPort)
+
+	// returns an error when the port is missing
+	_, _, _, err = getHostPort(v1alpha1.ClusterSpec{Address: "gateway.east.example.com"})
+	assert.Equal(errParseMulticlusterServiceAddress, err)
 }
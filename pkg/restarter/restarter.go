@@ -0,0 +1,210 @@
+// Package restarter implements the rate-limited rolling restart of workloads whose pods run a sidecar
+// that is out of date with the sidecar images configured in the MeshConfig.
+package restarter
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/mesh"
+)
+
+var log = logger.New("restarter")
+
+const (
+	kindDeployment  = "Deployment"
+	kindReplicaSet  = "ReplicaSet"
+	kindStatefulSet = "StatefulSet"
+)
+
+// Restarter performs rolling restarts of Deployments and StatefulSets owning pods with an outdated sidecar,
+// restarting at most one workload per configured interval.
+type Restarter struct {
+	kubeClient     kubernetes.Interface
+	kubeController k8s.Controller
+	cfg            configurator.Configurator
+}
+
+// workload is a reference to a Deployment or StatefulSet owning a meshed pod
+type workload struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// NewRestarter returns a new Restarter
+func NewRestarter(kubeClient kubernetes.Interface, kubeController k8s.Controller, cfg configurator.Configurator) *Restarter {
+	return &Restarter{
+		kubeClient:     kubeClient,
+		kubeController: kubeController,
+		cfg:            cfg,
+	}
+}
+
+// ListStalePods returns the meshed pods running a sidecar that differs from the sidecar they would be
+// injected with given the current MeshConfig, sorted by namespace and name.
+func ListStalePods(kubeController k8s.Controller, cfg configurator.Configurator) []*corev1.Pod {
+	var stalePods []*corev1.Pod
+	for _, pod := range kubeController.ListPods() {
+		expectedVersion := mesh.ExpectedSidecarVersion(*pod, cfg.GetEnvoyImage(), cfg.GetEnvoyWindowsImage(), cfg.GetInitContainerImage())
+		if mesh.IsSidecarStale(*pod, expectedVersion) {
+			stalePods = append(stalePods, pod)
+		}
+	}
+
+	sort.Slice(stalePods, func(i, j int) bool {
+		if stalePods[i].Namespace != stalePods[j].Namespace {
+			return stalePods[i].Namespace < stalePods[j].Namespace
+		}
+		return stalePods[i].Name < stalePods[j].Name
+	})
+	return stalePods
+}
+
+// Run restarts workloads with outdated sidecars until the stop channel is closed.
+// Restarts are only performed while automatic restarts are enabled in the MeshConfig.
+func (r *Restarter) Run(stop <-chan struct{}) {
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(r.cfg.GetStaleSidecarRestartInterval()):
+				if !r.cfg.IsStaleSidecarRestartEnabled() {
+					continue
+				}
+				if err := r.restartNext(); err != nil {
+					log.Error().Err(err).Msg("Error restarting workload with outdated sidecar")
+				}
+			}
+		}
+	}()
+}
+
+// restartNext restarts the first workload owning a pod with an outdated sidecar that is not already being rolled out.
+// Pods whose workload cannot be resolved are skipped so that they do not block the restart of other workloads.
+func (r *Restarter) restartNext() error {
+	seen := make(map[workload]bool)
+	for _, pod := range ListStalePods(r.kubeController, r.cfg) {
+		w, err := r.getOwningWorkload(pod)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting the workload owning pod %s/%s, skipping it", pod.Namespace, pod.Name)
+			continue
+		}
+		if w == nil || seen[*w] {
+			continue
+		}
+		seen[*w] = true
+
+		rollingOut, err := r.isRollingOut(*w)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting the rollout status of %s %s/%s, skipping it", w.kind, w.namespace, w.name)
+			continue
+		}
+		if rollingOut {
+			log.Debug().Msgf("Skipping restart of %s %s/%s, a rollout is already in progress", w.kind, w.namespace, w.name)
+			continue
+		}
+
+		log.Info().Msgf("Restarting %s %s/%s to update the sidecar of pod %s", w.kind, w.namespace, w.name, pod.Name)
+		return r.restart(*w)
+	}
+	return nil
+}
+
+// getOwningWorkload returns the Deployment or StatefulSet owning the given pod, or nil if the pod is
+// not owned by a workload that can be restarted
+func (r *Restarter) getOwningWorkload(pod *corev1.Pod) (*workload, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil, nil
+	}
+
+	switch owner.Kind {
+	case kindStatefulSet:
+		return &workload{kind: kindStatefulSet, namespace: pod.Namespace, name: owner.Name}, nil
+
+	case kindReplicaSet:
+		rs, err := r.kubeClient.AppsV1().ReplicaSets(pod.Namespace).Get(context.Background(), owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting ReplicaSet %s/%s", pod.Namespace, owner.Name)
+		}
+		if rsOwner := metav1.GetControllerOf(rs); rsOwner != nil && rsOwner.Kind == kindDeployment {
+			return &workload{kind: kindDeployment, namespace: pod.Namespace, name: rsOwner.Name}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// isRollingOut returns a boolean indicating if the given workload has a rollout in progress
+func (r *Restarter) isRollingOut(w workload) (bool, error) {
+	switch w.kind {
+	case kindDeployment:
+		d, err := r.kubeClient.AppsV1().Deployments(w.namespace).Get(context.Background(), w.name, metav1.GetOptions{})
+		if err != nil {
+			return false, errors.Wrapf(err, "Error getting Deployment %s/%s", w.namespace, w.name)
+		}
+		return isDeploymentRollingOut(d), nil
+
+	case kindStatefulSet:
+		ss, err := r.kubeClient.AppsV1().StatefulSets(w.namespace).Get(context.Background(), w.name, metav1.GetOptions{})
+		if err != nil {
+			return false, errors.Wrapf(err, "Error getting StatefulSet %s/%s", w.namespace, w.name)
+		}
+		return ss.Status.ObservedGeneration < ss.Generation || ss.Status.UpdateRevision != ss.Status.CurrentRevision, nil
+	}
+
+	return false, nil
+}
+
+func isDeploymentRollingOut(d *appsv1.Deployment) bool {
+	if d.Status.ObservedGeneration < d.Generation {
+		return true
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.UpdatedReplicas < replicas || d.Status.Replicas > d.Status.UpdatedReplicas
+}
+
+// restart triggers a rolling restart of the given workload the same way 'kubectl rollout restart' does
+func (r *Restarter) restart(w workload) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						constants.RestartedAtAnnotation: time.Now().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	switch w.kind {
+	case kindDeployment:
+		_, err = r.kubeClient.AppsV1().Deployments(w.namespace).Patch(context.Background(), w.name, types.StrategicMergePatchType, patchJSON, metav1.PatchOptions{})
+	case kindStatefulSet:
+		_, err = r.kubeClient.AppsV1().StatefulSets(w.namespace).Patch(context.Background(), w.name, types.StrategicMergePatchType, patchJSON, metav1.PatchOptions{})
+	}
+	return errors.Wrapf(err, "Error restarting %s %s/%s", w.kind, w.namespace, w.name)
+}
@@ -0,0 +1,182 @@
+package restarter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+const (
+	testNamespace = "test-ns"
+	currentEnvoy  = "envoy:v2"
+	currentInit   = "init:v2"
+)
+
+func newMeshedPod(name, version string, owner *metav1.OwnerReference) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   testNamespace,
+			Labels:      map[string]string{constants.EnvoyUniqueIDLabelName: uuid.New().String()},
+			Annotations: map[string]string{constants.SidecarVersionAnnotation: version},
+		},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func controllerRef(kind, name string) *metav1.OwnerReference {
+	isController := true
+	return &metav1.OwnerReference{Kind: kind, Name: name, Controller: &isController}
+}
+
+func TestListStalePods(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	mockConfigurator.EXPECT().GetEnvoyImage().Return(currentEnvoy).AnyTimes()
+	mockConfigurator.EXPECT().GetEnvoyWindowsImage().Return("envoy-windows:v2").AnyTimes()
+	mockConfigurator.EXPECT().GetInitContainerImage().Return(currentInit).AnyTimes()
+	mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{
+		newMeshedPod("b", "envoy:v1,init:v2", nil),
+		newMeshedPod("current", "envoy:v2,init:v2", nil),
+		newMeshedPod("a", "envoy:v2,init:v1", nil),
+	})
+
+	stalePods := ListStalePods(mockKubeController, mockConfigurator)
+	assert.Len(stalePods, 2)
+	assert.Equal("a", stalePods[0].Name)
+	assert.Equal("b", stalePods[1].Name)
+}
+
+func TestRestartNext(t *testing.T) {
+	one := int32(1)
+	testCases := []struct {
+		name                  string
+		pods                  []*corev1.Pod
+		deployments           []*appsv1.Deployment
+		statefulSets          []*appsv1.StatefulSet
+		replicaSets           []*appsv1.ReplicaSet
+		expectedRestartedDeps []string
+		expectedRestartedSets []string
+	}{
+		{
+			name: "deployment with a stale pod is restarted",
+			pods: []*corev1.Pod{newMeshedPod("bookstore-abc", "envoy:v1,init:v2", controllerRef(kindReplicaSet, "bookstore-rs"))},
+			replicaSets: []*appsv1.ReplicaSet{{
+				ObjectMeta: metav1.ObjectMeta{Name: "bookstore-rs", Namespace: testNamespace, OwnerReferences: []metav1.OwnerReference{*controllerRef(kindDeployment, "bookstore")}},
+			}},
+			deployments: []*appsv1.Deployment{{
+				ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: testNamespace},
+				Spec:       appsv1.DeploymentSpec{Replicas: &one},
+				Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1},
+			}},
+			expectedRestartedDeps: []string{"bookstore"},
+		},
+		{
+			name: "deployment already rolling out is skipped",
+			pods: []*corev1.Pod{newMeshedPod("bookstore-abc", "envoy:v1,init:v2", controllerRef(kindReplicaSet, "bookstore-rs"))},
+			replicaSets: []*appsv1.ReplicaSet{{
+				ObjectMeta: metav1.ObjectMeta{Name: "bookstore-rs", Namespace: testNamespace, OwnerReferences: []metav1.OwnerReference{*controllerRef(kindDeployment, "bookstore")}},
+			}},
+			deployments: []*appsv1.Deployment{{
+				ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: testNamespace},
+				Spec:       appsv1.DeploymentSpec{Replicas: &one},
+				Status:     appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1},
+			}},
+		},
+		{
+			name: "statefulset with a stale pod is restarted",
+			pods: []*corev1.Pod{newMeshedPod("mysql-0", "envoy:v1,init:v2", controllerRef(kindStatefulSet, "mysql"))},
+			statefulSets: []*appsv1.StatefulSet{{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: testNamespace},
+				Status:     appsv1.StatefulSetStatus{CurrentRevision: "1", UpdateRevision: "1"},
+			}},
+			expectedRestartedSets: []string{"mysql"},
+		},
+		{
+			name: "pod whose owner cannot be fetched does not block other workloads",
+			pods: []*corev1.Pod{
+				newMeshedPod("bookstore-abc", "envoy:v1,init:v2", controllerRef(kindReplicaSet, "missing-rs")),
+				newMeshedPod("mysql-0", "envoy:v1,init:v2", controllerRef(kindStatefulSet, "mysql")),
+			},
+			statefulSets: []*appsv1.StatefulSet{{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: testNamespace},
+				Status:     appsv1.StatefulSetStatus{CurrentRevision: "1", UpdateRevision: "1"},
+			}},
+			expectedRestartedSets: []string{"mysql"},
+		},
+		{
+			name: "pod without a restartable owner is skipped",
+			pods: []*corev1.Pod{newMeshedPod("standalone", "envoy:v1,init:v2", nil)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			mockConfigurator.EXPECT().GetEnvoyImage().Return(currentEnvoy).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyWindowsImage().Return("envoy-windows:v2").AnyTimes()
+			mockConfigurator.EXPECT().GetInitContainerImage().Return(currentInit).AnyTimes()
+			mockKubeController.EXPECT().ListPods().Return(tc.pods)
+
+			kubeClient := fake.NewSimpleClientset()
+			for _, rs := range tc.replicaSets {
+				_, err := kubeClient.AppsV1().ReplicaSets(rs.Namespace).Create(context.TODO(), rs, metav1.CreateOptions{})
+				assert.Nil(err)
+			}
+			for _, d := range tc.deployments {
+				_, err := kubeClient.AppsV1().Deployments(d.Namespace).Create(context.TODO(), d, metav1.CreateOptions{})
+				assert.Nil(err)
+			}
+			for _, ss := range tc.statefulSets {
+				_, err := kubeClient.AppsV1().StatefulSets(ss.Namespace).Create(context.TODO(), ss, metav1.CreateOptions{})
+				assert.Nil(err)
+			}
+
+			r := NewRestarter(kubeClient, mockKubeController, mockConfigurator)
+			assert.Nil(r.restartNext())
+
+			for _, d := range tc.deployments {
+				actual, err := kubeClient.AppsV1().Deployments(d.Namespace).Get(context.TODO(), d.Name, metav1.GetOptions{})
+				assert.Nil(err)
+				_, restarted := actual.Spec.Template.Annotations[constants.RestartedAtAnnotation]
+				assert.Equal(contains(tc.expectedRestartedDeps, d.Name), restarted)
+			}
+			for _, ss := range tc.statefulSets {
+				actual, err := kubeClient.AppsV1().StatefulSets(ss.Namespace).Get(context.TODO(), ss.Name, metav1.GetOptions{})
+				assert.Nil(err)
+				_, restarted := actual.Spec.Template.Annotations[constants.RestartedAtAnnotation]
+				assert.Equal(contains(tc.expectedRestartedSets, ss.Name), restarted)
+			}
+		})
+	}
+}
+
+func contains(list []string, item string) bool {
+	for _, s := range list {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package sharding
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// virtualNodesPerReplica is the number of points each replica owns on the hash ring. Multiple points
+// per replica spread the proxies evenly across the replicas and limit the number of proxies moving
+// between replicas when a replica is added or removed.
+const virtualNodesPerReplica = 128
+
+// Ring is an immutable consistent hash ring of osm-controller replicas
+type Ring struct {
+	// points are the sorted hashes of the virtual nodes on the ring
+	points []uint64
+
+	// owners maps the hash of a virtual node to the replica owning it
+	owners map[uint64]string
+
+	// replicas are the sorted names of the replicas on the ring
+	replicas []string
+}
+
+// NewRing returns a consistent hash ring for the given replicas
+func NewRing(replicas []string) *Ring {
+	ring := &Ring{
+		owners: make(map[uint64]string),
+	}
+
+	seen := make(map[string]bool)
+	for _, replica := range replicas {
+		if replica == "" || seen[replica] {
+			continue
+		}
+		seen[replica] = true
+		ring.replicas = append(ring.replicas, replica)
+
+		for i := 0; i < virtualNodesPerReplica; i++ {
+			point := hash(fmt.Sprintf("%s-%d", replica, i))
+			if _, collision := ring.owners[point]; collision {
+				continue
+			}
+			ring.owners[point] = replica
+			ring.points = append(ring.points, point)
+		}
+	}
+
+	sort.Strings(ring.replicas)
+	sort.Slice(ring.points, func(i, j int) bool { return ring.points[i] < ring.points[j] })
+
+	return ring
+}
+
+// Owner returns the replica owning the given key, which is the replica of the first virtual node
+// following the key's hash on the ring. An empty string is returned when the ring has no replicas.
+func (r *Ring) Owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	keyHash := hash(key)
+	idx := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= keyHash })
+	if idx == len(r.points) {
+		// Wrap around the ring
+		idx = 0
+	}
+
+	return r.owners[r.points[idx]]
+}
+
+// Replicas returns the sorted names of the replicas on the ring
+func (r *Ring) Replicas() []string {
+	return r.replicas
+}
+
+// Equals returns true if both rings have the same replicas
+func (r *Ring) Equals(other *Ring) bool {
+	if len(r.replicas) != len(other.replicas) {
+		return false
+	}
+	for i := range r.replicas {
+		if r.replicas[i] != other.replicas[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hash(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
@@ -0,0 +1,73 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestRingOwner(t *testing.T) {
+	assert := tassert.New(t)
+
+	emptyRing := NewRing(nil)
+	assert.Empty(emptyRing.Owner(uuid.New().String()))
+	assert.Empty(emptyRing.Replicas())
+
+	ring := NewRing([]string{"osm-controller-2", "osm-controller-1", "osm-controller-1", ""})
+	assert.Equal([]string{"osm-controller-1", "osm-controller-2"}, ring.Replicas())
+
+	// The owner of a key is deterministic and independent of the order of the replicas
+	otherRing := NewRing([]string{"osm-controller-1", "osm-controller-2"})
+	for i := 0; i < 100; i++ {
+		key := uuid.New().String()
+		assert.Equal(ring.Owner(key), otherRing.Owner(key))
+	}
+}
+
+func TestRingDistribution(t *testing.T) {
+	assert := tassert.New(t)
+
+	var replicas []string
+	for i := 0; i < 3; i++ {
+		replicas = append(replicas, fmt.Sprintf("osm-controller-%d", i))
+	}
+	ring := NewRing(replicas)
+
+	var keys []string
+	for i := 0; i < 3000; i++ {
+		keys = append(keys, uuid.New().String())
+	}
+
+	counts := make(map[string]int)
+	for _, key := range keys {
+		counts[ring.Owner(key)]++
+	}
+	assert.Len(counts, 3)
+	for replica, count := range counts {
+		// Each replica owns roughly a third of the keys
+		assert.InDelta(1000, count, 300, "replica %s owns %d keys", replica, count)
+	}
+
+	// Adding a replica only moves keys to the new replica
+	scaledRing := NewRing(append(replicas, "osm-controller-3"))
+	moved := 0
+	for _, key := range keys {
+		before, after := ring.Owner(key), scaledRing.Owner(key)
+		if before != after {
+			assert.Equal("osm-controller-3", after)
+			moved++
+		}
+	}
+	assert.InDelta(750, moved, 250)
+}
+
+func TestRingEquals(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.True(NewRing(nil).Equals(NewRing([]string{})))
+	assert.True(NewRing([]string{"a", "b"}).Equals(NewRing([]string{"b", "a"})))
+	assert.False(NewRing([]string{"a", "b"}).Equals(NewRing([]string{"a"})))
+	assert.False(NewRing([]string{"a", "b"}).Equals(NewRing([]string{"a", "c"})))
+}
@@ -0,0 +1,146 @@
+// Package sharding implements the distribution of proxies across the osm-controller replicas. Proxies are assigned
+// to the ready replicas by consistent hashing on the proxy UUID, such that each replica only serves its shard
+// of the proxies and only a fraction of the proxies move to another replica when replicas are scaled.
+package sharding
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+var log = logger.New("sharding")
+
+// Manager tracks the ready osm-controller replicas backing a Service and determines which replica
+// owns a given proxy
+type Manager struct {
+	identity string
+
+	ringMutex sync.RWMutex
+	ring      *Ring
+}
+
+// NewManager returns a new Manager for the replica with the given identity, ex. the pod name.
+// The replicas are discovered from the ready addresses of the Endpoints of the given Service.
+func NewManager(kubeClient kubernetes.Interface, namespace, serviceName, identity string, stop <-chan struct{}) (*Manager, error) {
+	m := &Manager{
+		identity: identity,
+		ring:     NewRing(nil),
+	}
+
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", serviceName).String()
+		}),
+	)
+	informer := informerFactory.Core().V1().Endpoints().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			m.updateRing(obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			m.updateRing(newObj)
+		},
+		DeleteFunc: func(_ interface{}) {
+			m.updateRing(nil)
+		},
+	})
+
+	go informer.Run(stop)
+	if !cache.WaitForCacheSync(stop, informer.HasSynced) {
+		return nil, errors.Errorf("Failed initial cache sync for Endpoints %s/%s", namespace, serviceName)
+	}
+
+	// Event handlers are invoked asynchronously, so build the initial ring from the synced cache
+	endpoints, _, err := informer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, serviceName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting Endpoints %s/%s", namespace, serviceName)
+	}
+	m.updateRing(endpoints)
+
+	return m, nil
+}
+
+// updateRing rebuilds the hash ring from the ready addresses of the given Endpoints, and notifies
+// the proxy streams when the replicas changed so that the proxies are rebalanced
+func (m *Manager) updateRing(obj interface{}) {
+	var replicas []string
+	if endpoints, ok := obj.(*corev1.Endpoints); ok {
+		replicas = getReadyReplicas(endpoints)
+	}
+	ring := NewRing(replicas)
+
+	m.ringMutex.Lock()
+	changed := !m.ring.Equals(ring)
+	m.ring = ring
+	m.ringMutex.Unlock()
+
+	if !changed {
+		return
+	}
+
+	log.Info().Msgf("osm-controller replicas changed to %v, rebalancing proxies", ring.Replicas())
+	metricsstore.DefaultMetricsStore.ControllerShardReplicas.Set(float64(len(ring.Replicas())))
+	events.Publish(events.PubSubMessage{
+		AnnouncementType: announcements.ADSShardsRebalanced,
+		NewObj:           ring.Replicas(),
+	})
+}
+
+// getReadyReplicas returns the names of the pods backing the ready addresses of the given Endpoints
+func getReadyReplicas(endpoints *corev1.Endpoints) []string {
+	var replicas []string
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			if address.TargetRef == nil || address.TargetRef.Kind != "Pod" {
+				continue
+			}
+			replicas = append(replicas, address.TargetRef.Name)
+		}
+	}
+	return replicas
+}
+
+// GetIdentity returns the name of this replica
+func (m *Manager) GetIdentity() string {
+	return m.identity
+}
+
+// ListReplicas returns the names of the ready replicas sharing the proxies
+func (m *Manager) ListReplicas() []string {
+	m.ringMutex.RLock()
+	defer m.ringMutex.RUnlock()
+	return m.ring.Replicas()
+}
+
+// GetOwner returns the replica owning the proxy with the given UUID. An empty string is returned
+// when no replica is ready.
+func (m *Manager) GetOwner(proxyUUID string) string {
+	m.ringMutex.RLock()
+	defer m.ringMutex.RUnlock()
+	return m.ring.Owner(proxyUUID)
+}
+
+// Owns returns true if this replica should serve the proxy with the given UUID. A nil Manager, i.e. when
+// sharding is disabled, owns all proxies. When no replica is ready yet, all proxies are served rather
+// than rejected.
+func (m *Manager) Owns(proxyUUID string) bool {
+	if m == nil {
+		return true
+	}
+	owner := m.GetOwner(proxyUUID)
+	return owner == "" || owner == m.identity
+}
@@ -0,0 +1,97 @@
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+)
+
+func newEndpoints(readyPods ...string) *corev1.Endpoints {
+	subset := corev1.EndpointSubset{
+		// Not ready replicas do not own proxies
+		NotReadyAddresses: []corev1.EndpointAddress{
+			{IP: "10.0.0.100", TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "osm-controller-not-ready"}},
+		},
+	}
+	for _, pod := range readyPods {
+		subset.Addresses = append(subset.Addresses, corev1.EndpointAddress{
+			IP:        "10.0.0.1",
+			TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: pod},
+		})
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "osm-controller",
+			Namespace: "osm-system",
+		},
+		Subsets: []corev1.EndpointSubset{subset},
+	}
+}
+
+func TestManager(t *testing.T) {
+	assert := tassert.New(t)
+
+	kubeClient := testclient.NewSimpleClientset(newEndpoints("osm-controller-1", "osm-controller-2"))
+	stop := make(chan struct{})
+	defer close(stop)
+
+	rebalanced := events.Subscribe(announcements.ADSShardsRebalanced)
+	defer events.Unsub(rebalanced)
+
+	m, err := NewManager(kubeClient, "osm-system", "osm-controller", "osm-controller-1", stop)
+	assert.Nil(err)
+	assert.Equal("osm-controller-1", m.GetIdentity())
+	assert.Equal([]string{"osm-controller-1", "osm-controller-2"}, m.ListReplicas())
+
+	select {
+	case <-rebalanced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the shards to be rebalanced")
+	}
+
+	owned := 0
+	for i := 0; i < 100; i++ {
+		proxyUUID := uuid.New().String()
+		owner := m.GetOwner(proxyUUID)
+		assert.Contains([]string{"osm-controller-1", "osm-controller-2"}, owner)
+		assert.Equal(owner == "osm-controller-1", m.Owns(proxyUUID))
+		if m.Owns(proxyUUID) {
+			owned++
+		}
+	}
+	assert.Greater(owned, 0)
+	assert.Less(owned, 100)
+
+	// Scaling down to a single replica moves all proxies to the remaining replica
+	_, err = kubeClient.CoreV1().Endpoints("osm-system").Update(context.Background(), newEndpoints("osm-controller-1"), metav1.UpdateOptions{})
+	assert.Nil(err)
+	select {
+	case <-rebalanced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the shards to be rebalanced")
+	}
+	assert.Equal([]string{"osm-controller-1"}, m.ListReplicas())
+	assert.True(m.Owns(uuid.New().String()))
+
+	// When no replica is ready, proxies are served rather than rejected
+	err = kubeClient.CoreV1().Endpoints("osm-system").Delete(context.Background(), "osm-controller", metav1.DeleteOptions{})
+	assert.Nil(err)
+	assert.Eventually(func() bool { return len(m.ListReplicas()) == 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.True(m.Owns(uuid.New().String()))
+}
+
+func TestNilManagerOwnsAllProxies(t *testing.T) {
+	assert := tassert.New(t)
+
+	var m *Manager
+	assert.True(m.Owns(uuid.New().String()))
+}
@@ -0,0 +1,28 @@
+package simulator
+
+import (
+	networkingV1 "k8s.io/api/networking/v1"
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
+	gwapiV1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// noIngressMonitor implements ingress.Monitor for a mesh without Kubernetes Ingress and Gateway API resources,
+// ingress traffic is simulated with IngressBackend policies only
+type noIngressMonitor struct{}
+
+// GetIngressNetworkingV1beta1 implements ingress.Monitor
+func (noIngressMonitor) GetIngressNetworkingV1beta1(service.MeshService) ([]*networkingV1beta1.Ingress, error) {
+	return nil, nil
+}
+
+// GetIngressNetworkingV1 implements ingress.Monitor
+func (noIngressMonitor) GetIngressNetworkingV1(service.MeshService) ([]*networkingV1.Ingress, error) {
+	return nil, nil
+}
+
+// GetHTTPRoutes implements ingress.Monitor
+func (noIngressMonitor) GetHTTPRoutes(service.MeshService) ([]*gwapiV1alpha1.HTTPRoute, error) {
+	return nil, nil
+}
@@ -0,0 +1,125 @@
+package simulator
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+
+	// manifestExtensions are the extensions of the files loaded from directories
+	manifestExtensions = []string{".yaml", ".yml", ".json"}
+)
+
+func init() {
+	// Only the API versions the OSM control plane watches are decoded
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = smiAccess.AddToScheme(scheme)
+	_ = smiSpecs.AddToScheme(scheme)
+	_ = smiSplit.AddToScheme(scheme)
+	_ = policyv1alpha1.AddToScheme(scheme)
+	_ = configv1alpha1.AddToScheme(scheme)
+}
+
+// LoadManifests decodes the resources of the given YAML or JSON manifest files, and of the manifest files in the
+// given directories. Resources of kinds unknown to the OSM control plane are skipped.
+func LoadManifests(paths []string) ([]runtime.Object, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Errorf("Error reading manifests %s: %s", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		var dirFiles []string
+		err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && hasManifestExtension(file) {
+				dirFiles = append(dirFiles, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Errorf("Error reading manifests in directory %s: %s", path, err)
+		}
+		sort.Strings(dirFiles)
+		files = append(files, dirFiles...)
+	}
+
+	var objects []runtime.Object
+	for _, file := range files {
+		data, err := ioutil.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, errors.Errorf("Error reading manifest %s: %s", file, err)
+		}
+		fileObjects, err := DecodeManifest(data)
+		if err != nil {
+			return nil, errors.Errorf("Error decoding manifest %s: %s", file, err)
+		}
+		objects = append(objects, fileObjects...)
+	}
+	return objects, nil
+}
+
+// DecodeManifest decodes the resources of a YAML manifest, which may hold several documents, or of a JSON manifest.
+// Resources of kinds unknown to the OSM control plane are skipped.
+func DecodeManifest(data []byte) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(bytes.TrimSpace(raw.Raw)) == 0 || bytes.Equal(bytes.TrimSpace(raw.Raw), []byte("null")) {
+			continue
+		}
+
+		obj, gvk, err := codecs.UniversalDeserializer().Decode(raw.Raw, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			log.Warn().Msgf("Skipping resource of unsupported kind %s", gvk)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+}
+
+func hasManifestExtension(file string) bool {
+	for _, ext := range manifestExtensions {
+		if strings.EqualFold(filepath.Ext(file), ext) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package simulator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+)
+
+func TestLoadManifests(t *testing.T) {
+	assert := tassert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "osm-simulator-test")
+	trequire.NoError(t, err)
+	defer func() {
+		err := os.RemoveAll(dir)
+		if err != nil {
+			t.Log("error cleaning up temp dir:", err)
+		}
+	}()
+
+	trequire.NoError(t, os.Mkdir(filepath.Join(dir, "policies"), 0750))
+	files := map[string]string{
+		"services.yaml":            "apiVersion: v1\nkind: Service\nmetadata:\n  name: bookstore\n---\napiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: bookstore\n",
+		"policies/split.json":      `{"apiVersion": "split.smi-spec.io/v1alpha2", "kind": "TrafficSplit", "metadata": {"name": "bookstore-split"}}`,
+		"policies/README.md":       "# not a manifest",
+		"policies/egress.yml":      "apiVersion: policy.openservicemesh.io/v1alpha1\nkind: Egress\nmetadata:\n  name: egress\n",
+		"policies/unsupported.yml": "apiVersion: split.smi-spec.io/v1alpha1\nkind: TrafficSplit\nmetadata:\n  name: old-split\n",
+	}
+	for name, content := range files {
+		trequire.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	objects, err := LoadManifests([]string{dir})
+	assert.NoError(err)
+	assert.Len(objects, 4)
+
+	objects, err = LoadManifests([]string{filepath.Join(dir, "services.yaml")})
+	assert.NoError(err)
+	assert.Len(objects, 2)
+
+	_, err = LoadManifests([]string{filepath.Join(dir, "missing.yaml")})
+	assert.Error(err)
+}
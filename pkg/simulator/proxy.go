@@ -0,0 +1,104 @@
+package simulator
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/cds"
+	"github.com/openservicemesh/osm/pkg/envoy/eds"
+	"github.com/openservicemesh/osm/pkg/envoy/lds"
+	"github.com/openservicemesh/osm/pkg/envoy/rds"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+// ProxyConfig is the configuration the OSM control plane programs the Envoy proxy of a pod with, each resource
+// rendered in the JSON representation of its xDS proto message
+type ProxyConfig struct {
+	Listeners []json.RawMessage `json:"listeners"`
+	Routes    []json.RawMessage `json:"routes"`
+	Clusters  []json.RawMessage `json:"clusters"`
+	Endpoints []json.RawMessage `json:"endpoints"`
+}
+
+// GetProxyConfig returns the LDS, RDS, CDS and EDS resources the OSM control plane programs the proxy of the
+// given pod with
+func (s *Simulator) GetProxyConfig(namespace, podName string) (*ProxyConfig, error) {
+	pod, err := s.GetPod(namespace, podName)
+	if err != nil {
+		return nil, err
+	}
+	proxyUUID, err := uuid.Parse(pod.Labels[constants.EnvoyUniqueIDLabelName])
+	if err != nil {
+		return nil, errors.Errorf("Invalid %s label on pod %s/%s: %s", constants.EnvoyUniqueIDLabelName, namespace, podName, err)
+	}
+
+	cn := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, pod.Spec.ServiceAccountName, pod.Namespace, s.cfg.GetTrustDomain())
+	proxy, err := envoy.NewProxy(cn, certificate.SerialNumber(big.NewInt(1).String()), &net.IPAddr{IP: net.ParseIP(pod.Status.PodIP)})
+	if err != nil {
+		return nil, errors.Errorf("Error creating the proxy of pod %s/%s: %s", namespace, podName, err)
+	}
+	proxy.PodMetadata = &envoy.PodMetadata{
+		UID:       string(pod.UID),
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		ServiceAccount: identity.K8sServiceAccount{
+			Namespace: pod.Namespace,
+			Name:      pod.Spec.ServiceAccountName,
+		},
+	}
+
+	listeners, err := lds.NewResponse(s.meshCatalog, proxy, nil, s.cfg, s.certManager, s.proxyRegistry)
+	if err != nil {
+		return nil, errors.Errorf("Error building the listeners of pod %s/%s: %s", namespace, podName, err)
+	}
+	routes, err := rds.NewResponse(s.meshCatalog, proxy, nil, s.cfg, s.certManager, s.proxyRegistry)
+	if err != nil {
+		return nil, errors.Errorf("Error building the routes of pod %s/%s: %s", namespace, podName, err)
+	}
+	clusters, err := cds.NewResponse(s.meshCatalog, proxy, nil, s.cfg, s.certManager, s.proxyRegistry)
+	if err != nil {
+		return nil, errors.Errorf("Error building the clusters of pod %s/%s: %s", namespace, podName, err)
+	}
+	endpoints, err := eds.NewResponse(s.meshCatalog, proxy, nil, s.cfg, s.certManager, s.proxyRegistry)
+	if err != nil {
+		return nil, errors.Errorf("Error building the endpoints of pod %s/%s: %s", namespace, podName, err)
+	}
+
+	config := &ProxyConfig{}
+	if config.Listeners, err = renderResources(listeners); err != nil {
+		return nil, err
+	}
+	if config.Routes, err = renderResources(routes); err != nil {
+		return nil, err
+	}
+	if config.Clusters, err = renderResources(clusters); err != nil {
+		return nil, err
+	}
+	if config.Endpoints, err = renderResources(endpoints); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// renderResources returns the JSON representation of the given xDS resources
+func renderResources(resources []types.Resource) ([]json.RawMessage, error) {
+	rendered := []json.RawMessage{}
+	for _, resource := range resources {
+		out, err := protojson.Marshal(proto.MessageV2(resource))
+		if err != nil {
+			return nil, errors.Errorf("Error rendering %T resource: %s", resource, err)
+		}
+		rendered = append(rendered, out)
+	}
+	return rendered, nil
+}
@@ -0,0 +1,286 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	smiAccessFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	smiSpecsFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	smiSplitFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	configFake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	policyFake "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/providers/kube"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+// NewSimulator returns a Simulator running the OSM control plane logic on the given resources. Every namespace of the
+// resources is part of the simulated mesh. The pods of Deployments, StatefulSets, DaemonSets and ReplicaSets, one per
+// workload and named after it, and the Endpoints of the Services selecting pods are created when they are not among
+// the resources.
+func NewSimulator(objects []runtime.Object) (*Simulator, error) {
+	var kubeObjects, accessObjects, specsObjects, splitObjects, policyObjects, configObjects []runtime.Object
+
+	var meshConfig *configv1alpha1.MeshConfig
+	namespaces := make(map[string]*corev1.Namespace)
+	endpoints := make(map[string]bool)
+	var pods []*corev1.Pod
+	var services []*corev1.Service
+
+	for _, obj := range objects {
+		obj = obj.DeepCopyObject()
+		if meta, ok := obj.(metav1.Object); ok {
+			if _, isNamespace := obj.(*corev1.Namespace); !isNamespace && meta.GetNamespace() == "" {
+				meta.SetNamespace(metav1.NamespaceDefault)
+			}
+			if _, exists := namespaces[meta.GetNamespace()]; !exists && meta.GetNamespace() != "" {
+				namespaces[meta.GetNamespace()] = nil
+			}
+		}
+
+		switch o := obj.(type) {
+		case *corev1.Namespace:
+			namespaces[o.Name] = o
+			continue
+		case *corev1.Pod:
+			pods = append(pods, o)
+			continue
+		case *corev1.Service:
+			services = append(services, o)
+		case *corev1.Endpoints:
+			endpoints[fmt.Sprintf("%s/%s", o.Namespace, o.Name)] = true
+		case *appsv1.Deployment:
+			pods = append(pods, newWorkloadPod(o.ObjectMeta, o.Spec.Template))
+		case *appsv1.StatefulSet:
+			pods = append(pods, newWorkloadPod(o.ObjectMeta, o.Spec.Template))
+		case *appsv1.DaemonSet:
+			pods = append(pods, newWorkloadPod(o.ObjectMeta, o.Spec.Template))
+		case *appsv1.ReplicaSet:
+			pods = append(pods, newWorkloadPod(o.ObjectMeta, o.Spec.Template))
+		case *configv1alpha1.MeshConfig:
+			meshConfig = o
+		}
+
+		gvks, _, err := scheme.ObjectKinds(obj)
+		if err != nil {
+			log.Warn().Msgf("Skipping resource of unsupported type %T", obj)
+			continue
+		}
+		switch gvks[0].Group {
+		case smiAccess.SchemeGroupVersion.Group:
+			accessObjects = append(accessObjects, obj)
+		case smiSpecs.SchemeGroupVersion.Group:
+			specsObjects = append(specsObjects, obj)
+		case smiSplit.SchemeGroupVersion.Group:
+			splitObjects = append(splitObjects, obj)
+		case policyv1alpha1.SchemeGroupVersion.Group:
+			policyObjects = append(policyObjects, obj)
+		case configv1alpha1.SchemeGroupVersion.Group:
+			configObjects = append(configObjects, obj)
+		default:
+			kubeObjects = append(kubeObjects, obj)
+		}
+	}
+
+	// The default values of the MeshConfig settings are used when there is no MeshConfig among the resources
+	if meshConfig == nil {
+		meshConfig = &configv1alpha1.MeshConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      defaultMeshConfigName,
+				Namespace: defaultOSMNamespace,
+			},
+		}
+		configObjects = append(configObjects, meshConfig)
+	}
+
+	// Every namespace is monitored by the simulated mesh
+	for name, ns := range namespaces {
+		if ns == nil {
+			ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		}
+		if ns.Labels == nil {
+			ns.Labels = make(map[string]string)
+		}
+		ns.Labels[constants.OSMKubeResourceMonitorAnnotation] = meshName
+		kubeObjects = append(kubeObjects, ns)
+	}
+
+	// Pods are simulated as running meshed pods
+	for idx, pod := range pods {
+		if pod.Labels == nil {
+			pod.Labels = make(map[string]string)
+		}
+		if _, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]; !ok {
+			pod.Labels[constants.EnvoyUniqueIDLabelName] = uuid.New().String()
+		}
+		if pod.UID == "" {
+			pod.UID = types.UID(uuid.New().String())
+		}
+		if pod.Spec.ServiceAccountName == "" {
+			pod.Spec.ServiceAccountName = "default"
+		}
+		if pod.Status.PodIP == "" {
+			pod.Status.PodIP = fmt.Sprintf("10.%d.%d.%d", (idx>>16)&0xff, (idx>>8)&0xff, (idx&0xff)+1)
+		}
+		pod.Status.Phase = corev1.PodRunning
+		kubeObjects = append(kubeObjects, pod)
+	}
+
+	for _, svc := range services {
+		if endpoints[fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)] {
+			continue
+		}
+		kubeObjects = append(kubeObjects, newServiceEndpoints(svc, pods))
+	}
+
+	kubeClient := fake.NewSimpleClientset(kubeObjects...)
+	stop := make(chan struct{})
+
+	cfg := configurator.NewConfigurator(configFake.NewSimpleClientset(configObjects...), stop, meshConfig.Namespace, meshConfig.Name)
+
+	policyClient := policyFake.NewSimpleClientset(policyObjects...)
+	kubeController, err := k8s.NewKubernetesController(kubeClient, policyClient, meshName, stop,
+		k8s.Namespaces, k8s.Services, k8s.ServiceAccounts, k8s.Pods, k8s.Endpoints, k8s.Secrets, k8s.ConfigMaps)
+	if err != nil {
+		close(stop)
+		return nil, errors.Errorf("Error creating the Kubernetes controller: %s", err)
+	}
+
+	meshSpec, err := smi.NewMeshSpecClientFromClientsets(kubeClient, smiSplitFake.NewSimpleClientset(splitObjects...),
+		smiSpecsFake.NewSimpleClientset(specsObjects...), smiAccessFake.NewSimpleClientset(accessObjects...), meshConfig.Namespace, kubeController, stop)
+	if err != nil {
+		close(stop)
+		return nil, errors.Errorf("Error creating the SMI client: %s", err)
+	}
+
+	policyController, err := policy.NewPolicyController(kubeController, policyClient, stop)
+	if err != nil {
+		close(stop)
+		return nil, errors.Errorf("Error creating the policy controller: %s", err)
+	}
+
+	kubeProvider := kube.NewClient(kubeController, nil, constants.KubeProviderName, cfg)
+	certManager := tresor.NewFakeCertManager(cfg)
+
+	meshCatalog := catalog.NewMeshCatalog(kubeController, meshSpec, certManager, noIngressMonitor{}, policyController, stop, cfg,
+		[]service.Provider{kubeProvider}, []endpoint.Provider{kubeProvider})
+
+	return &Simulator{
+		kubeClient:    kubeClient,
+		meshCatalog:   meshCatalog,
+		cfg:           cfg,
+		certManager:   certManager,
+		proxyRegistry: registry.NewProxyRegistry(&registry.KubeProxyServiceMapper{KubeController: kubeController}),
+		stop:          stop,
+	}, nil
+}
+
+// Stop stops the informers of the simulator
+func (s *Simulator) Stop() {
+	close(s.stop)
+}
+
+// CheckTrafficRequest evaluates whether the given request is allowed by the traffic policies of the simulated mesh
+func (s *Simulator) CheckTrafficRequest(req catalog.TrafficRequest) catalog.TrafficDecision {
+	return s.meshCatalog.CheckTrafficRequest(req)
+}
+
+// GetPod returns the pod with the given name in the given namespace, which may have been created for a workload
+func (s *Simulator) GetPod(namespace, name string) (*corev1.Pod, error) {
+	pod, err := s.kubeClient.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Errorf("Pod %s/%s not found in the manifests", namespace, name)
+	}
+	return pod, nil
+}
+
+// GetPodServiceIdentity returns the service identity, in the trust domain of the simulated mesh, of the given pod
+func (s *Simulator) GetPodServiceIdentity(pod *corev1.Pod) identity.ServiceIdentity {
+	return identity.K8sServiceAccount{Name: pod.Spec.ServiceAccountName, Namespace: pod.Namespace}.ToServiceIdentity(s.cfg.GetTrustDomain())
+}
+
+// newWorkloadPod returns the pod simulating the pods of a workload
+func newWorkloadPod(workload metav1.ObjectMeta, template corev1.PodTemplateSpec) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: *template.ObjectMeta.DeepCopy(),
+		Spec:       *template.Spec.DeepCopy(),
+	}
+	pod.Name = workload.Name
+	pod.Namespace = workload.Namespace
+	return pod
+}
+
+// newServiceEndpoints returns the Endpoints of the given service, listing the addresses of the pods it selects
+func newServiceEndpoints(svc *corev1.Service, pods []*corev1.Pod) *corev1.Endpoints {
+	eps := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      svc.Name,
+			Namespace: svc.Namespace,
+		},
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return eps
+	}
+
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+	for _, pod := range pods {
+		if pod.Namespace != svc.Namespace || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		subset := corev1.EndpointSubset{
+			Addresses: []corev1.EndpointAddress{{IP: pod.Status.PodIP}},
+		}
+		for _, port := range svc.Spec.Ports {
+			subset.Ports = append(subset.Ports, corev1.EndpointPort{
+				Name:        port.Name,
+				Port:        getPodPort(pod, port),
+				Protocol:    port.Protocol,
+				AppProtocol: port.AppProtocol,
+			})
+		}
+		eps.Subsets = append(eps.Subsets, subset)
+	}
+	return eps
+}
+
+// getPodPort returns the port of the pod the given service port targets
+func getPodPort(pod *corev1.Pod, port corev1.ServicePort) int32 {
+	switch {
+	case port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal != 0:
+		return port.TargetPort.IntVal
+	case port.TargetPort.Type == intstr.String && port.TargetPort.StrVal != "":
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == port.TargetPort.StrVal {
+					return containerPort.ContainerPort
+				}
+			}
+		}
+	}
+	return port.Port
+}
@@ -0,0 +1,344 @@
+package simulator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const testManifest = `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: bookbuyer
+  namespace: bookbuyer
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bookbuyer
+  namespace: bookbuyer
+spec:
+  selector:
+    matchLabels:
+      app: bookbuyer
+  template:
+    metadata:
+      labels:
+        app: bookbuyer
+    spec:
+      serviceAccountName: bookbuyer
+      containers:
+      - name: bookbuyer
+        image: openservicemesh/bookbuyer
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bookstore-v1
+  namespace: bookstore
+spec:
+  selector:
+    matchLabels:
+      app: bookstore
+      version: v1
+  template:
+    metadata:
+      labels:
+        app: bookstore
+        version: v1
+    spec:
+      serviceAccountName: bookstore
+      containers:
+      - name: bookstore
+        image: openservicemesh/bookstore
+        ports:
+        - name: web
+          containerPort: 14001
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  selector:
+    app: bookstore
+  ports:
+  - name: http
+    port: 14001
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: bookstore-v1
+  namespace: bookstore
+spec:
+  selector:
+    app: bookstore
+    version: v1
+  ports:
+  - name: http
+    port: 14001
+    targetPort: web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: mysql
+  namespace: bookstore
+spec:
+  selector:
+    app: bookstore
+  ports:
+  - name: tcp-mysql
+    port: 3306
+---
+apiVersion: specs.smi-spec.io/v1alpha4
+kind: HTTPRouteGroup
+metadata:
+  name: bookstore-routes
+  namespace: bookstore
+spec:
+  matches:
+  - name: books
+    pathRegex: /books/.*
+    methods:
+    - GET
+---
+apiVersion: specs.smi-spec.io/v1alpha4
+kind: TCPRoute
+metadata:
+  name: mysql
+  namespace: bookstore
+spec:
+  matches:
+    ports:
+    - 3306
+---
+apiVersion: access.smi-spec.io/v1alpha3
+kind: TrafficTarget
+metadata:
+  name: bookbuyer-access-bookstore
+  namespace: bookstore
+spec:
+  destination:
+    kind: ServiceAccount
+    name: bookstore
+    namespace: bookstore
+  rules:
+  - kind: HTTPRouteGroup
+    name: bookstore-routes
+    matches:
+    - books
+  - kind: TCPRoute
+    name: mysql
+  sources:
+  - kind: ServiceAccount
+    name: bookbuyer
+    namespace: bookbuyer
+---
+apiVersion: split.smi-spec.io/v1alpha2
+kind: TrafficSplit
+metadata:
+  name: bookstore-split
+  namespace: bookstore
+spec:
+  service: bookstore.bookstore
+  backends:
+  - service: bookstore-v1
+    weight: 100
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: skipped
+`
+
+func newTestSimulator(t *testing.T) *Simulator {
+	objects, err := DecodeManifest([]byte(testManifest))
+	trequire.NoError(t, err)
+
+	sim, err := NewSimulator(objects)
+	trequire.NoError(t, err)
+	return sim
+}
+
+func TestDecodeManifest(t *testing.T) {
+	assert := tassert.New(t)
+
+	objects, err := DecodeManifest([]byte(testManifest))
+	assert.NoError(err)
+	// The resource of the unknown kind is skipped
+	assert.Len(objects, 10)
+
+	_, err = DecodeManifest([]byte("apiVersion: v1\nkind: Service\nmetadata: [invalid"))
+	assert.Error(err)
+}
+
+func TestCheckTrafficRequest(t *testing.T) {
+	sim := newTestSimulator(t)
+	defer sim.Stop()
+
+	bookbuyer := identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "bookbuyer"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
+	bookstore := identity.K8sServiceAccount{Name: "bookstore", Namespace: "bookstore"}.ToServiceIdentity(identity.ClusterLocalTrustDomain)
+
+	testCases := []struct {
+		name            string
+		req             catalog.TrafficRequest
+		expectedAllowed bool
+		expectedReason  string
+		expectedRoute   string
+	}{
+		{
+			name: "HTTP request matching a route through the traffic split",
+			req: catalog.TrafficRequest{
+				Source:      bookbuyer,
+				Destination: service.MeshService{Name: "bookstore", Namespace: "bookstore"},
+				Method:      "GET",
+				Path:        "/books/1",
+			},
+			expectedAllowed: true,
+			expectedReason:  "Request from bookbuyer.bookbuyer.cluster.local to bookstore/bookstore is allowed on every backend",
+			expectedRoute:   "GET /books/.*",
+		},
+		{
+			name: "HTTP request with a method not allowed",
+			req: catalog.TrafficRequest{
+				Source:      bookbuyer,
+				Destination: service.MeshService{Name: "bookstore-v1", Namespace: "bookstore"},
+				Method:      "DELETE",
+				Path:        "/books/1",
+			},
+			expectedAllowed: false,
+			expectedReason:  "No HTTP route allowed for bookbuyer.bookbuyer.cluster.local on bookstore.bookstore.cluster.local matches DELETE /books/1",
+		},
+		{
+			name: "source not allowed by any TrafficTarget",
+			req: catalog.TrafficRequest{
+				Source:      bookstore,
+				Destination: service.MeshService{Name: "bookstore-v1", Namespace: "bookstore"},
+			},
+			expectedAllowed: false,
+			expectedReason:  "Service bookstore/bookstore-v1 is not an upstream of bookstore.bookstore.cluster.local, no TrafficTarget allows bookstore.bookstore.cluster.local to access the identities of its pods",
+		},
+		{
+			name: "TCP connection on a port allowed by a TCPRoute",
+			req: catalog.TrafficRequest{
+				Source:      bookbuyer,
+				Destination: service.MeshService{Name: "mysql", Namespace: "bookstore"},
+				Port:        3306,
+			},
+			expectedAllowed: true,
+			expectedReason:  "Request from bookbuyer.bookbuyer.cluster.local to bookstore/mysql is allowed on every backend",
+		},
+		{
+			name: "unknown port",
+			req: catalog.TrafficRequest{
+				Source:      bookbuyer,
+				Destination: service.MeshService{Name: "mysql", Namespace: "bookstore"},
+				Port:        5432,
+			},
+			expectedAllowed: false,
+			expectedReason:  "Service bookstore/mysql has no port 5432",
+		},
+		{
+			name: "unknown service",
+			req: catalog.TrafficRequest{
+				Source:      bookbuyer,
+				Destination: service.MeshService{Name: "unknown", Namespace: "bookstore"},
+			},
+			expectedAllowed: false,
+			expectedReason:  "Service bookstore/unknown not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			decision := sim.CheckTrafficRequest(tc.req)
+			assert.Equal(tc.expectedAllowed, decision.Allowed)
+			assert.Equal(tc.expectedReason, decision.Reason)
+			if tc.expectedRoute != "" {
+				trequire.Len(t, decision.Backends, 1)
+				assert.Equal("bookstore/bookstore-v1", decision.Backends[0].Service)
+				assert.Equal(100, decision.Backends[0].Weight)
+				assert.Equal([]string{"bookstore/bookbuyer-access-bookstore"}, decision.Backends[0].TrafficTargets)
+				trequire.NotNil(t, decision.Backends[0].Route)
+				assert.Equal(tc.expectedRoute, decision.Backends[0].Route.String())
+			}
+		})
+	}
+}
+
+func TestGetProxyConfig(t *testing.T) {
+	assert := tassert.New(t)
+	sim := newTestSimulator(t)
+	defer sim.Stop()
+
+	config, err := sim.GetProxyConfig("bookbuyer", "bookbuyer")
+	assert.NoError(err)
+	trequire.NotNil(t, config)
+
+	var listenerNames []string
+	for _, listener := range config.Listeners {
+		var l struct {
+			Name string `json:"name"`
+		}
+		assert.NoError(json.Unmarshal(listener, &l))
+		listenerNames = append(listenerNames, l.Name)
+	}
+	assert.Contains(listenerNames, "outbound-listener")
+
+	var clusterNames []string
+	for _, cluster := range config.Clusters {
+		var c struct {
+			Name string `json:"name"`
+		}
+		assert.NoError(json.Unmarshal(cluster, &c))
+		clusterNames = append(clusterNames, c.Name)
+	}
+	assert.Contains(clusterNames, "bookstore/bookstore-v1")
+
+	_, err = sim.GetProxyConfig("bookbuyer", "unknown")
+	assert.Error(err)
+	assert.True(strings.Contains(err.Error(), "not found"))
+}
+
+func TestCheckTrafficRequestPermissiveMode(t *testing.T) {
+	assert := tassert.New(t)
+
+	objects, err := DecodeManifest([]byte(testManifest + `
+---
+apiVersion: config.openservicemesh.io/v1alpha1
+kind: MeshConfig
+metadata:
+  name: osm-mesh-config
+  namespace: osm-system
+spec:
+  traffic:
+    enablePermissiveTrafficPolicyMode: true
+`))
+	trequire.NoError(t, err)
+	sim, err := NewSimulator(objects)
+	trequire.NoError(t, err)
+	defer sim.Stop()
+
+	decision := sim.CheckTrafficRequest(catalog.TrafficRequest{
+		Source:      identity.K8sServiceAccount{Name: "bookstore", Namespace: "bookstore"}.ToServiceIdentity(identity.ClusterLocalTrustDomain),
+		Destination: service.MeshService{Name: "bookstore-v1", Namespace: "bookstore"},
+		Method:      "DELETE",
+		Path:        "/books/1",
+	})
+	assert.True(decision.Allowed)
+	trequire.Len(t, decision.Backends, 1)
+	assert.Equal("Allowed in permissive traffic policy mode", decision.Backends[0].Reason)
+}
@@ -0,0 +1,37 @@
+// Package simulator runs the traffic policy logic of the OSM control plane offline, against Kubernetes, SMI and OSM
+// resources loaded from manifests instead of a cluster, to evaluate traffic policies before they are applied.
+package simulator
+
+import (
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	log = logger.New("simulator")
+)
+
+const (
+	// meshName is the name of the simulated mesh, all the namespaces of the resources are part of it
+	meshName = "osm"
+
+	// defaultOSMNamespace and defaultMeshConfigName locate the MeshConfig when none is loaded from the manifests
+	defaultOSMNamespace   = "osm-system"
+	defaultMeshConfigName = "osm-mesh-config"
+)
+
+// Simulator runs a MeshCatalog and the xDS builders of the OSM control plane on fake clientsets populated with
+// the resources loaded from manifests
+type Simulator struct {
+	kubeClient    kubernetes.Interface
+	meshCatalog   *catalog.MeshCatalog
+	cfg           configurator.Configurator
+	certManager   certificate.Manager
+	proxyRegistry *registry.ProxyRegistry
+	stop          chan struct{}
+}
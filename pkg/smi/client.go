@@ -48,6 +48,12 @@ func NewMeshSpecClient(smiKubeConfig *rest.Config, kubeClient kubernetes.Interfa
 	return client, err
 }
 
+// NewMeshSpecClientFromClientsets implements mesh.MeshSpec and creates the Kubernetes client, which retrieves SMI specific CRDs
+// using the given SMI clientsets.
+func NewMeshSpecClientFromClientsets(kubeClient kubernetes.Interface, splitClient smiTrafficSplitClient.Interface, specClient smiTrafficSpecClient.Interface, accessClient smiAccessClient.Interface, osmNamespace string, kubeController k8s.Controller, stop chan struct{}) (MeshSpec, error) {
+	return newSMIClient(kubeClient, splitClient, specClient, accessClient, osmNamespace, kubeController, kubernetesClientName, stop)
+}
+
 func (c *client) run(stop <-chan struct{}) error {
 	log.Info().Msg("SMI client started")
 	var hasSynced []cache.InformerSynced
@@ -391,7 +391,7 @@ var (
 	}
 
 	// BookstoreServiceIdentity is the ServiceIdentity for the Bookstore service.
-	BookstoreServiceIdentity = BookstoreServiceAccount.ToServiceIdentity()
+	BookstoreServiceIdentity = BookstoreServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)
 
 	// BookstoreV2ServiceAccount is a namespaced service account.
 	BookstoreV2ServiceAccount = identity.K8sServiceAccount{
@@ -400,7 +400,7 @@ var (
 	}
 
 	// BookstoreV2ServiceIdentity is the ServiceIdentity for the Bokstore v2 service.
-	BookstoreV2ServiceIdentity = BookstoreV2ServiceAccount.ToServiceIdentity()
+	BookstoreV2ServiceIdentity = BookstoreV2ServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)
 
 	// BookbuyerServiceAccount is a namespaced bookbuyer account.
 	BookbuyerServiceAccount = identity.K8sServiceAccount{
@@ -409,7 +409,7 @@ var (
 	}
 
 	// BookbuyerServiceIdentity is the ServiceIdentity for the Bookbuyer service.
-	BookbuyerServiceIdentity = BookbuyerServiceAccount.ToServiceIdentity()
+	BookbuyerServiceIdentity = BookbuyerServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain)
 
 	// HTTPRouteGroup is the HTTP route group SMI object.
 	HTTPRouteGroup = spec.HTTPRouteGroup{
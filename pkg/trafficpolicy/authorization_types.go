@@ -0,0 +1,67 @@
+package trafficpolicy
+
+import (
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+// AuthorizationTrafficPolicy defines the authorization rules applied to inbound HTTP requests for a given service,
+// in addition to the rules derived from SMI traffic policies
+type AuthorizationTrafficPolicy struct {
+	// DenyRules is the list of rules a request must not match
+	DenyRules []*AuthorizationRule
+
+	// AllowRules is the list of rules a request must match at least one of, if EnforceAllowRules is set
+	AllowRules []*AuthorizationRule
+
+	// EnforceAllowRules is set if an ALLOW policy applies, in which case a request that does not match any of
+	// the AllowRules is denied, including when no valid ALLOW rule remains
+	EnforceAllowRules bool
+
+	// JWTIssuers is the list of issuers the JWTs presented in the requests are authenticated against
+	JWTIssuers []*JWTIssuer
+}
+
+// JWTIssuer defines an issuer of the JWTs authenticated for authorization
+type JWTIssuer struct {
+	// Issuer is the issuer of the JWTs, matched against their 'iss' claim
+	Issuer string
+
+	// JWKS is the JSON Web Key Set used to verify the signature of the JWTs
+	JWKS string
+}
+
+// RequestPrincipal defines the principal of an authenticated JWT
+type RequestPrincipal struct {
+	// Issuer is the issuer of the JWT
+	Issuer string
+
+	// Subject is the subject of the JWT
+	Subject string
+}
+
+// AuthorizationRule defines the attributes a request is matched against for authorization.
+// A request matches the rule if it matches all the attributes specified in the rule.
+// An attribute is matched if the request matches any of its values, and a rule without attributes matches all requests.
+type AuthorizationRule struct {
+	// Name is the unique name of the rule
+	Name string
+
+	// Principals is the list of downstream service identities
+	Principals []identity.ServiceIdentity
+
+	// PrincipalSuffixes is the list of suffixes of downstream service identities, used to match
+	// all the service identities in a namespace
+	PrincipalSuffixes []string
+
+	// RequestPrincipals is the list of principals of the authenticated JWT
+	RequestPrincipals []RequestPrincipal
+
+	// Methods is the list of HTTP methods
+	Methods []string
+
+	// Paths is the list of HTTP paths, where a path ending with '*' is matched as a prefix
+	Paths []string
+
+	// Headers is the map of HTTP header names to values that must all be present in the request
+	Headers map[string]string
+}
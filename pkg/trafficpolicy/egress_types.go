@@ -1,5 +1,7 @@
 package trafficpolicy
 
+import mapset "github.com/deckarep/golang-set"
+
 // EgressTrafficPolicy is the type used to represent the different egress traffic policy configurations
 // applicable to a client of Egress destinations.
 type EgressTrafficPolicy struct {
@@ -63,6 +65,35 @@ type EgressClusterConfig struct {
 
 	// Port defines the port number of the external cluster's endpoint
 	Port int
+
+	// TLS defines the TLS configuration used to originate TLS connections to the external cluster.
+	// If unspecified, the connections to the external cluster are not TLS originated by the proxy.
+	// +optional
+	TLS *EgressTLSConfig
+
+	// ViaEgressGateway defines whether traffic to the external cluster is routed through the egress gateway
+	// instead of being sent to the external cluster directly.
+	// +optional
+	ViaEgressGateway bool
+}
+
+// EgressTLSConfig is the type used to represent the TLS origination configuration of an external cluster
+type EgressTLSConfig struct {
+	// CABundle defines the PEM encoded CA bundle used to validate the certificate presented by the external cluster
+	CABundle []byte
+
+	// ClientCertificate defines the PEM encoded client certificate presented to the external cluster
+	// +optional
+	ClientCertificate []byte
+
+	// ClientKey defines the PEM encoded private key corresponding to ClientCertificate
+	// +optional
+	ClientKey []byte
+
+	// ClientCertificateSecret defines the namespaced name of the secret holding ClientCertificate and ClientKey,
+	// used to name the SDS secret serving them to the proxy
+	// +optional
+	ClientCertificateSecret string
 }
 
 // EgressHTTPRouteConfig is the type used to represent an HTTP route configuration along with associated routing rules
@@ -78,6 +109,11 @@ type EgressHTTPRouteConfig struct {
 	// RoutingRules defines the list of routes for the Egress HTTP route configuration, and corresponding
 	// rules to be applied to those routes.
 	RoutingRules []*EgressHTTPRoutingRule
+
+	// ViaEgressGateway defines whether requests matching the Egress HTTP route configuration are routed
+	// through the egress gateway.
+	// +optional
+	ViaEgressGateway bool
 }
 
 // EgressHTTPRoutingRule is the type used to represent an Egress HTTP routing rule with its route and associated permissions
@@ -87,4 +123,9 @@ type EgressHTTPRoutingRule struct {
 
 	// AllowedDestinationIPRanges defines the destination IP ranges allowed for the `Route` defined in the routing rule.
 	AllowedDestinationIPRanges []string
+
+	// AllowedServiceIdentities defines the service identities allowed to access the `Route` defined in the
+	// routing rule. It is only set for routing rules programmed on the egress gateway.
+	// +optional
+	AllowedServiceIdentities mapset.Set
 }
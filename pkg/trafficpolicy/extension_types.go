@@ -0,0 +1,26 @@
+package trafficpolicy
+
+// EnvoyFilterExtension defines a user-supplied HTTP filter added to the HTTP filter chains of a proxy,
+// after the filters managed by OSM
+type EnvoyFilterExtension struct {
+	// Name is the name of the EnvoyFilterExtension resource the filter is derived from, in the form <namespace>/<name>
+	Name string
+
+	// WASM is the WASM module run by the filter, nil for a Lua filter
+	WASM *WASMModule
+
+	// LuaInlineCode is the Lua script run by the filter, empty for a WASM filter
+	LuaInlineCode string
+}
+
+// WASMModule defines the WASM module run by an EnvoyFilterExtension filter
+type WASMModule struct {
+	// Code is the compiled WASM module
+	Code []byte
+
+	// RootID is the root ID of the module's context handling the requests
+	RootID string
+
+	// Configuration is the configuration passed to the module
+	Configuration string
+}
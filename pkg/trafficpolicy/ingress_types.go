@@ -2,8 +2,11 @@ package trafficpolicy
 
 // IngressTrafficPolicy defines the ingress traffic match and routes for a given backend
 type IngressTrafficPolicy struct {
-	TrafficMatches    []*IngressTrafficMatch
-	HTTPRoutePolicies []*InboundTrafficPolicy
+	TrafficMatches []*IngressTrafficMatch
+
+	// HTTPRoutePoliciesPerPort are the HTTP route policies keyed by the port of the traffic matches they apply to,
+	// such that the routes allowed on a port do not apply to the traffic received on other ports
+	HTTPRoutePoliciesPerPort map[uint32][]*InboundTrafficPolicy
 }
 
 // IngressTrafficMatch defines the attributes to match ingress traffic for a given backend
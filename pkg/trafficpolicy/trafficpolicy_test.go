@@ -75,7 +75,7 @@ func TestAddRule(t *testing.T) {
 			expectedRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 		},
@@ -84,7 +84,7 @@ func TestAddRule(t *testing.T) {
 			existingRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 			allowedServiceAccount: testServiceAccount2,
@@ -92,7 +92,7 @@ func TestAddRule(t *testing.T) {
 			expectedRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(), testServiceAccount2.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain), testServiceAccount2.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 		},
@@ -101,7 +101,7 @@ func TestAddRule(t *testing.T) {
 			existingRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 			allowedServiceAccount: testServiceAccount1,
@@ -109,7 +109,7 @@ func TestAddRule(t *testing.T) {
 			expectedRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 		},
@@ -120,7 +120,7 @@ func TestAddRule(t *testing.T) {
 			assert := tassert.New(t)
 
 			inboundPolicy := newTestInboundPolicy(tc.name, tc.existingRules)
-			inboundPolicy.AddRule(tc.route, tc.allowedServiceAccount.ToServiceIdentity())
+			inboundPolicy.AddRule(tc.route, tc.allowedServiceAccount.ToServiceIdentity(identity.ClusterLocalTrustDomain))
 			assert.Equal(tc.expectedRules, inboundPolicy.Rules)
 		})
 	}
@@ -249,11 +249,11 @@ func TestAddRoute(t *testing.T) {
 func TestMergeInboundPolicies(t *testing.T) {
 	testRule1 := Rule{
 		Route:                    testRoute,
-		AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+		AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 	}
 	testRule2 := Rule{
 		Route:                    testRoute2,
-		AllowedServiceIdentities: mapset.NewSet(testServiceAccount2.ToServiceIdentity()),
+		AllowedServiceIdentities: mapset.NewSet(testServiceAccount2.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 	}
 	testRule1Modified := Rule{
 		Route: RouteWeightedClusters{
@@ -353,11 +353,11 @@ func TestMergeInboundPolicies(t *testing.T) {
 func TestMergeInboundPoliciesWithPartialHostnames(t *testing.T) {
 	testRule1 := Rule{
 		Route:                    testRoute,
-		AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+		AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 	}
 	testRule2 := Rule{
 		Route:                    testRoute2,
-		AllowedServiceIdentities: mapset.NewSet(testServiceAccount2.ToServiceIdentity()),
+		AllowedServiceIdentities: mapset.NewSet(testServiceAccount2.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 	}
 	testRule1Modified := Rule{
 		Route: RouteWeightedClusters{
@@ -441,19 +441,19 @@ func TestMergeRules(t *testing.T) {
 			originalRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 			newRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount2.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount2.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 			expectedRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSetWith(testServiceAccount1.ToServiceIdentity(), testServiceAccount2.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSetWith(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain), testServiceAccount2.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 		},
@@ -462,19 +462,19 @@ func TestMergeRules(t *testing.T) {
 			originalRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 			newRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 			expectedRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSetWith(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSetWith(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 		},
@@ -483,23 +483,23 @@ func TestMergeRules(t *testing.T) {
 			originalRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 			newRules: []*Rule{
 				{
 					Route:                    testRoute2,
-					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSet(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 			expectedRules: []*Rule{
 				{
 					Route:                    testRoute,
-					AllowedServiceIdentities: mapset.NewSetWith(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSetWith(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 				{
 					Route:                    testRoute2,
-					AllowedServiceIdentities: mapset.NewSetWith(testServiceAccount1.ToServiceIdentity()),
+					AllowedServiceIdentities: mapset.NewSetWith(testServiceAccount1.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 				},
 			},
 		},
@@ -41,9 +41,9 @@ func getPartialValidatingWebhookConfiguration(name string, cert certificate.Cert
 	}
 }
 
-// updateValidatingWebhookCABundle updates the existing ValidatingWebhookConfiguration with the CA this OSM instance runs with.
+// UpdateValidatingWebhookCABundle updates the existing ValidatingWebhookConfiguration with the CA this OSM instance runs with.
 // It is necessary to perform this patch because the original ValidatingWebhookConfig YAML does not contain the root certificate.
-func updateValidatingWebhookCABundle(webhookConfigName string, certificater certificate.Certificater, kubeClient kubernetes.Interface) error {
+func UpdateValidatingWebhookCABundle(webhookConfigName string, certificater certificate.Certificater, kubeClient kubernetes.Interface) error {
 	vwc := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations()
 
 	patchJSON, err := json.Marshal(getPartialValidatingWebhookConfiguration(webhookConfigName, certificater))
@@ -10,7 +10,6 @@ import (
 
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
-	"k8s.io/client-go/kubernetes"
 
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 
@@ -34,19 +33,17 @@ type validatingWebhookServer struct {
 }
 
 // NewValidatingWebhook returns a validatingWebhookServer with the defaultValidators that were previously registered.
-func NewValidatingWebhook(webhookConfigName string, port int, certificater certificate.Certificater, kubeClient kubernetes.Interface, stop <-chan struct{}) error {
+// The CA bundle of the ValidatingWebhookConfiguration is updated separately using UpdateValidatingWebhookCABundle.
+func NewValidatingWebhook(port int, certificater certificate.Certificater, stop <-chan struct{}) error {
 	v := &validatingWebhookServer{
 		validators: map[string]validateFunc{
-			policyv1alpha1.SchemeGroupVersion.WithKind("IngressBackend").String(): ingressBackendValidator,
-			policyv1alpha1.SchemeGroupVersion.WithKind("Egress").String():         egressValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("IngressBackend").String():       ingressBackendValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("Egress").String():               egressValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("AuthorizationPolicy").String():  authorizationPolicyValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("EnvoyFilterExtension").String(): envoyFilterExtensionValidator,
 		},
 	}
 
-	// Update the updateValidatingWebhookConfig with the OSM CA bundle
-	if err := updateValidatingWebhookCABundle(webhookConfigName, certificater, kubeClient); err != nil {
-		return errors.Wrapf(err, "Error configuring ValidatingWebhookConfiguration %s", webhookConfigName)
-	}
-
 	go v.run(port, certificater, stop)
 	return nil
 }
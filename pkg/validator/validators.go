@@ -9,11 +9,14 @@ import (
 
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/oci"
 )
 
 // validateFunc is a function type that accepts an AdmissionRequest and returns an AdmissionResponse.
@@ -112,9 +115,161 @@ func egressValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionR
 		}
 	}
 
+	for _, host := range egress.Spec.Hosts {
+		if strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			return nil, errors.Errorf("Invalid host %s, wildcards are only supported as a '*.' prefix", host)
+		}
+	}
+
+	if egress.Spec.TLS != nil {
+		hasHTTPPort := false
+		for _, port := range egress.Spec.Ports {
+			if strings.EqualFold(port.Protocol, constants.ProtocolHTTP) {
+				hasHTTPPort = true
+				break
+			}
+		}
+		if !hasHTTPPort {
+			return nil, errors.New("Expected an 'http' port when 'TLS' origination is specified")
+		}
+
+		// Secrets are restricted to the namespace of the Egress policy so that the policy cannot expose the
+		// secrets of other namespaces to its clients
+		secretRefs := []corev1.SecretReference{egress.Spec.TLS.CABundleSecret}
+		if egress.Spec.TLS.ClientCertificateSecret != nil {
+			secretRefs = append(secretRefs, *egress.Spec.TLS.ClientCertificateSecret)
+		}
+		for _, ref := range secretRefs {
+			if ref.Namespace != "" && ref.Namespace != req.Namespace {
+				return nil, errors.Errorf("Expected secret %s to be in the namespace %s of the Egress policy, got: %s", ref.Name, req.Namespace, ref.Namespace)
+			}
+		}
+	}
+
 	return nil, nil
 }
 
+// authorizationPolicyValidator validates the AuthorizationPolicy custom resource
+func authorizationPolicyValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	authzPolicy := &policyv1alpha1.AuthorizationPolicy{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(authzPolicy); err != nil {
+		return nil, err
+	}
+
+	switch authzPolicy.Spec.Action {
+	case policyv1alpha1.AuthorizationActionAllow, policyv1alpha1.AuthorizationActionDeny:
+		// Valid
+	default:
+		return nil, errors.Errorf("Expected 'action' to be '%s' or '%s', got: %s", policyv1alpha1.AuthorizationActionAllow, policyv1alpha1.AuthorizationActionDeny, authzPolicy.Spec.Action)
+	}
+
+	// An ALLOW policy without rules denies all requests to the services it applies to
+	if authzPolicy.Spec.Action == policyv1alpha1.AuthorizationActionAllow && len(authzPolicy.Spec.Rules) == 0 {
+		return nil, errors.Errorf("Expected at least one rule for action '%s'", policyv1alpha1.AuthorizationActionAllow)
+	}
+
+	issuers := make(map[string]bool)
+	for _, issuerSpec := range authzPolicy.Spec.JWTIssuers {
+		if issuerSpec.Issuer == "" || issuerSpec.JWKS == "" {
+			return nil, errors.New("Expected 'issuer' and 'jwks' to be specified for JWT issuers")
+		}
+		if issuers[issuerSpec.Issuer] {
+			return nil, errors.Errorf("Duplicate JWT issuer %q", issuerSpec.Issuer)
+		}
+		if !json.Valid([]byte(issuerSpec.JWKS)) {
+			return nil, errors.Errorf("Invalid JWKS for JWT issuer %q, expected a JSON Web Key Set", issuerSpec.Issuer)
+		}
+		issuers[issuerSpec.Issuer] = true
+	}
+
+	for i, rule := range authzPolicy.Spec.Rules {
+		for _, requestPrincipal := range rule.RequestPrincipals {
+			if !hasJWTIssuerPrefix(requestPrincipal, issuers) {
+				return nil, errors.Errorf("Invalid request principal %q in rule %d, expected <issuer>/<subject> with a JWT issuer of the policy", requestPrincipal, i)
+			}
+		}
+
+		for _, principal := range rule.Principals {
+			chunks := strings.Split(principal, "/")
+			if len(chunks) != 2 || len(validation.IsDNS1123Label(chunks[0])) != 0 || len(validation.IsDNS1123Subdomain(chunks[1])) != 0 {
+				return nil, errors.Errorf("Invalid principal %q in rule %d, expected <namespace>/<service account name>", principal, i)
+			}
+		}
+
+		for _, ns := range rule.Namespaces {
+			if len(validation.IsDNS1123Label(ns)) != 0 {
+				return nil, errors.Errorf("Invalid namespace %q in rule %d", ns, i)
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// envoyFilterExtensionValidator validates the EnvoyFilterExtension custom resource
+func envoyFilterExtensionValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	extension := &policyv1alpha1.EnvoyFilterExtension{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(extension); err != nil {
+		return nil, err
+	}
+
+	switch extension.Spec.Direction {
+	case policyv1alpha1.EnvoyFilterDirectionInbound, policyv1alpha1.EnvoyFilterDirectionOutbound:
+		// Valid
+	default:
+		return nil, errors.Errorf("Expected 'direction' to be '%s' or '%s', got: %s", policyv1alpha1.EnvoyFilterDirectionInbound, policyv1alpha1.EnvoyFilterDirectionOutbound, extension.Spec.Direction)
+	}
+
+	switch extension.Spec.Position {
+	case "", policyv1alpha1.EnvoyFilterPositionFirst, policyv1alpha1.EnvoyFilterPositionLast:
+		// Valid
+	default:
+		return nil, errors.Errorf("Expected 'position' to be '%s' or '%s', got: %s", policyv1alpha1.EnvoyFilterPositionFirst, policyv1alpha1.EnvoyFilterPositionLast, extension.Spec.Position)
+	}
+
+	for _, svcAccount := range extension.Spec.ServiceAccounts {
+		if len(validation.IsDNS1123Subdomain(svcAccount)) != 0 {
+			return nil, errors.Errorf("Invalid service account name %q", svcAccount)
+		}
+	}
+
+	wasm, lua := extension.Spec.WASM, extension.Spec.Lua
+	if (wasm == nil) == (lua == nil) {
+		return nil, errors.New("Expected exactly one of 'wasm' and 'lua' to be specified")
+	}
+
+	if lua != nil && strings.TrimSpace(lua.InlineCode) == "" {
+		return nil, errors.New("Expected 'inlineCode' to be specified for the Lua script")
+	}
+
+	if wasm != nil {
+		if (wasm.ConfigMap == nil) == (wasm.Image == "") {
+			return nil, errors.New("Expected exactly one of 'configMap' and 'image' to be specified for the WASM module")
+		}
+		if wasm.ConfigMap != nil && (wasm.ConfigMap.Name == "" || wasm.ConfigMap.Key == "") {
+			return nil, errors.New("Expected 'name' and 'key' to be specified for the WASM module's ConfigMap")
+		}
+		if wasm.Image != "" {
+			if _, err := oci.ParseReference(wasm.Image); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// hasJWTIssuerPrefix returns whether the given request principal is of the form <issuer>/<subject>
+// with one of the given issuers and a non-empty subject
+func hasJWTIssuerPrefix(requestPrincipal string, issuers map[string]bool) bool {
+	for issuer := range issuers {
+		if strings.HasPrefix(requestPrincipal, issuer+"/") && len(requestPrincipal) > len(issuer)+1 {
+			return true
+		}
+	}
+	return false
+}
+
 // MultiClusterServiceValidator validates the MultiClusterService CRD.
 func MultiClusterServiceValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
 	config := &configv1alpha1.MultiClusterService{}
@@ -134,12 +289,20 @@ func MultiClusterServiceValidator(req *admissionv1.AdmissionRequest) (*admission
 		if len(strings.TrimSpace(cluster.Address)) == 0 {
 			return nil, errors.Errorf("Cluster address %s is not valid", cluster.Address)
 		}
-		clusterAddress := strings.Split(cluster.Address, ":")
-		if net.ParseIP(clusterAddress[0]) == nil {
-			return nil, errors.Errorf("Error parsing IP address %s", cluster.Address)
-		}
-		_, err := strconv.ParseUint(clusterAddress[1], 10, 32)
+		host, port, err := net.SplitHostPort(cluster.Address)
 		if err != nil {
+			return nil, errors.Errorf("Error parsing cluster address %s, expected <host>:<port>", cluster.Address)
+		}
+		if net.ParseIP(host) == nil {
+			// A host made of digits and dots can only be an IP address
+			if strings.Trim(host, "0123456789.") == "" {
+				return nil, errors.Errorf("Error parsing IP address %s", cluster.Address)
+			}
+			if errs := validation.IsDNS1123Subdomain(host); len(errs) > 0 {
+				return nil, errors.Errorf("Error parsing hostname %s: %s", cluster.Address, strings.Join(errs, ", "))
+			}
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
 			return nil, errors.Errorf("Error parsing port value %s", cluster.Address)
 		}
 		clusterNames[cluster.Name] = true
@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	tassert "github.com/stretchr/testify/assert"
@@ -298,6 +300,112 @@ func TestEgressValidator(t *testing.T) {
 			expResp:   nil,
 			expErrStr: "",
 		},
+		{
+			name: "Egress with invalid wildcard host fails",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["*.example.com", "foo.*.com"]
+						}
+					}
+					`),
+				},
+			},
+
+			expResp:   nil,
+			expErrStr: "Invalid host foo.*.com, wildcards are only supported as a '*.' prefix",
+		},
+		{
+			name: "Egress with TLS origination and no HTTP port fails",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["example.com"],
+							"ports": [{"number": 443, "protocol": "https"}],
+							"tls": {"caBundleSecret": {"name": "ca"}}
+						}
+					}
+					`),
+				},
+			},
+
+			expResp:   nil,
+			expErrStr: "Expected an 'http' port when 'TLS' origination is specified",
+		},
+		{
+			name: "Egress with TLS origination on an HTTP port passes",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["*.example.com"],
+							"ports": [{"number": 443, "protocol": "http"}],
+							"tls": {"caBundleSecret": {"name": "ca"}}
+						}
+					}
+					`),
+				},
+			},
+
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "Egress with a client certificate secret in another namespace fails",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Namespace: "egress-ns",
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["example.com"],
+							"ports": [{"number": 443, "protocol": "http"}],
+							"tls": {
+								"caBundleSecret": {"name": "ca", "namespace": "egress-ns"},
+								"clientCertificateSecret": {"name": "client", "namespace": "other-ns"}
+							}
+						}
+					}
+					`),
+				},
+			},
+
+			expResp:   nil,
+			expErrStr: "Expected secret client to be in the namespace egress-ns of the Egress policy, got: other-ns",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -313,6 +421,189 @@ func TestEgressValidator(t *testing.T) {
 	}
 }
 
+func TestAuthorizationPolicyValidator(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      string
+		expErrStr string
+	}{
+		{
+			name:      "valid policy",
+			spec:      `{"action": "DENY", "rules": [{"principals": ["test/frontend"], "namespaces": ["web"], "methods": ["DELETE"]}]}`,
+			expErrStr: "",
+		},
+		{
+			name:      "unknown action fails",
+			spec:      `{"action": "AUDIT", "rules": [{"methods": ["GET"]}]}`,
+			expErrStr: "Expected 'action' to be 'ALLOW' or 'DENY', got: AUDIT",
+		},
+		{
+			name:      "ALLOW policy without rules fails",
+			spec:      `{"action": "ALLOW", "rules": []}`,
+			expErrStr: "Expected at least one rule for action 'ALLOW'",
+		},
+		{
+			name:      "principal without namespace fails",
+			spec:      `{"action": "DENY", "rules": [{"methods": ["GET"]}, {"principals": ["frontend"]}]}`,
+			expErrStr: `Invalid principal "frontend" in rule 1, expected <namespace>/<service account name>`,
+		},
+		{
+			name:      "principal with an invalid namespace fails",
+			spec:      `{"action": "ALLOW", "rules": [{"principals": ["Test/frontend"]}]}`,
+			expErrStr: `Invalid principal "Test/frontend" in rule 0, expected <namespace>/<service account name>`,
+		},
+		{
+			name:      "invalid namespace fails",
+			spec:      `{"action": "ALLOW", "rules": [{"namespaces": ["web/frontend"]}]}`,
+			expErrStr: `Invalid namespace "web/frontend" in rule 0`,
+		},
+		{
+			name: "request principals of a JWT issuer of the policy pass",
+			spec: `{"action": "DENY", "rules": [{"requestPrincipals": ["https://issuer.example.com/user1"]}],
+				"jwtIssuers": [{"issuer": "https://issuer.example.com", "jwks": "{\"keys\": []}"}]}`,
+		},
+		{
+			name:      "request principals without JWT issuers fail",
+			spec:      `{"action": "DENY", "rules": [{"requestPrincipals": ["https://issuer.example.com/user1"]}]}`,
+			expErrStr: "Invalid request principal \"https://issuer.example.com/user1\" in rule 0, expected <issuer>/<subject> with a JWT issuer of the policy",
+		},
+		{
+			name: "request principals without subject fail",
+			spec: `{"action": "DENY", "rules": [{"requestPrincipals": ["https://issuer.example.com/"]}],
+				"jwtIssuers": [{"issuer": "https://issuer.example.com", "jwks": "{\"keys\": []}"}]}`,
+			expErrStr: "Invalid request principal \"https://issuer.example.com/\" in rule 0, expected <issuer>/<subject> with a JWT issuer of the policy",
+		},
+		{
+			name: "duplicate JWT issuers fail",
+			spec: `{"action": "DENY", "rules": [],
+				"jwtIssuers": [{"issuer": "https://issuer.example.com", "jwks": "{}"}, {"issuer": "https://issuer.example.com", "jwks": "{}"}]}`,
+			expErrStr: "Duplicate JWT issuer \"https://issuer.example.com\"",
+		},
+		{
+			name:      "JWT issuers with invalid JWKS fail",
+			spec:      `{"action": "DENY", "rules": [], "jwtIssuers": [{"issuer": "https://issuer.example.com", "jwks": "not-json"}]}`,
+			expErrStr: "Invalid JWKS for JWT issuer \"https://issuer.example.com\", expected a JSON Web Key Set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			input := &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "policy.openservicemesh.io",
+					Version: "v1alpha1",
+					Kind:    "AuthorizationPolicy",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(fmt.Sprintf(`{"apiVersion": "policy.openservicemesh.io/v1alpha1", "kind": "AuthorizationPolicy", "spec": %s}`, tc.spec)),
+				},
+			}
+
+			resp, err := authorizationPolicyValidator(input)
+			assert.Nil(resp)
+			if tc.expErrStr == "" {
+				assert.Nil(err)
+			} else {
+				assert.EqualError(err, tc.expErrStr)
+			}
+		})
+	}
+}
+
+func TestEnvoyFilterExtensionValidator(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      string
+		expErrStr string
+	}{
+		{
+			name: "valid Lua extension",
+			spec: `{"direction": "inbound", "position": "First", "lua": {"inlineCode": "function envoy_on_request(handle) end"}}`,
+		},
+		{
+			name: "valid WASM extension from a ConfigMap",
+			spec: `{"direction": "outbound", "serviceAccounts": ["bookstore"], "wasm": {"configMap": {"name": "filters", "key": "filter.wasm"}}}`,
+		},
+		{
+			name: "valid WASM extension from an image",
+			spec: `{"direction": "inbound", "position": "Last", "wasm": {"image": "ghcr.io/org/filter@DIGEST", "rootID": "root"}}`,
+		},
+		{
+			name:      "unknown direction fails",
+			spec:      `{"direction": "both", "lua": {"inlineCode": "function envoy_on_request(handle) end"}}`,
+			expErrStr: "Expected 'direction' to be 'inbound' or 'outbound', got: both",
+		},
+		{
+			name:      "unknown position fails",
+			spec:      `{"direction": "inbound", "position": "Middle", "lua": {"inlineCode": "function envoy_on_request(handle) end"}}`,
+			expErrStr: "Expected 'position' to be 'First' or 'Last', got: Middle",
+		},
+		{
+			name:      "invalid service account fails",
+			spec:      `{"direction": "inbound", "serviceAccounts": ["Bookstore"], "lua": {"inlineCode": "function envoy_on_request(handle) end"}}`,
+			expErrStr: `Invalid service account name "Bookstore"`,
+		},
+		{
+			name:      "both WASM and Lua fail",
+			spec:      `{"direction": "inbound", "lua": {"inlineCode": "function envoy_on_request(handle) end"}, "wasm": {"image": "ghcr.io/org/filter@DIGEST"}}`,
+			expErrStr: "Expected exactly one of 'wasm' and 'lua' to be specified",
+		},
+		{
+			name:      "neither WASM nor Lua fail",
+			spec:      `{"direction": "inbound"}`,
+			expErrStr: "Expected exactly one of 'wasm' and 'lua' to be specified",
+		},
+		{
+			name:      "empty Lua script fails",
+			spec:      `{"direction": "inbound", "lua": {"inlineCode": " "}}`,
+			expErrStr: "Expected 'inlineCode' to be specified for the Lua script",
+		},
+		{
+			name:      "WASM module with both ConfigMap and image fails",
+			spec:      `{"direction": "inbound", "wasm": {"configMap": {"name": "filters", "key": "filter.wasm"}, "image": "ghcr.io/org/filter@DIGEST"}}`,
+			expErrStr: "Expected exactly one of 'configMap' and 'image' to be specified for the WASM module",
+		},
+		{
+			name:      "WASM module ConfigMap without key fails",
+			spec:      `{"direction": "inbound", "wasm": {"configMap": {"name": "filters"}}}`,
+			expErrStr: "Expected 'name' and 'key' to be specified for the WASM module's ConfigMap",
+		},
+		{
+			name:      "WASM module image referenced by tag fails",
+			spec:      `{"direction": "inbound", "wasm": {"image": "ghcr.io/org/filter:v1"}}`,
+			expErrStr: `Image "ghcr.io/org/filter:v1" must be referenced by digest`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			spec := strings.ReplaceAll(tc.spec, "DIGEST", "sha256:"+strings.Repeat("ab", 32))
+			input := &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "policy.openservicemesh.io",
+					Version: "v1alpha1",
+					Kind:    "EnvoyFilterExtension",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(fmt.Sprintf(`{"apiVersion": "policy.openservicemesh.io/v1alpha1", "kind": "EnvoyFilterExtension", "spec": %s}`, spec)),
+				},
+			}
+
+			resp, err := envoyFilterExtensionValidator(input)
+			assert.Nil(resp)
+			if tc.expErrStr == "" {
+				assert.Nil(err)
+			} else {
+				assert.EqualError(err, tc.expErrStr)
+			}
+		})
+	}
+}
+
 func TestMulticlusterServiceValidator(t *testing.T) {
 	assert := tassert.New(t)
 	testCases := []struct {
@@ -482,6 +773,84 @@ func TestMulticlusterServiceValidator(t *testing.T) {
 			expResp:   nil,
 			expErrStr: "Error parsing port value 0.0.0.0:a",
 		},
+		{
+			name: "MultiClusterService with a hostname is accepted",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "config.openservicemesh.io",
+					Kind:    "MultiClusterService",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "MultiClusterService",
+						"spec": {
+							"clusters": [{
+								"name": "test",
+								"address": "gateway.east.example.com:15443"
+							}]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "MultiClusterService with an invalid hostname fails",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "config.openservicemesh.io",
+					Kind:    "MultiClusterService",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "MultiClusterService",
+						"spec": {
+							"clusters": [{
+								"name": "test",
+								"address": "Gateway_East:15443"
+							}]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Error parsing hostname Gateway_East:15443: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+		},
+		{
+			name: "MultiClusterService without a port fails",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "config.openservicemesh.io",
+					Kind:    "MultiClusterService",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "MultiClusterService",
+						"spec": {
+							"clusters": [{
+								"name": "test",
+								"address": "gateway.east.example.com"
+							}]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Error parsing cluster address gateway.east.example.com, expected <host>:<port>",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -129,7 +129,7 @@ func TestRDSRespose(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -142,7 +142,7 @@ func TestRDSRespose(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
@@ -172,7 +172,7 @@ func TestRDSRespose(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -185,7 +185,7 @@ func TestRDSRespose(t *testing.T) {
 							AllowedServiceIdentities: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}.ToServiceIdentity()),
+							}.ToServiceIdentity(identity.ClusterLocalTrustDomain)),
 						},
 					},
 				},
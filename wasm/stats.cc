@@ -123,3 +123,159 @@ FilterHeadersStatus StatsContext::onResponseHeaders(uint32_t headers, bool end_o
 
   return FilterHeadersStatus::Continue;
 }
+
+// TCP stats are recorded by a network filter configured on the outbound TCP filter chains. All the chains of a
+// proxy share the same plugin configuration, providing the source labels as newline separated 'label=value' pairs,
+// because TCP traffic carries no headers to exchange them in-band. The destination labels are derived from the name
+// of the upstream cluster, '<namespace>/<service>', once the TCP proxy filter following this filter has selected it.
+// Inbound TCP connections are not recorded, the peer workload of a TCP connection is not known to the proxy.
+static const std::string kTcpStatsRootId = "osm_tcp_stats";
+
+using TcpCounter = Counter<std::string, std::string, std::string, std::string, std::string, std::string>;
+
+class TcpStatsRootContext : public RootContext
+{
+public:
+  explicit TcpStatsRootContext(uint32_t id, std::string_view root_id) : RootContext(id, root_id) {}
+
+  bool onConfigure(size_t configuration_size) override;
+
+  std::string source_namespace, source_kind, source_name, source_pod;
+};
+
+class TcpStatsContext : public Context
+{
+public:
+  explicit TcpStatsContext(uint32_t id, RootContext *root) : Context(id, root),
+                                                             root_(static_cast<TcpStatsRootContext *>(root)),
+                                                             cx_opened(newCounter("osm_tcp_connections_opened_total")),
+                                                             cx_closed(newCounter("osm_tcp_connections_closed_total")),
+                                                             bytes_sent(newCounter("osm_tcp_sent_bytes_total")),
+                                                             bytes_received(newCounter("osm_tcp_received_bytes_total"))
+  {
+  }
+
+  FilterStatus onDownstreamData(size_t data_length, bool end_of_stream) override;
+  FilterStatus onUpstreamData(size_t data_length, bool end_of_stream) override;
+  void onDownstreamConnectionClose(CloseType close_type) override;
+
+private:
+  static TcpCounter *newCounter(std::string_view name)
+  {
+    return TcpCounter::New(name,
+                           "source_namespace",
+                           "source_kind",
+                           "source_name",
+                           "source_pod",
+                           "destination_namespace",
+                           "destination_name");
+  }
+
+  void increment(TcpCounter *counter, int64_t offset)
+  {
+    counter->increment(offset,
+                       root_->source_namespace, root_->source_kind, root_->source_name, root_->source_pod,
+                       destination_namespace, destination_name);
+  }
+
+  // opened resolves the destination labels and records the connection as opened, on the first event of the
+  // connection, as the upstream cluster is not yet selected when the connection is created
+  void opened();
+
+  TcpStatsRootContext *root_;
+  TcpCounter *cx_opened;
+  TcpCounter *cx_closed;
+  TcpCounter *bytes_sent;
+  TcpCounter *bytes_received;
+  bool is_opened = false;
+  std::string destination_namespace = "unknown", destination_name = "unknown";
+};
+static RegisterContextFactory register_TcpStatsContext(CONTEXT_FACTORY(TcpStatsContext), ROOT_FACTORY(TcpStatsRootContext), kTcpStatsRootId);
+
+bool TcpStatsRootContext::onConfigure(size_t configuration_size)
+{
+  std::unordered_map<std::string, std::string *> labels = {
+      {"source_namespace", &source_namespace},
+      {"source_kind", &source_kind},
+      {"source_name", &source_name},
+      {"source_pod", &source_pod},
+  };
+  for (auto &label : labels)
+  {
+    *label.second = "unknown";
+  }
+
+  auto configuration = getBufferBytes(WasmBufferType::PluginConfiguration, 0, configuration_size)->toString();
+  size_t start = 0;
+  while (start < configuration.size())
+  {
+    size_t end = configuration.find('\n', start);
+    if (end == std::string::npos)
+    {
+      end = configuration.size();
+    }
+    std::string line = configuration.substr(start, end - start);
+    start = end + 1;
+
+    size_t separator = line.find('=');
+    if (separator == std::string::npos)
+    {
+      continue;
+    }
+    auto label = labels.find(line.substr(0, separator));
+    if (label != labels.end() && separator + 1 < line.size())
+    {
+      *label->second = line.substr(separator + 1);
+    }
+  }
+
+  return true;
+}
+
+void TcpStatsContext::opened()
+{
+  if (is_opened)
+  {
+    return;
+  }
+  is_opened = true;
+
+  std::string cluster_name;
+  if (getValue({"cluster_name"}, &cluster_name))
+  {
+    size_t separator = cluster_name.find('/');
+    if (separator != std::string::npos && separator > 0 && separator + 1 < cluster_name.size())
+    {
+      destination_namespace = cluster_name.substr(0, separator);
+      destination_name = cluster_name.substr(separator + 1);
+    }
+  }
+
+  increment(cx_opened, 1);
+}
+
+FilterStatus TcpStatsContext::onDownstreamData(size_t data_length, bool end_of_stream)
+{
+  opened();
+  if (data_length > 0)
+  {
+    increment(bytes_sent, data_length);
+  }
+  return FilterStatus::Continue;
+}
+
+FilterStatus TcpStatsContext::onUpstreamData(size_t data_length, bool end_of_stream)
+{
+  opened();
+  if (data_length > 0)
+  {
+    increment(bytes_received, data_length);
+  }
+  return FilterStatus::Continue;
+}
+
+void TcpStatsContext::onDownstreamConnectionClose(CloseType close_type)
+{
+  opened();
+  increment(cx_closed, 1);
+}